@@ -53,30 +53,45 @@ func (RXWindow) EnumDescriptor() ([]byte, []int) {
 type ErrorType int32
 
 const (
-	ErrorType_GENERIC                ErrorType = 0
-	ErrorType_OTAA                   ErrorType = 1
-	ErrorType_DATA_UP_FCNT           ErrorType = 2
-	ErrorType_DATA_UP_MIC            ErrorType = 3
-	ErrorType_DEVICE_QUEUE_ITEM_SIZE ErrorType = 4
-	ErrorType_DEVICE_QUEUE_ITEM_FCNT ErrorType = 5
+	ErrorType_GENERIC                     ErrorType = 0
+	ErrorType_OTAA                        ErrorType = 1
+	ErrorType_DATA_UP_FCNT                ErrorType = 2
+	ErrorType_DATA_UP_MIC                 ErrorType = 3
+	ErrorType_DEVICE_QUEUE_ITEM_SIZE      ErrorType = 4
+	ErrorType_DEVICE_QUEUE_ITEM_FCNT      ErrorType = 5
+	ErrorType_DATA_UP_FCNT_RESET          ErrorType = 6
+	ErrorType_DATA_UP_FCNT_RETRANSMISSION ErrorType = 7
+	ErrorType_DATA_UP_SIZE                ErrorType = 8
+	ErrorType_DATA_UP_FCNT_GAP_TOO_LARGE  ErrorType = 9
+	ErrorType_LINK_ADR_REQ_REJECTED       ErrorType = 10
 )
 
 var ErrorType_name = map[int32]string{
-	0: "GENERIC",
-	1: "OTAA",
-	2: "DATA_UP_FCNT",
-	3: "DATA_UP_MIC",
-	4: "DEVICE_QUEUE_ITEM_SIZE",
-	5: "DEVICE_QUEUE_ITEM_FCNT",
+	0:  "GENERIC",
+	1:  "OTAA",
+	2:  "DATA_UP_FCNT",
+	3:  "DATA_UP_MIC",
+	4:  "DEVICE_QUEUE_ITEM_SIZE",
+	5:  "DEVICE_QUEUE_ITEM_FCNT",
+	6:  "DATA_UP_FCNT_RESET",
+	7:  "DATA_UP_FCNT_RETRANSMISSION",
+	8:  "DATA_UP_SIZE",
+	9:  "DATA_UP_FCNT_GAP_TOO_LARGE",
+	10: "LINK_ADR_REQ_REJECTED",
 }
 
 var ErrorType_value = map[string]int32{
-	"GENERIC":                0,
-	"OTAA":                   1,
-	"DATA_UP_FCNT":           2,
-	"DATA_UP_MIC":            3,
-	"DEVICE_QUEUE_ITEM_SIZE": 4,
-	"DEVICE_QUEUE_ITEM_FCNT": 5,
+	"GENERIC":                     0,
+	"OTAA":                        1,
+	"DATA_UP_FCNT":                2,
+	"DATA_UP_MIC":                 3,
+	"DEVICE_QUEUE_ITEM_SIZE":      4,
+	"DEVICE_QUEUE_ITEM_FCNT":      5,
+	"DATA_UP_FCNT_RESET":          6,
+	"DATA_UP_FCNT_RETRANSMISSION": 7,
+	"DATA_UP_SIZE":                8,
+	"DATA_UP_FCNT_GAP_TOO_LARGE":  9,
+	"LINK_ADR_REQ_REJECTED":       10,
 }
 
 func (x ErrorType) String() string {
@@ -87,6 +102,43 @@ func (ErrorType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_426943aecdb4a493, []int{1}
 }
 
+type DownlinkStatus int32
+
+const (
+	// The device-queue item was sent to the gateway for transmission.
+	DownlinkStatus_TRANSMITTED DownlinkStatus = 0
+	// The (confirmed) device-queue item was acknowledged by the device.
+	DownlinkStatus_ACKNOWLEDGED DownlinkStatus = 1
+	// The device-queue item was discarded as it exceeds the max payload
+	// size for the data-rate.
+	DownlinkStatus_DISCARDED_SIZE DownlinkStatus = 2
+	// The device-queue item was discarded as it was not transmitted within
+	// its TTL.
+	DownlinkStatus_EXPIRED DownlinkStatus = 3
+)
+
+var DownlinkStatus_name = map[int32]string{
+	0: "TRANSMITTED",
+	1: "ACKNOWLEDGED",
+	2: "DISCARDED_SIZE",
+	3: "EXPIRED",
+}
+
+var DownlinkStatus_value = map[string]int32{
+	"TRANSMITTED":    0,
+	"ACKNOWLEDGED":   1,
+	"DISCARDED_SIZE": 2,
+	"EXPIRED":        3,
+}
+
+func (x DownlinkStatus) String() string {
+	return proto.EnumName(DownlinkStatus_name, int32(x))
+}
+
+func (DownlinkStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{2}
+}
+
 type DeviceActivationContext struct {
 	// Assigned Device Address.
 	DevAddr []byte `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
@@ -160,9 +212,12 @@ type HandleUplinkDataRequest struct {
 	// This field is only set on the first uplink frame when the security
 	// context has changed (e.g. a new OTAA (re)activation).
 	DeviceActivationContext *DeviceActivationContext `protobuf:"bytes,10,opt,name=device_activation_context,json=deviceActivationContext,proto3" json:"device_activation_context,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}                 `json:"-"`
-	XXX_unrecognized        []byte                   `json:"-"`
-	XXX_sizecache           int32                    `json:"-"`
+	// The uplink airtime budget of the service-profile has been exceeded
+	// by the device (within the rolling 24 hours window).
+	AirtimeBudgetExceeded bool     `protobuf:"varint,11,opt,name=airtime_budget_exceeded,json=airtimeBudgetExceeded,proto3" json:"airtime_budget_exceeded,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *HandleUplinkDataRequest) Reset()         { *m = HandleUplinkDataRequest{} }
@@ -260,6 +315,13 @@ func (m *HandleUplinkDataRequest) GetDeviceActivationContext() *DeviceActivation
 	return nil
 }
 
+func (m *HandleUplinkDataRequest) GetAirtimeBudgetExceeded() bool {
+	if m != nil {
+		return m.AirtimeBudgetExceeded
+	}
+	return false
+}
+
 type HandleProprietaryUplinkRequest struct {
 	// MACPayload of the proprietary LoRaWAN frame.
 	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
@@ -335,10 +397,14 @@ type HandleErrorRequest struct {
 	// Error string describing the error.
 	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 	// Frame-counter (if applicable) related to the error.
-	FCnt                 uint32   `protobuf:"varint,5,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	FCnt uint32 `protobuf:"varint,5,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Expected frame-counter (if applicable) related to the error.
+	ExpectedFCnt uint32 `protobuf:"varint,6,opt,name=expected_f_cnt,json=expectedFCnt,proto3" json:"expected_f_cnt,omitempty"`
+	// RX-info of the uplink (if applicable) related to the error.
+	RxInfo               []*gw.UplinkRXInfo `protobuf:"bytes,7,rep,name=rx_info,json=rxInfo,proto3" json:"rx_info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *HandleErrorRequest) Reset()         { *m = HandleErrorRequest{} }
@@ -394,6 +460,20 @@ func (m *HandleErrorRequest) GetFCnt() uint32 {
 	return 0
 }
 
+func (m *HandleErrorRequest) GetExpectedFCnt() uint32 {
+	if m != nil {
+		return m.ExpectedFCnt
+	}
+	return 0
+}
+
+func (m *HandleErrorRequest) GetRxInfo() []*gw.UplinkRXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
 type HandleDownlinkACKRequest struct {
 	// Device EUI (8 bytes).
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -452,6 +532,140 @@ func (m *HandleDownlinkACKRequest) GetAcknowledged() bool {
 	return false
 }
 
+type HandleDownlinkStatusRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Downlink frame-counter.
+	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Reference of the device-queue item, as set on enqueue.
+	Reference string `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Status of the device-queue item.
+	Status               DownlinkStatus `protobuf:"varint,4,opt,name=status,proto3,enum=as.DownlinkStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HandleDownlinkStatusRequest) Reset()         { *m = HandleDownlinkStatusRequest{} }
+func (m *HandleDownlinkStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleDownlinkStatusRequest) ProtoMessage()    {}
+func (*HandleDownlinkStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{5}
+}
+
+func (m *HandleDownlinkStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleDownlinkStatusRequest.Unmarshal(m, b)
+}
+func (m *HandleDownlinkStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleDownlinkStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleDownlinkStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleDownlinkStatusRequest.Merge(m, src)
+}
+func (m *HandleDownlinkStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleDownlinkStatusRequest.Size(m)
+}
+func (m *HandleDownlinkStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleDownlinkStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleDownlinkStatusRequest proto.InternalMessageInfo
+
+func (m *HandleDownlinkStatusRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *HandleDownlinkStatusRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *HandleDownlinkStatusRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *HandleDownlinkStatusRequest) GetStatus() DownlinkStatus {
+	if m != nil {
+		return m.Status
+	}
+	return DownlinkStatus_TRANSMITTED
+}
+
+type HandleMulticastDownlinkStatusRequest struct {
+	// Multicast-group ID (UUID).
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Downlink frame-counter.
+	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Gateway ID (8 bytes) of the gateway used for the transmission.
+	GatewayId []byte `protobuf:"bytes,3,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Status of the multicast queue-item.
+	Status               DownlinkStatus `protobuf:"varint,4,opt,name=status,proto3,enum=as.DownlinkStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HandleMulticastDownlinkStatusRequest) Reset()         { *m = HandleMulticastDownlinkStatusRequest{} }
+func (m *HandleMulticastDownlinkStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleMulticastDownlinkStatusRequest) ProtoMessage()    {}
+func (*HandleMulticastDownlinkStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{6}
+}
+
+func (m *HandleMulticastDownlinkStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleMulticastDownlinkStatusRequest.Unmarshal(m, b)
+}
+func (m *HandleMulticastDownlinkStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleMulticastDownlinkStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleMulticastDownlinkStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleMulticastDownlinkStatusRequest.Merge(m, src)
+}
+func (m *HandleMulticastDownlinkStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleMulticastDownlinkStatusRequest.Size(m)
+}
+func (m *HandleMulticastDownlinkStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleMulticastDownlinkStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleMulticastDownlinkStatusRequest proto.InternalMessageInfo
+
+func (m *HandleMulticastDownlinkStatusRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *HandleMulticastDownlinkStatusRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *HandleMulticastDownlinkStatusRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *HandleMulticastDownlinkStatusRequest) GetStatus() DownlinkStatus {
+	if m != nil {
+		return m.Status
+	}
+	return DownlinkStatus_TRANSMITTED
+}
+
 type SetDeviceStatusRequest struct {
 	// Device EUI (8 bytes).
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -478,7 +692,7 @@ func (m *SetDeviceStatusRequest) Reset()         { *m = SetDeviceStatusRequest{}
 func (m *SetDeviceStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceStatusRequest) ProtoMessage()    {}
 func (*SetDeviceStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{5}
+	return fileDescriptor_426943aecdb4a493, []int{7}
 }
 
 func (m *SetDeviceStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -555,7 +769,7 @@ func (m *SetDeviceLocationRequest) Reset()         { *m = SetDeviceLocationReque
 func (m *SetDeviceLocationRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceLocationRequest) ProtoMessage()    {}
 func (*SetDeviceLocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{6}
+	return fileDescriptor_426943aecdb4a493, []int{8}
 }
 
 func (m *SetDeviceLocationRequest) XXX_Unmarshal(b []byte) error {
@@ -593,11 +807,14 @@ func (m *SetDeviceLocationRequest) GetLocation() *common.Location {
 func init() {
 	proto.RegisterEnum("as.RXWindow", RXWindow_name, RXWindow_value)
 	proto.RegisterEnum("as.ErrorType", ErrorType_name, ErrorType_value)
+	proto.RegisterEnum("as.DownlinkStatus", DownlinkStatus_name, DownlinkStatus_value)
 	proto.RegisterType((*DeviceActivationContext)(nil), "as.DeviceActivationContext")
 	proto.RegisterType((*HandleUplinkDataRequest)(nil), "as.HandleUplinkDataRequest")
 	proto.RegisterType((*HandleProprietaryUplinkRequest)(nil), "as.HandleProprietaryUplinkRequest")
 	proto.RegisterType((*HandleErrorRequest)(nil), "as.HandleErrorRequest")
 	proto.RegisterType((*HandleDownlinkACKRequest)(nil), "as.HandleDownlinkACKRequest")
+	proto.RegisterType((*HandleDownlinkStatusRequest)(nil), "as.HandleDownlinkStatusRequest")
+	proto.RegisterType((*HandleMulticastDownlinkStatusRequest)(nil), "as.HandleMulticastDownlinkStatusRequest")
 	proto.RegisterType((*SetDeviceStatusRequest)(nil), "as.SetDeviceStatusRequest")
 	proto.RegisterType((*SetDeviceLocationRequest)(nil), "as.SetDeviceLocationRequest")
 }
@@ -605,62 +822,82 @@ func init() {
 func init() { proto.RegisterFile("as.proto", fileDescriptor_426943aecdb4a493) }
 
 var fileDescriptor_426943aecdb4a493 = []byte{
-	// 880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x73, 0xe2, 0x46,
-	0x10, 0x35, 0xdf, 0xb8, 0xb1, 0xd7, 0xca, 0x38, 0x0b, 0x32, 0xbb, 0x95, 0x10, 0xe5, 0xe2, 0x6c,
-	0xa5, 0xa0, 0x42, 0x6e, 0xb9, 0xa4, 0x28, 0x50, 0x36, 0x94, 0x77, 0x37, 0x44, 0x40, 0xec, 0xca,
-	0x65, 0x6a, 0x2c, 0x35, 0x94, 0x62, 0xa1, 0x51, 0x86, 0x01, 0xac, 0x4a, 0xe5, 0x07, 0xe5, 0x4f,
-	0xe5, 0x90, 0x5f, 0x91, 0x63, 0x6a, 0x46, 0xe2, 0xc3, 0x6b, 0x83, 0x73, 0x81, 0x99, 0x7e, 0xad,
-	0xd7, 0x4f, 0x4f, 0xdd, 0x0d, 0x65, 0x36, 0x6f, 0x46, 0x82, 0x4b, 0x4e, 0xb2, 0x6c, 0x5e, 0x7f,
-	0x35, 0xe5, 0x7c, 0x1a, 0x60, 0x4b, 0x47, 0x6e, 0x17, 0x93, 0x16, 0xce, 0x22, 0x19, 0x27, 0x09,
-	0xf5, 0x1a, 0x8b, 0xfc, 0x96, 0xcb, 0x67, 0x33, 0x1e, 0xa6, 0x7f, 0x29, 0x70, 0xa6, 0x80, 0xe9,
-	0xaa, 0x35, 0x5d, 0x25, 0x01, 0x0b, 0xa1, 0xd6, 0xc3, 0xa5, 0xef, 0x62, 0xc7, 0x95, 0xfe, 0x92,
-	0x49, 0x9f, 0x87, 0x5d, 0x1e, 0x4a, 0xbc, 0x97, 0xe4, 0x02, 0xca, 0x1e, 0x2e, 0x29, 0xf3, 0x3c,
-	0x61, 0x66, 0x1a, 0x99, 0xcb, 0x13, 0xa7, 0xe4, 0xe1, 0xb2, 0xe3, 0x79, 0x82, 0xb4, 0xe0, 0x98,
-	0x45, 0x11, 0x9d, 0xd3, 0x3b, 0x8c, 0xcd, 0x6c, 0x23, 0x73, 0x59, 0x69, 0x9f, 0x37, 0xd3, 0x42,
-	0x57, 0x18, 0xdb, 0xe1, 0x12, 0x03, 0x1e, 0xa1, 0x53, 0x62, 0x51, 0x34, 0xbc, 0xc2, 0xd8, 0xfa,
-	0x3b, 0x0b, 0xb5, 0x1f, 0x59, 0xe8, 0x05, 0x38, 0x8e, 0x02, 0x3f, 0xbc, 0xeb, 0x31, 0xc9, 0x1c,
-	0xfc, 0x7d, 0x81, 0x73, 0x49, 0x6a, 0xa0, 0x78, 0x29, 0x2e, 0xfc, 0xb4, 0x4c, 0xd1, 0xc3, 0xa5,
-	0xbd, 0xf0, 0x95, 0x80, 0xdf, 0xb8, 0x1f, 0x6a, 0x24, 0x9b, 0x08, 0x50, 0x77, 0x05, 0x9d, 0x43,
-	0x61, 0x42, 0xdd, 0x50, 0x9a, 0xb9, 0x46, 0xe6, 0xf2, 0xd4, 0xc9, 0x4f, 0xba, 0xa1, 0x24, 0x2f,
-	0xa1, 0x38, 0xa1, 0x11, 0x17, 0xd2, 0xcc, 0xeb, 0x68, 0x61, 0x32, 0xe0, 0x42, 0x12, 0x03, 0x72,
-	0xcc, 0x13, 0x66, 0xa1, 0x91, 0xb9, 0x2c, 0x3b, 0xea, 0x48, 0x5e, 0x40, 0xd6, 0x13, 0x66, 0x51,
-	0x27, 0x65, 0x3d, 0x41, 0xbe, 0x82, 0x92, 0xbc, 0xa7, 0x7e, 0x38, 0xe1, 0x66, 0x49, 0xbf, 0x8c,
-	0xd1, 0x9c, 0xae, 0x9a, 0x89, 0xd2, 0xd1, 0x4d, 0x3f, 0x9c, 0x70, 0xa7, 0x28, 0xef, 0xd5, 0xbf,
-	0x4a, 0x15, 0x69, 0x6a, 0xb9, 0x91, 0x7b, 0x98, 0xea, 0xa4, 0xa9, 0x22, 0x49, 0x25, 0x90, 0xf7,
-	0x98, 0x64, 0xe6, 0xb1, 0x96, 0xae, 0xcf, 0xe4, 0x1a, 0x2e, 0x3c, 0x6d, 0x37, 0x65, 0x1b, 0xbf,
-	0xa9, 0x9b, 0x18, 0x6e, 0x82, 0xae, 0xfd, 0xaa, 0xc9, 0xe6, 0xcd, 0x3d, 0xdf, 0xc4, 0xa9, 0x79,
-	0x4f, 0x03, 0xd6, 0x5f, 0x19, 0xf8, 0x2c, 0x31, 0x78, 0x20, 0x78, 0x24, 0x7c, 0x94, 0x4c, 0xc4,
-	0xa9, 0xac, 0xd4, 0xe7, 0xcf, 0xa1, 0x32, 0x63, 0x2e, 0x8d, 0x58, 0x1c, 0x70, 0xe6, 0xa5, 0x5e,
-	0xc3, 0x8c, 0xb9, 0x83, 0x24, 0xa2, 0x8c, 0x9a, 0xf9, 0x6e, 0x6a, 0xb5, 0x3a, 0xee, 0x1a, 0x93,
-	0xfb, 0xff, 0xc6, 0xe4, 0x0f, 0x1b, 0x63, 0xfd, 0x01, 0x24, 0x91, 0x6a, 0x0b, 0xc1, 0xc5, 0xb3,
-	0x6d, 0xf0, 0x05, 0xe4, 0x65, 0x1c, 0xa1, 0x56, 0xf0, 0xa2, 0x7d, 0xaa, 0xec, 0xd1, 0x0f, 0x8e,
-	0xe2, 0x08, 0x1d, 0x0d, 0x91, 0x4f, 0xa1, 0x80, 0x2a, 0xa4, 0x3f, 0xfc, 0xb1, 0x93, 0x5c, 0xb6,
-	0x4d, 0x52, 0xd8, 0x36, 0x89, 0x15, 0x80, 0x99, 0x14, 0xef, 0xf1, 0x55, 0xa8, 0xc4, 0x75, 0xba,
-	0x57, 0xcf, 0x4a, 0xd8, 0x30, 0x65, 0x77, 0xda, 0xcd, 0x82, 0x13, 0xe6, 0xde, 0x85, 0x7c, 0x15,
-	0xa0, 0x37, 0x45, 0x4f, 0xeb, 0x2b, 0x3b, 0x0f, 0x62, 0xd6, 0xbf, 0x19, 0xa8, 0x0e, 0x51, 0x26,
-	0x9f, 0x73, 0x28, 0x99, 0x5c, 0xcc, 0x9f, 0x2d, 0x66, 0x42, 0xe9, 0x96, 0x49, 0x89, 0x22, 0x4e,
-	0xcb, 0xad, 0xaf, 0xa4, 0x0a, 0xc5, 0x19, 0x13, 0x53, 0x3f, 0xd4, 0xb5, 0x0a, 0x4e, 0x7a, 0x23,
-	0x6d, 0x78, 0x89, 0xf7, 0x12, 0x45, 0xc8, 0x02, 0x1a, 0xf1, 0x15, 0x0a, 0x3a, 0xe7, 0x0b, 0xe1,
-	0xa2, 0xb6, 0xa3, 0xec, 0x9c, 0xaf, 0xc1, 0x81, 0xc2, 0x86, 0x1a, 0x22, 0xdf, 0xc1, 0x45, 0x4a,
-	0x4b, 0x03, 0x5c, 0x62, 0x40, 0x17, 0x21, 0x5b, 0x32, 0x3f, 0x60, 0xb7, 0x01, 0xa6, 0xb3, 0x52,
-	0x4b, 0x13, 0xde, 0x29, 0x7c, 0xbc, 0x85, 0xc9, 0x97, 0x70, 0xfa, 0xe0, 0x59, 0x3d, 0x4a, 0x59,
-	0xe7, 0x64, 0x37, 0xdf, 0x62, 0x60, 0x6e, 0xde, 0xfc, 0x1d, 0x77, 0x75, 0xb7, 0x3e, 0xfb, 0xee,
-	0x5f, 0x43, 0x39, 0x48, 0x73, 0xd3, 0xbd, 0x62, 0xac, 0xf7, 0xca, 0x86, 0x63, 0x93, 0xf1, 0xe6,
-	0x35, 0x94, 0x9d, 0x9b, 0x6b, 0x3f, 0xf4, 0xf8, 0x8a, 0x94, 0x20, 0xe7, 0xdc, 0x7c, 0x63, 0x1c,
-	0x25, 0x87, 0xb6, 0x91, 0x79, 0xf3, 0x27, 0x1c, 0x6f, 0xfa, 0x84, 0x54, 0xa0, 0xf4, 0xd6, 0xfe,
-	0x60, 0x3b, 0xfd, 0xae, 0x71, 0x44, 0xca, 0x90, 0xff, 0x69, 0xd4, 0xe9, 0x18, 0x19, 0x62, 0xc0,
-	0x49, 0xaf, 0x33, 0xea, 0xd0, 0xf1, 0x80, 0xfe, 0xd0, 0xfd, 0x30, 0x32, 0xb2, 0xe4, 0x0c, 0x2a,
-	0xeb, 0xc8, 0xfb, 0x7e, 0xd7, 0xc8, 0x91, 0x3a, 0x54, 0x7b, 0xf6, 0x2f, 0xfd, 0xae, 0x4d, 0x7f,
-	0x1e, 0xdb, 0x63, 0x9b, 0xf6, 0x47, 0xf6, 0x7b, 0x3a, 0xec, 0xff, 0x6a, 0x1b, 0xf9, 0xa7, 0x31,
-	0x4d, 0x54, 0x68, 0xff, 0x93, 0x03, 0xb3, 0x13, 0x45, 0x81, 0x9f, 0x88, 0x1d, 0xa2, 0x58, 0xa2,
-	0x50, 0xbf, 0xbe, 0x8b, 0xa4, 0x0f, 0xc6, 0xc7, 0xeb, 0x90, 0xe8, 0xc1, 0xdf, 0xb3, 0x24, 0xeb,
-	0xd5, 0x66, 0xb2, 0xef, 0x9b, 0xeb, 0x7d, 0xdf, 0xb4, 0xd5, 0xbe, 0xb7, 0x8e, 0xc8, 0xf5, 0x7a,
-	0xb3, 0x3e, 0x1a, 0x7c, 0x62, 0x6d, 0x19, 0xf7, 0x6d, 0x85, 0x03, 0xc4, 0xdf, 0x43, 0x65, 0x67,
-	0x4c, 0x49, 0x75, 0x4b, 0xb6, 0x3b, 0xb7, 0x07, 0x08, 0xae, 0xe0, 0x93, 0x47, 0xa3, 0x46, 0x5e,
-	0x6f, 0x69, 0x1e, 0x4f, 0xe0, 0x01, 0xb2, 0xb7, 0x70, 0xf6, 0xd1, 0x20, 0x91, 0xba, 0xa2, 0x7a,
-	0x7a, 0xba, 0x0e, 0xab, 0x7a, 0xd4, 0x97, 0x89, 0xaa, 0x7d, 0xed, 0xba, 0x9f, 0xec, 0xb6, 0xa8,
-	0x23, 0xdf, 0xfe, 0x17, 0x00, 0x00, 0xff, 0xff, 0x3c, 0x1e, 0x41, 0x86, 0x9c, 0x07, 0x00, 0x00,
+	// 1192 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4d, 0x53, 0xe3, 0x46,
+	0x13, 0xc6, 0xdf, 0x76, 0xc3, 0xb2, 0x7a, 0x67, 0x17, 0x2c, 0x60, 0x3f, 0x78, 0x9d, 0x3d, 0x10,
+	0x6a, 0xcb, 0x54, 0x48, 0x55, 0x0e, 0xb9, 0xa4, 0x14, 0x6b, 0x96, 0x28, 0x7c, 0x79, 0xc7, 0x26,
+	0x50, 0xb9, 0x4c, 0x0d, 0xd2, 0xd8, 0xa5, 0xac, 0x2c, 0x29, 0xe3, 0xb1, 0x8d, 0xcf, 0xf9, 0x0d,
+	0xc9, 0x21, 0xff, 0x20, 0xf7, 0xfc, 0x8c, 0xfc, 0xa0, 0x1c, 0x53, 0x33, 0x92, 0xbf, 0x16, 0x6c,
+	0x36, 0xb9, 0x80, 0xd4, 0x4f, 0xcf, 0xd3, 0x4f, 0xf7, 0xb4, 0xbb, 0x05, 0x65, 0xd6, 0xaf, 0xc7,
+	0x22, 0x92, 0x11, 0xca, 0xb2, 0xfe, 0xee, 0x5e, 0x37, 0x8a, 0xba, 0x01, 0x3f, 0xd2, 0x96, 0xdb,
+	0x41, 0xe7, 0x88, 0xf7, 0x62, 0x39, 0x4e, 0x1c, 0x76, 0xab, 0x2c, 0xf6, 0x8f, 0xdc, 0xa8, 0xd7,
+	0x8b, 0xc2, 0xf4, 0x5f, 0x0a, 0x3c, 0x55, 0x40, 0x77, 0x74, 0xd4, 0x1d, 0x25, 0x86, 0x1a, 0x87,
+	0xaa, 0xcd, 0x87, 0xbe, 0xcb, 0x2d, 0x57, 0xfa, 0x43, 0x26, 0xfd, 0x28, 0x6c, 0x44, 0xa1, 0xe4,
+	0x77, 0x12, 0xed, 0x40, 0xd9, 0xe3, 0x43, 0xca, 0x3c, 0x4f, 0x98, 0x99, 0xfd, 0xcc, 0xc1, 0x06,
+	0x29, 0x79, 0x7c, 0x68, 0x79, 0x9e, 0x40, 0x47, 0x50, 0x61, 0x71, 0x4c, 0xfb, 0xf4, 0x03, 0x1f,
+	0x9b, 0xd9, 0xfd, 0xcc, 0xc1, 0xfa, 0xf1, 0xb3, 0x7a, 0x1a, 0xe8, 0x94, 0x8f, 0x71, 0x38, 0xe4,
+	0x41, 0x14, 0x73, 0x52, 0x62, 0x71, 0xdc, 0x3a, 0xe5, 0xe3, 0xda, 0x6f, 0x39, 0xa8, 0x7e, 0xc7,
+	0x42, 0x2f, 0xe0, 0x57, 0x71, 0xe0, 0x87, 0x1f, 0x6c, 0x26, 0x19, 0xe1, 0x3f, 0x0f, 0x78, 0x5f,
+	0xa2, 0x2a, 0x28, 0x5e, 0xca, 0x07, 0x7e, 0x1a, 0xa6, 0xe8, 0xf1, 0x21, 0x1e, 0xf8, 0x4a, 0xc0,
+	0x4f, 0x91, 0x1f, 0x6a, 0x24, 0x9b, 0x08, 0x50, 0xef, 0x0a, 0x7a, 0x06, 0x85, 0x0e, 0x75, 0x43,
+	0x69, 0xe6, 0xf6, 0x33, 0x07, 0x4f, 0x48, 0xbe, 0xd3, 0x08, 0x25, 0xda, 0x82, 0x62, 0x87, 0xc6,
+	0x91, 0x90, 0x66, 0x5e, 0x5b, 0x0b, 0x9d, 0x66, 0x24, 0x24, 0x32, 0x20, 0xc7, 0x3c, 0x61, 0x16,
+	0xf6, 0x33, 0x07, 0x65, 0xa2, 0x1e, 0xd1, 0x26, 0x64, 0x3d, 0x61, 0x16, 0xb5, 0x53, 0xd6, 0x13,
+	0xe8, 0x73, 0x28, 0xc9, 0x3b, 0xea, 0x87, 0x9d, 0xc8, 0x2c, 0xe9, 0x64, 0x8c, 0x7a, 0x77, 0x54,
+	0x4f, 0x94, 0xb6, 0x6f, 0x9c, 0xb0, 0x13, 0x91, 0xa2, 0xbc, 0x53, 0xff, 0x95, 0xab, 0x48, 0x5d,
+	0xcb, 0xfb, 0xb9, 0x45, 0x57, 0x92, 0xba, 0x8a, 0xc4, 0x15, 0x41, 0xde, 0x63, 0x92, 0x99, 0x15,
+	0x2d, 0x5d, 0x3f, 0xa3, 0x6b, 0xd8, 0xf1, 0x74, 0xb9, 0x29, 0x9b, 0xd6, 0x9b, 0xba, 0x49, 0xc1,
+	0x4d, 0xd0, 0xb1, 0xf7, 0xea, 0xac, 0x5f, 0x5f, 0x72, 0x27, 0xa4, 0xea, 0x2d, 0xb9, 0xac, 0xaf,
+	0xa0, 0xca, 0x7c, 0x21, 0xfd, 0x1e, 0xa7, 0xb7, 0x03, 0xaf, 0xcb, 0x25, 0xe5, 0x77, 0x2e, 0xe7,
+	0x1e, 0xf7, 0xcc, 0x75, 0x9d, 0xf8, 0x56, 0x0a, 0x7f, 0xab, 0x51, 0x9c, 0x82, 0xb5, 0x3f, 0x32,
+	0xf0, 0x2a, 0xb9, 0x98, 0xa6, 0x88, 0x62, 0xe1, 0x73, 0xc9, 0xc4, 0x38, 0x4d, 0x27, 0xbd, 0x9f,
+	0xd7, 0xb0, 0xde, 0x63, 0x2e, 0x8d, 0xd9, 0x38, 0x88, 0x98, 0x97, 0xde, 0x11, 0xf4, 0x98, 0xdb,
+	0x4c, 0x2c, 0xaa, 0xc0, 0x3d, 0xdf, 0x4d, 0xaf, 0x48, 0x3d, 0xce, 0x17, 0x34, 0xf7, 0xe9, 0x05,
+	0xcd, 0xaf, 0x2e, 0x68, 0xed, 0xaf, 0x0c, 0xa0, 0x44, 0x2b, 0x16, 0x22, 0x12, 0x8f, 0xf6, 0xcf,
+	0xff, 0x21, 0x2f, 0xc7, 0x31, 0xd7, 0x12, 0x36, 0x8f, 0x9f, 0xa8, 0xba, 0xea, 0x83, 0xed, 0x71,
+	0xcc, 0x89, 0x86, 0xd0, 0x73, 0x28, 0x70, 0x65, 0xd2, 0x1d, 0x53, 0x21, 0xc9, 0xcb, 0xac, 0xbb,
+	0x0a, 0x73, 0xdd, 0xf5, 0x06, 0x36, 0xf9, 0x5d, 0xcc, 0x5d, 0xc9, 0x3d, 0x9a, 0xa0, 0x49, 0x03,
+	0x6d, 0x4c, 0xac, 0xef, 0x94, 0xd7, 0x5c, 0x3a, 0xa5, 0x47, 0xd2, 0x09, 0xc0, 0x4c, 0xb2, 0xb1,
+	0xa3, 0x51, 0xa8, 0x70, 0xab, 0x71, 0xfa, 0x68, 0x4e, 0x53, 0x69, 0xd9, 0x39, 0x69, 0x35, 0xd8,
+	0x60, 0xee, 0x87, 0x30, 0x1a, 0x05, 0xdc, 0xeb, 0x72, 0x4f, 0x27, 0x5c, 0x26, 0x0b, 0xb6, 0xda,
+	0xaf, 0x19, 0xd8, 0x5b, 0x0c, 0xd7, 0x92, 0x4c, 0x0e, 0xfa, 0xff, 0x2d, 0xe2, 0x0b, 0xa8, 0x08,
+	0xde, 0xe1, 0x82, 0x87, 0x6e, 0x52, 0xdf, 0x0a, 0x99, 0x19, 0xd0, 0x21, 0x14, 0xfb, 0x9a, 0x5c,
+	0x97, 0x75, 0xf3, 0x18, 0xe9, 0x96, 0x5e, 0x0c, 0x9b, 0x7a, 0xd4, 0xfe, 0xcc, 0xc0, 0x9b, 0x44,
+	0xd7, 0xf9, 0x20, 0x90, 0xbe, 0xcb, 0xfa, 0xf2, 0x61, 0x81, 0x6f, 0x01, 0xf5, 0x26, 0x1e, 0xb4,
+	0x2b, 0xa2, 0x41, 0x4c, 0xfd, 0x49, 0x37, 0x1a, 0x53, 0xe4, 0x44, 0x01, 0x8e, 0xf7, 0xb0, 0xea,
+	0x97, 0x00, 0x5d, 0x26, 0xf9, 0x88, 0x8d, 0xd5, 0xd1, 0x9c, 0x3e, 0x5a, 0x49, 0x2d, 0x8e, 0xf7,
+	0xaf, 0x64, 0xff, 0x9d, 0x81, 0xed, 0x16, 0x97, 0xc9, 0xef, 0xf4, 0x13, 0x2b, 0x69, 0x42, 0xe9,
+	0x96, 0x49, 0xc9, 0xc5, 0x38, 0x55, 0x35, 0x79, 0x45, 0xdb, 0x50, 0xec, 0x31, 0xd1, 0xf5, 0x43,
+	0x2d, 0xaa, 0x40, 0xd2, 0x37, 0x74, 0x0c, 0x5b, 0xfc, 0x4e, 0x72, 0x11, 0xb2, 0x80, 0xc6, 0xd1,
+	0x88, 0x0b, 0xda, 0x8f, 0x06, 0xc2, 0xe5, 0x5a, 0x60, 0x99, 0x3c, 0x9b, 0x80, 0x4d, 0x85, 0xb5,
+	0x34, 0x84, 0xbe, 0x86, 0x9d, 0x94, 0x96, 0x06, 0x7c, 0xc8, 0x03, 0x3a, 0x08, 0xd9, 0x90, 0xf9,
+	0x01, 0xbb, 0x0d, 0x78, 0x3a, 0x04, 0xab, 0xa9, 0xc3, 0x99, 0xc2, 0xaf, 0x66, 0x30, 0xfa, 0x0c,
+	0x9e, 0x2c, 0x9c, 0xd5, 0x2d, 0x9e, 0x25, 0x1b, 0xf3, 0xfe, 0x35, 0x06, 0xe6, 0x34, 0xf3, 0xb3,
+	0xc8, 0xd5, 0x63, 0xe8, 0xd1, 0xdc, 0xdf, 0x42, 0x39, 0x48, 0x7d, 0xd3, 0x85, 0x61, 0x4c, 0x16,
+	0xc6, 0x94, 0x63, 0xea, 0x71, 0xf8, 0x02, 0xca, 0xe4, 0xe6, 0xda, 0x0f, 0xbd, 0x68, 0x84, 0x4a,
+	0x90, 0x23, 0x37, 0x5f, 0x18, 0x6b, 0xc9, 0xc3, 0xb1, 0x91, 0x39, 0xfc, 0x25, 0x0b, 0x95, 0xe9,
+	0x0f, 0x19, 0xad, 0x43, 0xe9, 0x04, 0x5f, 0x60, 0xe2, 0x34, 0x8c, 0x35, 0x54, 0x86, 0xfc, 0x65,
+	0xdb, 0xb2, 0x8c, 0x0c, 0x32, 0x60, 0xc3, 0xb6, 0xda, 0x16, 0xbd, 0x6a, 0xd2, 0x77, 0x8d, 0x8b,
+	0xb6, 0x91, 0x45, 0x4f, 0x61, 0x7d, 0x62, 0x39, 0x77, 0x1a, 0x46, 0x0e, 0xed, 0xc2, 0xb6, 0x8d,
+	0x7f, 0x70, 0x1a, 0x98, 0xbe, 0xbf, 0xc2, 0x57, 0x98, 0x3a, 0x6d, 0x7c, 0x4e, 0x5b, 0xce, 0x8f,
+	0xd8, 0xc8, 0x3f, 0x8c, 0x69, 0xa2, 0x02, 0xda, 0x06, 0x34, 0x4f, 0x4d, 0x09, 0x6e, 0xe1, 0xb6,
+	0x51, 0x44, 0xaf, 0x61, 0xef, 0x23, 0x7b, 0x9b, 0x58, 0x17, 0xad, 0x73, 0xa7, 0xd5, 0x72, 0x2e,
+	0x2f, 0x8c, 0xd2, 0xbc, 0x26, 0x1d, 0xa6, 0x8c, 0x5e, 0xc1, 0xee, 0xc2, 0x91, 0x13, 0xab, 0x49,
+	0xdb, 0x97, 0x97, 0xf4, 0xcc, 0x22, 0x27, 0xd8, 0xa8, 0xa0, 0x1d, 0xd8, 0x3a, 0x73, 0x2e, 0x4e,
+	0xa9, 0x65, 0x13, 0x4a, 0xf0, 0x7b, 0x4a, 0xf0, 0xf7, 0xb8, 0xd1, 0xc6, 0xb6, 0x01, 0x87, 0x6d,
+	0xd8, 0x5c, 0xec, 0x4d, 0x95, 0x60, 0x1a, 0xb0, 0xad, 0x5c, 0xd6, 0x54, 0x3c, 0xab, 0x71, 0x7a,
+	0x71, 0x79, 0x7d, 0x86, 0xed, 0x13, 0x6c, 0x1b, 0x19, 0x84, 0x60, 0xd3, 0x76, 0x5a, 0x0d, 0x8b,
+	0xd8, 0xd8, 0x4e, 0x34, 0x64, 0x55, 0x01, 0xf1, 0x4d, 0xd3, 0x21, 0xd8, 0x36, 0x72, 0xc7, 0xbf,
+	0x17, 0xc0, 0xb4, 0xe2, 0x38, 0xf0, 0x93, 0x9b, 0x68, 0x71, 0x31, 0xe4, 0x42, 0xfd, 0xf5, 0x5d,
+	0x8e, 0x1c, 0x30, 0x3e, 0x5e, 0xe2, 0x48, 0xaf, 0xab, 0x25, 0xab, 0x7d, 0x77, 0xbb, 0x9e, 0x7c,
+	0xa5, 0xd4, 0x27, 0x5f, 0x29, 0x75, 0xac, 0xbe, 0x52, 0x6a, 0x6b, 0xe8, 0x1a, 0xaa, 0x4b, 0xd6,
+	0x0e, 0xaa, 0xcd, 0x18, 0x97, 0xed, 0xa4, 0x15, 0xc4, 0xdf, 0xc0, 0xfa, 0xdc, 0x8e, 0x40, 0xdb,
+	0x33, 0xb2, 0xf9, 0xa5, 0xb1, 0x82, 0xe0, 0x14, 0xfe, 0x77, 0x6f, 0x2c, 0xa3, 0x17, 0x33, 0x9a,
+	0xfb, 0xd3, 0x7a, 0x05, 0xd9, 0x7b, 0x78, 0xfe, 0xd0, 0xd0, 0x45, 0xaf, 0xef, 0xf3, 0x2d, 0x0c,
+	0x91, 0x15, 0x94, 0x2e, 0xbc, 0x5c, 0x39, 0x2f, 0xd1, 0xc1, 0x8c, 0x7b, 0xf5, 0x48, 0x5d, 0x11,
+	0xe4, 0x04, 0x9e, 0x7e, 0x34, 0xdd, 0xd0, 0xae, 0xa2, 0x7d, 0x78, 0xe4, 0xad, 0xae, 0xe6, 0xbd,
+	0x61, 0x91, 0x54, 0x73, 0xd9, 0x0c, 0x59, 0x4e, 0x76, 0x5b, 0xd4, 0x96, 0x2f, 0xff, 0x19, 0x00,
+	0xba, 0x7c, 0xb8, 0x77, 0x0a, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -683,6 +920,11 @@ type ApplicationServerServiceClient interface {
 	HandleError(ctx context.Context, in *HandleErrorRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// HandleDownlinkACK handles a downlink ACK or nACK response.
 	HandleDownlinkACK(ctx context.Context, in *HandleDownlinkACKRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleDownlinkStatus handles the delivery status of a device-queue item.
+	HandleDownlinkStatus(ctx context.Context, in *HandleDownlinkStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleMulticastDownlinkStatus handles the delivery status of a
+	// multicast queue-item, for each gateway used for the transmission.
+	HandleMulticastDownlinkStatus(ctx context.Context, in *HandleMulticastDownlinkStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// SetDeviceStatus updates the device-status for a device.
 	SetDeviceStatus(ctx context.Context, in *SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// SetDeviceLocation updates the device-location for a device.
@@ -733,6 +975,24 @@ func (c *applicationServerServiceClient) HandleDownlinkACK(ctx context.Context,
 	return out, nil
 }
 
+func (c *applicationServerServiceClient) HandleDownlinkStatus(ctx context.Context, in *HandleDownlinkStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleDownlinkStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServerServiceClient) HandleMulticastDownlinkStatus(ctx context.Context, in *HandleMulticastDownlinkStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleMulticastDownlinkStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationServerServiceClient) SetDeviceStatus(ctx context.Context, in *SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/SetDeviceStatus", in, out, opts...)
@@ -761,6 +1021,11 @@ type ApplicationServerServiceServer interface {
 	HandleError(context.Context, *HandleErrorRequest) (*empty.Empty, error)
 	// HandleDownlinkACK handles a downlink ACK or nACK response.
 	HandleDownlinkACK(context.Context, *HandleDownlinkACKRequest) (*empty.Empty, error)
+	// HandleDownlinkStatus handles the delivery status of a device-queue item.
+	HandleDownlinkStatus(context.Context, *HandleDownlinkStatusRequest) (*empty.Empty, error)
+	// HandleMulticastDownlinkStatus handles the delivery status of a
+	// multicast queue-item, for each gateway used for the transmission.
+	HandleMulticastDownlinkStatus(context.Context, *HandleMulticastDownlinkStatusRequest) (*empty.Empty, error)
 	// SetDeviceStatus updates the device-status for a device.
 	SetDeviceStatus(context.Context, *SetDeviceStatusRequest) (*empty.Empty, error)
 	// SetDeviceLocation updates the device-location for a device.
@@ -843,6 +1108,42 @@ func _ApplicationServerService_HandleDownlinkACK_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationServerService_HandleDownlinkStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleDownlinkStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServiceServer).HandleDownlinkStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServerService/HandleDownlinkStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServiceServer).HandleDownlinkStatus(ctx, req.(*HandleDownlinkStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationServerService_HandleMulticastDownlinkStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleMulticastDownlinkStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServiceServer).HandleMulticastDownlinkStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServerService/HandleMulticastDownlinkStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServiceServer).HandleMulticastDownlinkStatus(ctx, req.(*HandleMulticastDownlinkStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationServerService_SetDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetDeviceStatusRequest)
 	if err := dec(in); err != nil {
@@ -899,6 +1200,14 @@ var _ApplicationServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleDownlinkACK",
 			Handler:    _ApplicationServerService_HandleDownlinkACK_Handler,
 		},
+		{
+			MethodName: "HandleDownlinkStatus",
+			Handler:    _ApplicationServerService_HandleDownlinkStatus_Handler,
+		},
+		{
+			MethodName: "HandleMulticastDownlinkStatus",
+			Handler:    _ApplicationServerService_HandleMulticastDownlinkStatus_Handler,
+		},
 		{
 			MethodName: "SetDeviceStatus",
 			Handler:    _ApplicationServerService_SetDeviceStatus_Handler,
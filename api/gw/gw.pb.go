@@ -549,7 +549,13 @@ type UplinkRXInfo struct {
 	//	*UplinkRXInfo_PlainFineTimestamp
 	FineTimestamp isUplinkRXInfo_FineTimestamp `protobuf_oneof:"fine_timestamp"`
 	// Gateway specific context.
-	Context              []byte   `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	Context []byte `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	// RSSI calibration offset applied by the network-server.
+	// Within the frame-logs, rssi contains the raw (uncorrected) value.
+	RssiOffset float64 `protobuf:"fixed64,16,opt,name=rssi_offset,json=rssiOffset,proto3" json:"rssi_offset,omitempty"`
+	// LoRa SNR calibration offset applied by the network-server.
+	// Within the frame-logs, lora_snr contains the raw (uncorrected) value.
+	LoraSnrOffset        float64  `protobuf:"fixed64,17,opt,name=lora_snr_offset,json=loRaSNROffset,proto3" json:"lora_snr_offset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -701,6 +707,20 @@ func (m *UplinkRXInfo) GetContext() []byte {
 	return nil
 }
 
+func (m *UplinkRXInfo) GetRssiOffset() float64 {
+	if m != nil {
+		return m.RssiOffset
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetLoraSnrOffset() float64 {
+	if m != nil {
+		return m.LoraSnrOffset
+	}
+	return 0
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*UplinkRXInfo) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1722,107 +1742,109 @@ func init() {
 func init() { proto.RegisterFile("gw.proto", fileDescriptor_9ee4117efac0d846) }
 
 var fileDescriptor_9ee4117efac0d846 = []byte{
-	// 1625 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5f, 0x73, 0xe3, 0x48,
-	0x11, 0x8f, 0x9c, 0x75, 0x6c, 0xb7, 0xe3, 0xc4, 0x9e, 0x38, 0x89, 0x36, 0xc0, 0x5d, 0x50, 0x01,
-	0xb5, 0xbb, 0x77, 0xe5, 0x54, 0xe5, 0xa0, 0xa0, 0xd8, 0x2a, 0xaa, 0x92, 0xd8, 0xbb, 0xf1, 0xe6,
-	0xcf, 0xba, 0xc6, 0xe1, 0xea, 0xf6, 0x5e, 0xc4, 0x44, 0x1a, 0x3b, 0x2a, 0xdb, 0x92, 0x18, 0x4d,
-	0x62, 0x9b, 0x47, 0x0a, 0x0a, 0x9e, 0x79, 0xe1, 0x33, 0xf0, 0xc8, 0x97, 0xe1, 0xeb, 0x40, 0xcd,
-	0x1f, 0xc9, 0x92, 0xe5, 0xbb, 0x6c, 0x80, 0x7d, 0xb2, 0xbb, 0xa7, 0xa7, 0xbb, 0x67, 0xfa, 0x37,
-	0xbf, 0x6e, 0x41, 0x79, 0x38, 0x6d, 0x85, 0x2c, 0xe0, 0x01, 0x2a, 0x0c, 0xa7, 0x07, 0xfb, 0x24,
-	0xf4, 0x8e, 0x9c, 0x60, 0x32, 0x09, 0x7c, 0xfd, 0xa3, 0x16, 0x0f, 0x3e, 0x1f, 0x06, 0xc1, 0x70,
-	0x4c, 0x8f, 0xa4, 0x74, 0x7b, 0x3f, 0x38, 0xe2, 0xde, 0x84, 0x46, 0x9c, 0x4c, 0x42, 0x6d, 0xf0,
-	0xd9, 0xb2, 0x81, 0x7b, 0xcf, 0x08, 0xf7, 0x62, 0x07, 0xd6, 0x5f, 0x0b, 0xb0, 0xf9, 0xdb, 0x70,
-	0xec, 0xf9, 0xa3, 0x9b, 0x6f, 0xba, 0xfe, 0x20, 0x40, 0x3f, 0x84, 0xca, 0x80, 0xd1, 0xdf, 0xdf,
-	0x53, 0xdf, 0x99, 0x9b, 0xc6, 0xa1, 0xf1, 0xa2, 0x86, 0x17, 0x0a, 0x74, 0x0c, 0x30, 0x09, 0xdc,
-	0xfb, 0xb1, 0x74, 0x61, 0x16, 0x0e, 0x8d, 0x17, 0x5b, 0xc7, 0xa8, 0xa5, 0x53, 0xba, 0x4a, 0x56,
-	0x70, 0xca, 0x0a, 0xbd, 0x83, 0xe6, 0x38, 0x60, 0xc4, 0x5e, 0xa8, 0x6c, 0xcf, 0x1f, 0x04, 0xe6,
-	0xfa, 0xa1, 0xf1, 0xa2, 0x7a, 0xbc, 0xd7, 0x1a, 0x4e, 0x5b, 0x97, 0x01, 0x26, 0x8b, 0xdd, 0x22,
-	0x8f, 0xf3, 0x35, 0x8c, 0xc6, 0x39, 0x2d, 0x7a, 0x0b, 0x3b, 0x83, 0x68, 0x94, 0x73, 0xf5, 0x4c,
-	0xba, 0xda, 0x15, 0xae, 0xde, 0xf4, 0x2f, 0x72, 0x9e, 0x1a, 0x83, 0x68, 0x94, 0x55, 0x9e, 0x36,
-	0x60, 0x7b, 0xc9, 0x89, 0xf5, 0x4f, 0x03, 0x50, 0x3e, 0x11, 0x71, 0x21, 0xb7, 0xc4, 0x77, 0xa7,
-	0x9e, 0xcb, 0xef, 0xe2, 0x0b, 0x49, 0x14, 0xe8, 0x25, 0xd4, 0xa3, 0x90, 0x51, 0xe2, 0x7a, 0xfe,
-	0xd0, 0x1e, 0x10, 0x87, 0x07, 0x4c, 0x5e, 0x4b, 0x0d, 0x6f, 0x27, 0xfa, 0x37, 0x52, 0x8d, 0x7e,
-	0x00, 0x15, 0x27, 0x70, 0xa9, 0xcd, 0x08, 0xa7, 0xf2, 0xf0, 0x15, 0x5c, 0x16, 0x0a, 0x4c, 0x38,
-	0x45, 0xbf, 0x80, 0xbd, 0x30, 0x18, 0x13, 0xe6, 0xfd, 0x21, 0xce, 0xe8, 0x81, 0xb2, 0x48, 0x5c,
-	0xb2, 0x38, 0x5b, 0x19, 0xef, 0xa6, 0x57, 0xbb, 0xf1, 0xa2, 0x75, 0x01, 0x8d, 0xdc, 0x81, 0x1f,
-	0xc9, 0xd8, 0x84, 0xd2, 0xad, 0xc7, 0x65, 0x12, 0x2a, 0xd1, 0x58, 0xb4, 0x66, 0xb0, 0xd7, 0xf1,
-	0x1d, 0x36, 0x0f, 0x39, 0x75, 0xdf, 0x78, 0x3e, 0xbd, 0x89, 0xb1, 0x84, 0x2c, 0xa8, 0x11, 0x1a,
-	0xd9, 0x23, 0x3a, 0xb7, 0x3d, 0xdf, 0xa5, 0x33, 0xed, 0xb5, 0x4a, 0x68, 0x74, 0x41, 0xe7, 0x5d,
-	0xa1, 0x42, 0x3f, 0x86, 0x4d, 0x1a, 0xef, 0xb6, 0xfd, 0x48, 0x3a, 0xdf, 0xc4, 0xd5, 0x44, 0x77,
-	0xdd, 0x47, 0xfb, 0x50, 0x1a, 0x84, 0x43, 0x62, 0x7b, 0xae, 0x3c, 0xff, 0x26, 0xde, 0x10, 0x62,
-	0xb7, 0x6d, 0xb5, 0x01, 0xf5, 0xc6, 0xc4, 0xf3, 0xb3, 0x51, 0x5b, 0xf0, 0x4c, 0xc0, 0x59, 0x06,
-	0xab, 0x1e, 0x1f, 0xb4, 0x14, 0x94, 0x5b, 0x31, 0x94, 0x5b, 0x89, 0x25, 0x96, 0x76, 0xd6, 0xbf,
-	0xd7, 0x61, 0xf3, 0x2d, 0xe1, 0x74, 0x4a, 0xe6, 0x7d, 0x4e, 0x78, 0x84, 0x7e, 0x04, 0x30, 0x54,
-	0xb2, 0x08, 0x69, 0xc8, 0x90, 0x15, 0xad, 0xe9, 0xb6, 0xd1, 0x16, 0x14, 0xbc, 0xd0, 0xac, 0xc8,
-	0x4a, 0x14, 0xbc, 0x45, 0xbc, 0xc2, 0xc7, 0xc5, 0x43, 0x5f, 0x42, 0x79, 0x1c, 0x38, 0xea, 0x29,
-	0x28, 0x30, 0xd7, 0xe3, 0xa7, 0x70, 0xa9, 0xf5, 0x38, 0xb1, 0x40, 0x3f, 0x85, 0x2d, 0x27, 0xf0,
-	0x07, 0xde, 0xd0, 0x4e, 0x57, 0xb6, 0x82, 0x6b, 0x4a, 0xfb, 0xb5, 0x52, 0xa2, 0x16, 0xec, 0xb0,
-	0x99, 0x1d, 0x12, 0x67, 0x44, 0x79, 0x64, 0x33, 0xea, 0x50, 0xef, 0x81, 0xba, 0x66, 0x51, 0x5e,
-	0x78, 0x83, 0xcd, 0x7a, 0x6a, 0x05, 0xeb, 0x05, 0xf4, 0x15, 0xec, 0xad, 0xb0, 0xb7, 0x83, 0x91,
-	0xb9, 0x21, 0xb7, 0xec, 0xe4, 0xb6, 0xbc, 0xbf, 0x10, 0x41, 0xf8, 0x8a, 0x20, 0x25, 0x15, 0x84,
-	0xe7, 0x82, 0x7c, 0x09, 0x28, 0x65, 0x4f, 0x27, 0x1e, 0xe7, 0xd4, 0x35, 0xcb, 0xd2, 0xbc, 0x9e,
-	0x98, 0x77, 0x94, 0x1e, 0xbd, 0x86, 0xca, 0x84, 0x72, 0x62, 0xbb, 0x84, 0x13, 0x13, 0x0e, 0xd7,
-	0x5f, 0x54, 0x8f, 0x3f, 0x13, 0x4f, 0x33, 0x5d, 0x9b, 0xd6, 0x15, 0xe5, 0xa4, 0x4d, 0x38, 0xe9,
-	0xf8, 0x9c, 0xcd, 0x71, 0x79, 0xa2, 0xc5, 0x83, 0xd7, 0x50, 0xcb, 0x2c, 0xa1, 0x3a, 0xac, 0x8f,
-	0xa8, 0xa2, 0xa2, 0x0a, 0x16, 0x7f, 0x51, 0x13, 0x8a, 0x0f, 0x64, 0x7c, 0xaf, 0x0a, 0x55, 0xc1,
-	0x4a, 0xf8, 0x75, 0xe1, 0x57, 0x86, 0xf5, 0xc7, 0x62, 0xcc, 0x66, 0x58, 0xb1, 0xd9, 0x23, 0x08,
-	0x78, 0x6a, 0xc5, 0xdf, 0x41, 0x53, 0xfc, 0xda, 0x91, 0xe7, 0x3b, 0xd4, 0x1e, 0x86, 0x91, 0x4d,
-	0xc3, 0xc0, 0xb9, 0xd3, 0xd5, 0x7f, 0x9e, 0xdb, 0xdf, 0xd6, 0x64, 0x8b, 0x1b, 0x62, 0x5b, 0x5f,
-	0xec, 0x7a, 0xdb, 0xeb, 0x77, 0xc4, 0x1e, 0x84, 0xe0, 0x19, 0x8b, 0x22, 0x4f, 0x56, 0xb6, 0x88,
-	0xe5, 0x7f, 0xf4, 0x5c, 0x20, 0x8a, 0x11, 0x3b, 0xf2, 0x99, 0x2c, 0x9f, 0x81, 0x4b, 0x82, 0x04,
-	0xfb, 0xd7, 0x58, 0x3c, 0x5b, 0xe7, 0x8e, 0xf8, 0x3e, 0x1d, 0xeb, 0x32, 0xc5, 0xa2, 0xd8, 0xc4,
-	0x06, 0xb6, 0x73, 0x47, 0x3c, 0x5f, 0x97, 0xa4, 0xc4, 0x06, 0x67, 0x42, 0x14, 0x37, 0x75, 0x1b,
-	0x10, 0xe6, 0x4a, 0x90, 0xd7, 0xb0, 0x12, 0x84, 0x2b, 0xe2, 0x73, 0xea, 0xfb, 0xa2, 0x3a, 0xd2,
-	0x5e, 0x8b, 0x19, 0x44, 0x57, 0x1f, 0x45, 0x74, 0x07, 0x76, 0x06, 0x9e, 0x4f, 0xed, 0xa4, 0xe7,
-	0xd8, 0x7c, 0x1e, 0x52, 0x73, 0x53, 0x76, 0x05, 0x45, 0xc6, 0xe9, 0xf7, 0x7c, 0x33, 0x0f, 0x29,
-	0x6e, 0x0c, 0x96, 0x55, 0xe8, 0x6b, 0x30, 0x17, 0xc4, 0x91, 0x75, 0x68, 0xd6, 0xe2, 0xc2, 0x4c,
-	0x5b, 0xab, 0xa9, 0xe9, 0x7c, 0x0d, 0xef, 0xd1, 0xd5, 0xa4, 0xf5, 0x0e, 0x9a, 0xa1, 0x20, 0x95,
-	0x65, 0x9f, 0x5b, 0x8b, 0xbe, 0x93, 0x27, 0x1d, 0xd1, 0x77, 0xc2, 0x3c, 0x15, 0x89, 0xdb, 0x0f,
-	0x7c, 0x4e, 0x67, 0xdc, 0xdc, 0x96, 0x20, 0x8a, 0xc5, 0xd3, 0x3a, 0x6c, 0x65, 0xfd, 0x5b, 0xff,
-	0x28, 0xc2, 0x56, 0x3b, 0x98, 0xfa, 0xa9, 0xa6, 0xfa, 0x08, 0x0c, 0x33, 0x3d, 0xb7, 0xb8, 0xdc,
-	0x73, 0x9b, 0x50, 0x0c, 0x83, 0x29, 0x55, 0x88, 0x28, 0x62, 0x25, 0x2c, 0x75, 0xe2, 0xd2, 0xff,
-	0xd4, 0x89, 0xcb, 0xff, 0xbf, 0x4e, 0x5c, 0x79, 0x6a, 0x27, 0x5e, 0x60, 0x14, 0xbe, 0x03, 0xa3,
-	0xd5, 0x2c, 0x46, 0x5f, 0xc1, 0x06, 0xf7, 0x26, 0x9e, 0x3f, 0xd4, 0x40, 0x43, 0x22, 0x56, 0x72,
-	0xdf, 0x72, 0x05, 0x6b, 0x0b, 0xd4, 0x87, 0x7d, 0x6f, 0x32, 0xa1, 0xae, 0x47, 0x38, 0x1d, 0xcf,
-	0x6d, 0xa5, 0x55, 0x89, 0xd6, 0xe2, 0x27, 0x3b, 0x6d, 0x75, 0x17, 0x26, 0x6a, 0xbf, 0x4c, 0xd6,
-	0xc0, 0xbb, 0xde, 0xaa, 0x05, 0x74, 0x02, 0x0d, 0x97, 0x8e, 0x49, 0xd6, 0x9d, 0x02, 0xd5, 0x8e,
-	0xcc, 0x45, 0x2c, 0x66, 0x1c, 0x6d, 0xbb, 0x59, 0x15, 0xba, 0x80, 0xdd, 0x84, 0x3c, 0x32, 0x6e,
-	0xb6, 0x17, 0x95, 0x88, 0x89, 0x22, 0xe3, 0x09, 0x0d, 0xc3, 0x68, 0x49, 0x9b, 0xc6, 0x66, 0x3d,
-	0x8b, 0xcd, 0xfc, 0x90, 0x73, 0x5a, 0x83, 0x6a, 0x2a, 0x9e, 0xb5, 0x0f, 0xbb, 0x2b, 0x4f, 0x6f,
-	0x9d, 0xc2, 0xf6, 0xd2, 0x39, 0xd0, 0x11, 0x14, 0xe5, 0x39, 0x74, 0x3f, 0xfe, 0x1e, 0xb6, 0x53,
-	0x76, 0xd6, 0xef, 0x00, 0xe5, 0x0f, 0xf1, 0x9d, 0x1c, 0x6a, 0x3c, 0x9d, 0x43, 0xad, 0x3f, 0x19,
-	0x50, 0x55, 0x7c, 0xff, 0x86, 0x91, 0x09, 0x45, 0x9f, 0x43, 0x35, 0xbc, 0x9b, 0xdb, 0x21, 0x99,
-	0x8f, 0x03, 0x12, 0x3f, 0x34, 0x08, 0xef, 0xe6, 0x3d, 0xa5, 0x41, 0x2f, 0xa1, 0xc4, 0x67, 0xea,
-	0xaa, 0x0b, 0x9a, 0xdf, 0x86, 0xd3, 0x56, 0x7a, 0x00, 0xc6, 0x1b, 0x7c, 0x26, 0xf3, 0x7c, 0x09,
-	0x25, 0x36, 0x4b, 0x4f, 0xaa, 0x29, 0x53, 0xac, 0x4d, 0x99, 0x34, 0xb5, 0xfe, 0x62, 0xc0, 0x56,
-	0x2a, 0x8d, 0x3e, 0xe5, 0x9f, 0x2e, 0x93, 0xf5, 0xef, 0xcd, 0x24, 0x82, 0x5a, 0xfc, 0x14, 0x3e,
-	0xf2, 0x46, 0xbe, 0x58, 0xce, 0x23, 0xfb, 0x9e, 0xb2, 0x99, 0x34, 0xa1, 0xc8, 0x83, 0x11, 0x55,
-	0xe3, 0x4e, 0x0d, 0x2b, 0xc1, 0xfa, 0x76, 0x11, 0xf4, 0xe6, 0x9b, 0x13, 0x67, 0xf4, 0x18, 0xdd,
-	0x25, 0x5e, 0x0a, 0x29, 0x2f, 0x42, 0x4b, 0x19, 0x0b, 0x98, 0x1e, 0x8d, 0x95, 0x60, 0xfd, 0xd9,
-	0x80, 0xa6, 0x9e, 0x1b, 0xce, 0xe4, 0x9c, 0xa4, 0xd1, 0xf0, 0x58, 0x0c, 0x13, 0x4a, 0xf1, 0x98,
-	0xa5, 0xa6, 0x84, 0x58, 0x44, 0x3f, 0x87, 0xb2, 0xee, 0x9c, 0x91, 0xbe, 0x4e, 0x53, 0x9c, 0xf8,
-	0x4c, 0xe9, 0x32, 0x41, 0x70, 0x62, 0x69, 0xfd, 0xab, 0x00, 0xcd, 0x55, 0x26, 0x9f, 0xe0, 0x7b,
-	0xa9, 0x07, 0x7b, 0xcb, 0x2c, 0xad, 0x46, 0x44, 0x8d, 0x43, 0x33, 0xcf, 0xd3, 0x2a, 0xa5, 0xf3,
-	0x35, 0xdc, 0x1c, 0xaf, 0xd0, 0xa3, 0x2b, 0xd8, 0x5d, 0xe2, 0x6a, 0xed, 0x50, 0x7d, 0x37, 0xed,
-	0xe7, 0xd8, 0x3a, 0xf1, 0xb7, 0x93, 0xe1, 0x6b, 0xed, 0x2e, 0x61, 0xec, 0x62, 0x9a, 0xb1, 0x0f,
-	0xa1, 0xea, 0x52, 0x1d, 0x22, 0x60, 0x7a, 0xfa, 0x4c, 0xab, 0x4e, 0x77, 0xa0, 0x91, 0x4b, 0xc1,
-	0x22, 0xd0, 0x5c, 0x75, 0x96, 0x47, 0x3e, 0x62, 0xbe, 0x80, 0xc6, 0xf2, 0x67, 0x97, 0xf8, 0xe2,
-	0x58, 0x17, 0xf3, 0xe8, 0xd2, 0x77, 0x57, 0x64, 0x5d, 0xc1, 0xce, 0x8a, 0xd3, 0xfd, 0xd7, 0x9f,
-	0x49, 0x7f, 0x2b, 0xc0, 0xf3, 0x04, 0x92, 0x93, 0x09, 0xf1, 0xdd, 0xce, 0x8c, 0x3a, 0x58, 0x94,
-	0x3c, 0xe2, 0x1f, 0x81, 0x4b, 0x47, 0x6d, 0x8a, 0x71, 0xa9, 0xc5, 0xec, 0xdb, 0xda, 0x4c, 0xbd,
-	0x8a, 0x88, 0xbb, 0x9e, 0xfa, 0x58, 0xd8, 0xc4, 0x4a, 0x40, 0x3d, 0xa8, 0x52, 0xff, 0xc1, 0x63,
-	0x81, 0x3f, 0xa1, 0x3e, 0x37, 0x8b, 0x12, 0xc6, 0xad, 0xd4, 0x8c, 0x9d, 0x4f, 0xac, 0xd5, 0x59,
-	0x6c, 0x50, 0x33, 0x77, 0xda, 0xc5, 0xc1, 0x6f, 0xa0, 0xbe, 0x6c, 0xf0, 0xa4, 0xc9, 0xfb, 0xef,
-	0x06, 0x1c, 0xac, 0x8a, 0x1d, 0x85, 0x81, 0x1f, 0xd1, 0x27, 0x31, 0x42, 0x72, 0xf6, 0x3d, 0xd8,
-	0x88, 0xb8, 0x1b, 0xdc, 0xf3, 0xf8, 0x6b, 0x51, 0x49, 0x5a, 0x4f, 0x19, 0xd3, 0x97, 0xa2, 0xa5,
-	0x05, 0x83, 0x14, 0x53, 0x0c, 0xf2, 0xea, 0x75, 0x6a, 0x1a, 0x53, 0x53, 0xc1, 0x36, 0x54, 0xbb,
-	0x57, 0x57, 0x9d, 0x76, 0xf7, 0xe4, 0xa6, 0x73, 0xf9, 0xa1, 0xbe, 0x86, 0x2a, 0x50, 0x6c, 0x77,
-	0x2e, 0x4f, 0x3e, 0xd4, 0x0d, 0x54, 0x83, 0xca, 0xdb, 0x5e, 0xdf, 0xee, 0xf4, 0xde, 0x9f, 0x9d,
-	0xd7, 0x0b, 0xaf, 0x7e, 0x09, 0x8d, 0xdc, 0x0c, 0x8b, 0xca, 0xf0, 0xec, 0xfa, 0xfd, 0x75, 0xa7,
-	0xbe, 0x26, 0xac, 0x3b, 0xd7, 0x67, 0xf8, 0x43, 0xef, 0xa6, 0xd3, 0xae, 0x1b, 0xc2, 0x4f, 0xef,
-	0xf2, 0xa4, 0x7b, 0x5d, 0x2f, 0x9c, 0xfe, 0xec, 0xdb, 0x9f, 0x0c, 0x3d, 0x7e, 0x77, 0x7f, 0x2b,
-	0x1e, 0xfb, 0xd1, 0x2d, 0x0b, 0x1c, 0x42, 0xd8, 0x91, 0x78, 0xd7, 0x11, 0x65, 0x0f, 0x94, 0x1d,
-	0x91, 0xd0, 0x3b, 0x1a, 0x4e, 0x6f, 0x37, 0x64, 0x9f, 0xfb, 0xea, 0x3f, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0xbb, 0x87, 0xf7, 0x3e, 0xf0, 0x11, 0x00, 0x00,
+	// 1651 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4f, 0x73, 0xe3, 0x48,
+	0x15, 0x8f, 0x9c, 0x71, 0x6c, 0x3f, 0xc7, 0x89, 0xdd, 0x71, 0x12, 0x4d, 0x80, 0xdd, 0xa0, 0x82,
+	0xad, 0x99, 0xd9, 0x2d, 0xa7, 0x2a, 0x0b, 0x05, 0xc5, 0x54, 0x51, 0x95, 0xc4, 0x9e, 0x19, 0x6f,
+	0xfe, 0x8c, 0xab, 0x1d, 0xb6, 0x76, 0xf6, 0x22, 0x3a, 0x52, 0xdb, 0x51, 0xd9, 0x96, 0x44, 0xab,
+	0x13, 0xdb, 0x9c, 0xa1, 0xe0, 0xcc, 0x85, 0xcf, 0xc0, 0x91, 0xcf, 0xc1, 0x9d, 0xaf, 0x03, 0xd5,
+	0x7f, 0x24, 0x4b, 0x96, 0x17, 0x4f, 0x80, 0x3d, 0x59, 0xef, 0xd7, 0xaf, 0xdf, 0x7b, 0xdd, 0xef,
+	0x6f, 0x1b, 0xca, 0xc3, 0x69, 0x2b, 0x64, 0x01, 0x0f, 0x50, 0x61, 0x38, 0x3d, 0x3a, 0x24, 0xa1,
+	0x77, 0xe2, 0x04, 0x93, 0x49, 0xe0, 0xeb, 0x1f, 0xb5, 0x78, 0xf4, 0xe9, 0x30, 0x08, 0x86, 0x63,
+	0x7a, 0x22, 0xa9, 0xbb, 0x87, 0xc1, 0x09, 0xf7, 0x26, 0x34, 0xe2, 0x64, 0x12, 0x6a, 0x86, 0x4f,
+	0x96, 0x19, 0xdc, 0x07, 0x46, 0xb8, 0x17, 0x0b, 0xb0, 0xfe, 0x5c, 0x80, 0xed, 0xdf, 0x84, 0x63,
+	0xcf, 0x1f, 0xdd, 0x7e, 0xd3, 0xf5, 0x07, 0x01, 0xfa, 0x21, 0x54, 0x06, 0x8c, 0xfe, 0xee, 0x81,
+	0xfa, 0xce, 0xdc, 0x34, 0x8e, 0x8d, 0x17, 0x35, 0xbc, 0x00, 0xd0, 0x29, 0xc0, 0x24, 0x70, 0x1f,
+	0xc6, 0x52, 0x84, 0x59, 0x38, 0x36, 0x5e, 0xec, 0x9c, 0xa2, 0x96, 0x36, 0xe9, 0x3a, 0x59, 0xc1,
+	0x29, 0x2e, 0xf4, 0x15, 0x34, 0xc7, 0x01, 0x23, 0xf6, 0x02, 0xb2, 0x3d, 0x7f, 0x10, 0x98, 0x9b,
+	0xc7, 0xc6, 0x8b, 0xea, 0xe9, 0x41, 0x6b, 0x38, 0x6d, 0x5d, 0x05, 0x98, 0x2c, 0x76, 0x0b, 0x3b,
+	0xde, 0x6d, 0x60, 0x34, 0xce, 0xa1, 0xe8, 0x2d, 0xec, 0x0d, 0xa2, 0x51, 0x4e, 0xd4, 0x33, 0x29,
+	0x6a, 0x5f, 0x88, 0x7a, 0xd3, 0xbf, 0xcc, 0x49, 0x6a, 0x0c, 0xa2, 0x51, 0x16, 0x3c, 0x6f, 0xc0,
+	0xee, 0x92, 0x10, 0xeb, 0xef, 0x06, 0xa0, 0xbc, 0x21, 0xe2, 0x42, 0xee, 0x88, 0xef, 0x4e, 0x3d,
+	0x97, 0xdf, 0xc7, 0x17, 0x92, 0x00, 0xe8, 0x25, 0xd4, 0xa3, 0x90, 0x51, 0xe2, 0x7a, 0xfe, 0xd0,
+	0x1e, 0x10, 0x87, 0x07, 0x4c, 0x5e, 0x4b, 0x0d, 0xef, 0x26, 0xf8, 0x1b, 0x09, 0xa3, 0x1f, 0x40,
+	0xc5, 0x09, 0x5c, 0x6a, 0x33, 0xc2, 0xa9, 0x3c, 0x7c, 0x05, 0x97, 0x05, 0x80, 0x09, 0xa7, 0xe8,
+	0xe7, 0x70, 0x10, 0x06, 0x63, 0xc2, 0xbc, 0xdf, 0xc7, 0x16, 0x3d, 0x52, 0x16, 0x89, 0x4b, 0x16,
+	0x67, 0x2b, 0xe3, 0xfd, 0xf4, 0x6a, 0x37, 0x5e, 0xb4, 0x2e, 0xa1, 0x91, 0x3b, 0xf0, 0x1a, 0x8b,
+	0x4d, 0x28, 0xdd, 0x79, 0x5c, 0x1a, 0xa1, 0x0c, 0x8d, 0x49, 0x6b, 0x06, 0x07, 0x1d, 0xdf, 0x61,
+	0xf3, 0x90, 0x53, 0xf7, 0x8d, 0xe7, 0xd3, 0xdb, 0x38, 0x96, 0x90, 0x05, 0x35, 0x42, 0x23, 0x7b,
+	0x44, 0xe7, 0xb6, 0xe7, 0xbb, 0x74, 0xa6, 0xa5, 0x56, 0x09, 0x8d, 0x2e, 0xe9, 0xbc, 0x2b, 0x20,
+	0xf4, 0x63, 0xd8, 0xa6, 0xf1, 0x6e, 0xdb, 0x8f, 0xa4, 0xf0, 0x6d, 0x5c, 0x4d, 0xb0, 0x9b, 0x3e,
+	0x3a, 0x84, 0xd2, 0x20, 0x1c, 0x12, 0xdb, 0x73, 0xe5, 0xf9, 0xb7, 0xf1, 0x96, 0x20, 0xbb, 0x6d,
+	0xab, 0x0d, 0xa8, 0x37, 0x26, 0x9e, 0x9f, 0xd5, 0xda, 0x82, 0x67, 0x22, 0x9c, 0xa5, 0xb2, 0xea,
+	0xe9, 0x51, 0x4b, 0x85, 0x72, 0x2b, 0x0e, 0xe5, 0x56, 0xc2, 0x89, 0x25, 0x9f, 0xf5, 0xaf, 0x4d,
+	0xd8, 0x7e, 0x4b, 0x38, 0x9d, 0x92, 0x79, 0x9f, 0x13, 0x1e, 0xa1, 0x1f, 0x01, 0x0c, 0x15, 0x2d,
+	0x54, 0x1a, 0x52, 0x65, 0x45, 0x23, 0xdd, 0x36, 0xda, 0x81, 0x82, 0x17, 0x9a, 0x15, 0xe9, 0x89,
+	0x82, 0xb7, 0xd0, 0x57, 0xf8, 0x38, 0x7d, 0xe8, 0x0b, 0x28, 0x8f, 0x03, 0x47, 0xa5, 0x82, 0x0a,
+	0xe6, 0x7a, 0x9c, 0x0a, 0x57, 0x1a, 0xc7, 0x09, 0x07, 0xfa, 0x29, 0xec, 0x38, 0x81, 0x3f, 0xf0,
+	0x86, 0x76, 0xda, 0xb3, 0x15, 0x5c, 0x53, 0xe8, 0xd7, 0x0a, 0x44, 0x2d, 0xd8, 0x63, 0x33, 0x3b,
+	0x24, 0xce, 0x88, 0xf2, 0xc8, 0x66, 0xd4, 0xa1, 0xde, 0x23, 0x75, 0xcd, 0xa2, 0xbc, 0xf0, 0x06,
+	0x9b, 0xf5, 0xd4, 0x0a, 0xd6, 0x0b, 0xe8, 0x4b, 0x38, 0x58, 0xc1, 0x6f, 0x07, 0x23, 0x73, 0x4b,
+	0x6e, 0xd9, 0xcb, 0x6d, 0x79, 0x7f, 0x29, 0x94, 0xf0, 0x15, 0x4a, 0x4a, 0x4a, 0x09, 0xcf, 0x29,
+	0xf9, 0x02, 0x50, 0x8a, 0x9f, 0x4e, 0x3c, 0xce, 0xa9, 0x6b, 0x96, 0x25, 0x7b, 0x3d, 0x61, 0xef,
+	0x28, 0x1c, 0xbd, 0x86, 0xca, 0x84, 0x72, 0x62, 0xbb, 0x84, 0x13, 0x13, 0x8e, 0x37, 0x5f, 0x54,
+	0x4f, 0x3f, 0x11, 0xa9, 0x99, 0xf6, 0x4d, 0xeb, 0x9a, 0x72, 0xd2, 0x26, 0x9c, 0x74, 0x7c, 0xce,
+	0xe6, 0xb8, 0x3c, 0xd1, 0xe4, 0xd1, 0x6b, 0xa8, 0x65, 0x96, 0x50, 0x1d, 0x36, 0x47, 0x54, 0x95,
+	0xa2, 0x0a, 0x16, 0x9f, 0xa8, 0x09, 0xc5, 0x47, 0x32, 0x7e, 0x50, 0x8e, 0xaa, 0x60, 0x45, 0xfc,
+	0xaa, 0xf0, 0x4b, 0xc3, 0xfa, 0x47, 0x31, 0xae, 0x66, 0x58, 0x55, 0xb3, 0x35, 0x11, 0xf0, 0x54,
+	0x8f, 0x7f, 0x05, 0x4d, 0xf1, 0x6b, 0x47, 0x9e, 0xef, 0x50, 0x7b, 0x18, 0x46, 0x36, 0x0d, 0x03,
+	0xe7, 0x5e, 0x7b, 0xff, 0x79, 0x6e, 0x7f, 0x5b, 0x17, 0x5b, 0xdc, 0x10, 0xdb, 0xfa, 0x62, 0xd7,
+	0xdb, 0x5e, 0xbf, 0x23, 0xf6, 0x20, 0x04, 0xcf, 0x58, 0x14, 0x79, 0xd2, 0xb3, 0x45, 0x2c, 0xbf,
+	0xd1, 0x73, 0x11, 0x51, 0x8c, 0xd8, 0x91, 0xcf, 0xa4, 0xfb, 0x0c, 0x5c, 0x12, 0x45, 0xb0, 0x7f,
+	0x83, 0x45, 0xda, 0x3a, 0xf7, 0xc4, 0xf7, 0xe9, 0x58, 0xbb, 0x29, 0x26, 0xc5, 0x26, 0x36, 0xb0,
+	0x9d, 0x7b, 0xe2, 0xf9, 0xda, 0x25, 0x25, 0x36, 0xb8, 0x10, 0xa4, 0xb8, 0xa9, 0xbb, 0x80, 0x30,
+	0x57, 0x06, 0x79, 0x0d, 0x2b, 0x42, 0x88, 0x22, 0x3e, 0xa7, 0xbe, 0x2f, 0xbc, 0x23, 0xf9, 0x35,
+	0x99, 0x89, 0xe8, 0xea, 0xda, 0x88, 0xee, 0xc0, 0xde, 0xc0, 0xf3, 0xa9, 0x9d, 0xf4, 0x1c, 0x9b,
+	0xcf, 0x43, 0x6a, 0x6e, 0xcb, 0xae, 0xa0, 0x8a, 0x71, 0x3a, 0x9f, 0x6f, 0xe7, 0x21, 0xc5, 0x8d,
+	0xc1, 0x32, 0x84, 0xbe, 0x06, 0x73, 0x51, 0x38, 0xb2, 0x02, 0xcd, 0x5a, 0xec, 0x98, 0x69, 0x6b,
+	0x75, 0x69, 0x7a, 0xb7, 0x81, 0x0f, 0xe8, 0xca, 0x15, 0xe1, 0xac, 0x50, 0x14, 0x95, 0x65, 0x99,
+	0x3b, 0x8b, 0xbe, 0x93, 0x2f, 0x3a, 0xa2, 0xef, 0x84, 0x39, 0x54, 0xde, 0x7e, 0xe0, 0x73, 0x3a,
+	0xe3, 0xe6, 0xae, 0x0c, 0xa2, 0x98, 0x44, 0x9f, 0x42, 0x55, 0xb8, 0xce, 0x0e, 0x06, 0x83, 0x88,
+	0x72, 0xb3, 0x2e, 0xbd, 0x06, 0x02, 0x7a, 0x2f, 0x11, 0xf4, 0x19, 0xec, 0xc6, 0x3e, 0x8d, 0x99,
+	0x1a, 0x92, 0xa9, 0xa6, 0x5d, 0xab, 0xf8, 0xce, 0xeb, 0xb0, 0x93, 0x35, 0xd4, 0xfa, 0x5b, 0x11,
+	0x76, 0xda, 0xc1, 0xd4, 0x4f, 0x75, 0xe7, 0x35, 0xf1, 0x9c, 0x69, 0xde, 0xc5, 0xe5, 0xe6, 0xdd,
+	0x84, 0x62, 0x18, 0x4c, 0xa9, 0x0a, 0xad, 0x22, 0x56, 0xc4, 0x52, 0x4b, 0x2f, 0xfd, 0x4f, 0x2d,
+	0xbd, 0xfc, 0xff, 0x6b, 0xe9, 0x95, 0xa7, 0xb6, 0xf4, 0x45, 0xb0, 0xc3, 0x77, 0x04, 0x7b, 0x35,
+	0x1b, 0xec, 0xaf, 0x60, 0x8b, 0x7b, 0x13, 0xcf, 0x1f, 0xea, 0x88, 0x45, 0x42, 0x57, 0x72, 0xdf,
+	0x72, 0x05, 0x6b, 0x0e, 0xd4, 0x87, 0x43, 0x6f, 0x32, 0xa1, 0xae, 0x47, 0x38, 0x1d, 0xcf, 0x6d,
+	0x85, 0x2a, 0x43, 0x6b, 0x71, 0xee, 0x4f, 0x5b, 0xdd, 0x05, 0x8b, 0xda, 0x2f, 0x8d, 0x35, 0xf0,
+	0xbe, 0xb7, 0x6a, 0x01, 0x9d, 0x41, 0xc3, 0xa5, 0x63, 0x92, 0x15, 0xa7, 0xa2, 0x73, 0x4f, 0xda,
+	0x22, 0x16, 0x33, 0x82, 0x76, 0xdd, 0x2c, 0x84, 0x2e, 0x61, 0x3f, 0xa9, 0x42, 0x19, 0x31, 0xbb,
+	0x0b, 0x4f, 0xc4, 0x15, 0x27, 0x23, 0x09, 0x0d, 0xc3, 0x68, 0x09, 0x4d, 0x07, 0x79, 0x3d, 0x13,
+	0xe4, 0x2b, 0xa6, 0xa5, 0xf3, 0x1a, 0x54, 0x53, 0xfa, 0xac, 0x43, 0xd8, 0x5f, 0x79, 0x7a, 0xeb,
+	0x1c, 0x76, 0x97, 0xce, 0x81, 0x4e, 0xa0, 0x28, 0xcf, 0x61, 0x1a, 0xeb, 0xca, 0xa6, 0xe2, 0xb3,
+	0x7e, 0x0b, 0x28, 0x7f, 0x88, 0xef, 0x2c, 0xc6, 0xc6, 0xd3, 0x8b, 0xb1, 0xf5, 0x07, 0x03, 0xaa,
+	0xaa, 0x71, 0xbc, 0x61, 0x64, 0x42, 0x45, 0x56, 0x87, 0xf7, 0x73, 0x3b, 0x24, 0xf3, 0x71, 0x40,
+	0xe2, 0x44, 0x83, 0xf0, 0x7e, 0xde, 0x53, 0x08, 0x7a, 0x09, 0x25, 0x3e, 0x53, 0x57, 0x5d, 0xd0,
+	0x85, 0x72, 0x38, 0x6d, 0xa5, 0x27, 0x69, 0xbc, 0xc5, 0x67, 0xd2, 0xce, 0x97, 0x50, 0x62, 0xb3,
+	0xf4, 0xc8, 0x9b, 0x62, 0xc5, 0x9a, 0x95, 0x49, 0x56, 0xeb, 0x4f, 0x06, 0xec, 0xa4, 0xcc, 0xe8,
+	0x53, 0xfe, 0xfd, 0x59, 0xb2, 0xf9, 0x1f, 0x2d, 0x89, 0xa0, 0x16, 0xa7, 0xc2, 0x47, 0xde, 0xc8,
+	0xe7, 0xcb, 0x76, 0x64, 0xf3, 0x29, 0x6b, 0x49, 0x13, 0x8a, 0x3c, 0x18, 0x51, 0x35, 0x37, 0xd5,
+	0xb0, 0x22, 0xac, 0x6f, 0x17, 0x4a, 0x6f, 0xbf, 0x39, 0x73, 0x46, 0xeb, 0xca, 0x5d, 0x22, 0xa5,
+	0x90, 0x92, 0x22, 0x50, 0xca, 0x58, 0xc0, 0xf4, 0x8c, 0xad, 0x08, 0xeb, 0x8f, 0x06, 0x34, 0xf5,
+	0x00, 0x72, 0x21, 0x07, 0x2e, 0x1d, 0x0d, 0xeb, 0x74, 0x98, 0x50, 0x8a, 0xe7, 0x35, 0x35, 0x6e,
+	0xc4, 0x24, 0xfa, 0x19, 0x94, 0x75, 0x0b, 0x8e, 0xf4, 0x75, 0x9a, 0xe2, 0xc4, 0x17, 0x0a, 0xcb,
+	0x28, 0xc1, 0x09, 0xa7, 0xf5, 0xcf, 0x02, 0x34, 0x57, 0xb1, 0x7c, 0x0f, 0x0f, 0xaf, 0x1e, 0x1c,
+	0x2c, 0x57, 0x69, 0x35, 0x6b, 0xea, 0x38, 0x34, 0xf3, 0x75, 0x5a, 0x99, 0xf4, 0x6e, 0x03, 0x37,
+	0xc7, 0x2b, 0x70, 0x74, 0x0d, 0xfb, 0x4b, 0xb5, 0x5a, 0x0b, 0x54, 0x0f, 0xb0, 0xc3, 0x5c, 0xb5,
+	0x4e, 0xe4, 0xed, 0x65, 0xea, 0xb5, 0x16, 0x97, 0x54, 0xec, 0x62, 0xba, 0x62, 0x1f, 0x43, 0xd5,
+	0xa5, 0x5a, 0x45, 0xc0, 0xf4, 0x18, 0x9b, 0x86, 0xce, 0xf7, 0xa0, 0x91, 0x33, 0xc1, 0x22, 0xd0,
+	0x5c, 0x75, 0x96, 0x35, 0xaf, 0xa1, 0xcf, 0xa1, 0xb1, 0xfc, 0x7e, 0x13, 0x4f, 0x97, 0x4d, 0x31,
+	0xd8, 0x2e, 0x3d, 0xe0, 0x22, 0xeb, 0x1a, 0xf6, 0x56, 0x9c, 0xee, 0xbf, 0x7e, 0x6f, 0xfd, 0xa5,
+	0x00, 0xcf, 0x93, 0x90, 0x9c, 0x4c, 0x88, 0xef, 0x76, 0x66, 0xd4, 0xc1, 0xc2, 0xe5, 0x11, 0xff,
+	0x88, 0xb8, 0x74, 0xd4, 0xa6, 0x38, 0x2e, 0x35, 0x99, 0xcd, 0xad, 0xed, 0x54, 0x56, 0x44, 0xdc,
+	0xf5, 0xd4, 0xab, 0x63, 0x1b, 0x2b, 0x02, 0xf5, 0xa0, 0x4a, 0xfd, 0x47, 0x8f, 0x05, 0xfe, 0x84,
+	0xfa, 0xdc, 0x2c, 0xca, 0x30, 0x6e, 0xa5, 0x86, 0xf5, 0xbc, 0x61, 0xad, 0xce, 0x62, 0x83, 0x1a,
+	0xde, 0xd3, 0x22, 0x8e, 0x7e, 0x0d, 0xf5, 0x65, 0x86, 0x27, 0x8d, 0xf0, 0x7f, 0x35, 0xe0, 0x68,
+	0x95, 0xee, 0x28, 0x0c, 0xfc, 0x88, 0x3e, 0xa9, 0x22, 0x24, 0x67, 0x3f, 0x80, 0xad, 0x88, 0xbb,
+	0xc1, 0x03, 0x8f, 0x9f, 0x9d, 0x8a, 0xd2, 0x38, 0x65, 0x4c, 0x5f, 0x8a, 0xa6, 0x16, 0x15, 0xa4,
+	0x98, 0xaa, 0x20, 0xaf, 0x5e, 0xa7, 0xa6, 0x31, 0x35, 0x15, 0xec, 0x42, 0xb5, 0x7b, 0x7d, 0xdd,
+	0x69, 0x77, 0xcf, 0x6e, 0x3b, 0x57, 0x1f, 0xea, 0x1b, 0xa8, 0x02, 0xc5, 0x76, 0xe7, 0xea, 0xec,
+	0x43, 0xdd, 0x40, 0x35, 0xa8, 0xbc, 0xed, 0xf5, 0xed, 0x4e, 0xef, 0xfd, 0xc5, 0xbb, 0x7a, 0xe1,
+	0xd5, 0x2f, 0xa0, 0x91, 0x1b, 0x86, 0x51, 0x19, 0x9e, 0xdd, 0xbc, 0xbf, 0xe9, 0xd4, 0x37, 0x04,
+	0x77, 0xe7, 0xe6, 0x02, 0x7f, 0xe8, 0xdd, 0x76, 0xda, 0x75, 0x43, 0xc8, 0xe9, 0x5d, 0x9d, 0x75,
+	0x6f, 0xea, 0x85, 0xf3, 0xcf, 0xbe, 0xfd, 0xc9, 0xd0, 0xe3, 0xf7, 0x0f, 0x77, 0x22, 0xd9, 0x4f,
+	0xee, 0x58, 0xe0, 0x10, 0xc2, 0x4e, 0x44, 0x5e, 0x47, 0x94, 0x3d, 0x52, 0x76, 0x22, 0xfe, 0x1a,
+	0x1a, 0x4e, 0xef, 0xb6, 0x64, 0x9f, 0xfb, 0xf2, 0xdf, 0x03, 0x00, 0x81, 0x2a, 0xa4, 0x46, 0x39,
+	0x12, 0x00, 0x00,
 }
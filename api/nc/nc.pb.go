@@ -9,6 +9,7 @@ import (
 	gw "github.com/brocaar/loraserver/api/gw"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -140,34 +141,100 @@ func (m *HandleUplinkMACCommandRequest) GetCommands() [][]byte {
 	return nil
 }
 
+type HandleGatewayStateChangeRequest struct {
+	// Gateway ID (8 bytes).
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Gateway is online.
+	Online bool `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	// Last seen timestamp.
+	LastSeenAt           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HandleGatewayStateChangeRequest) Reset()         { *m = HandleGatewayStateChangeRequest{} }
+func (m *HandleGatewayStateChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleGatewayStateChangeRequest) ProtoMessage()    {}
+func (*HandleGatewayStateChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3fd7d898ee948e33, []int{2}
+}
+
+func (m *HandleGatewayStateChangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleGatewayStateChangeRequest.Unmarshal(m, b)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleGatewayStateChangeRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleGatewayStateChangeRequest.Merge(m, src)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleGatewayStateChangeRequest.Size(m)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleGatewayStateChangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleGatewayStateChangeRequest proto.InternalMessageInfo
+
+func (m *HandleGatewayStateChangeRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *HandleGatewayStateChangeRequest) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+func (m *HandleGatewayStateChangeRequest) GetLastSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*HandleUplinkMetaDataRequest)(nil), "nc.HandleUplinkMetaDataRequest")
 	proto.RegisterType((*HandleUplinkMACCommandRequest)(nil), "nc.HandleUplinkMACCommandRequest")
+	proto.RegisterType((*HandleGatewayStateChangeRequest)(nil), "nc.HandleGatewayStateChangeRequest")
 }
 
 func init() { proto.RegisterFile("nc.proto", fileDescriptor_3fd7d898ee948e33) }
 
 var fileDescriptor_3fd7d898ee948e33 = []byte{
-	// 303 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0xd1, 0x4a, 0xf3, 0x30,
-	0x18, 0x86, 0xff, 0xae, 0xd0, 0x8d, 0xfc, 0x13, 0x47, 0x90, 0x59, 0x3a, 0xc4, 0xba, 0xa3, 0x79,
-	0x92, 0xc2, 0xbc, 0x02, 0xa9, 0x03, 0x3d, 0x50, 0x30, 0x3a, 0xf0, 0x6c, 0x64, 0xe9, 0xd7, 0x12,
-	0xd6, 0x26, 0x35, 0x4b, 0xdb, 0x79, 0x0f, 0x5e, 0x96, 0x17, 0x26, 0x6d, 0xa7, 0x30, 0x9d, 0x7a,
-	0x94, 0xe4, 0xe3, 0xc9, 0xcb, 0xfb, 0x3d, 0xa8, 0x27, 0x39, 0xc9, 0xb5, 0x32, 0x0a, 0x77, 0x24,
-	0xf7, 0x46, 0x89, 0x52, 0x49, 0x0a, 0x41, 0x33, 0x59, 0x16, 0x71, 0x00, 0x59, 0x6e, 0x5e, 0x5a,
-	0xc0, 0x3b, 0x64, 0xb9, 0x08, 0x92, 0x2a, 0x48, 0xaa, 0x76, 0x30, 0x7e, 0xb5, 0xd0, 0xe8, 0x9a,
-	0xc9, 0x28, 0x85, 0x79, 0x9e, 0x0a, 0xb9, 0xba, 0x05, 0xc3, 0xae, 0x98, 0x61, 0x14, 0x9e, 0x0b,
-	0x58, 0x1b, 0x7c, 0x8c, 0xba, 0x11, 0x94, 0x0b, 0x28, 0x84, 0x6b, 0xf9, 0xd6, 0xa4, 0x4f, 0x9d,
-	0x08, 0xca, 0x59, 0x21, 0xf0, 0x39, 0xea, 0x9a, 0xcd, 0x42, 0xc8, 0x58, 0xb9, 0x1d, 0xdf, 0x9a,
-	0xfc, 0x9f, 0x0e, 0x48, 0x52, 0x91, 0x36, 0xe4, 0xf1, 0xe9, 0x46, 0xc6, 0x8a, 0x3a, 0x66, 0x53,
-	0x9f, 0x35, 0xaa, 0xb7, 0xa8, 0xed, 0xdb, 0xbb, 0x28, 0xdd, 0xa2, 0xba, 0x41, 0xc7, 0x31, 0x3a,
-	0xd9, 0x69, 0x73, 0x19, 0x86, 0x2a, 0xcb, 0x98, 0x8c, 0xfe, 0xec, 0x33, 0x40, 0x36, 0x17, 0x51,
-	0xd3, 0xe5, 0x80, 0xd6, 0x57, 0xec, 0xa1, 0x1e, 0x6f, 0x3f, 0xaf, 0x5d, 0xc7, 0xb7, 0x27, 0x7d,
-	0xfa, 0xf9, 0x9e, 0xbe, 0x59, 0xc8, 0xbd, 0x03, 0x53, 0x29, 0xbd, 0x0a, 0x95, 0x34, 0x5a, 0xa5,
-	0x29, 0xe8, 0x07, 0xd0, 0xa5, 0xe0, 0x80, 0xef, 0xd1, 0xd1, 0x3e, 0x25, 0xf8, 0x94, 0x48, 0x4e,
-	0x7e, 0x91, 0xe5, 0x0d, 0x49, 0xeb, 0x9e, 0x7c, 0xb8, 0x27, 0xb3, 0xda, 0xfd, 0xf8, 0x1f, 0x9e,
-	0xa3, 0xe1, 0xfe, 0xbd, 0xf0, 0xd9, 0xb7, 0xd0, 0xaf, 0x3b, 0xff, 0x1c, 0xbb, 0x74, 0x9a, 0xc9,
-	0xc5, 0x7b, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe2, 0x3e, 0x5f, 0x91, 0x02, 0x02, 0x00, 0x00,
+	// 406 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4f, 0x6f, 0x94, 0x40,
+	0x18, 0xc6, 0x65, 0x49, 0xe8, 0xfa, 0xba, 0xc6, 0x66, 0x62, 0x56, 0x42, 0xd3, 0xec, 0x8a, 0x97,
+	0xf5, 0x32, 0x24, 0xf5, 0xea, 0xa5, 0xc1, 0x46, 0x7b, 0xd0, 0xc4, 0xd9, 0x36, 0xd1, 0x13, 0x99,
+	0xc2, 0x0b, 0x4e, 0x0a, 0x33, 0x08, 0x2f, 0x4b, 0xfb, 0x1d, 0x3c, 0x7b, 0xf5, 0xab, 0x1a, 0xfe,
+	0xac, 0xa6, 0x7f, 0xb6, 0x3d, 0xc1, 0xbc, 0xf3, 0xe3, 0x79, 0xde, 0x3c, 0x0f, 0x30, 0xd5, 0x31,
+	0x2f, 0x2b, 0x43, 0x86, 0x4d, 0x74, 0xec, 0x1d, 0x64, 0xc6, 0x64, 0x39, 0x06, 0xfd, 0xe4, 0xa2,
+	0x49, 0x03, 0x2c, 0x4a, 0xba, 0x1e, 0x00, 0x6f, 0x71, 0xfb, 0x92, 0x54, 0x81, 0x35, 0xc9, 0xa2,
+	0x1c, 0x81, 0x17, 0xb2, 0x54, 0x41, 0xd6, 0x06, 0x59, 0x3b, 0x0c, 0xfc, 0x5f, 0x16, 0x1c, 0x7c,
+	0x92, 0x3a, 0xc9, 0xf1, 0xbc, 0xcc, 0x95, 0xbe, 0xfc, 0x8c, 0x24, 0x3f, 0x48, 0x92, 0x02, 0x7f,
+	0x36, 0x58, 0x13, 0x7b, 0x05, 0x7b, 0x09, 0x6e, 0x22, 0x6c, 0x94, 0x6b, 0x2d, 0xad, 0xd5, 0x4c,
+	0x38, 0x09, 0x6e, 0x4e, 0x1a, 0xc5, 0xde, 0xc2, 0x1e, 0x5d, 0x45, 0x4a, 0xa7, 0xc6, 0x9d, 0x2c,
+	0xad, 0xd5, 0xb3, 0xa3, 0x7d, 0x9e, 0xb5, 0x7c, 0x10, 0x39, 0xfb, 0x76, 0xaa, 0x53, 0x23, 0x1c,
+	0xba, 0xea, 0x9e, 0x1d, 0x5a, 0x8d, 0xa8, 0xbd, 0xb4, 0x6f, 0xa2, 0x62, 0x44, 0xab, 0x1e, 0xf5,
+	0x53, 0x38, 0xbc, 0xb1, 0xcd, 0x71, 0x18, 0x9a, 0xa2, 0x90, 0x3a, 0x79, 0x74, 0x9f, 0x7d, 0xb0,
+	0x63, 0x95, 0xf4, 0xbb, 0x3c, 0x17, 0xdd, 0x2b, 0xf3, 0x60, 0x1a, 0x0f, 0x1f, 0xd7, 0xae, 0xb3,
+	0xb4, 0x57, 0x33, 0xf1, 0xef, 0xec, 0xff, 0xb6, 0x60, 0x31, 0x18, 0x7d, 0x94, 0x84, 0xad, 0xbc,
+	0x5e, 0x93, 0x24, 0x0c, 0x7f, 0x48, 0x9d, 0xe1, 0xd6, 0xea, 0x10, 0x20, 0x1b, 0x2e, 0x23, 0x95,
+	0x8c, 0x6e, 0x4f, 0xc7, 0xc9, 0x69, 0xc2, 0xe6, 0xe0, 0x18, 0x9d, 0x2b, 0x8d, 0xbd, 0xe7, 0x54,
+	0x8c, 0x27, 0xf6, 0x1e, 0x66, 0xb9, 0xac, 0x29, 0xaa, 0x11, 0x75, 0x24, 0xc9, 0xb5, 0xfb, 0x74,
+	0x3c, 0x3e, 0x54, 0xc3, 0xb7, 0xd5, 0xf0, 0xb3, 0x6d, 0x35, 0x02, 0x3a, 0x7e, 0x8d, 0xa8, 0x8f,
+	0xe9, 0xe8, 0xcf, 0x04, 0xdc, 0x2f, 0x48, 0xad, 0xa9, 0x2e, 0x43, 0xa3, 0xa9, 0x32, 0x79, 0x8e,
+	0xd5, 0x1a, 0xab, 0x8d, 0x8a, 0x91, 0x7d, 0x85, 0x97, 0xf7, 0x75, 0xc5, 0x16, 0x5c, 0xc7, 0xfc,
+	0x81, 0x16, 0xbd, 0xf9, 0x1d, 0xf7, 0x93, 0xee, 0xaf, 0xf1, 0x9f, 0xb0, 0x73, 0x98, 0xdf, 0x1f,
+	0x38, 0x7b, 0x7d, 0x47, 0xf4, 0x76, 0x19, 0x0f, 0xc8, 0x7e, 0x07, 0x77, 0x57, 0xbc, 0xec, 0xcd,
+	0x7f, 0xe1, 0x9d, 0xe1, 0xef, 0x96, 0xbe, 0x70, 0xfa, 0xc9, 0xbb, 0xbf, 0x03, 0x00, 0x95, 0x71,
+	0x8a, 0xca, 0x17, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -189,6 +256,9 @@ type NetworkControllerServiceClient interface {
 	// enqueued throught the API or when the CID is >= 0x80 (proprietary
 	// mac-command range).
 	HandleUplinkMACCommand(ctx context.Context, in *HandleUplinkMACCommandRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleGatewayStateChange handles a gateway offline or back-online
+	// transition.
+	HandleGatewayStateChange(ctx context.Context, in *HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type networkControllerServiceClient struct {
@@ -217,6 +287,15 @@ func (c *networkControllerServiceClient) HandleUplinkMACCommand(ctx context.Cont
 	return out, nil
 }
 
+func (c *networkControllerServiceClient) HandleGatewayStateChange(ctx context.Context, in *HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/nc.NetworkControllerService/HandleGatewayStateChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkControllerServiceServer is the server API for NetworkControllerService service.
 type NetworkControllerServiceServer interface {
 	// HandleUplinkMetaData handles uplink meta-rata.
@@ -226,6 +305,9 @@ type NetworkControllerServiceServer interface {
 	// enqueued throught the API or when the CID is >= 0x80 (proprietary
 	// mac-command range).
 	HandleUplinkMACCommand(context.Context, *HandleUplinkMACCommandRequest) (*empty.Empty, error)
+	// HandleGatewayStateChange handles a gateway offline or back-online
+	// transition.
+	HandleGatewayStateChange(context.Context, *HandleGatewayStateChangeRequest) (*empty.Empty, error)
 }
 
 func RegisterNetworkControllerServiceServer(s *grpc.Server, srv NetworkControllerServiceServer) {
@@ -268,6 +350,24 @@ func _NetworkControllerService_HandleUplinkMACCommand_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkControllerService_HandleGatewayStateChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleGatewayStateChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServiceServer).HandleGatewayStateChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkControllerService/HandleGatewayStateChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServiceServer).HandleGatewayStateChange(ctx, req.(*HandleGatewayStateChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkControllerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nc.NetworkControllerService",
 	HandlerType: (*NetworkControllerServiceServer)(nil),
@@ -280,6 +380,10 @@ var _NetworkControllerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleUplinkMACCommand",
 			Handler:    _NetworkControllerService_HandleUplinkMACCommand_Handler,
 		},
+		{
+			MethodName: "HandleGatewayStateChange",
+			Handler:    _NetworkControllerService_HandleGatewayStateChange_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nc.proto",
@@ -9,8 +9,10 @@ import (
 	common "github.com/brocaar/loraserver/api/common"
 	gw "github.com/brocaar/loraserver/api/gw"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -53,6 +55,68 @@ func (RXWindow) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3b280de855f92a4a, []int{0}
 }
 
+type MACCommandTransport int32
+
+const (
+	// The downlink does not contain mac-commands.
+	MACCommandTransport_NO_MAC_COMMANDS MACCommandTransport = 0
+	// The mac-commands are sent in the FOpts field.
+	MACCommandTransport_FOPTS MACCommandTransport = 1
+	// The mac-commands are sent as (encrypted) FRMPayload using FPort 0.
+	MACCommandTransport_FRM_PAYLOAD MACCommandTransport = 2
+)
+
+var MACCommandTransport_name = map[int32]string{
+	0: "NO_MAC_COMMANDS",
+	1: "FOPTS",
+	2: "FRM_PAYLOAD",
+}
+
+var MACCommandTransport_value = map[string]int32{
+	"NO_MAC_COMMANDS": 0,
+	"FOPTS":           1,
+	"FRM_PAYLOAD":     2,
+}
+
+func (x MACCommandTransport) String() string {
+	return proto.EnumName(MACCommandTransport_name, int32(x))
+}
+
+func (MACCommandTransport) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{1}
+}
+
+type GatewayOrderBy int32
+
+const (
+	// Gateway ID.
+	GatewayOrderBy_GATEWAY_ID GatewayOrderBy = 0
+	// Created at timestamp.
+	GatewayOrderBy_CREATED_AT GatewayOrderBy = 1
+	// Last seen timestamp.
+	GatewayOrderBy_LAST_SEEN_AT GatewayOrderBy = 2
+)
+
+var GatewayOrderBy_name = map[int32]string{
+	0: "GATEWAY_ID",
+	1: "CREATED_AT",
+	2: "LAST_SEEN_AT",
+}
+
+var GatewayOrderBy_value = map[string]int32{
+	"GATEWAY_ID":   0,
+	"CREATED_AT":   1,
+	"LAST_SEEN_AT": 2,
+}
+
+func (x GatewayOrderBy) String() string {
+	return proto.EnumName(GatewayOrderBy_name, int32(x))
+}
+
+func (GatewayOrderBy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{2}
+}
+
 type AggregationInterval int32
 
 const (
@@ -93,7 +157,7 @@ func (x AggregationInterval) String() string {
 }
 
 func (AggregationInterval) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{1}
+	return fileDescriptor_3b280de855f92a4a, []int{3}
 }
 
 type MulticastGroupType int32
@@ -120,7 +184,7 @@ func (x MulticastGroupType) String() string {
 }
 
 func (MulticastGroupType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{2}
+	return fileDescriptor_3b280de855f92a4a, []int{4}
 }
 
 type CreateServiceProfileRequest struct {
@@ -639,6 +703,46 @@ func (m *DeleteRoutingProfileRequest) GetId() []byte {
 	return nil
 }
 
+type FlushDeadLetterQueueRequest struct {
+	// Routing-profile ID.
+	RoutingProfileId     []byte   `protobuf:"bytes,1,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlushDeadLetterQueueRequest) Reset()         { *m = FlushDeadLetterQueueRequest{} }
+func (m *FlushDeadLetterQueueRequest) String() string { return proto.CompactTextString(m) }
+func (*FlushDeadLetterQueueRequest) ProtoMessage()    {}
+func (*FlushDeadLetterQueueRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{12}
+}
+
+func (m *FlushDeadLetterQueueRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlushDeadLetterQueueRequest.Unmarshal(m, b)
+}
+func (m *FlushDeadLetterQueueRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlushDeadLetterQueueRequest.Marshal(b, m, deterministic)
+}
+func (m *FlushDeadLetterQueueRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlushDeadLetterQueueRequest.Merge(m, src)
+}
+func (m *FlushDeadLetterQueueRequest) XXX_Size() int {
+	return xxx_messageInfo_FlushDeadLetterQueueRequest.Size(m)
+}
+func (m *FlushDeadLetterQueueRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlushDeadLetterQueueRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlushDeadLetterQueueRequest proto.InternalMessageInfo
+
+func (m *FlushDeadLetterQueueRequest) GetRoutingProfileId() []byte {
+	if m != nil {
+		return m.RoutingProfileId
+	}
+	return nil
+}
+
 type CreateDeviceProfileRequest struct {
 	// Device-profile object to create.
 	DeviceProfile        *DeviceProfile `protobuf:"bytes,1,opt,name=device_profile,json=deviceProfile,proto3" json:"device_profile,omitempty"`
@@ -651,7 +755,7 @@ func (m *CreateDeviceProfileRequest) Reset()         { *m = CreateDeviceProfileR
 func (m *CreateDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceProfileRequest) ProtoMessage()    {}
 func (*CreateDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{12}
+	return fileDescriptor_3b280de855f92a4a, []int{13}
 }
 
 func (m *CreateDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -691,7 +795,7 @@ func (m *CreateDeviceProfileResponse) Reset()         { *m = CreateDeviceProfile
 func (m *CreateDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceProfileResponse) ProtoMessage()    {}
 func (*CreateDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{13}
+	return fileDescriptor_3b280de855f92a4a, []int{14}
 }
 
 func (m *CreateDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
@@ -731,7 +835,7 @@ func (m *GetDeviceProfileRequest) Reset()         { *m = GetDeviceProfileRequest
 func (m *GetDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceProfileRequest) ProtoMessage()    {}
 func (*GetDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{14}
+	return fileDescriptor_3b280de855f92a4a, []int{15}
 }
 
 func (m *GetDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -775,7 +879,7 @@ func (m *GetDeviceProfileResponse) Reset()         { *m = GetDeviceProfileRespon
 func (m *GetDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceProfileResponse) ProtoMessage()    {}
 func (*GetDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{15}
+	return fileDescriptor_3b280de855f92a4a, []int{16}
 }
 
 func (m *GetDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
@@ -829,7 +933,7 @@ func (m *UpdateDeviceProfileRequest) Reset()         { *m = UpdateDeviceProfileR
 func (m *UpdateDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceProfileRequest) ProtoMessage()    {}
 func (*UpdateDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{16}
+	return fileDescriptor_3b280de855f92a4a, []int{17}
 }
 
 func (m *UpdateDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -869,7 +973,7 @@ func (m *DeleteDeviceProfileRequest) Reset()         { *m = DeleteDeviceProfileR
 func (m *DeleteDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceProfileRequest) ProtoMessage()    {}
 func (*DeleteDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{17}
+	return fileDescriptor_3b280de855f92a4a, []int{18}
 }
 
 func (m *DeleteDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -923,7 +1027,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{18}
+	return fileDescriptor_3b280de855f92a4a, []int{19}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -998,7 +1102,7 @@ func (m *CreateDeviceRequest) Reset()         { *m = CreateDeviceRequest{} }
 func (m *CreateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceRequest) ProtoMessage()    {}
 func (*CreateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{19}
+	return fileDescriptor_3b280de855f92a4a, []int{20}
 }
 
 func (m *CreateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1038,7 +1142,7 @@ func (m *GetDeviceRequest) Reset()         { *m = GetDeviceRequest{} }
 func (m *GetDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceRequest) ProtoMessage()    {}
 func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{20}
+	return fileDescriptor_3b280de855f92a4a, []int{21}
 }
 
 func (m *GetDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1082,7 +1186,7 @@ func (m *GetDeviceResponse) Reset()         { *m = GetDeviceResponse{} }
 func (m *GetDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceResponse) ProtoMessage()    {}
 func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{21}
+	return fileDescriptor_3b280de855f92a4a, []int{22}
 }
 
 func (m *GetDeviceResponse) XXX_Unmarshal(b []byte) error {
@@ -1136,7 +1240,7 @@ func (m *UpdateDeviceRequest) Reset()         { *m = UpdateDeviceRequest{} }
 func (m *UpdateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceRequest) ProtoMessage()    {}
 func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{22}
+	return fileDescriptor_3b280de855f92a4a, []int{23}
 }
 
 func (m *UpdateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1176,7 +1280,7 @@ func (m *DeleteDeviceRequest) Reset()         { *m = DeleteDeviceRequest{} }
 func (m *DeleteDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceRequest) ProtoMessage()    {}
 func (*DeleteDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{23}
+	return fileDescriptor_3b280de855f92a4a, []int{24}
 }
 
 func (m *DeleteDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1232,7 +1336,7 @@ func (m *DeviceActivation) Reset()         { *m = DeviceActivation{} }
 func (m *DeviceActivation) String() string { return proto.CompactTextString(m) }
 func (*DeviceActivation) ProtoMessage()    {}
 func (*DeviceActivation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{24}
+	return fileDescriptor_3b280de855f92a4a, []int{25}
 }
 
 func (m *DeviceActivation) XXX_Unmarshal(b []byte) error {
@@ -1328,7 +1432,7 @@ func (m *ActivateDeviceRequest) Reset()         { *m = ActivateDeviceRequest{} }
 func (m *ActivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*ActivateDeviceRequest) ProtoMessage()    {}
 func (*ActivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{25}
+	return fileDescriptor_3b280de855f92a4a, []int{26}
 }
 
 func (m *ActivateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1368,7 +1472,7 @@ func (m *DeactivateDeviceRequest) Reset()         { *m = DeactivateDeviceRequest
 func (m *DeactivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeactivateDeviceRequest) ProtoMessage()    {}
 func (*DeactivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{26}
+	return fileDescriptor_3b280de855f92a4a, []int{27}
 }
 
 func (m *DeactivateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1408,7 +1512,7 @@ func (m *GetDeviceActivationRequest) Reset()         { *m = GetDeviceActivationR
 func (m *GetDeviceActivationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationRequest) ProtoMessage()    {}
 func (*GetDeviceActivationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{27}
+	return fileDescriptor_3b280de855f92a4a, []int{28}
 }
 
 func (m *GetDeviceActivationRequest) XXX_Unmarshal(b []byte) error {
@@ -1438,17 +1542,22 @@ func (m *GetDeviceActivationRequest) GetDevEui() []byte {
 
 type GetDeviceActivationResponse struct {
 	// Device-activation object.
-	DeviceActivation     *DeviceActivation `protobuf:"bytes,1,opt,name=device_activation,json=deviceActivation,proto3" json:"device_activation,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DeviceActivation *DeviceActivation `protobuf:"bytes,1,opt,name=device_activation,json=deviceActivation,proto3" json:"device_activation,omitempty"`
+	// Remaining device-session TTL.
+	// The TTL is refreshed on every uplink. When the TTL expires without
+	// receiving an uplink, the device-session is removed.
+	// This is not set when the device-session does not expire.
+	DeviceSessionTtl     *duration.Duration `protobuf:"bytes,2,opt,name=device_session_ttl,json=deviceSessionTtl,proto3" json:"device_session_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *GetDeviceActivationResponse) Reset()         { *m = GetDeviceActivationResponse{} }
 func (m *GetDeviceActivationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationResponse) ProtoMessage()    {}
 func (*GetDeviceActivationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{28}
+	return fileDescriptor_3b280de855f92a4a, []int{29}
 }
 
 func (m *GetDeviceActivationResponse) XXX_Unmarshal(b []byte) error {
@@ -1476,193 +1585,1816 @@ func (m *GetDeviceActivationResponse) GetDeviceActivation() *DeviceActivation {
 	return nil
 }
 
-type GetRandomDevAddrResponse struct {
-	// Random device address (DevAddr).
-	// Note that this includes the NetID prefix of the network-server.
-	DevAddr              []byte   `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrResponse{} }
-func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
-func (*GetRandomDevAddrResponse) ProtoMessage()    {}
-func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{29}
-}
-
-func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRandomDevAddrResponse.Unmarshal(m, b)
-}
-func (m *GetRandomDevAddrResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRandomDevAddrResponse.Marshal(b, m, deterministic)
-}
-func (m *GetRandomDevAddrResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRandomDevAddrResponse.Merge(m, src)
-}
-func (m *GetRandomDevAddrResponse) XXX_Size() int {
-	return xxx_messageInfo_GetRandomDevAddrResponse.Size(m)
-}
-func (m *GetRandomDevAddrResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRandomDevAddrResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_GetRandomDevAddrResponse proto.InternalMessageInfo
-
-func (m *GetRandomDevAddrResponse) GetDevAddr() []byte {
+func (m *GetDeviceActivationResponse) GetDeviceSessionTtl() *duration.Duration {
 	if m != nil {
-		return m.DevAddr
+		return m.DeviceSessionTtl
 	}
 	return nil
 }
 
-type CreateMACCommandQueueItemRequest struct {
-	// DevEUI EUI (8 bytes).
+type DeviceSession struct {
+	// Device EUI.
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
-	// Command identifier (specified by the LoRaWAN specs).
-	Cid uint32 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
-	// MAC-command(s). In case multiple payloads are defined, then they
-	// are always sent within a single frame.
-	Commands             [][]byte `protobuf:"bytes,5,rep,name=commands,proto3" json:"commands,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *CreateMACCommandQueueItemRequest) Reset()         { *m = CreateMACCommandQueueItemRequest{} }
-func (m *CreateMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateMACCommandQueueItemRequest) ProtoMessage()    {}
-func (*CreateMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
+	// Device address (DevAddr).
+	DevAddr []byte `protobuf:"bytes,2,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	// Join EUI.
+	JoinEui []byte `protobuf:"bytes,3,opt,name=join_eui,json=joinEui,proto3" json:"join_eui,omitempty"`
+	// LoRaWAN MAC version.
+	MacVersion string `protobuf:"bytes,4,opt,name=mac_version,json=macVersion,proto3" json:"mac_version,omitempty"`
+	// Device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,5,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,6,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,7,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// The next expected uplink frame-counter.
+	FCntUp uint32 `protobuf:"varint,8,opt,name=f_cnt_up,json=fCntUp,proto3" json:"f_cnt_up,omitempty"`
+	// The network frame-counter used for the next downlink frame.
+	NFCntDown uint32 `protobuf:"varint,9,opt,name=n_f_cnt_down,json=nFCntDown,proto3" json:"n_f_cnt_down,omitempty"`
+	// The application frame-counter used for the next downlink frame (LoRaWAN 1.1).
+	AFCntDown uint32 `protobuf:"varint,10,opt,name=a_f_cnt_down,json=aFCntDown,proto3" json:"a_f_cnt_down,omitempty"`
+	// The frame-counter of the last confirmed downlink frame.
+	ConfFCnt uint32 `protobuf:"varint,11,opt,name=conf_f_cnt,json=confFCnt,proto3" json:"conf_f_cnt,omitempty"`
+	// Skip frame-counter checks.
+	SkipFCntCheck bool `protobuf:"varint,12,opt,name=skip_f_cnt_check,json=skipFCntCheck,proto3" json:"skip_f_cnt_check,omitempty"`
+	// RX window to use for downlink.
+	RxWindow RXWindow `protobuf:"varint,13,opt,name=rx_window,json=rxWindow,proto3,enum=ns.RXWindow" json:"rx_window,omitempty"`
+	// RX1 delay.
+	RxDelay uint32 `protobuf:"varint,14,opt,name=rx_delay,json=rxDelay,proto3" json:"rx_delay,omitempty"`
+	// RX1 data-rate offset.
+	Rx1DrOffset uint32 `protobuf:"varint,15,opt,name=rx1_dr_offset,json=rx1DrOffset,proto3" json:"rx1_dr_offset,omitempty"`
+	// RX2 data-rate.
+	Rx2Dr uint32 `protobuf:"varint,16,opt,name=rx2_dr,json=rx2Dr,proto3" json:"rx2_dr,omitempty"`
+	// RX2 frequency (Hz).
+	Rx2Frequency uint32 `protobuf:"varint,17,opt,name=rx2_frequency,json=rx2Frequency,proto3" json:"rx2_frequency,omitempty"`
+	// TX power index used by the device.
+	TxPowerIndex uint32 `protobuf:"varint,18,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Data-rate used by the device.
+	Dr uint32 `protobuf:"varint,19,opt,name=dr,proto3" json:"dr,omitempty"`
+	// ADR is enabled by the device.
+	Adr bool `protobuf:"varint,20,opt,name=adr,proto3" json:"adr,omitempty"`
+	// Minimum supported TX power index by the device.
+	MinSupportedTxPowerIndex uint32 `protobuf:"varint,21,opt,name=min_supported_tx_power_index,json=minSupportedTxPowerIndex,proto3" json:"min_supported_tx_power_index,omitempty"`
+	// Maximum supported TX power index by the device.
+	MaxSupportedTxPowerIndex uint32 `protobuf:"varint,22,opt,name=max_supported_tx_power_index,json=maxSupportedTxPowerIndex,proto3" json:"max_supported_tx_power_index,omitempty"`
+	// Number of transmissions for each unconfirmed uplink frame.
+	NbTrans uint32 `protobuf:"varint,23,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// Enabled uplink channels.
+	EnabledUplinkChannels []uint32 `protobuf:"varint,24,rep,packed,name=enabled_uplink_channels,json=enabledUplinkChannels,proto3" json:"enabled_uplink_channels,omitempty"`
+	// Frequency (Hz) of each channel.
+	ChannelFrequencies []uint32 `protobuf:"varint,25,rep,packed,name=channel_frequencies,json=channelFrequencies,proto3" json:"channel_frequencies,omitempty"`
+	// Number of uplink history items (used by ADR).
+	UplinkHistoryCount uint32 `protobuf:"varint,26,opt,name=uplink_history_count,json=uplinkHistoryCount,proto3" json:"uplink_history_count,omitempty"`
+	// Last device-status request timestamp.
+	LastDevStatusRequestedAt *timestamp.Timestamp `protobuf:"bytes,27,opt,name=last_dev_status_requested_at,json=lastDevStatusRequestedAt,proto3" json:"last_dev_status_requested_at,omitempty"`
+	// Last downlink timestamp.
+	LastDownlinkTxAt *timestamp.Timestamp `protobuf:"bytes,28,opt,name=last_downlink_tx_at,json=lastDownlinkTxAt,proto3" json:"last_downlink_tx_at,omitempty"`
+	// Class-B beacon is locked.
+	BeaconLocked bool `protobuf:"varint,29,opt,name=beacon_locked,json=beaconLocked,proto3" json:"beacon_locked,omitempty"`
+	// Class-B ping-slot nb.
+	PingSlotNb uint32 `protobuf:"varint,30,opt,name=ping_slot_nb,json=pingSlotNb,proto3" json:"ping_slot_nb,omitempty"`
+	// Class-B ping-slot data-rate.
+	PingSlotDr uint32 `protobuf:"varint,31,opt,name=ping_slot_dr,json=pingSlotDr,proto3" json:"ping_slot_dr,omitempty"`
+	// Class-B ping-slot frequency (Hz).
+	PingSlotFrequency uint32 `protobuf:"varint,32,opt,name=ping_slot_frequency,json=pingSlotFrequency,proto3" json:"ping_slot_frequency,omitempty"`
+	// Device reference altitude (used for geolocation).
+	ReferenceAltitude float64 `protobuf:"fixed64,33,opt,name=reference_altitude,json=referenceAltitude,proto3" json:"reference_altitude,omitempty"`
+	// Uplink dwell-time 400ms limitation.
+	UplinkDwellTime_400Ms bool `protobuf:"varint,34,opt,name=uplink_dwell_time_400ms,json=uplinkDwellTime400ms,proto3" json:"uplink_dwell_time_400ms,omitempty"`
+	// Downlink dwell-time 400ms limitation.
+	DownlinkDwellTime_400Ms bool `protobuf:"varint,35,opt,name=downlink_dwell_time_400ms,json=downlinkDwellTime400ms,proto3" json:"downlink_dwell_time_400ms,omitempty"`
+	// Uplink max EIRP index.
+	UplinkMaxEirpIndex uint32 `protobuf:"varint,36,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
+	// ADR installation margin (dB).
+	// This is the effective value, either the device-session override
+	// or the global installation margin.
+	InstallationMargin float64 `protobuf:"fixed64,37,opt,name=installation_margin,json=installationMargin,proto3" json:"installation_margin,omitempty"`
+	// Last reported device battery level (DevStatusAns).
+	DevStatusBattery uint32 `protobuf:"varint,38,opt,name=dev_status_battery,json=devStatusBattery,proto3" json:"dev_status_battery,omitempty"`
+	// Last reported device margin (DevStatusAns).
+	DevStatusMargin int32 `protobuf:"varint,39,opt,name=dev_status_margin,json=devStatusMargin,proto3" json:"dev_status_margin,omitempty"`
+	// Timestamp of the last DevStatusAns.
+	// This is not set when the device never answered a DevStatusReq.
+	DevStatusReceivedAt *timestamp.Timestamp `protobuf:"bytes,40,opt,name=dev_status_received_at,json=devStatusReceivedAt,proto3" json:"dev_status_received_at,omitempty"`
+	// Best gateways (sorted by SNR and RSSI) which received the last uplink.
+	LastRxInfoSet []*DeviceSessionRXInfo `protobuf:"bytes,41,rep,name=last_rx_info_set,json=lastRxInfoSet,proto3" json:"last_rx_info_set,omitempty"`
+	// Meta-data of the last downlink transmission.
+	// This is not set when no downlink was sent since the activation.
+	LastTxInfo *DeviceSessionTXInfo `protobuf:"bytes,42,opt,name=last_tx_info,json=lastTxInfo,proto3" json:"last_tx_info,omitempty"`
+	// RX window preference of the device-session.
+	RxWindowPreference RXWindowPreference `protobuf:"varint,43,opt,name=rx_window_preference,json=rxWindowPreference,proto3,enum=ns.RXWindowPreference" json:"rx_window_preference,omitempty"`
+	// Number of received uplink retransmissions (same FCnt and MIC as the
+	// previous uplink) since the activation.
+	UplinkRetransmissionCount uint32 `protobuf:"varint,44,opt,name=uplink_retransmission_count,json=uplinkRetransmissionCount,proto3" json:"uplink_retransmission_count,omitempty"`
+	// Max data-rate supported by the device, lowered when the device
+	// rejects the data-rate of a LinkADRReq (only when max_supported_dr_set).
+	MaxSupportedDr uint32 `protobuf:"varint,45,opt,name=max_supported_dr,json=maxSupportedDr,proto3" json:"max_supported_dr,omitempty"`
+	// Number of consecutive LinkADRReq mac-commands rejected by the device.
+	LinkAdrReqRejectionCount uint32 `protobuf:"varint,46,opt,name=link_adr_req_rejection_count,json=linkAdrReqRejectionCount,proto3" json:"link_adr_req_rejection_count,omitempty"`
+	// The device rejected a channel-mask, no channel-mask reconfiguration
+	// is requested.
+	ChannelMaskRejected bool `protobuf:"varint,47,opt,name=channel_mask_rejected,json=channelMaskRejected,proto3" json:"channel_mask_rejected,omitempty"`
+	// ADR has been stopped because of too many rejected LinkADRReqs.
+	AdrStopped bool `protobuf:"varint,48,opt,name=adr_stopped,json=adrStopped,proto3" json:"adr_stopped,omitempty"`
+	// The max data-rate supported by the device is set.
+	MaxSupportedDrSet bool `protobuf:"varint,49,opt,name=max_supported_dr_set,json=maxSupportedDrSet,proto3" json:"max_supported_dr_set,omitempty"`
+	// Number of uplinks accepted because frame-counter validation is skipped
+	// (since the activation).
+	SkipFCntValidationCount uint32   `protobuf:"varint,50,opt,name=skip_f_cnt_validation_count,json=skipFCntValidationCount,proto3" json:"skip_f_cnt_validation_count,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *DeviceSession) Reset()         { *m = DeviceSession{} }
+func (m *DeviceSession) String() string { return proto.CompactTextString(m) }
+func (*DeviceSession) ProtoMessage()    {}
+func (*DeviceSession) Descriptor() ([]byte, []int) {
 	return fileDescriptor_3b280de855f92a4a, []int{30}
 }
 
-func (m *CreateMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Unmarshal(m, b)
+func (m *DeviceSession) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSession.Unmarshal(m, b)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Marshal(b, m, deterministic)
+func (m *DeviceSession) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSession.Marshal(b, m, deterministic)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMACCommandQueueItemRequest.Merge(m, src)
+func (m *DeviceSession) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSession.Merge(m, src)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Size(m)
+func (m *DeviceSession) XXX_Size() int {
+	return xxx_messageInfo_DeviceSession.Size(m)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMACCommandQueueItemRequest.DiscardUnknown(m)
+func (m *DeviceSession) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSession.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateMACCommandQueueItemRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeviceSession proto.InternalMessageInfo
 
-func (m *CreateMACCommandQueueItemRequest) GetDevEui() []byte {
+func (m *DeviceSession) GetDevEui() []byte {
 	if m != nil {
 		return m.DevEui
 	}
 	return nil
 }
 
-func (m *CreateMACCommandQueueItemRequest) GetCid() uint32 {
+func (m *DeviceSession) GetDevAddr() []byte {
 	if m != nil {
-		return m.Cid
+		return m.DevAddr
 	}
-	return 0
+	return nil
 }
 
-func (m *CreateMACCommandQueueItemRequest) GetCommands() [][]byte {
+func (m *DeviceSession) GetJoinEui() []byte {
 	if m != nil {
-		return m.Commands
+		return m.JoinEui
 	}
 	return nil
 }
 
-type SendProprietaryPayloadRequest struct {
-	// MACPayload of the proprietary LoRaWAN frame.
-	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
-	// MIC of the proprietary LoRaWAN frame (must be 4 bytes).
-	Mic []byte `protobuf:"bytes,2,opt,name=mic,proto3" json:"mic,omitempty"`
-	// Gateway MAC address(es) to use for transmitting the LoRaWAN frame.
-	GatewayMacs [][]byte `protobuf:"bytes,3,rep,name=gateway_macs,json=gatewayMacs,proto3" json:"gateway_macs,omitempty"`
-	// Set to true for sending as a gateway, or false for sending as a node.
-	// In the latter case the frame will be received by other gateways.
-	PolarizationInversion bool `protobuf:"varint,4,opt,name=polarization_inversion,json=polarizationInversion,proto3" json:"polarization_inversion,omitempty"`
-	// Frequency (Hz) to use for the transmission.
-	Frequency uint32 `protobuf:"varint,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	// Data-rate to use for the transmission.
-	Dr                   uint32   `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *DeviceSession) GetMacVersion() string {
+	if m != nil {
+		return m.MacVersion
+	}
+	return ""
 }
 
-func (m *SendProprietaryPayloadRequest) Reset()         { *m = SendProprietaryPayloadRequest{} }
-func (m *SendProprietaryPayloadRequest) String() string { return proto.CompactTextString(m) }
-func (*SendProprietaryPayloadRequest) ProtoMessage()    {}
-func (*SendProprietaryPayloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{31}
+func (m *DeviceSession) GetDeviceProfileId() []byte {
+	if m != nil {
+		return m.DeviceProfileId
+	}
+	return nil
 }
 
-func (m *SendProprietaryPayloadRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SendProprietaryPayloadRequest.Unmarshal(m, b)
-}
-func (m *SendProprietaryPayloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SendProprietaryPayloadRequest.Marshal(b, m, deterministic)
-}
-func (m *SendProprietaryPayloadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendProprietaryPayloadRequest.Merge(m, src)
-}
-func (m *SendProprietaryPayloadRequest) XXX_Size() int {
-	return xxx_messageInfo_SendProprietaryPayloadRequest.Size(m)
+func (m *DeviceSession) GetServiceProfileId() []byte {
+	if m != nil {
+		return m.ServiceProfileId
+	}
+	return nil
 }
-func (m *SendProprietaryPayloadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendProprietaryPayloadRequest.DiscardUnknown(m)
+
+func (m *DeviceSession) GetRoutingProfileId() []byte {
+	if m != nil {
+		return m.RoutingProfileId
+	}
+	return nil
 }
 
-var xxx_messageInfo_SendProprietaryPayloadRequest proto.InternalMessageInfo
+func (m *DeviceSession) GetFCntUp() uint32 {
+	if m != nil {
+		return m.FCntUp
+	}
+	return 0
+}
 
-func (m *SendProprietaryPayloadRequest) GetMacPayload() []byte {
+func (m *DeviceSession) GetNFCntDown() uint32 {
 	if m != nil {
-		return m.MacPayload
+		return m.NFCntDown
 	}
-	return nil
+	return 0
 }
 
-func (m *SendProprietaryPayloadRequest) GetMic() []byte {
+func (m *DeviceSession) GetAFCntDown() uint32 {
 	if m != nil {
-		return m.Mic
+		return m.AFCntDown
 	}
-	return nil
+	return 0
 }
 
-func (m *SendProprietaryPayloadRequest) GetGatewayMacs() [][]byte {
+func (m *DeviceSession) GetConfFCnt() uint32 {
 	if m != nil {
-		return m.GatewayMacs
+		return m.ConfFCnt
 	}
-	return nil
+	return 0
 }
 
-func (m *SendProprietaryPayloadRequest) GetPolarizationInversion() bool {
+func (m *DeviceSession) GetSkipFCntCheck() bool {
 	if m != nil {
-		return m.PolarizationInversion
+		return m.SkipFCntCheck
 	}
 	return false
 }
 
-func (m *SendProprietaryPayloadRequest) GetFrequency() uint32 {
+func (m *DeviceSession) GetRxWindow() RXWindow {
 	if m != nil {
-		return m.Frequency
+		return m.RxWindow
 	}
-	return 0
+	return RXWindow_RX1
 }
 
-func (m *SendProprietaryPayloadRequest) GetDr() uint32 {
+func (m *DeviceSession) GetRxDelay() uint32 {
 	if m != nil {
-		return m.Dr
+		return m.RxDelay
 	}
 	return 0
 }
 
-type Gateway struct {
+func (m *DeviceSession) GetRx1DrOffset() uint32 {
+	if m != nil {
+		return m.Rx1DrOffset
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetRx2Dr() uint32 {
+	if m != nil {
+		return m.Rx2Dr
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetAdr() bool {
+	if m != nil {
+		return m.Adr
+	}
+	return false
+}
+
+func (m *DeviceSession) GetMinSupportedTxPowerIndex() uint32 {
+	if m != nil {
+		return m.MinSupportedTxPowerIndex
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetMaxSupportedTxPowerIndex() uint32 {
+	if m != nil {
+		return m.MaxSupportedTxPowerIndex
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetEnabledUplinkChannels() []uint32 {
+	if m != nil {
+		return m.EnabledUplinkChannels
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetChannelFrequencies() []uint32 {
+	if m != nil {
+		return m.ChannelFrequencies
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetUplinkHistoryCount() uint32 {
+	if m != nil {
+		return m.UplinkHistoryCount
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetLastDevStatusRequestedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastDevStatusRequestedAt
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetLastDownlinkTxAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastDownlinkTxAt
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetBeaconLocked() bool {
+	if m != nil {
+		return m.BeaconLocked
+	}
+	return false
+}
+
+func (m *DeviceSession) GetPingSlotNb() uint32 {
+	if m != nil {
+		return m.PingSlotNb
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetPingSlotDr() uint32 {
+	if m != nil {
+		return m.PingSlotDr
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetPingSlotFrequency() uint32 {
+	if m != nil {
+		return m.PingSlotFrequency
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetReferenceAltitude() float64 {
+	if m != nil {
+		return m.ReferenceAltitude
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetUplinkDwellTime_400Ms() bool {
+	if m != nil {
+		return m.UplinkDwellTime_400Ms
+	}
+	return false
+}
+
+func (m *DeviceSession) GetDownlinkDwellTime_400Ms() bool {
+	if m != nil {
+		return m.DownlinkDwellTime_400Ms
+	}
+	return false
+}
+
+func (m *DeviceSession) GetUplinkMaxEirpIndex() uint32 {
+	if m != nil {
+		return m.UplinkMaxEirpIndex
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetDevStatusBattery() uint32 {
+	if m != nil {
+		return m.DevStatusBattery
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetDevStatusMargin() int32 {
+	if m != nil {
+		return m.DevStatusMargin
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetDevStatusReceivedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DevStatusReceivedAt
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetLastRxInfoSet() []*DeviceSessionRXInfo {
+	if m != nil {
+		return m.LastRxInfoSet
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetLastTxInfo() *DeviceSessionTXInfo {
+	if m != nil {
+		return m.LastTxInfo
+	}
+	return nil
+}
+
+func (m *DeviceSession) GetRxWindowPreference() RXWindowPreference {
+	if m != nil {
+		return m.RxWindowPreference
+	}
+	return RXWindowPreference_RX_WINDOW_AUTO
+}
+
+func (m *DeviceSession) GetUplinkRetransmissionCount() uint32 {
+	if m != nil {
+		return m.UplinkRetransmissionCount
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetMaxSupportedDr() uint32 {
+	if m != nil {
+		return m.MaxSupportedDr
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetLinkAdrReqRejectionCount() uint32 {
+	if m != nil {
+		return m.LinkAdrReqRejectionCount
+	}
+	return 0
+}
+
+func (m *DeviceSession) GetChannelMaskRejected() bool {
+	if m != nil {
+		return m.ChannelMaskRejected
+	}
+	return false
+}
+
+func (m *DeviceSession) GetAdrStopped() bool {
+	if m != nil {
+		return m.AdrStopped
+	}
+	return false
+}
+
+func (m *DeviceSession) GetMaxSupportedDrSet() bool {
+	if m != nil {
+		return m.MaxSupportedDrSet
+	}
+	return false
+}
+
+func (m *DeviceSession) GetSkipFCntValidationCount() uint32 {
+	if m != nil {
+		return m.SkipFCntValidationCount
+	}
+	return 0
+}
+
+type DeviceSessionRXInfo struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// RSSI.
+	Rssi int32 `protobuf:"varint,2,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	// LoRa SNR.
+	LoraSnr float64 `protobuf:"fixed64,3,opt,name=lora_snr,json=loraSnr,proto3" json:"lora_snr,omitempty"`
+	// Timestamp when the uplink was received.
+	Time *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Board.
+	Board uint32 `protobuf:"varint,5,opt,name=board,proto3" json:"board,omitempty"`
+	// Antenna.
+	Antenna uint32 `protobuf:"varint,6,opt,name=antenna,proto3" json:"antenna,omitempty"`
+	// Time since GPS epoch, as reported by the gateway.
+	// This is not set when the gateway has no GPS time.
+	TimeSinceGpsEpoch    *duration.Duration `protobuf:"bytes,7,opt,name=time_since_gps_epoch,json=timeSinceGpsEpoch,proto3" json:"time_since_gps_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeviceSessionRXInfo) Reset()         { *m = DeviceSessionRXInfo{} }
+func (m *DeviceSessionRXInfo) String() string { return proto.CompactTextString(m) }
+func (*DeviceSessionRXInfo) ProtoMessage()    {}
+func (*DeviceSessionRXInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{31}
+}
+
+func (m *DeviceSessionRXInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSessionRXInfo.Unmarshal(m, b)
+}
+func (m *DeviceSessionRXInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSessionRXInfo.Marshal(b, m, deterministic)
+}
+func (m *DeviceSessionRXInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSessionRXInfo.Merge(m, src)
+}
+func (m *DeviceSessionRXInfo) XXX_Size() int {
+	return xxx_messageInfo_DeviceSessionRXInfo.Size(m)
+}
+func (m *DeviceSessionRXInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSessionRXInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceSessionRXInfo proto.InternalMessageInfo
+
+func (m *DeviceSessionRXInfo) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *DeviceSessionRXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *DeviceSessionRXInfo) GetLoraSnr() float64 {
+	if m != nil {
+		return m.LoraSnr
+	}
+	return 0
+}
+
+func (m *DeviceSessionRXInfo) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *DeviceSessionRXInfo) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *DeviceSessionRXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *DeviceSessionRXInfo) GetTimeSinceGpsEpoch() *duration.Duration {
+	if m != nil {
+		return m.TimeSinceGpsEpoch
+	}
+	return nil
+}
+
+type DeviceSessionTXInfo struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,3,opt,name=dr,proto3" json:"dr,omitempty"`
+	// TX power (dBm).
+	TxPower int32 `protobuf:"varint,4,opt,name=tx_power,json=txPower,proto3" json:"tx_power,omitempty"`
+	// Timestamp when the downlink was sent.
+	Time *timestamp.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	// Frame-counter used for the downlink.
+	FCnt uint32 `protobuf:"varint,6,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Estimated airtime of the downlink.
+	Airtime              *duration.Duration `protobuf:"bytes,7,opt,name=airtime,proto3" json:"airtime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeviceSessionTXInfo) Reset()         { *m = DeviceSessionTXInfo{} }
+func (m *DeviceSessionTXInfo) String() string { return proto.CompactTextString(m) }
+func (*DeviceSessionTXInfo) ProtoMessage()    {}
+func (*DeviceSessionTXInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{32}
+}
+
+func (m *DeviceSessionTXInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSessionTXInfo.Unmarshal(m, b)
+}
+func (m *DeviceSessionTXInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSessionTXInfo.Marshal(b, m, deterministic)
+}
+func (m *DeviceSessionTXInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSessionTXInfo.Merge(m, src)
+}
+func (m *DeviceSessionTXInfo) XXX_Size() int {
+	return xxx_messageInfo_DeviceSessionTXInfo.Size(m)
+}
+func (m *DeviceSessionTXInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSessionTXInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceSessionTXInfo proto.InternalMessageInfo
+
+func (m *DeviceSessionTXInfo) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *DeviceSessionTXInfo) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *DeviceSessionTXInfo) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *DeviceSessionTXInfo) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *DeviceSessionTXInfo) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *DeviceSessionTXInfo) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *DeviceSessionTXInfo) GetAirtime() *duration.Duration {
+	if m != nil {
+		return m.Airtime
+	}
+	return nil
+}
+
+type GetDeviceSessionRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceSessionRequest) Reset()         { *m = GetDeviceSessionRequest{} }
+func (m *GetDeviceSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceSessionRequest) ProtoMessage()    {}
+func (*GetDeviceSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{33}
+}
+
+func (m *GetDeviceSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceSessionRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceSessionRequest.Merge(m, src)
+}
+func (m *GetDeviceSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceSessionRequest.Size(m)
+}
+func (m *GetDeviceSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceSessionRequest proto.InternalMessageInfo
+
+func (m *GetDeviceSessionRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetDeviceSessionResponse struct {
+	// Device-session object.
+	DeviceSession        *DeviceSession `protobuf:"bytes,1,opt,name=device_session,json=deviceSession,proto3" json:"device_session,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetDeviceSessionResponse) Reset()         { *m = GetDeviceSessionResponse{} }
+func (m *GetDeviceSessionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceSessionResponse) ProtoMessage()    {}
+func (*GetDeviceSessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{34}
+}
+
+func (m *GetDeviceSessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceSessionResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceSessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceSessionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceSessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceSessionResponse.Merge(m, src)
+}
+func (m *GetDeviceSessionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceSessionResponse.Size(m)
+}
+func (m *GetDeviceSessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceSessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceSessionResponse proto.InternalMessageInfo
+
+func (m *GetDeviceSessionResponse) GetDeviceSession() *DeviceSession {
+	if m != nil {
+		return m.DeviceSession
+	}
+	return nil
+}
+
+type UpdateDeviceSessionInstallationMarginRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// ADR installation margin (dB).
+	// Set to 0 to use the global installation margin.
+	InstallationMargin   float64  `protobuf:"fixed64,2,opt,name=installation_margin,json=installationMargin,proto3" json:"installation_margin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateDeviceSessionInstallationMarginRequest) Reset() {
+	*m = UpdateDeviceSessionInstallationMarginRequest{}
+}
+func (m *UpdateDeviceSessionInstallationMarginRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*UpdateDeviceSessionInstallationMarginRequest) ProtoMessage() {}
+func (*UpdateDeviceSessionInstallationMarginRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{35}
+}
+
+func (m *UpdateDeviceSessionInstallationMarginRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDeviceSessionInstallationMarginRequest.Unmarshal(m, b)
+}
+func (m *UpdateDeviceSessionInstallationMarginRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDeviceSessionInstallationMarginRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateDeviceSessionInstallationMarginRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDeviceSessionInstallationMarginRequest.Merge(m, src)
+}
+func (m *UpdateDeviceSessionInstallationMarginRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateDeviceSessionInstallationMarginRequest.Size(m)
+}
+func (m *UpdateDeviceSessionInstallationMarginRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDeviceSessionInstallationMarginRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDeviceSessionInstallationMarginRequest proto.InternalMessageInfo
+
+func (m *UpdateDeviceSessionInstallationMarginRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *UpdateDeviceSessionInstallationMarginRequest) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+type UpdateDeviceSessionRXWindowRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// RX window preference.
+	RxWindowPreference   RXWindowPreference `protobuf:"varint,2,opt,name=rx_window_preference,json=rxWindowPreference,proto3,enum=ns.RXWindowPreference" json:"rx_window_preference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UpdateDeviceSessionRXWindowRequest) Reset()         { *m = UpdateDeviceSessionRXWindowRequest{} }
+func (m *UpdateDeviceSessionRXWindowRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDeviceSessionRXWindowRequest) ProtoMessage()    {}
+func (*UpdateDeviceSessionRXWindowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{36}
+}
+
+func (m *UpdateDeviceSessionRXWindowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDeviceSessionRXWindowRequest.Unmarshal(m, b)
+}
+func (m *UpdateDeviceSessionRXWindowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDeviceSessionRXWindowRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateDeviceSessionRXWindowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDeviceSessionRXWindowRequest.Merge(m, src)
+}
+func (m *UpdateDeviceSessionRXWindowRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateDeviceSessionRXWindowRequest.Size(m)
+}
+func (m *UpdateDeviceSessionRXWindowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDeviceSessionRXWindowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDeviceSessionRXWindowRequest proto.InternalMessageInfo
+
+func (m *UpdateDeviceSessionRXWindowRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *UpdateDeviceSessionRXWindowRequest) GetRxWindowPreference() RXWindowPreference {
+	if m != nil {
+		return m.RxWindowPreference
+	}
+	return RXWindowPreference_RX_WINDOW_AUTO
+}
+
+type UplinkHistoryItem struct {
+	// Uplink frame-counter.
+	FCnt uint32 `protobuf:"varint,1,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Max SNR (of deduplicated frames received by one or multiple gateways).
+	MaxSnr float64 `protobuf:"fixed64,2,opt,name=max_snr,json=maxSnr,proto3" json:"max_snr,omitempty"`
+	// TX power index (as known by the network-server).
+	TxPowerIndex uint32 `protobuf:"varint,3,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Number of receiving gateways.
+	GatewayCount         uint32   `protobuf:"varint,4,opt,name=gateway_count,json=gatewayCount,proto3" json:"gateway_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UplinkHistoryItem) Reset()         { *m = UplinkHistoryItem{} }
+func (m *UplinkHistoryItem) String() string { return proto.CompactTextString(m) }
+func (*UplinkHistoryItem) ProtoMessage()    {}
+func (*UplinkHistoryItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{37}
+}
+
+func (m *UplinkHistoryItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UplinkHistoryItem.Unmarshal(m, b)
+}
+func (m *UplinkHistoryItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UplinkHistoryItem.Marshal(b, m, deterministic)
+}
+func (m *UplinkHistoryItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UplinkHistoryItem.Merge(m, src)
+}
+func (m *UplinkHistoryItem) XXX_Size() int {
+	return xxx_messageInfo_UplinkHistoryItem.Size(m)
+}
+func (m *UplinkHistoryItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_UplinkHistoryItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UplinkHistoryItem proto.InternalMessageInfo
+
+func (m *UplinkHistoryItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *UplinkHistoryItem) GetMaxSnr() float64 {
+	if m != nil {
+		return m.MaxSnr
+	}
+	return 0
+}
+
+func (m *UplinkHistoryItem) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *UplinkHistoryItem) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+type ADRDecision struct {
+	// Requested data-rate.
+	Dr uint32 `protobuf:"varint,1,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Requested TX power index.
+	TxPowerIndex uint32 `protobuf:"varint,2,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Requested number of transmissions.
+	NbTrans uint32 `protobuf:"varint,3,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// The device acknowledged the request (positive LinkADRAns).
+	Ack                  bool     `protobuf:"varint,4,opt,name=ack,proto3" json:"ack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ADRDecision) Reset()         { *m = ADRDecision{} }
+func (m *ADRDecision) String() string { return proto.CompactTextString(m) }
+func (*ADRDecision) ProtoMessage()    {}
+func (*ADRDecision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{38}
+}
+
+func (m *ADRDecision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ADRDecision.Unmarshal(m, b)
+}
+func (m *ADRDecision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ADRDecision.Marshal(b, m, deterministic)
+}
+func (m *ADRDecision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ADRDecision.Merge(m, src)
+}
+func (m *ADRDecision) XXX_Size() int {
+	return xxx_messageInfo_ADRDecision.Size(m)
+}
+func (m *ADRDecision) XXX_DiscardUnknown() {
+	xxx_messageInfo_ADRDecision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ADRDecision proto.InternalMessageInfo
+
+func (m *ADRDecision) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetAck() bool {
+	if m != nil {
+		return m.Ack
+	}
+	return false
+}
+
+type GetDeviceLinkMetricsRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceLinkMetricsRequest) Reset()         { *m = GetDeviceLinkMetricsRequest{} }
+func (m *GetDeviceLinkMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceLinkMetricsRequest) ProtoMessage()    {}
+func (*GetDeviceLinkMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{39}
+}
+
+func (m *GetDeviceLinkMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceLinkMetricsRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceLinkMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceLinkMetricsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceLinkMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceLinkMetricsRequest.Merge(m, src)
+}
+func (m *GetDeviceLinkMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceLinkMetricsRequest.Size(m)
+}
+func (m *GetDeviceLinkMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceLinkMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceLinkMetricsRequest proto.InternalMessageInfo
+
+func (m *GetDeviceLinkMetricsRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetDeviceLinkMetricsResponse struct {
+	// ADR enabled by the device.
+	Adr bool `protobuf:"varint,1,opt,name=adr,proto3" json:"adr,omitempty"`
+	// Uplink history (oldest first), as used by the ADR engine.
+	UplinkHistory []*UplinkHistoryItem `protobuf:"bytes,2,rep,name=uplink_history,json=uplinkHistory,proto3" json:"uplink_history,omitempty"`
+	// Packet-loss percentage based on the uplink history.
+	PacketLossPercentage float64 `protobuf:"fixed64,3,opt,name=packet_loss_percentage,json=packetLossPercentage,proto3" json:"packet_loss_percentage,omitempty"`
+	// Last ADR decision sent to the device (LinkADRReq) and its answer.
+	// This is not set when no LinkADRReq has been answered yet.
+	LastAdrDecision      *ADRDecision `protobuf:"bytes,4,opt,name=last_adr_decision,json=lastAdrDecision,proto3" json:"last_adr_decision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetDeviceLinkMetricsResponse) Reset()         { *m = GetDeviceLinkMetricsResponse{} }
+func (m *GetDeviceLinkMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceLinkMetricsResponse) ProtoMessage()    {}
+func (*GetDeviceLinkMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{40}
+}
+
+func (m *GetDeviceLinkMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceLinkMetricsResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceLinkMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceLinkMetricsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceLinkMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceLinkMetricsResponse.Merge(m, src)
+}
+func (m *GetDeviceLinkMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceLinkMetricsResponse.Size(m)
+}
+func (m *GetDeviceLinkMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceLinkMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceLinkMetricsResponse proto.InternalMessageInfo
+
+func (m *GetDeviceLinkMetricsResponse) GetAdr() bool {
+	if m != nil {
+		return m.Adr
+	}
+	return false
+}
+
+func (m *GetDeviceLinkMetricsResponse) GetUplinkHistory() []*UplinkHistoryItem {
+	if m != nil {
+		return m.UplinkHistory
+	}
+	return nil
+}
+
+func (m *GetDeviceLinkMetricsResponse) GetPacketLossPercentage() float64 {
+	if m != nil {
+		return m.PacketLossPercentage
+	}
+	return 0
+}
+
+func (m *GetDeviceLinkMetricsResponse) GetLastAdrDecision() *ADRDecision {
+	if m != nil {
+		return m.LastAdrDecision
+	}
+	return nil
+}
+
+type GetDeviceStatusRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceStatusRequest) Reset()         { *m = GetDeviceStatusRequest{} }
+func (m *GetDeviceStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceStatusRequest) ProtoMessage()    {}
+func (*GetDeviceStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{41}
+}
+
+func (m *GetDeviceStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceStatusRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceStatusRequest.Merge(m, src)
+}
+func (m *GetDeviceStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceStatusRequest.Size(m)
+}
+func (m *GetDeviceStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceStatusRequest proto.InternalMessageInfo
+
+func (m *GetDeviceStatusRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetDeviceStatusResponse struct {
+	// Device-status is available.
+	// This is false when the device never answered a DevStatusReq.
+	Available bool `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	// Battery level, as reported by the device.
+	// 0 = external power source, 1 - 254 = battery level, 255 = unknown.
+	Battery uint32 `protobuf:"varint,2,opt,name=battery,proto3" json:"battery,omitempty"`
+	// Device is connected to an external power source (battery = 0).
+	ExternalPowerSource bool `protobuf:"varint,3,opt,name=external_power_source,json=externalPowerSource,proto3" json:"external_power_source,omitempty"`
+	// Battery level could not be measured by the device (battery = 255).
+	BatteryLevelUnavailable bool `protobuf:"varint,4,opt,name=battery_level_unavailable,json=batteryLevelUnavailable,proto3" json:"battery_level_unavailable,omitempty"`
+	// Demodulation signal-to-noise ratio margin (dB).
+	Margin int32 `protobuf:"varint,5,opt,name=margin,proto3" json:"margin,omitempty"`
+	// Timestamp when the device-status was received.
+	ReceivedAt           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetDeviceStatusResponse) Reset()         { *m = GetDeviceStatusResponse{} }
+func (m *GetDeviceStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceStatusResponse) ProtoMessage()    {}
+func (*GetDeviceStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{42}
+}
+
+func (m *GetDeviceStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceStatusResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceStatusResponse.Merge(m, src)
+}
+func (m *GetDeviceStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceStatusResponse.Size(m)
+}
+func (m *GetDeviceStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceStatusResponse proto.InternalMessageInfo
+
+func (m *GetDeviceStatusResponse) GetAvailable() bool {
+	if m != nil {
+		return m.Available
+	}
+	return false
+}
+
+func (m *GetDeviceStatusResponse) GetBattery() uint32 {
+	if m != nil {
+		return m.Battery
+	}
+	return 0
+}
+
+func (m *GetDeviceStatusResponse) GetExternalPowerSource() bool {
+	if m != nil {
+		return m.ExternalPowerSource
+	}
+	return false
+}
+
+func (m *GetDeviceStatusResponse) GetBatteryLevelUnavailable() bool {
+	if m != nil {
+		return m.BatteryLevelUnavailable
+	}
+	return false
+}
+
+func (m *GetDeviceStatusResponse) GetMargin() int32 {
+	if m != nil {
+		return m.Margin
+	}
+	return 0
+}
+
+func (m *GetDeviceStatusResponse) GetReceivedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return nil
+}
+
+type GetDeviceLocationRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceLocationRequest) Reset()         { *m = GetDeviceLocationRequest{} }
+func (m *GetDeviceLocationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceLocationRequest) ProtoMessage()    {}
+func (*GetDeviceLocationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{43}
+}
+
+func (m *GetDeviceLocationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceLocationRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceLocationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceLocationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceLocationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceLocationRequest.Merge(m, src)
+}
+func (m *GetDeviceLocationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceLocationRequest.Size(m)
+}
+func (m *GetDeviceLocationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceLocationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceLocationRequest proto.InternalMessageInfo
+
+func (m *GetDeviceLocationRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetDeviceLocationResponse struct {
+	// Device location is available.
+	// This is false when the location of the device was never resolved.
+	Available bool `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	// Resolved location.
+	Location *common.Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// Timestamp when the location was resolved.
+	ResolvedAt           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetDeviceLocationResponse) Reset()         { *m = GetDeviceLocationResponse{} }
+func (m *GetDeviceLocationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceLocationResponse) ProtoMessage()    {}
+func (*GetDeviceLocationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{44}
+}
+
+func (m *GetDeviceLocationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceLocationResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceLocationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceLocationResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceLocationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceLocationResponse.Merge(m, src)
+}
+func (m *GetDeviceLocationResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceLocationResponse.Size(m)
+}
+func (m *GetDeviceLocationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceLocationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceLocationResponse proto.InternalMessageInfo
+
+func (m *GetDeviceLocationResponse) GetAvailable() bool {
+	if m != nil {
+		return m.Available
+	}
+	return false
+}
+
+func (m *GetDeviceLocationResponse) GetLocation() *common.Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *GetDeviceLocationResponse) GetResolvedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ResolvedAt
+	}
+	return nil
+}
+
+type GetDeviceSessionsForDevAddrRequest struct {
+	// Device address (DevAddr).
+	DevAddr              []byte   `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceSessionsForDevAddrRequest) Reset()         { *m = GetDeviceSessionsForDevAddrRequest{} }
+func (m *GetDeviceSessionsForDevAddrRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceSessionsForDevAddrRequest) ProtoMessage()    {}
+func (*GetDeviceSessionsForDevAddrRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{45}
+}
+
+func (m *GetDeviceSessionsForDevAddrRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceSessionsForDevAddrRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceSessionsForDevAddrRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceSessionsForDevAddrRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceSessionsForDevAddrRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceSessionsForDevAddrRequest.Merge(m, src)
+}
+func (m *GetDeviceSessionsForDevAddrRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceSessionsForDevAddrRequest.Size(m)
+}
+func (m *GetDeviceSessionsForDevAddrRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceSessionsForDevAddrRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceSessionsForDevAddrRequest proto.InternalMessageInfo
+
+func (m *GetDeviceSessionsForDevAddrRequest) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+type GetDeviceSessionsForDevAddrResponse struct {
+	// Device-sessions using the given DevAddr.
+	DeviceSessions       []*DeviceSession `protobuf:"bytes,1,rep,name=device_sessions,json=deviceSessions,proto3" json:"device_sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetDeviceSessionsForDevAddrResponse) Reset()         { *m = GetDeviceSessionsForDevAddrResponse{} }
+func (m *GetDeviceSessionsForDevAddrResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceSessionsForDevAddrResponse) ProtoMessage()    {}
+func (*GetDeviceSessionsForDevAddrResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{46}
+}
+
+func (m *GetDeviceSessionsForDevAddrResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceSessionsForDevAddrResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceSessionsForDevAddrResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceSessionsForDevAddrResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceSessionsForDevAddrResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceSessionsForDevAddrResponse.Merge(m, src)
+}
+func (m *GetDeviceSessionsForDevAddrResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceSessionsForDevAddrResponse.Size(m)
+}
+func (m *GetDeviceSessionsForDevAddrResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceSessionsForDevAddrResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceSessionsForDevAddrResponse proto.InternalMessageInfo
+
+func (m *GetDeviceSessionsForDevAddrResponse) GetDeviceSessions() []*DeviceSession {
+	if m != nil {
+		return m.DeviceSessions
+	}
+	return nil
+}
+
+type GetRandomDevAddrResponse struct {
+	// Random device address (DevAddr).
+	// Note that this includes the NetID prefix of the network-server.
+	DevAddr              []byte   `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrResponse{} }
+func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRandomDevAddrResponse) ProtoMessage()    {}
+func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{47}
+}
+
+func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRandomDevAddrResponse.Unmarshal(m, b)
+}
+func (m *GetRandomDevAddrResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRandomDevAddrResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRandomDevAddrResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRandomDevAddrResponse.Merge(m, src)
+}
+func (m *GetRandomDevAddrResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRandomDevAddrResponse.Size(m)
+}
+func (m *GetRandomDevAddrResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRandomDevAddrResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRandomDevAddrResponse proto.InternalMessageInfo
+
+func (m *GetRandomDevAddrResponse) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+type CreateMACCommandQueueItemRequest struct {
+	// DevEUI EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Command identifier (specified by the LoRaWAN specs).
+	Cid uint32 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
+	// MAC-command(s). In case multiple payloads are defined, then they
+	// are always sent within a single frame.
+	Commands             [][]byte `protobuf:"bytes,5,rep,name=commands,proto3" json:"commands,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateMACCommandQueueItemRequest) Reset()         { *m = CreateMACCommandQueueItemRequest{} }
+func (m *CreateMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateMACCommandQueueItemRequest) ProtoMessage()    {}
+func (*CreateMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{48}
+}
+
+func (m *CreateMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Unmarshal(m, b)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMACCommandQueueItemRequest.Merge(m, src)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Size(m)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMACCommandQueueItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateMACCommandQueueItemRequest proto.InternalMessageInfo
+
+func (m *CreateMACCommandQueueItemRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *CreateMACCommandQueueItemRequest) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+func (m *CreateMACCommandQueueItemRequest) GetCommands() [][]byte {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+type MACCommandQueueItem struct {
+	// Command identifier (specified by the LoRaWAN specs).
+	Cid uint32 `protobuf:"varint,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// The mac-command(s) were enqueued by an external service.
+	External bool `protobuf:"varint,2,opt,name=external,proto3" json:"external,omitempty"`
+	// MAC-command(s) (marshaled).
+	Commands [][]byte `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`
+	// Number of times the mac-command(s) were re-queued because no answer
+	// was received.
+	RetryCount           uint32   `protobuf:"varint,4,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MACCommandQueueItem) Reset()         { *m = MACCommandQueueItem{} }
+func (m *MACCommandQueueItem) String() string { return proto.CompactTextString(m) }
+func (*MACCommandQueueItem) ProtoMessage()    {}
+func (*MACCommandQueueItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{49}
+}
+
+func (m *MACCommandQueueItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MACCommandQueueItem.Unmarshal(m, b)
+}
+func (m *MACCommandQueueItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MACCommandQueueItem.Marshal(b, m, deterministic)
+}
+func (m *MACCommandQueueItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MACCommandQueueItem.Merge(m, src)
+}
+func (m *MACCommandQueueItem) XXX_Size() int {
+	return xxx_messageInfo_MACCommandQueueItem.Size(m)
+}
+func (m *MACCommandQueueItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_MACCommandQueueItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MACCommandQueueItem proto.InternalMessageInfo
+
+func (m *MACCommandQueueItem) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+func (m *MACCommandQueueItem) GetExternal() bool {
+	if m != nil {
+		return m.External
+	}
+	return false
+}
+
+func (m *MACCommandQueueItem) GetCommands() [][]byte {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+func (m *MACCommandQueueItem) GetRetryCount() uint32 {
+	if m != nil {
+		return m.RetryCount
+	}
+	return 0
+}
+
+type GetMACCommandQueueItemsRequest struct {
+	// DevEUI EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMACCommandQueueItemsRequest) Reset()         { *m = GetMACCommandQueueItemsRequest{} }
+func (m *GetMACCommandQueueItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMACCommandQueueItemsRequest) ProtoMessage()    {}
+func (*GetMACCommandQueueItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{50}
+}
+
+func (m *GetMACCommandQueueItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMACCommandQueueItemsRequest.Unmarshal(m, b)
+}
+func (m *GetMACCommandQueueItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMACCommandQueueItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMACCommandQueueItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMACCommandQueueItemsRequest.Merge(m, src)
+}
+func (m *GetMACCommandQueueItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMACCommandQueueItemsRequest.Size(m)
+}
+func (m *GetMACCommandQueueItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMACCommandQueueItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMACCommandQueueItemsRequest proto.InternalMessageInfo
+
+func (m *GetMACCommandQueueItemsRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetMACCommandQueueItemsResponse struct {
+	// MAC-command queue items.
+	Items []*MACCommandQueueItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// MAC-command(s) that were sent, but for which no answer has been
+	// received yet.
+	PendingItems         []*MACCommandQueueItem `protobuf:"bytes,2,rep,name=pending_items,json=pendingItems,proto3" json:"pending_items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetMACCommandQueueItemsResponse) Reset()         { *m = GetMACCommandQueueItemsResponse{} }
+func (m *GetMACCommandQueueItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMACCommandQueueItemsResponse) ProtoMessage()    {}
+func (*GetMACCommandQueueItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{51}
+}
+
+func (m *GetMACCommandQueueItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMACCommandQueueItemsResponse.Unmarshal(m, b)
+}
+func (m *GetMACCommandQueueItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMACCommandQueueItemsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMACCommandQueueItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMACCommandQueueItemsResponse.Merge(m, src)
+}
+func (m *GetMACCommandQueueItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMACCommandQueueItemsResponse.Size(m)
+}
+func (m *GetMACCommandQueueItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMACCommandQueueItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMACCommandQueueItemsResponse proto.InternalMessageInfo
+
+func (m *GetMACCommandQueueItemsResponse) GetItems() []*MACCommandQueueItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *GetMACCommandQueueItemsResponse) GetPendingItems() []*MACCommandQueueItem {
+	if m != nil {
+		return m.PendingItems
+	}
+	return nil
+}
+
+type DeleteMACCommandQueueItemRequest struct {
+	// DevEUI EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Command identifier (specified by the LoRaWAN specs).
+	Cid                  uint32   `protobuf:"varint,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMACCommandQueueItemRequest) Reset()         { *m = DeleteMACCommandQueueItemRequest{} }
+func (m *DeleteMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMACCommandQueueItemRequest) ProtoMessage()    {}
+func (*DeleteMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{52}
+}
+
+func (m *DeleteMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMACCommandQueueItemRequest.Unmarshal(m, b)
+}
+func (m *DeleteMACCommandQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMACCommandQueueItemRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteMACCommandQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMACCommandQueueItemRequest.Merge(m, src)
+}
+func (m *DeleteMACCommandQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMACCommandQueueItemRequest.Size(m)
+}
+func (m *DeleteMACCommandQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMACCommandQueueItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMACCommandQueueItemRequest proto.InternalMessageInfo
+
+func (m *DeleteMACCommandQueueItemRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *DeleteMACCommandQueueItemRequest) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+type SendProprietaryPayloadRequest struct {
+	// MACPayload of the proprietary LoRaWAN frame.
+	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
+	// MIC of the proprietary LoRaWAN frame (must be 4 bytes).
+	Mic []byte `protobuf:"bytes,2,opt,name=mic,proto3" json:"mic,omitempty"`
+	// Gateway MAC address(es) to use for transmitting the LoRaWAN frame.
+	GatewayMacs [][]byte `protobuf:"bytes,3,rep,name=gateway_macs,json=gatewayMacs,proto3" json:"gateway_macs,omitempty"`
+	// Set to true for sending as a gateway, or false for sending as a node.
+	// In the latter case the frame will be received by other gateways.
+	PolarizationInversion bool `protobuf:"varint,4,opt,name=polarization_inversion,json=polarizationInversion,proto3" json:"polarization_inversion,omitempty"`
+	// Frequency (Hz) to use for the transmission.
+	Frequency uint32 `protobuf:"varint,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Data-rate to use for the transmission.
+	Dr                   uint32   `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendProprietaryPayloadRequest) Reset()         { *m = SendProprietaryPayloadRequest{} }
+func (m *SendProprietaryPayloadRequest) String() string { return proto.CompactTextString(m) }
+func (*SendProprietaryPayloadRequest) ProtoMessage()    {}
+func (*SendProprietaryPayloadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{53}
+}
+
+func (m *SendProprietaryPayloadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendProprietaryPayloadRequest.Unmarshal(m, b)
+}
+func (m *SendProprietaryPayloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendProprietaryPayloadRequest.Marshal(b, m, deterministic)
+}
+func (m *SendProprietaryPayloadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendProprietaryPayloadRequest.Merge(m, src)
+}
+func (m *SendProprietaryPayloadRequest) XXX_Size() int {
+	return xxx_messageInfo_SendProprietaryPayloadRequest.Size(m)
+}
+func (m *SendProprietaryPayloadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendProprietaryPayloadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendProprietaryPayloadRequest proto.InternalMessageInfo
+
+func (m *SendProprietaryPayloadRequest) GetMacPayload() []byte {
+	if m != nil {
+		return m.MacPayload
+	}
+	return nil
+}
+
+func (m *SendProprietaryPayloadRequest) GetMic() []byte {
+	if m != nil {
+		return m.Mic
+	}
+	return nil
+}
+
+func (m *SendProprietaryPayloadRequest) GetGatewayMacs() [][]byte {
+	if m != nil {
+		return m.GatewayMacs
+	}
+	return nil
+}
+
+func (m *SendProprietaryPayloadRequest) GetPolarizationInversion() bool {
+	if m != nil {
+		return m.PolarizationInversion
+	}
+	return false
+}
+
+func (m *SendProprietaryPayloadRequest) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *SendProprietaryPayloadRequest) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+type Gateway struct {
 	// Gateway ID (8 bytes EUI64).
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// Gateway location.
@@ -1673,526 +3405,1509 @@ type Gateway struct {
 	// This is (currently) only needed when the gateway supports the fine-timestamp
 	// and you you would like to add the FPGA ID to the gateway meta-data or would
 	// like LoRa Server to decrypt the fine-timestamp.
-	Boards               []*GatewayBoard `protobuf:"bytes,4,rep,name=boards,proto3" json:"boards,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Boards []*GatewayBoard `protobuf:"bytes,4,rep,name=boards,proto3" json:"boards,omitempty"`
+	// Gateway is in maintenance mode.
+	// A gateway in maintenance mode is only used for downlink transmissions
+	// when no other gateway received the uplink of the device.
+	MaintenanceMode bool `protobuf:"varint,5,opt,name=maintenance_mode,json=maintenanceMode,proto3" json:"maintenance_mode,omitempty"`
+	// Gateway tags (key / value metadata).
+	// Keys must not exceed 64 bytes and a gateway must not have more
+	// than 32 tags.
+	Tags map[string]string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// RSSI calibration offset (dB).
+	// This offset is added to the RSSI reported by the gateway.
+	RssiOffset float64 `protobuf:"fixed64,7,opt,name=rssi_offset,json=rssiOffset,proto3" json:"rssi_offset,omitempty"`
+	// SNR calibration offset (dB).
+	// This offset is added to the LoRa SNR reported by the gateway.
+	SnrOffset float64 `protobuf:"fixed64,8,opt,name=snr_offset,json=snrOffset,proto3" json:"snr_offset,omitempty"`
+	// Update the location from the GPS location in the gateway stats.
+	// When set, the location source is GPS once it has been updated from
+	// the stats.
+	UpdateLocationFromStats bool `protobuf:"varint,9,opt,name=update_location_from_stats,json=updateLocationFromStats,proto3" json:"update_location_from_stats,omitempty"`
+	// Gateway discovery enabled.
+	// When set, the gateway periodically transmits a discovery ping.
+	DiscoveryEnabled bool `protobuf:"varint,10,opt,name=discovery_enabled,json=discoveryEnabled,proto3" json:"discovery_enabled,omitempty"`
+	// Antenna gain (dBi).
+	// The downlink TX power is the max EIRP of the band minus this gain.
+	AntennaGain float64 `protobuf:"fixed64,11,opt,name=antenna_gain,json=antennaGain,proto3" json:"antenna_gain,omitempty"`
+	// Max downlink EIRP (dBm).
+	// When set and lower than the max EIRP of the band, this is used as
+	// the max EIRP for downlinks through this gateway (0 = band default).
+	MaxTxPower           int32    `protobuf:"varint,12,opt,name=max_tx_power,json=maxTxPower,proto3" json:"max_tx_power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Gateway) Reset()         { *m = Gateway{} }
+func (m *Gateway) String() string { return proto.CompactTextString(m) }
+func (*Gateway) ProtoMessage()    {}
+func (*Gateway) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{54}
+}
+
+func (m *Gateway) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Gateway.Unmarshal(m, b)
+}
+func (m *Gateway) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Gateway.Marshal(b, m, deterministic)
+}
+func (m *Gateway) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Gateway.Merge(m, src)
+}
+func (m *Gateway) XXX_Size() int {
+	return xxx_messageInfo_Gateway.Size(m)
+}
+func (m *Gateway) XXX_DiscardUnknown() {
+	xxx_messageInfo_Gateway.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Gateway proto.InternalMessageInfo
+
+func (m *Gateway) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Gateway) GetLocation() *common.Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *Gateway) GetGatewayProfileId() []byte {
+	if m != nil {
+		return m.GatewayProfileId
+	}
+	return nil
+}
+
+func (m *Gateway) GetBoards() []*GatewayBoard {
+	if m != nil {
+		return m.Boards
+	}
+	return nil
+}
+
+func (m *Gateway) GetMaintenanceMode() bool {
+	if m != nil {
+		return m.MaintenanceMode
+	}
+	return false
+}
+
+func (m *Gateway) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Gateway) GetRssiOffset() float64 {
+	if m != nil {
+		return m.RssiOffset
+	}
+	return 0
+}
+
+func (m *Gateway) GetSnrOffset() float64 {
+	if m != nil {
+		return m.SnrOffset
+	}
+	return 0
+}
+
+func (m *Gateway) GetUpdateLocationFromStats() bool {
+	if m != nil {
+		return m.UpdateLocationFromStats
+	}
+	return false
+}
+
+func (m *Gateway) GetDiscoveryEnabled() bool {
+	if m != nil {
+		return m.DiscoveryEnabled
+	}
+	return false
+}
+
+func (m *Gateway) GetAntennaGain() float64 {
+	if m != nil {
+		return m.AntennaGain
+	}
+	return 0
+}
+
+func (m *Gateway) GetMaxTxPower() int32 {
+	if m != nil {
+		return m.MaxTxPower
+	}
+	return 0
+}
+
+type GatewayBoard struct {
+	// FPGA ID of the gateway (8 bytes) (optional).
+	FpgaId []byte `protobuf:"bytes,1,opt,name=fpga_id,json=fpgaId,proto3" json:"fpga_id,omitempty"`
+	// Fine-timestamp AES decryption key (16 bytes) (optional).
+	FineTimestampKey     []byte   `protobuf:"bytes,2,opt,name=fine_timestamp_key,json=fineTimestampKey,proto3" json:"fine_timestamp_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayBoard) Reset()         { *m = GatewayBoard{} }
+func (m *GatewayBoard) String() string { return proto.CompactTextString(m) }
+func (*GatewayBoard) ProtoMessage()    {}
+func (*GatewayBoard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{55}
+}
+
+func (m *GatewayBoard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayBoard.Unmarshal(m, b)
+}
+func (m *GatewayBoard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayBoard.Marshal(b, m, deterministic)
+}
+func (m *GatewayBoard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayBoard.Merge(m, src)
+}
+func (m *GatewayBoard) XXX_Size() int {
+	return xxx_messageInfo_GatewayBoard.Size(m)
+}
+func (m *GatewayBoard) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayBoard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayBoard proto.InternalMessageInfo
+
+func (m *GatewayBoard) GetFpgaId() []byte {
+	if m != nil {
+		return m.FpgaId
+	}
+	return nil
+}
+
+func (m *GatewayBoard) GetFineTimestampKey() []byte {
+	if m != nil {
+		return m.FineTimestampKey
+	}
+	return nil
+}
+
+type CreateGatewayRequest struct {
+	// Gateway object to create.
+	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateGatewayRequest) Reset()         { *m = CreateGatewayRequest{} }
+func (m *CreateGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGatewayRequest) ProtoMessage()    {}
+func (*CreateGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{56}
+}
+
+func (m *CreateGatewayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGatewayRequest.Unmarshal(m, b)
+}
+func (m *CreateGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGatewayRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateGatewayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGatewayRequest.Merge(m, src)
+}
+func (m *CreateGatewayRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateGatewayRequest.Size(m)
+}
+func (m *CreateGatewayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGatewayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGatewayRequest proto.InternalMessageInfo
+
+func (m *CreateGatewayRequest) GetGateway() *Gateway {
+	if m != nil {
+		return m.Gateway
+	}
+	return nil
+}
+
+type GetGatewayRequest struct {
+	// Gateway ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayRequest) Reset()         { *m = GetGatewayRequest{} }
+func (m *GetGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayRequest) ProtoMessage()    {}
+func (*GetGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{57}
+}
+
+func (m *GetGatewayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayRequest.Merge(m, src)
+}
+func (m *GetGatewayRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayRequest.Size(m)
+}
+func (m *GetGatewayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayRequest proto.InternalMessageInfo
+
+func (m *GetGatewayRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetGatewayResponse struct {
+	// Gateway object.
+	Gateway *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// First seen timestamp.
+	FirstSeenAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	// Last seen timestamp.
+	LastSeenAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// Duty-cycle budgets.
+	// This is only set when the duty-cycle accounting is enabled and
+	// supported by the configured band.
+	DutyCycleBudgets []*GatewayDutyCycleBudget `protobuf:"bytes,6,rep,name=duty_cycle_budgets,json=dutyCycleBudgets,proto3" json:"duty_cycle_budgets,omitempty"`
+	// Gateway is online.
+	// A gateway is marked offline when no stats were received within the
+	// configured offline detection threshold.
+	Online bool `protobuf:"varint,7,opt,name=online,proto3" json:"online,omitempty"`
+	// Gateway was automatically created on its first stats.
+	// This flag is cleared when the gateway is updated.
+	AutoCreated bool `protobuf:"varint,8,opt,name=auto_created,json=autoCreated,proto3" json:"auto_created,omitempty"`
+	// Last location update (from GPS or set through the API).
+	// The source of the location is set in gateway.location.source.
+	LocationUpdatedAt *timestamp.Timestamp `protobuf:"bytes,9,opt,name=location_updated_at,json=locationUpdatedAt,proto3" json:"location_updated_at,omitempty"`
+	// Configuration version last reported by the gateway.
+	ConfigVersion string `protobuf:"bytes,10,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+	// Configuration version of the gateway-profile. When this does not
+	// match the config_version, the gateway did not (yet) apply the
+	// gateway-profile configuration.
+	ExpectedConfigVersion string `protobuf:"bytes,11,opt,name=expected_config_version,json=expectedConfigVersion,proto3" json:"expected_config_version,omitempty"`
+	// Effective downlink TX power (dBm) at the RX2 frequency of the band,
+	// taking the max TX power and antenna gain of the gateway into account.
+	DownlinkTxPower      int32    `protobuf:"varint,12,opt,name=downlink_tx_power,json=downlinkTxPower,proto3" json:"downlink_tx_power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayResponse) Reset()         { *m = GetGatewayResponse{} }
+func (m *GetGatewayResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayResponse) ProtoMessage()    {}
+func (*GetGatewayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{58}
+}
+
+func (m *GetGatewayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayResponse.Merge(m, src)
+}
+func (m *GetGatewayResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayResponse.Size(m)
+}
+func (m *GetGatewayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayResponse proto.InternalMessageInfo
+
+func (m *GetGatewayResponse) GetGateway() *Gateway {
+	if m != nil {
+		return m.Gateway
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetFirstSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstSeenAt
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetLastSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetDutyCycleBudgets() []*GatewayDutyCycleBudget {
+	if m != nil {
+		return m.DutyCycleBudgets
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+func (m *GetGatewayResponse) GetAutoCreated() bool {
+	if m != nil {
+		return m.AutoCreated
+	}
+	return false
+}
+
+func (m *GetGatewayResponse) GetLocationUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LocationUpdatedAt
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetConfigVersion() string {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetExpectedConfigVersion() string {
+	if m != nil {
+		return m.ExpectedConfigVersion
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetDownlinkTxPower() int32 {
+	if m != nil {
+		return m.DownlinkTxPower
+	}
+	return 0
+}
+
+type GatewayDutyCycleBudget struct {
+	// Sub-band name.
+	SubBand string `protobuf:"bytes,1,opt,name=sub_band,json=subBand,proto3" json:"sub_band,omitempty"`
+	// Min frequency (Hz).
+	MinFrequency uint32 `protobuf:"varint,2,opt,name=min_frequency,json=minFrequency,proto3" json:"min_frequency,omitempty"`
+	// Max frequency (Hz).
+	MaxFrequency uint32 `protobuf:"varint,3,opt,name=max_frequency,json=maxFrequency,proto3" json:"max_frequency,omitempty"`
+	// Duty-cycle (e.g. 0.01 for 1%).
+	DutyCycle float32 `protobuf:"fixed32,4,opt,name=duty_cycle,json=dutyCycle,proto3" json:"duty_cycle,omitempty"`
+	// Used airtime within the sliding window (1 hour).
+	UsedAirtime *duration.Duration `protobuf:"bytes,5,opt,name=used_airtime,json=usedAirtime,proto3" json:"used_airtime,omitempty"`
+	// Remaining airtime within the sliding window (1 hour).
+	RemainingAirtime     *duration.Duration `protobuf:"bytes,6,opt,name=remaining_airtime,json=remainingAirtime,proto3" json:"remaining_airtime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GatewayDutyCycleBudget) Reset()         { *m = GatewayDutyCycleBudget{} }
+func (m *GatewayDutyCycleBudget) String() string { return proto.CompactTextString(m) }
+func (*GatewayDutyCycleBudget) ProtoMessage()    {}
+func (*GatewayDutyCycleBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{59}
+}
+
+func (m *GatewayDutyCycleBudget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayDutyCycleBudget.Unmarshal(m, b)
+}
+func (m *GatewayDutyCycleBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayDutyCycleBudget.Marshal(b, m, deterministic)
+}
+func (m *GatewayDutyCycleBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayDutyCycleBudget.Merge(m, src)
+}
+func (m *GatewayDutyCycleBudget) XXX_Size() int {
+	return xxx_messageInfo_GatewayDutyCycleBudget.Size(m)
+}
+func (m *GatewayDutyCycleBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayDutyCycleBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayDutyCycleBudget proto.InternalMessageInfo
+
+func (m *GatewayDutyCycleBudget) GetSubBand() string {
+	if m != nil {
+		return m.SubBand
+	}
+	return ""
+}
+
+func (m *GatewayDutyCycleBudget) GetMinFrequency() uint32 {
+	if m != nil {
+		return m.MinFrequency
+	}
+	return 0
+}
+
+func (m *GatewayDutyCycleBudget) GetMaxFrequency() uint32 {
+	if m != nil {
+		return m.MaxFrequency
+	}
+	return 0
+}
+
+func (m *GatewayDutyCycleBudget) GetDutyCycle() float32 {
+	if m != nil {
+		return m.DutyCycle
+	}
+	return 0
+}
+
+func (m *GatewayDutyCycleBudget) GetUsedAirtime() *duration.Duration {
+	if m != nil {
+		return m.UsedAirtime
+	}
+	return nil
+}
+
+func (m *GatewayDutyCycleBudget) GetRemainingAirtime() *duration.Duration {
+	if m != nil {
+		return m.RemainingAirtime
+	}
+	return nil
+}
+
+type UpdateGatewayRequest struct {
+	// Gateway object to update.
+	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateGatewayRequest) Reset()         { *m = UpdateGatewayRequest{} }
+func (m *UpdateGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateGatewayRequest) ProtoMessage()    {}
+func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{60}
+}
+
+func (m *UpdateGatewayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateGatewayRequest.Unmarshal(m, b)
+}
+func (m *UpdateGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateGatewayRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateGatewayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateGatewayRequest.Merge(m, src)
+}
+func (m *UpdateGatewayRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateGatewayRequest.Size(m)
+}
+func (m *UpdateGatewayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateGatewayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateGatewayRequest proto.InternalMessageInfo
+
+func (m *UpdateGatewayRequest) GetGateway() *Gateway {
+	if m != nil {
+		return m.Gateway
+	}
+	return nil
+}
+
+type DeleteGatewayRequest struct {
+	// Gateway ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteGatewayRequest) Reset()         { *m = DeleteGatewayRequest{} }
+func (m *DeleteGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGatewayRequest) ProtoMessage()    {}
+func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{61}
+}
+
+func (m *DeleteGatewayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGatewayRequest.Unmarshal(m, b)
+}
+func (m *DeleteGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGatewayRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteGatewayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGatewayRequest.Merge(m, src)
+}
+func (m *DeleteGatewayRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteGatewayRequest.Size(m)
+}
+func (m *DeleteGatewayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGatewayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteGatewayRequest proto.InternalMessageInfo
+
+func (m *DeleteGatewayRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type ListGatewaysRequest struct {
+	// Max number of gateways to return.
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Offset of the result-set (for pagination).
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Only return gateways having all of the given tags (key and value).
+	Tags map[string]string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Search on (a part of) the gateway ID (HEX encoded).
+	// Both colon-separated (01:02:03) and plain (010203) HEX are accepted.
+	Search string `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	// Order the result-set by the given field.
+	OrderBy GatewayOrderBy `protobuf:"varint,5,opt,name=order_by,json=orderBy,proto3,enum=ns.GatewayOrderBy" json:"order_by,omitempty"`
+	// Use descending ordering.
+	OrderDesc bool `protobuf:"varint,6,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`
+	// Only return gateways seen within the given duration (online only).
+	OnlineWithin *duration.Duration `protobuf:"bytes,7,opt,name=online_within,json=onlineWithin,proto3" json:"online_within,omitempty"`
+	// Only return gateways with the given maintenance mode (when set).
+	MaintenanceMode      *wrappers.BoolValue `protobuf:"bytes,8,opt,name=maintenance_mode,json=maintenanceMode,proto3" json:"maintenance_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListGatewaysRequest) Reset()         { *m = ListGatewaysRequest{} }
+func (m *ListGatewaysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGatewaysRequest) ProtoMessage()    {}
+func (*ListGatewaysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{62}
+}
+
+func (m *ListGatewaysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewaysRequest.Unmarshal(m, b)
+}
+func (m *ListGatewaysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewaysRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGatewaysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewaysRequest.Merge(m, src)
+}
+func (m *ListGatewaysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGatewaysRequest.Size(m)
+}
+func (m *ListGatewaysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewaysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewaysRequest proto.InternalMessageInfo
+
+func (m *ListGatewaysRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListGatewaysRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListGatewaysRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ListGatewaysRequest) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
+}
+
+func (m *ListGatewaysRequest) GetOrderBy() GatewayOrderBy {
+	if m != nil {
+		return m.OrderBy
+	}
+	return GatewayOrderBy_GATEWAY_ID
+}
+
+func (m *ListGatewaysRequest) GetOrderDesc() bool {
+	if m != nil {
+		return m.OrderDesc
+	}
+	return false
+}
+
+func (m *ListGatewaysRequest) GetOnlineWithin() *duration.Duration {
+	if m != nil {
+		return m.OnlineWithin
+	}
+	return nil
+}
+
+func (m *ListGatewaysRequest) GetMaintenanceMode() *wrappers.BoolValue {
+	if m != nil {
+		return m.MaintenanceMode
+	}
+	return nil
+}
+
+type ListGatewaysResponse struct {
+	// Total number of gateways matching the filters.
+	TotalCount int64 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Gateways within the limit and offset.
+	Result               []*ListGatewaysItem `protobuf:"bytes,2,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListGatewaysResponse) Reset()         { *m = ListGatewaysResponse{} }
+func (m *ListGatewaysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGatewaysResponse) ProtoMessage()    {}
+func (*ListGatewaysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{63}
+}
+
+func (m *ListGatewaysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewaysResponse.Unmarshal(m, b)
+}
+func (m *ListGatewaysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewaysResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGatewaysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewaysResponse.Merge(m, src)
+}
+func (m *ListGatewaysResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGatewaysResponse.Size(m)
+}
+func (m *ListGatewaysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewaysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewaysResponse proto.InternalMessageInfo
+
+func (m *ListGatewaysResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListGatewaysResponse) GetResult() []*ListGatewaysItem {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ListGatewaysItem struct {
+	// Gateway object.
+	// Note that the gateway boards are not included.
+	Gateway *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// First seen timestamp.
+	FirstSeenAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	// Last seen timestamp.
+	LastSeenAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// Gateway is online.
+	Online bool `protobuf:"varint,6,opt,name=online,proto3" json:"online,omitempty"`
+	// Gateway was automatically created on its first stats.
+	// This flag is cleared when the gateway is updated.
+	AutoCreated          bool     `protobuf:"varint,7,opt,name=auto_created,json=autoCreated,proto3" json:"auto_created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGatewaysItem) Reset()         { *m = ListGatewaysItem{} }
+func (m *ListGatewaysItem) String() string { return proto.CompactTextString(m) }
+func (*ListGatewaysItem) ProtoMessage()    {}
+func (*ListGatewaysItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{64}
+}
+
+func (m *ListGatewaysItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewaysItem.Unmarshal(m, b)
+}
+func (m *ListGatewaysItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewaysItem.Marshal(b, m, deterministic)
+}
+func (m *ListGatewaysItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewaysItem.Merge(m, src)
+}
+func (m *ListGatewaysItem) XXX_Size() int {
+	return xxx_messageInfo_ListGatewaysItem.Size(m)
+}
+func (m *ListGatewaysItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewaysItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewaysItem proto.InternalMessageInfo
+
+func (m *ListGatewaysItem) GetGateway() *Gateway {
+	if m != nil {
+		return m.Gateway
+	}
+	return nil
+}
+
+func (m *ListGatewaysItem) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
 }
 
-func (m *Gateway) Reset()         { *m = Gateway{} }
-func (m *Gateway) String() string { return proto.CompactTextString(m) }
-func (*Gateway) ProtoMessage()    {}
-func (*Gateway) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{32}
+func (m *ListGatewaysItem) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
 }
 
-func (m *Gateway) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Gateway.Unmarshal(m, b)
+func (m *ListGatewaysItem) GetFirstSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstSeenAt
+	}
+	return nil
 }
-func (m *Gateway) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Gateway.Marshal(b, m, deterministic)
+
+func (m *ListGatewaysItem) GetLastSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
 }
-func (m *Gateway) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Gateway.Merge(m, src)
+
+func (m *ListGatewaysItem) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
 }
-func (m *Gateway) XXX_Size() int {
-	return xxx_messageInfo_Gateway.Size(m)
+
+func (m *ListGatewaysItem) GetAutoCreated() bool {
+	if m != nil {
+		return m.AutoCreated
+	}
+	return false
 }
-func (m *Gateway) XXX_DiscardUnknown() {
-	xxx_messageInfo_Gateway.DiscardUnknown(m)
+
+type GatewayStats struct {
+	// Timestamp of the (aggregated) measurement.
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Packets received by the gateway.
+	RxPacketsReceived int32 `protobuf:"varint,2,opt,name=rx_packets_received,json=rxPacketsReceived,proto3" json:"rx_packets_received,omitempty"`
+	// Packets received by the gateway that passed the CRC check.
+	RxPacketsReceivedOk int32 `protobuf:"varint,3,opt,name=rx_packets_received_ok,json=rxPacketsReceivedOk,proto3" json:"rx_packets_received_ok,omitempty"`
+	// Packets received by the gateway for transmission.
+	TxPacketsReceived int32 `protobuf:"varint,4,opt,name=tx_packets_received,json=txPacketsReceived,proto3" json:"tx_packets_received,omitempty"`
+	// Packets transmitted by the gateway.
+	TxPacketsEmitted int32 `protobuf:"varint,5,opt,name=tx_packets_emitted,json=txPacketsEmitted,proto3" json:"tx_packets_emitted,omitempty"`
+	// Packets rejected by the gateway for transmission (negative TX
+	// acknowledgement, e.g. TOO_LATE or COLLISION_PACKET).
+	TxPacketsError int32 `protobuf:"varint,6,opt,name=tx_packets_error,json=txPacketsError,proto3" json:"tx_packets_error,omitempty"`
+	// Packets received per frequency and spreading-factor (LoRa only).
+	// Frequency / spreading-factor pairs without packets are omitted.
+	RxPacketsPerFrequencySf []*GatewayStatsRXPackets `protobuf:"bytes,7,rep,name=rx_packets_per_frequency_sf,json=rxPacketsPerFrequencySf,proto3" json:"rx_packets_per_frequency_sf,omitempty"`
+	// Cumulative airtime of the received uplink frames.
+	RxAirtime *duration.Duration `protobuf:"bytes,8,opt,name=rx_airtime,json=rxAirtime,proto3" json:"rx_airtime,omitempty"`
+	// Cumulative airtime of the transmitted downlink frames.
+	TxAirtime            *duration.Duration `protobuf:"bytes,9,opt,name=tx_airtime,json=txAirtime,proto3" json:"tx_airtime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-var xxx_messageInfo_Gateway proto.InternalMessageInfo
+func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
+func (m *GatewayStats) String() string { return proto.CompactTextString(m) }
+func (*GatewayStats) ProtoMessage()    {}
+func (*GatewayStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{65}
+}
 
-func (m *Gateway) GetId() []byte {
+func (m *GatewayStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayStats.Unmarshal(m, b)
+}
+func (m *GatewayStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayStats.Marshal(b, m, deterministic)
+}
+func (m *GatewayStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayStats.Merge(m, src)
+}
+func (m *GatewayStats) XXX_Size() int {
+	return xxx_messageInfo_GatewayStats.Size(m)
+}
+func (m *GatewayStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayStats proto.InternalMessageInfo
+
+func (m *GatewayStats) GetTimestamp() *timestamp.Timestamp {
 	if m != nil {
-		return m.Id
+		return m.Timestamp
 	}
 	return nil
 }
 
-func (m *Gateway) GetLocation() *common.Location {
+func (m *GatewayStats) GetRxPacketsReceived() int32 {
 	if m != nil {
-		return m.Location
+		return m.RxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetRxPacketsReceivedOk() int32 {
+	if m != nil {
+		return m.RxPacketsReceivedOk
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsReceived() int32 {
+	if m != nil {
+		return m.TxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsEmitted() int32 {
+	if m != nil {
+		return m.TxPacketsEmitted
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsError() int32 {
+	if m != nil {
+		return m.TxPacketsError
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetRxPacketsPerFrequencySf() []*GatewayStatsRXPackets {
+	if m != nil {
+		return m.RxPacketsPerFrequencySf
 	}
 	return nil
 }
 
-func (m *Gateway) GetGatewayProfileId() []byte {
+func (m *GatewayStats) GetRxAirtime() *duration.Duration {
 	if m != nil {
-		return m.GatewayProfileId
+		return m.RxAirtime
 	}
 	return nil
 }
 
-func (m *Gateway) GetBoards() []*GatewayBoard {
+func (m *GatewayStats) GetTxAirtime() *duration.Duration {
 	if m != nil {
-		return m.Boards
+		return m.TxAirtime
 	}
 	return nil
 }
 
-type GatewayBoard struct {
-	// FPGA ID of the gateway (8 bytes) (optional).
-	FpgaId []byte `protobuf:"bytes,1,opt,name=fpga_id,json=fpgaId,proto3" json:"fpga_id,omitempty"`
-	// Fine-timestamp AES decryption key (16 bytes) (optional).
-	FineTimestampKey     []byte   `protobuf:"bytes,2,opt,name=fine_timestamp_key,json=fineTimestampKey,proto3" json:"fine_timestamp_key,omitempty"`
+type GatewayStatsRXPackets struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Spreading-factor.
+	SpreadingFactor uint32 `protobuf:"varint,2,opt,name=spreading_factor,json=spreadingFactor,proto3" json:"spreading_factor,omitempty"`
+	// Packets received.
+	RxPackets            int32    `protobuf:"varint,3,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GatewayBoard) Reset()         { *m = GatewayBoard{} }
-func (m *GatewayBoard) String() string { return proto.CompactTextString(m) }
-func (*GatewayBoard) ProtoMessage()    {}
-func (*GatewayBoard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{33}
+func (m *GatewayStatsRXPackets) Reset()         { *m = GatewayStatsRXPackets{} }
+func (m *GatewayStatsRXPackets) String() string { return proto.CompactTextString(m) }
+func (*GatewayStatsRXPackets) ProtoMessage()    {}
+func (*GatewayStatsRXPackets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{66}
 }
 
-func (m *GatewayBoard) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GatewayBoard.Unmarshal(m, b)
+func (m *GatewayStatsRXPackets) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayStatsRXPackets.Unmarshal(m, b)
 }
-func (m *GatewayBoard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GatewayBoard.Marshal(b, m, deterministic)
+func (m *GatewayStatsRXPackets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayStatsRXPackets.Marshal(b, m, deterministic)
 }
-func (m *GatewayBoard) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GatewayBoard.Merge(m, src)
+func (m *GatewayStatsRXPackets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayStatsRXPackets.Merge(m, src)
 }
-func (m *GatewayBoard) XXX_Size() int {
-	return xxx_messageInfo_GatewayBoard.Size(m)
+func (m *GatewayStatsRXPackets) XXX_Size() int {
+	return xxx_messageInfo_GatewayStatsRXPackets.Size(m)
 }
-func (m *GatewayBoard) XXX_DiscardUnknown() {
-	xxx_messageInfo_GatewayBoard.DiscardUnknown(m)
+func (m *GatewayStatsRXPackets) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayStatsRXPackets.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GatewayBoard proto.InternalMessageInfo
+var xxx_messageInfo_GatewayStatsRXPackets proto.InternalMessageInfo
 
-func (m *GatewayBoard) GetFpgaId() []byte {
+func (m *GatewayStatsRXPackets) GetFrequency() uint32 {
 	if m != nil {
-		return m.FpgaId
+		return m.Frequency
 	}
-	return nil
+	return 0
 }
 
-func (m *GatewayBoard) GetFineTimestampKey() []byte {
+func (m *GatewayStatsRXPackets) GetSpreadingFactor() uint32 {
 	if m != nil {
-		return m.FineTimestampKey
+		return m.SpreadingFactor
 	}
-	return nil
+	return 0
 }
 
-type CreateGatewayRequest struct {
-	// Gateway object to create.
-	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+func (m *GatewayStatsRXPackets) GetRxPackets() int32 {
+	if m != nil {
+		return m.RxPackets
+	}
+	return 0
+}
+
+type GetGatewayStatsRequest struct {
+	// MAC address of the gateway.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Aggregation interval.
+	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,proto3,enum=ns.AggregationInterval" json:"interval,omitempty"`
+	// Timestamp to start from.
+	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
+	// Timezone (IANA name, e.g. Australia/Sydney) used for grouping the
+	// DAY and MONTH intervals. When empty, the configured metrics timezone
+	// is used.
+	Timezone             string   `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateGatewayRequest) Reset()         { *m = CreateGatewayRequest{} }
-func (m *CreateGatewayRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateGatewayRequest) ProtoMessage()    {}
-func (*CreateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{34}
+func (m *GetGatewayStatsRequest) Reset()         { *m = GetGatewayStatsRequest{} }
+func (m *GetGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayStatsRequest) ProtoMessage()    {}
+func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{67}
 }
 
-func (m *CreateGatewayRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateGatewayRequest.Unmarshal(m, b)
+func (m *GetGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayStatsRequest.Unmarshal(m, b)
 }
-func (m *CreateGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateGatewayRequest.Marshal(b, m, deterministic)
+func (m *GetGatewayStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayStatsRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateGatewayRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateGatewayRequest.Merge(m, src)
+func (m *GetGatewayStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayStatsRequest.Merge(m, src)
 }
-func (m *CreateGatewayRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateGatewayRequest.Size(m)
+func (m *GetGatewayStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayStatsRequest.Size(m)
 }
-func (m *CreateGatewayRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateGatewayRequest.DiscardUnknown(m)
+func (m *GetGatewayStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayStatsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateGatewayRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetGatewayStatsRequest proto.InternalMessageInfo
 
-func (m *CreateGatewayRequest) GetGateway() *Gateway {
+func (m *GetGatewayStatsRequest) GetGatewayId() []byte {
 	if m != nil {
-		return m.Gateway
+		return m.GatewayId
 	}
 	return nil
 }
 
-type GetGatewayRequest struct {
-	// Gateway ID.
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GetGatewayStatsRequest) GetInterval() AggregationInterval {
+	if m != nil {
+		return m.Interval
+	}
+	return AggregationInterval_SECOND
 }
 
-func (m *GetGatewayRequest) Reset()         { *m = GetGatewayRequest{} }
-func (m *GetGatewayRequest) String() string { return proto.CompactTextString(m) }
-func (*GetGatewayRequest) ProtoMessage()    {}
-func (*GetGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{35}
+func (m *GetGatewayStatsRequest) GetStartTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return nil
 }
 
-func (m *GetGatewayRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetGatewayRequest.Unmarshal(m, b)
+func (m *GetGatewayStatsRequest) GetEndTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return nil
 }
-func (m *GetGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetGatewayRequest.Marshal(b, m, deterministic)
+
+func (m *GetGatewayStatsRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+type GetGatewayStatsResponse struct {
+	Result               []*GatewayStats `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetGatewayStatsResponse) Reset()         { *m = GetGatewayStatsResponse{} }
+func (m *GetGatewayStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayStatsResponse) ProtoMessage()    {}
+func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{68}
+}
+
+func (m *GetGatewayStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayStatsResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayStatsResponse.Marshal(b, m, deterministic)
 }
-func (m *GetGatewayRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetGatewayRequest.Merge(m, src)
+func (m *GetGatewayStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayStatsResponse.Merge(m, src)
 }
-func (m *GetGatewayRequest) XXX_Size() int {
-	return xxx_messageInfo_GetGatewayRequest.Size(m)
+func (m *GetGatewayStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayStatsResponse.Size(m)
 }
-func (m *GetGatewayRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetGatewayRequest.DiscardUnknown(m)
+func (m *GetGatewayStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayStatsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetGatewayRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetGatewayStatsResponse proto.InternalMessageInfo
 
-func (m *GetGatewayRequest) GetId() []byte {
+func (m *GetGatewayStatsResponse) GetResult() []*GatewayStats {
 	if m != nil {
-		return m.Id
+		return m.Result
 	}
 	return nil
 }
 
-type GetGatewayResponse struct {
-	// Gateway object.
-	Gateway *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
-	// Created at timestamp.
-	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// Last update timestamp.
-	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	// First seen timestamp.
-	FirstSeenAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
-	// Last seen timestamp.
-	LastSeenAt           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type GatewayDiscoveryPingRX struct {
+	// Timestamp of the reception.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// ID of the gateway which received the ping.
+	RxGatewayId []byte `protobuf:"bytes,2,opt,name=rx_gateway_id,json=rxGatewayId,proto3" json:"rx_gateway_id,omitempty"`
+	// RSSI of the reception.
+	Rssi int32 `protobuf:"varint,3,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	// LoRa SNR of the reception.
+	LoraSnr              float64  `protobuf:"fixed64,4,opt,name=lora_snr,json=loraSnr,proto3" json:"lora_snr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetGatewayResponse) Reset()         { *m = GetGatewayResponse{} }
-func (m *GetGatewayResponse) String() string { return proto.CompactTextString(m) }
-func (*GetGatewayResponse) ProtoMessage()    {}
-func (*GetGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{36}
+func (m *GatewayDiscoveryPingRX) Reset()         { *m = GatewayDiscoveryPingRX{} }
+func (m *GatewayDiscoveryPingRX) String() string { return proto.CompactTextString(m) }
+func (*GatewayDiscoveryPingRX) ProtoMessage()    {}
+func (*GatewayDiscoveryPingRX) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{69}
 }
 
-func (m *GetGatewayResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetGatewayResponse.Unmarshal(m, b)
+func (m *GatewayDiscoveryPingRX) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayDiscoveryPingRX.Unmarshal(m, b)
 }
-func (m *GetGatewayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetGatewayResponse.Marshal(b, m, deterministic)
+func (m *GatewayDiscoveryPingRX) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayDiscoveryPingRX.Marshal(b, m, deterministic)
 }
-func (m *GetGatewayResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetGatewayResponse.Merge(m, src)
+func (m *GatewayDiscoveryPingRX) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayDiscoveryPingRX.Merge(m, src)
 }
-func (m *GetGatewayResponse) XXX_Size() int {
-	return xxx_messageInfo_GetGatewayResponse.Size(m)
+func (m *GatewayDiscoveryPingRX) XXX_Size() int {
+	return xxx_messageInfo_GatewayDiscoveryPingRX.Size(m)
 }
-func (m *GetGatewayResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetGatewayResponse.DiscardUnknown(m)
+func (m *GatewayDiscoveryPingRX) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayDiscoveryPingRX.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetGatewayResponse proto.InternalMessageInfo
-
-func (m *GetGatewayResponse) GetGateway() *Gateway {
-	if m != nil {
-		return m.Gateway
-	}
-	return nil
-}
+var xxx_messageInfo_GatewayDiscoveryPingRX proto.InternalMessageInfo
 
-func (m *GetGatewayResponse) GetCreatedAt() *timestamp.Timestamp {
+func (m *GatewayDiscoveryPingRX) GetCreatedAt() *timestamp.Timestamp {
 	if m != nil {
 		return m.CreatedAt
 	}
 	return nil
 }
 
-func (m *GetGatewayResponse) GetUpdatedAt() *timestamp.Timestamp {
+func (m *GatewayDiscoveryPingRX) GetRxGatewayId() []byte {
 	if m != nil {
-		return m.UpdatedAt
+		return m.RxGatewayId
 	}
 	return nil
 }
 
-func (m *GetGatewayResponse) GetFirstSeenAt() *timestamp.Timestamp {
+func (m *GatewayDiscoveryPingRX) GetRssi() int32 {
 	if m != nil {
-		return m.FirstSeenAt
+		return m.Rssi
 	}
-	return nil
+	return 0
 }
 
-func (m *GetGatewayResponse) GetLastSeenAt() *timestamp.Timestamp {
+func (m *GatewayDiscoveryPingRX) GetLoraSnr() float64 {
 	if m != nil {
-		return m.LastSeenAt
+		return m.LoraSnr
 	}
-	return nil
+	return 0
 }
 
-type UpdateGatewayRequest struct {
-	// Gateway object to update.
-	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type GetGatewayDiscoveryPingsRequest struct {
+	// ID of the gateway which transmitted the pings.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Timestamp to start from (optional).
+	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
+	// Timestamp until to get from (optional).
+	EndTimestamp         *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *UpdateGatewayRequest) Reset()         { *m = UpdateGatewayRequest{} }
-func (m *UpdateGatewayRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateGatewayRequest) ProtoMessage()    {}
-func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{37}
+func (m *GetGatewayDiscoveryPingsRequest) Reset()         { *m = GetGatewayDiscoveryPingsRequest{} }
+func (m *GetGatewayDiscoveryPingsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDiscoveryPingsRequest) ProtoMessage()    {}
+func (*GetGatewayDiscoveryPingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{70}
 }
 
-func (m *UpdateGatewayRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateGatewayRequest.Unmarshal(m, b)
+func (m *GetGatewayDiscoveryPingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDiscoveryPingsRequest.Unmarshal(m, b)
 }
-func (m *UpdateGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateGatewayRequest.Marshal(b, m, deterministic)
+func (m *GetGatewayDiscoveryPingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDiscoveryPingsRequest.Marshal(b, m, deterministic)
 }
-func (m *UpdateGatewayRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateGatewayRequest.Merge(m, src)
+func (m *GetGatewayDiscoveryPingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDiscoveryPingsRequest.Merge(m, src)
 }
-func (m *UpdateGatewayRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateGatewayRequest.Size(m)
+func (m *GetGatewayDiscoveryPingsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDiscoveryPingsRequest.Size(m)
 }
-func (m *UpdateGatewayRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateGatewayRequest.DiscardUnknown(m)
+func (m *GetGatewayDiscoveryPingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDiscoveryPingsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateGatewayRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetGatewayDiscoveryPingsRequest proto.InternalMessageInfo
 
-func (m *UpdateGatewayRequest) GetGateway() *Gateway {
+func (m *GetGatewayDiscoveryPingsRequest) GetGatewayId() []byte {
 	if m != nil {
-		return m.Gateway
+		return m.GatewayId
 	}
 	return nil
 }
 
-type DeleteGatewayRequest struct {
-	// Gateway ID.
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GetGatewayDiscoveryPingsRequest) GetStartTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return nil
 }
 
-func (m *DeleteGatewayRequest) Reset()         { *m = DeleteGatewayRequest{} }
-func (m *DeleteGatewayRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteGatewayRequest) ProtoMessage()    {}
-func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{38}
+func (m *GetGatewayDiscoveryPingsRequest) GetEndTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return nil
 }
 
-func (m *DeleteGatewayRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteGatewayRequest.Unmarshal(m, b)
+type GetGatewayDiscoveryPingsResponse struct {
+	Result               []*GatewayDiscoveryPingRX `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
-func (m *DeleteGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteGatewayRequest.Marshal(b, m, deterministic)
+
+func (m *GetGatewayDiscoveryPingsResponse) Reset()         { *m = GetGatewayDiscoveryPingsResponse{} }
+func (m *GetGatewayDiscoveryPingsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDiscoveryPingsResponse) ProtoMessage()    {}
+func (*GetGatewayDiscoveryPingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{71}
 }
-func (m *DeleteGatewayRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteGatewayRequest.Merge(m, src)
+
+func (m *GetGatewayDiscoveryPingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDiscoveryPingsResponse.Unmarshal(m, b)
 }
-func (m *DeleteGatewayRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteGatewayRequest.Size(m)
+func (m *GetGatewayDiscoveryPingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDiscoveryPingsResponse.Marshal(b, m, deterministic)
 }
-func (m *DeleteGatewayRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteGatewayRequest.DiscardUnknown(m)
+func (m *GetGatewayDiscoveryPingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDiscoveryPingsResponse.Merge(m, src)
+}
+func (m *GetGatewayDiscoveryPingsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDiscoveryPingsResponse.Size(m)
+}
+func (m *GetGatewayDiscoveryPingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDiscoveryPingsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteGatewayRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetGatewayDiscoveryPingsResponse proto.InternalMessageInfo
 
-func (m *DeleteGatewayRequest) GetId() []byte {
+func (m *GetGatewayDiscoveryPingsResponse) GetResult() []*GatewayDiscoveryPingRX {
 	if m != nil {
-		return m.Id
+		return m.Result
 	}
 	return nil
 }
 
-type GatewayStats struct {
+type NetworkStats struct {
 	// Timestamp of the (aggregated) measurement.
 	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// Packets received by the gateway.
+	// Packets received by all gateways.
 	RxPacketsReceived int32 `protobuf:"varint,2,opt,name=rx_packets_received,json=rxPacketsReceived,proto3" json:"rx_packets_received,omitempty"`
-	// Packets received by the gateway that passed the CRC check.
+	// Packets received by all gateways that passed the CRC check.
 	RxPacketsReceivedOk int32 `protobuf:"varint,3,opt,name=rx_packets_received_ok,json=rxPacketsReceivedOk,proto3" json:"rx_packets_received_ok,omitempty"`
-	// Packets received by the gateway for transmission.
+	// Packets received by all gateways for transmission.
 	TxPacketsReceived int32 `protobuf:"varint,4,opt,name=tx_packets_received,json=txPacketsReceived,proto3" json:"tx_packets_received,omitempty"`
-	// Packets transmitted by the gateway.
-	TxPacketsEmitted     int32    `protobuf:"varint,5,opt,name=tx_packets_emitted,json=txPacketsEmitted,proto3" json:"tx_packets_emitted,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Packets transmitted by all gateways.
+	TxPacketsEmitted int32 `protobuf:"varint,5,opt,name=tx_packets_emitted,json=txPacketsEmitted,proto3" json:"tx_packets_emitted,omitempty"`
+	// Cumulative airtime of the received uplink frames (counted once per
+	// uplink, independent of the number of receiving gateways).
+	RxAirtime *duration.Duration `protobuf:"bytes,6,opt,name=rx_airtime,json=rxAirtime,proto3" json:"rx_airtime,omitempty"`
+	// Cumulative airtime of the transmitted downlink frames.
+	TxAirtime            *duration.Duration `protobuf:"bytes,7,opt,name=tx_airtime,json=txAirtime,proto3" json:"tx_airtime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
-func (m *GatewayStats) String() string { return proto.CompactTextString(m) }
-func (*GatewayStats) ProtoMessage()    {}
-func (*GatewayStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{39}
+func (m *NetworkStats) Reset()         { *m = NetworkStats{} }
+func (m *NetworkStats) String() string { return proto.CompactTextString(m) }
+func (*NetworkStats) ProtoMessage()    {}
+func (*NetworkStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{72}
 }
 
-func (m *GatewayStats) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GatewayStats.Unmarshal(m, b)
+func (m *NetworkStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkStats.Unmarshal(m, b)
 }
-func (m *GatewayStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GatewayStats.Marshal(b, m, deterministic)
+func (m *NetworkStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkStats.Marshal(b, m, deterministic)
 }
-func (m *GatewayStats) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GatewayStats.Merge(m, src)
+func (m *NetworkStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkStats.Merge(m, src)
 }
-func (m *GatewayStats) XXX_Size() int {
-	return xxx_messageInfo_GatewayStats.Size(m)
+func (m *NetworkStats) XXX_Size() int {
+	return xxx_messageInfo_NetworkStats.Size(m)
 }
-func (m *GatewayStats) XXX_DiscardUnknown() {
-	xxx_messageInfo_GatewayStats.DiscardUnknown(m)
+func (m *NetworkStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GatewayStats proto.InternalMessageInfo
+var xxx_messageInfo_NetworkStats proto.InternalMessageInfo
 
-func (m *GatewayStats) GetTimestamp() *timestamp.Timestamp {
+func (m *NetworkStats) GetTimestamp() *timestamp.Timestamp {
 	if m != nil {
 		return m.Timestamp
 	}
 	return nil
 }
 
-func (m *GatewayStats) GetRxPacketsReceived() int32 {
+func (m *NetworkStats) GetRxPacketsReceived() int32 {
 	if m != nil {
 		return m.RxPacketsReceived
 	}
 	return 0
 }
 
-func (m *GatewayStats) GetRxPacketsReceivedOk() int32 {
+func (m *NetworkStats) GetRxPacketsReceivedOk() int32 {
 	if m != nil {
 		return m.RxPacketsReceivedOk
 	}
 	return 0
 }
 
-func (m *GatewayStats) GetTxPacketsReceived() int32 {
+func (m *NetworkStats) GetTxPacketsReceived() int32 {
 	if m != nil {
 		return m.TxPacketsReceived
 	}
 	return 0
 }
 
-func (m *GatewayStats) GetTxPacketsEmitted() int32 {
+func (m *NetworkStats) GetTxPacketsEmitted() int32 {
 	if m != nil {
 		return m.TxPacketsEmitted
 	}
 	return 0
 }
 
-type GetGatewayStatsRequest struct {
-	// MAC address of the gateway.
-	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+func (m *NetworkStats) GetRxAirtime() *duration.Duration {
+	if m != nil {
+		return m.RxAirtime
+	}
+	return nil
+}
+
+func (m *NetworkStats) GetTxAirtime() *duration.Duration {
+	if m != nil {
+		return m.TxAirtime
+	}
+	return nil
+}
+
+type GetNetworkStatsRequest struct {
 	// Aggregation interval.
-	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,proto3,enum=ns.AggregationInterval" json:"interval,omitempty"`
+	Interval AggregationInterval `protobuf:"varint,1,opt,name=interval,proto3,enum=ns.AggregationInterval" json:"interval,omitempty"`
 	// Timestamp to start from.
-	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
+	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
 	// Timestamp until to get from.
-	EndTimestamp         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	EndTimestamp *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
+	// Timezone (IANA name, e.g. Australia/Sydney) used for grouping the
+	// DAY and MONTH intervals. When empty, the configured metrics timezone
+	// is used.
+	Timezone             string   `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetGatewayStatsRequest) Reset()         { *m = GetGatewayStatsRequest{} }
-func (m *GetGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetGatewayStatsRequest) ProtoMessage()    {}
-func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{40}
+func (m *GetNetworkStatsRequest) Reset()         { *m = GetNetworkStatsRequest{} }
+func (m *GetNetworkStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNetworkStatsRequest) ProtoMessage()    {}
+func (*GetNetworkStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{73}
 }
 
-func (m *GetGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetGatewayStatsRequest.Unmarshal(m, b)
+func (m *GetNetworkStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNetworkStatsRequest.Unmarshal(m, b)
 }
-func (m *GetGatewayStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetGatewayStatsRequest.Marshal(b, m, deterministic)
+func (m *GetNetworkStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNetworkStatsRequest.Marshal(b, m, deterministic)
 }
-func (m *GetGatewayStatsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetGatewayStatsRequest.Merge(m, src)
+func (m *GetNetworkStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetworkStatsRequest.Merge(m, src)
 }
-func (m *GetGatewayStatsRequest) XXX_Size() int {
-	return xxx_messageInfo_GetGatewayStatsRequest.Size(m)
+func (m *GetNetworkStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNetworkStatsRequest.Size(m)
 }
-func (m *GetGatewayStatsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetGatewayStatsRequest.DiscardUnknown(m)
+func (m *GetNetworkStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetworkStatsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetGatewayStatsRequest proto.InternalMessageInfo
-
-func (m *GetGatewayStatsRequest) GetGatewayId() []byte {
-	if m != nil {
-		return m.GatewayId
-	}
-	return nil
-}
+var xxx_messageInfo_GetNetworkStatsRequest proto.InternalMessageInfo
 
-func (m *GetGatewayStatsRequest) GetInterval() AggregationInterval {
+func (m *GetNetworkStatsRequest) GetInterval() AggregationInterval {
 	if m != nil {
 		return m.Interval
 	}
 	return AggregationInterval_SECOND
 }
 
-func (m *GetGatewayStatsRequest) GetStartTimestamp() *timestamp.Timestamp {
+func (m *GetNetworkStatsRequest) GetStartTimestamp() *timestamp.Timestamp {
 	if m != nil {
 		return m.StartTimestamp
 	}
 	return nil
 }
 
-func (m *GetGatewayStatsRequest) GetEndTimestamp() *timestamp.Timestamp {
+func (m *GetNetworkStatsRequest) GetEndTimestamp() *timestamp.Timestamp {
 	if m != nil {
 		return m.EndTimestamp
 	}
 	return nil
 }
 
-type GetGatewayStatsResponse struct {
-	Result               []*GatewayStats `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+func (m *GetNetworkStatsRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+type GetNetworkStatsResponse struct {
+	// Number of gateways which are online.
+	GatewaysOnline int32 `protobuf:"varint,1,opt,name=gateways_online,json=gatewaysOnline,proto3" json:"gateways_online,omitempty"`
+	// Number of active device-sessions.
+	DeviceSessions int32 `protobuf:"varint,2,opt,name=device_sessions,json=deviceSessions,proto3" json:"device_sessions,omitempty"`
+	// Network-wide stats per interval.
+	Result               []*NetworkStats `protobuf:"bytes,3,rep,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetGatewayStatsResponse) Reset()         { *m = GetGatewayStatsResponse{} }
-func (m *GetGatewayStatsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetGatewayStatsResponse) ProtoMessage()    {}
-func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{41}
+func (m *GetNetworkStatsResponse) Reset()         { *m = GetNetworkStatsResponse{} }
+func (m *GetNetworkStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNetworkStatsResponse) ProtoMessage()    {}
+func (*GetNetworkStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{74}
 }
 
-func (m *GetGatewayStatsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetGatewayStatsResponse.Unmarshal(m, b)
+func (m *GetNetworkStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNetworkStatsResponse.Unmarshal(m, b)
 }
-func (m *GetGatewayStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetGatewayStatsResponse.Marshal(b, m, deterministic)
+func (m *GetNetworkStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNetworkStatsResponse.Marshal(b, m, deterministic)
 }
-func (m *GetGatewayStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetGatewayStatsResponse.Merge(m, src)
+func (m *GetNetworkStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetworkStatsResponse.Merge(m, src)
 }
-func (m *GetGatewayStatsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetGatewayStatsResponse.Size(m)
+func (m *GetNetworkStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNetworkStatsResponse.Size(m)
 }
-func (m *GetGatewayStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetGatewayStatsResponse.DiscardUnknown(m)
+func (m *GetNetworkStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetworkStatsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetGatewayStatsResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetNetworkStatsResponse proto.InternalMessageInfo
 
-func (m *GetGatewayStatsResponse) GetResult() []*GatewayStats {
+func (m *GetNetworkStatsResponse) GetGatewaysOnline() int32 {
+	if m != nil {
+		return m.GatewaysOnline
+	}
+	return 0
+}
+
+func (m *GetNetworkStatsResponse) GetDeviceSessions() int32 {
+	if m != nil {
+		return m.DeviceSessions
+	}
+	return 0
+}
+
+func (m *GetNetworkStatsResponse) GetResult() []*NetworkStats {
 	if m != nil {
 		return m.Result
 	}
@@ -2217,7 +4932,15 @@ type DeviceQueueItem struct {
 	// is a gap between the activation and the delivery of the AppSKey to the
 	// application-server, there is a possibility that the application-server
 	// tries to enqueue payloads encrypted with the old session-key.
-	DevAddr              []byte   `protobuf:"bytes,6,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	DevAddr []byte `protobuf:"bytes,6,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	// Reference (optional) of the item. This is included in the downlink
+	// status notifications sent to the application-server so that these
+	// can be correlated with the enqueued item.
+	Reference string `protobuf:"bytes,7,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Gateway ID (optional, 8 bytes).
+	// When set, the Class-B or Class-C downlink is transmitted by this
+	// gateway instead of the automatically selected gateway.
+	GatewayId            []byte   `protobuf:"bytes,8,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2227,7 +4950,7 @@ func (m *DeviceQueueItem) Reset()         { *m = DeviceQueueItem{} }
 func (m *DeviceQueueItem) String() string { return proto.CompactTextString(m) }
 func (*DeviceQueueItem) ProtoMessage()    {}
 func (*DeviceQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{42}
+	return fileDescriptor_3b280de855f92a4a, []int{75}
 }
 
 func (m *DeviceQueueItem) XXX_Unmarshal(b []byte) error {
@@ -2290,6 +5013,20 @@ func (m *DeviceQueueItem) GetDevAddr() []byte {
 	return nil
 }
 
+func (m *DeviceQueueItem) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
 type CreateDeviceQueueItemRequest struct {
 	Item                 *DeviceQueueItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -2301,7 +5038,7 @@ func (m *CreateDeviceQueueItemRequest) Reset()         { *m = CreateDeviceQueueI
 func (m *CreateDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceQueueItemRequest) ProtoMessage()    {}
 func (*CreateDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{43}
+	return fileDescriptor_3b280de855f92a4a, []int{76}
 }
 
 func (m *CreateDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
@@ -2341,7 +5078,7 @@ func (m *FlushDeviceQueueForDevEUIRequest) Reset()         { *m = FlushDeviceQue
 func (m *FlushDeviceQueueForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushDeviceQueueForDevEUIRequest) ProtoMessage()    {}
 func (*FlushDeviceQueueForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{44}
+	return fileDescriptor_3b280de855f92a4a, []int{77}
 }
 
 func (m *FlushDeviceQueueForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2381,7 +5118,7 @@ func (m *GetDeviceQueueItemsForDevEUIRequest) Reset()         { *m = GetDeviceQu
 func (m *GetDeviceQueueItemsForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIRequest) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{45}
+	return fileDescriptor_3b280de855f92a4a, []int{78}
 }
 
 func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2420,7 +5157,7 @@ func (m *GetDeviceQueueItemsForDevEUIResponse) Reset()         { *m = GetDeviceQ
 func (m *GetDeviceQueueItemsForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIResponse) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{46}
+	return fileDescriptor_3b280de855f92a4a, []int{79}
 }
 
 func (m *GetDeviceQueueItemsForDevEUIResponse) XXX_Unmarshal(b []byte) error {
@@ -2460,7 +5197,7 @@ func (m *GetNextDownlinkFCntForDevEUIRequest) Reset()         { *m = GetNextDown
 func (m *GetNextDownlinkFCntForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIRequest) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{47}
+	return fileDescriptor_3b280de855f92a4a, []int{80}
 }
 
 func (m *GetNextDownlinkFCntForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2500,7 +5237,7 @@ func (m *GetNextDownlinkFCntForDevEUIResponse) Reset()         { *m = GetNextDow
 func (m *GetNextDownlinkFCntForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIResponse) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{48}
+	return fileDescriptor_3b280de855f92a4a, []int{81}
 }
 
 func (m *GetNextDownlinkFCntForDevEUIResponse) XXX_Unmarshal(b []byte) error {
@@ -2540,7 +5277,7 @@ func (m *StreamFrameLogsForGatewayRequest) Reset()         { *m = StreamFrameLog
 func (m *StreamFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForGatewayRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{49}
+	return fileDescriptor_3b280de855f92a4a, []int{82}
 }
 
 func (m *StreamFrameLogsForGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -2572,17 +5309,20 @@ type StreamFrameLogsForGatewayResponse struct {
 	// Types that are valid to be assigned to Frame:
 	//	*StreamFrameLogsForGatewayResponse_UplinkFrameSet
 	//	*StreamFrameLogsForGatewayResponse_DownlinkFrame
-	Frame                isStreamFrameLogsForGatewayResponse_Frame `protobuf_oneof:"frame"`
-	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
-	XXX_unrecognized     []byte                                    `json:"-"`
-	XXX_sizecache        int32                                     `json:"-"`
+	//	*StreamFrameLogsForGatewayResponse_DownlinkTxAck
+	Frame isStreamFrameLogsForGatewayResponse_Frame `protobuf_oneof:"frame"`
+	// Airtime of the uplink or downlink frame.
+	Airtime              *duration.Duration `protobuf:"bytes,4,opt,name=airtime,proto3" json:"airtime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *StreamFrameLogsForGatewayResponse) Reset()         { *m = StreamFrameLogsForGatewayResponse{} }
 func (m *StreamFrameLogsForGatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForGatewayResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{50}
+	return fileDescriptor_3b280de855f92a4a, []int{83}
 }
 
 func (m *StreamFrameLogsForGatewayResponse) XXX_Unmarshal(b []byte) error {
@@ -2615,10 +5355,17 @@ type StreamFrameLogsForGatewayResponse_DownlinkFrame struct {
 	DownlinkFrame *gw.DownlinkFrame `protobuf:"bytes,2,opt,name=downlink_frame,json=downlinkFrame,proto3,oneof"`
 }
 
-func (*StreamFrameLogsForGatewayResponse_UplinkFrameSet) isStreamFrameLogsForGatewayResponse_Frame() {}
+type StreamFrameLogsForGatewayResponse_DownlinkTxAck struct {
+	DownlinkTxAck *gw.DownlinkTXAck `protobuf:"bytes,3,opt,name=downlink_tx_ack,json=downlinkTxAck,proto3,oneof"`
+}
+
+func (*StreamFrameLogsForGatewayResponse_UplinkFrameSet) isStreamFrameLogsForGatewayResponse_Frame() {
+}
 
 func (*StreamFrameLogsForGatewayResponse_DownlinkFrame) isStreamFrameLogsForGatewayResponse_Frame() {}
 
+func (*StreamFrameLogsForGatewayResponse_DownlinkTxAck) isStreamFrameLogsForGatewayResponse_Frame() {}
+
 func (m *StreamFrameLogsForGatewayResponse) GetFrame() isStreamFrameLogsForGatewayResponse_Frame {
 	if m != nil {
 		return m.Frame
@@ -2640,11 +5387,26 @@ func (m *StreamFrameLogsForGatewayResponse) GetDownlinkFrame() *gw.DownlinkFrame
 	return nil
 }
 
+func (m *StreamFrameLogsForGatewayResponse) GetDownlinkTxAck() *gw.DownlinkTXAck {
+	if x, ok := m.GetFrame().(*StreamFrameLogsForGatewayResponse_DownlinkTxAck); ok {
+		return x.DownlinkTxAck
+	}
+	return nil
+}
+
+func (m *StreamFrameLogsForGatewayResponse) GetAirtime() *duration.Duration {
+	if m != nil {
+		return m.Airtime
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*StreamFrameLogsForGatewayResponse) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*StreamFrameLogsForGatewayResponse_UplinkFrameSet)(nil),
 		(*StreamFrameLogsForGatewayResponse_DownlinkFrame)(nil),
+		(*StreamFrameLogsForGatewayResponse_DownlinkTxAck)(nil),
 	}
 }
 
@@ -2660,7 +5422,7 @@ func (m *StreamFrameLogsForDeviceRequest) Reset()         { *m = StreamFrameLogs
 func (m *StreamFrameLogsForDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{51}
+	return fileDescriptor_3b280de855f92a4a, []int{84}
 }
 
 func (m *StreamFrameLogsForDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -2692,17 +5454,27 @@ type StreamFrameLogsForDeviceResponse struct {
 	// Types that are valid to be assigned to Frame:
 	//	*StreamFrameLogsForDeviceResponse_UplinkFrameSet
 	//	*StreamFrameLogsForDeviceResponse_DownlinkFrame
-	Frame                isStreamFrameLogsForDeviceResponse_Frame `protobuf_oneof:"frame"`
-	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
-	XXX_unrecognized     []byte                                   `json:"-"`
-	XXX_sizecache        int32                                    `json:"-"`
+	//	*StreamFrameLogsForDeviceResponse_RejectedUplinkFrameSet
+	Frame isStreamFrameLogsForDeviceResponse_Frame `protobuf_oneof:"frame"`
+	// RX window used for the downlink frame (downlink frames only).
+	// Class-C downlinks use the RX2 parameters, for Class-B downlinks this
+	// value must be ignored.
+	DownlinkRxWindow RXWindow `protobuf:"varint,4,opt,name=downlink_rx_window,json=downlinkRxWindow,proto3,enum=ns.RXWindow" json:"downlink_rx_window,omitempty"`
+	// Airtime of the uplink or downlink frame.
+	Airtime *duration.Duration `protobuf:"bytes,5,opt,name=airtime,proto3" json:"airtime,omitempty"`
+	// Transport of the mac-commands of the downlink frame (downlink frames
+	// only).
+	DownlinkMacCommandTransport MACCommandTransport `protobuf:"varint,6,opt,name=downlink_mac_command_transport,json=downlinkMacCommandTransport,proto3,enum=ns.MACCommandTransport" json:"downlink_mac_command_transport,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}            `json:"-"`
+	XXX_unrecognized            []byte              `json:"-"`
+	XXX_sizecache               int32               `json:"-"`
 }
 
 func (m *StreamFrameLogsForDeviceResponse) Reset()         { *m = StreamFrameLogsForDeviceResponse{} }
 func (m *StreamFrameLogsForDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{52}
+	return fileDescriptor_3b280de855f92a4a, []int{85}
 }
 
 func (m *StreamFrameLogsForDeviceResponse) XXX_Unmarshal(b []byte) error {
@@ -2735,10 +5507,17 @@ type StreamFrameLogsForDeviceResponse_DownlinkFrame struct {
 	DownlinkFrame *gw.DownlinkFrame `protobuf:"bytes,2,opt,name=downlink_frame,json=downlinkFrame,proto3,oneof"`
 }
 
+type StreamFrameLogsForDeviceResponse_RejectedUplinkFrameSet struct {
+	RejectedUplinkFrameSet *RejectedUplinkFrameSet `protobuf:"bytes,3,opt,name=rejected_uplink_frame_set,json=rejectedUplinkFrameSet,proto3,oneof"`
+}
+
 func (*StreamFrameLogsForDeviceResponse_UplinkFrameSet) isStreamFrameLogsForDeviceResponse_Frame() {}
 
 func (*StreamFrameLogsForDeviceResponse_DownlinkFrame) isStreamFrameLogsForDeviceResponse_Frame() {}
 
+func (*StreamFrameLogsForDeviceResponse_RejectedUplinkFrameSet) isStreamFrameLogsForDeviceResponse_Frame() {
+}
+
 func (m *StreamFrameLogsForDeviceResponse) GetFrame() isStreamFrameLogsForDeviceResponse_Frame {
 	if m != nil {
 		return m.Frame
@@ -2760,29 +5539,174 @@ func (m *StreamFrameLogsForDeviceResponse) GetDownlinkFrame() *gw.DownlinkFrame
 	return nil
 }
 
+func (m *StreamFrameLogsForDeviceResponse) GetRejectedUplinkFrameSet() *RejectedUplinkFrameSet {
+	if x, ok := m.GetFrame().(*StreamFrameLogsForDeviceResponse_RejectedUplinkFrameSet); ok {
+		return x.RejectedUplinkFrameSet
+	}
+	return nil
+}
+
+func (m *StreamFrameLogsForDeviceResponse) GetDownlinkRxWindow() RXWindow {
+	if m != nil {
+		return m.DownlinkRxWindow
+	}
+	return RXWindow_RX1
+}
+
+func (m *StreamFrameLogsForDeviceResponse) GetAirtime() *duration.Duration {
+	if m != nil {
+		return m.Airtime
+	}
+	return nil
+}
+
+func (m *StreamFrameLogsForDeviceResponse) GetDownlinkMacCommandTransport() MACCommandTransport {
+	if m != nil {
+		return m.DownlinkMacCommandTransport
+	}
+	return MACCommandTransport_NO_MAC_COMMANDS
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*StreamFrameLogsForDeviceResponse) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*StreamFrameLogsForDeviceResponse_UplinkFrameSet)(nil),
 		(*StreamFrameLogsForDeviceResponse_DownlinkFrame)(nil),
+		(*StreamFrameLogsForDeviceResponse_RejectedUplinkFrameSet)(nil),
+	}
+}
+
+type DeviceDownlinkFrameLog struct {
+	// Downlink frame.
+	DownlinkFrame *gw.DownlinkFrame `protobuf:"bytes,1,opt,name=downlink_frame,json=downlinkFrame,proto3" json:"downlink_frame,omitempty"`
+	// RX window used for the downlink frame.
+	RxWindow RXWindow `protobuf:"varint,2,opt,name=rx_window,json=rxWindow,proto3,enum=ns.RXWindow" json:"rx_window,omitempty"`
+	// Transport of the mac-commands.
+	MacCommandTransport  MACCommandTransport `protobuf:"varint,3,opt,name=mac_command_transport,json=macCommandTransport,proto3,enum=ns.MACCommandTransport" json:"mac_command_transport,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DeviceDownlinkFrameLog) Reset()         { *m = DeviceDownlinkFrameLog{} }
+func (m *DeviceDownlinkFrameLog) String() string { return proto.CompactTextString(m) }
+func (*DeviceDownlinkFrameLog) ProtoMessage()    {}
+func (*DeviceDownlinkFrameLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{86}
+}
+
+func (m *DeviceDownlinkFrameLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceDownlinkFrameLog.Unmarshal(m, b)
+}
+func (m *DeviceDownlinkFrameLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceDownlinkFrameLog.Marshal(b, m, deterministic)
+}
+func (m *DeviceDownlinkFrameLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceDownlinkFrameLog.Merge(m, src)
+}
+func (m *DeviceDownlinkFrameLog) XXX_Size() int {
+	return xxx_messageInfo_DeviceDownlinkFrameLog.Size(m)
+}
+func (m *DeviceDownlinkFrameLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceDownlinkFrameLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceDownlinkFrameLog proto.InternalMessageInfo
+
+func (m *DeviceDownlinkFrameLog) GetDownlinkFrame() *gw.DownlinkFrame {
+	if m != nil {
+		return m.DownlinkFrame
+	}
+	return nil
+}
+
+func (m *DeviceDownlinkFrameLog) GetRxWindow() RXWindow {
+	if m != nil {
+		return m.RxWindow
+	}
+	return RXWindow_RX1
+}
+
+func (m *DeviceDownlinkFrameLog) GetMacCommandTransport() MACCommandTransport {
+	if m != nil {
+		return m.MacCommandTransport
+	}
+	return MACCommandTransport_NO_MAC_COMMANDS
+}
+
+type RejectedUplinkFrameSet struct {
+	// Uplink frame-set.
+	UplinkFrameSet *gw.UplinkFrameSet `protobuf:"bytes,1,opt,name=uplink_frame_set,json=uplinkFrameSet,proto3" json:"uplink_frame_set,omitempty"`
+	// Reason why the uplink was rejected.
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectedUplinkFrameSet) Reset()         { *m = RejectedUplinkFrameSet{} }
+func (m *RejectedUplinkFrameSet) String() string { return proto.CompactTextString(m) }
+func (*RejectedUplinkFrameSet) ProtoMessage()    {}
+func (*RejectedUplinkFrameSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{87}
+}
+
+func (m *RejectedUplinkFrameSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectedUplinkFrameSet.Unmarshal(m, b)
+}
+func (m *RejectedUplinkFrameSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectedUplinkFrameSet.Marshal(b, m, deterministic)
+}
+func (m *RejectedUplinkFrameSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectedUplinkFrameSet.Merge(m, src)
+}
+func (m *RejectedUplinkFrameSet) XXX_Size() int {
+	return xxx_messageInfo_RejectedUplinkFrameSet.Size(m)
+}
+func (m *RejectedUplinkFrameSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectedUplinkFrameSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectedUplinkFrameSet proto.InternalMessageInfo
+
+func (m *RejectedUplinkFrameSet) GetUplinkFrameSet() *gw.UplinkFrameSet {
+	if m != nil {
+		return m.UplinkFrameSet
+	}
+	return nil
+}
+
+func (m *RejectedUplinkFrameSet) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
+	return ""
 }
 
 type GetVersionResponse struct {
 	// LoRa Server version.
 	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	// Region configured for this network-server.
-	Region               common.Region `protobuf:"varint,2,opt,name=region,proto3,enum=common.Region" json:"region,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Region common.Region `protobuf:"varint,2,opt,name=region,proto3,enum=common.Region" json:"region,omitempty"`
+	// Band name as configured (e.g. EU_863_870).
+	BandName string `protobuf:"bytes,3,opt,name=band_name,json=bandName,proto3" json:"band_name,omitempty"`
+	// NetID of the network-server.
+	NetId []byte `protobuf:"bytes,4,opt,name=net_id,json=netId,proto3" json:"net_id,omitempty"`
+	// LoRaWAN MAC versions supported by this network-server.
+	MacVersions []string `protobuf:"bytes,5,rep,name=mac_versions,json=macVersions,proto3" json:"mac_versions,omitempty"`
+	// Uplink EIRP (dBm) of each TXPower index supported by the band,
+	// the TXPower index is the index into this list.
+	UplinkTxPowerEirp    []float32 `protobuf:"fixed32,6,rep,packed,name=uplink_tx_power_eirp,json=uplinkTxPowerEirp,proto3" json:"uplink_tx_power_eirp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{53}
+	return fileDescriptor_3b280de855f92a4a, []int{88}
 }
 
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
@@ -2817,6 +5741,34 @@ func (m *GetVersionResponse) GetRegion() common.Region {
 	return common.Region_EU868
 }
 
+func (m *GetVersionResponse) GetBandName() string {
+	if m != nil {
+		return m.BandName
+	}
+	return ""
+}
+
+func (m *GetVersionResponse) GetNetId() []byte {
+	if m != nil {
+		return m.NetId
+	}
+	return nil
+}
+
+func (m *GetVersionResponse) GetMacVersions() []string {
+	if m != nil {
+		return m.MacVersions
+	}
+	return nil
+}
+
+func (m *GetVersionResponse) GetUplinkTxPowerEirp() []float32 {
+	if m != nil {
+		return m.UplinkTxPowerEirp
+	}
+	return nil
+}
+
 type GatewayProfile struct {
 	// ID of the gateway-profile.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -2825,17 +5777,23 @@ type GatewayProfile struct {
 	Channels []uint32 `protobuf:"varint,2,rep,packed,name=channels,proto3" json:"channels,omitempty"`
 	// Extra channels added to the channel-configuration (in case the LoRaWAN
 	// region supports adding custom channels).
-	ExtraChannels        []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	ExtraChannels []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
+	// Name of the gateway-profile.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// Stats interval of the gateways using this gateway-profile.
+	// When set, this overrides the stats interval used for the gateway
+	// offline detection.
+	StatsInterval        *duration.Duration `protobuf:"bytes,5,opt,name=stats_interval,json=statsInterval,proto3" json:"stats_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *GatewayProfile) Reset()         { *m = GatewayProfile{} }
 func (m *GatewayProfile) String() string { return proto.CompactTextString(m) }
 func (*GatewayProfile) ProtoMessage()    {}
 func (*GatewayProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{54}
+	return fileDescriptor_3b280de855f92a4a, []int{89}
 }
 
 func (m *GatewayProfile) XXX_Unmarshal(b []byte) error {
@@ -2877,6 +5835,20 @@ func (m *GatewayProfile) GetExtraChannels() []*GatewayProfileExtraChannel {
 	return nil
 }
 
+func (m *GatewayProfile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GatewayProfile) GetStatsInterval() *duration.Duration {
+	if m != nil {
+		return m.StatsInterval
+	}
+	return nil
+}
+
 type GatewayProfileExtraChannel struct {
 	// Modulation.
 	Modulation common.Modulation `protobuf:"varint,1,opt,name=modulation,proto3,enum=common.Modulation" json:"modulation,omitempty"`
@@ -2897,7 +5869,7 @@ func (m *GatewayProfileExtraChannel) Reset()         { *m = GatewayProfileExtraC
 func (m *GatewayProfileExtraChannel) String() string { return proto.CompactTextString(m) }
 func (*GatewayProfileExtraChannel) ProtoMessage()    {}
 func (*GatewayProfileExtraChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{55}
+	return fileDescriptor_3b280de855f92a4a, []int{90}
 }
 
 func (m *GatewayProfileExtraChannel) XXX_Unmarshal(b []byte) error {
@@ -2965,7 +5937,7 @@ func (m *CreateGatewayProfileRequest) Reset()         { *m = CreateGatewayProfil
 func (m *CreateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayProfileRequest) ProtoMessage()    {}
 func (*CreateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{56}
+	return fileDescriptor_3b280de855f92a4a, []int{91}
 }
 
 func (m *CreateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3005,7 +5977,7 @@ func (m *CreateGatewayProfileResponse) Reset()         { *m = CreateGatewayProfi
 func (m *CreateGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayProfileResponse) ProtoMessage()    {}
 func (*CreateGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{57}
+	return fileDescriptor_3b280de855f92a4a, []int{92}
 }
 
 func (m *CreateGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
@@ -3045,7 +6017,7 @@ func (m *GetGatewayProfileRequest) Reset()         { *m = GetGatewayProfileReque
 func (m *GetGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayProfileRequest) ProtoMessage()    {}
 func (*GetGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{58}
+	return fileDescriptor_3b280de855f92a4a, []int{93}
 }
 
 func (m *GetGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3089,7 +6061,7 @@ func (m *GetGatewayProfileResponse) Reset()         { *m = GetGatewayProfileResp
 func (m *GetGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayProfileResponse) ProtoMessage()    {}
 func (*GetGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{59}
+	return fileDescriptor_3b280de855f92a4a, []int{94}
 }
 
 func (m *GetGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
@@ -3143,7 +6115,7 @@ func (m *UpdateGatewayProfileRequest) Reset()         { *m = UpdateGatewayProfil
 func (m *UpdateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGatewayProfileRequest) ProtoMessage()    {}
 func (*UpdateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{60}
+	return fileDescriptor_3b280de855f92a4a, []int{95}
 }
 
 func (m *UpdateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3183,7 +6155,7 @@ func (m *DeleteGatewayProfileRequest) Reset()         { *m = DeleteGatewayProfil
 func (m *DeleteGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteGatewayProfileRequest) ProtoMessage()    {}
 func (*DeleteGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{61}
+	return fileDescriptor_3b280de855f92a4a, []int{96}
 }
 
 func (m *DeleteGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3244,7 +6216,7 @@ func (m *MulticastGroup) Reset()         { *m = MulticastGroup{} }
 func (m *MulticastGroup) String() string { return proto.CompactTextString(m) }
 func (*MulticastGroup) ProtoMessage()    {}
 func (*MulticastGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{62}
+	return fileDescriptor_3b280de855f92a4a, []int{97}
 }
 
 func (m *MulticastGroup) XXX_Unmarshal(b []byte) error {
@@ -3347,7 +6319,7 @@ func (m *CreateMulticastGroupRequest) Reset()         { *m = CreateMulticastGrou
 func (m *CreateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateMulticastGroupRequest) ProtoMessage()    {}
 func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{63}
+	return fileDescriptor_3b280de855f92a4a, []int{98}
 }
 
 func (m *CreateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3387,7 +6359,7 @@ func (m *CreateMulticastGroupResponse) Reset()         { *m = CreateMulticastGro
 func (m *CreateMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateMulticastGroupResponse) ProtoMessage()    {}
 func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{64}
+	return fileDescriptor_3b280de855f92a4a, []int{99}
 }
 
 func (m *CreateMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
@@ -3427,7 +6399,7 @@ func (m *GetMulticastGroupRequest) Reset()         { *m = GetMulticastGroupReque
 func (m *GetMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*GetMulticastGroupRequest) ProtoMessage()    {}
 func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{65}
+	return fileDescriptor_3b280de855f92a4a, []int{100}
 }
 
 func (m *GetMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3471,7 +6443,7 @@ func (m *GetMulticastGroupResponse) Reset()         { *m = GetMulticastGroupResp
 func (m *GetMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
 func (*GetMulticastGroupResponse) ProtoMessage()    {}
 func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{66}
+	return fileDescriptor_3b280de855f92a4a, []int{101}
 }
 
 func (m *GetMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
@@ -3525,7 +6497,7 @@ func (m *UpdateMulticastGroupRequest) Reset()         { *m = UpdateMulticastGrou
 func (m *UpdateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateMulticastGroupRequest) ProtoMessage()    {}
 func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{67}
+	return fileDescriptor_3b280de855f92a4a, []int{102}
 }
 
 func (m *UpdateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3565,7 +6537,7 @@ func (m *DeleteMulticastGroupRequest) Reset()         { *m = DeleteMulticastGrou
 func (m *DeleteMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteMulticastGroupRequest) ProtoMessage()    {}
 func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{68}
+	return fileDescriptor_3b280de855f92a4a, []int{103}
 }
 
 func (m *DeleteMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3607,7 +6579,7 @@ func (m *AddDeviceToMulticastGroupRequest) Reset()         { *m = AddDeviceToMul
 func (m *AddDeviceToMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*AddDeviceToMulticastGroupRequest) ProtoMessage()    {}
 func (*AddDeviceToMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{69}
+	return fileDescriptor_3b280de855f92a4a, []int{104}
 }
 
 func (m *AddDeviceToMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3656,7 +6628,7 @@ func (m *RemoveDeviceFromMulticastGroupRequest) Reset()         { *m = RemoveDev
 func (m *RemoveDeviceFromMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveDeviceFromMulticastGroupRequest) ProtoMessage()    {}
 func (*RemoveDeviceFromMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{70}
+	return fileDescriptor_3b280de855f92a4a, []int{105}
 }
 
 func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3709,7 +6681,7 @@ func (m *MulticastQueueItem) Reset()         { *m = MulticastQueueItem{} }
 func (m *MulticastQueueItem) String() string { return proto.CompactTextString(m) }
 func (*MulticastQueueItem) ProtoMessage()    {}
 func (*MulticastQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{71}
+	return fileDescriptor_3b280de855f92a4a, []int{106}
 }
 
 func (m *MulticastQueueItem) XXX_Unmarshal(b []byte) error {
@@ -3769,7 +6741,7 @@ func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMultica
 func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
 func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{72}
+	return fileDescriptor_3b280de855f92a4a, []int{107}
 }
 
 func (m *EnqueueMulticastQueueItemRequest) XXX_Unmarshal(b []byte) error {
@@ -3813,7 +6785,7 @@ func (m *FlushMulticastQueueForMulticastGroupRequest) String() string {
 }
 func (*FlushMulticastQueueForMulticastGroupRequest) ProtoMessage() {}
 func (*FlushMulticastQueueForMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{73}
+	return fileDescriptor_3b280de855f92a4a, []int{108}
 }
 
 func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3857,7 +6829,7 @@ func (m *GetMulticastQueueItemsForMulticastGroupRequest) String() string {
 }
 func (*GetMulticastQueueItemsForMulticastGroupRequest) ProtoMessage() {}
 func (*GetMulticastQueueItemsForMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{74}
+	return fileDescriptor_3b280de855f92a4a, []int{109}
 }
 
 func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3900,7 +6872,7 @@ func (m *GetMulticastQueueItemsForMulticastGroupResponse) String() string {
 }
 func (*GetMulticastQueueItemsForMulticastGroupResponse) ProtoMessage() {}
 func (*GetMulticastQueueItemsForMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{75}
+	return fileDescriptor_3b280de855f92a4a, []int{110}
 }
 
 func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
@@ -3928,8 +6900,50 @@ func (m *GetMulticastQueueItemsForMulticastGroupResponse) GetMulticastQueueItems
 	return nil
 }
 
+type ResetDeviceSessionLinkADRStateRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetDeviceSessionLinkADRStateRequest) Reset()         { *m = ResetDeviceSessionLinkADRStateRequest{} }
+func (m *ResetDeviceSessionLinkADRStateRequest) String() string { return proto.CompactTextString(m) }
+func (*ResetDeviceSessionLinkADRStateRequest) ProtoMessage()    {}
+func (*ResetDeviceSessionLinkADRStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{111}
+}
+
+func (m *ResetDeviceSessionLinkADRStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetDeviceSessionLinkADRStateRequest.Unmarshal(m, b)
+}
+func (m *ResetDeviceSessionLinkADRStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetDeviceSessionLinkADRStateRequest.Marshal(b, m, deterministic)
+}
+func (m *ResetDeviceSessionLinkADRStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetDeviceSessionLinkADRStateRequest.Merge(m, src)
+}
+func (m *ResetDeviceSessionLinkADRStateRequest) XXX_Size() int {
+	return xxx_messageInfo_ResetDeviceSessionLinkADRStateRequest.Size(m)
+}
+func (m *ResetDeviceSessionLinkADRStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetDeviceSessionLinkADRStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetDeviceSessionLinkADRStateRequest proto.InternalMessageInfo
+
+func (m *ResetDeviceSessionLinkADRStateRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
+	proto.RegisterEnum("ns.MACCommandTransport", MACCommandTransport_name, MACCommandTransport_value)
+	proto.RegisterEnum("ns.GatewayOrderBy", GatewayOrderBy_name, GatewayOrderBy_value)
 	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
 	proto.RegisterEnum("ns.MulticastGroupType", MulticastGroupType_name, MulticastGroupType_value)
 	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
@@ -3944,6 +6958,7 @@ func init() {
 	proto.RegisterType((*GetRoutingProfileResponse)(nil), "ns.GetRoutingProfileResponse")
 	proto.RegisterType((*UpdateRoutingProfileRequest)(nil), "ns.UpdateRoutingProfileRequest")
 	proto.RegisterType((*DeleteRoutingProfileRequest)(nil), "ns.DeleteRoutingProfileRequest")
+	proto.RegisterType((*FlushDeadLetterQueueRequest)(nil), "ns.FlushDeadLetterQueueRequest")
 	proto.RegisterType((*CreateDeviceProfileRequest)(nil), "ns.CreateDeviceProfileRequest")
 	proto.RegisterType((*CreateDeviceProfileResponse)(nil), "ns.CreateDeviceProfileResponse")
 	proto.RegisterType((*GetDeviceProfileRequest)(nil), "ns.GetDeviceProfileRequest")
@@ -3961,19 +6976,53 @@ func init() {
 	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
 	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
 	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
+	proto.RegisterType((*DeviceSession)(nil), "ns.DeviceSession")
+	proto.RegisterType((*DeviceSessionRXInfo)(nil), "ns.DeviceSessionRXInfo")
+	proto.RegisterType((*DeviceSessionTXInfo)(nil), "ns.DeviceSessionTXInfo")
+	proto.RegisterType((*GetDeviceSessionRequest)(nil), "ns.GetDeviceSessionRequest")
+	proto.RegisterType((*GetDeviceSessionResponse)(nil), "ns.GetDeviceSessionResponse")
+	proto.RegisterType((*UpdateDeviceSessionInstallationMarginRequest)(nil), "ns.UpdateDeviceSessionInstallationMarginRequest")
+	proto.RegisterType((*UpdateDeviceSessionRXWindowRequest)(nil), "ns.UpdateDeviceSessionRXWindowRequest")
+	proto.RegisterType((*UplinkHistoryItem)(nil), "ns.UplinkHistoryItem")
+	proto.RegisterType((*ADRDecision)(nil), "ns.ADRDecision")
+	proto.RegisterType((*GetDeviceLinkMetricsRequest)(nil), "ns.GetDeviceLinkMetricsRequest")
+	proto.RegisterType((*GetDeviceLinkMetricsResponse)(nil), "ns.GetDeviceLinkMetricsResponse")
+	proto.RegisterType((*GetDeviceStatusRequest)(nil), "ns.GetDeviceStatusRequest")
+	proto.RegisterType((*GetDeviceStatusResponse)(nil), "ns.GetDeviceStatusResponse")
+	proto.RegisterType((*GetDeviceLocationRequest)(nil), "ns.GetDeviceLocationRequest")
+	proto.RegisterType((*GetDeviceLocationResponse)(nil), "ns.GetDeviceLocationResponse")
+	proto.RegisterType((*GetDeviceSessionsForDevAddrRequest)(nil), "ns.GetDeviceSessionsForDevAddrRequest")
+	proto.RegisterType((*GetDeviceSessionsForDevAddrResponse)(nil), "ns.GetDeviceSessionsForDevAddrResponse")
 	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
 	proto.RegisterType((*CreateMACCommandQueueItemRequest)(nil), "ns.CreateMACCommandQueueItemRequest")
+	proto.RegisterType((*MACCommandQueueItem)(nil), "ns.MACCommandQueueItem")
+	proto.RegisterType((*GetMACCommandQueueItemsRequest)(nil), "ns.GetMACCommandQueueItemsRequest")
+	proto.RegisterType((*GetMACCommandQueueItemsResponse)(nil), "ns.GetMACCommandQueueItemsResponse")
+	proto.RegisterType((*DeleteMACCommandQueueItemRequest)(nil), "ns.DeleteMACCommandQueueItemRequest")
 	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
 	proto.RegisterType((*Gateway)(nil), "ns.Gateway")
+	proto.RegisterMapType((map[string]string)(nil), "ns.Gateway.TagsEntry")
 	proto.RegisterType((*GatewayBoard)(nil), "ns.GatewayBoard")
 	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
 	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
 	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
+	proto.RegisterType((*GatewayDutyCycleBudget)(nil), "ns.GatewayDutyCycleBudget")
 	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
 	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
+	proto.RegisterType((*ListGatewaysRequest)(nil), "ns.ListGatewaysRequest")
+	proto.RegisterMapType((map[string]string)(nil), "ns.ListGatewaysRequest.TagsEntry")
+	proto.RegisterType((*ListGatewaysResponse)(nil), "ns.ListGatewaysResponse")
+	proto.RegisterType((*ListGatewaysItem)(nil), "ns.ListGatewaysItem")
 	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
+	proto.RegisterType((*GatewayStatsRXPackets)(nil), "ns.GatewayStatsRXPackets")
 	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
 	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
+	proto.RegisterType((*GatewayDiscoveryPingRX)(nil), "ns.GatewayDiscoveryPingRX")
+	proto.RegisterType((*GetGatewayDiscoveryPingsRequest)(nil), "ns.GetGatewayDiscoveryPingsRequest")
+	proto.RegisterType((*GetGatewayDiscoveryPingsResponse)(nil), "ns.GetGatewayDiscoveryPingsResponse")
+	proto.RegisterType((*NetworkStats)(nil), "ns.NetworkStats")
+	proto.RegisterType((*GetNetworkStatsRequest)(nil), "ns.GetNetworkStatsRequest")
+	proto.RegisterType((*GetNetworkStatsResponse)(nil), "ns.GetNetworkStatsResponse")
 	proto.RegisterType((*DeviceQueueItem)(nil), "ns.DeviceQueueItem")
 	proto.RegisterType((*CreateDeviceQueueItemRequest)(nil), "ns.CreateDeviceQueueItemRequest")
 	proto.RegisterType((*FlushDeviceQueueForDevEUIRequest)(nil), "ns.FlushDeviceQueueForDevEUIRequest")
@@ -3985,6 +7034,8 @@ func init() {
 	proto.RegisterType((*StreamFrameLogsForGatewayResponse)(nil), "ns.StreamFrameLogsForGatewayResponse")
 	proto.RegisterType((*StreamFrameLogsForDeviceRequest)(nil), "ns.StreamFrameLogsForDeviceRequest")
 	proto.RegisterType((*StreamFrameLogsForDeviceResponse)(nil), "ns.StreamFrameLogsForDeviceResponse")
+	proto.RegisterType((*DeviceDownlinkFrameLog)(nil), "ns.DeviceDownlinkFrameLog")
+	proto.RegisterType((*RejectedUplinkFrameSet)(nil), "ns.RejectedUplinkFrameSet")
 	proto.RegisterType((*GetVersionResponse)(nil), "ns.GetVersionResponse")
 	proto.RegisterType((*GatewayProfile)(nil), "ns.GatewayProfile")
 	proto.RegisterType((*GatewayProfileExtraChannel)(nil), "ns.GatewayProfileExtraChannel")
@@ -4008,200 +7059,398 @@ func init() {
 	proto.RegisterType((*FlushMulticastQueueForMulticastGroupRequest)(nil), "ns.FlushMulticastQueueForMulticastGroupRequest")
 	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupRequest)(nil), "ns.GetMulticastQueueItemsForMulticastGroupRequest")
 	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupResponse)(nil), "ns.GetMulticastQueueItemsForMulticastGroupResponse")
+	proto.RegisterType((*ResetDeviceSessionLinkADRStateRequest)(nil), "ns.ResetDeviceSessionLinkADRStateRequest")
 }
 
 func init() { proto.RegisterFile("ns.proto", fileDescriptor_3b280de855f92a4a) }
 
 var fileDescriptor_3b280de855f92a4a = []byte{
-	// 2998 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0xcd, 0x72, 0xdb, 0xc8,
-	0xf1, 0x17, 0x29, 0x89, 0x92, 0x5a, 0x22, 0x05, 0x8d, 0x6c, 0x8b, 0xa6, 0xe5, 0x95, 0x8c, 0xf5,
-	0xae, 0xb5, 0x5e, 0x2f, 0xfd, 0xff, 0x6b, 0xcb, 0x55, 0xfb, 0x91, 0x75, 0x8a, 0x4b, 0x51, 0xb6,
-	0xd6, 0xb6, 0x6c, 0x83, 0x96, 0xf7, 0xab, 0x2a, 0x08, 0x0c, 0x0c, 0x69, 0x94, 0x08, 0x80, 0x0b,
-	0x0c, 0x25, 0x2b, 0x55, 0x39, 0xa4, 0x72, 0xcc, 0x21, 0x97, 0xdc, 0x73, 0x4c, 0x2e, 0xa9, 0xe4,
-	0x9c, 0x47, 0xc8, 0x21, 0x97, 0xdc, 0xf6, 0x19, 0x72, 0xca, 0x13, 0xa4, 0x06, 0x33, 0x18, 0x7c,
-	0x70, 0x00, 0xd2, 0x6b, 0xbb, 0x9c, 0x93, 0x84, 0xe9, 0xee, 0xdf, 0xf4, 0x74, 0xf7, 0xcc, 0xf4,
-	0x74, 0x13, 0x16, 0xdd, 0xa0, 0x39, 0xf4, 0x3d, 0xe2, 0xa1, 0xb2, 0x1b, 0x34, 0xb6, 0xfa, 0x9e,
-	0xd7, 0x1f, 0xe0, 0x9b, 0xe1, 0xc8, 0xb3, 0x51, 0xef, 0x26, 0xb1, 0x1d, 0x1c, 0x10, 0xc3, 0x19,
-	0x32, 0xa6, 0xc6, 0xa5, 0x2c, 0x03, 0x76, 0x86, 0xe4, 0x8c, 0x13, 0x37, 0x8c, 0xa1, 0x7d, 0xd3,
-	0xf4, 0x1c, 0xc7, 0x73, 0xf9, 0x1f, 0x4e, 0x58, 0xa5, 0x84, 0xfe, 0xe9, 0xcd, 0xfe, 0x29, 0x1f,
-	0xa8, 0x0d, 0x7d, 0xaf, 0x67, 0x0f, 0x30, 0x9f, 0x5b, 0xfd, 0x0e, 0x2e, 0xb5, 0x7d, 0x6c, 0x10,
-	0xdc, 0xc5, 0xfe, 0x89, 0x6d, 0xe2, 0x47, 0x8c, 0xac, 0xe1, 0x1f, 0x46, 0x38, 0x20, 0xe8, 0x73,
-	0x58, 0x0d, 0x18, 0x41, 0xe7, 0x82, 0xf5, 0xd2, 0x76, 0x69, 0x67, 0x79, 0x17, 0x35, 0xdd, 0xa0,
-	0x99, 0x91, 0xa9, 0x05, 0xa9, 0x6f, 0xb5, 0x09, 0x9b, 0x72, 0xec, 0x60, 0xe8, 0xb9, 0x01, 0x46,
-	0x35, 0x28, 0xdb, 0x56, 0x88, 0xb7, 0xa2, 0x95, 0x6d, 0x4b, 0xbd, 0x0e, 0xf5, 0x3b, 0x98, 0xc8,
-	0x15, 0xc9, 0xf2, 0xfe, 0xb3, 0x04, 0x17, 0x25, 0xcc, 0x1c, 0xf9, 0x55, 0xd4, 0x46, 0x9f, 0x02,
-	0x98, 0xa1, 0xda, 0x96, 0x6e, 0x90, 0x7a, 0x39, 0x94, 0x6b, 0x34, 0x99, 0xf9, 0x9b, 0x91, 0xf9,
-	0x9b, 0x4f, 0x22, 0xff, 0x68, 0x4b, 0x9c, 0xbb, 0x45, 0xa8, 0xe8, 0x68, 0x68, 0x45, 0xa2, 0xb3,
-	0x93, 0x45, 0x39, 0x77, 0x8b, 0x50, 0x47, 0x1c, 0x85, 0x1f, 0x6f, 0xc0, 0x11, 0x1f, 0xc1, 0xa5,
-	0x3d, 0x3c, 0xc0, 0x79, 0xd8, 0x59, 0xdb, 0x8a, 0x98, 0xd0, 0xbc, 0x11, 0xb1, 0xdd, 0xfe, 0xb8,
-	0x2a, 0x3e, 0x23, 0xc8, 0x54, 0xc9, 0xc8, 0xd4, 0xfc, 0xd4, 0x77, 0x1c, 0x13, 0x59, 0xec, 0xc2,
-	0x98, 0x90, 0x2b, 0x92, 0x13, 0x13, 0x39, 0xc8, 0xaf, 0xa2, 0xf6, 0xdb, 0x8e, 0x89, 0x37, 0xe0,
-	0x08, 0x11, 0x13, 0xd3, 0xd9, 0xf6, 0x29, 0x34, 0x98, 0xdf, 0xf6, 0xb0, 0x24, 0x82, 0x3e, 0x81,
-	0x9a, 0x85, 0x25, 0xc1, 0xb9, 0x46, 0x15, 0x49, 0x4b, 0x54, 0x2d, 0x9c, 0x09, 0x4d, 0x29, 0x6e,
-	0x4e, 0x38, 0x7c, 0x00, 0x1b, 0x77, 0x30, 0x91, 0xea, 0x90, 0x65, 0xfd, 0x47, 0x29, 0x0c, 0x1d,
-	0x39, 0xee, 0x4f, 0x56, 0xf8, 0x2d, 0x45, 0xc2, 0x53, 0x68, 0xb0, 0x48, 0x78, 0xcd, 0xe6, 0xbf,
-	0x01, 0x0d, 0x16, 0x05, 0x53, 0x99, 0xf4, 0x37, 0x65, 0xa8, 0x30, 0x46, 0xb4, 0x01, 0x0b, 0x16,
-	0x3e, 0xd1, 0xf1, 0xc8, 0xe6, 0xf4, 0x8a, 0x85, 0x4f, 0x3a, 0x23, 0x1b, 0x5d, 0x87, 0xb5, 0xb4,
-	0x2e, 0xba, 0x6d, 0x85, 0x66, 0x5a, 0xd1, 0x56, 0x53, 0x73, 0x1f, 0x58, 0xe8, 0x06, 0xa0, 0xcc,
-	0xa1, 0x46, 0x99, 0x67, 0x43, 0x66, 0x25, 0x7d, 0x86, 0x31, 0xee, 0x4c, 0xb8, 0x53, 0xee, 0x39,
-	0xc6, 0x9d, 0x8e, 0xee, 0x03, 0x0b, 0x5d, 0x03, 0x25, 0x38, 0xb6, 0x87, 0x7a, 0x4f, 0x37, 0x5d,
-	0xa2, 0x9b, 0xcf, 0xb1, 0x79, 0x5c, 0x9f, 0xdf, 0x2e, 0xed, 0x2c, 0x6a, 0x55, 0x3a, 0xbe, 0xdf,
-	0x76, 0x49, 0x9b, 0x0e, 0xa2, 0x8f, 0x00, 0xf9, 0xb8, 0x87, 0x7d, 0xec, 0x9a, 0x58, 0x37, 0x06,
-	0xc4, 0x26, 0x23, 0x0b, 0xd7, 0x2b, 0xdb, 0xa5, 0x9d, 0x92, 0xb6, 0x26, 0x28, 0x2d, 0x4e, 0x50,
-	0x3f, 0x85, 0xf5, 0x64, 0xc0, 0x46, 0xa6, 0x52, 0xa1, 0xc2, 0x56, 0xc7, 0x4d, 0x0f, 0xb1, 0xe9,
-	0x35, 0x4e, 0x51, 0x3f, 0x04, 0x45, 0x04, 0x64, 0x24, 0x97, 0x67, 0x47, 0xf5, 0x2f, 0x25, 0x58,
-	0x4b, 0x70, 0xf3, 0xb8, 0x9d, 0x62, 0x9a, 0xb7, 0x14, 0xa1, 0x9f, 0xc2, 0x7a, 0x32, 0x42, 0x5f,
-	0xc6, 0x2e, 0x4d, 0x58, 0x4f, 0x06, 0xe1, 0x44, 0xd3, 0xfc, 0xbd, 0x0c, 0x0a, 0x63, 0x6d, 0x99,
-	0xc4, 0x3e, 0x31, 0x88, 0xed, 0xb9, 0xf9, 0x01, 0x79, 0x11, 0x16, 0x29, 0xc1, 0xb0, 0x2c, 0x9f,
-	0xc7, 0x21, 0x65, 0x6c, 0x59, 0x96, 0x8f, 0xae, 0xc2, 0x6a, 0xa0, 0xbb, 0xa7, 0xc7, 0x7a, 0xa0,
-	0xdb, 0x2e, 0xd1, 0x8f, 0xf1, 0x19, 0x0f, 0xbe, 0xe5, 0xe0, 0xf0, 0xf4, 0xb8, 0x7b, 0xe0, 0x92,
-	0x7b, 0xf8, 0x8c, 0x72, 0xf5, 0x32, 0x5c, 0x2c, 0xe8, 0x96, 0x7b, 0x09, 0xae, 0x2b, 0x50, 0x65,
-	0x3c, 0xd8, 0x35, 0x43, 0x9e, 0xf9, 0x90, 0x07, 0xdc, 0xd3, 0xe3, 0x6e, 0xc7, 0x35, 0x29, 0x4b,
-	0x1d, 0x16, 0x59, 0x34, 0x8e, 0x86, 0x61, 0x7c, 0x55, 0xb5, 0x4a, 0xaf, 0xed, 0x92, 0xa3, 0x21,
-	0xda, 0x82, 0x15, 0x97, 0x47, 0xaa, 0xe5, 0x9d, 0xba, 0xf5, 0x85, 0x90, 0xba, 0xe4, 0xd2, 0x28,
-	0xdd, 0xf3, 0x4e, 0x5d, 0xca, 0x60, 0x24, 0x19, 0x16, 0x19, 0x83, 0x21, 0x18, 0x64, 0xe1, 0xbe,
-	0x24, 0x09, 0x77, 0xf5, 0x3b, 0x38, 0xcf, 0xad, 0x96, 0x31, 0x77, 0x4b, 0x6c, 0x5c, 0x43, 0x58,
-	0x95, 0x3b, 0xed, 0x5c, 0xec, 0xb4, 0xd8, 0xe2, 0x9a, 0x62, 0x65, 0x46, 0xd4, 0x5d, 0xd8, 0xd8,
-	0xc3, 0x86, 0x14, 0x3d, 0xd7, 0x99, 0xb7, 0xa0, 0x21, 0xc2, 0x3c, 0x01, 0x3e, 0x49, 0xec, 0x97,
-	0x70, 0x49, 0x2a, 0xc6, 0xf7, 0xc9, 0x6b, 0x58, 0xcc, 0x2d, 0x96, 0x79, 0x18, 0xae, 0xe5, 0x39,
-	0x7b, 0x2c, 0x60, 0x04, 0x7c, 0x32, 0xa6, 0x4a, 0xa9, 0x98, 0x52, 0x6d, 0xd8, 0x66, 0xe7, 0xc3,
-	0x83, 0x56, 0xbb, 0xed, 0x39, 0x8e, 0xe1, 0x5a, 0x8f, 0x47, 0x78, 0x84, 0x0f, 0x08, 0x76, 0x26,
-	0xad, 0x0a, 0x29, 0x30, 0x6b, 0xf2, 0x33, 0xad, 0xaa, 0xd1, 0x7f, 0x51, 0x03, 0x16, 0x4d, 0x86,
-	0x12, 0xd4, 0xe7, 0xb7, 0x67, 0x77, 0x56, 0x34, 0xf1, 0xad, 0xfe, 0x58, 0x82, 0xcb, 0x5d, 0xec,
-	0x5a, 0x8f, 0x7c, 0x6f, 0xe8, 0xdb, 0x98, 0x18, 0xfe, 0xd9, 0x23, 0xe3, 0x6c, 0xe0, 0x19, 0x56,
-	0x34, 0xd1, 0x16, 0x2c, 0x3b, 0x86, 0xa9, 0x0f, 0xd9, 0x28, 0x9f, 0x0c, 0x1c, 0xc3, 0xe4, 0x7c,
-	0x74, 0x42, 0xc7, 0x36, 0xf9, 0xbe, 0xa0, 0xff, 0xa2, 0x2b, 0xb0, 0xd2, 0x37, 0x08, 0x3e, 0x35,
-	0xce, 0x74, 0xc7, 0x30, 0x83, 0xfa, 0x6c, 0x38, 0xe9, 0x32, 0x1f, 0x7b, 0x60, 0x98, 0x01, 0xba,
-	0x05, 0x17, 0x86, 0xde, 0xc0, 0xf0, 0xed, 0x5f, 0x85, 0x96, 0xd2, 0x6d, 0xf7, 0x04, 0xfb, 0x01,
-	0xb5, 0xf0, 0x5c, 0x18, 0x71, 0xe7, 0x93, 0xd4, 0x83, 0x88, 0x88, 0x36, 0x61, 0xa9, 0xe7, 0x53,
-	0xc5, 0x5c, 0x93, 0xed, 0x8e, 0xaa, 0x16, 0x0f, 0xd0, 0xbb, 0xc6, 0xf2, 0xf9, 0xb6, 0x28, 0x5b,
-	0xbe, 0xfa, 0xc7, 0x12, 0x2c, 0xdc, 0x61, 0x93, 0x66, 0xef, 0x21, 0x74, 0x03, 0x16, 0x07, 0x9e,
-	0xc9, 0x9c, 0xca, 0xce, 0x37, 0xa5, 0xc9, 0x9f, 0x3d, 0xf7, 0xf9, 0xb8, 0x26, 0x38, 0xe8, 0xbd,
-	0x11, 0xad, 0x68, 0xfc, 0x96, 0xe1, 0x94, 0xf8, 0xde, 0xd8, 0x81, 0xca, 0x33, 0xcf, 0xf0, 0xad,
-	0xa0, 0x3e, 0xb7, 0x3d, 0x1b, 0x22, 0xbb, 0x41, 0x93, 0x2b, 0xf2, 0x25, 0x25, 0x68, 0x9c, 0xae,
-	0x1e, 0xc1, 0x4a, 0x72, 0x9c, 0x7a, 0xb5, 0x37, 0xec, 0x1b, 0xba, 0x50, 0xb5, 0x42, 0x3f, 0xd9,
-	0xc5, 0xd5, 0xb3, 0x5d, 0xac, 0x8b, 0x27, 0x5d, 0x78, 0x3e, 0x30, 0x9b, 0x2b, 0x94, 0x22, 0x0e,
-	0xd4, 0x7b, 0xf8, 0x4c, 0xfd, 0x02, 0xce, 0xb1, 0x00, 0xe2, 0xe0, 0x91, 0x2f, 0xdf, 0x83, 0x05,
-	0xae, 0x2c, 0x0f, 0xe4, 0xe5, 0x84, 0x66, 0x5a, 0x44, 0x53, 0xdf, 0x0d, 0xaf, 0x8d, 0x8c, 0x6c,
-	0xf6, 0x22, 0xff, 0x6b, 0x19, 0x50, 0x92, 0x8b, 0x87, 0xf5, 0x74, 0x53, 0xbc, 0x9d, 0x0b, 0x06,
-	0xdd, 0x86, 0x6a, 0xcf, 0xf6, 0x03, 0xa2, 0x07, 0x18, 0xbb, 0x54, 0x7a, 0x6e, 0xa2, 0xf4, 0x72,
-	0x28, 0xd0, 0xc5, 0xd8, 0x6d, 0x11, 0xf4, 0x33, 0x58, 0x19, 0x18, 0x09, 0xf1, 0xf9, 0x89, 0xe2,
-	0x40, 0xf9, 0x99, 0x34, 0xf5, 0x0a, 0xbb, 0xde, 0x7e, 0x9a, 0x57, 0xde, 0x87, 0x73, 0xec, 0x8a,
-	0x9b, 0xe0, 0x98, 0xdf, 0x95, 0x45, 0x50, 0x75, 0x89, 0x41, 0x02, 0xf4, 0x09, 0x2c, 0x89, 0xb0,
-	0xe1, 0x33, 0x14, 0xda, 0x4b, 0x30, 0xa3, 0x26, 0xac, 0xfb, 0x2f, 0xf4, 0xa1, 0x61, 0x1e, 0x63,
-	0x12, 0xe8, 0x3e, 0x36, 0xb1, 0x7d, 0x82, 0x59, 0x2a, 0x36, 0xaf, 0xad, 0xf9, 0x2f, 0x1e, 0x31,
-	0x8a, 0xc6, 0x09, 0xe8, 0x63, 0xb8, 0x20, 0xe1, 0xd7, 0xbd, 0xe3, 0xd0, 0x4d, 0xf3, 0xda, 0xfa,
-	0x98, 0xc8, 0xc3, 0x63, 0x3a, 0x09, 0x91, 0x4c, 0x32, 0xc7, 0x26, 0x21, 0x63, 0x93, 0xdc, 0x00,
-	0x94, 0xe0, 0xc7, 0x8e, 0x4d, 0x08, 0xb6, 0x42, 0x57, 0xcc, 0x6b, 0x8a, 0x60, 0xef, 0xb0, 0x71,
-	0xf5, 0x3f, 0x25, 0xb8, 0x10, 0x87, 0x69, 0x68, 0x90, 0xc8, 0x70, 0x97, 0x01, 0xa2, 0x4d, 0x2d,
-	0x0c, 0xb8, 0xc4, 0x47, 0x0e, 0xe8, 0x62, 0x16, 0x6d, 0x97, 0x60, 0xff, 0xc4, 0x18, 0x84, 0x2b,
-	0xae, 0xed, 0x6e, 0x50, 0xbf, 0xb4, 0xfa, 0x7d, 0x1f, 0xf7, 0xf9, 0xb9, 0xc4, 0xc8, 0x9a, 0x60,
-	0x44, 0x6d, 0x58, 0x0d, 0x88, 0xe1, 0x93, 0x78, 0xa3, 0x4e, 0x11, 0xa1, 0xb5, 0x50, 0x44, 0x7c,
-	0xa3, 0x9f, 0x43, 0x15, 0xbb, 0x56, 0x02, 0x62, 0x72, 0x98, 0xae, 0x60, 0xd7, 0x12, 0x5f, 0x6a,
-	0x3b, 0x7c, 0xe2, 0xa4, 0xd7, 0xcc, 0xf7, 0xe7, 0x0e, 0x54, 0x7c, 0x1c, 0x8c, 0x06, 0xa4, 0x5e,
-	0x1a, 0x3b, 0x9b, 0x18, 0x27, 0xa7, 0xab, 0x7f, 0x2b, 0xc1, 0x2a, 0xbb, 0xe3, 0xc4, 0xe5, 0x93,
-	0x7f, 0xeb, 0x6c, 0xc1, 0x72, 0xcf, 0x77, 0xc4, 0x2d, 0xc1, 0x0e, 0x26, 0xe8, 0xf9, 0x4e, 0x74,
-	0x4b, 0xac, 0xc3, 0x7c, 0x98, 0x57, 0x84, 0xe6, 0xa8, 0x6a, 0x73, 0x34, 0x6b, 0x41, 0xe7, 0xa1,
-	0xd2, 0xd3, 0x87, 0x9e, 0x4f, 0xf8, 0x75, 0x35, 0xdf, 0x7b, 0xe4, 0xf9, 0x84, 0x9e, 0xf2, 0xa6,
-	0xe7, 0xf6, 0x6c, 0xdf, 0xe1, 0x8e, 0x5d, 0xd4, 0xe2, 0x81, 0xd4, 0xc5, 0x59, 0x49, 0x5f, 0x9c,
-	0x77, 0xa2, 0xca, 0x40, 0x46, 0xef, 0xc8, 0xe3, 0xd7, 0x60, 0xce, 0x26, 0xd8, 0xe1, 0x9b, 0x60,
-	0x3d, 0xbe, 0xc5, 0x63, 0xce, 0x90, 0x41, 0xfd, 0x1c, 0xb6, 0xf7, 0x07, 0xa3, 0xe0, 0x79, 0x82,
-	0xba, 0xef, 0xf9, 0x7b, 0xf8, 0xa4, 0x73, 0x74, 0x30, 0x31, 0xaf, 0xb8, 0x0d, 0xef, 0x8a, 0xbc,
-	0x42, 0x00, 0x07, 0xd3, 0xcb, 0x3f, 0x86, 0xab, 0xc5, 0xf2, 0xdc, 0x95, 0x1f, 0xc0, 0x3c, 0x55,
-	0x36, 0xe0, 0x9e, 0x94, 0x2e, 0x87, 0x71, 0x70, 0x95, 0x0e, 0xf1, 0x8b, 0x30, 0xd3, 0x1b, 0xd8,
-	0xee, 0x31, 0xcd, 0xe6, 0xa6, 0x57, 0xe9, 0xf3, 0x50, 0xa5, 0x02, 0x79, 0xae, 0x92, 0xf0, 0x72,
-	0x29, 0xf6, 0xb2, 0xda, 0x82, 0xed, 0x2e, 0xf1, 0xb1, 0xe1, 0xec, 0xfb, 0x86, 0x83, 0xef, 0x7b,
-	0x7d, 0xba, 0x96, 0xcc, 0x21, 0x56, 0xbc, 0x17, 0xd5, 0x3f, 0x97, 0xe0, 0x4a, 0x01, 0x06, 0x9f,
-	0xfd, 0x36, 0x28, 0xa3, 0x21, 0x55, 0x4e, 0xef, 0x51, 0x2e, 0x3d, 0xc0, 0x44, 0x54, 0x33, 0xfa,
-	0xa7, 0xcd, 0xa3, 0x90, 0x16, 0x02, 0x74, 0x31, 0xb9, 0x3b, 0xa3, 0xd5, 0x46, 0xa9, 0x11, 0xf4,
-	0x19, 0xd4, 0x2c, 0xbe, 0x3c, 0x86, 0xc0, 0x2f, 0xa6, 0x35, 0x2a, 0x2d, 0x16, 0x4e, 0x09, 0x77,
-	0x67, 0xb4, 0xaa, 0x95, 0x1c, 0xf8, 0x72, 0x01, 0xe6, 0x43, 0x11, 0xf5, 0x33, 0xd8, 0x1a, 0xd7,
-	0x74, 0xca, 0x44, 0xf6, 0x4f, 0x25, 0x99, 0xa9, 0x32, 0xef, 0xb7, 0xff, 0x89, 0x55, 0x3e, 0x0d,
-	0x2f, 0xff, 0xa7, 0x2c, 0x2d, 0x13, 0xaa, 0xd5, 0x61, 0x21, 0x4a, 0xe3, 0xa8, 0x46, 0x4b, 0x5a,
-	0xf4, 0x89, 0xde, 0xa7, 0xc7, 0x4e, 0x3f, 0x4a, 0xb6, 0x6a, 0xbb, 0xb5, 0x28, 0xd9, 0xd2, 0xc2,
-	0x51, 0x8d, 0x53, 0xd5, 0xdf, 0x96, 0xa0, 0x76, 0x27, 0x95, 0x4f, 0x8d, 0x65, 0x6e, 0x34, 0x9d,
-	0x7d, 0x6e, 0xb8, 0x2e, 0x1e, 0x04, 0xf5, 0xf2, 0xf6, 0xec, 0x4e, 0x55, 0x13, 0xdf, 0xa8, 0x03,
-	0x35, 0xfc, 0x82, 0xf8, 0x86, 0x2e, 0x38, 0x66, 0xc3, 0xbd, 0xf1, 0x4e, 0xe2, 0x94, 0xe3, 0xb8,
-	0x1d, 0xca, 0xd7, 0x66, 0x6c, 0x5a, 0x15, 0x27, 0xbe, 0x02, 0xf5, 0x5f, 0x25, 0x68, 0xe4, 0x73,
-	0xa3, 0x5d, 0x00, 0xc7, 0xb3, 0x46, 0x83, 0xf8, 0x49, 0x50, 0xdb, 0x45, 0xd1, 0x82, 0x1e, 0x08,
-	0x8a, 0x96, 0xe0, 0x4a, 0x67, 0xae, 0xe5, 0x6c, 0xe6, 0xba, 0x09, 0x4b, 0xcf, 0x0c, 0xd7, 0x3a,
-	0xb5, 0x2d, 0xf2, 0x9c, 0x9f, 0x90, 0xf1, 0x00, 0x35, 0xeb, 0x33, 0x9b, 0xf8, 0x06, 0xc1, 0xfc,
-	0x9c, 0x8c, 0x3e, 0xd1, 0x87, 0xb0, 0x16, 0x0c, 0x7d, 0x6c, 0x58, 0xb6, 0xdb, 0xd7, 0x7b, 0x86,
-	0x49, 0x3c, 0x9f, 0xe5, 0xf8, 0x55, 0x4d, 0x11, 0x84, 0x7d, 0x36, 0x1e, 0xd7, 0x64, 0xd3, 0x4b,
-	0x4b, 0x94, 0x02, 0x33, 0x39, 0x6e, 0xb2, 0x14, 0x98, 0x91, 0xa9, 0xa5, 0x93, 0xde, 0xb8, 0x26,
-	0x9b, 0xc5, 0x2e, 0xac, 0xc9, 0xca, 0x15, 0xc9, 0xa9, 0xc9, 0xe6, 0x20, 0xbf, 0x8a, 0xda, 0x6f,
-	0xbb, 0x26, 0xfb, 0x06, 0x1c, 0x21, 0x6a, 0xb2, 0xd3, 0xd9, 0xf6, 0xc7, 0x32, 0xd4, 0x1e, 0x8c,
-	0x06, 0xc4, 0x36, 0x8d, 0x80, 0xdc, 0xf1, 0xbd, 0xd1, 0x70, 0x6c, 0xbf, 0x6d, 0xc0, 0x82, 0x63,
-	0x26, 0x6b, 0x1f, 0x15, 0xc7, 0x0c, 0x4b, 0x1f, 0x5b, 0xb0, 0xe2, 0x98, 0xbc, 0xaa, 0x11, 0xd7,
-	0x3d, 0x96, 0x1c, 0xf3, 0xf0, 0xf4, 0xb8, 0x7b, 0x0f, 0x9f, 0xc5, 0xb7, 0xc1, 0x5c, 0xe2, 0xce,
-	0xbf, 0x05, 0xd0, 0xa7, 0xf3, 0xe8, 0xe4, 0x6c, 0x88, 0xc3, 0xdb, 0xbd, 0xb6, 0x7b, 0x81, 0x2e,
-	0x2c, 0xad, 0xc6, 0x93, 0xb3, 0x21, 0xd6, 0x96, 0xfa, 0xd1, 0xbf, 0xd9, 0xb7, 0x5d, 0x7a, 0x3f,
-	0x2d, 0x64, 0xf7, 0xd3, 0x0e, 0x28, 0x43, 0xba, 0x25, 0x82, 0x81, 0x47, 0xf4, 0x21, 0xf6, 0x6d,
-	0xcf, 0xe2, 0xf5, 0x8e, 0x1a, 0x1d, 0xef, 0x0e, 0x3c, 0xf2, 0x28, 0x1c, 0xcd, 0xa9, 0x1f, 0x2e,
-	0xbd, 0x54, 0xfd, 0x10, 0xe4, 0xf5, 0xc3, 0x78, 0xc3, 0xa5, 0x97, 0x96, 0xf0, 0xb3, 0x13, 0x11,
-	0xf4, 0x70, 0xa5, 0x49, 0x3f, 0x67, 0x64, 0x6a, 0x4e, 0xea, 0x3b, 0xde, 0x70, 0x59, 0xec, 0xc2,
-	0x0d, 0x27, 0x57, 0x24, 0x67, 0xc3, 0xe5, 0x20, 0xbf, 0x8a, 0xda, 0x6f, 0x7b, 0xc3, 0xbd, 0x01,
-	0x47, 0x88, 0x0d, 0x37, 0x9d, 0x6d, 0x6d, 0xd8, 0x6e, 0x59, 0x16, 0xbb, 0xd2, 0x9f, 0x78, 0x72,
-	0x99, 0xdc, 0x2c, 0xfb, 0x06, 0xa0, 0x8c, 0xa2, 0x71, 0x65, 0x5c, 0x49, 0xeb, 0x75, 0x60, 0xa9,
-	0x2e, 0xbc, 0xa7, 0x61, 0xc7, 0x3b, 0xe1, 0xd9, 0xf0, 0xbe, 0xef, 0x39, 0x6f, 0x74, 0xbe, 0xdf,
-	0x97, 0x00, 0x89, 0x09, 0xe2, 0x37, 0x83, 0x1c, 0xa4, 0x24, 0x07, 0x89, 0xcf, 0x8c, 0xb2, 0xf4,
-	0x9d, 0x30, 0x9b, 0x7c, 0x27, 0x64, 0x1e, 0x1d, 0x73, 0xd9, 0x47, 0x87, 0x3a, 0x80, 0xed, 0x8e,
-	0xfb, 0x03, 0xd5, 0x64, 0x5c, 0xaf, 0x68, 0xf1, 0x77, 0xe1, 0x5c, 0xac, 0x5e, 0xc8, 0xab, 0x27,
-	0xde, 0x08, 0xe9, 0x93, 0x29, 0x16, 0x8e, 0x97, 0x24, 0xc6, 0xd4, 0xef, 0xe1, 0xc3, 0xf0, 0xd1,
-	0x90, 0x66, 0xdf, 0xf7, 0x7c, 0xb9, 0xd5, 0x5f, 0xca, 0x2e, 0xea, 0x2f, 0xa0, 0x99, 0xdc, 0x92,
-	0xa9, 0x77, 0xc1, 0xeb, 0xc0, 0xff, 0x35, 0xdc, 0x9c, 0x1a, 0x9f, 0x1f, 0x04, 0x5f, 0xc1, 0x79,
-	0x99, 0xe5, 0xa2, 0xf7, 0x48, 0x9e, 0xe9, 0xd6, 0xc7, 0x4d, 0x17, 0x5c, 0xdf, 0x84, 0x45, 0xed,
-	0x9b, 0xaf, 0x6d, 0xd7, 0xf2, 0x4e, 0xd1, 0x02, 0xcc, 0x6a, 0xdf, 0xfc, 0xbf, 0x32, 0xc3, 0xfe,
-	0xd9, 0x55, 0x4a, 0xd7, 0x07, 0xb0, 0x2e, 0x79, 0x76, 0x23, 0x80, 0x4a, 0xb7, 0xd3, 0x7e, 0x78,
-	0xb8, 0xa7, 0xcc, 0xd0, 0xff, 0x1f, 0x1c, 0x1c, 0x1e, 0x3d, 0xe9, 0x28, 0x25, 0xb4, 0x08, 0x73,
-	0x77, 0x1f, 0x1e, 0x69, 0x4a, 0x99, 0x22, 0xec, 0xb5, 0xbe, 0x55, 0x66, 0xe9, 0xd0, 0xd7, 0x9d,
-	0xce, 0x3d, 0x65, 0x0e, 0x2d, 0xc1, 0xfc, 0x83, 0x87, 0x87, 0x4f, 0xee, 0x2a, 0xf3, 0x68, 0x19,
-	0x16, 0x1e, 0x1f, 0xb5, 0xb4, 0x27, 0x1d, 0x4d, 0xa9, 0x50, 0x8e, 0x6f, 0x3b, 0x2d, 0x4d, 0x59,
-	0xb8, 0xde, 0x4c, 0x84, 0xb1, 0xb8, 0x8b, 0x28, 0x73, 0xfb, 0x7e, 0xab, 0xdb, 0xd5, 0xdb, 0xca,
-	0x4c, 0xfc, 0xf1, 0xa5, 0x52, 0xda, 0xfd, 0xf7, 0x16, 0x9c, 0x3b, 0xc4, 0xe4, 0xd4, 0xf3, 0x8f,
-	0xbb, 0xd8, 0x3f, 0xc1, 0x3e, 0x6f, 0x91, 0xa3, 0xef, 0xa3, 0x32, 0x5c, 0xba, 0x67, 0x8e, 0xb6,
-	0xa8, 0x65, 0x0a, 0x7e, 0x32, 0xd1, 0xd8, 0xce, 0x67, 0x60, 0xb6, 0x57, 0x67, 0x90, 0x16, 0x16,
-	0xe9, 0x32, 0xc8, 0x9b, 0x61, 0x86, 0x90, 0xf3, 0x03, 0x88, 0xc6, 0xe5, 0x1c, 0xaa, 0xc0, 0x7c,
-	0x1c, 0x55, 0xa8, 0x64, 0x0a, 0x17, 0xfc, 0xb4, 0xa0, 0x71, 0x61, 0xec, 0x1c, 0xee, 0x38, 0x43,
-	0x72, 0xc6, 0x20, 0x65, 0xbf, 0x1b, 0x60, 0x90, 0x05, 0xbf, 0x28, 0x28, 0x80, 0x14, 0x66, 0x4d,
-	0xb7, 0x9d, 0x93, 0x66, 0x95, 0x36, 0xa4, 0x93, 0x66, 0x95, 0x37, 0xf8, 0x85, 0x59, 0x33, 0xc8,
-	0x91, 0x59, 0xe5, 0xb0, 0x97, 0x73, 0xa8, 0xe3, 0x66, 0x95, 0x29, 0x5c, 0xd0, 0x9d, 0x9f, 0xc6,
-	0xac, 0x32, 0xc8, 0x82, 0xa6, 0x7c, 0x01, 0xe4, 0x37, 0xe9, 0xae, 0x64, 0x84, 0xf8, 0x4e, 0x6c,
-	0x34, 0x59, 0x83, 0xb7, 0xb1, 0x95, 0x4b, 0x17, 0xeb, 0x7f, 0x98, 0x68, 0x5a, 0x46, 0xb0, 0x97,
-	0xb8, 0xd1, 0xa4, 0x98, 0x9b, 0x72, 0x62, 0x02, 0x70, 0x5d, 0xd2, 0xca, 0x66, 0xaa, 0xe6, 0xf7,
-	0xb8, 0x0b, 0xd6, 0xfe, 0x30, 0xdd, 0x3e, 0x4c, 0x01, 0xe6, 0x37, 0xb7, 0x0b, 0x00, 0x5b, 0xb0,
-	0x92, 0xb4, 0x09, 0xda, 0xc8, 0x5a, 0x69, 0x32, 0xc4, 0x67, 0xb0, 0x24, 0x4c, 0x80, 0xce, 0xa5,
-	0x2c, 0x12, 0x09, 0x9f, 0xcf, 0x8c, 0x0a, 0x03, 0xb5, 0x60, 0x25, 0x69, 0x07, 0x36, 0xbd, 0xa4,
-	0xb7, 0x5a, 0xbc, 0x82, 0xe4, 0xca, 0x19, 0x84, 0xa4, 0xc7, 0x5a, 0x00, 0xd1, 0x81, 0x5a, 0xba,
-	0x4f, 0x88, 0x2e, 0x86, 0x15, 0x54, 0x59, 0x77, 0xaf, 0x00, 0xe6, 0x00, 0x94, 0x6c, 0x4b, 0x90,
-	0x85, 0x4f, 0x4e, 0xa3, 0xb0, 0x38, 0xc6, 0x25, 0x2d, 0x3f, 0xe6, 0xe7, 0xfc, 0x16, 0x22, 0x8b,
-	0xf1, 0x82, 0x5e, 0xa1, 0x3a, 0x83, 0xba, 0x70, 0x5e, 0x5a, 0x7a, 0x44, 0xdb, 0x59, 0xcf, 0x67,
-	0x33, 0x90, 0xc2, 0x93, 0xee, 0x62, 0x6e, 0x19, 0x12, 0x5d, 0xa5, 0xc0, 0x93, 0xaa, 0x94, 0x05,
-	0xe0, 0x01, 0x6c, 0x16, 0x95, 0x19, 0xd1, 0xb5, 0xd4, 0xa2, 0xf3, 0x0b, 0x99, 0x8d, 0x9d, 0xc9,
-	0x8c, 0xc2, 0x4c, 0x6c, 0xd2, 0xdc, 0x42, 0xa2, 0x98, 0x74, 0x52, 0xa9, 0x52, 0x4c, 0x3a, 0xb1,
-	0x26, 0xa9, 0xce, 0xa0, 0xaf, 0xc2, 0xf3, 0x27, 0xd5, 0x86, 0x45, 0x39, 0x76, 0x11, 0x47, 0x8f,
-	0xb4, 0x69, 0xcb, 0x5c, 0x92, 0xdb, 0x9b, 0x65, 0x2e, 0x99, 0xd4, 0xba, 0x2d, 0x70, 0xc9, 0x11,
-	0x5c, 0x90, 0x37, 0x63, 0xd1, 0x15, 0xf6, 0x13, 0xbd, 0x82, 0x46, 0x6d, 0x01, 0x6c, 0x1b, 0xaa,
-	0xa9, 0xe2, 0x0c, 0xaa, 0xc7, 0x7a, 0xa6, 0xeb, 0xb0, 0x05, 0x20, 0x5f, 0x00, 0xc4, 0x45, 0x18,
-	0x14, 0x9d, 0x3c, 0x63, 0xe2, 0x99, 0x61, 0x61, 0xb7, 0x36, 0x54, 0x53, 0x35, 0x0f, 0xa6, 0x83,
-	0xac, 0x1f, 0x56, 0xbc, 0x90, 0x54, 0x71, 0x83, 0x81, 0xc8, 0xba, 0x62, 0xd3, 0xa4, 0x0f, 0x99,
-	0x3a, 0xe3, 0xd6, 0x98, 0x51, 0xf2, 0xd3, 0x07, 0x79, 0x2d, 0x4a, 0xa4, 0x0f, 0x19, 0xe4, 0xcd,
-	0xb4, 0x55, 0x72, 0xd2, 0x87, 0x5c, 0xcc, 0xc7, 0x99, 0xbe, 0xa1, 0x24, 0x7d, 0x90, 0x23, 0x4f,
-	0x91, 0x3e, 0xc8, 0x20, 0x0b, 0xea, 0x47, 0x05, 0x90, 0xf7, 0x61, 0x35, 0xd3, 0x73, 0x42, 0x8d,
-	0xf4, 0xca, 0x92, 0xcd, 0xb7, 0xc6, 0x25, 0x29, 0x4d, 0xac, 0x79, 0x00, 0x17, 0x73, 0xeb, 0xfd,
-	0x6c, 0x9b, 0x4d, 0x6a, 0x29, 0x34, 0xde, 0x9b, 0xc0, 0x15, 0xcd, 0xf5, 0x7f, 0x25, 0x64, 0x43,
-	0x3d, 0xaf, 0xec, 0x8e, 0xde, 0x95, 0xc3, 0xa4, 0x6f, 0x9c, 0xab, 0xc5, 0x4c, 0x89, 0xa9, 0x44,
-	0xf4, 0x65, 0xaa, 0x6e, 0x89, 0xe8, 0x93, 0x3e, 0xe7, 0x92, 0xd1, 0x27, 0x7f, 0x8f, 0x89, 0xe8,
-	0xcb, 0x20, 0x47, 0xd1, 0x27, 0x87, 0xbd, 0x9c, 0x43, 0x1d, 0x8f, 0x3e, 0x99, 0xc2, 0x05, 0x55,
-	0x95, 0x69, 0xa2, 0x4f, 0x06, 0x59, 0x50, 0x4c, 0x29, 0xbe, 0x29, 0x73, 0xcb, 0x2a, 0x2c, 0x5e,
-	0x26, 0x55, 0x5d, 0x0a, 0xc0, 0x31, 0xbc, 0x53, 0x5c, 0x48, 0x41, 0x1f, 0x84, 0xbf, 0x96, 0x9d,
-	0xa6, 0xd8, 0x52, 0xbc, 0x86, 0xdc, 0x6a, 0x05, 0x5b, 0xc3, 0xa4, 0x62, 0x46, 0x01, 0xf8, 0x0f,
-	0x70, 0x75, 0x9a, 0xe2, 0x04, 0xba, 0x29, 0xb2, 0x8a, 0xe9, 0xca, 0x18, 0x05, 0x53, 0xfe, 0xa1,
-	0x04, 0xd7, 0xa6, 0xac, 0x29, 0xa0, 0xdd, 0x6c, 0x18, 0x4e, 0x2e, 0x70, 0x34, 0x3e, 0x7e, 0x29,
-	0x19, 0x11, 0xd0, 0xb7, 0xc3, 0x8b, 0x8c, 0xb7, 0xae, 0x72, 0xf3, 0x80, 0xe8, 0x26, 0xcb, 0xb4,
-	0xb8, 0xd4, 0x99, 0x67, 0x95, 0x90, 0xf3, 0xe3, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x27, 0xc4,
-	0xef, 0x1f, 0x7d, 0x31, 0x00, 0x00,
+	// 6156 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3c, 0x4b, 0x73, 0x1b, 0x47,
+	0x7a, 0x1a, 0x80, 0x78, 0x7d, 0x24, 0x41, 0xb0, 0xf9, 0x1a, 0x82, 0x94, 0x04, 0x8f, 0x64, 0x9b,
+	0x92, 0x65, 0xca, 0xa6, 0xd7, 0x1b, 0x5b, 0xf6, 0x7a, 0x17, 0xe2, 0x43, 0xa2, 0x4d, 0x8a, 0xf4,
+	0x90, 0xb2, 0x65, 0x6f, 0x2a, 0x53, 0x43, 0x4c, 0x03, 0x9a, 0x25, 0x30, 0x03, 0xf7, 0x0c, 0x48,
+	0x70, 0xab, 0x52, 0x79, 0x5c, 0x72, 0x48, 0x6a, 0xf7, 0x92, 0xe4, 0x90, 0xaa, 0x54, 0xa5, 0x52,
+	0xa9, 0x54, 0xe5, 0x90, 0x3f, 0x90, 0x1f, 0x90, 0xc3, 0x1e, 0x92, 0x43, 0x4e, 0xd9, 0x43, 0xaa,
+	0x72, 0xcb, 0x25, 0x95, 0x43, 0x0e, 0x39, 0xa5, 0x92, 0xea, 0xc7, 0x3c, 0x31, 0x33, 0x80, 0xac,
+	0x75, 0x94, 0xc3, 0x9e, 0x80, 0xe9, 0xef, 0xd1, 0xdd, 0xdf, 0xf7, 0xf5, 0xd7, 0x5f, 0x7f, 0xfd,
+	0x80, 0xb2, 0xe5, 0x6c, 0xf6, 0x89, 0xed, 0xda, 0x28, 0x67, 0x39, 0xf5, 0x9b, 0x1d, 0xdb, 0xee,
+	0x74, 0xf1, 0x7d, 0x56, 0x72, 0x36, 0x68, 0xdf, 0x77, 0xcd, 0x1e, 0x76, 0x5c, 0xbd, 0xd7, 0xe7,
+	0x48, 0xf5, 0x1b, 0x71, 0x04, 0x63, 0x40, 0x74, 0xd7, 0xb4, 0x2d, 0x01, 0x5f, 0x8b, 0xc3, 0x71,
+	0xaf, 0xef, 0x5e, 0xa5, 0x11, 0x5f, 0x12, 0xbd, 0xdf, 0xc7, 0x44, 0xb4, 0xa0, 0xbe, 0xa2, 0xf7,
+	0xcd, 0xfb, 0x2d, 0xbb, 0xd7, 0xb3, 0x2d, 0xf1, 0x23, 0x00, 0x73, 0x14, 0xd0, 0xb9, 0xbc, 0xdf,
+	0xb9, 0x14, 0x05, 0xd5, 0x3e, 0xb1, 0xdb, 0x66, 0x17, 0x0b, 0x4a, 0xe5, 0x6b, 0x58, 0xdb, 0x26,
+	0x58, 0x77, 0xf1, 0x09, 0x26, 0x17, 0x66, 0x0b, 0x1f, 0x73, 0xb0, 0x8a, 0xbf, 0x19, 0x60, 0xc7,
+	0x45, 0x1f, 0xc1, 0x9c, 0xc3, 0x01, 0x9a, 0x20, 0x94, 0xa5, 0x86, 0xb4, 0x31, 0xbd, 0x85, 0x36,
+	0x2d, 0x67, 0x33, 0x46, 0x53, 0x75, 0x22, 0xdf, 0xca, 0x26, 0xac, 0x27, 0xf3, 0x76, 0xfa, 0xb6,
+	0xe5, 0x60, 0x54, 0x85, 0x9c, 0x69, 0x30, 0x7e, 0x33, 0x6a, 0xce, 0x34, 0x94, 0xbb, 0x20, 0x3f,
+	0xc2, 0x6e, 0x72, 0x43, 0xe2, 0xb8, 0xff, 0x20, 0xc1, 0x6a, 0x02, 0xb2, 0xe0, 0xfc, 0x32, 0xcd,
+	0x46, 0x1f, 0x02, 0xb4, 0x58, 0xb3, 0x0d, 0x4d, 0x77, 0xe5, 0x1c, 0xa3, 0xab, 0x6f, 0x72, 0x0d,
+	0x6c, 0x7a, 0x1a, 0xd8, 0x3c, 0xf5, 0xf4, 0xab, 0x56, 0x04, 0x76, 0xd3, 0xa5, 0xa4, 0x83, 0xbe,
+	0xe1, 0x91, 0xe6, 0xc7, 0x93, 0x0a, 0xec, 0xa6, 0x4b, 0x15, 0xf1, 0x94, 0x7d, 0x7c, 0x07, 0x8a,
+	0x78, 0x1b, 0xd6, 0x76, 0x70, 0x17, 0xbb, 0x78, 0x32, 0xd9, 0xfa, 0x36, 0xa1, 0xda, 0x03, 0xd7,
+	0xb4, 0x3a, 0xa3, 0x4d, 0x21, 0x1c, 0x90, 0xd4, 0x94, 0x18, 0x4d, 0x95, 0x44, 0xbe, 0x03, 0x9b,
+	0x88, 0xf3, 0xce, 0xb4, 0x89, 0xe4, 0x86, 0xa4, 0xd8, 0x44, 0x0a, 0xe7, 0x97, 0x69, 0xf6, 0xab,
+	0xb6, 0x89, 0xef, 0x40, 0x11, 0xbe, 0x4d, 0x4c, 0x26, 0xdb, 0xcf, 0x60, 0x6d, 0xaf, 0x3b, 0x70,
+	0x9e, 0xef, 0x60, 0xdd, 0x38, 0xc0, 0xae, 0x8b, 0xc9, 0xe7, 0x03, 0x3c, 0xf0, 0xd1, 0xef, 0x01,
+	0x8a, 0x35, 0x45, 0xf3, 0xc9, 0x6b, 0xd1, 0x9a, 0xf7, 0x0d, 0xe5, 0x0b, 0xa8, 0x73, 0x23, 0xd8,
+	0xc1, 0x09, 0xe6, 0xf8, 0x01, 0x54, 0x0d, 0x9c, 0x60, 0xe9, 0xf3, 0xb4, 0x57, 0x51, 0x8a, 0x59,
+	0x03, 0xc7, 0xec, 0x3c, 0x91, 0x6f, 0x8a, 0x6d, 0xdd, 0x81, 0x95, 0x47, 0xd8, 0x4d, 0x6c, 0x43,
+	0x1c, 0xf5, 0x17, 0x12, 0xc8, 0xa3, 0xb8, 0x82, 0xef, 0xb7, 0x6e, 0xf0, 0x2b, 0x32, 0xab, 0x2f,
+	0xa0, 0xce, 0xcd, 0xea, 0x57, 0x2c, 0xfe, 0x7b, 0x50, 0xe7, 0x26, 0x35, 0x91, 0x48, 0x7f, 0x2f,
+	0x07, 0x45, 0x8e, 0x88, 0x56, 0xa0, 0x64, 0xe0, 0x0b, 0x0d, 0x0f, 0x4c, 0x01, 0x2f, 0x1a, 0xf8,
+	0x62, 0x77, 0x60, 0xa2, 0xbb, 0x30, 0x1f, 0x6d, 0x0b, 0xb5, 0xaa, 0x1c, 0x43, 0x99, 0x8b, 0xd4,
+	0xbd, 0x6f, 0x50, 0x13, 0x8c, 0x79, 0x48, 0x8a, 0x9c, 0xe7, 0x26, 0x18, 0x75, 0x88, 0x1c, 0x3b,
+	0xc1, 0x60, 0xa7, 0x92, 0x0d, 0x16, 0xbd, 0x09, 0x35, 0xe7, 0xdc, 0xec, 0x6b, 0x6d, 0xad, 0x65,
+	0xb9, 0x5a, 0xeb, 0x39, 0x6e, 0x9d, 0xcb, 0x85, 0x86, 0xb4, 0x51, 0x56, 0x67, 0x69, 0xf9, 0xde,
+	0xb6, 0xe5, 0x6e, 0xd3, 0x42, 0xf4, 0x36, 0x20, 0x82, 0xdb, 0x98, 0x60, 0xab, 0x85, 0x35, 0xbd,
+	0xeb, 0x9a, 0xee, 0xc0, 0xc0, 0x72, 0xb1, 0x21, 0x6d, 0x48, 0xea, 0xbc, 0x0f, 0x69, 0x0a, 0x80,
+	0xf2, 0x21, 0x2c, 0x84, 0x0d, 0xd6, 0x13, 0x95, 0x02, 0x45, 0xde, 0x3b, 0x21, 0x7a, 0x08, 0x44,
+	0xaf, 0x0a, 0x88, 0xf2, 0x16, 0xd4, 0x7c, 0x83, 0xf4, 0xe8, 0xd2, 0xe4, 0xa8, 0xfc, 0xad, 0x04,
+	0xf3, 0x21, 0x6c, 0x61, 0xb7, 0x13, 0x54, 0xf3, 0x8a, 0x2c, 0xf4, 0x43, 0x58, 0x08, 0x5b, 0xe8,
+	0x8b, 0xc8, 0x65, 0x13, 0x16, 0xc2, 0x46, 0x38, 0x56, 0x34, 0x7f, 0x97, 0x83, 0x1a, 0x47, 0x6d,
+	0xb6, 0x5c, 0xf3, 0x82, 0x85, 0x64, 0xe9, 0x06, 0xb9, 0x0a, 0x65, 0x0a, 0xd0, 0x0d, 0x83, 0x08,
+	0x3b, 0xa4, 0x88, 0x4d, 0xc3, 0x20, 0xe8, 0x36, 0xcc, 0x39, 0x9a, 0x75, 0x79, 0xae, 0x39, 0x9a,
+	0x69, 0xb9, 0xda, 0x39, 0xbe, 0x12, 0xc6, 0x37, 0xed, 0x3c, 0xb9, 0x3c, 0x3f, 0xd9, 0xb7, 0xdc,
+	0xcf, 0xf0, 0x15, 0xc5, 0x6a, 0xc7, 0xb0, 0xb8, 0xd1, 0x4d, 0xb7, 0x43, 0x58, 0xaf, 0xc1, 0x2c,
+	0xc7, 0xc1, 0x56, 0x8b, 0xe1, 0x14, 0x18, 0x0e, 0x58, 0x97, 0xe7, 0x27, 0xbb, 0x56, 0x8b, 0xa2,
+	0xc8, 0x50, 0xe6, 0xd6, 0x38, 0xe8, 0x33, 0xfb, 0x9a, 0x55, 0x8b, 0xed, 0x6d, 0xcb, 0x7d, 0xda,
+	0x47, 0x37, 0x61, 0xc6, 0x12, 0x96, 0x6a, 0xd8, 0x97, 0x96, 0x5c, 0x62, 0xd0, 0x8a, 0x45, 0xad,
+	0x74, 0xc7, 0xbe, 0xb4, 0x28, 0x82, 0x1e, 0x46, 0x28, 0x73, 0x04, 0xdd, 0x47, 0x48, 0x32, 0xf7,
+	0x4a, 0x82, 0xb9, 0x2b, 0x5f, 0xc3, 0x92, 0x90, 0x5a, 0x4c, 0xdc, 0x4d, 0x7f, 0xe0, 0xea, 0xbe,
+	0x54, 0x85, 0xd2, 0x16, 0x03, 0xa5, 0x05, 0x12, 0x57, 0x6b, 0x46, 0xac, 0x44, 0xd9, 0x82, 0x95,
+	0x1d, 0xac, 0x27, 0x72, 0x4f, 0x55, 0xe6, 0xfb, 0x50, 0xf7, 0xcd, 0x3c, 0xc4, 0x7c, 0x1c, 0xd9,
+	0xdf, 0x48, 0xb0, 0x96, 0x48, 0x27, 0x06, 0xca, 0xcb, 0xf7, 0x06, 0x3d, 0x02, 0x24, 0x58, 0x38,
+	0xd8, 0x71, 0x4c, 0xdb, 0xd2, 0x5c, 0xb7, 0x2b, 0xc6, 0xd3, 0xea, 0xc8, 0xa0, 0xd8, 0x19, 0x90,
+	0x08, 0xa3, 0x13, 0x4e, 0x73, 0xea, 0x76, 0x95, 0xff, 0x9c, 0x87, 0xd9, 0x9d, 0x70, 0xe1, 0xb7,
+	0x32, 0xd6, 0x55, 0x28, 0xff, 0xc4, 0x36, 0x2d, 0x46, 0xc4, 0xad, 0xb4, 0x44, 0xbf, 0x29, 0xd5,
+	0x4d, 0x98, 0xee, 0xe9, 0x2d, 0xed, 0x02, 0x13, 0xca, 0x9d, 0x59, 0x67, 0x45, 0x85, 0x9e, 0xde,
+	0xfa, 0x82, 0x97, 0x24, 0x3b, 0xe5, 0xc2, 0x8b, 0x38, 0xe5, 0xe2, 0x0b, 0x39, 0xe5, 0x52, 0x8a,
+	0x53, 0x0e, 0x8f, 0x80, 0x72, 0xe6, 0x08, 0xa8, 0x8c, 0x1b, 0x01, 0x10, 0x1f, 0x01, 0xeb, 0x00,
+	0x2d, 0xdb, 0x6a, 0x73, 0x1c, 0x79, 0x9a, 0x81, 0xcb, 0xb4, 0x84, 0x62, 0x24, 0x8e, 0x8f, 0x99,
+	0xa4, 0xe9, 0xe0, 0x0e, 0x54, 0xc8, 0x50, 0xbb, 0x34, 0x2d, 0xc3, 0xbe, 0x94, 0x67, 0x1b, 0xd2,
+	0x46, 0x75, 0x6b, 0x86, 0xc5, 0x66, 0xcf, 0xbe, 0x64, 0x65, 0x6a, 0x99, 0x0c, 0xf9, 0x3f, 0xaa,
+	0x11, 0x32, 0xd4, 0x0c, 0xdc, 0xd5, 0xaf, 0xe4, 0x2a, 0xab, 0xaf, 0x44, 0x86, 0x3b, 0xf4, 0x13,
+	0x29, 0x30, 0x4b, 0x86, 0xef, 0x6a, 0x06, 0xd1, 0xec, 0x76, 0xdb, 0xc1, 0xae, 0x3c, 0xc7, 0xe0,
+	0xd3, 0x64, 0xf8, 0xee, 0x0e, 0x39, 0x62, 0x45, 0x68, 0x09, 0x8a, 0x64, 0xb8, 0xa5, 0x19, 0x44,
+	0xae, 0x31, 0x60, 0x81, 0x0c, 0xb7, 0x76, 0x08, 0xba, 0x45, 0x49, 0xb7, 0xb4, 0x36, 0xa1, 0x43,
+	0xc0, 0x6a, 0x5d, 0xc9, 0xf3, 0x0c, 0x3a, 0x43, 0x86, 0x5b, 0x7b, 0x5e, 0x19, 0xba, 0x0d, 0x55,
+	0x77, 0xa8, 0xf5, 0xed, 0x4b, 0x4c, 0x34, 0xd3, 0x32, 0xf0, 0x50, 0x46, 0x1c, 0xcb, 0x1d, 0x1e,
+	0xd3, 0xc2, 0x7d, 0x5a, 0x46, 0xe7, 0x6f, 0x83, 0xc8, 0x0b, 0x0c, 0x92, 0x33, 0x08, 0xaa, 0x41,
+	0x5e, 0x37, 0x88, 0xbc, 0xc8, 0xfa, 0x4d, 0xff, 0xa2, 0x4f, 0x60, 0xbd, 0x67, 0x5a, 0x9a, 0x33,
+	0xe8, 0xf7, 0x6d, 0x42, 0xdd, 0x7e, 0x8c, 0xeb, 0x12, 0xa3, 0x95, 0x7b, 0xa6, 0x75, 0xe2, 0xa1,
+	0x9c, 0x86, 0x6b, 0xa0, 0xf4, 0xfa, 0x30, 0x9d, 0x7e, 0x59, 0xd0, 0xeb, 0xc3, 0x64, 0xfa, 0x55,
+	0x28, 0x5b, 0x67, 0x9a, 0x4b, 0x74, 0xcb, 0x91, 0x57, 0xb8, 0x08, 0xad, 0xb3, 0x53, 0xfa, 0x89,
+	0xbe, 0x0f, 0x2b, 0xd8, 0xd2, 0xcf, 0xba, 0xd8, 0xd0, 0x06, 0xfd, 0xae, 0x69, 0x9d, 0x6b, 0xad,
+	0xe7, 0xba, 0x65, 0xe1, 0xae, 0x23, 0xcb, 0x8d, 0xfc, 0xc6, 0xac, 0xba, 0x24, 0xc0, 0x4f, 0x19,
+	0x74, 0x5b, 0x00, 0xd1, 0x7d, 0x58, 0x10, 0x88, 0xbe, 0x0c, 0x4d, 0xec, 0xc8, 0xab, 0x8c, 0x06,
+	0x09, 0xd0, 0x5e, 0x00, 0x41, 0xef, 0xc0, 0xa2, 0xa8, 0xe0, 0xb9, 0xe9, 0xb8, 0x36, 0xb9, 0xd2,
+	0x5a, 0xf6, 0xc0, 0x72, 0xe5, 0x3a, 0x6b, 0x0f, 0xe2, 0xb0, 0xc7, 0x1c, 0xb4, 0x4d, 0x21, 0xe8,
+	0x6b, 0x58, 0xef, 0xea, 0x8e, 0xab, 0xd1, 0xa1, 0xea, 0xb8, 0xba, 0x3b, 0x70, 0x34, 0xc2, 0x1d,
+	0x16, 0x9f, 0x38, 0xd7, 0xc6, 0x4e, 0x9c, 0x32, 0xa5, 0xdf, 0xc1, 0x17, 0x27, 0x8c, 0x5a, 0xf5,
+	0x88, 0x9b, 0x2e, 0xda, 0x87, 0x05, 0xce, 0xdb, 0xbe, 0xb4, 0x58, 0xa3, 0xdc, 0x21, 0x65, 0xb9,
+	0x3e, 0x96, 0x65, 0x8d, 0xb1, 0x14, 0x54, 0xa7, 0xc3, 0xa6, 0x4b, 0x2d, 0xe9, 0x0c, 0xeb, 0x2d,
+	0xdb, 0xd2, 0xba, 0x76, 0xeb, 0x1c, 0x1b, 0xf2, 0x75, 0xa6, 0xf8, 0x19, 0x5e, 0x78, 0xc0, 0xca,
+	0x50, 0x03, 0x66, 0xfa, 0x74, 0xf4, 0x3a, 0x5d, 0xdb, 0xd5, 0xac, 0x33, 0xf9, 0x06, 0xeb, 0x35,
+	0xd0, 0xb2, 0x93, 0xae, 0xed, 0x3e, 0x39, 0x8b, 0x62, 0x18, 0x44, 0xbe, 0x19, 0xc5, 0xd8, 0x21,
+	0x68, 0x13, 0x16, 0x02, 0x8c, 0xc0, 0x70, 0x1b, 0x0c, 0x71, 0xde, 0x43, 0x0c, 0xac, 0x37, 0x39,
+	0xe4, 0x7a, 0x2d, 0x25, 0xe4, 0x42, 0xef, 0xc3, 0x8a, 0x50, 0x90, 0x71, 0x89, 0xbb, 0x5d, 0xcd,
+	0x35, 0x7b, 0x58, 0xfb, 0xde, 0x3b, 0xef, 0xf4, 0x1c, 0x59, 0x61, 0x3d, 0x12, 0xfa, 0xdb, 0xa1,
+	0x50, 0x2a, 0x10, 0x06, 0x43, 0x1f, 0xc2, 0xaa, 0x2f, 0xc4, 0x11, 0xc2, 0x5b, 0x8c, 0x70, 0xd9,
+	0x43, 0x88, 0x91, 0xbe, 0x0b, 0x4b, 0xa2, 0x46, 0x6a, 0xdd, 0xd8, 0x24, 0x7d, 0x61, 0xcf, 0xb7,
+	0xc3, 0x36, 0x71, 0xa8, 0x0f, 0x77, 0x4d, 0xd2, 0xe7, 0x96, 0x7c, 0x1f, 0x16, 0x4c, 0xcb, 0x71,
+	0xf5, 0x6e, 0x97, 0x4d, 0x03, 0x5a, 0x4f, 0x27, 0x1d, 0xd3, 0x92, 0x5f, 0x67, 0x9d, 0x42, 0x61,
+	0xd0, 0x21, 0x83, 0x50, 0xcf, 0x19, 0xb2, 0x9f, 0x33, 0xdd, 0x75, 0x31, 0xb9, 0x92, 0xdf, 0x60,
+	0x15, 0xd4, 0x0c, 0xcf, 0x34, 0x1e, 0xf2, 0x72, 0xe1, 0xc1, 0x3d, 0x6c, 0xc1, 0xfc, 0xcd, 0x86,
+	0xb4, 0x51, 0x50, 0xe7, 0x7c, 0x64, 0xc1, 0xf9, 0x08, 0x96, 0x23, 0x96, 0xd9, 0xc2, 0xe6, 0x05,
+	0x37, 0xcc, 0x8d, 0xb1, 0x56, 0xb4, 0x60, 0x04, 0x46, 0xc9, 0xe9, 0x9a, 0x2e, 0xfa, 0x11, 0x30,
+	0xe3, 0xd2, 0xc8, 0x50, 0x33, 0xad, 0xb6, 0xad, 0x51, 0x87, 0x76, 0xa7, 0x91, 0xdf, 0x98, 0xde,
+	0x5a, 0x09, 0xe6, 0x52, 0x31, 0xb7, 0xa9, 0xcf, 0xf6, 0xad, 0xb6, 0xad, 0xce, 0x52, 0x02, 0x75,
+	0x48, 0xff, 0x9f, 0x60, 0x1a, 0x58, 0xce, 0x30, 0x0e, 0x2e, 0xe7, 0x20, 0xdf, 0x6d, 0x48, 0x89,
+	0xd4, 0xa7, 0x9c, 0x1a, 0x28, 0xf2, 0x29, 0xa3, 0x46, 0x8f, 0x61, 0xd1, 0x77, 0xc8, 0x5a, 0xdf,
+	0xb7, 0x0e, 0xf9, 0x2d, 0xe6, 0x9b, 0x97, 0xc3, 0xbe, 0xf9, 0xd8, 0x87, 0xaa, 0x88, 0x0c, 0xe3,
+	0x65, 0xe8, 0x13, 0x58, 0x13, 0x5a, 0x25, 0x98, 0xb9, 0x9c, 0x9e, 0xc9, 0xe7, 0x75, 0x3e, 0xde,
+	0xef, 0x31, 0xd1, 0xaf, 0x72, 0x14, 0x35, 0x82, 0xc1, 0x87, 0xfd, 0x06, 0xd4, 0xa2, 0xce, 0xce,
+	0x20, 0xf2, 0xdb, 0x8c, 0xa8, 0x1a, 0x76, 0x70, 0x3b, 0xcc, 0xad, 0xb2, 0x7a, 0x74, 0x83, 0x50,
+	0xcf, 0xa0, 0x11, 0xfc, 0x13, 0xdc, 0x72, 0x83, 0xaa, 0x36, 0xb9, 0x5b, 0xa4, 0x38, 0x4d, 0x83,
+	0xa8, 0xf8, 0x1b, 0xd5, 0x43, 0xe0, 0x35, 0x6d, 0xc1, 0x92, 0xe7, 0xc3, 0x7a, 0xba, 0x73, 0x2e,
+	0xe8, 0xb1, 0x21, 0xdf, 0x67, 0x66, 0xeb, 0x39, 0xb8, 0x43, 0xdd, 0x39, 0x57, 0x05, 0x88, 0x06,
+	0x01, 0xb4, 0x3a, 0xc7, 0xb5, 0xfb, 0x7d, 0x6c, 0xc8, 0xef, 0x30, 0x4c, 0xd0, 0x0d, 0x72, 0xc2,
+	0x4b, 0xd0, 0x7d, 0x58, 0x8c, 0x37, 0x9f, 0x69, 0xf2, 0x5d, 0x86, 0x39, 0x1f, 0xed, 0x02, 0x55,
+	0xda, 0xc7, 0xb0, 0x16, 0x9a, 0x33, 0x2f, 0xf4, 0xae, 0x69, 0xe8, 0xa1, 0x4e, 0x6c, 0xb1, 0x4e,
+	0xac, 0x78, 0xd3, 0xe7, 0x17, 0x3e, 0x9c, 0xf5, 0x41, 0xf9, 0x79, 0x0e, 0x16, 0x22, 0xba, 0xe5,
+	0x96, 0x81, 0xae, 0x03, 0x74, 0x74, 0x17, 0x5f, 0xea, 0x57, 0x41, 0xbe, 0xa1, 0x22, 0x4a, 0xf6,
+	0x0d, 0x84, 0x60, 0x8a, 0x38, 0x8e, 0xc9, 0xa2, 0x9f, 0x82, 0xca, 0xfe, 0xd3, 0x59, 0xa2, 0x6b,
+	0x13, 0x5d, 0x73, 0x2c, 0xc2, 0x42, 0x1f, 0x49, 0x2d, 0xd1, 0xef, 0x13, 0x8b, 0xba, 0x9e, 0x29,
+	0x3a, 0xaa, 0xe5, 0xa9, 0xb1, 0x96, 0xcd, 0xf0, 0xd0, 0x22, 0x14, 0xce, 0x6c, 0x9d, 0xf0, 0xe8,
+	0x67, 0x56, 0xe5, 0x1f, 0x48, 0x86, 0x92, 0x6e, 0xb9, 0xd8, 0xb2, 0x74, 0x11, 0x98, 0x7b, 0x9f,
+	0xe8, 0x53, 0x58, 0x64, 0x5e, 0xc3, 0x31, 0xa9, 0xaf, 0xea, 0xf4, 0x1d, 0x0d, 0xf7, 0xed, 0xd6,
+	0x73, 0xb9, 0x34, 0x2e, 0x0c, 0x9c, 0xa7, 0x64, 0x27, 0x94, 0xea, 0x51, 0xdf, 0xd9, 0xa5, 0x34,
+	0xca, 0x7f, 0x49, 0xb0, 0x90, 0x60, 0xed, 0xe3, 0x24, 0xb2, 0x0e, 0x95, 0xc0, 0xa7, 0xe6, 0x78,
+	0xd8, 0xe3, 0x17, 0x88, 0x39, 0x3e, 0xef, 0xcf, 0xf1, 0xab, 0x50, 0xf6, 0xe6, 0x60, 0x26, 0x94,
+	0x82, 0x5a, 0x12, 0x31, 0x81, 0x2f, 0xab, 0xc2, 0x84, 0xb2, 0x5a, 0x80, 0x02, 0x0f, 0xa6, 0xb8,
+	0x4c, 0xa6, 0x68, 0xa8, 0x86, 0xde, 0x83, 0x92, 0x6e, 0x12, 0xc6, 0x67, 0xac, 0x0c, 0x3c, 0x4c,
+	0xba, 0x30, 0xf0, 0x83, 0x75, 0xcf, 0x1a, 0xc6, 0x45, 0xf8, 0xa7, 0x20, 0x8f, 0xd2, 0x8c, 0xa4,
+	0x6f, 0x44, 0x68, 0x3e, 0x9a, 0xf0, 0xf0, 0x48, 0x66, 0x23, 0xe1, 0xb8, 0x32, 0x84, 0x7b, 0xe1,
+	0x65, 0xaa, 0x28, 0xde, 0x1f, 0x71, 0xcf, 0xe3, 0x9a, 0x97, 0xe6, 0xef, 0x73, 0x69, 0xfe, 0x5e,
+	0xf9, 0x03, 0x09, 0x94, 0x84, 0xaa, 0xfd, 0xb8, 0x72, 0x5c, 0x85, 0x69, 0x7e, 0x30, 0xf7, 0xa2,
+	0x7e, 0x50, 0xf9, 0x23, 0x09, 0xe6, 0x9f, 0x86, 0xa3, 0x9a, 0x7d, 0x17, 0xf7, 0x02, 0x6d, 0x4b,
+	0x21, 0x6d, 0xaf, 0x40, 0x89, 0xf9, 0x0c, 0x8b, 0x88, 0x9e, 0x15, 0xa9, 0x9b, 0xb0, 0x48, 0x42,
+	0x00, 0x9a, 0x4f, 0x08, 0x40, 0x6f, 0xc1, 0xac, 0x67, 0xd9, 0xdc, 0x67, 0x4c, 0x71, 0x24, 0x51,
+	0xc8, 0x1d, 0x45, 0x1f, 0xa6, 0x9b, 0x3b, 0xea, 0x0e, 0x6e, 0x99, 0x6c, 0xad, 0xc2, 0x0d, 0x5a,
+	0xf2, 0x0d, 0x7a, 0xb4, 0xa6, 0x5c, 0x42, 0x4d, 0xe1, 0x40, 0x32, 0x1f, 0x0d, 0x24, 0x69, 0xd4,
+	0xdb, 0x3a, 0x97, 0xa7, 0x44, 0xd4, 0xdb, 0x3a, 0x57, 0xbe, 0x1f, 0x5a, 0x3b, 0x1e, 0xd0, 0x89,
+	0x1c, 0xbb, 0xc4, 0x6c, 0x39, 0x63, 0x4d, 0xf2, 0x5f, 0x25, 0x58, 0x4f, 0x26, 0x14, 0x76, 0x29,
+	0x02, 0x6c, 0x29, 0x08, 0xb0, 0x3f, 0x86, 0x6a, 0x34, 0xb8, 0x94, 0x73, 0x6c, 0xe2, 0x5c, 0xa2,
+	0xfa, 0x1a, 0x51, 0x82, 0x3a, 0x1b, 0x89, 0x36, 0xd1, 0xf7, 0x60, 0xb9, 0xaf, 0xb7, 0xce, 0xb1,
+	0xab, 0x75, 0x6d, 0xc7, 0xd1, 0xfa, 0x98, 0xb4, 0xb0, 0xe5, 0xea, 0x1d, 0x2c, 0xdc, 0xe0, 0x22,
+	0x87, 0x1e, 0xd8, 0x8e, 0x73, 0xec, 0xc3, 0xd0, 0x47, 0x30, 0xcf, 0x26, 0x5b, 0x3a, 0x1d, 0x18,
+	0x42, 0xac, 0xc2, 0x41, 0xce, 0xd1, 0x6a, 0x43, 0xd2, 0x56, 0xe7, 0x28, 0x66, 0xd3, 0x20, 0x5e,
+	0x81, 0xf2, 0x2e, 0x2c, 0x07, 0xc3, 0x2e, 0x1c, 0x9d, 0xa6, 0x8b, 0xe5, 0x4f, 0x73, 0xb0, 0x32,
+	0x42, 0x23, 0x24, 0xb2, 0x0e, 0x15, 0xfd, 0x42, 0x37, 0xbb, 0x34, 0x52, 0x17, 0x72, 0x09, 0x0a,
+	0xa8, 0xdf, 0xf5, 0x02, 0x1f, 0xae, 0x54, 0xef, 0x93, 0xce, 0x80, 0x78, 0xe8, 0x62, 0x62, 0xe9,
+	0x5d, 0xa1, 0x7b, 0xc7, 0x1e, 0x90, 0x16, 0xef, 0x78, 0x59, 0x5d, 0xf0, 0x80, 0xcc, 0x04, 0x4e,
+	0x18, 0x08, 0x3d, 0x80, 0x55, 0x41, 0xae, 0x75, 0xf1, 0x05, 0xee, 0x6a, 0x03, 0x2b, 0xa8, 0x9b,
+	0xab, 0x7f, 0x45, 0x20, 0x1c, 0x50, 0xf8, 0xd3, 0x00, 0x8c, 0x96, 0xa1, 0x28, 0x46, 0x70, 0x81,
+	0x39, 0x4d, 0xf1, 0x85, 0x3e, 0x82, 0xe9, 0x70, 0x00, 0x55, 0x1c, 0xeb, 0x3a, 0x81, 0xf8, 0x71,
+	0x93, 0xf2, 0x5e, 0xc8, 0x85, 0x1d, 0xd8, 0xad, 0xc9, 0x32, 0x1b, 0x7f, 0xc5, 0xb7, 0x44, 0xe2,
+	0x54, 0x13, 0xc9, 0xf3, 0x1e, 0x9d, 0x28, 0x39, 0x85, 0x48, 0x54, 0xd4, 0x36, 0xc5, 0xe6, 0xa2,
+	0xcf, 0xc9, 0xc7, 0xe0, 0x7d, 0x73, 0xec, 0xee, 0xc5, 0xa4, 0xe9, 0x3e, 0xf0, 0xd0, 0x9b, 0xae,
+	0xf2, 0x43, 0x50, 0xe2, 0xee, 0xd9, 0xd9, 0xb3, 0xc9, 0x0e, 0xcf, 0x56, 0x78, 0xbd, 0x0c, 0xe7,
+	0x33, 0xa4, 0x48, 0x3e, 0x43, 0xd1, 0xe1, 0x56, 0x26, 0x03, 0xd1, 0xe1, 0x07, 0x30, 0x17, 0x75,
+	0xf5, 0x8e, 0x2c, 0x35, 0xf2, 0xc9, 0xbe, 0xbe, 0x1a, 0xf1, 0xf5, 0x8e, 0xf2, 0x3e, 0xdf, 0x89,
+	0xd2, 0x2d, 0xc3, 0xee, 0xc5, 0xf9, 0x66, 0xb4, 0xcc, 0x84, 0x06, 0x4f, 0xf1, 0x1e, 0x36, 0xb7,
+	0xb7, 0xed, 0x5e, 0x4f, 0xb7, 0x0c, 0xb6, 0x73, 0xc2, 0x46, 0xe8, 0x38, 0x37, 0x5d, 0x83, 0x7c,
+	0x4b, 0xa4, 0xa5, 0x67, 0x55, 0xfa, 0x17, 0xd5, 0xa1, 0xdc, 0xe2, 0x5c, 0x1c, 0xb9, 0xd0, 0xc8,
+	0x6f, 0xcc, 0xa8, 0xfe, 0xb7, 0xf2, 0xbb, 0x12, 0x2c, 0x24, 0xd4, 0xe2, 0x71, 0x91, 0x22, 0x5c,
+	0x3c, 0x9b, 0x67, 0xaa, 0x2d, 0xab, 0xfe, 0x77, 0xa4, 0x86, 0x7c, 0xb4, 0x06, 0x1a, 0x16, 0x12,
+	0xec, 0x92, 0xa8, 0x03, 0x06, 0x56, 0xc4, 0xdd, 0xef, 0x87, 0x70, 0xe3, 0x11, 0x76, 0x13, 0x1a,
+	0x31, 0x7e, 0xe0, 0xff, 0x4c, 0x82, 0x9b, 0xa9, 0xb4, 0x42, 0xce, 0x6f, 0x43, 0xc1, 0xa4, 0x05,
+	0x42, 0x6b, 0x2c, 0xe4, 0x4f, 0x92, 0x2b, 0xc7, 0x42, 0x1f, 0xc3, 0x6c, 0x1f, 0x5b, 0x06, 0x5d,
+	0x4d, 0x72, 0xb2, 0x5c, 0x36, 0xd9, 0x8c, 0xc0, 0x66, 0x95, 0x2a, 0x87, 0xd0, 0xe0, 0x99, 0xe4,
+	0x97, 0xd0, 0x5c, 0xce, 0x97, 0xb9, 0xf2, 0x4b, 0x09, 0xae, 0x9f, 0x60, 0xcb, 0x38, 0x26, 0x76,
+	0x9f, 0x98, 0xd8, 0xd5, 0xc9, 0xd5, 0xb1, 0x7e, 0xd5, 0xb5, 0x75, 0xc3, 0x63, 0x26, 0x32, 0x6f,
+	0x7d, 0x5e, 0x2a, 0x18, 0xd2, 0xcc, 0x9b, 0xc0, 0xa3, 0x4c, 0x7b, 0x66, 0x4b, 0xe4, 0xf2, 0xe8,
+	0x5f, 0xf4, 0x1a, 0x78, 0xd3, 0x9f, 0xd6, 0xd3, 0x5b, 0x9e, 0xc2, 0xa6, 0x45, 0xd9, 0xa1, 0xde,
+	0x72, 0xd0, 0xfb, 0xb0, 0xdc, 0xb7, 0xbb, 0x3a, 0x31, 0x7f, 0xca, 0x63, 0x0b, 0xd3, 0x0a, 0xa7,
+	0xf6, 0xca, 0xea, 0x52, 0x18, 0xba, 0xef, 0x01, 0xa3, 0x81, 0x62, 0x21, 0x39, 0x50, 0x2c, 0x7a,
+	0xf3, 0xaa, 0xf2, 0xe7, 0x53, 0x50, 0x7a, 0xc4, 0x2b, 0x8d, 0x6f, 0xf4, 0xbc, 0xa0, 0x1f, 0xb9,
+	0x07, 0xc8, 0xeb, 0xd1, 0xe8, 0x36, 0x8e, 0x80, 0x04, 0x39, 0xc0, 0x0d, 0x28, 0xb2, 0xa0, 0xdb,
+	0x91, 0xa7, 0x98, 0x6a, 0x6b, 0x54, 0xb5, 0xa2, 0x21, 0x0f, 0x29, 0x40, 0x15, 0x70, 0x74, 0x87,
+	0xae, 0xb7, 0x4c, 0xcb, 0xc5, 0x96, 0x4e, 0x83, 0xef, 0x9e, 0x6d, 0x60, 0xb1, 0x85, 0x33, 0x17,
+	0x2a, 0x3f, 0xb4, 0x0d, 0x8c, 0xee, 0xc0, 0x94, 0xab, 0x77, 0x1c, 0xb9, 0x18, 0x4c, 0xae, 0x82,
+	0xe5, 0xe6, 0xa9, 0xde, 0x71, 0x76, 0x2d, 0x97, 0x5c, 0xa9, 0x0c, 0x85, 0x0d, 0x08, 0xc7, 0x31,
+	0xbd, 0xc4, 0x5c, 0x89, 0x4d, 0xa4, 0x40, 0x8b, 0x44, 0x5e, 0xee, 0x3a, 0x80, 0x63, 0xf9, 0x89,
+	0xbb, 0x32, 0x83, 0x57, 0x1c, 0xcb, 0x4b, 0xdb, 0x7d, 0x04, 0x75, 0xbe, 0xeb, 0xa1, 0x79, 0x02,
+	0xd0, 0xda, 0xc4, 0xee, 0xb1, 0xe5, 0xb6, 0x23, 0x72, 0xee, 0x2b, 0x1c, 0xc3, 0x93, 0xd5, 0x1e,
+	0xb1, 0x7b, 0x74, 0x5e, 0x74, 0xd0, 0x5b, 0x30, 0x6f, 0x98, 0x4e, 0xcb, 0xbe, 0xa0, 0x93, 0x94,
+	0xc8, 0x5f, 0xb1, 0x54, 0x66, 0x59, 0xad, 0xf9, 0x80, 0x5d, 0x5e, 0x4e, 0x2d, 0x45, 0x2c, 0x43,
+	0xb4, 0x8e, 0x6e, 0x5a, 0x2c, 0xa7, 0x29, 0xa9, 0xd3, 0xa2, 0xec, 0x91, 0x6e, 0x5a, 0x34, 0x37,
+	0x43, 0xe3, 0x33, 0x3f, 0xe2, 0x9f, 0x61, 0x93, 0x17, 0xf4, 0xf4, 0xa1, 0x48, 0xb3, 0xd5, 0x7f,
+	0x03, 0x2a, 0xbe, 0x04, 0xa8, 0x35, 0xd2, 0xad, 0x09, 0x89, 0x25, 0x88, 0xe9, 0x5f, 0xba, 0x1e,
+	0xba, 0xd0, 0xbb, 0x03, 0x1e, 0x46, 0x56, 0x54, 0xfe, 0xf1, 0x20, 0xf7, 0x81, 0xa4, 0x3c, 0x85,
+	0x99, 0xb0, 0x56, 0xe8, 0xb8, 0x69, 0xf7, 0x3b, 0x7a, 0xb0, 0x44, 0x29, 0xd2, 0x4f, 0x9e, 0x02,
+	0x6e, 0x9b, 0x16, 0xd6, 0xfc, 0xe3, 0x33, 0x6c, 0xfb, 0x83, 0x5b, 0x7c, 0x8d, 0x42, 0xfc, 0x09,
+	0xe4, 0x33, 0x7c, 0xa5, 0xfc, 0x00, 0x16, 0xb9, 0x73, 0x15, 0xcc, 0xbd, 0x91, 0xf4, 0x3a, 0x94,
+	0x84, 0xa9, 0x88, 0x58, 0x7e, 0x3a, 0xa4, 0x44, 0xd5, 0x83, 0x29, 0xb7, 0xd8, 0xae, 0x58, 0x8c,
+	0x36, 0xbe, 0x4f, 0xf9, 0x87, 0x05, 0x40, 0x61, 0x2c, 0xe1, 0x8a, 0x26, 0xab, 0xe2, 0xd5, 0xec,
+	0x9f, 0xa1, 0x4f, 0x60, 0xb6, 0x6d, 0x12, 0xc7, 0xd5, 0x1c, 0x8c, 0x2d, 0x4a, 0x3d, 0x7e, 0x45,
+	0x3b, 0xcd, 0x08, 0x4e, 0x30, 0xb6, 0x9a, 0x74, 0xb1, 0xce, 0x33, 0x2c, 0x1e, 0xf9, 0xf8, 0x45,
+	0x1e, 0x4b, 0xb2, 0x08, 0xea, 0xc7, 0x80, 0x8c, 0x81, 0x7b, 0xa5, 0xb5, 0xae, 0x5a, 0x5d, 0xac,
+	0x9d, 0x0d, 0x8c, 0x0e, 0x76, 0xbd, 0xd1, 0x54, 0x0f, 0x49, 0x69, 0x67, 0xe0, 0x5e, 0x6d, 0x53,
+	0x9c, 0x87, 0x0c, 0x45, 0xad, 0x19, 0xd1, 0x02, 0x87, 0x06, 0x52, 0x36, 0x4d, 0xa9, 0xf1, 0xe5,
+	0x61, 0x59, 0x15, 0x5f, 0xcc, 0x98, 0x07, 0xae, 0xad, 0x09, 0x61, 0xb1, 0x71, 0x55, 0x56, 0xa7,
+	0x69, 0x19, 0xb7, 0x07, 0x03, 0x7d, 0x0a, 0x0b, 0xfe, 0x90, 0x0a, 0x89, 0xb1, 0x32, 0xb6, 0x27,
+	0xf3, 0x1e, 0xd9, 0x53, 0x5f, 0x9c, 0xaf, 0x43, 0x95, 0xe6, 0xfe, 0xcd, 0x8e, 0xbf, 0x2b, 0x02,
+	0xcc, 0xc0, 0x67, 0x79, 0xa9, 0xb7, 0x31, 0x42, 0x93, 0xcc, 0xc3, 0x3e, 0x4b, 0xa0, 0x68, 0x31,
+	0xfc, 0x69, 0x86, 0xbf, 0xe4, 0x81, 0xb7, 0x23, 0x74, 0x34, 0x1d, 0x17, 0x4a, 0xd0, 0x86, 0x07,
+	0xdf, 0x9c, 0xe1, 0xe7, 0x60, 0xd9, 0x08, 0x54, 0xfe, 0x32, 0x07, 0xcb, 0xc9, 0xe2, 0xa3, 0x41,
+	0x88, 0x33, 0x38, 0xd3, 0xce, 0x74, 0xcb, 0x10, 0x83, 0xb2, 0xe4, 0x0c, 0xce, 0x1e, 0xea, 0x96,
+	0x41, 0x97, 0x4e, 0x34, 0x33, 0x1f, 0x5f, 0xf9, 0xcf, 0xf4, 0x4c, 0x2b, 0x48, 0xa4, 0x52, 0x24,
+	0x7d, 0x18, 0x42, 0x12, 0x8b, 0xb0, 0x9e, 0x3e, 0x0c, 0x90, 0xae, 0x03, 0x04, 0xba, 0x65, 0x66,
+	0x95, 0x53, 0x2b, 0xbe, 0xde, 0xa8, 0xe1, 0x0c, 0x1c, 0x2a, 0x69, 0xb1, 0xaa, 0x2f, 0x8c, 0x5b,
+	0xd5, 0x4f, 0x53, 0xf4, 0x26, 0xc7, 0x46, 0x7b, 0x30, 0x4f, 0x30, 0xf5, 0xc6, 0x74, 0xc6, 0xf6,
+	0x58, 0x14, 0xc7, 0xee, 0x91, 0xf9, 0x34, 0x82, 0x0f, 0x75, 0x0b, 0x5c, 0x79, 0xdf, 0xce, 0x2d,
+	0xbc, 0x01, 0x8b, 0x7c, 0xe2, 0x1f, 0xe3, 0x19, 0xfe, 0x22, 0x0f, 0x0b, 0x07, 0xa6, 0xe3, 0xb9,
+	0x06, 0x3f, 0xc4, 0x59, 0x84, 0x42, 0xd7, 0xec, 0x99, 0x7c, 0xf1, 0x9b, 0x57, 0xf9, 0x07, 0xb3,
+	0x65, 0x3e, 0x0b, 0xe4, 0x58, 0xb1, 0xf8, 0x42, 0xef, 0x8b, 0xd9, 0x26, 0xcf, 0xc6, 0xc7, 0x6b,
+	0xb4, 0x45, 0x09, 0x4c, 0x47, 0x66, 0x9e, 0x65, 0x28, 0x3a, 0x58, 0x27, 0xad, 0xe7, 0x62, 0x87,
+	0x4e, 0x7c, 0xa1, 0xb7, 0xa1, 0x6c, 0x13, 0x03, 0x13, 0xed, 0x8c, 0x4f, 0xdb, 0x55, 0x7e, 0x1a,
+	0x48, 0xb0, 0x3b, 0xa2, 0xa0, 0x87, 0x57, 0x6a, 0xc9, 0xe6, 0x7f, 0xa8, 0x3e, 0x39, 0xba, 0x81,
+	0x9d, 0x16, 0x93, 0x75, 0x59, 0xad, 0xb0, 0x92, 0x1d, 0xec, 0xb4, 0xa8, 0x23, 0xe1, 0x43, 0x4e,
+	0xbb, 0x34, 0xdd, 0xe7, 0xa6, 0x35, 0x3e, 0x4d, 0x33, 0xc3, 0xf1, 0xbf, 0x64, 0xe8, 0x68, 0x37,
+	0x61, 0xd6, 0x2d, 0xa7, 0x0c, 0xc1, 0x87, 0xb6, 0xdd, 0xfd, 0x82, 0xce, 0x18, 0x23, 0x33, 0xf2,
+	0xb7, 0x9f, 0x77, 0x30, 0x2c, 0x46, 0x85, 0x29, 0xbc, 0xf7, 0x4d, 0x98, 0x76, 0x6d, 0x57, 0xef,
+	0x8a, 0x40, 0x96, 0x2b, 0x0a, 0x58, 0x11, 0x4f, 0x9a, 0xde, 0x83, 0x22, 0xc1, 0xce, 0xa0, 0xeb,
+	0x8a, 0x98, 0x71, 0x31, 0xae, 0x17, 0x16, 0x05, 0x0a, 0x1c, 0xe5, 0xdf, 0x72, 0x50, 0x8b, 0x03,
+	0x7f, 0x3d, 0x43, 0xa4, 0xcf, 0x10, 0x81, 0x5f, 0x2f, 0x66, 0xfa, 0xf5, 0xd2, 0x88, 0x5f, 0x57,
+	0x7e, 0x7f, 0xca, 0x0f, 0x25, 0x78, 0x14, 0xf4, 0x01, 0x54, 0xfc, 0x60, 0x41, 0x96, 0xc6, 0x36,
+	0x23, 0x40, 0xa6, 0x3b, 0x4d, 0x64, 0xa8, 0xf1, 0xac, 0x47, 0xb0, 0xb5, 0x21, 0x92, 0xc5, 0xf3,
+	0x64, 0x78, 0xcc, 0x21, 0xde, 0xde, 0x05, 0x7a, 0x0f, 0x96, 0x13, 0xf0, 0x35, 0xfb, 0x9c, 0x89,
+	0xbe, 0xa0, 0x2e, 0x8c, 0x90, 0x1c, 0x9d, 0xd3, 0x4a, 0xdc, 0x84, 0x4a, 0x78, 0x36, 0x75, 0xde,
+	0x1d, 0xa9, 0xe4, 0x1e, 0xa0, 0x10, 0x3e, 0xee, 0x99, 0x2e, 0x15, 0x04, 0xcf, 0x23, 0xd4, 0x7c,
+	0xf4, 0x5d, 0x5e, 0x4e, 0x77, 0x11, 0xc2, 0xd8, 0x84, 0xd8, 0x3c, 0x2a, 0x2f, 0xa8, 0xd5, 0x00,
+	0x97, 0x96, 0xa2, 0x2f, 0x61, 0x2d, 0xd4, 0xf8, 0x3e, 0x26, 0x81, 0xa3, 0xd7, 0x9c, 0xb6, 0x5c,
+	0x62, 0x56, 0xbe, 0x1a, 0xb2, 0x50, 0x26, 0x5d, 0xf5, 0x99, 0xd7, 0xbe, 0x15, 0xbf, 0x73, 0xc7,
+	0x98, 0xf8, 0xf3, 0xc1, 0x49, 0x1b, 0x7d, 0x00, 0x40, 0x86, 0xbe, 0xb7, 0x2e, 0x8f, 0xf3, 0x0f,
+	0x15, 0x32, 0xf4, 0xdc, 0xfd, 0x07, 0x00, 0x6e, 0x40, 0x59, 0x19, 0x4b, 0xe9, 0x7a, 0x94, 0xca,
+	0xef, 0xc0, 0x52, 0x62, 0x2b, 0xa3, 0xab, 0x16, 0x29, 0xbe, 0x6a, 0xb9, 0x03, 0x35, 0xa7, 0x4f,
+	0xb0, 0xce, 0x56, 0x84, 0x6d, 0xbd, 0xe5, 0xda, 0x44, 0xcc, 0x84, 0x73, 0x7e, 0xf9, 0x1e, 0x2b,
+	0xa6, 0x7e, 0x31, 0x10, 0x97, 0xd0, 0x6f, 0xc5, 0x17, 0x81, 0xf2, 0xb3, 0x1c, 0xcb, 0x6c, 0x45,
+	0x1a, 0x21, 0xbc, 0xff, 0x98, 0x04, 0xfc, 0x7b, 0x50, 0xa6, 0xbe, 0x8d, 0x5c, 0x88, 0xa5, 0x77,
+	0x95, 0x2f, 0x47, 0x9b, 0x9d, 0x0e, 0xc1, 0x1d, 0xb1, 0x06, 0xe3, 0x60, 0xd5, 0x47, 0x44, 0xdb,
+	0x30, 0xe7, 0xb8, 0x3a, 0x71, 0x83, 0xb0, 0x78, 0x82, 0xd1, 0x5e, 0x65, 0x24, 0xfe, 0x37, 0xfa,
+	0x21, 0xcc, 0x62, 0xcb, 0x08, 0xb1, 0x18, 0x3f, 0xe4, 0x67, 0xb0, 0x65, 0x04, 0x0c, 0xea, 0x50,
+	0xa6, 0xc4, 0x3f, 0xb5, 0x2d, 0x3e, 0xb1, 0x57, 0x54, 0xff, 0x5b, 0xd9, 0x86, 0x95, 0x11, 0x79,
+	0x08, 0x5f, 0xbb, 0xe1, 0xbb, 0x52, 0x69, 0x64, 0x8d, 0xc6, 0x31, 0x3d, 0x37, 0xfa, 0xd7, 0x52,
+	0x10, 0xdc, 0x78, 0xeb, 0x97, 0x63, 0xd3, 0xea, 0xa8, 0xcf, 0x62, 0x5e, 0x52, 0x7a, 0x11, 0x2f,
+	0xc9, 0x8e, 0x4f, 0x68, 0x21, 0x9d, 0xf0, 0xd5, 0xc4, 0x34, 0x19, 0x3e, 0x1a, 0xd9, 0x28, 0xca,
+	0xa7, 0x6c, 0x14, 0x4d, 0x45, 0x36, 0x8a, 0x94, 0xbf, 0xe7, 0xb9, 0x8a, 0xa4, 0xb6, 0x4e, 0x6a,
+	0x07, 0x09, 0x2a, 0xcd, 0xbd, 0xbc, 0x4a, 0xf3, 0x2f, 0xa6, 0x52, 0xe5, 0x0b, 0x68, 0xa4, 0xf7,
+	0x43, 0xe8, 0x6f, 0x2b, 0xa6, 0xbf, 0x48, 0x08, 0x1f, 0x55, 0x93, 0xaf, 0xc9, 0xff, 0xc9, 0xc1,
+	0xcc, 0x13, 0xec, 0x5e, 0xda, 0xe4, 0xfc, 0xd7, 0x5e, 0x3a, 0xe6, 0x22, 0x8b, 0xdf, 0xda, 0x45,
+	0x96, 0x5e, 0xc0, 0x45, 0xfe, 0x87, 0xc4, 0x3c, 0x54, 0x58, 0x09, 0x9e, 0x65, 0x86, 0x5d, 0x90,
+	0xf4, 0x12, 0x2e, 0xe8, 0xff, 0xde, 0x5e, 0x23, 0x2e, 0x68, 0x2a, 0xe6, 0x82, 0xfe, 0x44, 0x82,
+	0x95, 0x91, 0x1e, 0x0b, 0x1b, 0x7e, 0x13, 0xe6, 0xc4, 0xd0, 0x73, 0x34, 0x11, 0x79, 0x48, 0x7c,
+	0x9a, 0xf4, 0x8a, 0x8f, 0x58, 0x29, 0x45, 0x8c, 0x67, 0x88, 0xb9, 0xa5, 0xc5, 0xd2, 0xc1, 0x21,
+	0xaf, 0x96, 0x0f, 0xbc, 0x5a, 0xa4, 0x6e, 0x6f, 0x2c, 0xfc, 0xbb, 0x04, 0x73, 0x3c, 0xb5, 0x1c,
+	0xa4, 0x64, 0x53, 0xf3, 0x86, 0x37, 0x61, 0xba, 0x4d, 0x7a, 0x7e, 0x0e, 0x90, 0xbb, 0x2a, 0x68,
+	0x93, 0x9e, 0x97, 0x03, 0xf4, 0x77, 0xd6, 0xf2, 0xa1, 0x9d, 0xb5, 0x25, 0x28, 0xb6, 0x35, 0xba,
+	0xdb, 0x2e, 0x52, 0xb2, 0x85, 0xf6, 0xb1, 0x4d, 0x5c, 0x3a, 0x1b, 0xb2, 0x75, 0x28, 0xe9, 0x09,
+	0xe3, 0x2c, 0xab, 0x41, 0x41, 0x24, 0x69, 0x5d, 0x8c, 0x1e, 0x0f, 0x5c, 0x87, 0x4a, 0xb0, 0x27,
+	0x58, 0x62, 0x72, 0x0e, 0x0a, 0x62, 0x9e, 0xad, 0x1c, 0xf3, 0x6c, 0xca, 0x23, 0xef, 0x8a, 0x47,
+	0xac, 0xd3, 0x9e, 0xf9, 0xbd, 0x09, 0x53, 0xa6, 0x8b, 0x7b, 0xc2, 0x0b, 0x2c, 0x04, 0x99, 0xf7,
+	0x00, 0x93, 0x21, 0x28, 0x1f, 0x41, 0x43, 0xdc, 0x39, 0xf0, 0xa1, 0x3c, 0xa7, 0xbf, 0xfb, 0x74,
+	0x7f, 0x6c, 0x3a, 0xf9, 0x93, 0xd0, 0x8e, 0x80, 0xcf, 0xd8, 0x99, 0x9c, 0xfe, 0x73, 0xb8, 0x9d,
+	0x4d, 0x2f, 0x2c, 0xeb, 0x4e, 0x34, 0x25, 0x9d, 0xd8, 0x1d, 0x8e, 0x21, 0x9a, 0xf4, 0x04, 0x0f,
+	0xfd, 0x93, 0x55, 0xf4, 0xa8, 0xc3, 0xe4, 0x4d, 0xfa, 0x08, 0x6e, 0x67, 0xd3, 0x8b, 0x26, 0x25,
+	0x6d, 0xbe, 0x2a, 0x4d, 0x68, 0x9c, 0xb8, 0x04, 0xeb, 0xbd, 0x3d, 0xa2, 0xf7, 0xf0, 0x81, 0xdd,
+	0xa1, 0x7d, 0x89, 0x2d, 0x70, 0xb3, 0xa7, 0x2c, 0xe5, 0xcf, 0x72, 0xf0, 0x5a, 0x06, 0x0f, 0x51,
+	0xfb, 0x27, 0x50, 0x13, 0x9b, 0x94, 0x6d, 0x8a, 0xc5, 0x4e, 0x85, 0x78, 0xd7, 0x52, 0x3a, 0x97,
+	0x62, 0x9b, 0x92, 0x31, 0x38, 0xc1, 0xee, 0xe3, 0x6b, 0x6a, 0x75, 0x10, 0x29, 0x41, 0x0f, 0xa0,
+	0xea, 0x67, 0x43, 0x18, 0x07, 0xe1, 0x67, 0xe6, 0x29, 0xb5, 0xdf, 0x71, 0x0a, 0x78, 0x7c, 0x4d,
+	0x9d, 0x35, 0xc2, 0x05, 0xf4, 0x46, 0x4c, 0xe4, 0xa8, 0x5b, 0xeb, 0x5c, 0xce, 0x8f, 0x12, 0x9f,
+	0x3e, 0x6b, 0xb6, 0xce, 0xc3, 0xc4, 0xa7, 0xc3, 0x66, 0xeb, 0x3c, 0x7c, 0x18, 0x61, 0x6a, 0xd2,
+	0xc3, 0x08, 0x0f, 0x4b, 0x50, 0x60, 0x8d, 0x54, 0x1e, 0xc0, 0xcd, 0x51, 0xd9, 0x4c, 0x78, 0x6c,
+	0xf9, 0x1f, 0xf3, 0xd0, 0x48, 0x27, 0xfe, 0x7f, 0x20, 0xd7, 0x2f, 0x61, 0xd5, 0x3b, 0x35, 0xa4,
+	0x8d, 0x34, 0xc2, 0xf3, 0xe1, 0xf4, 0xcc, 0x80, 0x40, 0x1a, 0x69, 0xcc, 0x32, 0x49, 0x84, 0xa0,
+	0x07, 0x80, 0xfc, 0x46, 0x05, 0x27, 0x65, 0xa7, 0x12, 0x4e, 0xca, 0xd6, 0x3c, 0x3c, 0xd5, 0x3b,
+	0x31, 0x1b, 0xd2, 0x57, 0x61, 0x52, 0x7d, 0xa1, 0xdf, 0x84, 0x1b, 0x7e, 0x85, 0x74, 0xb3, 0x45,
+	0x6c, 0x6d, 0xf1, 0xcd, 0x7e, 0xe6, 0x41, 0x8b, 0xc1, 0x8c, 0x18, 0x6c, 0xfc, 0x9c, 0x7a, 0x60,
+	0x75, 0xcd, 0x23, 0x3f, 0xd4, 0x5b, 0x71, 0x60, 0x60, 0x0d, 0xbf, 0x90, 0x60, 0x99, 0xeb, 0x2f,
+	0x22, 0xd9, 0x03, 0xbb, 0xc3, 0x8e, 0x9b, 0x44, 0xf5, 0x20, 0xa5, 0xe8, 0x21, 0xae, 0x85, 0xc8,
+	0x69, 0xe2, 0x5c, 0xe6, 0x69, 0xe2, 0xcf, 0x60, 0x29, 0xb9, 0x77, 0xf9, 0xec, 0xde, 0x2d, 0xf4,
+	0x46, 0x7b, 0xa5, 0x58, 0xb0, 0x9c, 0xac, 0x58, 0xf4, 0xf1, 0x8b, 0xd8, 0xe4, 0x88, 0x45, 0x2e,
+	0xd3, 0x29, 0x54, 0x77, 0xc4, 0xb6, 0x50, 0x45, 0x15, 0x5f, 0xca, 0xbf, 0x48, 0x2c, 0xe3, 0x2e,
+	0xd2, 0xa3, 0xfe, 0x00, 0x90, 0xa1, 0xe4, 0xa5, 0x53, 0x45, 0x7a, 0x53, 0x7c, 0xa2, 0x37, 0x28,
+	0xa3, 0x8e, 0xb7, 0xbf, 0x54, 0xdd, 0xaa, 0x7a, 0xfb, 0x4b, 0x2a, 0x2b, 0x55, 0x05, 0x14, 0xad,
+	0x41, 0x85, 0x66, 0x47, 0x35, 0x8b, 0x4a, 0x3d, 0xcf, 0xc3, 0x07, 0x5a, 0xf0, 0x84, 0x4a, 0x77,
+	0x09, 0x8a, 0x16, 0x76, 0x83, 0x5b, 0x40, 0x05, 0x0b, 0xbb, 0xfb, 0x6c, 0xdf, 0x24, 0x74, 0x1c,
+	0x9e, 0x6f, 0xba, 0x56, 0xd4, 0xe9, 0xe0, 0x3c, 0x3c, 0x3d, 0x24, 0xec, 0x9d, 0xf9, 0xf5, 0xcf,
+	0x96, 0x60, 0x93, 0xf4, 0x59, 0xc6, 0x3b, 0xa7, 0xce, 0x0f, 0xfa, 0xa1, 0x04, 0x2e, 0x3d, 0xe3,
+	0xa9, 0xfc, 0xb3, 0x04, 0xd5, 0x47, 0x91, 0xad, 0xac, 0x91, 0x4d, 0x33, 0xba, 0x0b, 0xeb, 0x9d,
+	0x50, 0xce, 0xb1, 0xd3, 0xc6, 0xfe, 0x37, 0xda, 0x85, 0x2a, 0x1e, 0xba, 0x44, 0x0f, 0xce, 0x30,
+	0xf3, 0x10, 0xe4, 0x46, 0x28, 0x30, 0x17, 0x7c, 0x77, 0x29, 0x9e, 0x38, 0xcd, 0xac, 0xce, 0xe2,
+	0xd0, 0x97, 0x43, 0xd7, 0x3c, 0x4c, 0x10, 0x3c, 0x8e, 0x62, 0xff, 0xd1, 0x8f, 0xa0, 0xca, 0xb6,
+	0x9e, 0x34, 0x3f, 0x40, 0x1c, 0x3b, 0xb4, 0x66, 0x19, 0x81, 0x17, 0x31, 0x2a, 0xff, 0x24, 0x41,
+	0x3d, 0xbd, 0x0d, 0x68, 0x0b, 0xa0, 0x67, 0x1b, 0x83, 0x6e, 0x70, 0x87, 0x82, 0x26, 0x28, 0x85,
+	0xba, 0x0e, 0x7d, 0x88, 0x1a, 0xc2, 0x1a, 0x73, 0x66, 0x6d, 0x9d, 0x2b, 0xf5, 0xd2, 0x34, 0xdc,
+	0xe7, 0x22, 0x28, 0x0a, 0x0a, 0xd8, 0xa1, 0x10, 0xd3, 0x25, 0xba, 0x8b, 0x45, 0x68, 0xe4, 0x7d,
+	0xd2, 0xdd, 0xb3, 0x78, 0x32, 0x80, 0x6b, 0x77, 0x56, 0xad, 0xc5, 0xb2, 0x01, 0x4e, 0x70, 0x25,
+	0x36, 0xda, 0xb5, 0xd0, 0x4d, 0xcc, 0xd8, 0xa6, 0x65, 0xf8, 0x26, 0x66, 0x8c, 0xa6, 0x1a, 0xdd,
+	0xc5, 0x0c, 0xae, 0xc4, 0xc6, 0x79, 0x67, 0x5e, 0x89, 0x4d, 0x6e, 0x48, 0xca, 0x95, 0xd8, 0x14,
+	0xce, 0x2f, 0xd3, 0xec, 0x57, 0x7d, 0x25, 0xf6, 0x3b, 0x50, 0x84, 0x7f, 0x25, 0x76, 0x32, 0xd9,
+	0xfe, 0x32, 0x07, 0xd5, 0xc3, 0x41, 0xd7, 0x35, 0x5b, 0xba, 0xe3, 0x3e, 0x22, 0xf6, 0xa0, 0x3f,
+	0x32, 0x8a, 0xe9, 0x89, 0xb7, 0x56, 0xf8, 0x02, 0x4e, 0xb1, 0xd7, 0x62, 0x01, 0xf6, 0x4d, 0x98,
+	0xe9, 0xb5, 0xc4, 0x3d, 0xb0, 0xe0, 0xa6, 0x58, 0xa5, 0xd7, 0xa2, 0x97, 0xc0, 0xe8, 0xf5, 0x2e,
+	0x3f, 0x86, 0x9b, 0x0a, 0x85, 0xf9, 0xef, 0x03, 0x74, 0x68, 0x3d, 0x9a, 0x7b, 0xd5, 0xc7, 0x72,
+	0x21, 0x38, 0xab, 0x17, 0x6d, 0xc6, 0xe9, 0x55, 0x1f, 0xab, 0x95, 0x8e, 0xf7, 0x37, 0xbe, 0x59,
+	0x1f, 0x1d, 0x4f, 0xa5, 0xf8, 0x78, 0xda, 0x80, 0x5a, 0x70, 0xfe, 0xbe, 0x8f, 0x89, 0x69, 0x1b,
+	0xe2, 0x7a, 0x4d, 0xd5, 0x3b, 0x7c, 0x7f, 0xcc, 0x4a, 0x53, 0x2e, 0xf7, 0x54, 0x5e, 0xe8, 0x72,
+	0x0f, 0xa4, 0x5c, 0x11, 0xf6, 0x07, 0x5c, 0xb4, 0x6b, 0x21, 0x3d, 0xf7, 0x3c, 0x80, 0xc6, 0x7a,
+	0x1a, 0xd6, 0x73, 0x8c, 0xa6, 0xda, 0x8b, 0x7c, 0x07, 0x03, 0x2e, 0xce, 0x3b, 0x73, 0xc0, 0x25,
+	0x37, 0x24, 0x65, 0xc0, 0xa5, 0x70, 0x7e, 0x99, 0x66, 0xbf, 0xea, 0x01, 0xf7, 0x1d, 0x28, 0xc2,
+	0x1f, 0x70, 0x93, 0xc9, 0xd6, 0x84, 0x46, 0xd3, 0x30, 0x78, 0x58, 0x75, 0x6a, 0x27, 0xd3, 0xa4,
+	0x2e, 0xac, 0xef, 0x01, 0x8a, 0x35, 0x34, 0x48, 0x05, 0xd6, 0xa2, 0xed, 0xda, 0x37, 0x14, 0x0b,
+	0x5e, 0x57, 0x71, 0xcf, 0xbe, 0x10, 0x6b, 0x58, 0x7a, 0xe6, 0xe2, 0x3b, 0xad, 0xef, 0xe7, 0x12,
+	0x20, 0xbf, 0x82, 0x20, 0x4d, 0x90, 0xcc, 0x44, 0x4a, 0x66, 0x12, 0xf8, 0x8c, 0x5c, 0x62, 0x6a,
+	0x20, 0x1f, 0x4e, 0x0d, 0xc4, 0xf2, 0x0c, 0x53, 0xf1, 0x3c, 0x83, 0xd2, 0x85, 0xc6, 0xae, 0xf5,
+	0x0d, 0x6d, 0xc9, 0x68, 0xbb, 0xbc, 0xce, 0x3f, 0x86, 0xc5, 0xa0, 0x79, 0x0c, 0x57, 0x0b, 0xad,
+	0xec, 0xa3, 0x9e, 0x29, 0x20, 0x46, 0xbd, 0x91, 0x32, 0xe5, 0xc7, 0xf0, 0x16, 0x5b, 0xea, 0x47,
+	0xd1, 0xf7, 0x6c, 0x92, 0x2c, 0xf5, 0x17, 0x92, 0x8b, 0xf2, 0x5b, 0xb0, 0x19, 0x1e, 0x92, 0x91,
+	0xd5, 0xfc, 0xaf, 0x82, 0xff, 0x6f, 0xc3, 0xfd, 0x89, 0xf9, 0x0b, 0x47, 0xf0, 0x29, 0x2c, 0x25,
+	0x49, 0xce, 0xcb, 0x22, 0xa4, 0x89, 0x6e, 0x61, 0x54, 0x74, 0x8e, 0xf2, 0x23, 0x6a, 0xab, 0x4e,
+	0xec, 0xf4, 0x23, 0x3d, 0x50, 0xdc, 0xdc, 0x51, 0x69, 0x26, 0x6a, 0xec, 0xfa, 0xf3, 0xee, 0x3a,
+	0x94, 0xbd, 0x35, 0x04, 0x2a, 0x41, 0x5e, 0x7d, 0xf6, 0x6e, 0xed, 0x1a, 0xff, 0xb3, 0x55, 0x93,
+	0xee, 0xee, 0x85, 0x4f, 0x15, 0xfa, 0xab, 0x02, 0xb4, 0x00, 0x73, 0x4f, 0x8e, 0xb4, 0xc3, 0xe6,
+	0xb6, 0xb6, 0x7d, 0x74, 0x78, 0xd8, 0x7c, 0xb2, 0x73, 0x52, 0xbb, 0x86, 0x2a, 0x50, 0xd8, 0x3b,
+	0x3a, 0x3e, 0x3d, 0xa9, 0x49, 0x68, 0x0e, 0xa6, 0xf7, 0xd4, 0x43, 0xed, 0xb8, 0xf9, 0xd5, 0xc1,
+	0x51, 0x73, 0xa7, 0x96, 0xbb, 0xfb, 0x10, 0xaa, 0xd1, 0x5d, 0x68, 0x54, 0x05, 0x78, 0xd4, 0x3c,
+	0xdd, 0xfd, 0xb2, 0xf9, 0x95, 0xb6, 0xbf, 0x53, 0xbb, 0x46, 0xbf, 0xb7, 0xd5, 0xdd, 0xe6, 0xe9,
+	0xee, 0x8e, 0xd6, 0x3c, 0xad, 0x49, 0xa8, 0x06, 0x33, 0x07, 0xcd, 0x93, 0x53, 0xed, 0x64, 0x77,
+	0xf7, 0x09, 0x2d, 0xc9, 0xdd, 0xed, 0xc2, 0x42, 0x42, 0x9a, 0x12, 0x01, 0x14, 0x4f, 0x76, 0xb7,
+	0x8f, 0x9e, 0x50, 0x26, 0x00, 0xc5, 0xc3, 0xfd, 0x27, 0x4f, 0x4f, 0x77, 0x6b, 0x12, 0x2a, 0xc3,
+	0xd4, 0xe3, 0xa3, 0xa7, 0x6a, 0x2d, 0x47, 0x7b, 0xb3, 0xd3, 0xfc, 0xaa, 0x96, 0xa7, 0x45, 0x5f,
+	0xee, 0xee, 0x7e, 0x56, 0x9b, 0xa2, 0x6d, 0x3d, 0x3c, 0x7a, 0x72, 0xfa, 0xb8, 0x56, 0x40, 0xd3,
+	0x50, 0xfa, 0xfc, 0x69, 0x53, 0x3d, 0xdd, 0x55, 0x6b, 0x45, 0x8a, 0xf1, 0xd5, 0x6e, 0x53, 0xad,
+	0x95, 0xee, 0x6e, 0x02, 0x8a, 0xea, 0x8f, 0x4d, 0xa7, 0xd3, 0x50, 0xda, 0x3e, 0x68, 0x9e, 0x9c,
+	0x68, 0xdb, 0xb5, 0x6b, 0xc1, 0xc7, 0xc3, 0x9a, 0xb4, 0xf5, 0xdf, 0x77, 0x60, 0xd1, 0x4b, 0x01,
+	0x62, 0x72, 0x81, 0x89, 0x78, 0x6f, 0x05, 0xfd, 0xd8, 0x3b, 0xa7, 0x14, 0x7d, 0x80, 0x05, 0xdd,
+	0xa4, 0x7a, 0xce, 0x78, 0x7f, 0xa7, 0xde, 0x48, 0x47, 0xe0, 0x96, 0xa4, 0x5c, 0x43, 0x2a, 0x3b,
+	0xc5, 0x14, 0xe3, 0xbc, 0xce, 0xe2, 0x9d, 0x94, 0xd7, 0x74, 0xea, 0xd7, 0x53, 0xa0, 0x3e, 0xcf,
+	0xcf, 0xbd, 0x13, 0x14, 0x49, 0x0d, 0xce, 0x78, 0xa7, 0xa6, 0xbe, 0x3c, 0x32, 0xab, 0xec, 0xd2,
+	0x77, 0x8c, 0x38, 0xcb, 0xa4, 0x47, 0x68, 0x38, 0xcb, 0x8c, 0xe7, 0x69, 0x32, 0x58, 0xfa, 0x62,
+	0x8d, 0xbe, 0x61, 0x12, 0x16, 0x6b, 0xe2, 0xeb, 0x26, 0xf5, 0x46, 0x3a, 0x42, 0x4c, 0xac, 0x31,
+	0xce, 0x9e, 0x58, 0x93, 0xd9, 0x5e, 0x4f, 0x81, 0x8e, 0x8a, 0x35, 0xa9, 0xc1, 0x19, 0x4f, 0xbd,
+	0x4c, 0x22, 0xd6, 0x24, 0x96, 0x19, 0x2f, 0xbc, 0x64, 0xb3, 0x4c, 0x7a, 0xeb, 0x85, 0xb3, 0xcc,
+	0x78, 0x05, 0x26, 0x83, 0xe5, 0xb3, 0xe8, 0x43, 0x17, 0x5e, 0x23, 0x6f, 0x04, 0x7a, 0x48, 0x7a,
+	0x33, 0xa4, 0x7e, 0x33, 0x15, 0xee, 0x8b, 0xf4, 0x28, 0xf4, 0x0e, 0x86, 0xc7, 0x76, 0x4d, 0xe8,
+	0x21, 0x91, 0xe7, 0x7a, 0x32, 0x30, 0xc4, 0x70, 0x21, 0xe1, 0x75, 0x14, 0xde, 0xd4, 0xf4, 0x67,
+	0x53, 0x32, 0xfa, 0x7e, 0x14, 0x7d, 0x91, 0x22, 0xc2, 0x30, 0xfd, 0xbd, 0x94, 0x0c, 0x86, 0x4d,
+	0x98, 0x09, 0xcb, 0x04, 0xad, 0xc4, 0xa5, 0x34, 0x9e, 0xc5, 0x03, 0xa8, 0xf8, 0x22, 0x40, 0x8b,
+	0x11, 0x89, 0x78, 0xc4, 0x4b, 0xb1, 0x52, 0x5f, 0x40, 0x4d, 0x98, 0x09, 0xcb, 0x81, 0x57, 0x9f,
+	0xf0, 0x5c, 0x47, 0x76, 0x0f, 0xc2, 0x3d, 0x47, 0x2b, 0x71, 0x59, 0x8c, 0x67, 0xb1, 0x0b, 0xd5,
+	0xe8, 0xd3, 0x13, 0x88, 0x1d, 0x5e, 0x48, 0x7c, 0x8e, 0x22, 0x83, 0xcd, 0x3e, 0x7d, 0xfd, 0x23,
+	0xfa, 0xca, 0x04, 0x37, 0x9f, 0x94, 0xb7, 0x27, 0xb2, 0x6d, 0x3c, 0xe1, 0x11, 0x09, 0xae, 0xe7,
+	0xf4, 0x57, 0x29, 0xea, 0x37, 0x53, 0xe1, 0x89, 0x36, 0xee, 0xbd, 0xfa, 0x10, 0xb5, 0xf1, 0xe8,
+	0x3d, 0xb8, 0xfa, 0x7a, 0x32, 0xd0, 0x67, 0xd8, 0x87, 0xb5, 0x38, 0x34, 0x74, 0x5d, 0x02, 0xbd,
+	0x91, 0x44, 0x3e, 0x7a, 0x21, 0xa3, 0xfe, 0xe6, 0x58, 0x3c, 0xbf, 0x46, 0x07, 0x5e, 0x9f, 0xe8,
+	0xaa, 0x1c, 0x7a, 0x27, 0x6e, 0x4d, 0xe3, 0x6e, 0xd5, 0x65, 0x68, 0x44, 0x83, 0xb5, 0x04, 0x4e,
+	0x7e, 0xa8, 0xf3, 0x46, 0x4a, 0x55, 0xb1, 0x5b, 0x74, 0x19, 0x15, 0x60, 0xb8, 0x91, 0x1d, 0x7a,
+	0xa1, 0x3b, 0x3c, 0x2d, 0x3e, 0x41, 0x78, 0x96, 0x3d, 0xcf, 0x25, 0xdd, 0x14, 0x43, 0x51, 0xd3,
+	0x19, 0xbd, 0x7c, 0x56, 0x6f, 0xa4, 0x23, 0xf8, 0x9a, 0x39, 0x80, 0xb9, 0xd8, 0x7d, 0x2b, 0x54,
+	0x8f, 0xea, 0x35, 0x7c, 0x71, 0xab, 0xbe, 0x96, 0x08, 0x8b, 0xcd, 0x9a, 0xd1, 0xfb, 0x46, 0x28,
+	0x6a, 0x8e, 0xb1, 0xcb, 0x4b, 0xf5, 0xeb, 0x29, 0x50, 0x9f, 0xe7, 0x09, 0x2c, 0x25, 0x6e, 0x28,
+	0xa2, 0x46, 0xdc, 0xf1, 0xc5, 0x57, 0x28, 0x99, 0x32, 0x5d, 0x4d, 0xdd, 0x5c, 0x44, 0xb7, 0x43,
+	0x33, 0x5d, 0xea, 0xde, 0x63, 0x06, 0x73, 0x27, 0x74, 0xb5, 0x2f, 0x61, 0xf3, 0x10, 0x45, 0x07,
+	0x4e, 0xfa, 0xf6, 0x64, 0x7d, 0x63, 0x3c, 0x62, 0x68, 0x88, 0xad, 0x67, 0x6d, 0x0f, 0xfa, 0x95,
+	0x8e, 0xdb, 0x80, 0xac, 0x6f, 0x8c, 0x47, 0xf4, 0x2b, 0xfd, 0x14, 0x6a, 0xf1, 0x5b, 0x51, 0x28,
+	0x45, 0x2e, 0xbe, 0x57, 0x4a, 0xbc, 0x43, 0xc5, 0x55, 0x92, 0x7a, 0x55, 0x8a, 0xab, 0x64, 0xdc,
+	0x4d, 0xaa, 0x0c, 0x95, 0x18, 0xec, 0x70, 0x40, 0x02, 0xa9, 0x83, 0x14, 0xd1, 0xae, 0x8c, 0x6b,
+	0x4b, 0xf5, 0x5b, 0x99, 0x38, 0xe1, 0x2e, 0xa4, 0xde, 0x19, 0xe2, 0x5d, 0x18, 0x77, 0xa5, 0x28,
+	0xa3, 0x0b, 0x4f, 0x61, 0x39, 0xf9, 0x02, 0x11, 0x7a, 0x8d, 0x3f, 0x02, 0x99, 0x71, 0xb9, 0x28,
+	0x83, 0xed, 0x36, 0xcc, 0x46, 0xf2, 0xcf, 0x48, 0x0e, 0x44, 0x1d, 0xdd, 0x20, 0xce, 0x60, 0xf2,
+	0x03, 0x80, 0x20, 0xcf, 0x8c, 0xbc, 0xd8, 0x61, 0x84, 0x3c, 0x56, 0xec, 0xcb, 0x6d, 0x1b, 0x66,
+	0x23, 0x69, 0x5d, 0xde, 0x86, 0xa4, 0x43, 0xdc, 0xd9, 0x1d, 0x89, 0xe4, 0x6f, 0x39, 0x93, 0xa4,
+	0xa3, 0xdc, 0x99, 0x4c, 0x66, 0xc2, 0x27, 0x79, 0x79, 0x68, 0x92, 0x70, 0x20, 0xbb, 0x2e, 0x8f,
+	0x02, 0x42, 0x66, 0xb0, 0x98, 0x94, 0xd2, 0x0f, 0x2f, 0x4c, 0x12, 0x73, 0xcc, 0xf5, 0x46, 0x3a,
+	0x42, 0xcc, 0xc5, 0xc6, 0x38, 0xaf, 0x47, 0x45, 0x9b, 0xb2, 0x30, 0x49, 0xe5, 0xf9, 0x79, 0xec,
+	0xc4, 0x7c, 0xc2, 0xc2, 0x24, 0x99, 0xf3, 0x04, 0x0b, 0x93, 0x24, 0x96, 0x19, 0x79, 0xf6, 0x0c,
+	0x96, 0x7c, 0xaa, 0x8a, 0x9c, 0xfe, 0xad, 0x47, 0x7b, 0x16, 0x3e, 0xe7, 0x54, 0x5f, 0x4b, 0x84,
+	0xc5, 0x26, 0xbe, 0xc8, 0x29, 0xb5, 0xba, 0xef, 0xf9, 0x46, 0x4e, 0x4d, 0xd5, 0xd7, 0x12, 0x61,
+	0x3e, 0xb7, 0x4e, 0x78, 0x57, 0x26, 0x7a, 0x92, 0x0e, 0xdd, 0x8a, 0x36, 0x24, 0xf1, 0xbc, 0x60,
+	0xfd, 0x76, 0x36, 0x92, 0x5f, 0x51, 0x17, 0x56, 0x53, 0x0f, 0x61, 0x70, 0x17, 0x33, 0xee, 0x9c,
+	0x47, 0xfd, 0xf5, 0x31, 0x58, 0x5e, 0x5d, 0xef, 0x48, 0xc8, 0x04, 0x39, 0xed, 0x64, 0x02, 0xef,
+	0xd6, 0x98, 0x43, 0x0f, 0xf5, 0xdb, 0xd9, 0x48, 0xa1, 0xaa, 0xfc, 0x41, 0x13, 0xdb, 0x54, 0x09,
+	0x0d, 0x9a, 0xc4, 0x6c, 0x5d, 0xbd, 0x91, 0x8e, 0x10, 0x1b, 0x34, 0x31, 0xce, 0xde, 0xa0, 0x49,
+	0x66, 0x7b, 0x3d, 0x05, 0x3a, 0x3a, 0x68, 0x92, 0x1a, 0x9c, 0x91, 0x34, 0x9f, 0x64, 0xd0, 0x24,
+	0xb1, 0xcc, 0xc8, 0x95, 0x67, 0x07, 0x3a, 0xa9, 0x59, 0x73, 0x6e, 0x2f, 0xe3, 0x92, 0xea, 0xe3,
+	0x02, 0xe0, 0xac, 0x3c, 0xb9, 0x17, 0x00, 0x4f, 0x90, 0x4b, 0xcf, 0xee, 0x43, 0x6a, 0x32, 0x9a,
+	0xf7, 0x61, 0x5c, 0xae, 0x3a, 0x83, 0xf9, 0x37, 0x70, 0x7b, 0x92, 0xdc, 0x33, 0xba, 0xef, 0x07,
+	0x85, 0x93, 0x65, 0xa9, 0x33, 0xaa, 0xfc, 0x63, 0x09, 0xde, 0x9c, 0x30, 0x65, 0x8c, 0xb6, 0xe2,
+	0x66, 0x38, 0x3e, 0x7f, 0x5d, 0x7f, 0xef, 0x85, 0x68, 0x7c, 0x83, 0xfe, 0x84, 0x4d, 0xe2, 0xde,
+	0xb5, 0xb4, 0xb4, 0x30, 0xce, 0x9b, 0xc5, 0x63, 0xe7, 0x33, 0x94, 0x6b, 0x67, 0x45, 0x86, 0xf9,
+	0xde, 0xff, 0x0e, 0x00, 0xd3, 0xc8, 0x19, 0xfb, 0x1b, 0x5d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4232,6 +7481,9 @@ type NetworkServerServiceClient interface {
 	UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// DeleteRoutingProfile deletes the routing-profile matching the given id.
 	DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// FlushDeadLetterQueue removes the uplink data from the dead-letter queue
+	// of the given routing-profile.
+	FlushDeadLetterQueue(ctx context.Context, in *FlushDeadLetterQueueRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// CreateDeviceProfile creates the given device-profile.
 	CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error)
 	// GetDeviceProfile returns the device-profile matching the given id.
@@ -4254,6 +7506,22 @@ type NetworkServerServiceClient interface {
 	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetDeviceActivation returns the device activation details.
 	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// GetDeviceSession returns the device-session for the given DevEUI.
+	GetDeviceSession(ctx context.Context, in *GetDeviceSessionRequest, opts ...grpc.CallOption) (*GetDeviceSessionResponse, error)
+	// GetDeviceSessionsForDevAddr returns the device-sessions using the given DevAddr.
+	GetDeviceSessionsForDevAddr(ctx context.Context, in *GetDeviceSessionsForDevAddrRequest, opts ...grpc.CallOption) (*GetDeviceSessionsForDevAddrResponse, error)
+	// UpdateDeviceSessionInstallationMargin updates the ADR installation margin of the device-session.
+	UpdateDeviceSessionInstallationMargin(ctx context.Context, in *UpdateDeviceSessionInstallationMarginRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// UpdateDeviceSessionRXWindow updates the RX window preference of the device-session.
+	UpdateDeviceSessionRXWindow(ctx context.Context, in *UpdateDeviceSessionRXWindowRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ResetDeviceSessionLinkADRState resets the LinkADRReq rejection state of the device-session.
+	ResetDeviceSessionLinkADRState(ctx context.Context, in *ResetDeviceSessionLinkADRStateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetDeviceLinkMetrics returns the uplink history and the last ADR decision for the given DevEUI.
+	GetDeviceLinkMetrics(ctx context.Context, in *GetDeviceLinkMetricsRequest, opts ...grpc.CallOption) (*GetDeviceLinkMetricsResponse, error)
+	// GetDeviceStatus returns the last device-status reported by the device (DevStatusAns).
+	GetDeviceStatus(ctx context.Context, in *GetDeviceStatusRequest, opts ...grpc.CallOption) (*GetDeviceStatusResponse, error)
+	// GetDeviceLocation returns the last location of the device, as resolved by the geolocation-server.
+	GetDeviceLocation(ctx context.Context, in *GetDeviceLocationRequest, opts ...grpc.CallOption) (*GetDeviceLocationResponse, error)
 	// CreateDeviceQueueItem creates the given device-queue item.
 	CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
@@ -4267,6 +7535,10 @@ type NetworkServerServiceClient interface {
 	GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
 	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
 	CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMACCommandQueueItems returns the mac-command queue items for the given DevEUI.
+	GetMACCommandQueueItems(ctx context.Context, in *GetMACCommandQueueItemsRequest, opts ...grpc.CallOption) (*GetMACCommandQueueItemsResponse, error)
+	// DeleteMACCommandQueueItem deletes the mac-command queue item(s) for the given DevEUI and CID.
+	DeleteMACCommandQueueItem(ctx context.Context, in *DeleteMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 	SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// CreateGateway creates the given gateway.
@@ -4277,6 +7549,8 @@ type NetworkServerServiceClient interface {
 	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// DeleteGateway deletes a gateway.
 	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ListGateways returns the gateways, optionally filtered by tags.
+	ListGateways(ctx context.Context, in *ListGatewaysRequest, opts ...grpc.CallOption) (*ListGatewaysResponse, error)
 	// CreateGatewayProfile creates the given gateway-profile.
 	CreateGatewayProfile(ctx context.Context, in *CreateGatewayProfileRequest, opts ...grpc.CallOption) (*CreateGatewayProfileResponse, error)
 	// GetGatewayProfile returns the gateway-profile given an id.
@@ -4287,6 +7561,11 @@ type NetworkServerServiceClient interface {
 	DeleteGatewayProfile(ctx context.Context, in *DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetGatewayStats returns stats of an existing gateway.
 	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
+	// GetNetworkStats returns the network-wide stats, aggregated over all gateways.
+	GetNetworkStats(ctx context.Context, in *GetNetworkStatsRequest, opts ...grpc.CallOption) (*GetNetworkStatsResponse, error)
+	// GetGatewayDiscoveryPings returns the receptions of the discovery pings
+	// transmitted by the given gateway.
+	GetGatewayDiscoveryPings(ctx context.Context, in *GetGatewayDiscoveryPingsRequest, opts ...grpc.CallOption) (*GetGatewayDiscoveryPingsResponse, error)
 	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
 	StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error)
 	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
@@ -4394,6 +7673,15 @@ func (c *networkServerServiceClient) DeleteRoutingProfile(ctx context.Context, i
 	return out, nil
 }
 
+func (c *networkServerServiceClient) FlushDeadLetterQueue(ctx context.Context, in *FlushDeadLetterQueueRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/FlushDeadLetterQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error) {
 	out := new(CreateDeviceProfileResponse)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceProfile", in, out, opts...)
@@ -4448,45 +7736,117 @@ func (c *networkServerServiceClient) GetDevice(ctx context.Context, in *GetDevic
 	return out, nil
 }
 
-func (c *networkServerServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *networkServerServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ActivateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeactivateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
+	out := new(GetDeviceActivationResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceActivation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceSession(ctx context.Context, in *GetDeviceSessionRequest, opts ...grpc.CallOption) (*GetDeviceSessionResponse, error) {
+	out := new(GetDeviceSessionResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceSessionsForDevAddr(ctx context.Context, in *GetDeviceSessionsForDevAddrRequest, opts ...grpc.CallOption) (*GetDeviceSessionsForDevAddrResponse, error) {
+	out := new(GetDeviceSessionsForDevAddrResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceSessionsForDevAddr", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateDeviceSessionInstallationMargin(ctx context.Context, in *UpdateDeviceSessionInstallationMarginRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDeviceSessionInstallationMargin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateDeviceSessionRXWindow(ctx context.Context, in *UpdateDeviceSessionRXWindowRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDevice", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDeviceSessionRXWindow", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerServiceClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *networkServerServiceClient) ResetDeviceSessionLinkADRState(ctx context.Context, in *ResetDeviceSessionLinkADRStateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDevice", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ResetDeviceSessionLinkADRState", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerServiceClient) ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ActivateDevice", in, out, opts...)
+func (c *networkServerServiceClient) GetDeviceLinkMetrics(ctx context.Context, in *GetDeviceLinkMetricsRequest, opts ...grpc.CallOption) (*GetDeviceLinkMetricsResponse, error) {
+	out := new(GetDeviceLinkMetricsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceLinkMetrics", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerServiceClient) DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeactivateDevice", in, out, opts...)
+func (c *networkServerServiceClient) GetDeviceStatus(ctx context.Context, in *GetDeviceStatusRequest, opts ...grpc.CallOption) (*GetDeviceStatusResponse, error) {
+	out := new(GetDeviceStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerServiceClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
-	out := new(GetDeviceActivationResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceActivation", in, out, opts...)
+func (c *networkServerServiceClient) GetDeviceLocation(ctx context.Context, in *GetDeviceLocationRequest, opts ...grpc.CallOption) (*GetDeviceLocationResponse, error) {
+	out := new(GetDeviceLocationResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceLocation", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4547,6 +7907,24 @@ func (c *networkServerServiceClient) CreateMACCommandQueueItem(ctx context.Conte
 	return out, nil
 }
 
+func (c *networkServerServiceClient) GetMACCommandQueueItems(ctx context.Context, in *GetMACCommandQueueItemsRequest, opts ...grpc.CallOption) (*GetMACCommandQueueItemsResponse, error) {
+	out := new(GetMACCommandQueueItemsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMACCommandQueueItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteMACCommandQueueItem(ctx context.Context, in *DeleteMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteMACCommandQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/SendProprietaryPayload", in, out, opts...)
@@ -4592,6 +7970,15 @@ func (c *networkServerServiceClient) DeleteGateway(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *networkServerServiceClient) ListGateways(ctx context.Context, in *ListGatewaysRequest, opts ...grpc.CallOption) (*ListGatewaysResponse, error) {
+	out := new(ListGatewaysResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ListGateways", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) CreateGatewayProfile(ctx context.Context, in *CreateGatewayProfileRequest, opts ...grpc.CallOption) (*CreateGatewayProfileResponse, error) {
 	out := new(CreateGatewayProfileResponse)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateGatewayProfile", in, out, opts...)
@@ -4637,6 +8024,24 @@ func (c *networkServerServiceClient) GetGatewayStats(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *networkServerServiceClient) GetNetworkStats(ctx context.Context, in *GetNetworkStatsRequest, opts ...grpc.CallOption) (*GetNetworkStatsResponse, error) {
+	out := new(GetNetworkStatsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetNetworkStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayDiscoveryPings(ctx context.Context, in *GetGatewayDiscoveryPingsRequest, opts ...grpc.CallOption) (*GetGatewayDiscoveryPingsResponse, error) {
+	out := new(GetGatewayDiscoveryPingsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayDiscoveryPings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[0], "/ns.NetworkServerService/StreamFrameLogsForGateway", opts...)
 	if err != nil {
@@ -4809,6 +8214,9 @@ type NetworkServerServiceServer interface {
 	UpdateRoutingProfile(context.Context, *UpdateRoutingProfileRequest) (*empty.Empty, error)
 	// DeleteRoutingProfile deletes the routing-profile matching the given id.
 	DeleteRoutingProfile(context.Context, *DeleteRoutingProfileRequest) (*empty.Empty, error)
+	// FlushDeadLetterQueue removes the uplink data from the dead-letter queue
+	// of the given routing-profile.
+	FlushDeadLetterQueue(context.Context, *FlushDeadLetterQueueRequest) (*empty.Empty, error)
 	// CreateDeviceProfile creates the given device-profile.
 	CreateDeviceProfile(context.Context, *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error)
 	// GetDeviceProfile returns the device-profile matching the given id.
@@ -4831,6 +8239,22 @@ type NetworkServerServiceServer interface {
 	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*empty.Empty, error)
 	// GetDeviceActivation returns the device activation details.
 	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// GetDeviceSession returns the device-session for the given DevEUI.
+	GetDeviceSession(context.Context, *GetDeviceSessionRequest) (*GetDeviceSessionResponse, error)
+	// GetDeviceSessionsForDevAddr returns the device-sessions using the given DevAddr.
+	GetDeviceSessionsForDevAddr(context.Context, *GetDeviceSessionsForDevAddrRequest) (*GetDeviceSessionsForDevAddrResponse, error)
+	// UpdateDeviceSessionInstallationMargin updates the ADR installation margin of the device-session.
+	UpdateDeviceSessionInstallationMargin(context.Context, *UpdateDeviceSessionInstallationMarginRequest) (*empty.Empty, error)
+	// UpdateDeviceSessionRXWindow updates the RX window preference of the device-session.
+	UpdateDeviceSessionRXWindow(context.Context, *UpdateDeviceSessionRXWindowRequest) (*empty.Empty, error)
+	// ResetDeviceSessionLinkADRState resets the LinkADRReq rejection state of the device-session.
+	ResetDeviceSessionLinkADRState(context.Context, *ResetDeviceSessionLinkADRStateRequest) (*empty.Empty, error)
+	// GetDeviceLinkMetrics returns the uplink history and the last ADR decision for the given DevEUI.
+	GetDeviceLinkMetrics(context.Context, *GetDeviceLinkMetricsRequest) (*GetDeviceLinkMetricsResponse, error)
+	// GetDeviceStatus returns the last device-status reported by the device (DevStatusAns).
+	GetDeviceStatus(context.Context, *GetDeviceStatusRequest) (*GetDeviceStatusResponse, error)
+	// GetDeviceLocation returns the last location of the device, as resolved by the geolocation-server.
+	GetDeviceLocation(context.Context, *GetDeviceLocationRequest) (*GetDeviceLocationResponse, error)
 	// CreateDeviceQueueItem creates the given device-queue item.
 	CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error)
 	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
@@ -4844,6 +8268,10 @@ type NetworkServerServiceServer interface {
 	GetRandomDevAddr(context.Context, *empty.Empty) (*GetRandomDevAddrResponse, error)
 	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
 	CreateMACCommandQueueItem(context.Context, *CreateMACCommandQueueItemRequest) (*empty.Empty, error)
+	// GetMACCommandQueueItems returns the mac-command queue items for the given DevEUI.
+	GetMACCommandQueueItems(context.Context, *GetMACCommandQueueItemsRequest) (*GetMACCommandQueueItemsResponse, error)
+	// DeleteMACCommandQueueItem deletes the mac-command queue item(s) for the given DevEUI and CID.
+	DeleteMACCommandQueueItem(context.Context, *DeleteMACCommandQueueItemRequest) (*empty.Empty, error)
 	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 	SendProprietaryPayload(context.Context, *SendProprietaryPayloadRequest) (*empty.Empty, error)
 	// CreateGateway creates the given gateway.
@@ -4854,6 +8282,8 @@ type NetworkServerServiceServer interface {
 	UpdateGateway(context.Context, *UpdateGatewayRequest) (*empty.Empty, error)
 	// DeleteGateway deletes a gateway.
 	DeleteGateway(context.Context, *DeleteGatewayRequest) (*empty.Empty, error)
+	// ListGateways returns the gateways, optionally filtered by tags.
+	ListGateways(context.Context, *ListGatewaysRequest) (*ListGatewaysResponse, error)
 	// CreateGatewayProfile creates the given gateway-profile.
 	CreateGatewayProfile(context.Context, *CreateGatewayProfileRequest) (*CreateGatewayProfileResponse, error)
 	// GetGatewayProfile returns the gateway-profile given an id.
@@ -4864,6 +8294,11 @@ type NetworkServerServiceServer interface {
 	DeleteGatewayProfile(context.Context, *DeleteGatewayProfileRequest) (*empty.Empty, error)
 	// GetGatewayStats returns stats of an existing gateway.
 	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
+	// GetNetworkStats returns the network-wide stats, aggregated over all gateways.
+	GetNetworkStats(context.Context, *GetNetworkStatsRequest) (*GetNetworkStatsResponse, error)
+	// GetGatewayDiscoveryPings returns the receptions of the discovery pings
+	// transmitted by the given gateway.
+	GetGatewayDiscoveryPings(context.Context, *GetGatewayDiscoveryPingsRequest) (*GetGatewayDiscoveryPingsResponse, error)
 	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
 	StreamFrameLogsForGateway(*StreamFrameLogsForGatewayRequest, NetworkServerService_StreamFrameLogsForGatewayServer) error
 	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
@@ -5039,6 +8474,24 @@ func _NetworkServerService_DeleteRoutingProfile_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_FlushDeadLetterQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushDeadLetterQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).FlushDeadLetterQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/FlushDeadLetterQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).FlushDeadLetterQueue(ctx, req.(*FlushDeadLetterQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_CreateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateDeviceProfileRequest)
 	if err := dec(in); err != nil {
@@ -5237,6 +8690,150 @@ func _NetworkServerService_GetDeviceActivation_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetDeviceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceSession(ctx, req.(*GetDeviceSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceSessionsForDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceSessionsForDevAddrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceSessionsForDevAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceSessionsForDevAddr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceSessionsForDevAddr(ctx, req.(*GetDeviceSessionsForDevAddrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateDeviceSessionInstallationMargin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceSessionInstallationMarginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateDeviceSessionInstallationMargin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateDeviceSessionInstallationMargin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateDeviceSessionInstallationMargin(ctx, req.(*UpdateDeviceSessionInstallationMarginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateDeviceSessionRXWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceSessionRXWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateDeviceSessionRXWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateDeviceSessionRXWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateDeviceSessionRXWindow(ctx, req.(*UpdateDeviceSessionRXWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_ResetDeviceSessionLinkADRState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetDeviceSessionLinkADRStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ResetDeviceSessionLinkADRState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ResetDeviceSessionLinkADRState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ResetDeviceSessionLinkADRState(ctx, req.(*ResetDeviceSessionLinkADRStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceLinkMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceLinkMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceLinkMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceLinkMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceLinkMetrics(ctx, req.(*GetDeviceLinkMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceStatus(ctx, req.(*GetDeviceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceLocation(ctx, req.(*GetDeviceLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_CreateDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateDeviceQueueItemRequest)
 	if err := dec(in); err != nil {
@@ -5345,6 +8942,42 @@ func _NetworkServerService_CreateMACCommandQueueItem_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetMACCommandQueueItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMACCommandQueueItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMACCommandQueueItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMACCommandQueueItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMACCommandQueueItems(ctx, req.(*GetMACCommandQueueItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteMACCommandQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMACCommandQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteMACCommandQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteMACCommandQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteMACCommandQueueItem(ctx, req.(*DeleteMACCommandQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_SendProprietaryPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendProprietaryPayloadRequest)
 	if err := dec(in); err != nil {
@@ -5435,6 +9068,24 @@ func _NetworkServerService_DeleteGateway_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ListGateways(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ListGateways",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ListGateways(ctx, req.(*ListGatewaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_CreateGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateGatewayProfileRequest)
 	if err := dec(in); err != nil {
@@ -5525,6 +9176,42 @@ func _NetworkServerService_GetGatewayStats_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetNetworkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetNetworkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetNetworkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetNetworkStats(ctx, req.(*GetNetworkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetGatewayDiscoveryPings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayDiscoveryPingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetGatewayDiscoveryPings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetGatewayDiscoveryPings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetGatewayDiscoveryPings(ctx, req.(*GetGatewayDiscoveryPingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_StreamFrameLogsForGateway_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamFrameLogsForGatewayRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -5783,6 +9470,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRoutingProfile",
 			Handler:    _NetworkServerService_DeleteRoutingProfile_Handler,
 		},
+		{
+			MethodName: "FlushDeadLetterQueue",
+			Handler:    _NetworkServerService_FlushDeadLetterQueue_Handler,
+		},
 		{
 			MethodName: "CreateDeviceProfile",
 			Handler:    _NetworkServerService_CreateDeviceProfile_Handler,
@@ -5827,6 +9518,38 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeviceActivation",
 			Handler:    _NetworkServerService_GetDeviceActivation_Handler,
 		},
+		{
+			MethodName: "GetDeviceSession",
+			Handler:    _NetworkServerService_GetDeviceSession_Handler,
+		},
+		{
+			MethodName: "GetDeviceSessionsForDevAddr",
+			Handler:    _NetworkServerService_GetDeviceSessionsForDevAddr_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceSessionInstallationMargin",
+			Handler:    _NetworkServerService_UpdateDeviceSessionInstallationMargin_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceSessionRXWindow",
+			Handler:    _NetworkServerService_UpdateDeviceSessionRXWindow_Handler,
+		},
+		{
+			MethodName: "ResetDeviceSessionLinkADRState",
+			Handler:    _NetworkServerService_ResetDeviceSessionLinkADRState_Handler,
+		},
+		{
+			MethodName: "GetDeviceLinkMetrics",
+			Handler:    _NetworkServerService_GetDeviceLinkMetrics_Handler,
+		},
+		{
+			MethodName: "GetDeviceStatus",
+			Handler:    _NetworkServerService_GetDeviceStatus_Handler,
+		},
+		{
+			MethodName: "GetDeviceLocation",
+			Handler:    _NetworkServerService_GetDeviceLocation_Handler,
+		},
 		{
 			MethodName: "CreateDeviceQueueItem",
 			Handler:    _NetworkServerService_CreateDeviceQueueItem_Handler,
@@ -5851,6 +9574,14 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMACCommandQueueItem",
 			Handler:    _NetworkServerService_CreateMACCommandQueueItem_Handler,
 		},
+		{
+			MethodName: "GetMACCommandQueueItems",
+			Handler:    _NetworkServerService_GetMACCommandQueueItems_Handler,
+		},
+		{
+			MethodName: "DeleteMACCommandQueueItem",
+			Handler:    _NetworkServerService_DeleteMACCommandQueueItem_Handler,
+		},
 		{
 			MethodName: "SendProprietaryPayload",
 			Handler:    _NetworkServerService_SendProprietaryPayload_Handler,
@@ -5871,6 +9602,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGateway",
 			Handler:    _NetworkServerService_DeleteGateway_Handler,
 		},
+		{
+			MethodName: "ListGateways",
+			Handler:    _NetworkServerService_ListGateways_Handler,
+		},
 		{
 			MethodName: "CreateGatewayProfile",
 			Handler:    _NetworkServerService_CreateGatewayProfile_Handler,
@@ -5891,6 +9626,14 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetGatewayStats",
 			Handler:    _NetworkServerService_GetGatewayStats_Handler,
 		},
+		{
+			MethodName: "GetNetworkStats",
+			Handler:    _NetworkServerService_GetNetworkStats_Handler,
+		},
+		{
+			MethodName: "GetGatewayDiscoveryPings",
+			Handler:    _NetworkServerService_GetGatewayDiscoveryPings_Handler,
+		},
 		{
 			MethodName: "CreateMulticastGroup",
 			Handler:    _NetworkServerService_CreateMulticastGroup_Handler,
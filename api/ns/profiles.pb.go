@@ -47,6 +47,37 @@ func (RatePolicy) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9610db3cccb08234, []int{0}
 }
 
+type RXWindowPreference int32
+
+const (
+	// Use the network-server rx_window setting.
+	RXWindowPreference_RX_WINDOW_AUTO RXWindowPreference = 0
+	// Always use RX1.
+	RXWindowPreference_RX_WINDOW_RX1 RXWindowPreference = 1
+	// Always use RX2.
+	RXWindowPreference_RX_WINDOW_RX2 RXWindowPreference = 2
+)
+
+var RXWindowPreference_name = map[int32]string{
+	0: "RX_WINDOW_AUTO",
+	1: "RX_WINDOW_RX1",
+	2: "RX_WINDOW_RX2",
+}
+
+var RXWindowPreference_value = map[string]int32{
+	"RX_WINDOW_AUTO": 0,
+	"RX_WINDOW_RX1":  1,
+	"RX_WINDOW_RX2":  2,
+}
+
+func (x RXWindowPreference) String() string {
+	return proto.EnumName(RXWindowPreference_name, int32(x))
+}
+
+func (RXWindowPreference) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9610db3cccb08234, []int{1}
+}
+
 type ServiceProfile struct {
 	// Service-profile ID.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -87,7 +118,15 @@ type ServiceProfile struct {
 	// Target Packet Error Rate.
 	TargetPer uint32 `protobuf:"varint,19,opt,name=target_per,json=targetPer,proto3" json:"target_per,omitempty"`
 	// Minimum number of receiving GWs (informative).
-	MinGwDiversity       uint32   `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	MinGwDiversity uint32 `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	// Max. aggregated uplink airtime per device per rolling 24 hours (ms).
+	// When exceeded, uplinks are still processed but flagged to the
+	// application-server. Set to 0 for unlimited.
+	UlAirtimeBudget uint32 `protobuf:"varint,21,opt,name=ul_airtime_budget,json=ulAirtimeBudget,proto3" json:"ul_airtime_budget,omitempty"`
+	// Max. aggregated downlink airtime per device per rolling 24 hours (ms).
+	// When exceeded, no device-queue items are sent and new device-queue
+	// items are rejected. Set to 0 for unlimited.
+	DlAirtimeBudget      uint32   `protobuf:"varint,22,opt,name=dl_airtime_budget,json=dlAirtimeBudget,proto3" json:"dl_airtime_budget,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -258,6 +297,20 @@ func (m *ServiceProfile) GetMinGwDiversity() uint32 {
 	return 0
 }
 
+func (m *ServiceProfile) GetUlAirtimeBudget() uint32 {
+	if m != nil {
+		return m.UlAirtimeBudget
+	}
+	return 0
+}
+
+func (m *ServiceProfile) GetDlAirtimeBudget() uint32 {
+	if m != nil {
+		return m.DlAirtimeBudget
+	}
+	return 0
+}
+
 type DeviceProfile struct {
 	// Device-profile ID.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -306,7 +359,25 @@ type DeviceProfile struct {
 	// Geolocation minimum buffer size.
 	// When > 0, geolocation will only be performed when the buffer has
 	// at least the given size.
-	GeolocMinBufferSize  uint32   `protobuf:"varint,22,opt,name=geoloc_min_buffer_size,json=geolocMinBufferSize,proto3" json:"geoloc_min_buffer_size,omitempty"`
+	GeolocMinBufferSize uint32 `protobuf:"varint,22,opt,name=geoloc_min_buffer_size,json=geolocMinBufferSize,proto3" json:"geoloc_min_buffer_size,omitempty"`
+	// ADR algorithm ID.
+	// When empty, the default ADR algorithm is used.
+	AdrAlgorithmId string `protobuf:"bytes,23,opt,name=adr_algorithm_id,json=adrAlgorithmId,proto3" json:"adr_algorithm_id,omitempty"`
+	// RX window preference for downlink transmissions.
+	// This overrides the network-server rx_window setting and is copied
+	// to the device-session on activation.
+	RxWindowPreference RXWindowPreference `protobuf:"varint,24,opt,name=rx_window_preference,json=rxWindowPreference,proto3,enum=ns.RXWindowPreference" json:"rx_window_preference,omitempty"`
+	// ABP frame-counter reset detection.
+	// When set, an ABP device which restarts its frame-counters (e.g. after
+	// a power-cycle) is detected and the frame-counters of the session are
+	// reset. Note that this weakens the replay protection.
+	// This is never applied to OTAA devices.
+	AbpFcntReset bool `protobuf:"varint,25,opt,name=abp_fcnt_reset,json=abpFcntReset,proto3" json:"abp_fcnt_reset,omitempty"`
+	// Max frame-counter gap.
+	// This overrides the max frame-counter gap of the band for devices
+	// that miss many uplinks (e.g. because of poor coverage). It must be
+	// less than or equal to 32768. When 0, the band default is used.
+	MaxFcntGap           uint32   `protobuf:"varint,26,opt,name=max_fcnt_gap,json=maxFcntGap,proto3" json:"max_fcnt_gap,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -491,6 +562,34 @@ func (m *DeviceProfile) GetGeolocMinBufferSize() uint32 {
 	return 0
 }
 
+func (m *DeviceProfile) GetAdrAlgorithmId() string {
+	if m != nil {
+		return m.AdrAlgorithmId
+	}
+	return ""
+}
+
+func (m *DeviceProfile) GetRxWindowPreference() RXWindowPreference {
+	if m != nil {
+		return m.RxWindowPreference
+	}
+	return RXWindowPreference_RX_WINDOW_AUTO
+}
+
+func (m *DeviceProfile) GetAbpFcntReset() bool {
+	if m != nil {
+		return m.AbpFcntReset
+	}
+	return false
+}
+
+func (m *DeviceProfile) GetMaxFcntGap() uint32 {
+	if m != nil {
+		return m.MaxFcntGap
+	}
+	return 0
+}
+
 type RoutingProfile struct {
 	// ID of the routing profile.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -572,6 +671,7 @@ func (m *RoutingProfile) GetTlsKey() string {
 
 func init() {
 	proto.RegisterEnum("ns.RatePolicy", RatePolicy_name, RatePolicy_value)
+	proto.RegisterEnum("ns.RXWindowPreference", RXWindowPreference_name, RXWindowPreference_value)
 	proto.RegisterType((*ServiceProfile)(nil), "ns.ServiceProfile")
 	proto.RegisterType((*DeviceProfile)(nil), "ns.DeviceProfile")
 	proto.RegisterType((*RoutingProfile)(nil), "ns.RoutingProfile")
@@ -580,66 +680,76 @@ func init() {
 func init() { proto.RegisterFile("profiles.proto", fileDescriptor_9610db3cccb08234) }
 
 var fileDescriptor_9610db3cccb08234 = []byte{
-	// 964 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5d, 0x6f, 0xdb, 0x36,
-	0x14, 0x9d, 0xd3, 0xc4, 0xb1, 0x19, 0x4b, 0x71, 0xe8, 0x24, 0x55, 0xf7, 0xe9, 0xa5, 0xc3, 0x60,
-	0x14, 0x58, 0xb6, 0x38, 0x03, 0x86, 0x3d, 0x36, 0xf6, 0x1a, 0x6c, 0x9d, 0x51, 0x83, 0x29, 0xf6,
-	0x4a, 0xd0, 0x22, 0xed, 0x70, 0x96, 0x44, 0xe5, 0x92, 0x8a, 0xed, 0x3e, 0xee, 0x17, 0xee, 0x87,
-	0xec, 0x47, 0x0c, 0xbc, 0x92, 0xed, 0x34, 0xdd, 0xfa, 0x66, 0x9f, 0x73, 0xae, 0x0e, 0xef, 0xe5,
-	0xb9, 0x12, 0x09, 0x73, 0x30, 0x53, 0x9d, 0x28, 0x7b, 0x9e, 0x83, 0x71, 0x86, 0xee, 0x64, 0xf6,
-	0xec, 0x9f, 0x3d, 0x12, 0xde, 0x28, 0xb8, 0xd7, 0xb1, 0x1a, 0x97, 0x2c, 0x0d, 0xc9, 0x8e, 0x96,
-	0x51, 0xad, 0x5b, 0xeb, 0xb5, 0xd8, 0x8e, 0x96, 0xf4, 0x29, 0xd9, 0x2f, 0x12, 0x0e, 0xc2, 0xa9,
-	0x68, 0xa7, 0x5b, 0xeb, 0x05, 0xac, 0x5e, 0x24, 0x4c, 0x38, 0x45, 0xbf, 0x21, 0x61, 0x91, 0xf0,
-	0x49, 0x11, 0xcf, 0x95, 0xe3, 0x56, 0xbf, 0x53, 0xd1, 0x13, 0xe4, 0x5b, 0x45, 0x72, 0x85, 0xe0,
-	0x8d, 0x7e, 0xa7, 0xe8, 0x8f, 0xa8, 0xf2, 0xe5, 0x3c, 0x37, 0x89, 0x8e, 0x57, 0xd1, 0x6e, 0xb7,
-	0xd6, 0x0b, 0xfb, 0xe1, 0x79, 0x66, 0xcf, 0xfd, 0x73, 0xc6, 0x88, 0xfa, 0xaa, 0xed, 0x3f, 0x6f,
-	0x2a, 0x2b, 0xd3, 0xbd, 0xd2, 0x54, 0x6e, 0x4c, 0xe5, 0xfb, 0xa6, 0xf5, 0xd2, 0x54, 0x3e, 0x32,
-	0x95, 0xef, 0x9b, 0xee, 0xff, 0xb7, 0xa9, 0x7c, 0x68, 0xfa, 0x2d, 0x39, 0x14, 0x52, 0xf2, 0xd9,
-	0x82, 0xa7, 0xca, 0x09, 0x29, 0x9c, 0x88, 0x1a, 0xdd, 0x5a, 0xaf, 0xc1, 0x02, 0x21, 0xe5, 0xf5,
-	0x62, 0x54, 0x81, 0xf4, 0x3b, 0xd2, 0x91, 0xea, 0x9e, 0x5b, 0x27, 0x5c, 0x61, 0x39, 0xa8, 0x3b,
-	0x3e, 0x05, 0x75, 0x17, 0x35, 0xf1, 0x20, 0x6d, 0xa9, 0xee, 0x6f, 0x90, 0x61, 0xea, 0xee, 0x15,
-	0xa8, 0x3b, 0xfa, 0x33, 0x79, 0x06, 0x2a, 0x37, 0xe0, 0xf8, 0x83, 0xaa, 0x89, 0x70, 0x4e, 0xc1,
-	0x2a, 0x22, 0x68, 0x70, 0x5a, 0x0a, 0x86, 0xeb, 0xd2, 0xab, 0x92, 0xa5, 0x3f, 0x91, 0xe8, 0xc3,
-	0xd2, 0x54, 0xc0, 0x4c, 0x67, 0xd1, 0x01, 0x56, 0x9e, 0x3c, 0xaa, 0x1c, 0x21, 0x49, 0x4f, 0x48,
-	0x5d, 0x02, 0x4f, 0x75, 0x16, 0xb5, 0xf0, 0x54, 0x7b, 0x12, 0x46, 0x5b, 0x58, 0x2c, 0xa3, 0x60,
-	0x03, 0x8b, 0x25, 0xfd, 0x9a, 0xb4, 0xe2, 0x5b, 0x91, 0x65, 0x2a, 0xe1, 0xa9, 0xb0, 0xf3, 0x28,
-	0xc4, 0xcb, 0x3f, 0xa8, 0xb0, 0x91, 0xb0, 0x73, 0xfa, 0x05, 0x21, 0x39, 0x70, 0x91, 0x24, 0x66,
-	0xa1, 0x64, 0x74, 0x88, 0xde, 0xcd, 0x1c, 0x5e, 0x96, 0x80, 0xa7, 0x6f, 0xb7, 0x74, 0xbb, 0xa4,
-	0x6f, 0x1f, 0xd2, 0x20, 0x36, 0xf4, 0x51, 0x49, 0x83, 0x58, 0xd3, 0x5f, 0x92, 0x83, 0x6c, 0x31,
-	0xe7, 0x33, 0x65, 0x78, 0x62, 0xe2, 0x88, 0x96, 0x7c, 0xb6, 0x98, 0x5f, 0x2b, 0xf3, 0xbb, 0x89,
-	0x7d, 0xb9, 0x13, 0x30, 0x53, 0x8e, 0xe7, 0x0a, 0xa2, 0x0e, 0x1e, 0xbd, 0x59, 0x22, 0x63, 0x05,
-	0xb4, 0x47, 0xda, 0xa9, 0xce, 0xfc, 0xbd, 0x49, 0x7d, 0xaf, 0xc0, 0x6a, 0xb7, 0x8a, 0x8e, 0x51,
-	0x14, 0xa6, 0x3a, 0xbb, 0x5e, 0x0c, 0xd7, 0xe8, 0xd9, 0xdf, 0x75, 0x12, 0x0c, 0xd5, 0xc7, 0xd2,
-	0xde, 0x23, 0x6d, 0x5b, 0xe4, 0x7e, 0xa4, 0x96, 0xc7, 0x89, 0xb0, 0x96, 0x4f, 0x30, 0xf6, 0x0d,
-	0x16, 0xae, 0xf1, 0x81, 0x87, 0xaf, 0x7c, 0x5a, 0x2a, 0x01, 0x77, 0x3a, 0x55, 0xa6, 0x70, 0x55,
-	0xfe, 0x03, 0x84, 0xaf, 0xde, 0x96, 0xa0, 0x7f, 0x62, 0xae, 0xb3, 0x19, 0xb7, 0x89, 0xc1, 0xf3,
-	0x6b, 0x23, 0x71, 0x05, 0x02, 0x16, 0x7a, 0xfc, 0x26, 0x31, 0xbe, 0x09, 0x6d, 0x24, 0xed, 0x92,
-	0xd6, 0x56, 0x29, 0xa1, 0x4a, 0x3e, 0x59, 0xab, 0x86, 0xe0, 0xd3, 0xbf, 0x55, 0x60, 0xe8, 0xaa,
-	0xf4, 0xaf, 0x35, 0x18, 0xb8, 0x0f, 0x7b, 0x88, 0x31, 0xff, 0x8f, 0x7b, 0x18, 0x6c, 0x7b, 0x88,
-	0x37, 0x3d, 0x34, 0x1e, 0xf4, 0x30, 0x58, 0xf7, 0xf0, 0x15, 0x39, 0x48, 0x45, 0xcc, 0x71, 0x8c,
-	0x26, 0xc3, 0xa4, 0x37, 0x19, 0x49, 0x45, 0xfc, 0x47, 0x89, 0xd0, 0x73, 0xd2, 0x01, 0x35, 0xe3,
-	0xb9, 0x00, 0x91, 0xfa, 0x95, 0xb8, 0xd7, 0x28, 0x24, 0x28, 0x3c, 0x02, 0x35, 0x1b, 0x23, 0xc3,
-	0x2a, 0x82, 0x7e, 0x4e, 0x08, 0x2c, 0xb9, 0x54, 0x89, 0x58, 0xf1, 0x0b, 0x8c, 0x72, 0xc0, 0x1a,
-	0xb0, 0x1c, 0x7a, 0xe0, 0x82, 0x3e, 0x27, 0xa1, 0x67, 0x81, 0x9b, 0xe9, 0xd4, 0x2a, 0xc7, 0x2f,
-	0xaa, 0x14, 0x1f, 0xc0, 0x72, 0x08, 0x6f, 0x10, 0xbb, 0xa0, 0x67, 0x24, 0xf0, 0x22, 0xe1, 0x04,
-	0xee, 0x79, 0xbf, 0x8a, 0xb4, 0xd7, 0x54, 0x58, 0x9f, 0x7e, 0x4a, 0x9a, 0xb0, 0xc4, 0x41, 0xf1,
-	0x3e, 0xa6, 0x3a, 0x60, 0xfb, 0xb0, 0xf4, 0x43, 0xea, 0xd3, 0x1f, 0xc8, 0xf1, 0x54, 0xc4, 0xce,
-	0xc0, 0x8a, 0xe7, 0xa0, 0xbc, 0x8d, 0xd7, 0xd9, 0xe8, 0xb0, 0xfb, 0xa4, 0x17, 0x30, 0x5a, 0x71,
-	0x63, 0xa4, 0x7c, 0x85, 0xa5, 0xcf, 0x48, 0x23, 0x15, 0x4b, 0xae, 0x34, 0xe4, 0x18, 0xf1, 0x80,
-	0xed, 0xa7, 0x62, 0xf9, 0x8b, 0x86, 0xdc, 0x5f, 0x8c, 0xa7, 0x64, 0xe1, 0x56, 0x3c, 0x5e, 0xc5,
-	0x89, 0xc2, 0x90, 0x07, 0xac, 0x95, 0x8a, 0xe5, 0xb0, 0x70, 0xab, 0x81, 0xc7, 0xe8, 0x73, 0x12,
-	0x6c, 0x2e, 0xe6, 0x4f, 0xa3, 0xb3, 0x2a, 0xe9, 0xad, 0x35, 0xf8, 0x9b, 0xd1, 0x19, 0xfd, 0x8c,
-	0x34, 0x61, 0xca, 0x41, 0xcd, 0xfc, 0x00, 0x3b, 0x38, 0xc0, 0x06, 0x4c, 0x19, 0xfe, 0xa7, 0xdf,
-	0x93, 0xe3, 0xcd, 0x13, 0x2e, 0xfb, 0x13, 0xed, 0xf8, 0x94, 0xc7, 0x99, 0xc3, 0xb8, 0x37, 0xd8,
-	0xd1, 0x9a, 0x43, 0xea, 0xd5, 0x20, 0x73, 0xf4, 0x05, 0x39, 0x9a, 0x29, 0x93, 0x98, 0x98, 0x4f,
-	0x8a, 0xe9, 0x54, 0x01, 0x77, 0x2e, 0x89, 0x4e, 0xf0, 0x6c, 0x87, 0x25, 0x71, 0x85, 0xf8, 0x5b,
-	0x97, 0xd0, 0x4b, 0x72, 0x5a, 0x69, 0xfd, 0x3a, 0x55, 0x7a, 0x7c, 0xc7, 0x9e, 0x62, 0x41, 0xa7,
-	0x64, 0x47, 0x3a, 0x2b, 0x6b, 0xfc, 0xab, 0xf6, 0xec, 0xaf, 0x1a, 0x09, 0x99, 0x29, 0x9c, 0xce,
-	0x66, 0xff, 0xb7, 0x53, 0x1d, 0xb2, 0x27, 0x2c, 0xd7, 0x12, 0x17, 0xa9, 0xc9, 0x76, 0x85, 0xfd,
-	0x15, 0x3f, 0x2b, 0xb1, 0xe0, 0xb1, 0x82, 0x72, 0x6d, 0x9a, 0xac, 0x1e, 0x8b, 0x81, 0x02, 0xe7,
-	0xa7, 0xec, 0x12, 0x5b, 0x32, 0xbb, 0xc8, 0xec, 0xbb, 0xc4, 0x22, 0xf5, 0x94, 0xf8, 0x9f, 0x7c,
-	0xae, 0x56, 0xb8, 0x1b, 0x4d, 0x56, 0x77, 0x89, 0x7d, 0xad, 0x56, 0x2f, 0xba, 0x84, 0x3c, 0x78,
-	0x8f, 0x37, 0xc8, 0xee, 0x90, 0xbd, 0x19, 0xb7, 0x3f, 0xf1, 0xbf, 0x46, 0x2f, 0xd9, 0xeb, 0x76,
-	0x6d, 0x52, 0xc7, 0x6f, 0xde, 0xe5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x88, 0x3f, 0x1d,
-	0x05, 0x07, 0x00, 0x00,
+	// 1129 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x4f, 0x73, 0xdb, 0xb6,
+	0x13, 0x8d, 0x1c, 0x47, 0x96, 0x60, 0x91, 0x96, 0x61, 0xc7, 0x61, 0xf2, 0xfb, 0xb5, 0x55, 0x9d,
+	0x4e, 0x47, 0x93, 0x99, 0xba, 0xb5, 0xd2, 0x99, 0x4e, 0x8f, 0xb6, 0xd5, 0xb8, 0x69, 0xea, 0x58,
+	0x03, 0xa7, 0x4d, 0x6e, 0x18, 0x88, 0x80, 0x68, 0x54, 0x24, 0x41, 0x2f, 0x41, 0x8b, 0xca, 0xb1,
+	0xe7, 0x7e, 0xac, 0x7e, 0xb0, 0x0e, 0x96, 0x94, 0xe4, 0x3f, 0x69, 0x6f, 0xe2, 0x7b, 0x6f, 0xb9,
+	0xd8, 0x5d, 0xbc, 0xa5, 0x88, 0x9f, 0x81, 0x99, 0xe8, 0x58, 0xe5, 0x07, 0x19, 0x18, 0x6b, 0xe8,
+	0x5a, 0x9a, 0xef, 0xff, 0xdd, 0x24, 0xfe, 0x85, 0x82, 0x6b, 0x1d, 0xaa, 0x51, 0xc5, 0x52, 0x9f,
+	0xac, 0x69, 0x19, 0x34, 0x7a, 0x8d, 0x7e, 0x87, 0xad, 0x69, 0x49, 0x9f, 0x90, 0x8d, 0x22, 0xe6,
+	0x20, 0xac, 0x0a, 0xd6, 0x7a, 0x8d, 0xbe, 0xc7, 0x9a, 0x45, 0xcc, 0x84, 0x55, 0xf4, 0x2b, 0xe2,
+	0x17, 0x31, 0x1f, 0x17, 0xe1, 0x54, 0x59, 0x9e, 0xeb, 0x8f, 0x2a, 0x78, 0x88, 0x7c, 0xa7, 0x88,
+	0x8f, 0x11, 0xbc, 0xd0, 0x1f, 0x15, 0xfd, 0x9e, 0xf8, 0x75, 0x38, 0xcf, 0x4c, 0xac, 0xc3, 0x79,
+	0xb0, 0xde, 0x6b, 0xf4, 0xfd, 0x81, 0x7f, 0x90, 0xe6, 0x07, 0xee, 0x3d, 0x23, 0x44, 0x5d, 0xd4,
+	0xea, 0xc9, 0x25, 0x95, 0x75, 0xd2, 0x47, 0x55, 0x52, 0xb9, 0x4c, 0x2a, 0x6f, 0x27, 0x6d, 0x56,
+	0x49, 0xe5, 0x9d, 0xa4, 0xf2, 0x76, 0xd2, 0x8d, 0x4f, 0x27, 0x95, 0x37, 0x93, 0x7e, 0x4d, 0xb6,
+	0x84, 0x94, 0x3c, 0x9a, 0xf1, 0x44, 0x59, 0x21, 0x85, 0x15, 0x41, 0xab, 0xd7, 0xe8, 0xb7, 0x98,
+	0x27, 0xa4, 0x3c, 0x9d, 0x9d, 0xd5, 0x20, 0xfd, 0x86, 0xec, 0x48, 0x75, 0xcd, 0x73, 0x2b, 0x6c,
+	0x91, 0x73, 0x50, 0x57, 0x7c, 0x02, 0xea, 0x2a, 0x68, 0xe3, 0x41, 0xba, 0x52, 0x5d, 0x5f, 0x20,
+	0xc3, 0xd4, 0xd5, 0x2b, 0x50, 0x57, 0xf4, 0x47, 0xf2, 0x14, 0x54, 0x66, 0xc0, 0xf2, 0x1b, 0x51,
+	0x63, 0x61, 0xad, 0x82, 0x79, 0x40, 0x30, 0xc1, 0x5e, 0x25, 0x18, 0x2e, 0x42, 0x8f, 0x2b, 0x96,
+	0xfe, 0x40, 0x82, 0xfb, 0xa1, 0x89, 0x80, 0x48, 0xa7, 0xc1, 0x26, 0x46, 0x3e, 0xbe, 0x13, 0x79,
+	0x86, 0x24, 0x7d, 0x4c, 0x9a, 0x12, 0x78, 0xa2, 0xd3, 0xa0, 0x83, 0xa7, 0x7a, 0x24, 0xe1, 0x6c,
+	0x05, 0x8b, 0x32, 0xf0, 0x96, 0xb0, 0x28, 0xe9, 0x97, 0xa4, 0x13, 0x5e, 0x8a, 0x34, 0x55, 0x31,
+	0x4f, 0x44, 0x3e, 0x0d, 0x7c, 0x1c, 0xfe, 0x66, 0x8d, 0x9d, 0x89, 0x7c, 0x4a, 0x3f, 0x23, 0x24,
+	0x03, 0x2e, 0xe2, 0xd8, 0xcc, 0x94, 0x0c, 0xb6, 0x30, 0x77, 0x3b, 0x83, 0xa3, 0x0a, 0x70, 0xf4,
+	0xe5, 0x8a, 0xee, 0x56, 0xf4, 0xe5, 0x4d, 0x1a, 0xc4, 0x92, 0xde, 0xae, 0x68, 0x10, 0x0b, 0xfa,
+	0x73, 0xb2, 0x99, 0xce, 0xa6, 0x3c, 0x52, 0x86, 0xc7, 0x26, 0x0c, 0x68, 0xc5, 0xa7, 0xb3, 0xe9,
+	0xa9, 0x32, 0xbf, 0x9a, 0xd0, 0x85, 0x5b, 0x01, 0x91, 0xb2, 0x3c, 0x53, 0x10, 0xec, 0xe0, 0xd1,
+	0xdb, 0x15, 0x32, 0x52, 0x40, 0xfb, 0xa4, 0x9b, 0xe8, 0xd4, 0xcd, 0x4d, 0xea, 0x6b, 0x05, 0xb9,
+	0xb6, 0xf3, 0x60, 0x17, 0x45, 0x7e, 0xa2, 0xd3, 0xd3, 0xd9, 0x70, 0x81, 0xd2, 0x17, 0x64, 0xbb,
+	0x88, 0xb9, 0xd0, 0x60, 0x75, 0xa2, 0xf8, 0xb8, 0x90, 0x91, 0xb2, 0xc1, 0x63, 0x94, 0x6e, 0x15,
+	0xf1, 0x51, 0x85, 0x1f, 0x23, 0xec, 0xb4, 0xf2, 0x9e, 0x76, 0xaf, 0xd2, 0xca, 0xdb, 0xda, 0xfd,
+	0xbf, 0x5a, 0xc4, 0x1b, 0xaa, 0xff, 0x72, 0x51, 0x9f, 0x74, 0xf3, 0x22, 0x73, 0xa3, 0xca, 0x79,
+	0x18, 0x8b, 0x3c, 0xe7, 0x63, 0xb4, 0x53, 0x8b, 0xf9, 0x0b, 0xfc, 0xc4, 0xc1, 0xc7, 0xee, 0x16,
+	0xd6, 0x02, 0xee, 0x32, 0x98, 0xc2, 0xd6, 0xbe, 0xf2, 0x10, 0x3e, 0x7e, 0x57, 0x81, 0xee, 0x8d,
+	0x99, 0x4e, 0x23, 0x9e, 0xc7, 0x06, 0xfb, 0xa2, 0x8d, 0x44, 0x6b, 0x79, 0xcc, 0x77, 0xf8, 0x45,
+	0x6c, 0x5c, 0x73, 0xb4, 0x91, 0xb4, 0x47, 0x3a, 0x2b, 0xa5, 0x84, 0xda, 0x51, 0x64, 0xa1, 0x1a,
+	0x82, 0x73, 0xd5, 0x4a, 0x81, 0x97, 0xb9, 0x76, 0xd5, 0x42, 0x83, 0x17, 0xf9, 0x7e, 0x0d, 0x61,
+	0xb0, 0xf1, 0x89, 0x1a, 0x4e, 0x56, 0x35, 0x84, 0xcb, 0x1a, 0x5a, 0x37, 0x6a, 0x38, 0x59, 0xd4,
+	0xf0, 0x05, 0xd9, 0x4c, 0x44, 0xc8, 0x71, 0x3c, 0x26, 0x45, 0x07, 0xb5, 0x19, 0x49, 0x44, 0xf8,
+	0x7b, 0x85, 0xd0, 0x03, 0xb2, 0x03, 0x2a, 0xe2, 0x99, 0x00, 0x91, 0x38, 0xab, 0x5d, 0x6b, 0x14,
+	0x12, 0x14, 0x6e, 0x83, 0x8a, 0x46, 0xc8, 0xb0, 0x9a, 0xa0, 0xff, 0x27, 0x04, 0x4a, 0x2e, 0x55,
+	0x2c, 0xe6, 0xfc, 0x10, 0x2d, 0xe2, 0xb1, 0x16, 0x94, 0x43, 0x07, 0x1c, 0xd2, 0xe7, 0xc4, 0x77,
+	0x2c, 0x70, 0x33, 0x99, 0xe4, 0xca, 0xf2, 0xc3, 0xda, 0x1d, 0x9b, 0x50, 0x0e, 0xe1, 0x1c, 0xb1,
+	0x43, 0xba, 0x4f, 0x3c, 0x27, 0x12, 0x56, 0xe0, 0xfe, 0x18, 0x04, 0xde, 0x52, 0x53, 0x63, 0x03,
+	0xfa, 0x8c, 0xb4, 0xa1, 0xc4, 0x46, 0xf1, 0x01, 0xba, 0xc5, 0x63, 0x1b, 0x50, 0xba, 0x26, 0x0d,
+	0xe8, 0x77, 0x64, 0x77, 0x22, 0x42, 0x6b, 0x60, 0xce, 0x33, 0x50, 0x2e, 0x8d, 0xd3, 0xe5, 0xc1,
+	0x56, 0xef, 0x61, 0xdf, 0x63, 0xb4, 0xe6, 0x46, 0x48, 0xb9, 0x88, 0x9c, 0x3e, 0x25, 0xad, 0x44,
+	0x94, 0x5c, 0x69, 0xc8, 0xd0, 0x3a, 0x1e, 0xdb, 0x48, 0x44, 0xf9, 0x93, 0x86, 0xcc, 0x0d, 0xc6,
+	0x51, 0xb2, 0xb0, 0x73, 0x1e, 0xce, 0xc3, 0x58, 0xa1, 0x79, 0x3c, 0xd6, 0x49, 0x44, 0x39, 0x2c,
+	0xec, 0xfc, 0xc4, 0x61, 0xf4, 0x39, 0xf1, 0x96, 0x83, 0xf9, 0xc3, 0xe8, 0xb4, 0x76, 0x50, 0x67,
+	0x01, 0xfe, 0x62, 0x74, 0x4a, 0xff, 0x47, 0xda, 0x30, 0xe1, 0xa0, 0x22, 0xd7, 0xc0, 0x1d, 0x6c,
+	0x60, 0x0b, 0x26, 0x0c, 0x9f, 0xe9, 0xb7, 0x64, 0x77, 0xf9, 0x86, 0x97, 0x83, 0xb1, 0xb6, 0x7c,
+	0xc2, 0xc3, 0xd4, 0xa2, 0x8d, 0x5a, 0x6c, 0x7b, 0xc1, 0x21, 0xf5, 0xea, 0x24, 0x45, 0x77, 0x44,
+	0xca, 0xc4, 0x26, 0xe4, 0xe3, 0x62, 0x32, 0x51, 0xc0, 0xad, 0x8d, 0x17, 0x4e, 0xaa, 0x88, 0x63,
+	0xc4, 0xdf, 0xd9, 0x98, 0xbe, 0x24, 0x7b, 0xb5, 0xd6, 0xd9, 0xb4, 0xd6, 0xe3, 0xee, 0xae, 0xec,
+	0xb4, 0x53, 0xb1, 0x67, 0x3a, 0xad, 0x62, 0x70, 0x85, 0xf7, 0x49, 0x57, 0x48, 0xb7, 0x52, 0x22,
+	0x03, 0xda, 0x5e, 0x26, 0x5c, 0xcb, 0xe0, 0x09, 0x9e, 0xda, 0x17, 0x12, 0x8e, 0x16, 0xf0, 0x6b,
+	0x49, 0x7f, 0x26, 0xbb, 0x50, 0xf2, 0x99, 0x4e, 0xa5, 0x99, 0xb9, 0x96, 0x4f, 0x14, 0xa8, 0x34,
+	0x54, 0x41, 0x80, 0x2b, 0x7f, 0x0f, 0x57, 0xfe, 0x87, 0xf7, 0x48, 0x8f, 0x96, 0x2c, 0xa3, 0x50,
+	0xde, 0xc5, 0x5c, 0xb7, 0xc5, 0x38, 0xe3, 0x93, 0x30, 0xb5, 0x1c, 0xe7, 0x13, 0x3c, 0xad, 0x1a,
+	0x29, 0xc6, 0xd9, 0xab, 0x30, 0xb5, 0xcc, 0x61, 0xce, 0x4e, 0x6e, 0x26, 0xa8, 0x8a, 0x44, 0x16,
+	0x3c, 0xab, 0xec, 0x94, 0x88, 0xd2, 0x69, 0x4e, 0x45, 0xb6, 0xff, 0x67, 0x83, 0xf8, 0xcc, 0x14,
+	0x56, 0xa7, 0xd1, 0xbf, 0xed, 0x83, 0x1d, 0xf2, 0x48, 0xe4, 0xae, 0xa6, 0x35, 0xac, 0x69, 0x5d,
+	0xe4, 0xaf, 0xf1, 0x53, 0x1b, 0x0a, 0x1e, 0x2a, 0xa8, 0x2c, 0xdf, 0x66, 0xcd, 0x50, 0x9c, 0x28,
+	0xb0, 0xee, 0x86, 0xd8, 0x38, 0xaf, 0x98, 0x75, 0x64, 0x36, 0x6c, 0x9c, 0x23, 0xf5, 0x84, 0xb8,
+	0x9f, 0x7c, 0xaa, 0xe6, 0xe8, 0xeb, 0x36, 0x6b, 0xda, 0x38, 0x7f, 0xa3, 0xe6, 0x2f, 0x7a, 0x84,
+	0xdc, 0xf8, 0xb6, 0xb5, 0xc8, 0xfa, 0x90, 0x9d, 0x8f, 0xba, 0x0f, 0xdc, 0xaf, 0xb3, 0x23, 0xf6,
+	0xa6, 0xdb, 0x78, 0xf1, 0x96, 0xd0, 0xfb, 0x8d, 0xa1, 0x94, 0xf8, 0xec, 0x03, 0x7f, 0xff, 0xfa,
+	0xed, 0xf0, 0xfc, 0x3d, 0x3f, 0xfa, 0xed, 0xdd, 0x79, 0xf7, 0x01, 0xdd, 0x26, 0xde, 0x0a, 0x63,
+	0x1f, 0x0e, 0xbb, 0x8d, 0xbb, 0xd0, 0xa0, 0xbb, 0x36, 0x6e, 0xe2, 0xff, 0x8a, 0x97, 0xff, 0x0c,
+	0x00, 0xb6, 0xea, 0xc9, 0xa1, 0x69, 0x08, 0x00, 0x00,
 }
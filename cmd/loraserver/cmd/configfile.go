@@ -59,6 +59,19 @@ dsn="{{ .PostgreSQL.DSN }}"
 # App Server and / or applying migrations.
 automigrate={{ .PostgreSQL.Automigrate }}
 
+# Device-, service- and routing-profile cache TTL.
+#
+# The profiles are cached in-memory to avoid a database query for every
+# uplink. Updates made through the API of this instance are applied directly,
+# updates made through other instances are picked up after the TTL.
+profile_cache_ttl="{{ .PostgreSQL.ProfileCacheTTL }}"
+
+# Disable the in-memory profile cache.
+#
+# When set, the profiles are always read from Redis or the database. This can
+# be useful for debugging.
+disable_profile_cache={{ .PostgreSQL.DisableProfileCache }}
+
 
 # Redis settings
 #
@@ -85,12 +98,32 @@ idle_timeout="{{ .Redis.IdleTimeout }}"
 # When zero, there is no limit on the number of connections in the pool.
 max_active={{ .Redis.MaxActive }}
 
+# Redis Sentinel master name (optional).
+#
+# When set, LoRa Server connects to the master as reported by the
+# Sentinels below. The host of the url setting is then ignored, the
+# other parts (e.g. password and database) are still used.
+#
+# Note that Redis Cluster is not supported, LoRa Server requires a single
+# (Sentinel managed) master.
+sentinel_master_name="{{ .Redis.SentinelMasterName }}"
+
+# Redis Sentinel addresses (hostname:port).
+sentinel_addrs=[{{ range $index, $element := .Redis.SentinelAddrs }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
 
 # Network-server settings.
 [network_server]
 # Network identifier (NetID, 3 bytes) encoded as HEX (e.g. 010203)
 net_id="{{ .NetworkServer.NetID }}"
 
+# Extra NetIDs.
+#
+# DevAddrs matching one of these NetIDs (3 bytes, encoded as HEX) are handled
+# as if they match the NetID above, e.g. for devices that were activated
+# using the experimental NetIDs 000000 or 000001.
+extra_net_ids=[{{ range $index, $element := .NetworkServer.ExtraNetIDs }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
 # Time to wait for uplink de-duplication.
 #
 # This is the time that LoRa Server will wait for other gateways to receive
@@ -107,6 +140,15 @@ deduplication_delay="{{ .NetworkServer.DeduplicationDelay }}"
 # values can be combined, e.g. '24h30m15s'.
 device_session_ttl="{{ .NetworkServer.DeviceSessionTTL }}"
 
+# Device-queue item expiration.
+#
+# When set, device-queue items that have not been transmitted within this
+# duration after they were enqueued are discarded and the application-server
+# is notified that the item expired. Expired items are discarded on the next
+# downlink opportunity of the device, or else by a periodic (every minute)
+# sweep. Set to 0 to disable expiration.
+device_queue_item_ttl="{{ .NetworkServer.DeviceQueueItemTTL }}"
+
 # Get downlink data delay.
 #
 # This is the time that LoRa Server waits between forwarding data to the
@@ -119,6 +161,15 @@ device_session_ttl="{{ .NetworkServer.DeviceSessionTTL }}"
 # unable to respond to the device within its receive-window.
 get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
 
+# Shutdown timeout.
+#
+# On shutdown (SIGTERM or SIGINT), LoRa Server stops consuming new gateway
+# messages and rejects new API requests with Unavailable (so that clients
+# can retry using an other instance). It then waits at most this duration
+# for the in-flight uplink and downlink handling to complete before
+# closing the API server and database connections.
+shutdown_timeout="{{ .NetworkServer.ShutdownTimeout }}"
+
 
   # LoRaWAN regional band configuration.
   #
@@ -190,6 +241,11 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # 0: RX1, fallback to RX2 (on RX1 scheduling error)
   # 1: RX1 only
   # 2: RX2 only
+  # 3: auto, RX1 or RX2 depending on which window is able to carry the
+  #    payload, fallback to RX2 (on RX1 scheduling error)
+  #
+  # When RX1 with fallback to RX2 is used (0 or 3), RX2 is used in case it is
+  # too late to schedule the RX1 transmission.
   rx_window={{ .NetworkServer.NetworkSettings.RXWindow }}
 
   # Class A RX1 delay
@@ -225,7 +281,8 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # Downlink TX Power (dBm)
   #
   # When set to -1, the downlink TX Power from the configured band will
-  # be used.
+  # be used, capped by the max TX power and minus the antenna gain
+  # configured for the gateway.
   #
   # Please consult the LoRaWAN Regional Parameters and local regulations
   # for valid and legal options. Note that the configured TX Power must be
@@ -240,11 +297,79 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # only.
   disable_mac_commands={{ .NetworkServer.NetworkSettings.DisableMACCommands }}
 
+  # Mac-command acknowledgement uplinks
+  #
+  # The number of uplinks after which a pending mac-command, for which no
+  # answer was received, is considered timed out. Set to 0 to disable.
+  mac_command_ack_uplinks={{ .NetworkServer.NetworkSettings.MACCommandAckUplinks }}
+
+  # Mac-command max retries
+  #
+  # The max number of times a timed out mac-command enqueued by an external
+  # service is re-queued. After this, the mac-command is dropped.
+  mac_command_max_retries={{ .NetworkServer.NetworkSettings.MACCommandMaxRetries }}
+
+  # Prioritize external mac-commands
+  #
+  # When the mac-commands to send do not fit within a single downlink,
+  # mac-commands generated by LoRa Server (e.g. ADR and channel
+  # re-configuration) are sent first and the remaining mac-commands are sent
+  # on the next downlink opportunity. When set to true, mac-commands enqueued
+  # by an external service get priority instead.
+  prioritize_external_mac_commands={{ .NetworkServer.NetworkSettings.PrioritizeExternalMACCommands }}
+
   # Disable ADR
   #
   # When set, this globally disables ADR.
   disable_adr={{ .NetworkServer.NetworkSettings.DisableADR }}
 
+  # Max LinkADRReq rejections
+  #
+  # When a device rejects a LinkADRReq, the ADR engine retries with a less
+  # aggressive data-rate or tx-power. After this number of consecutive
+  # rejections, ADR is stopped for the device and an error is sent to the
+  # application-server. Set this to 0 to never stop ADR. ADR can be resumed
+  # using the ResetDeviceSessionLinkADRState API method.
+  max_link_adr_req_rejections={{ .NetworkServer.NetworkSettings.MaxLinkADRReqRejections }}
+
+  # ABP frame-counter reset max. frame-counter
+  #
+  # For ABP devices with frame-counter reset detection enabled in the
+  # device-profile, an uplink with a lower frame-counter than expected is
+  # only handled as a frame-counter reset (e.g. after a power-cycle) when
+  # its frame-counter does not exceed this value. Other uplinks with a
+  # lower frame-counter are rejected, to avoid that a replayed uplink
+  # resets the device-session.
+  abp_fcnt_reset_max_fcnt={{ .NetworkServer.NetworkSettings.ABPFCntResetMaxFCnt }}
+
+  # Disable ADRACKReq downlink
+  #
+  # By default, LoRa Server sends an (empty) downlink when the device sets
+  # the ADRACKReq bit, so that the device does not lower its data-rate. When
+  # set to true, no downlink is sent in this case (unless there is something
+  # else to send), which saves downlink airtime.
+  disable_adr_ack_req_downlink={{ .NetworkServer.NetworkSettings.DisableADRACKReqDownlink }}
+
+  # Disable ACK-only downlink
+  #
+  # By default, LoRa Server sends an (empty) downlink with the ACK bit set
+  # when a confirmed uplink was received and there is nothing else to send.
+  # Without this ACK, the device retransmits the uplink and eventually
+  # considers it lost. When set to true, the ACK is only sent together with
+  # other downlink data, which saves downlink airtime.
+  disable_ack_only_downlink={{ .NetworkServer.NetworkSettings.DisableACKOnlyDownlink }}
+
+  # Gateway duty-cycle
+  #
+  # When enabled, LoRa Server keeps track of the downlink airtime of each
+  # gateway per regulatory sub-band (sliding window of one hour). Gateways
+  # that exhausted their duty-cycle budget are skipped for RX1, RX2,
+  # Class-B and Class-C downlink transmissions, in which case the next-best
+  # gateway that received the (last) uplink is used. Downlinks pinned to a
+  # gateway are not affected. This is currently implemented for the EU868
+  # band only.
+  gateway_duty_cycle_enabled={{ .NetworkServer.NetworkSettings.GatewayDutyCycleEnabled }}
+
   # Enable only a given sub-set of channels
   #
   # Use this when ony a sub-set of the by default enabled channels are being
@@ -348,6 +473,140 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     # after a preceeding downlink tx (per device).
     downlink_lock_duration="{{ .NetworkServer.Scheduler.ClassC.DownlinkLockDuration }}"
 
+    # Downlink retry count
+    #
+    # The number of times a confirmed Class-C downlink is retransmitted
+    # when it was not acknowledged by the device within the Class-C timeout
+    # (configured in the device-profile). After the last attempt, the
+    # application-server is notified that the downlink was not acknowledged.
+    downlink_retry_count={{ .NetworkServer.Scheduler.ClassC.DownlinkRetryCount }}
+
+
+  # Frame-log settings.
+  #
+  # The frame-logs are published to Redis so that they can be streamed
+  # using the StreamFrameLogsForGateway and StreamFrameLogsForDevice API
+  # methods.
+  [network_server.frame_log]
+  # Publish the frame-logs asynchronously.
+  #
+  # When enabled, the frame-logs are buffered and published in batches
+  # (every 100ms or per 100 frame-logs) so that a slow or unavailable Redis
+  # does not block the uplink and downlink handling. When the buffer is full,
+  # frame-logs are dropped (see the framelog_dropped_count metric).
+  # When disabled, each frame-log is published before the handling continues.
+  async={{ .NetworkServer.FrameLog.Async }}
+
+  # Buffer size.
+  #
+  # The max. number of frame-logs that are buffered when async is enabled.
+  buffer_size={{ .NetworkServer.FrameLog.BufferSize }}
+
+
+  # Uplink error settings.
+  #
+  # When an uplink frame can't be handled because of an invalid MIC, frame-
+  # counter or payload size, the error is forwarded to the application-server
+  # of the device using the HandleError API method. This also applies when
+  # ADR has been stopped because of too many rejected LinkADRReqs.
+  [network_server.uplink_errors]
+  # Error types to forward.
+  #
+  # Valid options are: DATA_UP_MIC, DATA_UP_FCNT, DATA_UP_FCNT_RESET,
+  # DATA_UP_FCNT_RETRANSMISSION, DATA_UP_FCNT_GAP_TOO_LARGE, DATA_UP_SIZE and
+  # LINK_ADR_REQ_REJECTED.
+  # DATA_UP_FCNT also enables DATA_UP_FCNT_GAP_TOO_LARGE. Note that all
+  # errors are counted in the uplink_data_error_count metric, including the
+  # error types that are not forwarded.
+  forward_types=[{{ range $index, $element := .NetworkServer.UplinkErrors.ForwardTypes }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
+  # Rate-limit interval.
+  #
+  # Each error type is forwarded at most once per this interval per device.
+  rate_limit_interval="{{ .NetworkServer.UplinkErrors.RateLimitInterval }}"
+
+
+  # NetID filter.
+  #
+  # When enabled, uplink data frames of which the DevAddr does not match the
+  # NetID (or one of the extra NetIDs) are rejected, unless passive-roaming
+  # is enabled (see [roaming]). Rejected frames are counted (per DevAddr
+  # prefix) in the uplink_data_net_id_rejected_count metric.
+  [network_server.net_id_filter]
+  # Enable the NetID filter.
+  enabled={{ .NetworkServer.NetIDFilter.Enabled }}
+
+  # Log rejected frames.
+  #
+  # When enabled, rejected frames are logged at debug level, including the
+  # IDs of the receiving gateways.
+  log_rejected={{ .NetworkServer.NetIDFilter.LogRejected }}
+
+
+  # Proprietary uplink.
+  #
+  # Proprietary uplink frames (MType Proprietary) are forwarded, together with
+  # the TX meta-data and the RX meta-data of all receiving gateways, to the
+  # configured handler. There is no device-session lookup for these frames.
+  [network_server.proprietary_uplink]
+  # Handler.
+  #
+  # Valid options are:
+  #  * application_server: forward to the application-server of each
+  #    routing-profile using the HandleProprietaryUplink API method
+  #  * mqtt: publish (JSON encoded) to the MQTT topic configured below
+  handler="{{ .NetworkServer.ProprietaryUplink.Handler }}"
+
+  # Rate-limit.
+  #
+  # The max. number of proprietary uplink frames per gateway within the
+  # rate-limit interval. Frames exceeding this limit are not forwarded for
+  # the given gateway. Set to 0 to disable the rate-limit.
+  rate_limit={{ .NetworkServer.ProprietaryUplink.RateLimit }}
+
+  # Rate-limit interval.
+  rate_limit_interval="{{ .NetworkServer.ProprietaryUplink.RateLimitInterval }}"
+
+    # MQTT handler settings.
+    [network_server.proprietary_uplink.mqtt]
+    # MQTT server (e.g. scheme://host:port where scheme is tcp, ssl or ws)
+    server="{{ .NetworkServer.ProprietaryUplink.MQTT.Server }}"
+
+    # Connect with the given username (optional)
+    username="{{ .NetworkServer.ProprietaryUplink.MQTT.Username }}"
+
+    # Connect with the given password (optional)
+    password="{{ .NetworkServer.ProprietaryUplink.MQTT.Password }}"
+
+    # Quality of service level
+    #
+    # 0: at most once
+    # 1: at least once
+    # 2: exactly once
+    #
+    # Note: an increase of this value will decrease the performance.
+    # For more information: https://www.hivemq.com/blog/mqtt-essentials-part-6-mqtt-quality-of-service-levels
+    qos={{ .NetworkServer.ProprietaryUplink.MQTT.QOS }}
+
+    # Client ID
+    #
+    # Set the client id to be used by this client when connecting to the MQTT
+    # broker. A client id must be no longer than 23 characters. When left blank,
+    # a random id will be generated. This requires clean_session=true.
+    client_id="{{ .NetworkServer.ProprietaryUplink.MQTT.ClientID }}"
+
+    # CA certificate file (optional)
+    ca_cert="{{ .NetworkServer.ProprietaryUplink.MQTT.CACert }}"
+
+    # TLS certificate file (optional)
+    tls_cert="{{ .NetworkServer.ProprietaryUplink.MQTT.TLSCert }}"
+
+    # TLS key file (optional)
+    tls_key="{{ .NetworkServer.ProprietaryUplink.MQTT.TLSKey }}"
+
+    # Topic to publish the proprietary uplink frames to.
+    topic="{{ .NetworkServer.ProprietaryUplink.MQTT.Topic }}"
+
 
   # Network-server API
   #
@@ -366,6 +625,128 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # tls key used by the api server (optional)
   tls_key="{{ .NetworkServer.API.TLSKey }}"
 
+  # Allowed client-certificate common names (optional).
+  #
+  # When the ca_cert, tls_cert and tls_key are set, clients must present
+  # a certificate signed by the ca_cert. When this list is not empty, the
+  # common name (CN) of the client-certificate must also be in this list.
+  allowed_client_cns=[{{ range $index, $element := .NetworkServer.API.AllowedClientCNs }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
+  # Allow health-checks without client-certificate.
+  #
+  # When set, the gRPC health-check service (grpc.health.v1.Health) can be
+  # called without client-certificate, e.g. by load balancers.
+  #
+  # The health-check service reports the readiness for the empty service
+  # name (PostgreSQL, Redis and the gateway backend must be available) and
+  # the liveness for the "liveness" service name. The dependencies can be
+  # checked individually using the "postgresql", "redis" and
+  # "gateway_backend" service names.
+  health_check_without_client_cert={{ .NetworkServer.API.HealthCheckWithoutClientCert }}
+
+
+  # Gateway offline detection.
+  #
+  # A gateway is marked offline when it did not send its stats within
+  # offline_multiplier times the stats_interval. On every offline and
+  # back-online transition, an event is sent to the network-controller.
+  [network_server.gateway.offline_detection]
+  # Stats interval.
+  #
+  # This must match the stats interval configured in the gateways
+  # (LoRa Gateway Bridge).
+  stats_interval="{{ .NetworkServer.Gateway.OfflineDetection.StatsInterval }}"
+
+  # Offline multiplier.
+  #
+  # The gateway is marked offline after stats_interval * offline_multiplier
+  # without receiving gateway stats.
+  offline_multiplier={{ .NetworkServer.Gateway.OfflineDetection.OfflineMultiplier }}
+
+  # Check interval.
+  #
+  # The interval in which the gateway states are checked.
+  check_interval="{{ .NetworkServer.Gateway.OfflineDetection.CheckInterval }}"
+
+  # Debounce duration.
+  #
+  # The min. duration between two state transitions of a gateway, to avoid
+  # an event storm in case of a flapping gateway.
+  debounce_duration="{{ .NetworkServer.Gateway.OfflineDetection.DebounceDuration }}"
+
+
+  # Gateway auto-create.
+  #
+  # When enabled, unknown gateways are created when they send their first
+  # stats. Auto-created gateways are flagged as such, until they are
+  # updated through the API.
+  [network_server.gateway.auto_create]
+  # Enable gateway auto-create.
+  enabled={{ .NetworkServer.Gateway.AutoCreate.Enabled }}
+
+  # Gateway-profile ID (optional).
+  #
+  # When set, auto-created gateways are assigned to this gateway-profile.
+  gateway_profile_id="{{ .NetworkServer.Gateway.AutoCreate.GatewayProfileID }}"
+
+
+  # Gateway location update.
+  #
+  # Gateways with update_location_from_stats set will get their location
+  # updated from the GPS location in their stats.
+  [network_server.gateway.location_update]
+  # Max. jump distance (meters).
+  #
+  # Location updates moving the gateway more than this distance are
+  # ignored, as these are most likely caused by a bogus GPS fix.
+  # Set this to 0 to disable this check.
+  max_jump_distance={{ .NetworkServer.Gateway.LocationUpdate.MaxJumpDistance }}
+
+  # Jump confirmation count.
+  #
+  # Once a location update has been ignored because of the max. jump
+  # distance, the new location is accepted after this number of
+  # consecutive location updates within the max. jump distance of the
+  # ignored location (e.g. the gateway has been moved).
+  # Set this to 0 to never accept such a location update.
+  jump_confirmation_count={{ .NetworkServer.Gateway.LocationUpdate.JumpConfirmationCount }}
+
+
+  # Gateway discovery.
+  #
+  # When enabled, the network-server periodically instructs each gateway
+  # with discovery_enabled set to transmit a signed proprietary ping frame.
+  # The receptions of this ping by other gateways are stored and can be
+  # retrieved using the GetGatewayDiscoveryPings API method.
+  [network_server.gateway.discovery]
+  # Ping interval.
+  #
+  # Set this to 0 to disable the gateway discovery.
+  interval="{{ .NetworkServer.Gateway.Discovery.Interval }}"
+
+  # Ping frequency (Hz).
+  #
+  # This must be an uplink frequency on which the other gateways are
+  # listening. When set to 0, the frequency of the first enabled uplink
+  # channel is used. Make sure that the data-rate below is valid for this
+  # channel.
+  frequency={{ .NetworkServer.Gateway.Discovery.Frequency }}
+
+  # Ping data-rate.
+  dr={{ .NetworkServer.Gateway.Discovery.DR }}
+
+  # HMAC key (HEX encoded).
+  #
+  # This key is used to sign the ping frames, so that only pings sent by
+  # the network-server are stored. This must be set when the gateway
+  # discovery is enabled.
+  hmac_key="{{ .NetworkServer.Gateway.Discovery.HMACKey }}"
+
+  # Retention.
+  #
+  # Ping receptions older than this duration are removed.
+  retention="{{ .NetworkServer.Gateway.Discovery.Retention }}"
+
 
   # Backend defines the gateway backend settings.
   #
@@ -517,6 +898,99 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # TLS key used by the API client (optional).
   tls_key="{{ .GeolocationServer.TLSKey }}"
 
+  # Resolve workers.
+  #
+  # The geolocation requests are queued and handled by the given number of
+  # workers, so that they never delay the uplink handling. When the queue
+  # is full, geolocation requests are dropped (see the
+  # geolocation_dropped_count metric).
+  # When set to 0, each geolocation request is handled in its own goroutine.
+  workers={{ .GeolocationServer.Workers }}
+
+  # Queue size.
+  #
+  # The max. number of queued geolocation requests.
+  queue_size={{ .GeolocationServer.QueueSize }}
+
+  # Resolve interval.
+  #
+  # The min. interval between two geolocation requests for the same device.
+  # Geolocation requests within this interval are skipped. Set this to 0 to
+  # disable this rate-limit.
+  resolve_interval="{{ .GeolocationServer.ResolveInterval }}"
+
+  # Request timeout.
+  #
+  # The max. duration of a geolocation request (including forwarding the
+  # resolved location to the application-server).
+  request_timeout="{{ .GeolocationServer.RequestTimeout }}"
+
+
+# Application-server client settings.
+#
+# These settings apply to the connections to the application-servers
+# configured in the routing-profiles (one connection per routing-profile).
+[application_server]
+# Keepalive interval.
+#
+# The interval in which an idle connection is pinged to detect broken
+# connections (e.g. after an application-server restart). Note that the
+# application-server might reject pings sent more frequently than its
+# keepalive enforcement policy allows (the gRPC default is 5 minutes) or
+# pings sent while there are no active RPCs.
+# Set to 0 to disable.
+keepalive_interval="{{ .ApplicationServer.KeepaliveInterval }}"
+
+# Keepalive timeout.
+#
+# The time to wait for the ping response before the connection is closed
+# and re-connected.
+keepalive_timeout="{{ .ApplicationServer.KeepaliveTimeout }}"
+
+# Reconnect max. delay.
+#
+# The max. backoff delay between reconnect attempts.
+reconnect_max_delay="{{ .ApplicationServer.ReconnectMaxDelay }}"
+
+# Idle timeout.
+#
+# Connections which have not been used within this duration are closed.
+# Set to 0 to disable.
+idle_timeout="{{ .ApplicationServer.IdleTimeout }}"
+
+# Uplink data retry count.
+#
+# The max. number of times forwarding uplink data to the application-server
+# is retried when it is unavailable. Set to 0 to disable retries.
+retry_count={{ .ApplicationServer.RetryCount }}
+
+# Uplink data retry backoff.
+#
+# The delay before the first retry. This delay is doubled on every retry.
+retry_backoff="{{ .ApplicationServer.RetryBackoff }}"
+
+  # Dead-letter queue.
+  #
+  # When enabled, uplink data which could not be forwarded after the last
+  # retry is stored in Redis (per routing-profile) and forwarded once the
+  # application-server is available again. When disabled, the uplink data
+  # is dropped.
+  [application_server.dead_letter_queue]
+  # Enable the dead-letter queue.
+  enabled={{ .ApplicationServer.DeadLetterQueue.Enabled }}
+
+  # Max. length.
+  #
+  # The max. number of items stored per routing-profile. When exceeded, the
+  # oldest items are removed.
+  max_length={{ .ApplicationServer.DeadLetterQueue.MaxLength }}
+
+  # Drain interval.
+  #
+  # The interval in which the dead-letter queues are forwarded to the
+  # application-servers.
+  drain_interval="{{ .ApplicationServer.DeadLetterQueue.DrainInterval }}"
+
 
 # Metrics collection settings.
 [metrics]
@@ -652,6 +1126,98 @@ resolve_domain_suffix="{{ .JoinServer.ResolveDomainSuffix }}"
   kek="{{ $element.KEK }}"
   {{ end }}
 
+# Roaming settings.
+[roaming]
+# Enable passive roaming.
+#
+# When enabled, uplink data frames of which the DevAddr does not match the
+# configured NetID (or one of the extra NetIDs) are forwarded to the
+# network-server of the matching roaming partner (see [[roaming.servers]]), using the LoRaWAN Backend Interfaces
+# PRStartReq / XmitDataReq messages. Frames of which the DevAddr does not
+# match any of the roaming partners are dropped.
+enabled={{ .Roaming.Enabled }}
+
+# Roaming partner request timeout.
+#
+# The uplinks are forwarded to the roaming partners while handling the
+# uplink. Requests taking longer than this duration are canceled.
+request_timeout="{{ .Roaming.RequestTimeout }}"
+
+
+  # Roaming API.
+  #
+  # This API receives the XmitDataReq messages (containing the downlink) from
+  # the roaming partners.
+  [roaming.api]
+  # ip:port to bind the roaming API server to.
+  bind="{{ .Roaming.API.Bind }}"
+
+  # CA certificate.
+  #
+  # The roaming API server validates the client-certificate of the roaming
+  # partners using this CA certificate. The CommonName of the
+  # client-certificate must match the NetID (SenderID) of the roaming
+  # partner. The roaming API will not start without ca_cert, tls_cert
+  # and tls_key.
+  ca_cert="{{ .Roaming.API.CACert }}"
+
+  # TLS certificate and key.
+  tls_cert="{{ .Roaming.API.TLSCert }}"
+  tls_key="{{ .Roaming.API.TLSKey }}"
+
+  # ULToken key.
+  #
+  # This key is used to sign the ULToken (containing the gateway context)
+  # forwarded to the roaming partners, which is validated when receiving
+  # the downlink. When not set, a random key is used, in which case
+  # downlinks for uplinks forwarded by other LoRa Server instances are
+  # rejected.
+  ul_token_key="{{ .Roaming.API.ULTokenKey }}"
+
+
+  # Roaming partners.
+  #
+  # Example (the [[roaming.servers]] can be repeated):
+  # [[roaming.servers]]
+  # # NetID of the roaming partner.
+  # net_id="010203"
+
+  # # Server.
+  # #
+  # # The URL of the LoRaWAN Backend Interfaces API of the roaming partner.
+  # server="https://example.com:8005/"
+
+  # # Passive-roaming lifetime.
+  # #
+  # # When set to 0, every uplink is forwarded using a PRStartReq (stateless
+  # # passive-roaming). Otherwise, the passive-roaming session is kept for
+  # # this duration (or the shorter lifetime returned by the roaming partner)
+  # # and uplinks are forwarded using a XmitDataReq.
+  # passive_roaming_lifetime="24h"
+
+  # # CA certificate (optional).
+  # #
+  # # Set this to validate the roaming partner server certificate.
+  # ca_cert="/path/to/ca.pem"
+
+  # # TLS client-certificate (optional).
+  # #
+  # # Set this to enable client-certificate authentication with the roaming
+  # # partner.
+  # tls_cert="/path/to/tls_cert.pem"
+
+  # # TLS client-certificate key (optional).
+  # tls_key="/path/to/tls_key.pem"
+  {{ range $index, $element := .Roaming.Servers }}
+  [[roaming.servers]]
+  net_id="{{ $element.NetID }}"
+  server="{{ $element.Server }}"
+  passive_roaming_lifetime="{{ $element.PassiveRoamingLifetime }}"
+  ca_cert="{{ $element.CACert }}"
+  tls_cert="{{ $element.TLSCert }}"
+  tls_key="{{ $element.TLSKey }}"
+  {{ end }}
+
   # Network-controller configuration.
   [network_controller]
   # hostname:port of the network-controller api server (optional)
@@ -57,6 +57,7 @@ func init() {
 
 	viper.SetDefault("postgresql.dsn", "postgres://localhost/loraserver_ns?sslmode=disable")
 	viper.SetDefault("postgresql.automigrate", true)
+	viper.SetDefault("postgresql.profile_cache_ttl", time.Minute)
 
 	viper.SetDefault("network_server.net_id", "000000")
 	viper.SetDefault("network_server.band.name", "EU_863_870")
@@ -69,6 +70,15 @@ func init() {
 
 	viper.SetDefault("network_server.gateway.stats.aggregation_intervals", []string{"minute", "hour", "day"})
 	viper.SetDefault("network_server.gateway.stats.create_gateway_on_stats", true)
+	viper.SetDefault("network_server.gateway.offline_detection.stats_interval", 30*time.Second)
+	viper.SetDefault("network_server.gateway.offline_detection.offline_multiplier", 3)
+	viper.SetDefault("network_server.gateway.offline_detection.check_interval", 30*time.Second)
+	viper.SetDefault("network_server.gateway.offline_detection.debounce_duration", 5*time.Minute)
+	viper.SetDefault("network_server.gateway.auto_create.enabled", false)
+	viper.SetDefault("network_server.gateway.location_update.max_jump_distance", 10000)
+	viper.SetDefault("network_server.gateway.location_update.jump_confirmation_count", 3)
+	viper.SetDefault("network_server.gateway.discovery.interval", 0)
+	viper.SetDefault("network_server.gateway.discovery.retention", 24*time.Hour*7)
 	viper.SetDefault("network_server.gateway.backend.mqtt.server", "tcp://localhost:1883")
 
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
@@ -79,16 +89,45 @@ func init() {
 	viper.SetDefault("network_server.network_settings.rx2_dr", -1)
 	viper.SetDefault("network_server.network_settings.downlink_tx_power", -1)
 	viper.SetDefault("network_server.network_settings.disable_adr", false)
+	viper.SetDefault("network_server.network_settings.max_link_adr_req_rejections", 3)
+	viper.SetDefault("network_server.network_settings.abp_fcnt_reset_max_fcnt", 3)
+	viper.SetDefault("network_server.network_settings.mac_command_ack_uplinks", 3)
+	viper.SetDefault("network_server.network_settings.mac_command_max_retries", 2)
+	viper.SetDefault("network_server.network_settings.gateway_duty_cycle_enabled", true)
 
 	viper.SetDefault("network_server.gateway.backend.type", "mqtt")
 
 	viper.SetDefault("network_server.scheduler.scheduler_interval", 1*time.Second)
 	viper.SetDefault("network_server.scheduler.class_c.downlink_lock_duration", 2*time.Second)
+	viper.SetDefault("network_server.shutdown_timeout", 30*time.Second)
+	viper.SetDefault("network_server.frame_log.async", true)
+	viper.SetDefault("network_server.frame_log.buffer_size", 10000)
+	viper.SetDefault("network_server.uplink_errors.forward_types", []string{"DATA_UP_MIC", "DATA_UP_FCNT_RESET", "DATA_UP_FCNT_RETRANSMISSION", "DATA_UP_SIZE", "LINK_ADR_REQ_REJECTED"})
+	viper.SetDefault("network_server.uplink_errors.rate_limit_interval", time.Minute)
+	viper.SetDefault("network_server.proprietary_uplink.handler", "application_server")
+	viper.SetDefault("network_server.proprietary_uplink.rate_limit", 60)
+	viper.SetDefault("network_server.proprietary_uplink.rate_limit_interval", time.Minute)
+	viper.SetDefault("network_server.proprietary_uplink.mqtt.server", "tcp://localhost:1883")
+	viper.SetDefault("network_server.proprietary_uplink.mqtt.topic", "proprietary/up")
+
+	viper.SetDefault("application_server.keepalive_interval", 5*time.Minute)
+	viper.SetDefault("application_server.keepalive_timeout", 20*time.Second)
+	viper.SetDefault("application_server.reconnect_max_delay", 30*time.Second)
+	viper.SetDefault("application_server.idle_timeout", time.Hour)
+	viper.SetDefault("application_server.retry_count", 3)
+	viper.SetDefault("application_server.retry_backoff", 100*time.Millisecond)
+	viper.SetDefault("application_server.dead_letter_queue.max_length", 10000)
+	viper.SetDefault("application_server.dead_letter_queue.drain_interval", 10*time.Second)
 	viper.SetDefault("network_server.gateway.backend.mqtt.event_topic", "gateway/+/event/+")
 	viper.SetDefault("network_server.gateway.backend.mqtt.command_topic_template", "gateway/{{ .GatewayID }}/command/{{ .CommandType }}")
 	viper.SetDefault("network_server.gateway.backend.mqtt.clean_session", true)
+	viper.SetDefault("geolocation_server.workers", 4)
+	viper.SetDefault("geolocation_server.queue_size", 100)
+	viper.SetDefault("geolocation_server.request_timeout", 10*time.Second)
 	viper.SetDefault("join_server.resolve_domain_suffix", ".joineuis.lora-alliance.org")
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
+	viper.SetDefault("roaming.api.bind", "0.0.0.0:8005")
+	viper.SetDefault("roaming.request_timeout", 5*time.Second)
 
 	viper.SetDefault("network_server.gateway.backend.gcp_pub_sub.uplink_retention_duration", time.Hour*24)
 
@@ -33,8 +33,12 @@ import (
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/downlink"
+	"github.com/brocaar/loraserver/internal/dutycycle"
+	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/geolocation"
 	"github.com/brocaar/loraserver/internal/migrations/code"
+	"github.com/brocaar/loraserver/internal/roaming"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/uplink"
 )
@@ -51,20 +55,28 @@ func run(cmd *cobra.Command, args []string) error {
 		setupMetrics,
 		enableUplinkChannels,
 		setupStorage,
+		setupFrameLog,
 		setGatewayBackend,
 		setupApplicationServer,
 		setupADR,
+		setupDutyCycle,
+		setupGateway,
 		setupGeolocationServer,
+		setupGeolocation,
 		setupJoinServer,
+		setupRoaming,
 		setupNetworkController,
 		setupUplink,
 		setupDownlink,
 		fixV2RedisCache,
 		migrateGatewayStats,
+		rebuildDevAddrIndex,
 		setupAPI,
 		startLoRaServer(server),
 		startStatsServer(gwStats),
 		startQueueScheduler,
+		startGatewayStateChecker,
+		startGatewayDiscovery,
 	}
 
 	for _, t := range tasks {
@@ -79,10 +91,7 @@ func run(cmd *cobra.Command, args []string) error {
 	log.WithField("signal", <-sigChan).Info("signal received")
 	go func() {
 		log.Warning("stopping loraserver")
-		if err := server.Stop(); err != nil {
-			log.Fatal(err)
-		}
-		if err := gwStats.Stop(); err != nil {
+		if err := shutdown(server, gwStats); err != nil {
 			log.Fatal(err)
 		}
 		exitChan <- struct{}{}
@@ -96,6 +105,40 @@ func run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// shutdown stops consuming new gateway messages and API requests, waits
+// (bounded by the shutdown timeout) for the in-flight uplink and downlink
+// flows to complete and then closes the API server and storage.
+func shutdown(server *uplink.Server, gwStats *gateway.StatsHandler) error {
+	timeout := config.C.NetworkServer.ShutdownTimeout
+
+	api.Drain()
+	downlink.StopSchedulers()
+
+	if err := server.Stop(timeout); err != nil {
+		log.WithError(err).Warning("stop uplink server error")
+	}
+	if err := gwStats.Stop(); err != nil {
+		return err
+	}
+	if err := roaming.Stop(timeout); err != nil {
+		return err
+	}
+	if err := geolocation.Stop(timeout); err != nil {
+		return err
+	}
+	if err := api.Stop(timeout); err != nil {
+		return err
+	}
+	if err := framelog.Stop(); err != nil {
+		return err
+	}
+	if err := storage.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func setupBand() error {
 	if err := band.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup band error")
@@ -199,6 +242,13 @@ func setupStorage() error {
 	return nil
 }
 
+func setupFrameLog() error {
+	if err := framelog.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup framelog error")
+	}
+	return nil
+}
+
 func setupADR() error {
 	if err := adr.Setup(config.C); err != nil {
 		errors.Wrap(err, "setup adr error")
@@ -206,6 +256,27 @@ func setupADR() error {
 	return nil
 }
 
+func setupDutyCycle() error {
+	if err := dutycycle.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup duty-cycle error")
+	}
+	return nil
+}
+
+func setupGateway() error {
+	if err := gateway.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup gateway error")
+	}
+	return nil
+}
+
+func setupGeolocation() error {
+	if err := geolocation.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup geolocation error")
+	}
+	return nil
+}
+
 func setGatewayBackend() error {
 	var err error
 	var gw gwbackend.Gateway
@@ -233,7 +304,7 @@ func setGatewayBackend() error {
 }
 
 func setupApplicationServer() error {
-	if err := applicationserver.Setup(); err != nil {
+	if err := applicationserver.Setup(config.C); err != nil {
 		return errors.Wrap(err, "application-server setup error")
 	}
 	return nil
@@ -279,6 +350,13 @@ func setupJoinServer() error {
 	return nil
 }
 
+func setupRoaming() error {
+	if err := roaming.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup roaming error")
+	}
+	return nil
+}
+
 func setupNetworkController() error {
 	// TODO: move this logic to controller.Setup function
 	if config.C.NetworkController.Server != "" {
@@ -351,12 +429,29 @@ func startQueueScheduler() error {
 	log.Info("starting downlink device-queue scheduler")
 	go downlink.DeviceQueueSchedulerLoop()
 
+	log.Info("starting device-queue expiry")
+	go downlink.DeviceQueueExpiryLoop()
+
 	log.Info("starting multicast scheduler")
 	go downlink.MulticastQueueSchedulerLoop()
 
 	return nil
 }
 
+func startGatewayStateChecker() error {
+	log.Info("starting gateway state checker")
+	go gateway.StateCheckerLoop()
+
+	return nil
+}
+
+func startGatewayDiscovery() error {
+	log.Info("starting gateway discovery")
+	go gateway.DiscoveryLoop()
+
+	return nil
+}
+
 func mustGetTransportCredentials(tlsCert, tlsKey, caCert string, verifyClientCert bool) credentials.TransportCredentials {
 	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
 	if err != nil {
@@ -404,3 +499,9 @@ func migrateGatewayStats() error {
 		return code.MigrateGatewayStats(storage.RedisPool(), db)
 	})
 }
+
+func rebuildDevAddrIndex() error {
+	return code.Migrate("rebuild_devaddr_index", func(db sqlx.Ext) error {
+		return code.RebuildDevAddrIndex(storage.RedisPool(), db)
+	})
+}
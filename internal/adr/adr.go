@@ -12,82 +12,82 @@ import (
 	"github.com/brocaar/lorawan"
 )
 
-var pktLossRateTable = [][3]uint8{
-	{1, 1, 2},
-	{1, 2, 3},
-	{2, 3, 3},
-	{3, 3, 3},
-}
-
 // disableADR disables the ADR engine when set to true.
 var disableADR bool
 
 // installationMargin defines the ADR installation-margin.
 var installationMargin float64
 
+// maxLinkADRReqRejections defines the number of consecutive LinkADRReq
+// rejections after which ADR is stopped for a device (0 = never).
+var maxLinkADRReqRejections int
+
 // Setup configures the adr engine.
 func Setup(c config.Config) error {
 	disableADR = c.NetworkServer.NetworkSettings.DisableADR
 	installationMargin = c.NetworkServer.NetworkSettings.InstallationMargin
+	maxLinkADRReqRejections = c.NetworkServer.NetworkSettings.MaxLinkADRReqRejections
 
 	return nil
 }
 
+// GetInstallationMargin returns the installation margin for the given
+// device-session. When the device-session does not override the installation
+// margin, the global installation margin is returned.
+func GetInstallationMargin(ds storage.DeviceSession) float64 {
+	if ds.InstallationMargin != 0 {
+		return ds.InstallationMargin
+	}
+	return installationMargin
+}
+
+// GetMaxLinkADRReqRejections returns the number of consecutive LinkADRReq
+// rejections after which ADR must be stopped for a device. When 0, ADR is
+// never stopped.
+func GetMaxLinkADRReqRejections() int {
+	return maxLinkADRReqRejections
+}
+
 // HandleADR handles ADR in case requested by the node and configured
-// in the device-session.
-func HandleADR(sp storage.ServiceProfile, ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+// in the device-session. The ADR algorithm is selected by the ADR algorithm
+// ID of the device-profile. When not set, the default algorithm is used.
+func HandleADR(sp storage.ServiceProfile, dp storage.DeviceProfile, ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
 
 	// if the node has ADR disabled or it's disabled gloablly
 	if !ds.ADR || disableADR {
 		return nil, nil
 	}
 
-	// get the max SNR from the UplinkHistory
-	var snrM float64 = -999
-	var historyCount int
-	for _, uh := range ds.UplinkHistory {
-		if uh.TXPowerIndex == ds.TXPowerIndex {
-			historyCount++
-
-			if uh.MaxSNR > snrM {
-				snrM = uh.MaxSNR
-			}
-		}
-	}
-
-	dr, err := band.Band().GetDataRate(ds.DR)
-	if err != nil {
-		return nil, errors.Wrap(err, "get data-rate error")
+	// ADR has been stopped after too many rejected LinkADRReqs
+	if ds.ADRStopped {
+		return nil, nil
 	}
 
-	requiredSNR, err := getRequiredSNRForSF(dr.SpreadFactor)
+	handler, err := GetHandler(dp.ADRAlgorithmID)
 	if err != nil {
 		return nil, err
 	}
 
-	snrMargin := snrM - requiredSNR - installationMargin
-	nStep := int(snrMargin / 3)
-
-	// In case of negative steps the ADR algorithm will increase the TXPower
-	// if possible. To avoid up / down / up / down TXPower changes, wait until
-	// we have a full history table before making adjustments.
-	if nStep < 0 && historyCount != storage.UplinkHistorySize {
-		return nil, nil
-	}
-
-	maxSupportedDR := sp.DRMax
-	maxSupportedTXPowerOffsetIndex := getMaxSupportedTXPowerOffsetIndexForDevice(ds)
-
-	var idealTXPowerIndex, idealDR int
-
-	if ds.DR > maxSupportedDR {
-		idealDR = maxSupportedDR
-		idealTXPowerIndex = ds.TXPowerIndex
-	} else {
-		idealTXPowerIndex, idealDR = getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, ds.DR, ds.MinSupportedTXPowerIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+	resp, err := handler.Handle(HandleRequest{
+		DevEUI:                   ds.DevEUI,
+		DR:                       ds.DR,
+		TXPowerIndex:             ds.TXPowerIndex,
+		NbTrans:                  int(ds.NbTrans),
+		MinDR:                    sp.DRMin,
+		MaxDR:                    getMaxSupportedDRForDevice(sp, ds),
+		MinSupportedTXPowerIndex: getMinSupportedTXPowerIndexForDevice(dp, ds),
+		MaxSupportedTXPowerIndex: getMaxSupportedTXPowerOffsetIndexForDevice(ds),
+		InstallationMargin:       GetInstallationMargin(ds),
+		UplinkHistory:            ds.UplinkHistory,
+		PacketLossPercentage:     ds.GetPacketLossPercentage(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "handle adr error")
 	}
 
-	idealNbRep := getNbRep(ds.NbTrans, ds.GetPacketLossPercentage())
+	idealDR := resp.DR
+	idealTXPowerIndex := resp.TXPowerIndex
+	idealNbRep := uint8(resp.NbTrans)
 
 	// there is nothing to adjust
 	if ds.TXPowerIndex == idealTXPowerIndex && ds.DR == idealDR && ds.NbTrans == idealNbRep {
@@ -156,24 +156,6 @@ func HandleADR(sp storage.ServiceProfile, ds storage.DeviceSession, linkADRReqBl
 	return []storage.MACCommandBlock{*linkADRReqBlock}, nil
 }
 
-func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
-	if currentNbRep < 1 {
-		currentNbRep = 1
-	}
-	if currentNbRep > 3 {
-		currentNbRep = 3
-	}
-
-	if pktLossRate < 5 {
-		return pktLossRateTable[0][currentNbRep-1]
-	} else if pktLossRate < 10 {
-		return pktLossRateTable[1][currentNbRep-1]
-	} else if pktLossRate < 30 {
-		return pktLossRateTable[2][currentNbRep-1]
-	}
-	return pktLossRateTable[3][currentNbRep-1]
-}
-
 func getMaxTXPowerOffsetIndex() int {
 	var idx int
 	for i := 0; ; i++ {
@@ -196,48 +178,29 @@ func getMaxSupportedTXPowerOffsetIndexForDevice(ds storage.DeviceSession) int {
 	return getMaxTXPowerOffsetIndex()
 }
 
-func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR int) (int, int) {
-	if nStep == 0 {
-		return txPowerOffsetIndex, dr
+// getMinSupportedTXPowerIndexForDevice returns the min TXPower index that
+// can be used for the device. The EIRP of this index (and the indices after
+// it) does not exceed the max EIRP of the device-profile (when set).
+func getMinSupportedTXPowerIndexForDevice(dp storage.DeviceProfile, ds storage.DeviceSession) int {
+	if dp.MaxEIRP == 0 {
+		return ds.MinSupportedTXPowerIndex
 	}
 
-	if nStep > 0 {
-		if dr < maxSupportedDR {
-			// maxSupportedDR is the max supported DR by the device. Depending the
-			// Regional Parameters specification the node is implementing, this
-			// might not be equal to the getMaxAllowedDR value.
-			dr++
-
-		} else if txPowerOffsetIndex < getMaxTXPowerOffsetIndex() && txPowerOffsetIndex < maxSupportedTXPowerOffsetIndex {
-			// maxSupportedTXPowerOffsetIndex is the max supported TXPower
-			// index by the node. Depending the Regional Parameters
-			// specification the node is implementing, this might not be
-			// equal to the getMaxTXPowerOffsetIndex value.
-			txPowerOffsetIndex++
-
-		}
-
-		nStep--
-		if txPowerOffsetIndex >= getMaxTXPowerOffsetIndex() {
-			return getMaxTXPowerOffsetIndex(), dr
-		}
-
-	} else {
-		if txPowerOffsetIndex > minSupportedTXPowerOffsetIndex {
-			txPowerOffsetIndex--
-			nStep++
-		} else if txPowerOffsetIndex <= minSupportedTXPowerOffsetIndex {
-			return minSupportedTXPowerOffsetIndex, dr
+	for i, eirp := range band.GetUplinkTXPowerEIRPs() {
+		if eirp <= float32(dp.MaxEIRP) {
+			if i > ds.MinSupportedTXPowerIndex {
+				return i
+			}
+			break
 		}
 	}
 
-	return getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+	return ds.MinSupportedTXPowerIndex
 }
 
-func getRequiredSNRForSF(sf int) (float64, error) {
-	snr, ok := config.SpreadFactorToRequiredSNRTable[sf]
-	if !ok {
-		return 0, fmt.Errorf("sf to required snr for does not exsists (sf: %d)", sf)
+func getMaxSupportedDRForDevice(sp storage.ServiceProfile, ds storage.DeviceSession) int {
+	if ds.MaxSupportedDRSet && ds.MaxSupportedDR < sp.DRMax {
+		return ds.MaxSupportedDR
 	}
-	return snr, nil
+	return sp.DRMax
 }
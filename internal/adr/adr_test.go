@@ -58,6 +58,28 @@ func TestADR(t *testing.T) {
 			})
 		})
 
+		Convey("Testing getMaxSupportedDRForDevice", func() {
+			sp := storage.ServiceProfile{
+				DRMax: 5,
+			}
+
+			Convey("When no MaxSupportedDR is set on the device session, it returns the service-profile max DR", func() {
+				So(getMaxSupportedDRForDevice(sp, storage.DeviceSession{}), ShouldEqual, 5)
+			})
+
+			Convey("When MaxSupportedDR is set on the device session, this value is returned", func() {
+				So(getMaxSupportedDRForDevice(sp, storage.DeviceSession{MaxSupportedDR: 3, MaxSupportedDRSet: true}), ShouldEqual, 3)
+			})
+
+			Convey("When MaxSupportedDR is set to DR0 on the device session, this value is returned", func() {
+				So(getMaxSupportedDRForDevice(sp, storage.DeviceSession{MaxSupportedDR: 0, MaxSupportedDRSet: true}), ShouldEqual, 0)
+			})
+
+			Convey("When MaxSupportedDR exceeds the service-profile max DR, the service-profile max DR is returned", func() {
+				So(getMaxSupportedDRForDevice(sp, storage.DeviceSession{MaxSupportedDR: 7, MaxSupportedDRSet: true}), ShouldEqual, 5)
+			})
+		})
+
 		Convey("Given a testtable for getIdealTXPowerAndDR", func() {
 			testTable := []struct {
 				Name                     string
@@ -299,6 +321,43 @@ func TestADR(t *testing.T) {
 						},
 						ExpectedError: nil,
 					},
+					{
+						Name: "ADR increasing data-rate as a higher minimum value has been specified in the service-profile",
+						ServiceProfile: storage.ServiceProfile{
+							DRMin: 3,
+							DRMax: 5,
+						},
+						DeviceSession: storage.DeviceSession{
+							DevAddr:               [4]byte{1, 2, 3, 4},
+							DevEUI:                [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+							EnabledUplinkChannels: []int{0, 1, 2},
+							DR:                    1,
+							ADR:                   true,
+							UplinkHistory: []storage.UplinkHistory{
+								{MaxSNR: -12},
+							},
+						},
+						Expected: []storage.MACCommandBlock{
+							{
+								CID: lorawan.LinkADRReq,
+								MACCommands: []lorawan.MACCommand{
+									{
+										CID: lorawan.LinkADRReq,
+										Payload: &lorawan.LinkADRReqPayload{
+											DataRate: 3,
+											TXPower:  0,
+											ChMask:   lorawan.ChMask{true, true, true},
+											Redundancy: lorawan.Redundancy{
+												ChMaskCntl: 0,
+												NbRep:      1,
+											},
+										},
+									},
+								},
+							},
+						},
+						ExpectedError: nil,
+					},
 					{
 						Name: "ADR increasing tx-power by one step (no CFlist)",
 						ServiceProfile: storage.ServiceProfile{
@@ -603,7 +662,7 @@ func TestADR(t *testing.T) {
 
 				for i, tst := range testTable {
 					Convey(fmt.Sprintf("Test: %s [%d]", tst.Name, i), func() {
-						blocks, err := HandleADR(tst.ServiceProfile, tst.DeviceSession, tst.LinkADRReqBlock)
+						blocks, err := HandleADR(tst.ServiceProfile, storage.DeviceProfile{}, tst.DeviceSession, tst.LinkADRReqBlock)
 						if tst.ExpectedError != nil {
 							So(err, ShouldNotBeNil)
 							So(err, ShouldResemble, tst.ExpectedError)
@@ -650,7 +709,7 @@ func TestADR(t *testing.T) {
 					},
 				}
 
-				blocks, err := HandleADR(sp, ds, larb)
+				blocks, err := HandleADR(sp, storage.DeviceProfile{}, ds, larb)
 
 				So(err, ShouldBeNil)
 				So(blocks, ShouldBeNil)
@@ -658,3 +717,27 @@ func TestADR(t *testing.T) {
 		})
 	})
 }
+
+func TestGetInstallationMargin(t *testing.T) {
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.InstallationMargin = 10
+	if err := Setup(conf); err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a device-session without installation margin", t, func() {
+		ds := storage.DeviceSession{}
+
+		Convey("Then the global installation margin is returned", func() {
+			So(GetInstallationMargin(ds), ShouldEqual, 10)
+		})
+
+		Convey("When the installation margin is set on the device-session", func() {
+			ds.InstallationMargin = 2.5
+
+			Convey("Then the device-session installation margin is returned", func() {
+				So(GetInstallationMargin(ds), ShouldEqual, 2.5)
+			})
+		})
+	})
+}
@@ -0,0 +1,154 @@
+package adr
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// DefaultHandlerID contains the ID of the default ADR algorithm.
+const DefaultHandlerID = "default"
+
+var pktLossRateTable = [][3]uint8{
+	{1, 1, 2},
+	{1, 2, 3},
+	{2, 3, 3},
+	{3, 3, 3},
+}
+
+// DefaultHandler implements the default ADR algorithm, based on the max
+// SNR of the uplink history and the packet-loss.
+type DefaultHandler struct{}
+
+// ID returns the ID of the algorithm.
+func (h *DefaultHandler) ID() string {
+	return DefaultHandlerID
+}
+
+// Name returns the name of the algorithm.
+func (h *DefaultHandler) Name() string {
+	return "Default ADR algorithm"
+}
+
+// Handle handles the ADR request.
+func (h *DefaultHandler) Handle(req HandleRequest) (HandleResponse, error) {
+	resp := HandleResponse{
+		DR:           req.DR,
+		TXPowerIndex: req.TXPowerIndex,
+		NbTrans:      req.NbTrans,
+	}
+
+	// get the max SNR from the UplinkHistory
+	var snrM float64 = -999
+	var historyCount int
+	for _, uh := range req.UplinkHistory {
+		if uh.TXPowerIndex == req.TXPowerIndex {
+			historyCount++
+
+			if uh.MaxSNR > snrM {
+				snrM = uh.MaxSNR
+			}
+		}
+	}
+
+	dr, err := band.Band().GetDataRate(req.DR)
+	if err != nil {
+		return resp, errors.Wrap(err, "get data-rate error")
+	}
+
+	requiredSNR, err := getRequiredSNRForSF(dr.SpreadFactor)
+	if err != nil {
+		return resp, err
+	}
+
+	snrMargin := snrM - requiredSNR - req.InstallationMargin
+	nStep := int(snrMargin / 3)
+
+	// In case of negative steps the ADR algorithm will increase the TXPower
+	// if possible. To avoid up / down / up / down TXPower changes, wait until
+	// we have a full history table before making adjustments.
+	if nStep < 0 && historyCount != storage.UplinkHistorySize {
+		return resp, nil
+	}
+
+	if req.DR > req.MaxDR {
+		resp.DR = req.MaxDR
+	} else if req.DR < req.MinDR && req.MinDR <= req.MaxDR {
+		// steer the device back within the service-profile data-rate range
+		resp.DR = req.MinDR
+	} else {
+		resp.TXPowerIndex, resp.DR = getIdealTXPowerOffsetAndDR(nStep, req.TXPowerIndex, req.DR, req.MinSupportedTXPowerIndex, req.MaxSupportedTXPowerIndex, req.MaxDR)
+	}
+
+	resp.NbTrans = int(getNbRep(uint8(req.NbTrans), req.PacketLossPercentage))
+
+	return resp, nil
+}
+
+func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
+	if currentNbRep < 1 {
+		currentNbRep = 1
+	}
+	if currentNbRep > 3 {
+		currentNbRep = 3
+	}
+
+	if pktLossRate < 5 {
+		return pktLossRateTable[0][currentNbRep-1]
+	} else if pktLossRate < 10 {
+		return pktLossRateTable[1][currentNbRep-1]
+	} else if pktLossRate < 30 {
+		return pktLossRateTable[2][currentNbRep-1]
+	}
+	return pktLossRateTable[3][currentNbRep-1]
+}
+
+func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR int) (int, int) {
+	if nStep == 0 {
+		return txPowerOffsetIndex, dr
+	}
+
+	if nStep > 0 {
+		if dr < maxSupportedDR {
+			// maxSupportedDR is the max supported DR by the device. Depending the
+			// Regional Parameters specification the node is implementing, this
+			// might not be equal to the getMaxAllowedDR value.
+			dr++
+
+		} else if txPowerOffsetIndex < getMaxTXPowerOffsetIndex() && txPowerOffsetIndex < maxSupportedTXPowerOffsetIndex {
+			// maxSupportedTXPowerOffsetIndex is the max supported TXPower
+			// index by the node. Depending the Regional Parameters
+			// specification the node is implementing, this might not be
+			// equal to the getMaxTXPowerOffsetIndex value.
+			txPowerOffsetIndex++
+
+		}
+
+		nStep--
+		if txPowerOffsetIndex >= getMaxTXPowerOffsetIndex() {
+			return getMaxTXPowerOffsetIndex(), dr
+		}
+
+	} else {
+		if txPowerOffsetIndex > minSupportedTXPowerOffsetIndex {
+			txPowerOffsetIndex--
+			nStep++
+		} else if txPowerOffsetIndex <= minSupportedTXPowerOffsetIndex {
+			return minSupportedTXPowerOffsetIndex, dr
+		}
+	}
+
+	return getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+}
+
+func getRequiredSNRForSF(sf int) (float64, error) {
+	snr, ok := config.SpreadFactorToRequiredSNRTable[sf]
+	if !ok {
+		return 0, fmt.Errorf("sf to required snr for does not exsists (sf: %d)", sf)
+	}
+	return snr, nil
+}
@@ -0,0 +1,8 @@
+package adr
+
+import "github.com/pkg/errors"
+
+// Errors
+var (
+	ErrUnknownAlgorithm = errors.New("unknown adr algorithm")
+)
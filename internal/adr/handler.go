@@ -0,0 +1,96 @@
+package adr
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// Handler defines the interface of an ADR algorithm.
+type Handler interface {
+	// ID returns the unique identifier of the ADR algorithm. This is the
+	// value that must be set as ADR algorithm ID in the device-profile.
+	ID() string
+
+	// Name returns the human-readable name of the ADR algorithm.
+	Name() string
+
+	// Handle returns the data-rate, TX power index and number of
+	// transmissions that must be requested from the device.
+	Handle(HandleRequest) (HandleResponse, error)
+}
+
+// HandleRequest contains the ADR input parameters.
+type HandleRequest struct {
+	// DevEUI of the device.
+	DevEUI lorawan.EUI64
+
+	// DR, TXPowerIndex and NbTrans currently used by the device.
+	DR           int
+	TXPowerIndex int
+	NbTrans      int
+
+	// Data-rate range configured in the service-profile.
+	MinDR int
+	MaxDR int
+
+	// TX power index range supported by the device.
+	MinSupportedTXPowerIndex int
+	MaxSupportedTXPowerIndex int
+
+	// InstallationMargin (dB).
+	InstallationMargin float64
+
+	// UplinkHistory contains the meta-data of the last uplinks.
+	UplinkHistory []storage.UplinkHistory
+
+	// PacketLossPercentage based on the uplink history.
+	PacketLossPercentage float64
+}
+
+// HandleResponse contains the ADR output parameters.
+type HandleResponse struct {
+	DR           int
+	TXPowerIndex int
+	NbTrans      int
+}
+
+var (
+	handlersMux sync.RWMutex
+	handlers    = make(map[string]Handler)
+)
+
+func init() {
+	Register(&DefaultHandler{})
+	Register(&StaticHandler{})
+}
+
+// Register registers the given ADR algorithm. In case an algorithm with the
+// same ID has already been registered, it will be replaced.
+func Register(h Handler) {
+	handlersMux.Lock()
+	defer handlersMux.Unlock()
+
+	handlers[h.ID()] = h
+}
+
+// GetHandler returns the ADR algorithm for the given ID. When the ID is
+// empty, the default algorithm is returned.
+func GetHandler(id string) (Handler, error) {
+	if id == "" {
+		id = DefaultHandlerID
+	}
+
+	handlersMux.RLock()
+	defer handlersMux.RUnlock()
+
+	h, ok := handlers[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownAlgorithm, "id: %s", id)
+	}
+
+	return h, nil
+}
@@ -0,0 +1,45 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHandler(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert := require.New(t)
+
+		h, err := GetHandler("")
+		assert.NoError(err)
+		assert.Equal(DefaultHandlerID, h.ID())
+	})
+
+	t.Run("Static", func(t *testing.T) {
+		assert := require.New(t)
+
+		h, err := GetHandler(StaticHandlerID)
+		assert.NoError(err)
+		assert.Equal(StaticHandlerID, h.ID())
+
+		resp, err := h.Handle(HandleRequest{
+			DR:           2,
+			TXPowerIndex: 1,
+			NbTrans:      3,
+		})
+		assert.NoError(err)
+		assert.Equal(HandleResponse{
+			DR:           2,
+			TXPowerIndex: 1,
+			NbTrans:      3,
+		}, resp)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetHandler("unknown")
+		assert.Equal(ErrUnknownAlgorithm, errors.Cause(err))
+	})
+}
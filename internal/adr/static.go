@@ -0,0 +1,28 @@
+package adr
+
+// StaticHandlerID contains the ID of the static ADR algorithm.
+const StaticHandlerID = "static"
+
+// StaticHandler implements an ADR algorithm which never requests any
+// changes. This can be used to pin the data-rate, TX power and number of
+// transmissions of specific devices.
+type StaticHandler struct{}
+
+// ID returns the ID of the algorithm.
+func (h *StaticHandler) ID() string {
+	return StaticHandlerID
+}
+
+// Name returns the name of the algorithm.
+func (h *StaticHandler) Name() string {
+	return "Static (no ADR adjustments)"
+}
+
+// Handle returns the current device parameters.
+func (h *StaticHandler) Handle(req HandleRequest) (HandleResponse, error) {
+	return HandleResponse{
+		DR:           req.DR,
+		TXPowerIndex: req.TXPowerIndex,
+		NbTrans:      req.NbTrans,
+	}, nil
+}
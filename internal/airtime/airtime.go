@@ -0,0 +1,159 @@
+// Package airtime implements the calculation of the time on air of LoRa
+// and FSK modulated uplink and downlink frames.
+package airtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
+)
+
+// defaultPreambleNumber defines the number of preamble symbols used by LoRaWAN.
+const defaultPreambleNumber = 8
+
+// dataFrameOverhead defines the size of a LoRaWAN data frame without the
+// FOpts and FRMPayload fields (MHDR, DevAddr, FCtrl, FCnt, FPort and MIC).
+const dataFrameOverhead = 1 + 4 + 1 + 2 + 1 + 4
+
+// CalculateLoRa returns the airtime of a LoRa modulated frame, using the
+// formula as defined by the Semtech SX1276 datasheet. The bandwidth is in
+// kHz and the coding-rate is in the "4/5" notation. The low data-rate
+// optimization is enabled when the symbol duration exceeds 16ms.
+func CalculateLoRa(payloadSize, sf, bandwidth, preambleNumber int, codingRate string, headerEnabled, crcEnabled bool) (time.Duration, error) {
+	if sf < 6 || sf > 12 {
+		return 0, fmt.Errorf("invalid spreading-factor: %d", sf)
+	}
+
+	if bandwidth <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth: %d", bandwidth)
+	}
+
+	cr, err := getCodingRate(codingRate)
+	if err != nil {
+		return 0, err
+	}
+
+	symbolDuration := time.Duration(1<<uint(sf)) * time.Second / time.Duration(bandwidth*1000)
+
+	var de, h, crc int
+	if symbolDuration > 16*time.Millisecond {
+		de = 1
+	}
+	if !headerEnabled {
+		h = 1
+	}
+	if crcEnabled {
+		crc = 1
+	}
+
+	// ceil(a / b) * (cr + 4), with a minimum of 0
+	a := 8*payloadSize - 4*sf + 28 + 16*crc - 20*h
+	b := 4 * (sf - 2*de)
+	var payloadSymbols int
+	if a > 0 {
+		payloadSymbols = ((a + b - 1) / b) * (cr + 4)
+	}
+
+	// the preamble has 4.25 symbols on top of the programmed preamble length
+	preambleDuration := time.Duration(4*preambleNumber+17) * symbolDuration / 4
+
+	return preambleDuration + time.Duration(8+payloadSymbols)*symbolDuration, nil
+}
+
+// CalculateFSK returns the airtime of a FSK modulated frame for the given
+// bitrate (bits per second). This includes the preamble (5 bytes), the
+// sync-word (3 bytes), the length byte and the CRC (2 bytes).
+func CalculateFSK(payloadSize, bitrate int) (time.Duration, error) {
+	if bitrate <= 0 {
+		return 0, fmt.Errorf("invalid bitrate: %d", bitrate)
+	}
+
+	bits := (5 + 3 + 1 + payloadSize + 2) * 8
+	return time.Duration(bits) * time.Second / time.Duration(bitrate), nil
+}
+
+// GetUplinkAirtime returns the airtime of an uplink frame with the given
+// PHYPayload size. LoRaWAN uplinks use the explicit header and CRC.
+func GetUplinkAirtime(phyPayloadSize int, txInfo *gw.UplinkTXInfo) (time.Duration, error) {
+	if txInfo == nil {
+		return 0, errors.New("tx_info must not be nil")
+	}
+
+	switch txInfo.Modulation {
+	case common.Modulation_LORA:
+		modInfo := txInfo.GetLoraModulationInfo()
+		if modInfo == nil {
+			return 0, errors.New("lora_modulation_info must not be nil")
+		}
+
+		return CalculateLoRa(phyPayloadSize, int(modInfo.SpreadingFactor), int(modInfo.Bandwidth), defaultPreambleNumber, modInfo.CodeRate, true, true)
+	case common.Modulation_FSK:
+		modInfo := txInfo.GetFskModulationInfo()
+		if modInfo == nil {
+			return 0, errors.New("fsk_modulation_info must not be nil")
+		}
+
+		return CalculateFSK(phyPayloadSize, int(modInfo.Bitrate))
+	default:
+		return 0, fmt.Errorf("modulation %s is not supported", txInfo.Modulation)
+	}
+}
+
+// GetDownlinkAirtime returns the airtime of the given downlink frame.
+func GetDownlinkAirtime(frame gw.DownlinkFrame) (time.Duration, error) {
+	return getDownlinkAirtime(len(frame.PhyPayload), frame.TxInfo)
+}
+
+// GetDownlinkDataAirtime returns the airtime of a downlink data frame
+// carrying a FRMPayload of the given size. As the mac-commands are not
+// known in advance, the FOpts field is not taken into account.
+func GetDownlinkDataAirtime(frmPayloadSize int, txInfo *gw.DownlinkTXInfo) (time.Duration, error) {
+	return getDownlinkAirtime(dataFrameOverhead+frmPayloadSize, txInfo)
+}
+
+// getDownlinkAirtime returns the airtime of a downlink frame with the given
+// PHYPayload size. LoRaWAN downlinks use the explicit header, but no
+// payload CRC.
+func getDownlinkAirtime(phyPayloadSize int, txInfo *gw.DownlinkTXInfo) (time.Duration, error) {
+	if txInfo == nil {
+		return 0, errors.New("tx_info must not be nil")
+	}
+
+	switch txInfo.Modulation {
+	case common.Modulation_LORA:
+		modInfo := txInfo.GetLoraModulationInfo()
+		if modInfo == nil {
+			return 0, errors.New("lora_modulation_info must not be nil")
+		}
+
+		return CalculateLoRa(phyPayloadSize, int(modInfo.SpreadingFactor), int(modInfo.Bandwidth), defaultPreambleNumber, modInfo.CodeRate, true, false)
+	case common.Modulation_FSK:
+		modInfo := txInfo.GetFskModulationInfo()
+		if modInfo == nil {
+			return 0, errors.New("fsk_modulation_info must not be nil")
+		}
+
+		return CalculateFSK(phyPayloadSize, int(modInfo.Bitrate))
+	default:
+		return 0, fmt.Errorf("modulation %s is not supported", txInfo.Modulation)
+	}
+}
+
+func getCodingRate(cr string) (int, error) {
+	switch cr {
+	case "4/5", "":
+		return 1, nil
+	case "4/6":
+		return 2, nil
+	case "4/7":
+		return 3, nil
+	case "4/8":
+		return 4, nil
+	default:
+		return 0, fmt.Errorf("invalid code-rate: %s", cr)
+	}
+}
@@ -0,0 +1,259 @@
+package airtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
+)
+
+func TestCalculateLoRa(t *testing.T) {
+	tests := []struct {
+		Name            string
+		PayloadSize     int
+		SF              int
+		Bandwidth       int
+		CodingRate      string
+		HeaderEnabled   bool
+		CRCEnabled      bool
+		ExpectedAirtime time.Duration
+		ExpectedError   bool
+	}{
+		{
+			Name:            "51 byte FRMPayload (64 byte PHYPayload) SF7BW125",
+			PayloadSize:     64,
+			SF:              7,
+			Bandwidth:       125,
+			CodingRate:      "4/5",
+			HeaderEnabled:   true,
+			CRCEnabled:      true,
+			ExpectedAirtime: 118016 * time.Microsecond,
+		},
+		{
+			Name:            "13 bytes SF7BW125",
+			PayloadSize:     13,
+			SF:              7,
+			Bandwidth:       125,
+			CodingRate:      "4/5",
+			HeaderEnabled:   true,
+			CRCEnabled:      true,
+			ExpectedAirtime: 46336 * time.Microsecond,
+		},
+		{
+			Name:            "13 bytes SF7BW125 without crc",
+			PayloadSize:     13,
+			SF:              7,
+			Bandwidth:       125,
+			CodingRate:      "4/5",
+			HeaderEnabled:   true,
+			CRCEnabled:      false,
+			ExpectedAirtime: 41216 * time.Microsecond,
+		},
+		{
+			Name:            "13 bytes SF12BW125 (low data-rate optimization)",
+			PayloadSize:     13,
+			SF:              12,
+			Bandwidth:       125,
+			CodingRate:      "4/5",
+			HeaderEnabled:   true,
+			CRCEnabled:      true,
+			ExpectedAirtime: 1155072 * time.Microsecond,
+		},
+		{
+			Name:            "13 bytes SF7BW250 implicit header",
+			PayloadSize:     13,
+			SF:              7,
+			Bandwidth:       250,
+			CodingRate:      "4/5",
+			HeaderEnabled:   false,
+			CRCEnabled:      true,
+			ExpectedAirtime: 20608 * time.Microsecond,
+		},
+		{
+			Name:            "13 bytes SF9BW125 4/8",
+			PayloadSize:     13,
+			SF:              9,
+			Bandwidth:       125,
+			CodingRate:      "4/8",
+			HeaderEnabled:   true,
+			CRCEnabled:      true,
+			ExpectedAirtime: 214016 * time.Microsecond,
+		},
+		{
+			Name:          "invalid code-rate",
+			PayloadSize:   13,
+			SF:            7,
+			Bandwidth:     125,
+			CodingRate:    "4/9",
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid spreading-factor",
+			PayloadSize:   13,
+			SF:            13,
+			Bandwidth:     125,
+			CodingRate:    "4/5",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			d, err := CalculateLoRa(tst.PayloadSize, tst.SF, tst.Bandwidth, 8, tst.CodingRate, tst.HeaderEnabled, tst.CRCEnabled)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedAirtime, d)
+		})
+	}
+}
+
+func TestCalculateFSK(t *testing.T) {
+	assert := require.New(t)
+
+	d, err := CalculateFSK(13, 50000)
+	assert.NoError(err)
+	assert.Equal(3840*time.Microsecond, d)
+
+	_, err = CalculateFSK(13, 0)
+	assert.Error(err)
+}
+
+func TestGetUplinkAirtime(t *testing.T) {
+	assert := require.New(t)
+
+	d, err := GetUplinkAirtime(64, &gw.UplinkTXInfo{
+		Modulation: common.Modulation_LORA,
+		ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+			LoraModulationInfo: &gw.LoRaModulationInfo{
+				SpreadingFactor: 7,
+				Bandwidth:       125,
+				CodeRate:        "4/5",
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(118016*time.Microsecond, d)
+
+	d, err = GetUplinkAirtime(13, &gw.UplinkTXInfo{
+		Modulation: common.Modulation_FSK,
+		ModulationInfo: &gw.UplinkTXInfo_FskModulationInfo{
+			FskModulationInfo: &gw.FSKModulationInfo{
+				Bitrate: 50000,
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(3840*time.Microsecond, d)
+
+	_, err = GetUplinkAirtime(13, nil)
+	assert.Error(err)
+}
+
+func TestGetDownlinkAirtime(t *testing.T) {
+	tests := []struct {
+		Name            string
+		Frame           gw.DownlinkFrame
+		ExpectedAirtime time.Duration
+		ExpectedError   bool
+	}{
+		{
+			Name: "LoRa SF7",
+			Frame: gw.DownlinkFrame{
+				PhyPayload: make([]byte, 13),
+				TxInfo: &gw.DownlinkTXInfo{
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							SpreadingFactor: 7,
+							Bandwidth:       125,
+							CodeRate:        "4/5",
+						},
+					},
+				},
+			},
+			ExpectedAirtime: 41216 * time.Microsecond,
+		},
+		{
+			Name: "FSK",
+			Frame: gw.DownlinkFrame{
+				PhyPayload: make([]byte, 13),
+				TxInfo: &gw.DownlinkTXInfo{
+					Modulation: common.Modulation_FSK,
+					ModulationInfo: &gw.DownlinkTXInfo_FskModulationInfo{
+						FskModulationInfo: &gw.FSKModulationInfo{
+							Bitrate: 50000,
+						},
+					},
+				},
+			},
+			ExpectedAirtime: 3840 * time.Microsecond,
+		},
+		{
+			Name: "Invalid code-rate",
+			Frame: gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							SpreadingFactor: 7,
+							Bandwidth:       125,
+							CodeRate:        "4/9",
+						},
+					},
+				},
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			d, err := GetDownlinkAirtime(tst.Frame)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedAirtime, d)
+		})
+	}
+}
+
+func TestGetDownlinkDataAirtime(t *testing.T) {
+	assert := require.New(t)
+
+	txInfo := gw.DownlinkTXInfo{
+		Modulation: common.Modulation_LORA,
+		ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+			LoraModulationInfo: &gw.LoRaModulationInfo{
+				SpreadingFactor: 7,
+				Bandwidth:       125,
+				CodeRate:        "4/5",
+			},
+		},
+	}
+
+	// an empty FRMPayload equals the 13 bytes PHYPayload
+	d, err := GetDownlinkDataAirtime(0, &txInfo)
+	assert.NoError(err)
+	assert.Equal(41216*time.Microsecond, d)
+
+	d, err = GetDownlinkDataAirtime(10, &txInfo)
+	assert.NoError(err)
+	expected, err := GetDownlinkAirtime(gw.DownlinkFrame{PhyPayload: make([]byte, 23), TxInfo: &txInfo})
+	assert.NoError(err)
+	assert.Equal(expected, d)
+
+	_, err = GetDownlinkDataAirtime(10, nil)
+	assert.Error(err)
+}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
 	"net"
+	"sync/atomic"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
@@ -10,12 +13,23 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/tls"
 )
 
+// server holds the gRPC server, it is set by Setup.
+var server *grpc.Server
+
+// draining is set to 1 when the server is shutting down.
+var draining int32
+
+// Setup configures the API package and starts the network-server API
+// server.
 func Setup(c config.Config) error {
 	apiConfig := c.NetworkServer.API
 
@@ -26,14 +40,21 @@ func Setup(c config.Config) error {
 		"tls-key":  apiConfig.TLSKey,
 	}).Info("api: starting network-server api server")
 
-	opts := serverOptions()
+	var auth *clientCertAuth
+	var creds credentials.TransportCredentials
 
 	if apiConfig.CACert != "" || apiConfig.TLSCert != "" || apiConfig.TLSKey != "" {
-		creds, err := tls.GetTransportCredentials(apiConfig.CACert, apiConfig.TLSCert, apiConfig.TLSKey, true)
+		var err error
+		creds, err = tls.GetServerTransportCredentials(apiConfig.CACert, apiConfig.TLSCert, apiConfig.TLSKey, !apiConfig.HealthCheckWithoutClientCert)
 		if err != nil {
 			return errors.Wrap(err, "get transport credentials error")
 		}
 
+		auth = newClientCertAuth(apiConfig.AllowedClientCNs, apiConfig.HealthCheckWithoutClientCert)
+	}
+
+	opts := serverOptions(auth)
+	if creds != nil {
 		opts = append(opts, grpc.Creds(creds))
 	}
 
@@ -41,31 +62,96 @@ func Setup(c config.Config) error {
 	nsAPI := NewNetworkServerAPI()
 	ns.RegisterNetworkServerServiceServer(gs, nsAPI)
 
+	healthpb.RegisterHealthServer(gs, newHealthServer())
+
+	// initialize the per-method metrics, so that all methods are exported
+	grpc_prometheus.Register(gs)
+
 	ln, err := net.Listen("tcp", apiConfig.Bind)
 	if err != nil {
 		return errors.Wrap(err, "start api listener error")
 	}
 	go gs.Serve(ln)
 
+	server = gs
+	atomic.StoreInt32(&draining, 0)
+
+	return nil
+}
+
+// Drain makes the API reject new requests with codes.Unavailable, so that
+// clients retry using an other instance. Requests in progress are not
+// affected.
+func Drain() {
+	atomic.StoreInt32(&draining, 1)
+}
+
+// Stop stops the API server after all requests in progress have completed.
+// Requests (e.g. frame-log streams) that are still in progress after the
+// given timeout are canceled.
+func Stop(timeout time.Duration) error {
+	if server == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Warning("api: graceful stop timed out, canceling remaining requests")
+		server.Stop()
+	}
+
 	return nil
 }
 
-func serverOptions() []grpc.ServerOption {
+func drainUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if atomic.LoadInt32(&draining) == 1 {
+		return nil, grpc.Errorf(codes.Unavailable, "server is shutting down")
+	}
+	return handler(ctx, req)
+}
+
+func drainStreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if atomic.LoadInt32(&draining) == 1 {
+		return grpc.Errorf(codes.Unavailable, "server is shutting down")
+	}
+	return handler(srv, ss)
+}
+
+// serverOptions returns the gRPC server options. When auth is not nil,
+// the client-certificate authorization is added to the interceptor chains.
+func serverOptions(auth *clientCertAuth) []grpc.ServerOption {
 	logrusEntry := log.NewEntry(log.StandardLogger())
 	logrusOpts := []grpc_logrus.Option{
 		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
 	}
 
+	unary := []grpc.UnaryServerInterceptor{
+		drainUnaryServerInterceptor,
+		grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+		grpc_logrus.UnaryServerInterceptor(logrusEntry, logrusOpts...),
+		grpc_prometheus.UnaryServerInterceptor,
+	}
+	stream := []grpc.StreamServerInterceptor{
+		drainStreamServerInterceptor,
+		grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+		grpc_logrus.StreamServerInterceptor(logrusEntry, logrusOpts...),
+		grpc_prometheus.StreamServerInterceptor,
+	}
+
+	if auth != nil {
+		unary = append(unary, auth.unaryServerInterceptor)
+		stream = append(stream, auth.streamServerInterceptor)
+	}
+
 	return []grpc.ServerOption{
-		grpc_middleware.WithUnaryServerChain(
-			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-			grpc_logrus.UnaryServerInterceptor(logrusEntry, logrusOpts...),
-			grpc_prometheus.UnaryServerInterceptor,
-		),
-		grpc_middleware.WithStreamServerChain(
-			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-			grpc_logrus.StreamServerInterceptor(logrusEntry, logrusOpts...),
-			grpc_prometheus.StreamServerInterceptor,
-		),
+		grpc_middleware.WithUnaryServerChain(unary...),
+		grpc_middleware.WithStreamServerChain(stream...),
 	}
 }
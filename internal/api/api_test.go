@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestDrainInterceptors(t *testing.T) {
+	defer atomic.StoreInt32(&draining, 0)
+
+	unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	streamHandler := func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	}
+
+	t.Run("not draining", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := drainUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, unaryHandler)
+		assert.NoError(err)
+		assert.Equal("ok", resp)
+
+		assert.NoError(drainStreamServerInterceptor(nil, nil, &grpc.StreamServerInfo{}, streamHandler))
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		assert := require.New(t)
+		Drain()
+
+		_, err := drainUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, unaryHandler)
+		assert.Equal(codes.Unavailable, grpc.Code(err))
+
+		err = drainStreamServerInterceptor(nil, nil, &grpc.StreamServerInfo{}, streamHandler)
+		assert.Equal(codes.Unavailable, grpc.Code(err))
+	})
+}
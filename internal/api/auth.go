@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// healthCheckMethodPrefix is the method prefix of the gRPC health-check service.
+const healthCheckMethodPrefix = "/grpc.health.v1.Health/"
+
+// clientCertAuth implements the client-certificate based authorization
+// of the network-server API.
+type clientCertAuth struct {
+	allowedCNs        map[string]struct{}
+	allowHealthNoCert bool
+}
+
+// newClientCertAuth creates a new clientCertAuth. When allowedCNs is empty,
+// any client-certificate signed by the configured CA is accepted.
+func newClientCertAuth(allowedCNs []string, allowHealthNoCert bool) *clientCertAuth {
+	a := clientCertAuth{
+		allowedCNs:        make(map[string]struct{}),
+		allowHealthNoCert: allowHealthNoCert,
+	}
+
+	for _, cn := range allowedCNs {
+		a.allowedCNs[cn] = struct{}{}
+	}
+
+	return &a
+}
+
+// validate validates the client-certificate for the given method.
+func (a *clientCertAuth) validate(ctx context.Context, fullMethod string) error {
+	if a.allowHealthNoCert && strings.HasPrefix(fullMethod, healthCheckMethodPrefix) {
+		return nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return grpc.Errorf(codes.Unauthenticated, "no peer information")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return grpc.Errorf(codes.Unauthenticated, "client-certificate required")
+	}
+
+	// VerifiedChains is only set when the certificate was signed by the CA.
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return grpc.Errorf(codes.Unauthenticated, "client-certificate required")
+	}
+
+	if len(a.allowedCNs) == 0 {
+		return nil
+	}
+
+	cn := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	if _, ok := a.allowedCNs[cn]; !ok {
+		return grpc.Errorf(codes.PermissionDenied, "client-certificate common name %s is not allowed", cn)
+	}
+
+	return nil
+}
+
+func (a *clientCertAuth) unaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.validate(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *clientCertAuth) streamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.validate(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
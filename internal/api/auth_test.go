@@ -0,0 +1,290 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/test"
+	nstls "github.com/brocaar/loraserver/internal/tls"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func (c testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{c.cert.Raw},
+		PrivateKey:  c.key,
+	}
+}
+
+var testCertSerial int64
+
+// newTestCertificate creates a certificate signed by the given parent. When
+// parent is nil, a self-signed CA certificate is returned.
+func newTestCertificate(cn string, parent *testCertificate) (testCertificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return testCertificate{}, err
+	}
+
+	testCertSerial++
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(testCertSerial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	parentCert := &tmpl
+	parentKey := key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		parentCert = parent.cert
+		parentKey = parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return testCertificate{}, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return testCertificate{}, err
+	}
+
+	return testCertificate{cert: cert, key: key}, nil
+}
+
+type ClientCertAuthTestSuite struct {
+	suite.Suite
+
+	tempDir string
+	ca      testCertificate
+	server  testCertificate
+	allowed testCertificate
+	other   testCertificate
+	foreign testCertificate
+}
+
+func (ts *ClientCertAuthTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	assert.NoError(band.Setup(test.GetConfig()))
+
+	var err error
+	ts.tempDir, err = ioutil.TempDir("", "auth-test")
+	assert.NoError(err)
+
+	ts.ca, err = newTestCertificate("test-ca", nil)
+	assert.NoError(err)
+	ts.server, err = newTestCertificate("localhost", &ts.ca)
+	assert.NoError(err)
+	ts.allowed, err = newTestCertificate("lora-app-server", &ts.ca)
+	assert.NoError(err)
+	ts.other, err = newTestCertificate("other-client", &ts.ca)
+	assert.NoError(err)
+
+	foreignCA, err := newTestCertificate("foreign-ca", nil)
+	assert.NoError(err)
+	ts.foreign, err = newTestCertificate("lora-app-server", &foreignCA)
+	assert.NoError(err)
+
+	keyDER, err := x509.MarshalECPrivateKey(ts.server.key)
+	assert.NoError(err)
+
+	files := map[string]*pem.Block{
+		"ca.pem":     {Type: "CERTIFICATE", Bytes: ts.ca.cert.Raw},
+		"server.pem": {Type: "CERTIFICATE", Bytes: ts.server.cert.Raw},
+		"server.key": {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	}
+	for name, block := range files {
+		assert.NoError(ioutil.WriteFile(filepath.Join(ts.tempDir, name), pem.EncodeToMemory(block), 0600))
+	}
+}
+
+func (ts *ClientCertAuthTestSuite) TearDownSuite() {
+	os.RemoveAll(ts.tempDir)
+}
+
+// startServer starts the API server with the client-certificate
+// authorization and returns its address.
+func (ts *ClientCertAuthTestSuite) startServer(allowedCNs []string, allowHealthNoCert bool) (string, func()) {
+	assert := require.New(ts.T())
+
+	creds, err := nstls.GetServerTransportCredentials(
+		filepath.Join(ts.tempDir, "ca.pem"),
+		filepath.Join(ts.tempDir, "server.pem"),
+		filepath.Join(ts.tempDir, "server.key"),
+		!allowHealthNoCert,
+	)
+	assert.NoError(err)
+
+	opts := serverOptions(newClientCertAuth(allowedCNs, allowHealthNoCert))
+	opts = append(opts, grpc.Creds(creds))
+
+	gs := grpc.NewServer(opts...)
+	ns.RegisterNetworkServerServiceServer(gs, NewNetworkServerAPI())
+	healthpb.RegisterHealthServer(gs, health.NewServer())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	go gs.Serve(ln)
+
+	return ln.Addr().String(), gs.Stop
+}
+
+func (ts *ClientCertAuthTestSuite) dial(addr string, clientCert *testCertificate) *grpc.ClientConn {
+	assert := require.New(ts.T())
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ts.ca.cert)
+
+	tlsConf := tls.Config{
+		RootCAs:    rootCAs,
+		ServerName: "localhost",
+	}
+	if clientCert != nil {
+		tlsConf.Certificates = []tls.Certificate{clientCert.tlsCertificate()}
+	}
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tlsConf)))
+	assert.NoError(err)
+	return conn
+}
+
+func (ts *ClientCertAuthTestSuite) TestClientCertificateRequired() {
+	addr, stop := ts.startServer([]string{"lora-app-server"}, false)
+	defer stop()
+
+	tests := []struct {
+		name       string
+		clientCert *testCertificate
+		expected   codes.Code
+	}{
+		{
+			name:       "allowed common name",
+			clientCert: &ts.allowed,
+			expected:   codes.OK,
+		},
+		{
+			name:       "common name not allowed",
+			clientCert: &ts.other,
+			expected:   codes.PermissionDenied,
+		},
+		{
+			name:       "certificate signed by other ca",
+			clientCert: &ts.foreign,
+			expected:   codes.Unavailable,
+		},
+		{
+			name:     "no client certificate",
+			expected: codes.Unavailable,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			conn := ts.dial(addr, tst.clientCert)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := ns.NewNetworkServerServiceClient(conn).GetVersion(ctx, &empty.Empty{})
+			assert.Equal(tst.expected, grpc.Code(err), "%v", err)
+
+			// the health-check requires a client-certificate too
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			assert.Equal(tst.expected, grpc.Code(err), "%v", err)
+		})
+	}
+}
+
+func (ts *ClientCertAuthTestSuite) TestHealthCheckWithoutClientCertificate() {
+	addr, stop := ts.startServer(nil, true)
+	defer stop()
+
+	ts.T().Run("no client certificate", func(t *testing.T) {
+		assert := require.New(t)
+
+		conn := ts.dial(addr, nil)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		assert.NoError(err)
+
+		_, err = ns.NewNetworkServerServiceClient(conn).GetVersion(ctx, &empty.Empty{})
+		assert.Equal(codes.Unauthenticated, grpc.Code(err), "%v", err)
+	})
+
+	ts.T().Run("any certificate signed by ca", func(t *testing.T) {
+		assert := require.New(t)
+
+		conn := ts.dial(addr, &ts.other)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := ns.NewNetworkServerServiceClient(conn).GetVersion(ctx, &empty.Empty{})
+		assert.NoError(err)
+	})
+
+	ts.T().Run("certificate signed by other ca", func(t *testing.T) {
+		assert := require.New(t)
+
+		conn := ts.dial(addr, &ts.foreign)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// the client does not present a certificate of a ca that is not
+		// accepted by the server, thus this equals no client-certificate
+		_, err := ns.NewNetworkServerServiceClient(conn).GetVersion(ctx, &empty.Empty{})
+		assert.Equal(codes.Unauthenticated, grpc.Code(err), "%v", err)
+	})
+}
+
+func TestClientCertAuth(t *testing.T) {
+	suite.Run(t, new(ClientCertAuthTestSuite))
+}
@@ -0,0 +1,22 @@
+package asclient
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	csg = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "application_server_client_connection_state",
+		Help: "The connection state of the application-server client (0 = idle, 1 = connecting, 2 = ready, 3 = transient failure).",
+	}, []string{"routing_profile_id", "server"})
+)
+
+func connectionStateGauge(routingProfileID uuid.UUID, server string) prometheus.Gauge {
+	return csg.With(prometheus.Labels{"routing_profile_id": routingProfileID.String(), "server": server})
+}
+
+func connectionStateDelete(routingProfileID uuid.UUID, server string) {
+	csg.Delete(prometheus.Labels{"routing_profile_id": routingProfileID.String(), "server": server})
+}
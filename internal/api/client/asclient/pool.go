@@ -8,56 +8,99 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/brocaar/loraserver/api/as"
 )
 
 // Pool defines the application-server client pool.
 type Pool interface {
-	Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error)
+	Get(routingProfileID uuid.UUID, hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error)
+}
+
+// PoolOptions holds the options of the client connections managed by the
+// Pool.
+type PoolOptions struct {
+	// KeepaliveInterval defines the interval in which the connection is
+	// pinged when there is no activity (0 = disabled).
+	KeepaliveInterval time.Duration
+
+	// KeepaliveTimeout defines the time to wait for a ping response before
+	// the connection is considered broken.
+	KeepaliveTimeout time.Duration
+
+	// ReconnectMaxDelay defines the max. backoff delay between reconnect
+	// attempts (0 = gRPC default).
+	ReconnectMaxDelay time.Duration
+
+	// IdleTimeout defines the duration after which unused connections are
+	// closed (0 = never). Idle connections are closed every IdleTimeout.
+	IdleTimeout time.Duration
 }
 
 type client struct {
 	client     as.ApplicationServerServiceClient
 	clientConn *grpc.ClientConn
+	hostname   string
 	caCert     []byte
 	tlsCert    []byte
 	tlsKey     []byte
+	lastUsed   time.Time
 }
 
 type pool struct {
 	sync.RWMutex
-	clients map[string]client
+	opts    PoolOptions
+	clients map[uuid.UUID]client
+	done    chan struct{}
 }
 
 // NewPool creates a new Pool.
-func NewPool() Pool {
-	return &pool{
-		clients: make(map[string]client),
+func NewPool(opts PoolOptions) Pool {
+	p := pool{
+		opts:    opts,
+		clients: make(map[uuid.UUID]client),
+		done:    make(chan struct{}),
+	}
+
+	if opts.IdleTimeout > 0 {
+		go p.closeIdleClientsLoop()
 	}
+
+	return &p
+}
+
+// Close stops closing the idle connections.
+func (p *pool) Close() error {
+	close(p.done)
+	return nil
 }
 
-// Get Returns an ApplicationServerClient for the given server (hostname:ip).
-func (p *pool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
+// Get Returns an ApplicationServerClient for the given routing-profile and
+// server (hostname:ip). Connections are kept per routing-profile, as
+// routing-profiles pointing to the same server might use different
+// certificates.
+func (p *pool) Get(routingProfileID uuid.UUID, hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
 	defer p.Unlock()
 	p.Lock()
 
 	var connect bool
-	c, ok := p.clients[hostname]
+	c, ok := p.clients[routingProfileID]
 	if !ok {
 		connect = true
 	}
 
-	// if the connection exists in the map, but when the certificates changed
-	// try to close the connection and re-connect
-	if ok && (!bytes.Equal(c.caCert, caCert) || !bytes.Equal(c.tlsCert, tlsCert) || !bytes.Equal(c.tlsKey, tlsKey)) {
-		c.clientConn.Close()
-		delete(p.clients, hostname)
+	// if the connection exists in the map, but when the server or certificates
+	// changed try to close the connection and re-connect
+	if ok && (c.hostname != hostname || !bytes.Equal(c.caCert, caCert) || !bytes.Equal(c.tlsCert, tlsCert) || !bytes.Equal(c.tlsKey, tlsKey)) {
+		p.closeClient(routingProfileID, c)
 		connect = true
 	}
 
@@ -69,16 +112,62 @@ func (p *pool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.Applicat
 		c = client{
 			client:     asClient,
 			clientConn: clientConn,
+			hostname:   hostname,
 			caCert:     caCert,
 			tlsCert:    tlsCert,
 			tlsKey:     tlsKey,
 		}
-		p.clients[hostname] = c
+		go p.watchConnectionState(routingProfileID, hostname, clientConn)
 	}
 
+	c.lastUsed = time.Now()
+	p.clients[routingProfileID] = c
+
 	return c.client, nil
 }
 
+func (p *pool) closeIdleClientsLoop() {
+	ticker := time.NewTicker(p.opts.IdleTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.closeIdleClients()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// closeIdleClients closes the connections which have not been used within
+// the idle timeout.
+func (p *pool) closeIdleClients() {
+	p.Lock()
+	defer p.Unlock()
+
+	for id, c := range p.clients {
+		if time.Since(c.lastUsed) > p.opts.IdleTimeout {
+			log.WithFields(log.Fields{
+				"routing_profile_id": id,
+				"server":             c.hostname,
+			}).Info("closing idle application-server client")
+			p.closeClient(id, c)
+		}
+	}
+}
+
+func (p *pool) closeClient(routingProfileID uuid.UUID, c client) {
+	if err := c.clientConn.Close(); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"routing_profile_id": routingProfileID,
+			"server":             c.hostname,
+		}).Error("close application-server client error")
+	}
+	delete(p.clients, routingProfileID)
+	connectionStateDelete(routingProfileID, c.hostname)
+}
+
 func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*grpc.ClientConn, as.ApplicationServerServiceClient, error) {
 	logrusEntry := log.NewEntry(log.StandardLogger())
 	logrusOpts := []grpc_logrus.Option{
@@ -95,28 +184,32 @@ func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*g
 		),
 	}
 
+	if p.opts.KeepaliveInterval != 0 {
+		// PermitWithoutStream makes sure that also idle connections (without
+		// active RPCs) are pinged, so that broken connections are detected
+		// before the next RPC.
+		asOpts = append(asOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                p.opts.KeepaliveInterval,
+			Timeout:             p.opts.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	if p.opts.ReconnectMaxDelay != 0 {
+		asOpts = append(asOpts, grpc.WithBackoffMaxDelay(p.opts.ReconnectMaxDelay))
+	}
+
 	if len(tlsCert) == 0 && len(tlsKey) == 0 && len(caCert) == 0 {
 		asOpts = append(asOpts, grpc.WithInsecure())
 		log.WithField("server", hostname).Warning("creating insecure application-server client")
 	} else {
 		log.WithField("server", hostname).Info("creating application-server client")
-		cert, err := tls.X509KeyPair(tlsCert, tlsKey)
+		tlsConfig, err := newTLSConfig(caCert, tlsCert, tlsKey)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "load x509 keypair error")
+			return nil, nil, err
 		}
 
-		var caCertPool *x509.CertPool
-		if len(caCert) != 0 {
-			caCertPool = x509.NewCertPool()
-			if !caCertPool.AppendCertsFromPEM(caCert) {
-				return nil, nil, errors.Wrap(err, "append ca cert to pool error")
-			}
-		}
-
-		asOpts = append(asOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-			Certificates: []tls.Certificate{cert},
-			RootCAs:      caCertPool,
-		})))
+		asOpts = append(asOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
@@ -129,3 +222,57 @@ func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*g
 
 	return asClient, as.NewApplicationServerServiceClient(asClient), nil
 }
+
+// newTLSConfig returns the TLS configuration for the given certificates.
+// The client certificate is optional, e.g. when only the server certificate
+// must be validated using the given CA certificate.
+func newTLSConfig(caCert, tlsCert, tlsKey []byte) (*tls.Config, error) {
+	var tlsConfig tls.Config
+
+	if len(tlsCert) != 0 || len(tlsKey) != 0 {
+		cert, err := tls.X509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load x509 keypair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(caCert) != 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("append ca cert to pool error")
+		}
+	}
+
+	return &tlsConfig, nil
+}
+
+// watchConnectionState updates the connection-state metric of the given
+// connection until it is closed or replaced by an other connection.
+func (p *pool) watchConnectionState(routingProfileID uuid.UUID, hostname string, conn *grpc.ClientConn) {
+	state := conn.GetState()
+	for state != connectivity.Shutdown {
+		if !p.setConnectionState(routingProfileID, hostname, conn, state) {
+			return
+		}
+		if !conn.WaitForStateChange(context.Background(), state) {
+			return
+		}
+		state = conn.GetState()
+	}
+}
+
+// setConnectionState sets the connection-state metric when the given
+// connection is the current connection for the routing-profile. It returns
+// false otherwise.
+func (p *pool) setConnectionState(routingProfileID uuid.UUID, hostname string, conn *grpc.ClientConn, state connectivity.State) bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	if c, ok := p.clients[routingProfileID]; !ok || c.clientConn != conn {
+		return false
+	}
+
+	connectionStateGauge(routingProfileID, hostname).Set(float64(state))
+	return true
+}
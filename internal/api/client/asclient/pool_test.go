@@ -0,0 +1,116 @@
+package asclient
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	t.Run("No certificates", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf, err := newTLSConfig(nil, nil, nil)
+		assert.NoError(err)
+		assert.Len(conf.Certificates, 0)
+		assert.Nil(conf.RootCAs)
+	})
+
+	t.Run("Invalid CA certificate", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := newTLSConfig([]byte("invalid"), nil, nil)
+		assert.Error(err)
+	})
+
+	t.Run("Invalid client certificate", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := newTLSConfig(nil, []byte("invalid"), []byte("invalid"))
+		assert.Error(err)
+	})
+}
+
+func TestPool(t *testing.T) {
+	assert := require.New(t)
+
+	var servers []string
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(err)
+		server := grpc.NewServer()
+		go server.Serve(ln)
+		defer server.Stop()
+
+		servers = append(servers, ln.Addr().String())
+	}
+
+	rpID1, err := uuid.NewV4()
+	assert.NoError(err)
+	rpID2, err := uuid.NewV4()
+	assert.NoError(err)
+
+	t.Run("Get returns the same client", func(t *testing.T) {
+		assert := require.New(t)
+		p := NewPool(PoolOptions{}).(*pool)
+
+		c1, err := p.Get(rpID1, servers[0], nil, nil, nil)
+		assert.NoError(err)
+		c2, err := p.Get(rpID1, servers[0], nil, nil, nil)
+		assert.NoError(err)
+		assert.True(c1 == c2)
+		assert.Len(p.clients, 1)
+	})
+
+	t.Run("Routing-profiles using the same server get their own client", func(t *testing.T) {
+		assert := require.New(t)
+		p := NewPool(PoolOptions{}).(*pool)
+
+		c1, err := p.Get(rpID1, servers[0], nil, nil, nil)
+		assert.NoError(err)
+		c2, err := p.Get(rpID2, servers[0], nil, nil, nil)
+		assert.NoError(err)
+		assert.False(c1 == c2)
+		assert.Len(p.clients, 2)
+	})
+
+	t.Run("Changing the server re-connects", func(t *testing.T) {
+		assert := require.New(t)
+		p := NewPool(PoolOptions{}).(*pool)
+
+		_, err := p.Get(rpID1, servers[0], nil, nil, nil)
+		assert.NoError(err)
+		conn := p.clients[rpID1].clientConn
+
+		_, err = p.Get(rpID1, servers[1], nil, nil, nil)
+		assert.NoError(err)
+		assert.Len(p.clients, 1)
+		assert.Equal(servers[1], p.clients[rpID1].hostname)
+		assert.Equal(connectivity.Shutdown, conn.GetState())
+	})
+
+	t.Run("Idle clients are closed", func(t *testing.T) {
+		assert := require.New(t)
+		p := NewPool(PoolOptions{IdleTimeout: time.Millisecond}).(*pool)
+		defer p.Close()
+
+		_, err := p.Get(rpID1, servers[0], nil, nil, nil)
+		assert.NoError(err)
+		p.RLock()
+		conn := p.clients[rpID1].clientConn
+		p.RUnlock()
+
+		// the idle client is closed without calling Get
+		time.Sleep(20 * time.Millisecond)
+
+		p.RLock()
+		assert.Len(p.clients, 0)
+		p.RUnlock()
+		assert.Equal(connectivity.Shutdown, conn.GetState())
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/loraserver/internal/adr"
 	"github.com/brocaar/loraserver/internal/downlink/data"
 	"github.com/brocaar/loraserver/internal/downlink/multicast"
 	"github.com/brocaar/loraserver/internal/downlink/proprietary"
@@ -12,6 +13,8 @@ import (
 )
 
 var errToCode = map[error]codes.Code{
+	adr.ErrUnknownAlgorithm: codes.InvalidArgument,
+
 	data.ErrFPortMustNotBeZero:     codes.InvalidArgument,
 	data.ErrFPortMustBeZero:        codes.InvalidArgument,
 	data.ErrNoLastRXInfoSet:        codes.FailedPrecondition,
@@ -20,14 +23,20 @@ var errToCode = map[error]codes.Code{
 
 	proprietary.ErrInvalidDataRate: codes.Internal,
 
-	multicast.ErrInvalidFCnt: codes.InvalidArgument,
+	multicast.ErrInvalidFCnt:            codes.InvalidArgument,
+	multicast.ErrMaxPayloadSizeExceeded: codes.InvalidArgument,
 
 	storage.ErrAlreadyExists:                  codes.AlreadyExists,
 	storage.ErrDoesNotExistOrFCntOrMICInvalid: codes.NotFound,
 	storage.ErrDoesNotExist:                   codes.NotFound,
 	storage.ErrInvalidName:                    codes.InvalidArgument,
 	storage.ErrInvalidAggregationInterval:     codes.InvalidArgument,
+	storage.ErrMetricsRangeExceedsTTL:         codes.InvalidArgument,
 	storage.ErrInvalidFPort:                   codes.InvalidArgument,
+	storage.ErrDeviceQueueReferenceTooLong:    codes.InvalidArgument,
+	storage.ErrDevAddrSpaceExhausted:          codes.ResourceExhausted,
+	storage.ErrGatewayTagKeyTooLong:           codes.InvalidArgument,
+	storage.ErrGatewayTooManyTags:             codes.InvalidArgument,
 }
 
 func errToRPCError(err error) error {
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	gwbackend "github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// Health-check service names. The empty service name and the
+// network-server service name report the readiness (all dependencies
+// must be available), the liveness service name reports SERVING as long
+// as the API server is running. Each dependency can also be checked
+// individually using its service name.
+const (
+	healthServiceNetworkServer  = "ns.NetworkServerService"
+	healthServiceLiveness       = "liveness"
+	healthServicePostgreSQL     = "postgresql"
+	healthServiceRedis          = "redis"
+	healthServiceGatewayBackend = "gateway_backend"
+)
+
+const (
+	healthCheckTimeout  = 5 * time.Second
+	healthWatchInterval = 5 * time.Second
+)
+
+// healthServer implements the gRPC health-check service.
+type healthServer struct {
+	checks map[string]func(context.Context) error
+}
+
+// newHealthServer creates a new healthServer with the dependency checks.
+func newHealthServer() *healthServer {
+	return &healthServer{
+		checks: map[string]func(context.Context) error{
+			healthServicePostgreSQL:     checkPostgreSQL,
+			healthServiceRedis:          checkRedis,
+			healthServiceGatewayBackend: checkGatewayBackend,
+		},
+	}
+}
+
+// Check returns the serving status of the requested service.
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	status, err := h.getStatus(ctx, req.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	return &healthpb.HealthCheckResponse{
+		Status: status,
+	}, nil
+}
+
+// Watch sends the serving status of the requested service on every change.
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, srv healthpb.Health_WatchServer) error {
+	var last healthpb.HealthCheckResponse_ServingStatus = -1
+
+	for {
+		status, err := h.getStatus(srv.Context(), req.Service)
+		if err != nil {
+			return err
+		}
+
+		if status != last {
+			if err := srv.Send(&healthpb.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			last = status
+		}
+
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-time.After(healthWatchInterval):
+		}
+	}
+}
+
+func (h *healthServer) getStatus(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	switch service {
+	case healthServiceLiveness:
+		return healthpb.HealthCheckResponse_SERVING, nil
+	case "", healthServiceNetworkServer:
+		status := healthpb.HealthCheckResponse_SERVING
+		for name := range h.checks {
+			if h.runCheck(ctx, name) != healthpb.HealthCheckResponse_SERVING {
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+		return status, nil
+	default:
+		if _, ok := h.checks[service]; !ok {
+			return healthpb.HealthCheckResponse_UNKNOWN, grpc.Errorf(codes.NotFound, "unknown service: %s", service)
+		}
+		return h.runCheck(ctx, service), nil
+	}
+}
+
+func (h *healthServer) runCheck(ctx context.Context, name string) healthpb.HealthCheckResponse_ServingStatus {
+	if err := h.checks[name](ctx); err != nil {
+		log.WithField("dependency", name).WithError(err).Warning("api: health-check failed")
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+func checkPostgreSQL(ctx context.Context) error {
+	if _, err := storage.DB().ExecContext(ctx, "select 1"); err != nil {
+		return errors.Wrap(err, "select 1 error")
+	}
+	return nil
+}
+
+// checkRedis pings Redis. The wait for a pool connection and the ping
+// itself are bounded by the deadline of the given context.
+func checkRedis(ctx context.Context) error {
+	c, err := storage.RedisPool().GetContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get redis connection error")
+	}
+	defer c.Close()
+
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+		if timeout <= 0 {
+			return errors.Wrap(context.DeadlineExceeded, "ping error")
+		}
+	}
+
+	if _, err := redis.DoWithTimeout(c, timeout, "PING"); err != nil {
+		return errors.Wrap(err, "ping error")
+	}
+	return nil
+}
+
+func checkGatewayBackend(ctx context.Context) error {
+	b := gwbackend.Backend()
+	if b == nil {
+		return errors.New("gateway backend is not set")
+	}
+
+	if c, ok := b.(gwbackend.Connectable); ok && !c.IsConnected() {
+		return errors.New("gateway backend is not connected")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	gwbackend "github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/test"
+)
+
+type connectableBackend struct {
+	*test.GatewayBackend
+	connected bool
+}
+
+func (b connectableBackend) IsConnected() bool {
+	return b.connected
+}
+
+func TestHealthServer(t *testing.T) {
+	checkOK := func(context.Context) error { return nil }
+	checkError := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name           string
+		checks         map[string]func(context.Context) error
+		service        string
+		expectedStatus healthpb.HealthCheckResponse_ServingStatus
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "readiness all dependencies available",
+			checks:         map[string]func(context.Context) error{"postgresql": checkOK, "redis": checkOK},
+			expectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:           "readiness dependency unavailable",
+			checks:         map[string]func(context.Context) error{"postgresql": checkOK, "redis": checkError},
+			expectedStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:           "readiness using network-server service name",
+			checks:         map[string]func(context.Context) error{"postgresql": checkError},
+			service:        "ns.NetworkServerService",
+			expectedStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:           "liveness dependency unavailable",
+			checks:         map[string]func(context.Context) error{"postgresql": checkError, "redis": checkError},
+			service:        "liveness",
+			expectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:           "single dependency available",
+			checks:         map[string]func(context.Context) error{"postgresql": checkError, "redis": checkOK},
+			service:        "redis",
+			expectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:           "single dependency unavailable",
+			checks:         map[string]func(context.Context) error{"postgresql": checkError, "redis": checkOK},
+			service:        "postgresql",
+			expectedStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:         "unknown service",
+			checks:       map[string]func(context.Context) error{"postgresql": checkOK},
+			service:      "foo",
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			h := healthServer{checks: tst.checks}
+
+			resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tst.service})
+			if tst.expectedCode != codes.OK {
+				assert.Equal(tst.expectedCode, grpc.Code(err))
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tst.expectedStatus, resp.Status)
+		})
+	}
+}
+
+func TestCheckGatewayBackend(t *testing.T) {
+	defer gwbackend.SetBackend(gwbackend.Backend())
+
+	t.Run("no backend", func(t *testing.T) {
+		assert := require.New(t)
+		gwbackend.SetBackend(nil)
+		assert.Error(checkGatewayBackend(context.Background()))
+	})
+
+	t.Run("backend without connection state", func(t *testing.T) {
+		assert := require.New(t)
+		gwbackend.SetBackend(test.NewGatewayBackend())
+		assert.NoError(checkGatewayBackend(context.Background()))
+	})
+
+	t.Run("connected", func(t *testing.T) {
+		assert := require.New(t)
+		gwbackend.SetBackend(connectableBackend{GatewayBackend: test.NewGatewayBackend(), connected: true})
+		assert.NoError(checkGatewayBackend(context.Background()))
+	})
+
+	t.Run("disconnected", func(t *testing.T) {
+		assert := require.New(t)
+		gwbackend.SetBackend(connectableBackend{GatewayBackend: test.NewGatewayBackend()})
+		assert.EqualError(checkGatewayBackend(context.Background()), "gateway backend is not connected")
+	})
+}
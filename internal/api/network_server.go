@@ -1,25 +1,36 @@
 package api
 
 import (
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/adr"
+	"github.com/brocaar/loraserver/internal/airtime"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/data"
 	"github.com/brocaar/loraserver/internal/downlink/data/classb"
 	"github.com/brocaar/loraserver/internal/downlink/multicast"
 	proprietarydown "github.com/brocaar/loraserver/internal/downlink/proprietary"
+	"github.com/brocaar/loraserver/internal/dutycycle"
 	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/gateway"
 	"github.com/brocaar/loraserver/internal/gps"
 	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/storage"
@@ -34,6 +45,11 @@ const defaultCodeRate = "4/5"
 // there is enough time between scheduling and the actual Class-B ping-slot.
 const classBScheduleMargin = 5 * time.Second
 
+// frameLogBufferSize contains the number of frame-logs that are buffered
+// for a stream subscriber. When the subscriber is too slow and the buffer
+// is full, frame-logs are dropped.
+const frameLogBufferSize = 10
+
 // NetworkServerAPI defines the nework-server API.
 type NetworkServerAPI struct{}
 
@@ -70,6 +86,8 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		NwkGeoLoc:              req.ServiceProfile.NwkGeoLoc,
 		TargetPER:              int(req.ServiceProfile.TargetPer),
 		MinGWDiversity:         int(req.ServiceProfile.MinGwDiversity),
+		ULAirtimeBudget:        int(req.ServiceProfile.UlAirtimeBudget),
+		DLAirtimeBudget:        int(req.ServiceProfile.DlAirtimeBudget),
 	}
 
 	switch req.ServiceProfile.UlRatePolicy {
@@ -125,6 +143,8 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 			NwkGeoLoc:              sp.NwkGeoLoc,
 			TargetPer:              uint32(sp.TargetPER),
 			MinGwDiversity:         uint32(sp.MinGWDiversity),
+			UlAirtimeBudget:        uint32(sp.ULAirtimeBudget),
+			DlAirtimeBudget:        uint32(sp.DLAirtimeBudget),
 		},
 	}
 
@@ -186,6 +206,8 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 	sp.NwkGeoLoc = req.ServiceProfile.NwkGeoLoc
 	sp.TargetPER = int(req.ServiceProfile.TargetPer)
 	sp.MinGWDiversity = int(req.ServiceProfile.MinGwDiversity)
+	sp.ULAirtimeBudget = int(req.ServiceProfile.UlAirtimeBudget)
+	sp.DLAirtimeBudget = int(req.ServiceProfile.DlAirtimeBudget)
 
 	switch req.ServiceProfile.UlRatePolicy {
 	case ns.RatePolicy_MARK:
@@ -315,6 +337,8 @@ func (n *NetworkServerAPI) UpdateRoutingProfile(ctx context.Context, req *ns.Upd
 		return nil, errToRPCError(err)
 	}
 
+	storage.FlushRoutingProfileCache(rp.ID)
+
 	return &empty.Empty{}, nil
 }
 
@@ -327,6 +351,25 @@ func (n *NetworkServerAPI) DeleteRoutingProfile(ctx context.Context, req *ns.Del
 		return nil, errToRPCError(err)
 	}
 
+	storage.FlushRoutingProfileCache(rpID)
+
+	if err := storage.FlushDeadLetterQueue(storage.RedisPool(), rpID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// FlushDeadLetterQueue removes the uplink data from the dead-letter queue of
+// the given routing-profile.
+func (n *NetworkServerAPI) FlushDeadLetterQueue(ctx context.Context, req *ns.FlushDeadLetterQueueRequest) (*empty.Empty, error) {
+	var rpID uuid.UUID
+	copy(rpID[:], req.RoutingProfileId)
+
+	if err := storage.FlushDeadLetterQueue(storage.RedisPool(), rpID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -368,6 +411,24 @@ func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.Crea
 		RFRegion:            band.Band().Name(),
 		GeolocBufferTTL:     int(req.DeviceProfile.GeolocBufferTtl),
 		GeolocMinBufferSize: int(req.DeviceProfile.GeolocMinBufferSize),
+		ADRAlgorithmID:      req.DeviceProfile.AdrAlgorithmId,
+		RXWindowPreference:  storage.RXWindowPreference(req.DeviceProfile.RxWindowPreference),
+		ABPFCntReset:        req.DeviceProfile.AbpFcntReset,
+		MaxFCntGap:          int(req.DeviceProfile.MaxFcntGap),
+	}
+
+	if _, err := adr.GetHandler(dp.ADRAlgorithmID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if _, ok := ns.RXWindowPreference_name[int32(req.DeviceProfile.RxWindowPreference)]; !ok {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid rx_window_preference")
+	}
+
+	// a larger gap would overlap with the re-transmission and frame-counter
+	// reset detection of the 16 bit frame-counter
+	if dp.MaxFCntGap > 32768 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "max_fcnt_gap must be less than or equal to 32768")
 	}
 
 	if err := storage.CreateDeviceProfile(storage.DB(), &dp); err != nil {
@@ -418,6 +479,10 @@ func (n *NetworkServerAPI) GetDeviceProfile(ctx context.Context, req *ns.GetDevi
 			Supports_32BitFCnt:  dp.Supports32bitFCnt,
 			GeolocBufferTtl:     uint32(dp.GeolocBufferTTL),
 			GeolocMinBufferSize: uint32(dp.GeolocMinBufferSize),
+			AdrAlgorithmId:      dp.ADRAlgorithmID,
+			RxWindowPreference:  ns.RXWindowPreference(dp.RXWindowPreference),
+			AbpFcntReset:        dp.ABPFCntReset,
+			MaxFcntGap:          uint32(dp.MaxFCntGap),
 		},
 	}
 
@@ -475,6 +540,24 @@ func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.Upda
 	dp.RFRegion = band.Band().Name()
 	dp.GeolocBufferTTL = int(req.DeviceProfile.GeolocBufferTtl)
 	dp.GeolocMinBufferSize = int(req.DeviceProfile.GeolocMinBufferSize)
+	dp.ADRAlgorithmID = req.DeviceProfile.AdrAlgorithmId
+	dp.RXWindowPreference = storage.RXWindowPreference(req.DeviceProfile.RxWindowPreference)
+	dp.ABPFCntReset = req.DeviceProfile.AbpFcntReset
+	dp.MaxFCntGap = int(req.DeviceProfile.MaxFcntGap)
+
+	if _, err := adr.GetHandler(dp.ADRAlgorithmID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if _, ok := ns.RXWindowPreference_name[int32(req.DeviceProfile.RxWindowPreference)]; !ok {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid rx_window_preference")
+	}
+
+	// a larger gap would overlap with the re-transmission and frame-counter
+	// reset detection of the 16 bit frame-counter
+	if dp.MaxFCntGap > 32768 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "max_fcnt_gap must be less than or equal to 32768")
+	}
 
 	if err := storage.FlushDeviceProfileCache(storage.RedisPool(), dp.ID); err != nil {
 		return nil, errToRPCError(err)
@@ -509,6 +592,10 @@ func (n *NetworkServerAPI) CreateDevice(ctx context.Context, req *ns.CreateDevic
 		return nil, grpc.Errorf(codes.InvalidArgument, "device must not be nil")
 	}
 
+	if err := validateBytesFields(devEUIField("device.dev_eui", req.Device.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	var dpID, spID, rpID uuid.UUID
 
@@ -534,6 +621,10 @@ func (n *NetworkServerAPI) CreateDevice(ctx context.Context, req *ns.CreateDevic
 
 // GetDevice returns the device matching the given DevEUI.
 func (n *NetworkServerAPI) GetDevice(ctx context.Context, req *ns.GetDeviceRequest) (*ns.GetDeviceResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
@@ -572,6 +663,10 @@ func (n *NetworkServerAPI) UpdateDevice(ctx context.Context, req *ns.UpdateDevic
 		return nil, grpc.Errorf(codes.InvalidArgument, "device must not be nil")
 	}
 
+	if err := validateBytesFields(devEUIField("device.dev_eui", req.Device.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	var dpID, spID, rpID uuid.UUID
 
@@ -600,6 +695,10 @@ func (n *NetworkServerAPI) UpdateDevice(ctx context.Context, req *ns.UpdateDevic
 
 // DeleteDevice deletes the device matching the given DevEUI.
 func (n *NetworkServerAPI) DeleteDevice(ctx context.Context, req *ns.DeleteDeviceRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
@@ -627,6 +726,16 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		return nil, grpc.Errorf(codes.InvalidArgument, "device_activation must not be nil")
 	}
 
+	if err := validateBytesFields(
+		devEUIField("device_activation.dev_eui", req.DeviceActivation.DevEui),
+		devAddrField("device_activation.dev_addr", req.DeviceActivation.DevAddr),
+		keyField("device_activation.s_nwk_s_int_key", req.DeviceActivation.SNwkSIntKey),
+		keyField("device_activation.f_nwk_s_int_key", req.DeviceActivation.FNwkSIntKey),
+		keyField("device_activation.nwk_s_enc_key", req.DeviceActivation.NwkSEncKey),
+	); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	var devAddr lorawan.DevAddr
 	var sNwkSIntKey, fNwkSIntKey, nwkSEncKey lorawan.AES128Key
@@ -662,7 +771,10 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		AFCntDown:          req.DeviceActivation.AFCntDown,
 		SkipFCntValidation: req.DeviceActivation.SkipFCntCheck || d.SkipFCntCheck,
 
-		RXWindow: storage.RX1,
+		RXWindow:           storage.RX1,
+		RXWindowPreference: dp.RXWindowPreference,
+		ABPFCntReset:       dp.ABPFCntReset && !dp.SupportsJoin,
+		MaxFCntGap:         uint32(dp.MaxFCntGap),
 
 		MACVersion: dp.MACVersion,
 	}
@@ -681,15 +793,15 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 	// reset the device-session to the device boot parameters
 	ds.ResetToBootParameters(dp)
 
-	if err := storage.SaveDeviceSession(storage.RedisPool(), ds); err != nil {
-		return nil, errToRPCError(err)
-	}
-
+	// the device-queue is flushed first, so that a failure does not leave an
+	// activated device with a stale device-queue
 	if err := storage.FlushDeviceQueueForDevEUI(storage.DB(), d.DevEUI); err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	if err := storage.FlushMACCommandQueue(storage.RedisPool(), ds.DevEUI); err != nil {
+	// save the device-session and flush the mac-command queue and pending
+	// mac-commands atomically
+	if err := storage.ActivateDeviceSession(storage.RedisPool(), ds); err != nil {
 		return nil, errToRPCError(err)
 	}
 
@@ -698,22 +810,51 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 
 // DeactivateDevice de-activates a device.
 func (n *NetworkServerAPI) DeactivateDevice(ctx context.Context, req *ns.DeactivateDeviceRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
+	// All per-device state is removed on a best-effort basis, so that a
+	// failure of one step does not leave the remaining state behind.
+	var errs []error
+
+	// this also removes the pending (e.g. Class-C) device-queue items
+	if err := storage.FlushDeviceQueueForDevEUI(storage.DB(), devEUI); err != nil {
+		errs = append(errs, errors.Wrap(err, "flush device-queue error"))
+	}
+
+	// this also removes the mac-command queue and pending mac-commands
 	if err := storage.DeleteDeviceSession(storage.RedisPool(), devEUI); err != nil {
-		return nil, errToRPCError(err)
+		errs = append(errs, errors.Wrap(err, "delete device-session error"))
 	}
 
-	if err := storage.FlushDeviceQueueForDevEUI(storage.DB(), devEUI); err != nil {
-		return nil, errToRPCError(err)
+	if err := storage.DeleteDeviceGatewayRXInfoSet(storage.RedisPool(), devEUI); err != nil && err != storage.ErrDoesNotExist {
+		errs = append(errs, errors.Wrap(err, "delete device gateway rx-info set error"))
 	}
 
-	return &empty.Empty{}, nil
+	switch len(errs) {
+	case 0:
+		return &empty.Empty{}, nil
+	case 1:
+		return nil, errToRPCError(errs[0])
+	default:
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return nil, grpc.Errorf(codes.Internal, "deactivate device errors: %s", strings.Join(msgs, ", "))
+	}
 }
 
 // GetDeviceActivation returns the device activation details.
 func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetDeviceActivationRequest) (*ns.GetDeviceActivationResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
@@ -722,7 +863,12 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 		return nil, errToRPCError(err)
 	}
 
-	return &ns.GetDeviceActivationResponse{
+	ttl, err := storage.GetDeviceSessionTTL(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetDeviceActivationResponse{
 		DeviceActivation: &ns.DeviceActivation{
 			DevEui:        ds.DevEUI[:],
 			DevAddr:       ds.DevAddr[:],
@@ -734,9 +880,246 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 			AFCntDown:     ds.AFCntDown,
 			SkipFCntCheck: ds.SkipFCntValidation,
 		},
+	}
+
+	if ttl > 0 {
+		resp.DeviceSessionTtl = ptypes.DurationProto(ttl)
+	}
+
+	return &resp, nil
+}
+
+// GetDeviceSession returns the device-session for the given DevEUI.
+func (n *NetworkServerAPI) GetDeviceSession(ctx context.Context, req *ns.GetDeviceSessionRequest) (*ns.GetDeviceSessionResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetDeviceSessionResponse{
+		DeviceSession: deviceSessionToProto(ds),
 	}, nil
 }
 
+// GetDeviceSessionsForDevAddr returns the device-sessions using the given
+// DevAddr. This can be used to debug DevAddr collisions.
+func (n *NetworkServerAPI) GetDeviceSessionsForDevAddr(ctx context.Context, req *ns.GetDeviceSessionsForDevAddrRequest) (*ns.GetDeviceSessionsForDevAddrResponse, error) {
+	if err := validateBytesFields(devAddrField("dev_addr", req.DevAddr)); err != nil {
+		return nil, err
+	}
+
+	var devAddr lorawan.DevAddr
+	copy(devAddr[:], req.DevAddr)
+
+	sessions, err := storage.GetDeviceSessionsForDevAddr(storage.RedisPool(), devAddr)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetDeviceSessionsForDevAddrResponse
+	for _, ds := range sessions {
+		resp.DeviceSessions = append(resp.DeviceSessions, deviceSessionToProto(ds))
+	}
+
+	return &resp, nil
+}
+
+// UpdateDeviceSessionInstallationMargin updates the ADR installation margin
+// of the device-session. Setting it to 0 reverts to the global installation
+// margin.
+func (n *NetworkServerAPI) UpdateDeviceSessionInstallationMargin(ctx context.Context, req *ns.UpdateDeviceSessionInstallationMarginRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ds.InstallationMargin = req.InstallationMargin
+
+	if err := storage.SaveDeviceSession(storage.RedisPool(), ds); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// UpdateDeviceSessionRXWindow updates the RX window preference of the
+// device-session. This takes effect on the next downlink, without the need
+// to re-activate the device.
+func (n *NetworkServerAPI) UpdateDeviceSessionRXWindow(ctx context.Context, req *ns.UpdateDeviceSessionRXWindowRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	if _, ok := ns.RXWindowPreference_name[int32(req.RxWindowPreference)]; !ok {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid rx_window_preference")
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ds.RXWindowPreference = storage.RXWindowPreference(req.RxWindowPreference)
+
+	if err := storage.SaveDeviceSession(storage.RedisPool(), ds); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// ResetDeviceSessionLinkADRState resets the state resulting from LinkADRReq
+// mac-commands rejected by the device (max supported data-rate, rejected
+// channel-mask and stopped ADR), e.g. after the device has been
+// reconfigured.
+func (n *NetworkServerAPI) ResetDeviceSessionLinkADRState(ctx context.Context, req *ns.ResetDeviceSessionLinkADRStateRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ds.ResetLinkADRState()
+
+	if err := storage.SaveDeviceSession(storage.RedisPool(), ds); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetDeviceLinkMetrics returns the uplink history and the last ADR decision
+// for the given DevEUI.
+func (n *NetworkServerAPI) GetDeviceLinkMetrics(ctx context.Context, req *ns.GetDeviceLinkMetricsRequest) (*ns.GetDeviceLinkMetricsResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetDeviceLinkMetricsResponse{
+		Adr:                  ds.ADR,
+		PacketLossPercentage: ds.GetPacketLossPercentage(),
+	}
+
+	for _, h := range ds.UplinkHistory {
+		resp.UplinkHistory = append(resp.UplinkHistory, &ns.UplinkHistoryItem{
+			FCnt:         h.FCnt,
+			MaxSnr:       h.MaxSNR,
+			TxPowerIndex: uint32(h.TXPowerIndex),
+			GatewayCount: uint32(h.GatewayCount),
+		})
+	}
+
+	if ds.LastLinkADRReq != nil {
+		resp.LastAdrDecision = &ns.ADRDecision{
+			Dr:           uint32(ds.LastLinkADRReq.DR),
+			TxPowerIndex: uint32(ds.LastLinkADRReq.TXPowerIndex),
+			NbTrans:      uint32(ds.LastLinkADRReq.NbTrans),
+			Ack:          ds.LastLinkADRReq.ACK,
+		}
+	}
+
+	return &resp, nil
+}
+
+// GetDeviceStatus returns the last device-status reported by the device.
+func (n *NetworkServerAPI) GetDeviceStatus(ctx context.Context, req *ns.GetDeviceStatusRequest) (*ns.GetDeviceStatusResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetDeviceStatusResponse
+	if ds.LastDevStatus == nil {
+		return &resp, nil
+	}
+
+	resp.Available = true
+	resp.Battery = uint32(ds.LastDevStatus.Battery)
+	resp.ExternalPowerSource = ds.LastDevStatus.Battery == 0
+	resp.BatteryLevelUnavailable = ds.LastDevStatus.Battery == 255
+	resp.Margin = int32(ds.LastDevStatus.Margin)
+	resp.ReceivedAt, err = ptypes.TimestampProto(ds.LastDevStatus.ReceivedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+// GetDeviceLocation returns the last location of the device, as resolved by
+// the geolocation-server.
+func (n *NetworkServerAPI) GetDeviceLocation(ctx context.Context, req *ns.GetDeviceLocationRequest) (*ns.GetDeviceLocationResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	var resp ns.GetDeviceLocationResponse
+
+	loc, err := storage.GetDeviceLocation(storage.RedisPool(), devEUI)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return &resp, nil
+		}
+		return nil, errToRPCError(err)
+	}
+
+	resp.Available = true
+	resp.Location = &common.Location{
+		Latitude:  loc.Latitude,
+		Longitude: loc.Longitude,
+		Altitude:  loc.Altitude,
+		Source:    common.LocationSource_GEO_RESOLVER,
+		Accuracy:  loc.Accuracy,
+	}
+	resp.ResolvedAt, err = ptypes.TimestampProto(loc.ResolvedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
 // GetRandomDevAddr returns a random DevAddr.
 func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *empty.Empty) (*ns.GetRandomDevAddrResponse, error) {
 	devAddr, err := storage.GetRandomDevAddr(storage.RedisPool(), config.C.NetworkServer.NetID)
@@ -752,6 +1135,10 @@ func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *empty.Empt
 // CreateMACCommandQueueItem adds a data down MAC command to the queue.
 // It replaces already enqueued mac-commands with the same CID.
 func (n *NetworkServerAPI) CreateMACCommandQueueItem(ctx context.Context, req *ns.CreateMACCommandQueueItemRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var commands []lorawan.MACCommand
 	var devEUI lorawan.EUI64
 
@@ -778,8 +1165,110 @@ func (n *NetworkServerAPI) CreateMACCommandQueueItem(ctx context.Context, req *n
 	return &empty.Empty{}, nil
 }
 
+// GetMACCommandQueueItems returns the mac-command queue items for the
+// given DevEUI.
+func (n *NetworkServerAPI) GetMACCommandQueueItems(ctx context.Context, req *ns.GetMACCommandQueueItemsRequest) (*ns.GetMACCommandQueueItemsResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	blocks, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	pending, err := storage.GetPendingMACCommands(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetMACCommandQueueItemsResponse
+	for _, block := range blocks {
+		item, err := macCommandBlockToQueueItem(block)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	for _, block := range pending {
+		item, err := macCommandBlockToQueueItem(block)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		resp.PendingItems = append(resp.PendingItems, item)
+	}
+
+	return &resp, nil
+}
+
+func macCommandBlockToQueueItem(block storage.MACCommandBlock) (*ns.MACCommandQueueItem, error) {
+	item := ns.MACCommandQueueItem{
+		Cid:        uint32(block.CID),
+		External:   block.External,
+		RetryCount: uint32(block.RetryCount),
+	}
+
+	for _, mac := range block.MACCommands {
+		b, err := mac.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		item.Commands = append(item.Commands, b)
+	}
+
+	return &item, nil
+}
+
+// DeleteMACCommandQueueItem deletes the mac-command queue item(s) matching
+// the given DevEUI and CID.
+func (n *NetworkServerAPI) DeleteMACCommandQueueItem(ctx context.Context, req *ns.DeleteMACCommandQueueItemRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	blocks, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var deleted bool
+	for _, block := range blocks {
+		if block.CID != lorawan.CID(req.Cid) {
+			continue
+		}
+
+		if err := storage.DeleteMACCommandQueueItem(storage.RedisPool(), devEUI, block); err != nil {
+			return nil, errToRPCError(err)
+		}
+		deleted = true
+	}
+
+	if !deleted {
+		return nil, errToRPCError(storage.ErrDoesNotExist)
+	}
+
+	return &empty.Empty{}, nil
+}
+
 // SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 func (n *NetworkServerAPI) SendProprietaryPayload(ctx context.Context, req *ns.SendProprietaryPayloadRequest) (*empty.Empty, error) {
+	fields := []bytesField{
+		{name: "mic", value: req.Mic, length: micLength},
+	}
+	for i := range req.GatewayMacs {
+		fields = append(fields, gatewayIDField(indexedName("gateway_macs", i), req.GatewayMacs[i]))
+	}
+	if err := validateBytesFields(fields...); err != nil {
+		return nil, err
+	}
+
 	var mic lorawan.MIC
 	var gwIDs []lorawan.EUI64
 
@@ -808,14 +1297,30 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 		return nil, grpc.Errorf(codes.InvalidArgument, "gateway.location must not be nil")
 	}
 
+	if err := validateBytesFields(gatewayBytesFields(req.Gateway)...); err != nil {
+		return nil, err
+	}
+
 	gw := storage.Gateway{
 		Location: storage.GPSPoint{
 			Latitude:  req.Gateway.Location.Latitude,
 			Longitude: req.Gateway.Location.Longitude,
 		},
-		Altitude: req.Gateway.Location.Altitude,
+		Altitude:        req.Gateway.Location.Altitude,
+		MaintenanceMode: req.Gateway.MaintenanceMode,
+		Tags:            storage.GatewayTags(req.Gateway.Tags),
+		RSSIOffset:      req.Gateway.RssiOffset,
+		SNROffset:       req.Gateway.SnrOffset,
+		AntennaGain:     req.Gateway.AntennaGain,
+		MaxTXPower:      int(req.Gateway.MaxTxPower),
+
+		UpdateLocationFromStats: req.Gateway.UpdateLocationFromStats,
+		DiscoveryEnabled:        req.Gateway.DiscoveryEnabled,
 	}
 
+	now := time.Now()
+	gw.LocationUpdatedAt = &now
+
 	// Gateway ID
 	copy(gw.GatewayID[:], req.Gateway.Id)
 
@@ -854,11 +1359,19 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 		return nil, err
 	}
 
+	if err := gateway.UpdateConfiguration(storage.DB(), gw); err != nil {
+		log.WithField("gateway_id", gw.GatewayID).WithError(err).Error("update gateway configuration error")
+	}
+
 	return &empty.Empty{}, nil
 }
 
 // GetGateway returns data for a particular gateway.
 func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayRequest) (*ns.GetGatewayResponse, error) {
+	if err := validateBytesFields(gatewayIDField("id", req.Id)); err != nil {
+		return nil, err
+	}
+
 	var id lorawan.EUI64
 	copy(id[:], req.Id)
 
@@ -874,8 +1387,21 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 				Latitude:  gw.Location.Latitude,
 				Longitude: gw.Location.Longitude,
 				Altitude:  gw.Altitude,
+				Source:    gatewayLocationSource(gw),
 			},
+			MaintenanceMode:         gw.MaintenanceMode,
+			Tags:                    gw.Tags,
+			RssiOffset:              gw.RSSIOffset,
+			SnrOffset:               gw.SNROffset,
+			AntennaGain:             gw.AntennaGain,
+			MaxTxPower:              int32(gw.MaxTXPower),
+			UpdateLocationFromStats: gw.UpdateLocationFromStats,
+			DiscoveryEnabled:        gw.DiscoveryEnabled,
 		},
+		Online:          gw.Online,
+		AutoCreated:     gw.AutoCreated,
+		ConfigVersion:   gw.ConfigVersion,
+		DownlinkTxPower: int32(gatewayDownlinkTXPower(gw)),
 	}
 
 	resp.CreatedAt, _ = ptypes.TimestampProto(gw.CreatedAt)
@@ -883,6 +1409,12 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 
 	if gw.GatewayProfileID != nil {
 		resp.Gateway.GatewayProfileId = gw.GatewayProfileID.Bytes()
+
+		gp, err := storage.GetGatewayProfile(storage.DB(), *gw.GatewayProfileID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		resp.ExpectedConfigVersion = gp.GetVersion()
 	}
 
 	if gw.FirstSeenAt != nil {
@@ -893,6 +1425,10 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 		resp.LastSeenAt, _ = ptypes.TimestampProto(*gw.LastSeenAt)
 	}
 
+	if gw.LocationUpdatedAt != nil {
+		resp.LocationUpdatedAt, _ = ptypes.TimestampProto(*gw.LocationUpdatedAt)
+	}
+
 	for i := range gw.Boards {
 		var gwBoard ns.GatewayBoard
 		if gw.Boards[i].FPGAID != nil {
@@ -903,7 +1439,23 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 			gwBoard.FineTimestampKey = gw.Boards[i].FineTimestampKey[:]
 		}
 
-		resp.Gateway.Boards = append(resp.Gateway.Boards, &gwBoard)
+		resp.Gateway.Boards = append(resp.Gateway.Boards, &gwBoard)
+	}
+
+	budgets, err := dutycycle.GetBudgets(storage.RedisPool(), gw.GatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	for _, b := range budgets {
+		resp.DutyCycleBudgets = append(resp.DutyCycleBudgets, &ns.GatewayDutyCycleBudget{
+			SubBand:          b.SubBand.Name,
+			MinFrequency:     uint32(b.SubBand.MinFrequency),
+			MaxFrequency:     uint32(b.SubBand.MaxFrequency),
+			DutyCycle:        float32(b.SubBand.DutyCycle),
+			UsedAirtime:      ptypes.DurationProto(b.Used),
+			RemainingAirtime: ptypes.DurationProto(b.Remaining),
+		})
 	}
 
 	return &resp, nil
@@ -919,6 +1471,10 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 		return nil, grpc.Errorf(codes.InvalidArgument, "gateway.location must not be nil")
 	}
 
+	if err := validateBytesFields(gatewayBytesFields(req.Gateway)...); err != nil {
+		return nil, err
+	}
+
 	var id lorawan.EUI64
 	copy(id[:], req.Gateway.Id)
 
@@ -936,11 +1492,29 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 		gw.GatewayProfileID = nil
 	}
 
-	gw.Location = storage.GPSPoint{
+	location := storage.GPSPoint{
 		Latitude:  req.Gateway.Location.Latitude,
 		Longitude: req.Gateway.Location.Longitude,
 	}
+	if location != gw.Location || req.Gateway.Location.Altitude != gw.Altitude {
+		now := time.Now()
+		gw.LocationFromGPS = false
+		gw.LocationUpdatedAt = &now
+	}
+
+	gw.Location = location
 	gw.Altitude = req.Gateway.Location.Altitude
+	gw.UpdateLocationFromStats = req.Gateway.UpdateLocationFromStats
+	gw.DiscoveryEnabled = req.Gateway.DiscoveryEnabled
+	gw.MaintenanceMode = req.Gateway.MaintenanceMode
+	gw.Tags = storage.GatewayTags(req.Gateway.Tags)
+	gw.RSSIOffset = req.Gateway.RssiOffset
+	gw.SNROffset = req.Gateway.SnrOffset
+	gw.AntennaGain = req.Gateway.AntennaGain
+	gw.MaxTXPower = int(req.Gateway.MaxTxPower)
+
+	// the gateway has been reviewed by the operator
+	gw.AutoCreated = false
 
 	gw.Boards = nil
 	for _, board := range req.Gateway.Boards {
@@ -975,11 +1549,19 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 		return nil, err
 	}
 
+	if err := gateway.UpdateConfiguration(storage.DB(), gw); err != nil {
+		log.WithField("gateway_id", gw.GatewayID).WithError(err).Error("update gateway configuration error")
+	}
+
 	return &empty.Empty{}, nil
 }
 
 // DeleteGateway deletes a gateway.
 func (n *NetworkServerAPI) DeleteGateway(ctx context.Context, req *ns.DeleteGatewayRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(gatewayIDField("id", req.Id)); err != nil {
+		return nil, err
+	}
+
 	var id lorawan.EUI64
 	copy(id[:], req.Id)
 
@@ -994,8 +1576,99 @@ func (n *NetworkServerAPI) DeleteGateway(ctx context.Context, req *ns.DeleteGate
 	return &empty.Empty{}, nil
 }
 
+// ListGateways returns the gateways, optionally filtered by tags.
+func (n *NetworkServerAPI) ListGateways(ctx context.Context, req *ns.ListGatewaysRequest) (*ns.ListGatewaysResponse, error) {
+	filters := storage.GatewayFilters{
+		Tags:      storage.GatewayTags(req.Tags),
+		Search:    req.Search,
+		OrderDesc: req.OrderDesc,
+	}
+
+	switch req.OrderBy {
+	case ns.GatewayOrderBy_CREATED_AT:
+		filters.OrderBy = storage.GatewayOrderByCreatedAt
+	case ns.GatewayOrderBy_LAST_SEEN_AT:
+		filters.OrderBy = storage.GatewayOrderByLastSeenAt
+	default:
+		filters.OrderBy = storage.GatewayOrderByID
+	}
+
+	if req.OnlineWithin != nil {
+		d, err := ptypes.Duration(req.OnlineWithin)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		filters.OnlineWithin = d
+	}
+
+	if req.MaintenanceMode != nil {
+		filters.MaintenanceMode = &req.MaintenanceMode.Value
+	}
+
+	count, err := storage.GetGatewayCount(storage.DB(), filters)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	gws, err := storage.GetGateways(storage.DB(), filters, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.ListGatewaysResponse{
+		TotalCount: int64(count),
+	}
+
+	for _, gw := range gws {
+		item := ns.ListGatewaysItem{
+			Gateway: &ns.Gateway{
+				Id: gw.GatewayID[:],
+				Location: &common.Location{
+					Latitude:  gw.Location.Latitude,
+					Longitude: gw.Location.Longitude,
+					Altitude:  gw.Altitude,
+					Source:    gatewayLocationSource(gw),
+				},
+				MaintenanceMode:         gw.MaintenanceMode,
+				Tags:                    gw.Tags,
+				RssiOffset:              gw.RSSIOffset,
+				SnrOffset:               gw.SNROffset,
+				AntennaGain:             gw.AntennaGain,
+				MaxTxPower:              int32(gw.MaxTXPower),
+				UpdateLocationFromStats: gw.UpdateLocationFromStats,
+				DiscoveryEnabled:        gw.DiscoveryEnabled,
+			},
+			Online:      gw.Online,
+			AutoCreated: gw.AutoCreated,
+		}
+
+		if gw.GatewayProfileID != nil {
+			item.Gateway.GatewayProfileId = gw.GatewayProfileID.Bytes()
+		}
+
+		item.CreatedAt, _ = ptypes.TimestampProto(gw.CreatedAt)
+		item.UpdatedAt, _ = ptypes.TimestampProto(gw.UpdatedAt)
+
+		if gw.FirstSeenAt != nil {
+			item.FirstSeenAt, _ = ptypes.TimestampProto(*gw.FirstSeenAt)
+		}
+
+		if gw.LastSeenAt != nil {
+			item.LastSeenAt, _ = ptypes.TimestampProto(*gw.LastSeenAt)
+		}
+
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
 // GetGatewayStats returns stats of an existing gateway.
 func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatewayStatsRequest) (*ns.GetGatewayStatsResponse, error) {
+	if err := validateBytesFields(gatewayIDField("gateway_id", req.GatewayId)); err != nil {
+		return nil, err
+	}
+
 	gatewayID := helpers.GetGatewayID(req)
 
 	start, err := ptypes.Timestamp(req.StartTimestamp)
@@ -1008,9 +1681,9 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
 	}
 
-	metrics, err := storage.GetMetrics(storage.RedisPool(), storage.AggregationInterval(req.Interval.String()), "gw:"+gatewayID.String(), start, end)
+	metrics, err := getMetrics("gw:"+gatewayID.String(), req.Interval, start, end, req.Timezone)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, err
 	}
 
 	var resp ns.GetGatewayStatsResponse
@@ -1021,6 +1694,82 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 			RxPacketsReceivedOk: int32(m.Metrics["rx_ok_count"]),
 			TxPacketsReceived:   int32(m.Metrics["tx_count"]),
 			TxPacketsEmitted:    int32(m.Metrics["tx_ok_count"]),
+			TxPacketsError:      int32(m.Metrics["tx_error_count"]),
+			RxAirtime:           airtimeMetricToProto(m.Metrics["rx_airtime_ms"]),
+			TxAirtime:           airtimeMetricToProto(m.Metrics["tx_airtime_ms"]),
+		}
+
+		for k, v := range m.Metrics {
+			freq, sf, ok := gateway.ParseRXPacketMetric(k)
+			if !ok || v == 0 {
+				continue
+			}
+
+			row.RxPacketsPerFrequencySf = append(row.RxPacketsPerFrequencySf, &ns.GatewayStatsRXPackets{
+				Frequency:       freq,
+				SpreadingFactor: sf,
+				RxPackets:       int32(v),
+			})
+		}
+
+		sort.Slice(row.RxPacketsPerFrequencySf, func(i, j int) bool {
+			a, b := row.RxPacketsPerFrequencySf[i], row.RxPacketsPerFrequencySf[j]
+			if a.Frequency == b.Frequency {
+				return a.SpreadingFactor < b.SpreadingFactor
+			}
+			return a.Frequency < b.Frequency
+		})
+
+		row.Timestamp, err = ptypes.TimestampProto(m.Time)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &row)
+	}
+
+	return &resp, nil
+}
+
+// GetNetworkStats returns the network-wide stats, aggregated over all gateways.
+func (n *NetworkServerAPI) GetNetworkStats(ctx context.Context, req *ns.GetNetworkStatsRequest) (*ns.GetNetworkStatsResponse, error) {
+	start, err := ptypes.Timestamp(req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	end, err := ptypes.Timestamp(req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	metrics, err := getMetrics("network", req.Interval, start, end, req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ns.GetNetworkStatsResponse
+
+	gatewaysOnline, err := storage.GetOnlineGatewayCount(storage.DB())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	resp.GatewaysOnline = int32(gatewaysOnline)
+
+	deviceSessions, err := storage.GetDeviceSessionCount(storage.RedisPool())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	resp.DeviceSessions = int32(deviceSessions)
+
+	for _, m := range metrics {
+		row := ns.NetworkStats{
+			RxPacketsReceived:   int32(m.Metrics["rx_count"]),
+			RxPacketsReceivedOk: int32(m.Metrics["rx_ok_count"]),
+			TxPacketsReceived:   int32(m.Metrics["tx_count"]),
+			TxPacketsEmitted:    int32(m.Metrics["tx_ok_count"]),
+			RxAirtime:           airtimeMetricToProto(m.Metrics["rx_airtime_ms"]),
+			TxAirtime:           airtimeMetricToProto(m.Metrics["tx_airtime_ms"]),
 		}
 
 		row.Timestamp, err = ptypes.TimestampProto(m.Time)
@@ -1034,9 +1783,64 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 	return &resp, nil
 }
 
+// GetGatewayDiscoveryPings returns the receptions of the discovery pings
+// transmitted by the given gateway.
+func (n *NetworkServerAPI) GetGatewayDiscoveryPings(ctx context.Context, req *ns.GetGatewayDiscoveryPingsRequest) (*ns.GetGatewayDiscoveryPingsResponse, error) {
+	if err := validateBytesFields(gatewayIDField("gateway_id", req.GatewayId)); err != nil {
+		return nil, err
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], req.GatewayId)
+
+	var start, end time.Time
+	var err error
+
+	if req.StartTimestamp != nil {
+		start, err = ptypes.Timestamp(req.StartTimestamp)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.EndTimestamp != nil {
+		end, err = ptypes.Timestamp(req.EndTimestamp)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	rxs, err := storage.GetGatewayDiscoveryPingRXsForGatewayID(storage.DB(), gatewayID, start, end)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetGatewayDiscoveryPingsResponse
+	for _, rx := range rxs {
+		row := ns.GatewayDiscoveryPingRX{
+			RxGatewayId: rx.RXGatewayID[:],
+			Rssi:        int32(rx.RSSI),
+			LoraSnr:     rx.LoRaSNR,
+		}
+
+		row.CreatedAt, err = ptypes.TimestampProto(rx.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &row)
+	}
+
+	return &resp, nil
+}
+
 // StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
 func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForGatewayRequest, srv ns.NetworkServerService_StreamFrameLogsForGatewayServer) error {
-	frameLogChan := make(chan framelog.FrameLog)
+	if err := validateBytesFields(gatewayIDField("gateway_id", req.GatewayId)); err != nil {
+		return err
+	}
+
+	frameLogChan := make(chan framelog.FrameLog, frameLogBufferSize)
 	var id lorawan.EUI64
 	copy(id[:], req.GatewayId)
 
@@ -1063,6 +1867,16 @@ func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForG
 			}
 		}
 
+		if fl.DownlinkTXAck != nil {
+			resp.Frame = &ns.StreamFrameLogsForGatewayResponse_DownlinkTxAck{
+				DownlinkTxAck: fl.DownlinkTXAck,
+			}
+		}
+
+		if fl.Airtime != 0 {
+			resp.Airtime = ptypes.DurationProto(fl.Airtime)
+		}
+
 		if err := srv.Send(&resp); err != nil {
 			log.WithError(err).Error("error sending frame-log response")
 		}
@@ -1073,7 +1887,11 @@ func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForG
 
 // StreamFrameLogsForDevice returns a stream of frames seen by the given device.
 func (n *NetworkServerAPI) StreamFrameLogsForDevice(req *ns.StreamFrameLogsForDeviceRequest, srv ns.NetworkServerService_StreamFrameLogsForDeviceServer) error {
-	frameLogChan := make(chan framelog.FrameLog)
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return err
+	}
+
+	frameLogChan := make(chan framelog.FrameLog, frameLogBufferSize)
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
@@ -1098,6 +1916,18 @@ func (n *NetworkServerAPI) StreamFrameLogsForDevice(req *ns.StreamFrameLogsForDe
 			resp.Frame = &ns.StreamFrameLogsForDeviceResponse_DownlinkFrame{
 				DownlinkFrame: fl.DownlinkFrame,
 			}
+			resp.DownlinkRxWindow = fl.DownlinkRXWindow
+			resp.DownlinkMacCommandTransport = fl.DownlinkMACCommandTransport
+		}
+
+		if fl.RejectedUplinkFrame != nil {
+			resp.Frame = &ns.StreamFrameLogsForDeviceResponse_RejectedUplinkFrameSet{
+				RejectedUplinkFrameSet: fl.RejectedUplinkFrame,
+			}
+		}
+
+		if fl.Airtime != 0 {
+			resp.Airtime = ptypes.DurationProto(fl.Airtime)
 		}
 
 		if err := srv.Send(&resp); err != nil {
@@ -1118,7 +1948,16 @@ func (n *NetworkServerAPI) CreateGatewayProfile(ctx context.Context, req *ns.Cre
 	copy(gpID[:], req.GatewayProfile.Id)
 
 	gc := storage.GatewayProfile{
-		ID: gpID,
+		ID:   gpID,
+		Name: req.GatewayProfile.Name,
+	}
+
+	if req.GatewayProfile.StatsInterval != nil {
+		d, err := ptypes.Duration(req.GatewayProfile.StatsInterval)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		gc.StatsInterval = d
 	}
 
 	for _, c := range req.GatewayProfile.Channels {
@@ -1168,10 +2007,15 @@ func (n *NetworkServerAPI) GetGatewayProfile(ctx context.Context, req *ns.GetGat
 
 	out := ns.GetGatewayProfileResponse{
 		GatewayProfile: &ns.GatewayProfile{
-			Id: gc.ID.Bytes(),
+			Id:   gc.ID.Bytes(),
+			Name: gc.Name,
 		},
 	}
 
+	if gc.StatsInterval != 0 {
+		out.GatewayProfile.StatsInterval = ptypes.DurationProto(gc.StatsInterval)
+	}
+
 	out.CreatedAt, err = ptypes.TimestampProto(gc.CreatedAt)
 	if err != nil {
 		return nil, errToRPCError(err)
@@ -1224,6 +2068,15 @@ func (n *NetworkServerAPI) UpdateGatewayProfile(ctx context.Context, req *ns.Upd
 		return nil, errToRPCError(err)
 	}
 
+	gc.Name = req.GatewayProfile.Name
+	gc.StatsInterval = 0
+	if req.GatewayProfile.StatsInterval != nil {
+		gc.StatsInterval, err = ptypes.Duration(req.GatewayProfile.StatsInterval)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	gc.Channels = []int64{}
 	for _, c := range req.GatewayProfile.Channels {
 		gc.Channels = append(gc.Channels, int64(c))
@@ -1258,6 +2111,11 @@ func (n *NetworkServerAPI) UpdateGatewayProfile(ctx context.Context, req *ns.Upd
 		return nil, errToRPCError(err)
 	}
 
+	// push the updated configuration to the gateways using this profile
+	if err := gateway.UpdateConfigurationForGatewayProfile(storage.DB(), gc.ID); err != nil {
+		log.WithField("gateway_profile_id", gc.ID).WithError(err).Error("update gateway configuration error")
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -1279,6 +2137,14 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
 	}
 
+	if err := validateBytesFields(
+		devEUIField("item.dev_eui", req.Item.DevEui),
+		bytesField{name: "item.dev_addr", value: req.Item.DevAddr, length: devAddrLength, optional: true},
+		bytesField{name: "item.gateway_id", value: req.Item.GatewayId, length: euiLength, optional: true},
+	); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.Item.DevEui)
 
@@ -1304,6 +2170,47 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		return nil, grpc.Errorf(codes.InvalidArgument, "device security-context out of sync")
 	}
 
+	// Validate the payload size against the data-rate at which the item
+	// will be transmitted. For Class-A this is best-effort only, as the
+	// data-rate might change (e.g. by ADR) before the next uplink.
+	maxSize, dr, drErr := getMaxDownlinkPayloadSize(d, dp, ds)
+	if drErr != nil {
+		log.WithError(drErr).WithField("dev_eui", d.DevEUI).Warning("get max downlink payload size error")
+	} else if len(req.Item.FrmPayload) > maxSize {
+		if d.Mode != storage.DeviceModeA {
+			return nil, grpc.Errorf(codes.InvalidArgument, "frm_payload exceeds the max payload size of %d bytes for data-rate %d", maxSize, dr)
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":          d.DevEUI,
+			"dr":               dr,
+			"max_payload_size": maxSize,
+			"payload_size":     len(req.Item.FrmPayload),
+		}).Warning("device-queue item exceeds the max payload size for the current data-rate")
+	}
+
+	// Reject the item when its transmission would exceed the downlink
+	// airtime budget of the service-profile. When the data-rate is unknown,
+	// only the consumed airtime is validated.
+	sp, err := storage.GetAndCacheServiceProfile(storage.DB(), storage.RedisPool(), d.ServiceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	var frameAirtime time.Duration
+	if drErr == nil {
+		frameAirtime, err = getDownlinkDataAirtime(dr, len(req.Item.FrmPayload))
+		if err != nil {
+			log.WithError(err).WithField("dev_eui", d.DevEUI).Warning("get downlink airtime error")
+		}
+	}
+	exceeded, err := storage.DeviceAirtimeBudgetExceeded(storage.RedisPool(), d.DevEUI, storage.DownlinkAirtime, frameAirtime, time.Duration(sp.DLAirtimeBudget)*time.Millisecond)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	if exceeded {
+		return nil, grpc.Errorf(codes.ResourceExhausted, "downlink airtime budget of %d ms per 24 hours exceeded", sp.DLAirtimeBudget)
+	}
+
 	qi := storage.DeviceQueueItem{
 		DevAddr:    devAddr,
 		DevEUI:     d.DevEUI,
@@ -1311,6 +2218,25 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		FCnt:       req.Item.FCnt,
 		FPort:      uint8(req.Item.FPort),
 		Confirmed:  req.Item.Confirmed,
+		Reference:  req.Item.Reference,
+	}
+
+	// The downlink is pinned to the given gateway, validate that it exists
+	// and is able to transmit.
+	if len(req.Item.GatewayId) != 0 {
+		var gatewayID lorawan.EUI64
+		copy(gatewayID[:], req.Item.GatewayId)
+
+		g, err := storage.GetAndCacheGateway(storage.DB(), storage.RedisPool(), gatewayID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		if g.MaintenanceMode {
+			return nil, grpc.Errorf(codes.FailedPrecondition, "gateway %s is in maintenance mode", gatewayID)
+		}
+
+		qi.GatewayID = &gatewayID
 	}
 
 	// When the device is operating in Class-B and has a beacon lock, calculate
@@ -1351,6 +2277,10 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 
 // FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
 func (n *NetworkServerAPI) FlushDeviceQueueForDevEUI(ctx context.Context, req *ns.FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
@@ -1364,6 +2294,10 @@ func (n *NetworkServerAPI) FlushDeviceQueueForDevEUI(ctx context.Context, req *n
 
 // GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
 func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req *ns.GetDeviceQueueItemsForDevEUIRequest) (*ns.GetDeviceQueueItemsForDevEUIResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
@@ -1381,6 +2315,11 @@ func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req
 			FCnt:       items[i].FCnt,
 			FPort:      uint32(items[i].FPort),
 			Confirmed:  items[i].Confirmed,
+			Reference:  items[i].Reference,
+		}
+
+		if items[i].GatewayID != nil {
+			qi.GatewayId = items[i].GatewayID[:]
 		}
 
 		out.Items = append(out.Items, &qi)
@@ -1394,6 +2333,10 @@ func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req
 // In case the device is not activated, this will return an error as no
 // device-session exists.
 func (n *NetworkServerAPI) GetNextDownlinkFCntForDevEUI(ctx context.Context, req *ns.GetNextDownlinkFCntForDevEUIRequest) (*ns.GetNextDownlinkFCntForDevEUIResponse, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	var resp ns.GetNextDownlinkFCntForDevEUIResponse
 
@@ -1443,6 +2386,13 @@ func (n *NetworkServerAPI) CreateMulticastGroup(ctx context.Context, req *ns.Cre
 		return nil, grpc.Errorf(codes.InvalidArgument, "invalid group_type")
 	}
 
+	if err := validateBytesFields(
+		devAddrField("multicast_group.mc_addr", req.MulticastGroup.McAddr),
+		keyField("multicast_group.mc_nwk_s_key", req.MulticastGroup.McNwkSKey),
+	); err != nil {
+		return nil, err
+	}
+
 	copy(mg.ID[:], req.MulticastGroup.Id)
 	copy(mg.MCAddr[:], req.MulticastGroup.McAddr)
 	copy(mg.MCNwkSKey[:], req.MulticastGroup.McNwkSKey)
@@ -1510,6 +2460,13 @@ func (n *NetworkServerAPI) UpdateMulticastGroup(ctx context.Context, req *ns.Upd
 		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
 	}
 
+	if err := validateBytesFields(
+		devAddrField("multicast_group.mc_addr", req.MulticastGroup.McAddr),
+		keyField("multicast_group.mc_nwk_s_key", req.MulticastGroup.McNwkSKey),
+	); err != nil {
+		return nil, err
+	}
+
 	var mgID uuid.UUID
 	copy(mgID[:], req.MulticastGroup.Id)
 
@@ -1564,6 +2521,10 @@ func (n *NetworkServerAPI) DeleteMulticastGroup(ctx context.Context, req *ns.Del
 
 // AddDeviceToMulticastGroup adds the given device to the given multicast-group.
 func (n *NetworkServerAPI) AddDeviceToMulticastGroup(ctx context.Context, req *ns.AddDeviceToMulticastGroupRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	var mgID uuid.UUID
 	copy(devEUI[:], req.DevEui)
@@ -1578,6 +2539,10 @@ func (n *NetworkServerAPI) AddDeviceToMulticastGroup(ctx context.Context, req *n
 
 // RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
 func (n *NetworkServerAPI) RemoveDeviceFromMulticastGroup(ctx context.Context, req *ns.RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error) {
+	if err := validateBytesFields(devEUIField("dev_eui", req.DevEui)); err != nil {
+		return nil, err
+	}
+
 	var devEUI lorawan.EUI64
 	var mgID uuid.UUID
 	copy(devEUI[:], req.DevEui)
@@ -1659,6 +2624,9 @@ func (n *NetworkServerAPI) GetMulticastQueueItemsForMulticastGroup(ctx context.C
 	return &out, nil
 }
 
+// supportedMACVersions contains the supported LoRaWAN MAC versions.
+var supportedMACVersions = []string{"1.0.0", "1.0.1", "1.0.2", "1.0.3", "1.1.0"}
+
 // GetVersion returns the LoRa Server version.
 func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*ns.GetVersionResponse, error) {
 	region, ok := map[string]common.Region{
@@ -1681,7 +2649,201 @@ func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*n
 	}
 
 	return &ns.GetVersionResponse{
-		Region:  region,
-		Version: config.Version,
+		Region:            region,
+		Version:           config.Version,
+		BandName:          string(config.C.NetworkServer.Band.Name),
+		NetId:             config.C.NetworkServer.NetID[:],
+		MacVersions:       supportedMACVersions,
+		UplinkTxPowerEirp: band.GetUplinkTXPowerEIRPs(),
 	}, nil
 }
+
+func deviceSessionToProto(ds storage.DeviceSession) *ns.DeviceSession {
+	// the effective RX window, taking the RX window preference into account
+	rxWindow := ns.RXWindow(ds.RXWindow)
+	if data.GetRXWindow(ds) == 2 {
+		rxWindow = ns.RXWindow_RX2
+	}
+
+	out := ns.DeviceSession{
+		DevEui:                    ds.DevEUI[:],
+		DevAddr:                   ds.DevAddr[:],
+		JoinEui:                   ds.JoinEUI[:],
+		MacVersion:                ds.MACVersion,
+		DeviceProfileId:           ds.DeviceProfileID.Bytes(),
+		ServiceProfileId:          ds.ServiceProfileID.Bytes(),
+		RoutingProfileId:          ds.RoutingProfileID.Bytes(),
+		FCntUp:                    ds.FCntUp,
+		NFCntDown:                 ds.NFCntDown,
+		AFCntDown:                 ds.AFCntDown,
+		ConfFCnt:                  ds.ConfFCnt,
+		SkipFCntCheck:             ds.SkipFCntValidation,
+		RxWindow:                  rxWindow,
+		RxDelay:                   uint32(ds.RXDelay),
+		Rx1DrOffset:               uint32(ds.RX1DROffset),
+		Rx2Dr:                     uint32(ds.RX2DR),
+		Rx2Frequency:              uint32(ds.RX2Frequency),
+		TxPowerIndex:              uint32(ds.TXPowerIndex),
+		Dr:                        uint32(ds.DR),
+		Adr:                       ds.ADR,
+		MinSupportedTxPowerIndex:  uint32(ds.MinSupportedTXPowerIndex),
+		MaxSupportedTxPowerIndex:  uint32(ds.MaxSupportedTXPowerIndex),
+		NbTrans:                   uint32(ds.NbTrans),
+		UplinkHistoryCount:        uint32(len(ds.UplinkHistory)),
+		BeaconLocked:              ds.BeaconLocked,
+		PingSlotNb:                uint32(ds.PingSlotNb),
+		PingSlotDr:                uint32(ds.PingSlotDR),
+		PingSlotFrequency:         uint32(ds.PingSlotFrequency),
+		ReferenceAltitude:         ds.ReferenceAltitude,
+		UplinkDwellTime_400Ms:     ds.UplinkDwellTime400ms,
+		DownlinkDwellTime_400Ms:   ds.DownlinkDwellTime400ms,
+		UplinkMaxEirpIndex:        uint32(ds.UplinkMaxEIRPIndex),
+		InstallationMargin:        adr.GetInstallationMargin(ds),
+		RxWindowPreference:        ns.RXWindowPreference(ds.RXWindowPreference),
+		UplinkRetransmissionCount: ds.UplinkRetransmissionCount,
+		SkipFCntValidationCount:   ds.SkipFCntValidationCount,
+		MaxSupportedDr:            uint32(ds.MaxSupportedDR),
+		MaxSupportedDrSet:         ds.MaxSupportedDRSet,
+		LinkAdrReqRejectionCount:  uint32(ds.LinkADRReqRejectionCount),
+		ChannelMaskRejected:       ds.ChannelMaskRejected,
+		AdrStopped:                ds.ADRStopped,
+	}
+
+	for _, c := range ds.EnabledUplinkChannels {
+		out.EnabledUplinkChannels = append(out.EnabledUplinkChannels, uint32(c))
+	}
+
+	for _, f := range ds.ChannelFrequencies {
+		out.ChannelFrequencies = append(out.ChannelFrequencies, uint32(f))
+	}
+
+	if !ds.LastDevStatusRequested.IsZero() {
+		out.LastDevStatusRequestedAt, _ = ptypes.TimestampProto(ds.LastDevStatusRequested)
+	}
+
+	if !ds.LastDownlinkTX.IsZero() {
+		out.LastDownlinkTxAt, _ = ptypes.TimestampProto(ds.LastDownlinkTX)
+	}
+
+	if ds.LastDevStatus != nil {
+		out.DevStatusBattery = uint32(ds.LastDevStatus.Battery)
+		out.DevStatusMargin = int32(ds.LastDevStatus.Margin)
+		out.DevStatusReceivedAt, _ = ptypes.TimestampProto(ds.LastDevStatus.ReceivedAt)
+	}
+
+	for _, rxInfo := range ds.LastRXInfoSet {
+		item := ns.DeviceSessionRXInfo{
+			GatewayId: rxInfo.GatewayID[:],
+			Rssi:      int32(rxInfo.RSSI),
+			LoraSnr:   rxInfo.LoRaSNR,
+			Board:     rxInfo.Board,
+			Antenna:   rxInfo.Antenna,
+		}
+		item.Time, _ = ptypes.TimestampProto(rxInfo.Time)
+		if rxInfo.TimeSinceGPSEpoch != 0 {
+			item.TimeSinceGpsEpoch = ptypes.DurationProto(rxInfo.TimeSinceGPSEpoch)
+		}
+		out.LastRxInfoSet = append(out.LastRxInfoSet, &item)
+	}
+
+	if ds.LastTXInfo != nil {
+		out.LastTxInfo = &ns.DeviceSessionTXInfo{
+			GatewayId: ds.LastTXInfo.GatewayID[:],
+			Frequency: uint32(ds.LastTXInfo.Frequency),
+			Dr:        uint32(ds.LastTXInfo.DR),
+			TxPower:   int32(ds.LastTXInfo.TXPower),
+			FCnt:      ds.LastTXInfo.FCnt,
+			Airtime:   ptypes.DurationProto(ds.LastTXInfo.Airtime),
+		}
+		out.LastTxInfo.Time, _ = ptypes.TimestampProto(ds.LastTXInfo.Time)
+	}
+
+	return &out
+}
+
+// airtimeMetricToProto returns the given airtime metric (in milliseconds)
+// as duration.
+func airtimeMetricToProto(ms float64) *duration.Duration {
+	return ptypes.DurationProto(time.Duration(math.Round(ms * float64(time.Millisecond))))
+}
+
+// getMaxDownlinkPayloadSize returns the max FRMPayload size and the data-rate
+// of the next downlink opportunity of the device, based on the device class
+// and the downlink dwell-time limitation.
+func getMaxDownlinkPayloadSize(d storage.Device, dp storage.DeviceProfile, ds storage.DeviceSession) (int, int, error) {
+	var dr int
+	switch d.Mode {
+	case storage.DeviceModeB:
+		dr = ds.PingSlotDR
+	case storage.DeviceModeC:
+		dr = int(ds.RX2DR)
+	default:
+		if data.GetRXWindow(ds) == 2 {
+			dr = int(ds.RX2DR)
+		} else {
+			var err error
+			dr, err = band.Band().GetRX1DataRateIndex(ds.DR, int(ds.RX1DROffset))
+			if err != nil {
+				return 0, 0, errors.Wrap(err, "get rx1 data-rate index error")
+			}
+		}
+	}
+
+	plSize, err := band.BandForDownlinkDwellTime(ds.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(dp.MACVersion, dp.RegParamsRevision, dr)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "get max-payload size error")
+	}
+
+	return plSize.N, dr, nil
+}
+
+// getDownlinkDataAirtime returns the airtime of a downlink data frame
+// carrying a FRMPayload of the given size at the given data-rate.
+func getDownlinkDataAirtime(dr, frmPayloadSize int) (time.Duration, error) {
+	var txInfo gw.DownlinkTXInfo
+	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, dr, band.Band()); err != nil {
+		return 0, errors.Wrap(err, "set downlink tx-info data-rate error")
+	}
+
+	return airtime.GetDownlinkDataAirtime(frmPayloadSize, &txInfo)
+}
+
+// getMetrics returns the metrics for the given name and interval. When the
+// timezone is set, the metrics are grouped by this timezone.
+func getMetrics(name string, interval ns.AggregationInterval, start, end time.Time, timezone string) ([]storage.MetricsRecord, error) {
+	if timezone == "" {
+		metrics, err := storage.GetMetrics(storage.RedisPool(), storage.AggregationInterval(interval.String()), name, start, end)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		return metrics, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid timezone: %s", err)
+	}
+
+	metrics, err := storage.GetMetricsForLocation(storage.RedisPool(), storage.AggregationInterval(interval.String()), name, start, end, loc)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	return metrics, nil
+}
+
+// gatewayDownlinkTXPower returns the effective downlink TX power (dBm) of
+// the given gateway at the RX2 frequency of the band.
+func gatewayDownlinkTXPower(gw storage.Gateway) int {
+	if p := config.C.NetworkServer.NetworkSettings.DownlinkTXPower; p != -1 {
+		return p
+	}
+	return gw.GetDownlinkTXPower(band.Band().GetDefaults().RX2Frequency)
+}
+
+// gatewayLocationSource returns the source of the gateway location.
+func gatewayLocationSource(gw storage.Gateway) common.LocationSource {
+	if gw.LocationFromGPS {
+		return common.LocationSource_GPS
+	}
+	return common.LocationSource_CONFIG
+}
@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
@@ -562,6 +564,7 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 					PingSlotFrequency:     868100000,
 					NbTrans:               1,
 					MACVersion:            "1.0.2",
+					Version:               1,
 				}, ds)
 			})
 
@@ -581,6 +584,10 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 					AFCntDown:     12,
 					SkipFCntCheck: true,
 				}, resp.DeviceActivation)
+
+				ttl, err := ptypes.Duration(resp.DeviceSessionTtl)
+				assert.NoError(err)
+				assert.True(ttl > 0 && ttl <= time.Hour)
 			})
 
 			t.Run("GetNextDownlinkFCntForDevEUI", func(t *testing.T) {
@@ -628,6 +635,18 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 				assert.NoError(err)
 				assert.Len(items, 1)
 
+				linkADRReq := storage.MACCommandBlock{
+					CID: lorawan.LinkADRReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID:     lorawan.LinkADRReq,
+							Payload: &lorawan.LinkADRReqPayload{DataRate: 1},
+						},
+					},
+				}
+				assert.NoError(storage.CreateMACCommandQueueItem(storage.RedisPool(), devEUI, linkADRReq))
+				assert.NoError(storage.SetPendingMACCommand(storage.RedisPool(), devEUI, linkADRReq))
+
 				_, err = ts.api.DeactivateDevice(context.Background(), &ns.DeactivateDeviceRequest{
 					DevEui: devEUI[:],
 				})
@@ -639,6 +658,33 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 				items, err = storage.GetDeviceQueueItemsForDevEUI(storage.DB(), devEUI)
 				assert.NoError(err)
 				assert.Len(items, 0)
+
+				blocks, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+				assert.NoError(err)
+				assert.Len(blocks, 0)
+
+				pending, err := storage.GetPendingMACCommand(storage.RedisPool(), devEUI, lorawan.LinkADRReq)
+				assert.NoError(err)
+				assert.Nil(pending)
+
+				t.Run("Re-activate", func(t *testing.T) {
+					assert := require.New(t)
+
+					_, err := ts.api.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
+						DeviceActivation: &ns.DeviceActivation{
+							DevEui:      devEUI[:],
+							DevAddr:     devAddr[:],
+							SNwkSIntKey: sNwkSIntKey[:],
+							FNwkSIntKey: fNwkSIntKey[:],
+							NwkSEncKey:  nwkSEncKey[:],
+						},
+					})
+					assert.NoError(err)
+
+					blocks, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+					assert.NoError(err)
+					assert.Len(blocks, 0)
+				})
 			})
 
 			t.Run("Activate with Device.SkipFCntCheck set to true", func(t *testing.T) {
@@ -776,6 +822,237 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 	})
 }
 
+func (ts *NetworkServerAPITestSuite) TestDeviceSession() {
+	assert := require.New(ts.T())
+
+	ds := storage.DeviceSession{
+		DevEUI:                lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+		MACVersion:            "1.0.2",
+		FCntUp:                10,
+		NFCntDown:             11,
+		TXPowerIndex:          2,
+		DR:                    5,
+		NbTrans:               1,
+		RX2Frequency:          869525000,
+		EnabledUplinkChannels: []int{0, 1, 2},
+		UplinkHistory: []storage.UplinkHistory{
+			{FCnt: 9, MaxSNR: 5},
+		},
+	}
+	assert.NoError(storage.SaveDeviceSession(storage.RedisPool(), ds))
+
+	ts.T().Run("GetDeviceSession", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetDeviceSession(context.Background(), &ns.GetDeviceSessionRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(ds.DevEUI[:], resp.DeviceSession.DevEui)
+		assert.Equal(ds.DevAddr[:], resp.DeviceSession.DevAddr)
+		assert.EqualValues(10, resp.DeviceSession.FCntUp)
+		assert.EqualValues(11, resp.DeviceSession.NFCntDown)
+		assert.EqualValues(2, resp.DeviceSession.TxPowerIndex)
+		assert.EqualValues(5, resp.DeviceSession.Dr)
+		assert.EqualValues(1, resp.DeviceSession.NbTrans)
+		assert.EqualValues(869525000, resp.DeviceSession.Rx2Frequency)
+		assert.Equal([]uint32{0, 1, 2}, resp.DeviceSession.EnabledUplinkChannels)
+		assert.EqualValues(1, resp.DeviceSession.UplinkHistoryCount)
+	})
+
+	ts.T().Run("GetDeviceSession does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetDeviceSession(context.Background(), &ns.GetDeviceSessionRequest{
+			DevEui: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+
+	ts.T().Run("GetDeviceSessionsForDevAddr", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetDeviceSessionsForDevAddr(context.Background(), &ns.GetDeviceSessionsForDevAddrRequest{
+			DevAddr: ds.DevAddr[:],
+		})
+		assert.NoError(err)
+		assert.Len(resp.DeviceSessions, 1)
+		assert.Equal(ds.DevEUI[:], resp.DeviceSessions[0].DevEui)
+	})
+
+	ts.T().Run("UpdateDeviceSessionInstallationMargin", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.UpdateDeviceSessionInstallationMargin(context.Background(), &ns.UpdateDeviceSessionInstallationMarginRequest{
+			DevEui:             ds.DevEUI[:],
+			InstallationMargin: 7.5,
+		})
+		assert.NoError(err)
+
+		dsUpdated, err := storage.GetDeviceSession(storage.RedisPool(), ds.DevEUI)
+		assert.NoError(err)
+		assert.Equal(7.5, dsUpdated.InstallationMargin)
+
+		resp, err := ts.api.GetDeviceSession(context.Background(), &ns.GetDeviceSessionRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(7.5, resp.DeviceSession.InstallationMargin)
+	})
+
+	ts.T().Run("UpdateDeviceSessionRXWindow", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.UpdateDeviceSessionRXWindow(context.Background(), &ns.UpdateDeviceSessionRXWindowRequest{
+			DevEui:             ds.DevEUI[:],
+			RxWindowPreference: ns.RXWindowPreference_RX_WINDOW_RX2,
+		})
+		assert.NoError(err)
+
+		dsUpdated, err := storage.GetDeviceSession(storage.RedisPool(), ds.DevEUI)
+		assert.NoError(err)
+		assert.Equal(storage.RXWindowPreferenceRX2, dsUpdated.RXWindowPreference)
+
+		resp, err := ts.api.GetDeviceSession(context.Background(), &ns.GetDeviceSessionRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(ns.RXWindowPreference_RX_WINDOW_RX2, resp.DeviceSession.RxWindowPreference)
+		assert.Equal(ns.RXWindow_RX2, resp.DeviceSession.RxWindow)
+
+		_, err = ts.api.UpdateDeviceSessionRXWindow(context.Background(), &ns.UpdateDeviceSessionRXWindowRequest{
+			DevEui:             ds.DevEUI[:],
+			RxWindowPreference: ns.RXWindowPreference(10),
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("ResetDeviceSessionLinkADRState", func(t *testing.T) {
+		assert := require.New(t)
+
+		dsRejected, err := storage.GetDeviceSession(storage.RedisPool(), ds.DevEUI)
+		assert.NoError(err)
+		dsRejected.MaxSupportedDR = 0
+		dsRejected.MaxSupportedDRSet = true
+		dsRejected.LinkADRReqRejectionCount = 3
+		dsRejected.ChannelMaskRejected = true
+		dsRejected.ADRStopped = true
+		assert.NoError(storage.SaveDeviceSession(storage.RedisPool(), dsRejected))
+
+		resp, err := ts.api.GetDeviceSession(context.Background(), &ns.GetDeviceSessionRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.True(resp.DeviceSession.MaxSupportedDrSet)
+		assert.True(resp.DeviceSession.AdrStopped)
+
+		_, err = ts.api.ResetDeviceSessionLinkADRState(context.Background(), &ns.ResetDeviceSessionLinkADRStateRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+
+		dsUpdated, err := storage.GetDeviceSession(storage.RedisPool(), ds.DevEUI)
+		assert.NoError(err)
+		assert.False(dsUpdated.MaxSupportedDRSet)
+		assert.Equal(0, dsUpdated.LinkADRReqRejectionCount)
+		assert.False(dsUpdated.ChannelMaskRejected)
+		assert.False(dsUpdated.ADRStopped)
+	})
+
+	ts.T().Run("GetDeviceLinkMetrics", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetDeviceLinkMetrics(context.Background(), &ns.GetDeviceLinkMetricsRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal([]*ns.UplinkHistoryItem{
+			{FCnt: 9, MaxSnr: 5},
+		}, resp.UplinkHistory)
+		assert.Nil(resp.LastAdrDecision)
+
+		ds.LastLinkADRReq = &storage.LinkADRReq{
+			DR:           3,
+			TXPowerIndex: 1,
+			NbTrans:      1,
+			ACK:          true,
+		}
+		assert.NoError(storage.SaveDeviceSession(storage.RedisPool(), ds))
+
+		resp, err = ts.api.GetDeviceLinkMetrics(context.Background(), &ns.GetDeviceLinkMetricsRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(&ns.ADRDecision{
+			Dr:           3,
+			TxPowerIndex: 1,
+			NbTrans:      1,
+			Ack:          true,
+		}, resp.LastAdrDecision)
+	})
+
+	ts.T().Run("GetDeviceStatus", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetDeviceStatus(context.Background(), &ns.GetDeviceStatusRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.False(resp.Available)
+
+		ds.LastDevStatus = &storage.DevStatus{
+			Battery:    0,
+			Margin:     -3,
+			ReceivedAt: time.Now(),
+		}
+		assert.NoError(storage.SaveDeviceSession(storage.RedisPool(), ds))
+
+		resp, err = ts.api.GetDeviceStatus(context.Background(), &ns.GetDeviceStatusRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.True(resp.Available)
+		assert.True(resp.ExternalPowerSource)
+		assert.False(resp.BatteryLevelUnavailable)
+		assert.EqualValues(-3, resp.Margin)
+		assert.NotNil(resp.ReceivedAt)
+	})
+
+	ts.T().Run("GetDeviceLocation", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetDeviceLocation(context.Background(), &ns.GetDeviceLocationRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.False(resp.Available)
+
+		assert.NoError(storage.SaveDeviceLocation(storage.RedisPool(), storage.DeviceLocation{
+			DevEUI:     ds.DevEUI,
+			Latitude:   1.123,
+			Longitude:  2.123,
+			Altitude:   3.123,
+			Accuracy:   10,
+			ResolvedAt: time.Now(),
+		}))
+
+		resp, err = ts.api.GetDeviceLocation(context.Background(), &ns.GetDeviceLocationRequest{
+			DevEui: ds.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.True(resp.Available)
+		assert.Equal(&common.Location{
+			Latitude:  1.123,
+			Longitude: 2.123,
+			Altitude:  3.123,
+			Source:    common.LocationSource_GEO_RESOLVER,
+			Accuracy:  10,
+		}, resp.Location)
+		assert.NotNil(resp.ResolvedAt)
+	})
+}
+
 func TestNetworkServerAPINew(t *testing.T) {
 	suite.Run(t, new(NetworkServerAPITestSuite))
 }
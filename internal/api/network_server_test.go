@@ -23,6 +23,7 @@ import (
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
 )
 
 func TestNetworkServerAPI(t *testing.T) {
@@ -140,12 +141,14 @@ func TestNetworkServerAPI(t *testing.T) {
 			}()
 
 			Convey("When logging a downlink device frame", func() {
-				So(framelog.LogDownlinkFrameForDevEUI(storage.RedisPool(), devEUI, gw.DownlinkFrame{}), ShouldBeNil)
+				So(framelog.LogDownlinkFrameForDevEUI(storage.RedisPool(), devEUI, gw.DownlinkFrame{}, ns.RXWindow_RX2, ns.MACCommandTransport_FOPTS), ShouldBeNil)
 
 				Convey("Then the frame-log was received by the client", func() {
 					resp := <-respChan
 					So(resp.GetDownlinkFrame(), ShouldNotBeNil)
 					So(resp.GetUplinkFrameSet(), ShouldBeNil)
+					So(resp.DownlinkRxWindow, ShouldEqual, ns.RXWindow_RX2)
+					So(resp.DownlinkMacCommandTransport, ShouldEqual, ns.MACCommandTransport_FOPTS)
 				})
 			})
 
@@ -373,6 +376,7 @@ func TestNetworkServerAPI(t *testing.T) {
 					Supports_32BitFCnt:  true,
 					GeolocBufferTtl:     60,
 					GeolocMinBufferSize: 3,
+					AdrAlgorithmId:      "static",
 				},
 			})
 			So(err, ShouldBeNil)
@@ -407,8 +411,37 @@ func TestNetworkServerAPI(t *testing.T) {
 					Supports_32BitFCnt:  true,
 					GeolocBufferTtl:     60,
 					GeolocMinBufferSize: 3,
+					AdrAlgorithmId:      "static",
 				})
 			})
+
+			Convey("Then UpdateDeviceProfile rejects an invalid rx_window_preference", func() {
+				_, err := api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:                 resp.Id,
+						MacVersion:         "1.0.2",
+						AdrAlgorithmId:     "static",
+						RxWindowPreference: ns.RXWindowPreference(10),
+					},
+				})
+				So(err, ShouldNotBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When calling CreateDeviceProfile with an invalid rx_window_preference", func() {
+			_, err := api.CreateDeviceProfile(ctx, &ns.CreateDeviceProfileRequest{
+				DeviceProfile: &ns.DeviceProfile{
+					MacVersion:         "1.0.2",
+					AdrAlgorithmId:     "static",
+					RxWindowPreference: ns.RXWindowPreference(10),
+				},
+			})
+
+			Convey("Then an InvalidArgument error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
 		})
 
 		Convey("Given a ServiceProfile, RoutingProfile, DeviceProfile and Device", func() {
@@ -451,6 +484,71 @@ func TestNetworkServerAPI(t *testing.T) {
 			}
 			So(storage.SaveDeviceSession(storage.RedisPool(), ds), ShouldBeNil)
 
+			Convey("When enqueueing an item exceeding the max payload size", func() {
+				req := ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     devEUI[:],
+						FrmPayload: make([]byte, 60),
+						FCnt:       10,
+						FPort:      20,
+					},
+				}
+
+				Convey("Then it is accepted for a Class-A device", func() {
+					_, err := api.CreateDeviceQueueItem(ctx, &req)
+					So(err, ShouldBeNil)
+				})
+
+				Convey("Then it is rejected for a Class-C device", func() {
+					d.Mode = storage.DeviceModeC
+					So(storage.UpdateDevice(storage.DB(), &d), ShouldBeNil)
+
+					_, err := api.CreateDeviceQueueItem(ctx, &req)
+					So(err, ShouldNotBeNil)
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
+			Convey("When the device consumed the downlink airtime budget of the service-profile", func() {
+				sp.DLAirtimeBudget = 100
+				So(storage.UpdateServiceProfile(storage.DB(), &sp), ShouldBeNil)
+				So(storage.FlushServiceProfileCache(storage.RedisPool(), sp.ID), ShouldBeNil)
+				So(storage.AddDeviceAirtime(storage.RedisPool(), devEUI, storage.DownlinkAirtime, time.Now(), 100*time.Millisecond), ShouldBeNil)
+
+				Convey("Then CreateDeviceQueueItem returns a resource exhausted error", func() {
+					_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
+						Item: &ns.DeviceQueueItem{
+							DevEui:     devEUI[:],
+							FrmPayload: []byte{1, 2, 3, 4},
+							FCnt:       10,
+							FPort:      20,
+						},
+					})
+					So(err, ShouldNotBeNil)
+					So(grpc.Code(err), ShouldEqual, codes.ResourceExhausted)
+				})
+			})
+
+			Convey("When the item does not fit within the remaining downlink airtime budget of the service-profile", func() {
+				sp.DLAirtimeBudget = 100
+				So(storage.UpdateServiceProfile(storage.DB(), &sp), ShouldBeNil)
+				So(storage.FlushServiceProfileCache(storage.RedisPool(), sp.ID), ShouldBeNil)
+				So(storage.AddDeviceAirtime(storage.RedisPool(), devEUI, storage.DownlinkAirtime, time.Now(), 99*time.Millisecond), ShouldBeNil)
+
+				Convey("Then CreateDeviceQueueItem returns a resource exhausted error", func() {
+					_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
+						Item: &ns.DeviceQueueItem{
+							DevEui:     devEUI[:],
+							FrmPayload: []byte{1, 2, 3, 4},
+							FCnt:       10,
+							FPort:      20,
+						},
+					})
+					So(err, ShouldNotBeNil)
+					So(grpc.Code(err), ShouldEqual, codes.ResourceExhausted)
+				})
+			})
+
 			Convey("Given an item in the device-queue", func() {
 				_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
 					Item: &ns.DeviceQueueItem{
@@ -532,6 +630,40 @@ func TestNetworkServerAPI(t *testing.T) {
 								},
 							})
 						})
+
+						Convey("Then GetMACCommandQueueItems returns the mac-command", func() {
+							resp, err := api.GetMACCommandQueueItems(ctx, &ns.GetMACCommandQueueItemsRequest{
+								DevEui: devEUI[:],
+							})
+							So(err, ShouldBeNil)
+							So(resp.Items, ShouldResemble, []*ns.MACCommandQueueItem{
+								{
+									Cid:      uint32(lorawan.RXParamSetupReq),
+									External: true,
+									Commands: [][]byte{b},
+								},
+							})
+						})
+
+						Convey("Then DeleteMACCommandQueueItem deletes the mac-command", func() {
+							_, err := api.DeleteMACCommandQueueItem(ctx, &ns.DeleteMACCommandQueueItemRequest{
+								DevEui: devEUI[:],
+								Cid:    uint32(lorawan.RXParamSetupReq),
+							})
+							So(err, ShouldBeNil)
+
+							queue, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+							So(err, ShouldBeNil)
+							So(queue, ShouldHaveLength, 0)
+
+							Convey("Then deleting it again returns NotFound", func() {
+								_, err := api.DeleteMACCommandQueueItem(ctx, &ns.DeleteMACCommandQueueItemRequest{
+									DevEui: devEUI[:],
+									Cid:    uint32(lorawan.RXParamSetupReq),
+								})
+								So(grpc.Code(err), ShouldEqual, codes.NotFound)
+							})
+						})
 					})
 				})
 			})
@@ -660,7 +792,9 @@ func TestNetworkServerAPI(t *testing.T) {
 						Latitude:  1.1234,
 						Longitude: 1.1235,
 						Altitude:  15.5,
+						Source:    common.LocationSource_CONFIG,
 					},
+					UpdateLocationFromStats: true,
 					Boards: []*ns.GatewayBoard{
 						{
 							FpgaId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
@@ -684,6 +818,7 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(resp.CreatedAt.String(), ShouldNotEqual, "")
 				So(resp.UpdatedAt.String(), ShouldNotEqual, "")
 				So(resp.LastSeenAt, ShouldBeNil)
+				So(resp.LocationUpdatedAt, ShouldNotBeNil)
 			})
 
 			Convey("Then UpdateGateway updates the gateway", func() {
@@ -694,6 +829,7 @@ func TestNetworkServerAPI(t *testing.T) {
 							Latitude:  1.1235,
 							Longitude: 1.1236,
 							Altitude:  15.7,
+							Source:    common.LocationSource_CONFIG,
 						},
 						Boards: []*ns.GatewayBoard{
 							{
@@ -716,6 +852,7 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(resp.CreatedAt.String(), ShouldNotEqual, "")
 				So(resp.UpdatedAt.String(), ShouldNotEqual, "")
 				So(resp.LastSeenAt, ShouldBeNil)
+				So(resp.LocationUpdatedAt, ShouldNotBeNil)
 			})
 
 			Convey("Then DeleteGateway deletes the gateway", func() {
@@ -735,13 +872,19 @@ func TestNetworkServerAPI(t *testing.T) {
 				metrics := storage.MetricsRecord{
 					Time: now,
 					Metrics: map[string]float64{
-						"rx_count":    10,
-						"rx_ok_count": 5,
-						"tx_count":    11,
-						"tx_ok_count": 10,
+						"rx_count":                      10,
+						"rx_ok_count":                   5,
+						"tx_count":                      11,
+						"tx_ok_count":                   10,
+						"rx_airtime_ms":                 118.016,
+						"tx_airtime_ms":                 41.216,
+						"rx_count_freq_sf:868300000:7":  2,
+						"rx_count_freq_sf:868100000:12": 1,
+						"rx_count_freq_sf:868100000:7":  3,
 					},
 				}
 				So(storage.SaveMetricsForInterval(storage.RedisPool(), storage.AggregationMinute, "gw:0102030405060708", metrics), ShouldBeNil)
+				So(storage.SaveMetricsForInterval(storage.RedisPool(), storage.AggregationMinute, "network", metrics), ShouldBeNil)
 
 				Convey("Then GetGatewayStats returns these stats", func() {
 					start, _ := ptypes.TimestampProto(now.Truncate(time.Minute))
@@ -761,13 +904,60 @@ func TestNetworkServerAPI(t *testing.T) {
 					So(resp.Result[0].RxPacketsReceivedOk, ShouldEqual, 5)
 					So(resp.Result[0].TxPacketsReceived, ShouldEqual, 11)
 					So(resp.Result[0].TxPacketsEmitted, ShouldEqual, 10)
+					So(resp.Result[0].RxAirtime, ShouldResemble, ptypes.DurationProto(118016*time.Microsecond))
+					So(resp.Result[0].TxAirtime, ShouldResemble, ptypes.DurationProto(41216*time.Microsecond))
+					So(resp.Result[0].RxPacketsPerFrequencySf, ShouldResemble, []*ns.GatewayStatsRXPackets{
+						{Frequency: 868100000, SpreadingFactor: 7, RxPackets: 3},
+						{Frequency: 868100000, SpreadingFactor: 12, RxPackets: 1},
+						{Frequency: 868300000, SpreadingFactor: 7, RxPackets: 2},
+					})
+				})
+
+				Convey("Then GetGatewayStats returns an error for an invalid timezone", func() {
+					start, _ := ptypes.TimestampProto(now.Truncate(time.Minute))
+					end, _ := ptypes.TimestampProto(now)
+
+					_, err := api.GetGatewayStats(ctx, &ns.GetGatewayStatsRequest{
+						GatewayId:      []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Interval:       ns.AggregationInterval_DAY,
+						StartTimestamp: start,
+						EndTimestamp:   end,
+						Timezone:       "Invalid/Timezone",
+					})
+					So(err, ShouldNotBeNil)
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+
+				Convey("Then GetNetworkStats returns the network stats", func() {
+					start, _ := ptypes.TimestampProto(now.Truncate(time.Minute))
+					end, _ := ptypes.TimestampProto(now)
+					nowTrunc, _ := ptypes.TimestampProto(now.Truncate(time.Minute))
+
+					resp, err := api.GetNetworkStats(ctx, &ns.GetNetworkStatsRequest{
+						Interval:       ns.AggregationInterval_MINUTE,
+						StartTimestamp: start,
+						EndTimestamp:   end,
+					})
+					So(err, ShouldBeNil)
+					So(resp.GatewaysOnline, ShouldEqual, 0)
+					So(resp.DeviceSessions, ShouldEqual, 0)
+					So(resp.Result, ShouldHaveLength, 1)
+					So(resp.Result[0].Timestamp, ShouldResemble, nowTrunc)
+					So(resp.Result[0].RxPacketsReceived, ShouldEqual, 10)
+					So(resp.Result[0].RxPacketsReceivedOk, ShouldEqual, 5)
+					So(resp.Result[0].TxPacketsReceived, ShouldEqual, 11)
+					So(resp.Result[0].TxPacketsEmitted, ShouldEqual, 10)
+					So(resp.Result[0].RxAirtime, ShouldResemble, ptypes.DurationProto(118016*time.Microsecond))
+					So(resp.Result[0].TxAirtime, ShouldResemble, ptypes.DurationProto(41216*time.Microsecond))
 				})
 			})
 
 			Convey("When creating a gateway-profile object", func() {
 				req := ns.CreateGatewayProfileRequest{
 					GatewayProfile: &ns.GatewayProfile{
-						Channels: []uint32{0, 1, 2},
+						Name:          "test-profile",
+						StatsInterval: ptypes.DurationProto(30 * time.Second),
+						Channels:      []uint32{0, 1, 2},
 						ExtraChannels: []*ns.GatewayProfileExtraChannel{
 							{
 								Modulation:       common.Modulation_LORA,
@@ -880,12 +1070,16 @@ func TestNetworkServerAPI(t *testing.T) {
 
 			Convey("Then GetVersion returns the expected value", func() {
 				config.Version = "1.2.3"
+				config.C.NetworkServer.Band.Name = loraband.EU_863_870
 
 				resp, err := api.GetVersion(ctx, &empty.Empty{})
 				So(err, ShouldBeNil)
 				So(resp, ShouldResemble, &ns.GetVersionResponse{
-					Version: "1.2.3",
-					Region:  common.Region_EU868,
+					Version:     "1.2.3",
+					Region:      common.Region_EU868,
+					BandName:    "EU_863_870",
+					NetId:       []byte{1, 2, 3},
+					MacVersions: []string{"1.0.0", "1.0.1", "1.0.2", "1.0.3", "1.1.0"},
 				})
 			})
 		})
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/brocaar/loraserver/api/ns"
+)
+
+// Expected byte lengths of the request fields.
+const (
+	euiLength     = 8
+	devAddrLength = 4
+	keyLength     = 16
+	micLength     = 4
+)
+
+// bytesField defines a bytes field of a request with its expected length.
+type bytesField struct {
+	name     string
+	value    []byte
+	length   int
+	optional bool
+}
+
+// validateBytesFields validates that the given fields have exactly the
+// expected length. Optional fields may also be empty. Without this check,
+// copying a too short value into a fixed-size array would silently
+// zero-pad it.
+func validateBytesFields(fields ...bytesField) error {
+	for _, f := range fields {
+		if f.optional && len(f.value) == 0 {
+			continue
+		}
+
+		if len(f.value) != f.length {
+			return grpc.Errorf(codes.InvalidArgument, "%s must be exactly %d bytes, got %d", f.name, f.length, len(f.value))
+		}
+	}
+
+	return nil
+}
+
+// devEUIField returns the bytesField for a DevEUI.
+func devEUIField(name string, b []byte) bytesField {
+	return bytesField{name: name, value: b, length: euiLength}
+}
+
+// gatewayIDField returns the bytesField for a gateway ID.
+func gatewayIDField(name string, b []byte) bytesField {
+	return bytesField{name: name, value: b, length: euiLength}
+}
+
+// devAddrField returns the bytesField for a DevAddr.
+func devAddrField(name string, b []byte) bytesField {
+	return bytesField{name: name, value: b, length: devAddrLength}
+}
+
+// keyField returns the bytesField for an AES128 key.
+func keyField(name string, b []byte) bytesField {
+	return bytesField{name: name, value: b, length: keyLength}
+}
+
+// indexedName returns the field name for the i-th element of a repeated field.
+func indexedName(name string, i int) string {
+	return fmt.Sprintf("%s[%d]", name, i)
+}
+
+// gatewayBytesFields returns the bytes fields of the given gateway.
+func gatewayBytesFields(gw *ns.Gateway) []bytesField {
+	fields := []bytesField{
+		gatewayIDField("gateway.id", gw.Id),
+	}
+
+	for i, board := range gw.Boards {
+		fields = append(fields,
+			bytesField{name: indexedName("gateway.boards", i) + ".fpga_id", value: board.FpgaId, length: euiLength, optional: true},
+			bytesField{name: indexedName("gateway.boards", i) + ".fine_timestamp_key", value: board.FineTimestampKey, length: keyLength, optional: true},
+		)
+	}
+
+	return fields
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/ns"
+)
+
+func TestValidateBytesFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		fields        []bytesField
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			fields: []bytesField{devEUIField("dev_eui", make([]byte, 8)), keyField("key", make([]byte, 16))},
+		},
+		{
+			name:          "too short",
+			fields:        []bytesField{devEUIField("dev_eui", []byte{1, 2, 3})},
+			expectedError: "dev_eui must be exactly 8 bytes, got 3",
+		},
+		{
+			name:          "too long",
+			fields:        []bytesField{devAddrField("dev_addr", make([]byte, 5))},
+			expectedError: "dev_addr must be exactly 4 bytes, got 5",
+		},
+		{
+			name:          "empty",
+			fields:        []bytesField{keyField("nwk_s_enc_key", nil)},
+			expectedError: "nwk_s_enc_key must be exactly 16 bytes, got 0",
+		},
+		{
+			name:   "optional empty",
+			fields: []bytesField{{name: "dev_addr", length: devAddrLength, optional: true}},
+		},
+		{
+			name:          "optional invalid",
+			fields:        []bytesField{{name: "dev_addr", value: []byte{1}, length: devAddrLength, optional: true}},
+			expectedError: "dev_addr must be exactly 4 bytes, got 1",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			err := validateBytesFields(tst.fields...)
+			if tst.expectedError == "" {
+				assert.NoError(err)
+				return
+			}
+
+			assert.Equal(codes.InvalidArgument, grpc.Code(err))
+			assert.Equal(tst.expectedError, grpc.ErrorDesc(err))
+		})
+	}
+}
+
+func TestNetworkServerAPIBytesFieldValidation(t *testing.T) {
+	api := NewNetworkServerAPI()
+	ctx := context.Background()
+
+	devEUI := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	devAddr := []byte{1, 2, 3, 4}
+	key := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	tests := []struct {
+		name          string
+		call          func() error
+		expectedField string
+	}{
+		{
+			name: "ActivateDevice dev_eui",
+			call: func() error {
+				_, err := api.ActivateDevice(ctx, &ns.ActivateDeviceRequest{
+					DeviceActivation: &ns.DeviceActivation{
+						DevEui:      []byte{1, 2, 3},
+						DevAddr:     devAddr,
+						SNwkSIntKey: key,
+						FNwkSIntKey: key,
+						NwkSEncKey:  key,
+					},
+				})
+				return err
+			},
+			expectedField: "device_activation.dev_eui",
+		},
+		{
+			name: "ActivateDevice dev_addr",
+			call: func() error {
+				_, err := api.ActivateDevice(ctx, &ns.ActivateDeviceRequest{
+					DeviceActivation: &ns.DeviceActivation{
+						DevEui:      devEUI,
+						DevAddr:     []byte{1, 2},
+						SNwkSIntKey: key,
+						FNwkSIntKey: key,
+						NwkSEncKey:  key,
+					},
+				})
+				return err
+			},
+			expectedField: "device_activation.dev_addr",
+		},
+		{
+			name: "ActivateDevice nwk_s_enc_key",
+			call: func() error {
+				_, err := api.ActivateDevice(ctx, &ns.ActivateDeviceRequest{
+					DeviceActivation: &ns.DeviceActivation{
+						DevEui:      devEUI,
+						DevAddr:     devAddr,
+						SNwkSIntKey: key,
+						FNwkSIntKey: key,
+						NwkSEncKey:  key[:8],
+					},
+				})
+				return err
+			},
+			expectedField: "device_activation.nwk_s_enc_key",
+		},
+		{
+			name: "GetDeviceActivation dev_eui",
+			call: func() error {
+				_, err := api.GetDeviceActivation(ctx, &ns.GetDeviceActivationRequest{DevEui: []byte{1}})
+				return err
+			},
+			expectedField: "dev_eui",
+		},
+		{
+			name: "CreateDeviceQueueItem dev_addr",
+			call: func() error {
+				_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:  devEUI,
+						DevAddr: []byte{1, 2, 3, 4, 5},
+					},
+				})
+				return err
+			},
+			expectedField: "item.dev_addr",
+		},
+		{
+			name: "CreateMACCommandQueueItem dev_eui",
+			call: func() error {
+				_, err := api.CreateMACCommandQueueItem(ctx, &ns.CreateMACCommandQueueItemRequest{DevEui: devEUI[:7]})
+				return err
+			},
+			expectedField: "dev_eui",
+		},
+		{
+			name: "SendProprietaryPayload mic",
+			call: func() error {
+				_, err := api.SendProprietaryPayload(ctx, &ns.SendProprietaryPayloadRequest{
+					Mic:         []byte{1, 2},
+					GatewayMacs: [][]byte{devEUI},
+				})
+				return err
+			},
+			expectedField: "mic",
+		},
+		{
+			name: "SendProprietaryPayload gateway_macs",
+			call: func() error {
+				_, err := api.SendProprietaryPayload(ctx, &ns.SendProprietaryPayloadRequest{
+					Mic:         devAddr,
+					GatewayMacs: [][]byte{devEUI, {1, 2, 3}},
+				})
+				return err
+			},
+			expectedField: "gateway_macs[1]",
+		},
+		{
+			name: "CreateGateway id",
+			call: func() error {
+				_, err := api.CreateGateway(ctx, &ns.CreateGatewayRequest{
+					Gateway: &ns.Gateway{
+						Id:       []byte{1, 2, 3, 4},
+						Location: &common.Location{},
+					},
+				})
+				return err
+			},
+			expectedField: "gateway.id",
+		},
+		{
+			name: "UpdateGateway board fine_timestamp_key",
+			call: func() error {
+				_, err := api.UpdateGateway(ctx, &ns.UpdateGatewayRequest{
+					Gateway: &ns.Gateway{
+						Id:       devEUI,
+						Location: &common.Location{},
+						Boards: []*ns.GatewayBoard{
+							{},
+							{FineTimestampKey: []byte{1, 2, 3}},
+						},
+					},
+				})
+				return err
+			},
+			expectedField: "gateway.boards[1].fine_timestamp_key",
+		},
+		{
+			name: "GetGateway id",
+			call: func() error {
+				_, err := api.GetGateway(ctx, &ns.GetGatewayRequest{Id: nil})
+				return err
+			},
+			expectedField: "id",
+		},
+		{
+			name: "DeleteGateway id",
+			call: func() error {
+				_, err := api.DeleteGateway(ctx, &ns.DeleteGatewayRequest{Id: devEUI[:6]})
+				return err
+			},
+			expectedField: "id",
+		},
+		{
+			name: "GetGatewayStats gateway_id",
+			call: func() error {
+				_, err := api.GetGatewayStats(ctx, &ns.GetGatewayStatsRequest{GatewayId: []byte{1}})
+				return err
+			},
+			expectedField: "gateway_id",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			err := tst.call()
+			assert.Equal(codes.InvalidArgument, grpc.Code(err), "%v", err)
+			assert.Contains(grpc.ErrorDesc(err), tst.expectedField+" must be exactly")
+		})
+	}
+}
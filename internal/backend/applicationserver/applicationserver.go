@@ -1,6 +1,11 @@
 package applicationserver
 
-import "github.com/brocaar/loraserver/internal/api/client/asclient"
+import (
+	"io"
+
+	"github.com/brocaar/loraserver/internal/api/client/asclient"
+	"github.com/brocaar/loraserver/internal/config"
+)
 
 var pool asclient.Pool
 
@@ -15,7 +20,16 @@ func Pool() asclient.Pool {
 }
 
 // Setup sets up the application-server pool.
-func Setup() error {
-	pool = asclient.NewPool()
+func Setup(conf config.Config) error {
+	if c, ok := pool.(io.Closer); ok {
+		c.Close()
+	}
+
+	pool = asclient.NewPool(asclient.PoolOptions{
+		KeepaliveInterval: conf.ApplicationServer.KeepaliveInterval,
+		KeepaliveTimeout:  conf.ApplicationServer.KeepaliveTimeout,
+		ReconnectMaxDelay: conf.ApplicationServer.ReconnectMaxDelay,
+		IdleTimeout:       conf.ApplicationServer.IdleTimeout,
+	})
 	return nil
 }
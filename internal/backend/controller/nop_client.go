@@ -24,3 +24,9 @@ func (n *NopNetworkControllerClient) HandleUplinkMetaData(ctx context.Context, i
 func (n *NopNetworkControllerClient) HandleUplinkMACCommand(ctx context.Context, in *nc.HandleUplinkMACCommandRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
+
+// HandleGatewayStateChange handles a gateway offline or back-online
+// transition.
+func (n *NopNetworkControllerClient) HandleGatewayStateChange(ctx context.Context, in *nc.HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
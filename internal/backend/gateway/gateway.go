@@ -24,3 +24,9 @@ type Gateway interface {
 	DownlinkTXAckChan() chan gw.DownlinkTXAck              // channel containing the downlink tx acknowledgements
 	Close() error                                          // close the gateway backend.
 }
+
+// Connectable is implemented by gateway backends that are able to report
+// their connection state, e.g. to the MQTT broker.
+type Connectable interface {
+	IsConnected() bool // returns true when the backend is connected
+}
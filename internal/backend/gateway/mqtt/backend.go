@@ -110,6 +110,11 @@ func NewBackend(redisPool *redis.Pool, c config.Config) (gateway.Gateway, error)
 	return &b, nil
 }
 
+// IsConnected returns true when the backend is connected to the MQTT broker.
+func (b *Backend) IsConnected() bool {
+	return b.conn.IsConnectionOpen()
+}
+
 // Close closes the backend.
 // Note that this closes the backend one-way (gateway to backend).
 // This makes it possible to perform a graceful shutdown (e.g. when there are
@@ -30,24 +30,12 @@ type client struct {
 func (c *client) JoinReq(pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
 	var ans backend.JoinAnsPayload
 
-	b, err := json.Marshal(pl)
-	if err != nil {
-		return ans, errors.Wrap(err, "marshal request error")
-	}
-
-	resp, err := c.httpClient.Post(c.server, "application/json", bytes.NewReader(b))
-	if err != nil {
-		return ans, errors.Wrap(err, "http post error")
-	}
-	defer resp.Body.Close()
-
-	err = json.NewDecoder(resp.Body).Decode(&ans)
-	if err != nil {
-		return ans, errors.Wrap(err, "unmarshal response error")
+	if err := c.request(pl, &ans); err != nil {
+		return ans, err
 	}
 
-	if ans.Result.ResultCode != backend.Success {
-		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	if err := checkAnswer(pl.BasePayload, ans.BasePayload, backend.JoinAns, ans.Result); err != nil {
+		return ans, err
 	}
 
 	return ans, nil
@@ -57,27 +45,53 @@ func (c *client) JoinReq(pl backend.JoinReqPayload) (backend.JoinAnsPayload, err
 func (c *client) RejoinReq(pl backend.RejoinReqPayload) (backend.RejoinAnsPayload, error) {
 	var ans backend.RejoinAnsPayload
 
+	if err := c.request(pl, &ans); err != nil {
+		return ans, err
+	}
+
+	if err := checkAnswer(pl.BasePayload, ans.BasePayload, backend.RejoinAns, ans.Result); err != nil {
+		return ans, err
+	}
+
+	return ans, nil
+}
+
+func (c *client) request(pl, ans interface{}) error {
 	b, err := json.Marshal(pl)
 	if err != nil {
-		return ans, errors.Wrap(err, "marshal request error")
+		return errors.Wrap(err, "marshal request error")
 	}
 
 	resp, err := c.httpClient.Post(c.server, "application/json", bytes.NewReader(b))
 	if err != nil {
-		return ans, errors.Wrap(err, "http post error")
+		return errors.Wrap(err, "http post error")
 	}
 	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(&ans)
+	err = json.NewDecoder(resp.Body).Decode(ans)
 	if err != nil {
-		return ans, errors.Wrap(err, "unmarshal response error")
+		return errors.Wrapf(err, "unmarshal response error (http status: %s)", resp.Status)
+	}
+
+	return nil
+}
+
+// checkAnswer validates that the answer belongs to the given request and
+// that the join-server returned a successful result.
+func checkAnswer(req, ans backend.BasePayload, mt backend.MessageType, result backend.Result) error {
+	if result.ResultCode != backend.Success {
+		return fmt.Errorf("response error, code: %s, description: %s", result.ResultCode, result.Description)
 	}
 
-	if ans.Result.ResultCode != backend.Success {
-		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	if ans.MessageType != mt {
+		return fmt.Errorf("expected message-type %s, got: %s", mt, ans.MessageType)
 	}
 
-	return ans, nil
+	if ans.TransactionID != req.TransactionID {
+		return fmt.Errorf("expected transaction-id %d, got: %d", req.TransactionID, ans.TransactionID)
+	}
+
+	return nil
 }
 
 // NewClient creates a new join-server client.
@@ -0,0 +1,104 @@
+package joinserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestClient(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Answer        interface{}
+		ExpectedError string
+	}{
+		{
+			Name: "success",
+			Answer: backend.JoinAnsPayload{
+				BasePayload: backend.BasePayload{
+					MessageType:   backend.JoinAns,
+					TransactionID: 1234,
+				},
+				Result:     backend.Result{ResultCode: backend.Success},
+				PHYPayload: backend.HEXBytes{1, 2, 3},
+			},
+		},
+		{
+			Name: "error result",
+			Answer: backend.JoinAnsPayload{
+				BasePayload: backend.BasePayload{
+					MessageType:   backend.JoinAns,
+					TransactionID: 1234,
+				},
+				Result: backend.Result{ResultCode: backend.MICFailed, Description: "invalid mic"},
+			},
+			ExpectedError: "response error, code: MICFailed, description: invalid mic",
+		},
+		{
+			Name: "transaction-id mismatch",
+			Answer: backend.JoinAnsPayload{
+				BasePayload: backend.BasePayload{
+					MessageType:   backend.JoinAns,
+					TransactionID: 4321,
+				},
+				Result: backend.Result{ResultCode: backend.Success},
+			},
+			ExpectedError: "expected transaction-id 1234, got: 4321",
+		},
+		{
+			Name: "message-type mismatch",
+			Answer: backend.RejoinAnsPayload{
+				BasePayload: backend.BasePayload{
+					MessageType:   backend.RejoinAns,
+					TransactionID: 1234,
+				},
+				Result: backend.Result{ResultCode: backend.Success},
+			},
+			ExpectedError: "expected message-type JoinAns, got: RejoinAns",
+		},
+		{
+			Name:          "invalid response",
+			ExpectedError: "unmarshal response error (http status: 200 OK): EOF",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var req backend.JoinReqPayload
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(json.NewDecoder(r.Body).Decode(&req))
+				if tst.Answer != nil {
+					assert.NoError(json.NewEncoder(w).Encode(tst.Answer))
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(server.URL, "", "", "")
+			assert.NoError(err)
+
+			ans, err := c.JoinReq(backend.JoinReqPayload{
+				BasePayload: backend.BasePayload{
+					MessageType:   backend.JoinReq,
+					TransactionID: 1234,
+				},
+			})
+			assert.Equal(backend.JoinReq, req.MessageType)
+			assert.EqualValues(1234, req.TransactionID)
+
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(backend.HEXBytes{1, 2, 3}, ans.PHYPayload)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package band
 
 import (
+	"math"
+
 	"github.com/pkg/errors"
 
 	"github.com/brocaar/loraserver/internal/config"
@@ -8,7 +10,12 @@ import (
 	loraband "github.com/brocaar/lorawan/band"
 )
 
-var band loraband.Band
+var (
+	band          loraband.Band
+	dwellTimeBand loraband.Band
+	bandName      loraband.Name
+	uplinkMaxEIRP float32
+)
 
 // Setup sets up the band with the given configuration.
 func Setup(c config.Config) error {
@@ -16,20 +23,95 @@ func Setup(c config.Config) error {
 	if c.NetworkServer.Band.DownlinkDwellTime400ms {
 		dwellTime = lorawan.DwellTime400ms
 	}
+	bandConfig, err := getBandConfig(c, dwellTime)
+	if err != nil {
+		return err
+	}
+
+	// the band with the dwell-time limit applied is used for devices
+	// that did not (yet) acknowledge the configured downlink dwell-time
+	dwellTimeBandConfig, err := getBandConfig(c, lorawan.DwellTime400ms)
+	if err != nil {
+		return err
+	}
+
+	band = bandConfig
+	dwellTimeBand = dwellTimeBandConfig
+	bandName = c.NetworkServer.Band.Name
+
+	uplinkMaxEIRP = c.NetworkServer.Band.UplinkMaxEIRP
+	if uplinkMaxEIRP == -1 {
+		uplinkMaxEIRP = band.GetDefaultMaxUplinkEIRP()
+	}
+
+	return nil
+}
+
+func getBandConfig(c config.Config, dwellTime lorawan.DwellTime) (loraband.Band, error) {
 	bandConfig, err := loraband.GetConfig(c.NetworkServer.Band.Name, c.NetworkServer.Band.RepeaterCompatible, dwellTime)
 	if err != nil {
-		return errors.Wrap(err, "get band config error")
+		return nil, errors.Wrap(err, "get band config error")
 	}
 	for _, c := range config.C.NetworkServer.NetworkSettings.ExtraChannels {
 		if err := bandConfig.AddChannel(c.Frequency, c.MinDR, c.MaxDR); err != nil {
-			return errors.Wrap(err, "add channel error")
+			return nil, errors.Wrap(err, "add channel error")
 		}
 	}
-	band = bandConfig
-	return nil
+	return bandConfig, nil
 }
 
 // Band returns the configured band.
 func Band() loraband.Band {
 	return band
 }
+
+// BandForDownlinkDwellTime returns the configured band, with the 400ms
+// dwell-time limit applied when downlinkDwellTime400ms is set. This must be
+// used for the max-payload size calculation, as the device might not have
+// acknowledged the configured dwell-time yet.
+func BandForDownlinkDwellTime(downlinkDwellTime400ms bool) loraband.Band {
+	if downlinkDwellTime400ms {
+		return dwellTimeBand
+	}
+	return band
+}
+
+// DwellTime400msByDefault returns true when devices in the configured band
+// must assume the 400ms uplink and downlink dwell-time limit, until
+// configured otherwise by the TxParamSetupReq mac-command.
+func DwellTime400msByDefault() bool {
+	return bandName == loraband.AS_923 || bandName == loraband.AS923
+}
+
+// GetUplinkMaxEIRP returns the uplink max EIRP (dBm). This is either the
+// configured uplink max EIRP or the default of the band.
+func GetUplinkMaxEIRP() float32 {
+	return uplinkMaxEIRP
+}
+
+// GetUplinkTXPowerEIRPs returns the EIRP (dBm) for each TXPower index
+// supported by the band. The TXPower index of a LinkADRReq is used as index
+// into the returned slice.
+func GetUplinkTXPowerEIRPs() []float32 {
+	var out []float32
+	for i := 0; ; i++ {
+		offset, err := band.GetTXPowerOffset(i)
+		if err != nil {
+			break
+		}
+		out = append(out, uplinkMaxEIRP+float32(offset))
+	}
+	return out
+}
+
+// GetDownlinkTXPower returns the downlink TX power (dBm) for the given
+// frequency. This is the max EIRP of the band, capped by the given max EIRP
+// (when not 0), minus the given antenna gain (dBi). The result is rounded
+// down so that the max EIRP is never exceeded.
+func GetDownlinkTXPower(frequency, maxEIRP int, antennaGain float64) int {
+	eirp := band.GetDownlinkTXPower(frequency)
+	if maxEIRP != 0 && maxEIRP < eirp {
+		eirp = maxEIRP
+	}
+	return int(math.Floor(float64(eirp) - antennaGain))
+}
@@ -0,0 +1,126 @@
+package band
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/internal/config"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+func TestDwellTime(t *testing.T) {
+	tests := []struct {
+		Name                   string
+		Band                   loraband.Name
+		DownlinkDwellTime400ms bool
+
+		ExpectedDwellTimeByDefault bool
+		ExpectedMaxPayloadSize     int
+		ExpectedDwellTimeMaxSize   int
+	}{
+		{
+			Name:                       "AS923 without downlink dwell-time",
+			Band:                       loraband.AS_923,
+			ExpectedDwellTimeByDefault: true,
+			ExpectedMaxPayloadSize:     51,
+			ExpectedDwellTimeMaxSize:   0,
+		},
+		{
+			Name:                       "AS923 with downlink dwell-time",
+			Band:                       loraband.AS_923,
+			DownlinkDwellTime400ms:     true,
+			ExpectedDwellTimeByDefault: true,
+			ExpectedMaxPayloadSize:     0,
+			ExpectedDwellTimeMaxSize:   0,
+		},
+		{
+			Name:                       "EU868",
+			Band:                       loraband.EU_863_870,
+			ExpectedDwellTimeByDefault: false,
+			ExpectedMaxPayloadSize:     51,
+			ExpectedDwellTimeMaxSize:   51,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var conf config.Config
+			conf.NetworkServer.Band.Name = tst.Band
+			conf.NetworkServer.Band.DownlinkDwellTime400ms = tst.DownlinkDwellTime400ms
+			assert.NoError(Setup(conf))
+
+			assert.Equal(tst.ExpectedDwellTimeByDefault, DwellTime400msByDefault())
+
+			pl, err := BandForDownlinkDwellTime(false).GetMaxPayloadSizeForDataRateIndex("1.0.2", "B", 0)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedMaxPayloadSize, pl.N)
+
+			pl, err = BandForDownlinkDwellTime(true).GetMaxPayloadSizeForDataRateIndex("1.0.2", "B", 0)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedDwellTimeMaxSize, pl.N)
+		})
+	}
+}
+
+func TestTXPower(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Band          loraband.Name
+		UplinkMaxEIRP float32
+		MaxEIRP       int
+		AntennaGain   float64
+
+		ExpectedUplinkTXPowerEIRPs []float32
+		ExpectedDownlinkTXPower    int
+	}{
+		{
+			Name:                       "EU868 band default",
+			Band:                       loraband.EU_863_870,
+			UplinkMaxEIRP:              -1,
+			ExpectedUplinkTXPowerEIRPs: []float32{16, 14, 12, 10, 8, 6, 4, 2},
+			ExpectedDownlinkTXPower:    14,
+		},
+		{
+			Name:                       "EU868 configured max EIRP and antenna gain",
+			Band:                       loraband.EU_863_870,
+			UplinkMaxEIRP:              14,
+			AntennaGain:                2.5,
+			ExpectedUplinkTXPowerEIRPs: []float32{14, 12, 10, 8, 6, 4, 2, 0},
+			ExpectedDownlinkTXPower:    11,
+		},
+		{
+			Name:                       "EU868 max EIRP cap and antenna gain",
+			Band:                       loraband.EU_863_870,
+			UplinkMaxEIRP:              -1,
+			MaxEIRP:                    12,
+			AntennaGain:                3,
+			ExpectedUplinkTXPowerEIRPs: []float32{16, 14, 12, 10, 8, 6, 4, 2},
+			ExpectedDownlinkTXPower:    9,
+		},
+		{
+			Name:                       "EU868 max EIRP above band max EIRP",
+			Band:                       loraband.EU_863_870,
+			UplinkMaxEIRP:              -1,
+			MaxEIRP:                    20,
+			ExpectedUplinkTXPowerEIRPs: []float32{16, 14, 12, 10, 8, 6, 4, 2},
+			ExpectedDownlinkTXPower:    14,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var conf config.Config
+			conf.NetworkServer.Band.Name = tst.Band
+			conf.NetworkServer.Band.UplinkMaxEIRP = tst.UplinkMaxEIRP
+			assert.NoError(Setup(conf))
+
+			assert.Equal(tst.ExpectedUplinkTXPowerEIRPs, GetUplinkTXPowerEIRPs())
+			assert.Equal(tst.ExpectedDownlinkTXPower, GetDownlinkTXPower(868100000, tst.MaxEIRP, tst.AntennaGain))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package channels
 
 import (
+	"sort"
+
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
@@ -9,9 +11,22 @@ import (
 // HandleChannelReconfigure handles the reconfiguration of active channels
 // on the node. This is needed in case only a sub-set of channels is used
 // (e.g. for the US band) or when a reconfiguration of active channels
-// happens.
-func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
-	payloads := band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+// happens. When the service-profile has a channel-mask, the enabled channels
+// are limited to the channels within this mask.
+// No reconfiguration is requested when the device rejected a previous
+// channel-mask, in which case the known-good channels are kept.
+func HandleChannelReconfigure(sp storage.ServiceProfile, ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
+	if ds.ChannelMaskRejected {
+		return nil, nil
+	}
+
+	var payloads []lorawan.LinkADRReqPayload
+	if len(sp.ChannelMask) == 0 {
+		payloads = band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+	} else {
+		payloads = getLinkADRReqPayloadsForUplinkChannelIndices(ds.EnabledUplinkChannels, GetEnabledUplinkChannelIndices(sp, ds))
+	}
+
 	if len(payloads) == 0 {
 		return nil, nil
 	}
@@ -32,3 +47,183 @@ func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlo
 
 	return []storage.MACCommandBlock{block}, nil
 }
+
+// GetEnabledUplinkChannelIndices returns the uplink channels that must be
+// enabled on the given device. These are the channels enabled by the band
+// configuration, limited to the channel-mask of the service-profile (when
+// set). Bit n (LSB first) of the channel-mask enables channel n.
+// Note that custom (CFList) channels are only returned when they are already
+// active on the device, as we have no knowledge if the device has been
+// provisioned with these frequencies.
+func GetEnabledUplinkChannelIndices(sp storage.ServiceProfile, ds storage.DeviceSession) []int {
+	custom := make(map[int]bool)
+	for _, c := range band.Band().GetCustomUplinkChannelIndices() {
+		custom[c] = true
+	}
+
+	active := make(map[int]bool)
+	for _, c := range ds.EnabledUplinkChannels {
+		active[c] = true
+	}
+
+	var out []int
+	for _, c := range band.Band().GetEnabledUplinkChannelIndices() {
+		if custom[c] && !active[c] {
+			continue
+		}
+
+		if len(sp.ChannelMask) != 0 && (c/8 >= len(sp.ChannelMask) || sp.ChannelMask[c/8]&(1<<uint(c%8)) == 0) {
+			continue
+		}
+
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// getLinkADRReqPayloadsForUplinkChannelIndices returns the LinkADRReq
+// payloads to reconfigure the device from the device enabled channels to
+// the given channels. Only the blocks of 16 channels containing changes
+// are returned.
+func getLinkADRReqPayloadsForUplinkChannelIndices(deviceEnabledChannels, channels []int) []lorawan.LinkADRReqPayload {
+	// never disable all channels
+	if len(channels) == 0 {
+		return nil
+	}
+
+	active := make(map[int]bool)
+	for _, c := range deviceEnabledChannels {
+		active[c] = true
+	}
+
+	wanted := make(map[int]bool)
+	for _, c := range channels {
+		wanted[c] = true
+	}
+
+	payloads := getLinkADRReqPayloadsForChangedBlocks(active, wanted)
+
+	// US915 and AU915 support turning off all 125 kHz channels at once,
+	// which often results in less payloads when only a single sub-band
+	// must be enabled
+	if name := band.Band().Name(); name == "US915" || name == "AU915" {
+		if pls := getLinkADRReqPayloadsForSubBands(channels); len(pls) < len(payloads) {
+			return pls
+		}
+	}
+
+	return payloads
+}
+
+// getLinkADRReqPayloadsForChangedBlocks returns a LinkADRReq payload for
+// each block of 16 channels containing changes.
+func getLinkADRReqPayloadsForChangedBlocks(active, wanted map[int]bool) []lorawan.LinkADRReqPayload {
+	var payloads []lorawan.LinkADRReqPayload
+	channelCount := len(band.Band().GetUplinkChannelIndices())
+
+	for chMaskCntl := 0; chMaskCntl*16 < channelCount; chMaskCntl++ {
+		var changed bool
+		pl := lorawan.LinkADRReqPayload{
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: uint8(chMaskCntl),
+			},
+		}
+
+		for i := range pl.ChMask {
+			c := chMaskCntl*16 + i
+			pl.ChMask[i] = wanted[c]
+			if wanted[c] != active[c] {
+				changed = true
+			}
+		}
+
+		if changed {
+			payloads = append(payloads, pl)
+		}
+	}
+
+	return payloads
+}
+
+// getLinkADRReqPayloadsForSubBands returns the LinkADRReq payloads for the
+// US915 and AU915 bands, turning off all 125 kHz channels first (ChMaskCntl
+// 7, with the ChMask applying to the 500 kHz channels 64 - 71) and then
+// enabling the 125 kHz channels per block of 16 channels.
+func getLinkADRReqPayloadsForSubBands(channels []int) []lorawan.LinkADRReqPayload {
+	sorted := make([]int, len(channels))
+	copy(sorted, channels)
+	sort.Ints(sorted)
+
+	out := []lorawan.LinkADRReqPayload{
+		{Redundancy: lorawan.Redundancy{ChMaskCntl: 7}},
+	}
+
+	for _, c := range sorted {
+		if c >= 64 {
+			out[0].ChMask[c%16] = true
+			continue
+		}
+
+		if out[len(out)-1].Redundancy.ChMaskCntl != uint8(c/16) {
+			out = append(out, lorawan.LinkADRReqPayload{
+				Redundancy: lorawan.Redundancy{
+					ChMaskCntl: uint8(c / 16),
+				},
+			})
+		}
+		out[len(out)-1].ChMask[c%16] = true
+	}
+
+	return out
+}
+
+// GetCFList returns the CFList to include in the join-accept. In case the
+// band uses the channel-mask CFList (LoRaWAN 1.0.3+, e.g. US915) and the
+// service-profile has a channel-mask, the CFList channel-mask is limited
+// to the channels of the service-profile channel-mask.
+func GetCFList(sp storage.ServiceProfile, macVersion string) *lorawan.CFList {
+	cFList := band.Band().GetCFList(macVersion)
+	if cFList == nil || cFList.CFListType != lorawan.CFListChannelMask || len(sp.ChannelMask) == 0 {
+		return cFList
+	}
+
+	pl, ok := cFList.Payload.(*lorawan.CFListChannelMaskPayload)
+	if !ok {
+		return cFList
+	}
+
+	// never disable all channels
+	channels := GetEnabledUplinkChannelIndices(sp, storage.DeviceSession{})
+	if len(channels) == 0 {
+		return cFList
+	}
+
+	out := lorawan.CFListChannelMaskPayload{
+		ChannelMasks: make([]lorawan.ChMask, len(pl.ChannelMasks)),
+	}
+	for _, c := range channels {
+		if c/16 < len(out.ChannelMasks) {
+			out.ChannelMasks[c/16][c%16] = true
+		}
+	}
+
+	return &lorawan.CFList{
+		CFListType: lorawan.CFListChannelMask,
+		Payload:    &out,
+	}
+}
+
+// GetCFListEnabledUplinkChannelIndices returns the uplink channels that
+// are enabled by the given channel-mask CFList.
+func GetCFListEnabledUplinkChannelIndices(pl lorawan.CFListChannelMaskPayload) []int {
+	var out []int
+	for i, chMask := range pl.ChannelMasks {
+		for j, enabled := range chMask {
+			if enabled {
+				out = append(out, i*len(chMask)+j)
+			}
+		}
+	}
+	return out
+}
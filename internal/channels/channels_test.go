@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -15,9 +17,10 @@ func TestHandleChannelReconfigure(t *testing.T) {
 
 	Convey("Given a set of tests", t, func() {
 		tests := []struct {
-			Name          string
-			DeviceSession storage.DeviceSession
-			Expected      []storage.MACCommandBlock
+			Name           string
+			ServiceProfile storage.ServiceProfile
+			DeviceSession  storage.DeviceSession
+			Expected       []storage.MACCommandBlock
 		}{
 			{
 				Name: "no channels to reconfigure",
@@ -54,14 +57,145 @@ func TestHandleChannelReconfigure(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "channels to reconfigure (service-profile channel-mask)",
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x03}, // channel 0 and 1
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 1, 2},
+					DR:                    3,
+				},
+				Expected: []storage.MACCommandBlock{
+					{
+						CID: lorawan.LinkADRReq,
+						MACCommands: storage.MACCommands{
+							lorawan.MACCommand{
+								CID: lorawan.LinkADRReq,
+								Payload: &lorawan.LinkADRReqPayload{
+									DataRate: 3,
+									TXPower:  1,
+									ChMask:   lorawan.ChMask{true, true},
+									Redundancy: lorawan.Redundancy{
+										NbRep: 2,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "no channels to reconfigure (service-profile channel-mask)",
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x03}, // channel 0 and 1
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 1},
+				},
+			},
 		}
 
 		for i, test := range tests {
 			Convey(fmt.Sprintf("test: %s [%d]", test.Name, i), func() {
-				blocks, err := HandleChannelReconfigure(test.DeviceSession)
+				blocks, err := HandleChannelReconfigure(test.ServiceProfile, test.DeviceSession)
 				So(err, ShouldBeNil)
 				So(blocks, ShouldResemble, test.Expected)
 			})
 		}
 	})
 }
+
+func TestUS915SubBand(t *testing.T) {
+	Convey("Given the US915 band and a service-profile channel-mask enabling channels 8 - 15 and 65", t, func() {
+		conf := test.GetConfig()
+		conf.NetworkServer.Band.Name = loraband.US_902_928
+		So(band.Setup(conf), ShouldBeNil)
+
+		// restore the default test band
+		Reset(func() {
+			So(band.Setup(test.GetConfig()), ShouldBeNil)
+		})
+
+		sp := storage.ServiceProfile{
+			ChannelMask: []byte{0x00, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02},
+		}
+
+		Convey("Then the CFList channel-mask contains these channels", func() {
+			cFList := GetCFList(sp, "1.0.3")
+			So(cFList, ShouldResemble, &lorawan.CFList{
+				CFListType: lorawan.CFListChannelMask,
+				Payload: &lorawan.CFListChannelMaskPayload{
+					ChannelMasks: []lorawan.ChMask{
+						{false, false, false, false, false, false, false, false, true, true, true, true, true, true, true, true},
+						{},
+						{},
+						{},
+						{false, true},
+					},
+				},
+			})
+
+			pl := cFList.Payload.(*lorawan.CFListChannelMaskPayload)
+			So(GetCFListEnabledUplinkChannelIndices(*pl), ShouldResemble, []int{8, 9, 10, 11, 12, 13, 14, 15, 65})
+		})
+
+		Convey("Then no CFList is returned for LoRaWAN 1.0.2 devices", func() {
+			So(GetCFList(sp, "1.0.2"), ShouldBeNil)
+		})
+
+		Convey("When the device has all channels enabled", func() {
+			ds := storage.DeviceSession{
+				TXPowerIndex:          1,
+				NbTrans:               1,
+				EnabledUplinkChannels: band.Band().GetUplinkChannelIndices(),
+			}
+
+			Convey("Then all 125 kHz channels are turned off first and channels 8 - 15 are enabled", func() {
+				blocks, err := HandleChannelReconfigure(sp, ds)
+				So(err, ShouldBeNil)
+				So(blocks, ShouldResemble, []storage.MACCommandBlock{
+					{
+						CID: lorawan.LinkADRReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.LinkADRReq,
+								Payload: &lorawan.LinkADRReqPayload{
+									ChMask: lorawan.ChMask{false, true},
+									Redundancy: lorawan.Redundancy{
+										ChMaskCntl: 7,
+									},
+								},
+							},
+							{
+								CID: lorawan.LinkADRReq,
+								Payload: &lorawan.LinkADRReqPayload{
+									TXPower: 1,
+									ChMask:  lorawan.ChMask{false, false, false, false, false, false, false, false, true, true, true, true, true, true, true, true},
+									Redundancy: lorawan.Redundancy{
+										NbRep: 1,
+									},
+								},
+							},
+						},
+					},
+				})
+
+				Convey("Then the device enabled channels can be derived from these payloads", func() {
+					var pls []lorawan.LinkADRReqPayload
+					for _, mac := range blocks[0].MACCommands {
+						pls = append(pls, *mac.Payload.(*lorawan.LinkADRReqPayload))
+					}
+
+					enabled, err := band.Band().GetEnabledUplinkChannelIndicesForLinkADRReqPayloads(ds.EnabledUplinkChannels, pls)
+					So(err, ShouldBeNil)
+					So(enabled, ShouldResemble, []int{8, 9, 10, 11, 12, 13, 14, 15, 65})
+				})
+			})
+		})
+	})
+}
@@ -20,6 +20,9 @@ type Config struct {
 	PostgreSQL struct {
 		DSN         string `mapstructure:"dsn"`
 		Automigrate bool
+
+		ProfileCacheTTL     time.Duration `mapstructure:"profile_cache_ttl"`
+		DisableProfileCache bool          `mapstructure:"disable_profile_cache"`
 	} `mapstructure:"postgresql"`
 
 	Redis struct {
@@ -27,14 +30,20 @@ type Config struct {
 		MaxIdle     int           `mapstructure:"max_idle"`
 		MaxActive   int           `mapstructure:"max_active"`
 		IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+		SentinelMasterName string   `mapstructure:"sentinel_master_name"`
+		SentinelAddrs      []string `mapstructure:"sentinel_addrs"`
 	}
 
 	NetworkServer struct {
 		NetID                lorawan.NetID
 		NetIDString          string        `mapstructure:"net_id"`
+		ExtraNetIDs          []string      `mapstructure:"extra_net_ids"`
 		DeduplicationDelay   time.Duration `mapstructure:"deduplication_delay"`
 		DeviceSessionTTL     time.Duration `mapstructure:"device_session_ttl"`
+		DeviceQueueItemTTL   time.Duration `mapstructure:"device_queue_item_ttl"`
 		GetDownlinkDataDelay time.Duration `mapstructure:"get_downlink_data_delay"`
+		ShutdownTimeout      time.Duration `mapstructure:"shutdown_timeout"`
 
 		Band struct {
 			Name                   band.Name
@@ -54,8 +63,17 @@ type Config struct {
 			DownlinkTXPower       int     `mapstructure:"downlink_tx_power"`
 			EnabledUplinkChannels []int   `mapstructure:"enabled_uplink_channels"`
 			DisableMACCommands    bool    `mapstructure:"disable_mac_commands"`
+			MACCommandAckUplinks  int     `mapstructure:"mac_command_ack_uplinks"`
+			MACCommandMaxRetries  int     `mapstructure:"mac_command_max_retries"`
 			DisableADR            bool    `mapstructure:"disable_adr"`
 
+			PrioritizeExternalMACCommands bool   `mapstructure:"prioritize_external_mac_commands"`
+			DisableADRACKReqDownlink      bool   `mapstructure:"disable_adr_ack_req_downlink"`
+			MaxLinkADRReqRejections       int    `mapstructure:"max_link_adr_req_rejections"`
+			ABPFCntResetMaxFCnt           uint32 `mapstructure:"abp_fcnt_reset_max_fcnt"`
+			DisableACKOnlyDownlink        bool   `mapstructure:"disable_ack_only_downlink"`
+			GatewayDutyCycleEnabled       bool   `mapstructure:"gateway_duty_cycle_enabled"`
+
 			ExtraChannels []struct {
 				Frequency int
 				MinDR     int `mapstructure:"min_dr"`
@@ -79,14 +97,51 @@ type Config struct {
 
 			ClassC struct {
 				DownlinkLockDuration time.Duration `mapstructure:"downlink_lock_duration"`
+				DownlinkRetryCount   int           `mapstructure:"downlink_retry_count"`
 			} `mapstructure:"class_c"`
 		} `mapstructure:"scheduler"`
 
+		FrameLog struct {
+			Async      bool `mapstructure:"async"`
+			BufferSize int  `mapstructure:"buffer_size"`
+		} `mapstructure:"frame_log"`
+
+		UplinkErrors struct {
+			ForwardTypes      []string      `mapstructure:"forward_types"`
+			RateLimitInterval time.Duration `mapstructure:"rate_limit_interval"`
+		} `mapstructure:"uplink_errors"`
+
+		NetIDFilter struct {
+			Enabled     bool `mapstructure:"enabled"`
+			LogRejected bool `mapstructure:"log_rejected"`
+		} `mapstructure:"net_id_filter"`
+
+		ProprietaryUplink struct {
+			Handler           string        `mapstructure:"handler"`
+			RateLimit         int           `mapstructure:"rate_limit"`
+			RateLimitInterval time.Duration `mapstructure:"rate_limit_interval"`
+
+			MQTT struct {
+				Server   string
+				Username string
+				Password string
+				QOS      uint8  `mapstructure:"qos"`
+				ClientID string `mapstructure:"client_id"`
+				CACert   string `mapstructure:"ca_cert"`
+				TLSCert  string `mapstructure:"tls_cert"`
+				TLSKey   string `mapstructure:"tls_key"`
+				Topic    string `mapstructure:"topic"`
+			} `mapstructure:"mqtt"`
+		} `mapstructure:"proprietary_uplink"`
+
 		API struct {
 			Bind    string
 			CACert  string `mapstructure:"ca_cert"`
 			TLSCert string `mapstructure:"tls_cert"`
 			TLSKey  string `mapstructure:"tls_key"`
+
+			AllowedClientCNs             []string `mapstructure:"allowed_client_cns"`
+			HealthCheckWithoutClientCert bool     `mapstructure:"health_check_without_client_cert"`
 		} `mapstructure:"api"`
 
 		Gateway struct {
@@ -95,6 +150,31 @@ type Config struct {
 				Timezone string
 			}
 
+			OfflineDetection struct {
+				StatsInterval     time.Duration `mapstructure:"stats_interval"`
+				OfflineMultiplier int           `mapstructure:"offline_multiplier"`
+				CheckInterval     time.Duration `mapstructure:"check_interval"`
+				DebounceDuration  time.Duration `mapstructure:"debounce_duration"`
+			} `mapstructure:"offline_detection"`
+
+			AutoCreate struct {
+				Enabled          bool   `mapstructure:"enabled"`
+				GatewayProfileID string `mapstructure:"gateway_profile_id"`
+			} `mapstructure:"auto_create"`
+
+			LocationUpdate struct {
+				MaxJumpDistance       float64 `mapstructure:"max_jump_distance"`
+				JumpConfirmationCount int     `mapstructure:"jump_confirmation_count"`
+			} `mapstructure:"location_update"`
+
+			Discovery struct {
+				Interval  time.Duration `mapstructure:"interval"`
+				Frequency int           `mapstructure:"frequency"`
+				DR        int           `mapstructure:"dr"`
+				HMACKey   string        `mapstructure:"hmac_key"`
+				Retention time.Duration `mapstructure:"retention"`
+			} `mapstructure:"discovery"`
+
 			Backend struct {
 				Type string `mapstructure:"type"`
 
@@ -130,12 +210,31 @@ type Config struct {
 	} `mapstructure:"network_server"`
 
 	GeolocationServer struct {
-		Server  string `mapstructure:"server"`
-		CACert  string `mapstructure:"ca_cert"`
-		TLSCert string `mapstructure:"tls_cert"`
-		TLSKey  string `mapstructure:"tls_key"`
+		Server          string        `mapstructure:"server"`
+		CACert          string        `mapstructure:"ca_cert"`
+		TLSCert         string        `mapstructure:"tls_cert"`
+		TLSKey          string        `mapstructure:"tls_key"`
+		Workers         int           `mapstructure:"workers"`
+		QueueSize       int           `mapstructure:"queue_size"`
+		ResolveInterval time.Duration `mapstructure:"resolve_interval"`
+		RequestTimeout  time.Duration `mapstructure:"request_timeout"`
 	} `mapstructure:"geolocation_server"`
 
+	ApplicationServer struct {
+		KeepaliveInterval time.Duration `mapstructure:"keepalive_interval"`
+		KeepaliveTimeout  time.Duration `mapstructure:"keepalive_timeout"`
+		ReconnectMaxDelay time.Duration `mapstructure:"reconnect_max_delay"`
+		IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+		RetryCount        int           `mapstructure:"retry_count"`
+		RetryBackoff      time.Duration `mapstructure:"retry_backoff"`
+
+		DeadLetterQueue struct {
+			Enabled       bool          `mapstructure:"enabled"`
+			MaxLength     int           `mapstructure:"max_length"`
+			DrainInterval time.Duration `mapstructure:"drain_interval"`
+		} `mapstructure:"dead_letter_queue"`
+	} `mapstructure:"application_server"`
+
 	JoinServer struct {
 		ResolveJoinEUI      bool   `mapstructure:"resolve_join_eui"`
 		ResolveDomainSuffix string `mapstructure:"resolve_domain_suffix"`
@@ -162,6 +261,28 @@ type Config struct {
 		} `mapstructure:"kek"`
 	} `mapstructure:"join_server"`
 
+	Roaming struct {
+		Enabled        bool          `mapstructure:"enabled"`
+		RequestTimeout time.Duration `mapstructure:"request_timeout"`
+
+		API struct {
+			Bind       string `mapstructure:"bind"`
+			CACert     string `mapstructure:"ca_cert"`
+			TLSCert    string `mapstructure:"tls_cert"`
+			TLSKey     string `mapstructure:"tls_key"`
+			ULTokenKey string `mapstructure:"ul_token_key"`
+		} `mapstructure:"api"`
+
+		Servers []struct {
+			NetID                  string        `mapstructure:"net_id"`
+			Server                 string        `mapstructure:"server"`
+			PassiveRoamingLifetime time.Duration `mapstructure:"passive_roaming_lifetime"`
+			CACert                 string        `mapstructure:"ca_cert"`
+			TLSCert                string        `mapstructure:"tls_cert"`
+			TLSKey                 string        `mapstructure:"tls_key"`
+		} `mapstructure:"servers"`
+	} `mapstructure:"roaming"`
+
 	NetworkController struct {
 		Client nc.NetworkControllerServiceClient
 
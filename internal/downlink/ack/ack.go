@@ -1,11 +1,19 @@
 package ack
 
 import (
+	"time"
+
 	"github.com/brocaar/lorawan"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/dutycycle"
+	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/storage"
 )
 
@@ -14,7 +22,10 @@ var (
 )
 
 var handleDownlinkTXAckTasks = []func(*ackContext) error{
+	logDownlinkTXAck,
+	sendDownlinkStatusTransmitted,
 	abortOnNoError,
+	saveTXErrorMetrics,
 	getDownlinkFrame,
 	sendDownlinkFrame,
 }
@@ -43,6 +54,48 @@ func HandleDownlinkTXAck(downlinkTXAck gw.DownlinkTXAck) error {
 	return nil
 }
 
+func logDownlinkTXAck(ctx *ackContext) error {
+	if err := framelog.LogDownlinkTXAckForGateway(storage.RedisPool(), ctx.DownlinkTXAck); err != nil {
+		log.WithError(err).Error("log downlink tx ack for gateway error")
+	}
+	return nil
+}
+
+// sendDownlinkStatusTransmitted notifies the application-server that the
+// device-queue item was transmitted by the gateway. On a TX error, the
+// status is kept for the next downlink-frame (sharing the same token).
+func sendDownlinkStatusTransmitted(ctx *ackContext) error {
+	if ctx.DownlinkTXAck.Error != "" {
+		return nil
+	}
+
+	status, err := storage.PopDownlinkFrameStatus(storage.RedisPool(), ctx.DownlinkTXAck.Token)
+	if err != nil {
+		if err != storage.ErrDoesNotExist {
+			log.WithError(err).Error("pop downlink-frame status error")
+		}
+		return nil
+	}
+
+	if err := func() error {
+		rp, err := storage.GetAndCacheRoutingProfile(storage.DB(), status.RoutingProfileID)
+		if err != nil {
+			return errors.Wrap(err, "get routing-profile error")
+		}
+
+		asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+		if err != nil {
+			return errors.Wrap(err, "get application-server client error")
+		}
+
+		return storage.SendDownlinkStatus(asClient, status.DeviceQueueItem, as.DownlinkStatus_TRANSMITTED)
+	}(); err != nil {
+		log.WithError(err).WithField("dev_eui", status.DeviceQueueItem.DevEUI).Error("send downlink status error")
+	}
+
+	return nil
+}
+
 func abortOnNoError(ctx *ackContext) error {
 	if ctx.DownlinkTXAck.Error == "" {
 		// no error, nothing to do
@@ -51,6 +104,28 @@ func abortOnNoError(ctx *ackContext) error {
 	return nil
 }
 
+func saveTXErrorMetrics(ctx *ackContext) error {
+	gatewayID := helpers.GetGatewayID(&ctx.DownlinkTXAck)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"token":      ctx.DownlinkTXAck.Token,
+		"error":      ctx.DownlinkTXAck.Error,
+	}).Warning("downlink tx ack error received")
+
+	err := storage.SaveMetrics(storage.RedisPool(), "gw:"+gatewayID.String(), storage.MetricsRecord{
+		Time: time.Now(),
+		Metrics: map[string]float64{
+			"tx_error_count": 1,
+		},
+	})
+	if err != nil {
+		log.WithError(err).Error("save gateway tx error metrics error")
+	}
+
+	return nil
+}
+
 func getDownlinkFrame(ctx *ackContext) error {
 	var err error
 	ctx.DevEUI, ctx.DownlinkFrame, err = storage.PopDownlinkFrame(storage.RedisPool(), ctx.DownlinkTXAck.Token)
@@ -68,5 +143,10 @@ func sendDownlinkFrame(ctx *ackContext) error {
 	if err := gateway.Backend().SendTXPacket(ctx.DownlinkFrame); err != nil {
 		return errors.Wrap(err, "send downlink-frame to gateway error")
 	}
+
+	if err := dutycycle.LogDownlinkFrame(storage.RedisPool(), ctx.DownlinkFrame); err != nil {
+		log.WithError(err).Error("log gateway duty-cycle airtime error")
+	}
+
 	return nil
 }
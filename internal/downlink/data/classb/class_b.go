@@ -33,6 +33,22 @@ func GetBeaconStartForTime(ts time.Time) time.Duration {
 	return gpsTime - (gpsTime % beaconPeriod)
 }
 
+// GetNextBeaconStartForTime returns the start time of the beacon following
+// the given time.Time, as a duration since GPS epoch.
+func GetNextBeaconStartForTime(ts time.Time) time.Duration {
+	return GetBeaconStartForTime(ts) + beaconPeriod
+}
+
+// GetPingNbForPeriodicity returns the number of ping-slots per beacon period
+// for the given ping-slot periodicity (0 - 7).
+func GetPingNbForPeriodicity(periodicity int) (int, error) {
+	if periodicity < 0 || periodicity > 7 {
+		return 0, fmt.Errorf("periodicity must be between 0 and 7, got: %d", periodicity)
+	}
+
+	return 1 << uint(7-periodicity), nil
+}
+
 // GetPingOffset returns the ping offset for the given beacon.
 func GetPingOffset(beacon time.Duration, devAddr lorawan.DevAddr, pingNb int) (int, error) {
 	if pingNb == 0 {
@@ -71,29 +87,44 @@ func GetPingOffset(beacon time.Duration, devAddr lorawan.DevAddr, pingNb int) (i
 	return (int(rand[0]) + int(rand[1])*256) % pingPeriod, nil
 }
 
+// GetPingSlotsForBeacon returns the ping-slots (as durations since GPS
+// epoch) within the beacon period starting at the given beacon.
+func GetPingSlotsForBeacon(beacon time.Duration, devAddr lorawan.DevAddr, pingNb int) ([]time.Duration, error) {
+	pingOffset, err := GetPingOffset(beacon, devAddr, pingNb)
+	if err != nil {
+		return nil, err
+	}
+
+	pingPeriod := pingPeriodBase / pingNb
+	out := make([]time.Duration, pingNb)
+
+	for n := 0; n < pingNb; n++ {
+		out[n] = beacon + beaconReserved + (time.Duration(pingOffset+n*pingPeriod) * slotLen)
+	}
+
+	return out, nil
+}
+
 // GetNextPingSlotAfter returns the next pingslot occuring after the given gps epoch timestamp.
 func GetNextPingSlotAfter(afterGPSEpochTS time.Duration, devAddr lorawan.DevAddr, pingNb int) (time.Duration, error) {
 	if pingNb == 0 {
 		return 0, errors.New("pingNb must be > 0")
 	}
 	beaconStart := afterGPSEpochTS - (afterGPSEpochTS % beaconPeriod)
-	pingPeriod := pingPeriodBase / pingNb
 
 	for {
-		pingOffset, err := GetPingOffset(beaconStart, devAddr, pingNb)
+		pingSlots, err := GetPingSlotsForBeacon(beaconStart, devAddr, pingNb)
 		if err != nil {
 			return 0, err
 		}
 
-		for n := 0; n < pingNb; n++ {
-			gpsEpochTime := beaconStart + beaconReserved + (time.Duration(pingOffset+n*pingPeriod) * slotLen)
-
+		for n, gpsEpochTime := range pingSlots {
 			if gpsEpochTime > afterGPSEpochTS {
 				log.WithFields(log.Fields{
 					"dev_addr":                   devAddr,
 					"beacon_start_time_s":        int(beaconStart / beaconPeriod),
 					"after_beacon_start_time_ms": int((gpsEpochTime - beaconStart) / time.Millisecond),
-					"ping_offset_ms":             pingOffset,
+					"ping_offset_ms":             int((pingSlots[0] - beaconStart - beaconReserved) / slotLen),
 					"ping_slot_n":                n,
 					"ping_nb":                    pingNb,
 				}).Info("get next ping-slot timestamp")
@@ -143,3 +174,36 @@ func ScheduleDeviceQueueToPingSlotsForDevEUI(db sqlx.Ext, dp storage.DeviceProfi
 
 	return nil
 }
+
+// UnscheduleDeviceQueueFromPingSlotsForDevEUI removes the Class-B ping-slot
+// scheduling from the device-queue items for the given DevEUI, so that these
+// can be sent as Class-A downlink (e.g. after the device lost its beacon
+// lock). Pending items are left untouched.
+func UnscheduleDeviceQueueFromPingSlotsForDevEUI(db sqlx.Ext, ds storage.DeviceSession) error {
+	queueItems, err := storage.GetDeviceQueueItemsForDevEUI(db, ds.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device-queue items error")
+	}
+
+	var count int
+	for _, qi := range queueItems {
+		if qi.IsPending || qi.EmitAtTimeSinceGPSEpoch == nil {
+			continue
+		}
+
+		qi.EmitAtTimeSinceGPSEpoch = nil
+		qi.TimeoutAfter = nil
+
+		if err := storage.UpdateDeviceQueueItem(db, &qi); err != nil {
+			return errors.Wrap(err, "update device-queue item error")
+		}
+		count++
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": ds.DevEUI,
+		"count":   count,
+	}).Info("device-queue items unscheduled from ping-slots")
+
+	return nil
+}
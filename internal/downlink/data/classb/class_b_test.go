@@ -43,6 +43,108 @@ func TestGetBeaconStartForTime(t *testing.T) {
 	})
 }
 
+func TestGetNextBeaconStartForTime(t *testing.T) {
+	tests := []struct {
+		Time                time.Time
+		ExpectedBeaconStart time.Duration
+		ExpectedNextBeacon  time.Duration
+		ExpectedNextUTC     time.Time
+	}{
+		{
+			Time:                time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC),
+			ExpectedBeaconStart: 0,
+			ExpectedNextBeacon:  beaconPeriod,
+			ExpectedNextUTC:     time.Date(1980, time.January, 6, 0, 2, 8, 0, time.UTC),
+		},
+		// before and after the 2017 leap second, both within the same
+		// beacon period
+		{
+			Time:                time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC),
+			ExpectedBeaconStart: 1167264000 * time.Second,
+			ExpectedNextBeacon:  1167264128 * time.Second,
+			ExpectedNextUTC:     time.Date(2017, time.January, 1, 0, 1, 50, 0, time.UTC),
+		},
+		{
+			Time:                time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedBeaconStart: 1167264000 * time.Second,
+			ExpectedNextBeacon:  1167264128 * time.Second,
+			ExpectedNextUTC:     time.Date(2017, time.January, 1, 0, 1, 50, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		if d := GetBeaconStartForTime(test.Time); d != test.ExpectedBeaconStart {
+			t.Errorf("expected beacon start %s for %s, got: %s", test.ExpectedBeaconStart, test.Time, d)
+		}
+
+		d := GetNextBeaconStartForTime(test.Time)
+		if d != test.ExpectedNextBeacon {
+			t.Errorf("expected next beacon start %s for %s, got: %s", test.ExpectedNextBeacon, test.Time, d)
+		}
+
+		if ts := time.Time(gps.NewFromTimeSinceGPSEpoch(d)); !ts.Equal(test.ExpectedNextUTC) {
+			t.Errorf("expected next beacon start %s for %s, got: %s", test.ExpectedNextUTC, test.Time, ts)
+		}
+	}
+}
+
+func TestGetPingNbForPeriodicity(t *testing.T) {
+	for periodicity, expected := range []int{128, 64, 32, 16, 8, 4, 2, 1} {
+		pingNb, err := GetPingNbForPeriodicity(periodicity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pingNb != expected {
+			t.Errorf("expected pingNb %d for periodicity %d, got: %d", expected, periodicity, pingNb)
+		}
+	}
+
+	for _, periodicity := range []int{-1, 8} {
+		if _, err := GetPingNbForPeriodicity(periodicity); err == nil {
+			t.Errorf("expected error for periodicity %d", periodicity)
+		}
+	}
+}
+
+func TestGetPingSlotsForBeacon(t *testing.T) {
+	slots, err := GetPingSlotsForBeacon(0, lorawan.DevAddr{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []time.Duration{12860 * time.Millisecond, 74300 * time.Millisecond}
+	if len(slots) != len(expected) {
+		t.Fatalf("expected %d ping-slots, got: %d", len(expected), len(slots))
+	}
+	for i := range expected {
+		if slots[i] != expected[i] {
+			t.Errorf("expected ping-slot %d at %s, got: %s", i, expected[i], slots[i])
+		}
+	}
+
+	for k := uint(0); k < 8; k++ {
+		pingNb := 1 << k
+		beacon := 1167264000 * time.Second
+
+		slots, err := GetPingSlotsForBeacon(beacon, lorawan.DevAddr{1, 2, 3, 4}, pingNb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(slots) != pingNb {
+			t.Fatalf("expected %d ping-slots, got: %d", pingNb, len(slots))
+		}
+
+		for i, slot := range slots {
+			if slot < beacon+beaconReserved || slot >= beacon+beaconReserved+beaconWindow {
+				t.Errorf("ping-slot %d (%s) is outside the beacon window at pingNb %d", i, slot, pingNb)
+			}
+			if i != 0 && slot-slots[i-1] != time.Duration(pingPeriodBase/pingNb)*slotLen {
+				t.Errorf("unexpected ping period between slot %d and %d at pingNb %d", i-1, i, pingNb)
+			}
+		}
+	}
+}
+
 func TestGetPingOffset(t *testing.T) {
 	for k := uint(0); k < 8; k++ {
 		var beacon time.Duration
@@ -203,6 +305,29 @@ func TestScheduleDeviceQueueToPingSlotsForDevEUI(t *testing.T) {
 						timeSinceGPSEpochNow = *qi.EmitAtTimeSinceGPSEpoch
 					}
 				})
+
+				Convey("When calling UnscheduleDeviceQueueFromPingSlotsForDevEUI", func() {
+					qi, err := storage.GetDeviceQueueItem(storage.DB(), queueItems[0].ID)
+					So(err, ShouldBeNil)
+					qi.IsPending = true
+					So(storage.UpdateDeviceQueueItem(storage.DB(), &qi), ShouldBeNil)
+
+					So(UnscheduleDeviceQueueFromPingSlotsForDevEUI(storage.DB(), ds), ShouldBeNil)
+
+					Convey("Then the Class-B scheduling is removed from the queue-items which are not pending", func() {
+						qi, err := storage.GetDeviceQueueItem(storage.DB(), queueItems[0].ID)
+						So(err, ShouldBeNil)
+						So(qi.EmitAtTimeSinceGPSEpoch, ShouldNotBeNil)
+						So(qi.TimeoutAfter, ShouldNotBeNil)
+
+						for i := range queueItems[1:] {
+							qi, err := storage.GetDeviceQueueItem(storage.DB(), queueItems[i+1].ID)
+							So(err, ShouldBeNil)
+							So(qi.EmitAtTimeSinceGPSEpoch, ShouldBeNil)
+							So(qi.TimeoutAfter, ShouldBeNil)
+						}
+					})
+				})
 			})
 		})
 	})
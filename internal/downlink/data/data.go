@@ -3,6 +3,7 @@ package data
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"sort"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -10,11 +11,14 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/adr"
+	"github.com/brocaar/loraserver/internal/airtime"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/channels"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
 	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/maccommand"
@@ -26,6 +30,11 @@ import (
 
 const defaultCodeRate = "4/5"
 
+// rx1ScheduleMargin defines the margin before the RX1 receive delay
+// expires, after which it is considered too late to schedule the RX1
+// transmission.
+const rx1ScheduleMargin = 100 * time.Millisecond
+
 type incompatibleCIDMapping struct {
 	CID              lorawan.CID
 	IncompatibleCIDs []lorawan.CID
@@ -61,13 +70,18 @@ var (
 	downlinkTXPower int
 
 	// MAC Commands
-	disableMACCommands bool
+	disableMACCommands            bool
+	prioritizeExternalMACCommands bool
 
 	// ADR
 	disableADR bool
 
+	// ACK
+	disableACKOnlyDownlink bool
+
 	// ClassC
 	classCDownlinkLockDuration time.Duration
+	classCDownlinkRetryCount   int
 
 	// Dwell time.
 	uplinkDwellTime400ms   bool
@@ -96,6 +110,25 @@ var responseTasks = []func(*dataContext) error{
 	setMACCommandsSet,
 	stopOnNothingToSend,
 	setPHYPayloads,
+	skipRX1WhenTooLate,
+	reserveGatewayTXSlot,
+	saveDownlinkStatusTransmitted,
+	sendDownlinkFrame,
+	saveDeviceSession,
+	saveRemainingFrames,
+}
+
+// retransmissionResponseTasks only acknowledge the (confirmed) uplink
+// retransmission, the device-queue and mac-commands are left untouched.
+var retransmissionResponseTasks = []func(*dataContext) error{
+	getDeviceProfile,
+	getServiceProfile,
+	setDataTXInfo,
+	setToken,
+	stopOnNothingToSend,
+	setPHYPayloads,
+	skipRX1WhenTooLate,
+	reserveGatewayTXSlot,
 	sendDownlinkFrame,
 	saveDeviceSession,
 	saveRemainingFrames,
@@ -105,9 +138,11 @@ var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	getDeviceProfile,
 	getServiceProfile,
 	checkLastDownlinkTimestamp,
+	getPinnedGateway,
 	forClass(storage.DeviceModeC,
 		setImmediately,
 		setTXInfoForRX2,
+		setTXInfoForRX2OnOtherGateways,
 	),
 	forClass(storage.DeviceModeB,
 		setTXInfoForClassB,
@@ -120,8 +155,10 @@ var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	setMACCommandsSet,
 	stopOnNothingToSend,
 	setPHYPayloads,
+	saveDownlinkStatusTransmitted,
 	sendDownlinkFrame,
 	saveDeviceSession,
+	saveRemainingFrames,
 }
 
 // Setup configures the package.
@@ -143,9 +180,12 @@ func Setup(conf config.Config) error {
 	downlinkTXPower = nsConf.DownlinkTXPower
 
 	disableMACCommands = nsConf.DisableMACCommands
+	prioritizeExternalMACCommands = nsConf.PrioritizeExternalMACCommands
 	disableADR = nsConf.DisableADR
+	disableACKOnlyDownlink = nsConf.DisableACKOnlyDownlink
 
 	classCDownlinkLockDuration = conf.NetworkServer.Scheduler.ClassC.DownlinkLockDuration
+	classCDownlinkRetryCount = conf.NetworkServer.Scheduler.ClassC.DownlinkRetryCount
 
 	uplinkDwellTime400ms = conf.NetworkServer.Band.UplinkDwellTime400ms
 	downlinkDwellTime400ms = conf.NetworkServer.Band.DownlinkDwellTime400ms
@@ -196,6 +236,18 @@ type dataContext struct {
 	// value other than 0.
 	Data []byte
 
+	// FCnt holds the (32 bit) downlink frame-counter used for the downlink
+	// frames.
+	FCnt uint32
+
+	// DeviceQueueItem holds the device-queue item from which Data was taken
+	// (if any).
+	DeviceQueueItem *storage.DeviceQueueItem
+
+	// PinnedGatewayID holds the gateway to which the next (Class-B or
+	// Class-C) device-queue item is pinned (if any).
+	PinnedGatewayID *lorawan.EUI64
+
 	// RXPacket holds the received uplink packet (in case of Class-A downlink).
 	RXPacket *models.RXPacket
 
@@ -220,6 +272,13 @@ type downlinkFrame struct {
 	// The remaining payload size which can be used for mac-commands and / or
 	// FRMPayload.
 	RemainingPayloadSize int
+
+	// RX window of the downlink frame (Class-A). Class-C downlink frames use
+	// the RX2 parameters.
+	RXWindow storage.RXWindow
+
+	// MACCommandTransport defines how the mac-commands are sent.
+	MACCommandTransport ns.MACCommandTransport
 }
 
 func (ctx dataContext) Validate() error {
@@ -230,6 +289,21 @@ func (ctx dataContext) Validate() error {
 	return nil
 }
 
+// downlinkType returns the downlink type used in the metrics, response
+// when responding to an uplink or else scheduler.
+func (ctx dataContext) downlinkType() string {
+	if ctx.RXPacket != nil {
+		return "response"
+	}
+	return "scheduler"
+}
+
+// isACKOnly returns true when the downlink only acknowledges the uplink
+// and does not contain any mac-commands or FRMPayload.
+func (ctx dataContext) isACKOnly() bool {
+	return ctx.ACK && !ctx.MustSend && ctx.FPort == 0 && len(ctx.MACCommands) == 0
+}
+
 func forClass(mode storage.DeviceMode, tasks ...func(*dataContext) error) func(*dataContext) error {
 	return func(ctx *dataContext) error {
 		if mode != ctx.DeviceMode {
@@ -263,6 +337,32 @@ func HandleResponse(rxPacket models.RXPacket, sp storage.ServiceProfile, ds stor
 				return nil
 			}
 
+			downlinkScheduleErrorCounter(ctx.downlinkType()).Inc()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HandleRetransmissionResponse handles the downlink response to a
+// retransmission of a confirmed uplink. As the ACK of the original uplink
+// might have been lost, an ACK-only downlink is sent.
+func HandleRetransmissionResponse(rxPacket models.RXPacket, sp storage.ServiceProfile, ds storage.DeviceSession) error {
+	ctx := dataContext{
+		ServiceProfile: sp,
+		DeviceSession:  ds,
+		ACK:            true,
+		RXPacket:       &rxPacket,
+	}
+
+	for _, t := range retransmissionResponseTasks {
+		if err := t(&ctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
+
+			downlinkScheduleErrorCounter(ctx.downlinkType()).Inc()
 			return err
 		}
 	}
@@ -282,6 +382,7 @@ func HandleScheduleNextQueueItem(ds storage.DeviceSession, mode storage.DeviceMo
 			if err == ErrAbort {
 				return nil
 			}
+			downlinkScheduleErrorCounter(ctx.downlinkType()).Inc()
 			return err
 		}
 	}
@@ -387,33 +488,89 @@ func setTXParameters(ctx *dataContext) error {
 	return nil
 }
 
+// GetRXWindow returns the rx_window setting (0 = RX1 / RX2, 1 = RX1 only,
+// 2 = RX2 only, 3 = auto) to use for the given device-session. The RX window
+// preference of the device-session overrides the network-server setting.
+func GetRXWindow(ds storage.DeviceSession) int {
+	switch ds.RXWindowPreference {
+	case storage.RXWindowPreferenceRX1:
+		return 1
+	case storage.RXWindowPreferenceRX2:
+		return 2
+	default:
+		return rxWindow
+	}
+}
+
 func setDataTXInfo(ctx *dataContext) error {
-	if rxWindow == 0 || rxWindow == 1 {
+	rxWindow := GetRXWindow(ctx.DeviceSession)
+
+	if rxWindow == 0 || rxWindow == 1 || rxWindow == 3 {
 		if err := setTXInfoForRX1(ctx); err != nil {
 			return err
 		}
 	}
 
-	if rxWindow == 0 || rxWindow == 2 {
+	if rxWindow == 0 || rxWindow == 2 || rxWindow == 3 {
 		if err := setTXInfoForRX2(ctx); err != nil {
 			return err
 		}
+
+		if err := setTXInfoForRX2OnOtherGateways(ctx); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// getDownlinkRXInfo returns the rx-info of the gateway to use for the
+// transmission on the given frequency. The rx-info set is sorted by signal
+// strength. Gateways that exhausted their duty-cycle budget are skipped.
+// When all gateways exhausted their budget, the best gateway is returned.
+func getDownlinkRXInfo(rxInfoSet []*gw.UplinkRXInfo, frequency int) *gw.UplinkRXInfo {
+	for _, rxInfo := range rxInfoSet {
+		var gatewayID lorawan.EUI64
+		copy(gatewayID[:], rxInfo.GatewayId)
+
+		ok, err := dutycycle.HasBudget(storage.RedisPool(), gatewayID, frequency)
+		if err != nil {
+			log.WithError(err).WithField("gateway_id", gatewayID).Error("get gateway duty-cycle budget error")
+			return rxInfoSet[0]
+		}
+
+		if ok {
+			return rxInfo
+		}
+
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"frequency":  frequency,
+		}).Warning("gateway duty-cycle budget exhausted, skipping gateway")
+	}
+
+	return rxInfoSet[0]
+}
+
 func setTXInfoForRX1(ctx *dataContext) error {
 	if len(ctx.RXPacket.RXInfoSet) == 0 {
 		return ErrNoLastRXInfoSet
 	}
-	rxInfo := ctx.RXPacket.RXInfoSet[0]
+
+	// get rx1 frequency
+	freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(int(ctx.RXPacket.TXInfo.Frequency))
+	if err != nil {
+		return errors.Wrap(err, "get rx1 frequency error")
+	}
+
+	rxInfo := getDownlinkRXInfo(ctx.RXPacket.RXInfoSet, freq)
 
 	txInfo := gw.DownlinkTXInfo{
 		GatewayId: rxInfo.GatewayId,
 		Board:     rxInfo.Board,
 		Antenna:   rxInfo.Antenna,
 		Context:   rxInfo.Context,
+		Frequency: uint32(freq),
 	}
 
 	// get rx1 data-rate
@@ -432,13 +589,6 @@ func setTXInfoForRX1(ctx *dataContext) error {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
 
-	// get rx1 frequency
-	freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(int(ctx.RXPacket.TXInfo.Frequency))
-	if err != nil {
-		return errors.Wrap(err, "get rx1 frequency error")
-	}
-	txInfo.Frequency = uint32(freq)
-
 	// get timestamp
 	delay := band.Band().GetDefaults().ReceiveDelay1
 	if ctx.DeviceSession.RXDelay > 0 {
@@ -452,14 +602,12 @@ func setTXInfoForRX1(ctx *dataContext) error {
 	}
 
 	// get tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+		return err
 	}
 
 	// get remaining payload size
-	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, rx1DR)
+	plSize, err := band.BandForDownlinkDwellTime(ctx.DeviceSession.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, rx1DR)
 	if err != nil {
 		return errors.Wrap(err, "get max-payload size error")
 	}
@@ -469,49 +617,192 @@ func setTXInfoForRX1(ctx *dataContext) error {
 			TxInfo: &txInfo,
 		},
 		RemainingPayloadSize: plSize.N,
+		RXWindow:             storage.RX1,
 	})
 
 	return nil
 }
 
+// getPinnedGateway sets the gateway to which the next device-queue item is
+// pinned, so that the downlink is sent through this gateway, also when the
+// device has no uplink history. The downlink is skipped (and retried on the
+// next scheduler run) while the pinned gateway is in maintenance mode.
+func getPinnedGateway(ctx *dataContext) error {
+	qi, err := storage.GetNextDeviceQueueItemForDevEUI(storage.DB(), ctx.DeviceSession.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get next device-queue item error")
+	}
+
+	if qi.GatewayID == nil {
+		return nil
+	}
+
+	g, err := storage.GetAndCacheGateway(storage.DB(), storage.RedisPool(), *qi.GatewayID)
+	if err != nil {
+		return errors.Wrap(err, "get gateway error")
+	}
+
+	if g.MaintenanceMode {
+		log.WithFields(log.Fields{
+			"dev_eui":    ctx.DeviceSession.DevEUI,
+			"gateway_id": g.GatewayID,
+		}).Warning("pinned gateway is in maintenance mode, skipping device-queue item")
+		return ErrAbort
+	}
+
+	ctx.PinnedGatewayID = qi.GatewayID
+
+	return nil
+}
+
+// getDownlinkGateway returns the gateway ID, board, antenna and context to
+// use for the downlink on the given frequency. This is the pinned gateway
+// when set, else the gateway which received the (last) uplink best and
+// which did not exhaust its duty-cycle budget.
+func getDownlinkGateway(ctx *dataContext, frequency int) (lorawan.EUI64, uint32, uint32, []byte, error) {
+	if ctx.PinnedGatewayID != nil {
+		return *ctx.PinnedGatewayID, 0, 0, nil, nil
+	}
+
+	var rxInfoSet []*gw.UplinkRXInfo
+	if ctx.RXPacket != nil && len(ctx.RXPacket.RXInfoSet) != 0 {
+		rxInfoSet = ctx.RXPacket.RXInfoSet
+	} else {
+		// use the antenna of the gateways which received the last uplink
+		for _, rxInfo := range ctx.DeviceSession.LastRXInfoSet {
+			rxInfoSet = append(rxInfoSet, &gw.UplinkRXInfo{
+				GatewayId: rxInfo.GatewayID[:],
+				Board:     rxInfo.Board,
+				Antenna:   rxInfo.Antenna,
+			})
+		}
+	}
+
+	if len(rxInfoSet) == 0 {
+		gatewayID, err := ctx.DeviceSession.GetDownlinkGatewayMAC()
+		if err != nil {
+			return gatewayID, 0, 0, nil, ErrNoLastRXInfoSet
+		}
+		return gatewayID, 0, 0, nil, nil
+	}
+
+	rxInfo := getDownlinkRXInfo(rxInfoSet, frequency)
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], rxInfo.GatewayId)
+
+	return gatewayID, rxInfo.Board, rxInfo.Antenna, rxInfo.Context, nil
+}
+
 func setImmediately(ctx *dataContext) error {
 	ctx.Immediately = true
 	return nil
 }
 
 func setTXInfoForRX2(ctx *dataContext) error {
-	gatewayID, err := ctx.DeviceSession.GetDownlinkGatewayMAC()
+	gatewayID, board, antenna, context, err := getDownlinkGateway(ctx, ctx.DeviceSession.RX2Frequency)
 	if err != nil {
 		return err
 	}
 
-	var board, antenna uint32
-	var context []byte
-	if ctx.RXPacket != nil && len(ctx.RXPacket.RXInfoSet) != 0 {
-		board = ctx.RXPacket.RXInfoSet[0].Board
-		antenna = ctx.RXPacket.RXInfoSet[0].Antenna
-		context = ctx.RXPacket.RXInfoSet[0].Context
-	}
-
-	txInfo := gw.DownlinkTXInfo{
+	return appendTXInfoForRX2(ctx, gw.DownlinkTXInfo{
 		GatewayId: gatewayID[:],
 		Board:     board,
 		Antenna:   antenna,
 		Frequency: uint32(ctx.DeviceSession.RX2Frequency),
 		Context:   context,
+	})
+}
+
+// setTXInfoForRX2OnOtherGateways adds a RX2 downlink-frame for each gateway
+// that received the uplink, but which is not yet used by one of the other
+// downlink-frames. In case of Class-C (no uplink), the best gateways of the
+// last uplink as stored in the device-session are used, falling back to the
+// device gateway rx-info set. These frames are sent in order when the gateway
+// returns a negative TX acknowledgement (e.g. TOO_LATE or COLLISION_PACKET)
+// for the previous frame. Gateways that exhausted their duty-cycle budget
+// are skipped.
+func setTXInfoForRX2OnOtherGateways(ctx *dataContext) error {
+	// a pinned downlink is only sent through the pinned gateway
+	if ctx.PinnedGatewayID != nil {
+		return nil
+	}
+
+	used := make(map[lorawan.EUI64]struct{})
+	for _, df := range ctx.DownlinkFrames {
+		var id lorawan.EUI64
+		copy(id[:], df.DownlinkFrame.TxInfo.GatewayId)
+		used[id] = struct{}{}
 	}
 
+	var rxInfoSet []*gw.UplinkRXInfo
+	if ctx.RXPacket != nil {
+		rxInfoSet = ctx.RXPacket.RXInfoSet
+	} else if len(ctx.DeviceSession.LastRXInfoSet) != 0 {
+		for i := range ctx.DeviceSession.LastRXInfoSet {
+			rxInfoSet = append(rxInfoSet, &gw.UplinkRXInfo{
+				GatewayId: ctx.DeviceSession.LastRXInfoSet[i].GatewayID[:],
+			})
+		}
+	} else {
+		devGWRXInfoSet, err := storage.GetDeviceGatewayRXInfoSet(storage.RedisPool(), ctx.DeviceSession.DevEUI)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return nil
+			}
+			return errors.Wrap(err, "get device gateway rx-info set error")
+		}
+
+		for i := range devGWRXInfoSet.Items {
+			rxInfoSet = append(rxInfoSet, &gw.UplinkRXInfo{
+				GatewayId: devGWRXInfoSet.Items[i].GatewayID[:],
+			})
+		}
+	}
+
+	for _, rxInfo := range rxInfoSet {
+		var id lorawan.EUI64
+		copy(id[:], rxInfo.GatewayId)
+		if _, ok := used[id]; ok {
+			continue
+		}
+		used[id] = struct{}{}
+
+		ok, err := dutycycle.HasBudget(storage.RedisPool(), id, ctx.DeviceSession.RX2Frequency)
+		if err != nil {
+			return errors.Wrap(err, "get gateway duty-cycle budget error")
+		}
+		if !ok {
+			continue
+		}
+
+		if err := appendTXInfoForRX2(ctx, gw.DownlinkTXInfo{
+			GatewayId: rxInfo.GatewayId,
+			Board:     rxInfo.Board,
+			Antenna:   rxInfo.Antenna,
+			Frequency: uint32(ctx.DeviceSession.RX2Frequency),
+			Context:   rxInfo.Context,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func appendTXInfoForRX2(ctx *dataContext, txInfo gw.DownlinkTXInfo) error {
 	// get data-rate
-	err = helpers.SetDownlinkTXInfoDataRate(&txInfo, int(ctx.DeviceSession.RX2DR), band.Band())
+	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, int(ctx.DeviceSession.RX2DR), band.Band())
 	if err != nil {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
 
 	// get tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+		return err
 	}
 
 	// get timestamp (when not tx immediately)
@@ -529,6 +820,9 @@ func setTXInfoForRX2(ctx *dataContext) error {
 	}
 
 	if ctx.Immediately {
+		// the concentrator timestamp of a previous uplink must not be used
+		// for scheduling an immediate downlink
+		txInfo.Context = nil
 		txInfo.Timing = gw.DownlinkTiming_IMMEDIATELY
 		txInfo.TimingInfo = &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
 			ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
@@ -536,7 +830,7 @@ func setTXInfoForRX2(ctx *dataContext) error {
 	}
 
 	// get remaining payload size
-	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.RX2DR))
+	plSize, err := band.BandForDownlinkDwellTime(ctx.DeviceSession.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.RX2DR))
 	if err != nil {
 		return errors.Wrap(err, "get max-payload size error")
 	}
@@ -546,25 +840,18 @@ func setTXInfoForRX2(ctx *dataContext) error {
 			TxInfo: &txInfo,
 		},
 		RemainingPayloadSize: plSize.N,
+		RXWindow:             storage.RX2,
 	})
 
 	return nil
 }
 
 func setTXInfoForClassB(ctx *dataContext) error {
-	gatewayID, err := ctx.DeviceSession.GetDownlinkGatewayMAC()
+	gatewayID, board, antenna, context, err := getDownlinkGateway(ctx, ctx.DeviceSession.PingSlotFrequency)
 	if err != nil {
 		return err
 	}
 
-	var board, antenna uint32
-	var context []byte
-	if ctx.RXPacket != nil && len(ctx.RXPacket.RXInfoSet) != 0 {
-		board = ctx.RXPacket.RXInfoSet[0].Board
-		antenna = ctx.RXPacket.RXInfoSet[0].Antenna
-		context = ctx.RXPacket.RXInfoSet[0].Context
-	}
-
 	txInfo := gw.DownlinkTXInfo{
 		GatewayId: gatewayID[:],
 		Board:     board,
@@ -580,14 +867,12 @@ func setTXInfoForClassB(ctx *dataContext) error {
 	}
 
 	// get tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+		return err
 	}
 
 	// get remaining payload size
-	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.PingSlotDR))
+	plSize, err := band.BandForDownlinkDwellTime(ctx.DeviceSession.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.PingSlotDR))
 	if err != nil {
 		return errors.Wrap(err, "get max-payload size error")
 	}
@@ -611,13 +896,27 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 	}
 
 	// the first downlink opportunity will be used to decide the
-	// max payload size
+	// max payload size, in case of the auto RX window selection the
+	// downlink opportunity with the largest payload size
 	var remainingPayloadSize int
 	if len(ctx.DownlinkFrames) > 0 {
 		remainingPayloadSize = ctx.DownlinkFrames[0].RemainingPayloadSize
 	}
+	if ctx.RXPacket != nil && GetRXWindow(ctx.DeviceSession) == 3 {
+		for _, df := range ctx.DownlinkFrames {
+			if df.RemainingPayloadSize > remainingPayloadSize {
+				remainingPayloadSize = df.RemainingPayloadSize
+			}
+		}
+	}
+
+	// only pending Class-C items are retransmitted on timeout
+	var maxRetryCount int
+	if ctx.DeviceMode == storage.DeviceModeC {
+		maxRetryCount = classCDownlinkRetryCount
+	}
 
-	qi, err := storage.GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(storage.DB(), ctx.DeviceSession.DevEUI, remainingPayloadSize, fCnt, ctx.DeviceSession.RoutingProfileID)
+	qi, err := storage.GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(storage.DB(), ctx.DeviceSession.DevEUI, remainingPayloadSize, fCnt, ctx.DeviceSession.RoutingProfileID, maxRetryCount)
 	if err != nil {
 		if errors.Cause(err) == storage.ErrDoesNotExist {
 			return nil
@@ -625,9 +924,38 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		return errors.Wrap(err, "get next device-queue item for max payload error")
 	}
 
+	// The tx-info of a Class-B or Class-C downlink was set for the pinned
+	// gateway of the first queue item. In case this item was discarded and
+	// the next item is pinned differently, it will be sent on the next
+	// scheduler run.
+	if ctx.RXPacket == nil && !pinnedGatewayEqual(ctx.PinnedGatewayID, qi.GatewayID) {
+		return ErrAbort
+	}
+
+	// device-queue items are not sent when the transmission of the item
+	// would exceed the downlink airtime budget of the service-profile
+	exceeded, err := downlinkAirtimeBudgetExceeded(ctx, len(qi.FRMPayload))
+	if err != nil {
+		return errors.Wrap(err, "get device airtime budget error")
+	}
+	if exceeded {
+		log.WithFields(log.Fields{
+			"dev_eui":      ctx.DeviceSession.DevEUI,
+			"dl_budget_ms": ctx.ServiceProfile.DLAirtimeBudget,
+		}).Warning("downlink airtime budget exceeded, skipping device-queue")
+		return nil
+	}
+
 	ctx.Confirmed = qi.Confirmed
 	ctx.Data = qi.FRMPayload
 	ctx.FPort = qi.FPort
+	ctx.DeviceQueueItem = &qi
+
+	// move the downlink opportunities that are able to carry the payload
+	// to the front (e.g. RX2 in case RX1 can't carry the payload)
+	sort.SliceStable(ctx.DownlinkFrames, func(i, j int) bool {
+		return ctx.DownlinkFrames[i].RemainingPayloadSize >= len(ctx.Data) && ctx.DownlinkFrames[j].RemainingPayloadSize < len(ctx.Data)
+	})
 
 	for i := range ctx.DownlinkFrames {
 		ctx.DownlinkFrames[i].RemainingPayloadSize = ctx.DownlinkFrames[i].RemainingPayloadSize - len(ctx.Data)
@@ -698,6 +1026,13 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 	return nil
 }
 
+func pinnedGatewayEqual(a, b *lorawan.EUI64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func filterIncompatibleMACCommands(macCommands []storage.MACCommandBlock) []storage.MACCommandBlock {
 	for _, mapping := range incompatibleMACCommands {
 		var seen bool
@@ -761,25 +1096,23 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 			remainingMACCommandSize = remainingPayloadSize
 		}
 
-		for i, block := range ctx.MACCommands {
-			macSize, err := block.Size()
-			if err != nil {
-				return errors.Wrap(err, "get mac-command block size error")
-			}
-
-			remainingMACCommandSize = remainingMACCommandSize - macSize
-
-			// truncate mac-commands when we exceed the max-size
-			if remainingMACCommandSize < 0 {
-				ctx.MACCommands = ctx.MACCommands[0:i]
-				ctx.MoreData = true
-				break
-			}
+		// select the mac-commands that fit, the remaining external
+		// mac-commands stay in the queue for the next downlink opportunity
+		packed, remaining, err := maccommand.Pack(ctx.MACCommands, remainingMACCommandSize, prioritizeExternalMACCommands)
+		if err != nil {
+			return errors.Wrap(err, "pack mac-commands error")
+		}
+		ctx.MACCommands = packed
+		if len(remaining) > 0 {
+			ctx.MoreData = true
 		}
 
 		for _, block := range ctx.MACCommands {
-			// set mac-command pending
-			if err := storage.SetPendingMACCommand(storage.RedisPool(), ctx.DeviceSession.DevEUI, block); err != nil {
+			// set mac-command pending, the frame-counter is stored so that
+			// unanswered blocks can be detected on uplink
+			pending := block
+			pending.FCntUp = ctx.DeviceSession.FCntUp
+			if err := storage.SetPendingMACCommand(storage.RedisPool(), ctx.DeviceSession.DevEUI, pending); err != nil {
 				return errors.Wrap(err, "set mac-command pending error")
 			}
 
@@ -824,7 +1157,7 @@ func requestCustomChannelReconfiguration(ctx *dataContext) error {
 func requestChannelMaskReconfiguration(ctx *dataContext) error {
 	// handle channel configuration
 	// note that this must come before ADR!
-	blocks, err := channels.HandleChannelReconfigure(ctx.DeviceSession)
+	blocks, err := channels.HandleChannelReconfigure(ctx.ServiceProfile, ctx.DeviceSession)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -844,7 +1177,7 @@ func requestADRChange(ctx *dataContext) error {
 		}
 	}
 
-	blocks, err := adr.HandleADR(ctx.ServiceProfile, ctx.DeviceSession, linkADRReq)
+	blocks, err := adr.HandleADR(ctx.ServiceProfile, ctx.DeviceProfile, ctx.DeviceSession, linkADRReq)
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -864,6 +1197,8 @@ func getMACCommandsFromQueue(ctx *dataContext) error {
 		return errors.Wrap(err, "get mac-command queue items error")
 	}
 
+	macCommandQueueSize().Observe(float64(len(blocks)))
+
 	for i := range blocks {
 		ctx.MACCommands = append(ctx.MACCommands, blocks[i])
 	}
@@ -872,11 +1207,21 @@ func getMACCommandsFromQueue(ctx *dataContext) error {
 }
 
 func stopOnNothingToSend(ctx *dataContext) error {
-	if ctx.FPort == 0 && len(ctx.MACCommands) == 0 && !ctx.ACK && !ctx.MustSend {
+	// a confirmed uplink must be acknowledged, even when there is nothing
+	// else to send, unless this has been disabled
+	ack := ctx.ACK && !disableACKOnlyDownlink
+
+	if ctx.FPort == 0 && len(ctx.MACCommands) == 0 && !ack && !ctx.MustSend {
 		// ErrAbort will not be handled as a real error
 		return ErrAbort
 	}
 
+	if ctx.isACKOnly() {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+		}).Info("sending ack-only downlink frame")
+	}
+
 	return nil
 }
 
@@ -893,6 +1238,7 @@ func setPHYPayloads(ctx *dataContext) error {
 		fCnt = ctx.DeviceSession.AFCntDown
 		ctx.DeviceSession.AFCntDown++
 	}
+	ctx.FCnt = fCnt
 
 	for i := range ctx.DownlinkFrames {
 		// LoRaWAN MAC payload
@@ -938,11 +1284,18 @@ func setPHYPayloads(ctx *dataContext) error {
 			}
 		}
 
+		// mac-commands exceeding the FOpts size are sent as FRMPayload
+		// using FPort 0, which is only possible when there is no
+		// application payload
 		if macCommandSize > 15 && ctx.FPort == 0 {
 			macPL.FPort = &ctx.FPort
 			macPL.FRMPayload = maccommands
+			ctx.DownlinkFrames[i].MACCommandTransport = ns.MACCommandTransport_FRM_PAYLOAD
 		} else if macCommandSize <= 15 {
 			macPL.FHDR.FOpts = maccommands
+			if len(maccommands) > 0 {
+				ctx.DownlinkFrames[i].MACCommandTransport = ns.MACCommandTransport_FOPTS
+			}
 		} else {
 			// this should not happen, but log it in case it would
 			log.WithFields(log.Fields{
@@ -991,6 +1344,163 @@ func setPHYPayloads(ctx *dataContext) error {
 	return nil
 }
 
+// skipRX1WhenTooLate removes the RX1 downlink-frames when it is too late to
+// schedule the RX1 transmission (e.g. because of a high deduplication delay
+// or a slow network). This only applies when there is a RX2 downlink-frame
+// to fall back to.
+func skipRX1WhenTooLate(ctx *dataContext) error {
+	if ctx.RXPacket == nil || ctx.RXPacket.ReceivedAt.IsZero() {
+		return nil
+	}
+
+	if rxWindow := GetRXWindow(ctx.DeviceSession); !(rxWindow == 0 || rxWindow == 3) {
+		return nil
+	}
+
+	delay := band.Band().GetDefaults().ReceiveDelay1
+	if ctx.DeviceSession.RXDelay > 0 {
+		delay = time.Duration(ctx.DeviceSession.RXDelay) * time.Second
+	}
+
+	if time.Since(ctx.RXPacket.ReceivedAt) < delay-rx1ScheduleMargin {
+		return nil
+	}
+
+	var frames []downlinkFrame
+	for _, df := range ctx.DownlinkFrames {
+		if df.RXWindow == storage.RX2 {
+			frames = append(frames, df)
+		}
+	}
+
+	if len(frames) == 0 || frames[0].RemainingPayloadSize < 0 {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":     ctx.DeviceSession.DevEUI,
+		"received_at": ctx.RXPacket.ReceivedAt,
+	}).Warning("too late for rx1 transmission, using rx2")
+	ctx.DownlinkFrames = frames
+
+	return nil
+}
+
+// reserveGatewayTXSlot reserves the TX slot for the first downlink-frame.
+// When the slot overlaps with the slot of an other downlink scheduled for
+// the same gateway, the next downlink-frame (e.g. RX2 or an other gateway)
+// is tried. When no slot could be reserved, the downlink-frames are left
+// untouched.
+func reserveGatewayTXSlot(ctx *dataContext) error {
+	for i := range ctx.DownlinkFrames {
+		if ctx.DownlinkFrames[i].RemainingPayloadSize < 0 {
+			continue
+		}
+
+		ok, err := reserveTXSlotForDownlinkFrame(ctx.DownlinkFrames[i].DownlinkFrame)
+		if err != nil {
+			log.WithError(err).Error("reserve gateway tx slot error")
+			return nil
+		}
+
+		if ok {
+			if i != 0 {
+				log.WithFields(log.Fields{
+					"dev_eui": ctx.DeviceSession.DevEUI,
+					"skipped": i,
+				}).Info("gateway tx slot is already reserved, using next downlink-frame")
+			}
+
+			ctx.DownlinkFrames = ctx.DownlinkFrames[i:]
+			return nil
+		}
+	}
+
+	log.WithField("dev_eui", ctx.DeviceSession.DevEUI).Warning("no free gateway tx slot for any of the downlink-frames")
+
+	return nil
+}
+
+// downlinkAirtimeBudgetExceeded returns true when the transmission of the
+// given FRMPayload size would exceed the downlink airtime budget of the
+// service-profile. The airtime is calculated for the first downlink
+// opportunity which is able to carry the payload.
+func downlinkAirtimeBudgetExceeded(ctx *dataContext, frmPayloadSize int) (bool, error) {
+	if ctx.ServiceProfile.DLAirtimeBudget == 0 || len(ctx.DownlinkFrames) == 0 {
+		return false, nil
+	}
+
+	txInfo := ctx.DownlinkFrames[0].DownlinkFrame.TxInfo
+	for _, df := range ctx.DownlinkFrames {
+		if df.RemainingPayloadSize >= frmPayloadSize {
+			txInfo = df.DownlinkFrame.TxInfo
+			break
+		}
+	}
+
+	d, err := airtime.GetDownlinkDataAirtime(frmPayloadSize, txInfo)
+	if err != nil {
+		return false, errors.Wrap(err, "get downlink airtime error")
+	}
+
+	return storage.DeviceAirtimeBudgetExceeded(storage.RedisPool(), ctx.DeviceSession.DevEUI, storage.DownlinkAirtime, d, time.Duration(ctx.ServiceProfile.DLAirtimeBudget)*time.Millisecond)
+}
+
+// reserveTXSlotForDownlinkFrame reserves the TX slot for the given frame.
+// Only frames using the delay timing relative to the concentrator timestamp
+// of the uplink can be reserved, for other frames true is returned.
+func reserveTXSlotForDownlinkFrame(frame gw.DownlinkFrame) (bool, error) {
+	timingInfo := frame.TxInfo.GetDelayTimingInfo()
+	if frame.TxInfo.Timing != gw.DownlinkTiming_DELAY || timingInfo == nil || len(frame.TxInfo.Context) != 4 {
+		return true, nil
+	}
+
+	delay, err := ptypes.Duration(timingInfo.Delay)
+	if err != nil {
+		return false, errors.Wrap(err, "get delay duration error")
+	}
+
+	airtime, err := airtime.GetDownlinkAirtime(frame)
+	if err != nil {
+		return false, errors.Wrap(err, "get airtime error")
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], frame.TxInfo.GatewayId)
+
+	start := getConcentratorTXTimestamp(binary.BigEndian.Uint32(frame.TxInfo.Context), delay)
+
+	return storage.ReserveGatewayTXSlot(storage.RedisPool(), gatewayID, start, airtime)
+}
+
+// getConcentratorTXTimestamp returns the concentrator timestamp at which
+// the downlink will be emitted, given the concentrator timestamp of the
+// uplink and the RX delay. The concentrator timestamp is a 32 bit
+// microsecond counter which rolls over roughly every 72 minutes, therefore
+// the addition is performed modulo 2^32.
+func getConcentratorTXTimestamp(rxTimestamp uint32, delay time.Duration) uint32 {
+	return uint32((uint64(rxTimestamp) + uint64(delay/time.Microsecond)) % (1 << 32))
+}
+
+// saveDownlinkStatusTransmitted stores the device-queue item sent by the
+// downlink-frame, so that the application-server is notified once the
+// gateway acknowledged the transmission. Retransmissions (Class-C) of the
+// device-queue item are not notified again.
+func saveDownlinkStatusTransmitted(ctx *dataContext) error {
+	if ctx.DeviceQueueItem == nil || ctx.DeviceQueueItem.RetryCount > 0 || len(ctx.DownlinkFrames) == 0 {
+		return nil
+	}
+
+	if err := storage.SaveDownlinkFrameStatus(storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame.Token, storage.DownlinkFrameStatus{
+		RoutingProfileID: ctx.DeviceSession.RoutingProfileID,
+		DeviceQueueItem:  *ctx.DeviceQueueItem,
+	}); err != nil {
+		log.WithError(err).WithField("dev_eui", ctx.DeviceSession.DevEUI).Error("save downlink-frame status error")
+	}
+
+	return nil
+}
+
 func sendDownlinkFrame(ctx *dataContext) error {
 	if len(ctx.DownlinkFrames) == 0 {
 		return nil
@@ -1001,9 +1511,34 @@ func sendDownlinkFrame(ctx *dataContext) error {
 		return errors.Wrap(err, "send downlink-frame to gateway error")
 	}
 
-	// set last downlink tx timestamp
+	downlinkTXCounter(ctx.downlinkType()).Inc()
+
+	// set last downlink tx timestamp and meta-data
 	ctx.DeviceSession.LastDownlinkTX = time.Now()
 
+	txInfo := ctx.DownlinkFrames[0].DownlinkFrame.TxInfo
+	ctx.DeviceSession.LastTXInfo = &storage.DeviceSessionTXInfo{
+		GatewayID: helpers.GetGatewayID(txInfo),
+		Frequency: int(txInfo.Frequency),
+		TXPower:   int(txInfo.Power),
+		Time:      ctx.DeviceSession.LastDownlinkTX,
+		FCnt:      ctx.FCnt,
+	}
+	if dr, err := helpers.GetDataRateIndex(false, txInfo, band.Band()); err == nil {
+		ctx.DeviceSession.LastTXInfo.DR = dr
+	}
+	if d, err := airtime.GetDownlinkAirtime(ctx.DownlinkFrames[0].DownlinkFrame); err == nil {
+		ctx.DeviceSession.LastTXInfo.Airtime = d
+
+		if err := storage.AddDeviceAirtime(storage.RedisPool(), ctx.DeviceSession.DevEUI, storage.DownlinkAirtime, ctx.DeviceSession.LastDownlinkTX, d); err != nil {
+			log.WithError(err).Error("add device downlink airtime error")
+		}
+	}
+
+	if err := dutycycle.LogDownlinkFrame(storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+		log.WithError(err).Error("log gateway duty-cycle airtime error")
+	}
+
 	// log for gateway (with encrypted mac-commands)
 	if err := framelog.LogDownlinkFrameForGateway(storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
@@ -1040,7 +1575,7 @@ func sendDownlinkFrame(ctx *dataContext) error {
 			Token:      uint32(ctx.DownlinkFrames[0].DownlinkFrame.Token),
 			TxInfo:     ctx.DownlinkFrames[0].DownlinkFrame.TxInfo,
 			PhyPayload: phyB,
-		}); err != nil {
+		}, ns.RXWindow(ctx.DownlinkFrames[0].RXWindow), ctx.DownlinkFrames[0].MACCommandTransport); err != nil {
 			return err
 		}
 
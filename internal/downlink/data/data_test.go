@@ -1,14 +1,22 @@
 package data
 
 import (
+	"math"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/backend/applicationserver"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
+	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
@@ -1017,3 +1025,414 @@ func TestSetTXParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPHYPayloadsFCntRollover(t *testing.T) {
+	assert := require.New(t)
+
+	ctx := dataContext{
+		DeviceSession: storage.DeviceSession{
+			MACVersion: "1.0.2",
+			DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+			NFCntDown:  65535,
+		},
+		FPort: 1,
+		Data:  []byte{1, 2, 3},
+	}
+
+	// the on-air FCnt contains the 16 LSB, the MIC must be calculated
+	// using the full 32 bit frame-counter
+	for _, fullFCnt := range []uint32{65535, 65536} {
+		ctx.DownlinkFrames = []downlinkFrame{{RemainingPayloadSize: 242}}
+		assert.NoError(setPHYPayloads(&ctx))
+		assert.Equal(fullFCnt+1, ctx.DeviceSession.NFCntDown)
+		assert.Equal(fullFCnt, ctx.FCnt)
+
+		var phy lorawan.PHYPayload
+		assert.NoError(phy.UnmarshalBinary(ctx.DownlinkFrames[0].DownlinkFrame.PhyPayload))
+		macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+		assert.True(ok)
+		assert.Equal(fullFCnt%65536, macPL.FHDR.FCnt)
+
+		macPL.FHDR.FCnt = fullFCnt
+		valid, err := phy.ValidateDownlinkDataMIC(lorawan.LoRaWAN1_0, 0, ctx.DeviceSession.SNwkSIntKey)
+		assert.NoError(err)
+		assert.True(valid)
+	}
+}
+
+func TestSkipRX1WhenTooLate(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(band.Setup(test.GetConfig()))
+
+	rxWindow = 3
+	defer func() { rxWindow = 0 }()
+
+	frames := []downlinkFrame{
+		{RemainingPayloadSize: 10, RXWindow: storage.RX1},
+		{RemainingPayloadSize: 10, RXWindow: storage.RX2},
+	}
+
+	tests := []struct {
+		Name           string
+		ReceivedAt     time.Time
+		RX2Remaining   int
+		ExpectedFrames int
+	}{
+		{"unknown receive time", time.Time{}, 10, 2},
+		{"in time for rx1", time.Now(), 10, 2},
+		{"too late for rx1", time.Now().Add(-time.Second), 10, 1},
+		{"too late for rx1, rx2 unable to carry the payload", time.Now().Add(-time.Second), -1, 2},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctx := dataContext{
+				RXPacket:       &models.RXPacket{ReceivedAt: tst.ReceivedAt},
+				DownlinkFrames: append([]downlinkFrame{}, frames...),
+			}
+			ctx.DownlinkFrames[1].RemainingPayloadSize = tst.RX2Remaining
+
+			assert.NoError(skipRX1WhenTooLate(&ctx))
+			assert.Len(ctx.DownlinkFrames, tst.ExpectedFrames)
+			assert.Equal(storage.RXWindow(storage.RX2), ctx.DownlinkFrames[len(ctx.DownlinkFrames)-1].RXWindow)
+		})
+	}
+}
+
+func TestGetConcentratorTXTimestamp(t *testing.T) {
+	tests := []struct {
+		Name        string
+		RXTimestamp uint32
+		Delay       time.Duration
+		Expected    uint32
+	}{
+		{"no rollover", 1000000, time.Second, 2000000},
+		{"rx1 delay, rollover", math.MaxUint32 - 500000, time.Second, 499999},
+		{"rx2 delay, rollover", math.MaxUint32 - 500000, 2 * time.Second, 1499999},
+		{"rx1 delay, just before rollover", math.MaxUint32 - 1000000, time.Second, math.MaxUint32},
+		{"rx2 delay, at rollover", math.MaxUint32, 2 * time.Second, 1999999},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, getConcentratorTXTimestamp(tst.RXTimestamp, tst.Delay))
+		})
+	}
+}
+
+func TestAppendTXInfoForRX2Immediately(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(band.Setup(test.GetConfig()))
+
+	ctx := dataContext{
+		Immediately: true,
+		DeviceSession: storage.DeviceSession{
+			RX2Frequency: 869525000,
+		},
+	}
+
+	assert.NoError(appendTXInfoForRX2(&ctx, gw.DownlinkTXInfo{
+		Frequency: 869525000,
+		Context:   []byte{0xff, 0xff, 0xff, 0xff},
+	}))
+	assert.Len(ctx.DownlinkFrames, 1)
+
+	txInfo := ctx.DownlinkFrames[0].DownlinkFrame.TxInfo
+	assert.Equal(gw.DownlinkTiming_IMMEDIATELY, txInfo.Timing)
+	assert.Nil(txInfo.Context)
+}
+
+func TestGetDownlinkGatewayDutyCycle(t *testing.T) {
+	assert := require.New(t)
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.GatewayDutyCycleEnabled = true
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(band.Setup(conf))
+	assert.NoError(dutycycle.Setup(conf))
+	defer dutycycle.Setup(test.GetConfig())
+	test.MustFlushRedis(storage.RedisPool())
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	// 868.9 MHz is within the g2 sub-band (0.1%, 3.6 seconds per hour)
+	frequency := 868900000
+	for i := 0; i < 2; i++ {
+		assert.NoError(dutycycle.LogDownlinkFrame(storage.RedisPool(), gw.DownlinkFrame{
+			PhyPayload: make([]byte, 51),
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId:  gw1[:],
+				Frequency:  uint32(frequency),
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{
+						SpreadingFactor: 12,
+						Bandwidth:       125,
+						CodeRate:        "4/5",
+					},
+				},
+			},
+		}))
+	}
+
+	tests := []struct {
+		Name              string
+		Context           dataContext
+		Frequency         int
+		ExpectedGatewayID lorawan.EUI64
+		ExpectedBoard     uint32
+	}{
+		{
+			Name: "uplink rx-info set",
+			Context: dataContext{
+				RXPacket: &models.RXPacket{
+					RXInfoSet: []*gw.UplinkRXInfo{
+						{GatewayId: gw1[:], Board: 1},
+						{GatewayId: gw2[:], Board: 2},
+					},
+				},
+			},
+			Frequency:         frequency,
+			ExpectedGatewayID: gw2,
+			ExpectedBoard:     2,
+		},
+		{
+			Name: "last rx-info set (Class-C)",
+			Context: dataContext{
+				DeviceSession: storage.DeviceSession{
+					LastRXInfoSet: []storage.DeviceSessionRXInfo{
+						{GatewayID: gw1, Board: 1},
+						{GatewayID: gw2, Board: 2},
+					},
+				},
+			},
+			Frequency:         frequency,
+			ExpectedGatewayID: gw2,
+			ExpectedBoard:     2,
+		},
+		{
+			Name: "other sub-band",
+			Context: dataContext{
+				DeviceSession: storage.DeviceSession{
+					LastRXInfoSet: []storage.DeviceSessionRXInfo{
+						{GatewayID: gw1, Board: 1},
+						{GatewayID: gw2, Board: 2},
+					},
+				},
+			},
+			Frequency:         869525000,
+			ExpectedGatewayID: gw1,
+			ExpectedBoard:     1,
+		},
+		{
+			Name: "all gateways exhausted",
+			Context: dataContext{
+				DeviceSession: storage.DeviceSession{
+					LastRXInfoSet: []storage.DeviceSessionRXInfo{
+						{GatewayID: gw1, Board: 1},
+					},
+				},
+			},
+			Frequency:         frequency,
+			ExpectedGatewayID: gw1,
+			ExpectedBoard:     1,
+		},
+		{
+			Name: "pinned gateway",
+			Context: dataContext{
+				PinnedGatewayID: &gw1,
+				DeviceSession: storage.DeviceSession{
+					LastRXInfoSet: []storage.DeviceSessionRXInfo{
+						{GatewayID: gw2, Board: 2},
+					},
+				},
+			},
+			Frequency:         frequency,
+			ExpectedGatewayID: gw1,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			gatewayID, board, _, _, err := getDownlinkGateway(&tst.Context, tst.Frequency)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedGatewayID, gatewayID)
+			assert.Equal(tst.ExpectedBoard, board)
+		})
+	}
+}
+
+func TestGetRXWindow(t *testing.T) {
+	rxWindow = 3
+	defer func() { rxWindow = 0 }()
+
+	tests := []struct {
+		Name       string
+		Preference storage.RXWindowPreference
+		Expected   int
+	}{
+		{"auto uses network-server setting", storage.RXWindowPreferenceAuto, 3},
+		{"rx1", storage.RXWindowPreferenceRX1, 1},
+		{"rx2", storage.RXWindowPreferenceRX2, 2},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, GetRXWindow(storage.DeviceSession{RXWindowPreference: tst.Preference}))
+		})
+	}
+}
+
+func TestStopOnNothingToSend(t *testing.T) {
+	tests := []struct {
+		Name                   string
+		Context                dataContext
+		DisableACKOnlyDownlink bool
+		ExpectedError          error
+	}{
+		{
+			Name:          "nothing to send",
+			ExpectedError: ErrAbort,
+		},
+		{
+			Name:    "ack only",
+			Context: dataContext{ACK: true},
+		},
+		{
+			Name:                   "ack only, ack-only downlink disabled",
+			Context:                dataContext{ACK: true},
+			DisableACKOnlyDownlink: true,
+			ExpectedError:          ErrAbort,
+		},
+		{
+			Name:                   "ack with mac-commands, ack-only downlink disabled",
+			Context:                dataContext{ACK: true, MACCommands: []storage.MACCommandBlock{{CID: lorawan.DevStatusReq}}},
+			DisableACKOnlyDownlink: true,
+		},
+		{
+			Name:                   "must send, ack-only downlink disabled",
+			Context:                dataContext{ACK: true, MustSend: true},
+			DisableACKOnlyDownlink: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			disableACKOnlyDownlink = tst.DisableACKOnlyDownlink
+			defer func() { disableACKOnlyDownlink = false }()
+
+			assert.Equal(tst.ExpectedError, stopOnNothingToSend(&tst.Context))
+		})
+	}
+}
+
+func TestSetPHYPayloadsMACCommandTransport(t *testing.T) {
+	newChannelReq := storage.MACCommandBlock{
+		CID: lorawan.NewChannelReq,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5}},
+			{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5}},
+			{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 5, Freq: 867500000, MaxDR: 5}},
+		},
+	}
+	devStatusReq := storage.MACCommandBlock{
+		CID: lorawan.DevStatusReq,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.DevStatusReq},
+		},
+	}
+
+	tests := []struct {
+		Name                        string
+		FPort                       uint8
+		Data                        []byte
+		MACCommands                 []storage.MACCommandBlock
+		ExpectedMACCommandTransport ns.MACCommandTransport
+		ExpectedFOptsCount          int
+		ExpectedFRMPayloadCount     int
+		ExpectedError               error
+	}{
+		{
+			Name:                        "mac-commands exceeding FOpts are sent as FRMPayload",
+			MACCommands:                 []storage.MACCommandBlock{newChannelReq},
+			ExpectedMACCommandTransport: ns.MACCommandTransport_FRM_PAYLOAD,
+			ExpectedFRMPayloadCount:     3,
+		},
+		{
+			Name:                        "mac-commands fitting in FOpts",
+			MACCommands:                 []storage.MACCommandBlock{devStatusReq},
+			ExpectedMACCommandTransport: ns.MACCommandTransport_FOPTS,
+			ExpectedFOptsCount:          1,
+		},
+		{
+			Name:                        "mac-commands in FOpts with application payload",
+			FPort:                       1,
+			Data:                        []byte{1, 2, 3},
+			MACCommands:                 []storage.MACCommandBlock{devStatusReq},
+			ExpectedMACCommandTransport: ns.MACCommandTransport_FOPTS,
+			ExpectedFOptsCount:          1,
+			ExpectedFRMPayloadCount:     1,
+		},
+		{
+			Name:                        "no mac-commands",
+			FPort:                       1,
+			Data:                        []byte{1, 2, 3},
+			ExpectedMACCommandTransport: ns.MACCommandTransport_NO_MAC_COMMANDS,
+			ExpectedFRMPayloadCount:     1,
+		},
+		{
+			Name:          "application payload with FPort 0",
+			Data:          []byte{1, 2, 3},
+			ExpectedError: ErrFPortMustNotBeZero,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctx := dataContext{
+				DeviceSession: storage.DeviceSession{
+					MACVersion: "1.0.2",
+					DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+					FCntUp:     1,
+					NwkSEncKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+				},
+				FPort:          tst.FPort,
+				Data:           tst.Data,
+				MACCommands:    tst.MACCommands,
+				DownlinkFrames: []downlinkFrame{{RemainingPayloadSize: 51}},
+			}
+
+			err := setPHYPayloads(&ctx)
+			if tst.ExpectedError != nil {
+				assert.Equal(tst.ExpectedError, errors.Cause(err))
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedMACCommandTransport, ctx.DownlinkFrames[0].MACCommandTransport)
+
+			var phy lorawan.PHYPayload
+			assert.NoError(phy.UnmarshalBinary(ctx.DownlinkFrames[0].DownlinkFrame.PhyPayload))
+			if tst.FPort == 0 {
+				assert.NoError(phy.DecryptFRMPayload(ctx.DeviceSession.NwkSEncKey))
+			}
+
+			macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+			assert.True(ok)
+			assert.Len(macPL.FHDR.FOpts, tst.ExpectedFOptsCount)
+			assert.Len(macPL.FRMPayload, tst.ExpectedFRMPayloadCount)
+			if tst.ExpectedFRMPayloadCount > 0 {
+				assert.NotNil(macPL.FPort)
+				assert.Equal(tst.FPort, *macPL.FPort)
+			}
+		})
+	}
+}
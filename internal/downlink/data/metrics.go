@@ -0,0 +1,36 @@
+package data
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dtc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "downlink_data_tx_count",
+		Help: "The number of data downlink frames sent to the gateway (per type, response or scheduler).",
+	}, []string{"type"})
+
+	dsec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "downlink_data_schedule_error_count",
+		Help: "The number of failed data downlink schedule attempts (per type, response or scheduler).",
+	}, []string{"type"})
+
+	mcqs = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "downlink_data_mac_command_queue_size",
+		Help:    "The number of items in the mac-command queue of a device on downlink.",
+		Buckets: []float64{0, 1, 2, 5, 10, 20, 50},
+	})
+)
+
+func downlinkTXCounter(t string) prometheus.Counter {
+	return dtc.With(prometheus.Labels{"type": t})
+}
+
+func downlinkScheduleErrorCounter(t string) prometheus.Counter {
+	return dsec.With(prometheus.Labels{"type": t})
+}
+
+func macCommandQueueSize() prometheus.Observer {
+	return mcqs
+}
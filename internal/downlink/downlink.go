@@ -13,8 +13,9 @@ import (
 )
 
 var (
-	schedulerBatchSize = 100
-	schedulerInterval  time.Duration
+	schedulerBatchSize        = 100
+	schedulerInterval         time.Duration
+	deviceQueueExpiryInterval = time.Minute
 )
 
 // Setup sets up the downlink.
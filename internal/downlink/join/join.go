@@ -9,9 +9,11 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
 	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/models"
@@ -72,13 +74,13 @@ func Handle(ds storage.DeviceSession, rxPacket models.RXPacket, phy lorawan.PHYP
 }
 
 func setTXInfo(ctx *joinContext) error {
-	if rxWindow == 0 || rxWindow == 1 {
+	if rxWindow == 0 || rxWindow == 1 || rxWindow == 3 {
 		if err := setTXInfoForRX1(ctx); err != nil {
 			return err
 		}
 	}
 
-	if rxWindow == 0 || rxWindow == 2 {
+	if rxWindow == 0 || rxWindow == 2 || rxWindow == 3 {
 		if err := setTXInfoForRX2(ctx); err != nil {
 			return err
 		}
@@ -120,10 +122,8 @@ func setTXInfoForRX1(ctx *joinContext) error {
 	txInfo.Frequency = uint32(freq)
 
 	// set tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+		return err
 	}
 
 	// set timestamp
@@ -162,10 +162,8 @@ func setTXInfoForRX2(ctx *joinContext) error {
 	}
 
 	// set tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+		return err
 	}
 
 	// set timestamp
@@ -219,12 +217,22 @@ func sendJoinAcceptResponse(ctx *joinContext) error {
 		return errors.Wrap(err, "send downlink frame error")
 	}
 
+	if err := dutycycle.LogDownlinkFrame(storage.RedisPool(), ctx.DownlinkFrames[0]); err != nil {
+		log.WithError(err).Error("log gateway duty-cycle airtime error")
+	}
+
 	// log frame
 	if err := framelog.LogDownlinkFrameForGateway(storage.RedisPool(), ctx.DownlinkFrames[0]); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
 	}
 
-	if err := framelog.LogDownlinkFrameForDevEUI(storage.RedisPool(), ctx.DeviceSession.DevEUI, ctx.DownlinkFrames[0]); err != nil {
+	// the first frame is the RX1 frame, unless only RX2 is used
+	rxWindowUsed := ns.RXWindow_RX1
+	if rxWindow == 2 {
+		rxWindowUsed = ns.RXWindow_RX2
+	}
+
+	if err := framelog.LogDownlinkFrameForDevEUI(storage.RedisPool(), ctx.DeviceSession.DevEUI, ctx.DownlinkFrames[0], rxWindowUsed, ns.MACCommandTransport_NO_MAC_COMMANDS); err != nil {
 		log.WithError(err).Error("log downlink frame for device error")
 	}
 
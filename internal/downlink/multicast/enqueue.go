@@ -7,7 +7,9 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
+	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/downlink/data/classb"
+	"github.com/brocaar/loraserver/internal/dutycycle"
 	"github.com/brocaar/loraserver/internal/gps"
 	"github.com/brocaar/loraserver/internal/storage"
 )
@@ -27,6 +29,18 @@ func EnqueueQueueItem(p *redis.Pool, db sqlx.Ext, qi storage.MulticastQueueItem)
 		return ErrInvalidFCnt
 	}
 
+	// Validate the payload size against the data-rate of the multicast-group
+	// so that the caller gets feedback instead of discarding the payload
+	// when it is being scheduled.
+	maxSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex("", "", mg.DR)
+	if err != nil {
+		return errors.Wrap(err, "get max payload-size for data-rate index error")
+	}
+
+	if len(qi.FRMPayload) > maxSize.N {
+		return ErrMaxPayloadSizeExceeded
+	}
+
 	mg.FCnt = qi.FCnt + 1
 	if err := storage.UpdateMulticastGroup(db, &mg); err != nil {
 		return errors.Wrap(err, "update multicast-group error")
@@ -70,7 +84,9 @@ func EnqueueQueueItem(p *redis.Pool, db sqlx.Ext, qi storage.MulticastQueueItem)
 		}
 	}
 
-	// for each gateway the use the next ping-slot
+	// all gateways transmit the frame in the same ping-slot, unless the
+	// frequency is within a duty-cycle constrained sub-band, in which case
+	// each gateway uses the next ping-slot
 	if mg.GroupType == storage.MulticastGroupB {
 		var pingSlotNb int
 		if mg.PingSlotPeriod != 0 {
@@ -86,10 +102,14 @@ func EnqueueQueueItem(p *redis.Pool, db sqlx.Ext, qi storage.MulticastQueueItem)
 			scheduleTS = gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
 		}
 
-		for _, gatewayID := range gatewayIDs {
-			scheduleTS, err = classb.GetNextPingSlotAfter(scheduleTS, mg.MCAddr, pingSlotNb)
-			if err != nil {
-				return errors.Wrap(err, "get next ping-slot after error")
+		_, stagger := dutycycle.GetSubBand(mg.Frequency)
+
+		for i, gatewayID := range gatewayIDs {
+			if i == 0 || stagger {
+				scheduleTS, err = classb.GetNextPingSlotAfter(scheduleTS, mg.MCAddr, pingSlotNb)
+				if err != nil {
+					return errors.Wrap(err, "get next ping-slot after error")
+				}
 			}
 
 			qi.EmitAtTimeSinceGPSEpoch = &scheduleTS
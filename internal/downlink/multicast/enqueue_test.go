@@ -10,6 +10,7 @@ import (
 	"github.com/brocaar/lorawan"
 
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 )
@@ -121,6 +122,18 @@ func (ts *EnqueueQueueItemTestCase) TestInvalidFCnt() {
 	assert.Equal(ErrInvalidFCnt, EnqueueQueueItem(storage.RedisPool(), ts.tx, qi))
 }
 
+func (ts *EnqueueQueueItemTestCase) TestMaxPayloadSizeExceeded() {
+	assert := require.New(ts.T())
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       make([]byte, 116),
+	}
+	assert.Equal(ErrMaxPayloadSizeExceeded, EnqueueQueueItem(storage.RedisPool(), ts.tx, qi))
+}
+
 func (ts *EnqueueQueueItemTestCase) TestClassC() {
 	assert := require.New(ts.T())
 	assert.Equal(storage.MulticastGroupC, ts.MulticastGroup.GroupType)
@@ -169,13 +182,48 @@ func (ts *EnqueueQueueItemTestCase) TestClassB() {
 	assert.Len(items, 2)
 	assert.NotNil(items[0].EmitAtTimeSinceGPSEpoch)
 	assert.NotNil(items[1].EmitAtTimeSinceGPSEpoch)
-	assert.NotEqual(items[0].ScheduleAt, items[1].ScheduleAt)
+
+	// all gateways use the same ping-slot
+	assert.Equal(*items[0].EmitAtTimeSinceGPSEpoch, *items[1].EmitAtTimeSinceGPSEpoch)
+	assert.True(items[0].ScheduleAt.Equal(items[1].ScheduleAt))
 
 	mg, err := storage.GetMulticastGroup(ts.tx, ts.MulticastGroup.ID, false)
 	assert.NoError(err)
 	assert.Equal(qi.FCnt+1, mg.FCnt)
 }
 
+func (ts *EnqueueQueueItemTestCase) TestClassBDutyCycle() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.GatewayDutyCycleEnabled = true
+	assert.NoError(dutycycle.Setup(conf))
+	defer dutycycle.Setup(test.GetConfig())
+
+	ts.MulticastGroup.PingSlotPeriod = 16
+	ts.MulticastGroup.GroupType = storage.MulticastGroupB
+	assert.NoError(storage.UpdateMulticastGroup(ts.tx, &ts.MulticastGroup))
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+	}
+	assert.NoError(EnqueueQueueItem(storage.RedisPool(), ts.tx, qi))
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(items, 2)
+	assert.NotNil(items[0].EmitAtTimeSinceGPSEpoch)
+	assert.NotNil(items[1].EmitAtTimeSinceGPSEpoch)
+
+	// the frequency is within a duty-cycle constrained sub-band, each
+	// gateway uses the next ping-slot
+	assert.NotEqual(*items[0].EmitAtTimeSinceGPSEpoch, *items[1].EmitAtTimeSinceGPSEpoch)
+	assert.NotEqual(items[0].ScheduleAt, items[1].ScheduleAt)
+}
+
 func TestEnqueueQueueItem(t *testing.T) {
 	suite.Run(t, new(EnqueueQueueItemTestCase))
 }
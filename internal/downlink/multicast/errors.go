@@ -4,5 +4,6 @@ import "github.com/pkg/errors"
 
 // Errors
 var (
-	ErrInvalidFCnt = errors.New("invalid frame-counter value")
+	ErrInvalidFCnt            = errors.New("invalid frame-counter value")
+	ErrMaxPayloadSizeExceeded = errors.New("maximum payload size exceeded")
 )
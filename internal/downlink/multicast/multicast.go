@@ -5,15 +5,19 @@ import (
 	"encoding/binary"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
 	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/storage"
@@ -22,6 +26,16 @@ import (
 
 var errAbort = errors.New("")
 
+// DownlinkStatus holds the status of a handled multicast queue-item. As the
+// queue-item is handled within a database transaction, the status must be
+// sent to the application-server (using SendDownlinkStatus) after the
+// transaction has been committed.
+type DownlinkStatus struct {
+	RoutingProfileID   uuid.UUID
+	MulticastQueueItem storage.MulticastQueueItem
+	Status             as.DownlinkStatus
+}
+
 type multicastContext struct {
 	Token              uint16
 	DB                 sqlx.Ext
@@ -29,6 +43,7 @@ type multicastContext struct {
 	MulticastQueueItem storage.MulticastQueueItem
 	TXInfo             gw.DownlinkTXInfo
 	PHYPayload         lorawan.PHYPayload
+	DownlinkStatus     *DownlinkStatus
 }
 
 var multicastTasks = []func(*multicastContext) error{
@@ -39,6 +54,7 @@ var multicastTasks = []func(*multicastContext) error{
 	setTXInfo,
 	setPHYPayload,
 	sendDownlinkData,
+	setDownlinkStatusTransmitted,
 }
 
 var (
@@ -62,8 +78,9 @@ func Setup(conf config.Config) error {
 }
 
 // HandleScheduleNextQueueItem handles the scheduling of the next queue-item
-// for the given multicast-group.
-func HandleScheduleNextQueueItem(db sqlx.Ext, mg storage.MulticastGroup) error {
+// for the given multicast-group. It returns the downlink status to send
+// to the application-server (if any).
+func HandleScheduleNextQueueItem(db sqlx.Ext, mg storage.MulticastGroup) (*DownlinkStatus, error) {
 	ctx := multicastContext{
 		DB:             db,
 		MulticastGroup: mg,
@@ -72,17 +89,18 @@ func HandleScheduleNextQueueItem(db sqlx.Ext, mg storage.MulticastGroup) error {
 	for _, t := range multicastTasks {
 		if err := t(&ctx); err != nil {
 			if err == errAbort {
-				return nil
+				return ctx.DownlinkStatus, nil
 			}
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return ctx.DownlinkStatus, nil
 }
 
 // HandleScheduleQueueItem handles the scheduling of the given queue-item.
-func HandleScheduleQueueItem(db sqlx.Ext, qi storage.MulticastQueueItem) error {
+// It returns the downlink status to send to the application-server (if any).
+func HandleScheduleQueueItem(db sqlx.Ext, qi storage.MulticastQueueItem) (*DownlinkStatus, error) {
 	ctx := multicastContext{
 		DB:                 db,
 		MulticastQueueItem: qi,
@@ -91,13 +109,13 @@ func HandleScheduleQueueItem(db sqlx.Ext, qi storage.MulticastQueueItem) error {
 	for _, t := range multicastTasks {
 		if err := t(&ctx); err != nil {
 			if err == errAbort {
-				return nil
+				return ctx.DownlinkStatus, nil
 			}
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return ctx.DownlinkStatus, nil
 }
 
 func getMulticastGroup(ctx *multicastContext) error {
@@ -142,6 +160,8 @@ func validatePayloadSize(ctx *multicastContext) error {
 			"frm_payload_size":     len(ctx.MulticastQueueItem.FRMPayload),
 		}).Error("payload exceeds max size for data-rate")
 
+		setDownlinkStatus(ctx, as.DownlinkStatus_DISCARDED_SIZE)
+
 		return errAbort
 	}
 
@@ -172,10 +192,8 @@ func setTXInfo(ctx *multicastContext) error {
 		return errors.Wrap(err, "set data-rate error")
 	}
 
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(ctx.MulticastGroup.Frequency))
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+		return err
 	}
 
 	ctx.TXInfo = txInfo
@@ -224,9 +242,49 @@ func sendDownlinkData(ctx *multicastContext) error {
 		return errors.Wrap(err, "send downlink frame to gateway error")
 	}
 
+	if err := dutycycle.LogDownlinkFrame(storage.RedisPool(), downlinkFrame); err != nil {
+		log.WithError(err).Error("log gateway duty-cycle airtime error")
+	}
+
 	if err := framelog.LogDownlinkFrameForGateway(storage.RedisPool(), downlinkFrame); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
 	}
 
 	return nil
 }
+
+// setDownlinkStatusTransmitted sets the status to notify the
+// application-server that the multicast queue-item was sent to the gateway.
+func setDownlinkStatusTransmitted(ctx *multicastContext) error {
+	setDownlinkStatus(ctx, as.DownlinkStatus_TRANSMITTED)
+	return nil
+}
+
+func setDownlinkStatus(ctx *multicastContext, s as.DownlinkStatus) {
+	ctx.DownlinkStatus = &DownlinkStatus{
+		RoutingProfileID:   ctx.MulticastGroup.RoutingProfileID,
+		MulticastQueueItem: ctx.MulticastQueueItem,
+		Status:             s,
+	}
+}
+
+// SendDownlinkStatus notifies the application-server of the multicast-group
+// about the status of the multicast queue-item. Errors are logged, as these
+// must not affect the scheduling of the multicast queue.
+func SendDownlinkStatus(db sqlx.Queryer, s DownlinkStatus) {
+	if err := func() error {
+		rp, err := storage.GetAndCacheRoutingProfile(db, s.RoutingProfileID)
+		if err != nil {
+			return errors.Wrap(err, "get routing-profile error")
+		}
+
+		asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+		if err != nil {
+			return errors.Wrap(err, "get application-server client error")
+		}
+
+		return storage.SendMulticastDownlinkStatus(asClient, s.MulticastQueueItem, s.Status)
+	}(); err != nil {
+		log.WithError(err).WithField("multicast_group_id", s.MulticastQueueItem.MulticastGroupID).Error("send multicast downlink status error")
+	}
+}
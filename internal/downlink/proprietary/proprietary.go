@@ -5,13 +5,17 @@ import (
 	"encoding/binary"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
+	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/helpers"
+	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
 
@@ -74,13 +78,6 @@ func setToken(ctx *proprietaryContext) error {
 }
 
 func sendProprietaryDown(ctx *proprietaryContext) error {
-	var txPower int
-	if downlinkTXPower != -1 {
-		txPower = downlinkTXPower
-	} else {
-		txPower = band.Band().GetDownlinkTXPower(ctx.Frequency)
-	}
-
 	phy := lorawan.PHYPayload{
 		MHDR: lorawan.MHDR{
 			Major: lorawan.LoRaWANR1,
@@ -98,7 +95,6 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 		txInfo := gw.DownlinkTXInfo{
 			GatewayId: mac[:],
 			Frequency: uint32(ctx.Frequency),
-			Power:     int32(txPower),
 
 			Timing: gw.DownlinkTiming_IMMEDIATELY,
 			TimingInfo: &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
@@ -111,6 +107,10 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 			return errors.Wrap(err, "set downlink tx-info data-rate error")
 		}
 
+		if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), &txInfo, downlinkTXPower); err != nil {
+			return err
+		}
+
 		// for LoRa, set the iPol value
 		if txInfo.Modulation == common.Modulation_LORA {
 			modInfo := txInfo.GetLoraModulationInfo()
@@ -119,13 +119,23 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 			}
 		}
 
-		if err := gateway.Backend().SendTXPacket(gw.DownlinkFrame{
+		downlinkFrame := gw.DownlinkFrame{
 			Token:      uint32(ctx.Token),
 			TxInfo:     &txInfo,
 			PhyPayload: phyB,
-		}); err != nil {
+		}
+
+		if err := gateway.Backend().SendTXPacket(downlinkFrame); err != nil {
 			return errors.Wrap(err, "send tx packet to gateway error")
 		}
+
+		if err := dutycycle.LogDownlinkFrame(storage.RedisPool(), downlinkFrame); err != nil {
+			log.WithError(err).Error("log gateway duty-cycle airtime error")
+		}
+
+		if err := framelog.LogDownlinkFrameForGateway(storage.RedisPool(), downlinkFrame); err != nil {
+			log.WithError(err).Error("log downlink frame for gateway error")
+		}
 	}
 
 	return nil
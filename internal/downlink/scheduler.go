@@ -1,41 +1,93 @@
 package downlink
 
 import (
+	"sync"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
 	"github.com/brocaar/loraserver/internal/downlink/data"
 	"github.com/brocaar/loraserver/internal/downlink/multicast"
 	"github.com/brocaar/loraserver/internal/storage"
 )
 
+var (
+	schedulerStop = make(chan struct{})
+	schedulerWG   sync.WaitGroup
+)
+
 // DeviceQueueSchedulerLoop starts an infinit loop calling the scheduler loop for Class-B
-// and Class-C sheduling.
+// and Class-C sheduling. The loop returns after StopSchedulers has been called.
 func DeviceQueueSchedulerLoop() {
+	schedulerWG.Add(1)
+	defer schedulerWG.Done()
+
 	for {
 		log.Debug("running class-b / class-c scheduler batch")
 		if err := ScheduleDeviceQueueBatch(schedulerBatchSize); err != nil {
 			log.WithError(err).Error("class-b / class-c scheduler error")
 		}
-		time.Sleep(schedulerInterval)
+
+		select {
+		case <-schedulerStop:
+			return
+		case <-time.After(schedulerInterval):
+		}
 	}
 }
 
 // MulticastQueueSchedulerLoop starts an infinit loop calling the multicast
-// scheduler loop.
+// scheduler loop. The loop returns after StopSchedulers has been called.
 func MulticastQueueSchedulerLoop() {
+	schedulerWG.Add(1)
+	defer schedulerWG.Done()
+
 	for {
 		log.Debug("running multicast scheduler batch")
 		if err := ScheduleMulticastQueueBatch(schedulerBatchSize); err != nil {
 			log.WithError(err).Error("multicast scheduler error")
 		}
-		time.Sleep(schedulerInterval)
+
+		select {
+		case <-schedulerStop:
+			return
+		case <-time.After(schedulerInterval):
+		}
 	}
 }
 
+// DeviceQueueExpiryLoop starts an infinit loop removing the expired
+// device-queue items (e.g. of devices which do not uplink anymore). The loop
+// returns after StopSchedulers has been called.
+func DeviceQueueExpiryLoop() {
+	schedulerWG.Add(1)
+	defer schedulerWG.Done()
+
+	for {
+		log.Debug("running device-queue expiry batch")
+		if err := ExpireDeviceQueueBatch(schedulerBatchSize); err != nil {
+			log.WithError(err).Error("device-queue expiry error")
+		}
+
+		select {
+		case <-schedulerStop:
+			return
+		case <-time.After(deviceQueueExpiryInterval):
+		}
+	}
+}
+
+// StopSchedulers stops the scheduler loops and waits until the batches in
+// progress have completed.
+func StopSchedulers() {
+	close(schedulerStop)
+	schedulerWG.Wait()
+}
+
 // ScheduleDeviceQueueBatch schedules a downlink batch (Class-B or Class-C).
 func ScheduleDeviceQueueBatch(size int) error {
 	return storage.Transaction(func(tx sqlx.Ext) error {
@@ -61,9 +113,71 @@ func ScheduleDeviceQueueBatch(size int) error {
 	})
 }
 
+// ExpireDeviceQueueBatch removes a batch of expired device-queue items and
+// notifies the application-server once the transaction has been committed.
+func ExpireDeviceQueueBatch(size int) error {
+	var expired []storage.DeviceQueueItem
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		// this locks the selected queue-items so that this query can be
+		// executed by other instances in parallel.
+		items, err := storage.GetExpiredDeviceQueueItems(tx, size)
+		if err != nil {
+			return errors.Wrap(err, "get expired device-queue items error")
+		}
+
+		for _, qi := range items {
+			if err := storage.DeleteDeviceQueueItem(tx, qi.ID); err != nil {
+				return errors.Wrap(err, "delete device-queue item error")
+			}
+		}
+
+		expired = items
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, qi := range expired {
+		log.WithFields(log.Fields{
+			"dev_eui":                qi.DevEUI,
+			"device_queue_item_fcnt": qi.FCnt,
+			"expires_at":             qi.ExpiresAt,
+		}).Warning("device-queue item discarded as it expired")
+
+		if err := sendDownlinkStatusExpired(storage.DB(), qi); err != nil {
+			log.WithError(err).WithField("dev_eui", qi.DevEUI).Error("send downlink status error")
+		}
+	}
+
+	return nil
+}
+
+func sendDownlinkStatusExpired(db sqlx.Queryer, qi storage.DeviceQueueItem) error {
+	d, err := storage.GetDevice(db, qi.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device error")
+	}
+
+	rp, err := storage.GetAndCacheRoutingProfile(db, d.RoutingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	return storage.SendDownlinkStatus(asClient, qi, as.DownlinkStatus_EXPIRED)
+}
+
 // ScheduleMulticastQueueBatch schedules a donwlink multicast batch (Class-B & -C).
 func ScheduleMulticastQueueBatch(size int) error {
-	return storage.Transaction(func(tx sqlx.Ext) error {
+	var statuses []multicast.DownlinkStatus
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
 		// this locks the selected queue-items so that this query can be
 		// executed by other instances in parallel.
 		multicastQueueItems, err := storage.GetSchedulableMulticastQueueItems(tx, size)
@@ -72,15 +186,30 @@ func ScheduleMulticastQueueBatch(size int) error {
 		}
 
 		for _, qi := range multicastQueueItems {
-			err := multicast.HandleScheduleQueueItem(tx, qi)
+			s, err := multicast.HandleScheduleQueueItem(tx, qi)
 			if err != nil {
 				log.WithFields(log.Fields{
 					"multicast_group_id": qi.MulticastGroupID,
 					"id":                 qi.ID,
 				}).WithError(err).Error("schedule multicast queue-item error")
 			}
+
+			if s != nil {
+				statuses = append(statuses, *s)
+			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// the application-server is notified after the commit, so that it does
+	// not block the transaction and only committed changes are reported
+	for _, s := range statuses {
+		multicast.SendDownlinkStatus(storage.DB(), s)
+	}
+
+	return nil
 }
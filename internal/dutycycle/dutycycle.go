@@ -0,0 +1,227 @@
+// Package dutycycle implements the accounting of the gateway downlink
+// airtime per regulatory sub-band, so that gateways that exhausted their
+// duty-cycle budget can be skipped for downlink transmissions.
+package dutycycle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/airtime"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+const gatewayAirtimeKeyTempl = "lora:ns:gw:%s:dutycycle:%s"
+
+// window defines the sliding window over which the duty-cycle is
+// calculated.
+const window = time.Hour
+
+// SubBand defines a regulatory sub-band with its duty-cycle limit.
+type SubBand struct {
+	Name         string
+	MinFrequency int
+	MaxFrequency int
+	DutyCycle    float64
+}
+
+// Budget returns the airtime a gateway is allowed to transmit within the
+// sliding window for this sub-band.
+func (s SubBand) Budget() time.Duration {
+	return time.Duration(s.DutyCycle * float64(window))
+}
+
+// SubBandBudget contains the used and remaining airtime of a gateway for a
+// sub-band.
+type SubBandBudget struct {
+	SubBand   SubBand
+	Used      time.Duration
+	Remaining time.Duration
+}
+
+// euSubBands contains the ETSI EN 300 220 sub-bands used by EU868.
+var euSubBands = []SubBand{
+	{Name: "g", MinFrequency: 863000000, MaxFrequency: 868000000, DutyCycle: 0.01},
+	{Name: "g1", MinFrequency: 868000000, MaxFrequency: 868600000, DutyCycle: 0.01},
+	{Name: "g2", MinFrequency: 868700000, MaxFrequency: 869200000, DutyCycle: 0.001},
+	{Name: "g3", MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 0.1},
+	{Name: "g4", MinFrequency: 869700000, MaxFrequency: 870000000, DutyCycle: 0.01},
+}
+
+// subBands contains the sub-bands for which the duty-cycle is tracked. When
+// empty, the duty-cycle accounting is disabled.
+var subBands []SubBand
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	subBands = nil
+
+	if !conf.NetworkServer.NetworkSettings.GatewayDutyCycleEnabled {
+		return nil
+	}
+
+	switch conf.NetworkServer.Band.Name {
+	case loraband.EU_863_870, loraband.EU868:
+		subBands = euSubBands
+	}
+
+	return nil
+}
+
+// GetSubBand returns the sub-band for the given frequency. It returns false
+// when the frequency is not within a tracked sub-band.
+func GetSubBand(frequency int) (SubBand, bool) {
+	for _, sb := range subBands {
+		if frequency >= sb.MinFrequency && frequency <= sb.MaxFrequency {
+			return sb, true
+		}
+	}
+	return SubBand{}, false
+}
+
+// LogDownlinkFrame adds the airtime of the given downlink frame to the
+// TX airtime metrics of the gateway and to the used airtime of the gateway
+// for the sub-band of the frame.
+func LogDownlinkFrame(p *redis.Pool, frame gw.DownlinkFrame) error {
+	if frame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	d, err := airtime.GetDownlinkAirtime(frame)
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], frame.TxInfo.GatewayId)
+
+	if err := saveTXAirtimeMetrics(p, gatewayID, d); err != nil {
+		return err
+	}
+
+	sb, ok := GetSubBand(int(frame.TxInfo.Frequency))
+	if !ok {
+		return nil
+	}
+
+	return addAirtime(p, gatewayID, sb, time.Now(), d)
+}
+
+// saveTXAirtimeMetrics adds the given airtime (in milliseconds) to the TX
+// airtime metrics of the gateway and the network.
+func saveTXAirtimeMetrics(p *redis.Pool, gatewayID lorawan.EUI64, d time.Duration) error {
+	metrics := storage.MetricsRecord{
+		Time: time.Now(),
+		Metrics: map[string]float64{
+			"tx_airtime_ms": float64(d) / float64(time.Millisecond),
+		},
+	}
+
+	if err := storage.SaveMetrics(p, "gw:"+gatewayID.String(), metrics); err != nil {
+		return errors.Wrap(err, "save metrics error")
+	}
+
+	if err := storage.SaveMetrics(p, "network", metrics); err != nil {
+		return errors.Wrap(err, "save network metrics error")
+	}
+
+	return nil
+}
+
+func addAirtime(p *redis.Pool, gatewayID lorawan.EUI64, sb SubBand, ts time.Time, d time.Duration) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayAirtimeKeyTempl, gatewayID, sb.Name)
+	member := fmt.Sprintf("%d:%d", ts.UnixNano(), int64(d))
+
+	c.Send("MULTI")
+	c.Send("ZADD", key, toMillis(ts), member)
+	c.Send("ZREMRANGEBYSCORE", key, "-inf", toMillis(ts.Add(-window)))
+	c.Send("PEXPIRE", key, int64(window/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "add gateway airtime error")
+	}
+
+	return nil
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// GetSubBandBudget returns the used and remaining airtime of the given
+// gateway and sub-band within the sliding window.
+func GetSubBandBudget(p *redis.Pool, gatewayID lorawan.EUI64, sb SubBand) (SubBandBudget, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayAirtimeKeyTempl, gatewayID, sb.Name)
+	members, err := redis.Strings(c.Do("ZRANGEBYSCORE", key, toMillis(time.Now().Add(-window)), "+inf"))
+	if err != nil {
+		return SubBandBudget{}, errors.Wrap(err, "get gateway airtime error")
+	}
+
+	budget := SubBandBudget{
+		SubBand: sb,
+	}
+
+	for _, m := range members {
+		parts := strings.SplitN(m, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		d, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		budget.Used += time.Duration(d)
+	}
+
+	if budget.Used < sb.Budget() {
+		budget.Remaining = sb.Budget() - budget.Used
+	}
+
+	return budget, nil
+}
+
+// GetBudgets returns the used and remaining airtime of the given gateway
+// for all the tracked sub-bands.
+func GetBudgets(p *redis.Pool, gatewayID lorawan.EUI64) ([]SubBandBudget, error) {
+	var out []SubBandBudget
+	for _, sb := range subBands {
+		budget, err := GetSubBandBudget(p, gatewayID, sb)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, budget)
+	}
+	return out, nil
+}
+
+// HasBudget returns true when the given gateway has budget left for
+// transmitting on the given frequency. It always returns true when the
+// frequency is not within a tracked sub-band.
+func HasBudget(p *redis.Pool, gatewayID lorawan.EUI64, frequency int) (bool, error) {
+	sb, ok := GetSubBand(frequency)
+	if !ok {
+		return true, nil
+	}
+
+	budget, err := GetSubBandBudget(p, gatewayID, sb)
+	if err != nil {
+		return false, err
+	}
+
+	return budget.Remaining > 0, nil
+}
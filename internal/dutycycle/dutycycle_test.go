@@ -0,0 +1,61 @@
+package dutycycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/internal/config"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+func TestGetSubBand(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	conf.NetworkServer.Band.Name = loraband.EU_863_870
+	conf.NetworkServer.NetworkSettings.GatewayDutyCycleEnabled = true
+	assert.NoError(Setup(conf))
+
+	tests := []struct {
+		Frequency       int
+		ExpectedSubBand string
+		ExpectedOK      bool
+	}{
+		{Frequency: 868100000, ExpectedSubBand: "g1", ExpectedOK: true},
+		{Frequency: 867100000, ExpectedSubBand: "g", ExpectedOK: true},
+		{Frequency: 869525000, ExpectedSubBand: "g3", ExpectedOK: true},
+		{Frequency: 869300000, ExpectedOK: false},
+	}
+
+	for _, tst := range tests {
+		sb, ok := GetSubBand(tst.Frequency)
+		assert.Equal(tst.ExpectedOK, ok)
+		assert.Equal(tst.ExpectedSubBand, sb.Name)
+	}
+
+	assert.Equal(36*time.Second, euSubBands[0].Budget())
+	assert.Equal(360*time.Second, euSubBands[3].Budget())
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.NetworkServer.NetworkSettings.GatewayDutyCycleEnabled = false
+		assert.NoError(Setup(conf))
+
+		_, ok := GetSubBand(868100000)
+		assert.False(ok)
+	})
+
+	t.Run("Unsupported band", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.NetworkServer.Band.Name = loraband.US_902_928
+		conf.NetworkServer.NetworkSettings.GatewayDutyCycleEnabled = true
+		assert.NoError(Setup(conf))
+
+		_, ok := GetSubBand(923300000)
+		assert.False(ok)
+	})
+}
@@ -11,28 +11,37 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/airtime"
 	"github.com/brocaar/lorawan"
 )
 
 const (
 	gatewayFrameLogUplinkPubSubKeyTempl   = "lora:ns:gw:%s:pubsub:frame:uplink"
 	gatewayFrameLogDownlinkPubSubKeyTempl = "lora:ns:gw:%s:pubsub:frame:downlink"
+	gatewayFrameLogTXAckPubSubKeyTempl    = "lora:ns:gw:%s:pubsub:frame:txack"
 	deviceFrameLogUplinkPubSubKeyTempl    = "lora:ns:device:%s:pubsub:frame:uplink"
 	deviceFrameLogDownlinkPubSubKeyTempl  = "lora:ns:device:%s:pubsub:frame:downlink"
+	deviceFrameLogRejectedPubSubKeyTempl  = "lora:ns:device:%s:pubsub:frame:rejected"
 )
 
-// FrameLog contains either an uplink or downlink frame.
+// FrameLog contains either an uplink, downlink or rejected uplink frame or
+// a downlink TX acknowledgement. DownlinkRXWindow and
+// DownlinkMACCommandTransport are only set for device downlink frames.
+// Airtime is set for uplink and downlink frames.
 type FrameLog struct {
-	UplinkFrame   *gw.UplinkFrameSet
-	DownlinkFrame *gw.DownlinkFrame
+	UplinkFrame                 *gw.UplinkFrameSet
+	DownlinkFrame               *gw.DownlinkFrame
+	DownlinkRXWindow            ns.RXWindow
+	DownlinkMACCommandTransport ns.MACCommandTransport
+	RejectedUplinkFrame         *ns.RejectedUplinkFrameSet
+	DownlinkTXAck               *gw.DownlinkTXAck
+	Airtime                     time.Duration
 }
 
 // LogUplinkFrameForGateways logs the given frame to all the gateway pub-sub keys.
 func LogUplinkFrameForGateways(p *redis.Pool, uplinkFrameSet gw.UplinkFrameSet) error {
-	c := p.Get()
-	defer c.Close()
-
-	c.Send("MULTI")
+	var msgs []message
 	for _, rx := range uplinkFrameSet.RxInfo {
 		var id lorawan.EUI64
 		copy(id[:], rx.GatewayId)
@@ -48,11 +57,14 @@ func LogUplinkFrameForGateways(p *redis.Pool, uplinkFrameSet gw.UplinkFrameSet)
 			return errors.Wrap(err, "marshal uplink frame-set error")
 		}
 
-		key := fmt.Sprintf(gatewayFrameLogUplinkPubSubKeyTempl, id)
-		c.Send("PUBLISH", key, b)
+		msgs = append(msgs, message{
+			pool: p,
+			key:  fmt.Sprintf(gatewayFrameLogUplinkPubSubKeyTempl, id),
+			data: b,
+		})
 	}
-	_, err := c.Do("EXEC")
-	if err != nil {
+
+	if err := publish(msgs...); err != nil {
 		return errors.Wrap(err, "publish frame to gateway channel error")
 	}
 
@@ -64,9 +76,6 @@ func LogDownlinkFrameForGateway(p *redis.Pool, frame gw.DownlinkFrame) error {
 	var id lorawan.EUI64
 	copy(id[:], frame.TxInfo.GatewayId)
 
-	c := p.Get()
-	defer c.Close()
-
 	key := fmt.Sprintf(gatewayFrameLogDownlinkPubSubKeyTempl, id)
 
 	b, err := proto.Marshal(&frame)
@@ -74,27 +83,27 @@ func LogDownlinkFrameForGateway(p *redis.Pool, frame gw.DownlinkFrame) error {
 		return errors.Wrap(err, "marshal downlink frame error")
 	}
 
-	_, err = c.Do("PUBLISH", key, b)
-	if err != nil {
+	if err := publish(message{pool: p, key: key, data: b}); err != nil {
 		return errors.Wrap(err, "publish frame to gateway channel error")
 	}
 	return nil
 }
 
-// LogDownlinkFrameForDevEUI logs the given frame to the device pub-sub key.
-func LogDownlinkFrameForDevEUI(p *redis.Pool, devEUI lorawan.EUI64, frame gw.DownlinkFrame) error {
-	c := p.Get()
-	defer c.Close()
-
+// LogDownlinkFrameForDevEUI logs the given frame, the RX window used and
+// the transport of the mac-commands to the device pub-sub key.
+func LogDownlinkFrameForDevEUI(p *redis.Pool, devEUI lorawan.EUI64, frame gw.DownlinkFrame, rxWindow ns.RXWindow, macCommandTransport ns.MACCommandTransport) error {
 	key := fmt.Sprintf(deviceFrameLogDownlinkPubSubKeyTempl, devEUI)
 
-	b, err := proto.Marshal(&frame)
+	b, err := proto.Marshal(&ns.DeviceDownlinkFrameLog{
+		DownlinkFrame:       &frame,
+		RxWindow:            rxWindow,
+		MacCommandTransport: macCommandTransport,
+	})
 	if err != nil {
 		return errors.Wrap(err, "marshal downlink frame error")
 	}
 
-	_, err = c.Do("PUBLISH", key, b)
-	if err != nil {
+	if err := publish(message{pool: p, key: key, data: b}); err != nil {
 		return errors.Wrap(err, "publish frame to device channel error")
 	}
 	return nil
@@ -102,44 +111,90 @@ func LogDownlinkFrameForDevEUI(p *redis.Pool, devEUI lorawan.EUI64, frame gw.Dow
 
 // LogUplinkFrameForDevEUI logs the given frame to the pub-sub key of the given DevEUI.
 func LogUplinkFrameForDevEUI(p *redis.Pool, devEUI lorawan.EUI64, frame gw.UplinkFrameSet) error {
-	c := p.Get()
-	defer c.Close()
-
 	b, err := proto.Marshal(&frame)
 	if err != nil {
 		return errors.Wrap(err, "marshal uplink frame error")
 	}
 
 	key := fmt.Sprintf(deviceFrameLogUplinkPubSubKeyTempl, devEUI)
-	_, err = c.Do("PUBLISH", key, b)
+	if err := publish(message{pool: p, key: key, data: b}); err != nil {
+		return errors.Wrap(err, "publish frame to device channel error")
+	}
+	return nil
+}
+
+// LogRejectedUplinkFrameForDevEUI logs the given rejected frame, together
+// with the reason of the rejection, to the pub-sub key of the given DevEUI.
+func LogRejectedUplinkFrameForDevEUI(p *redis.Pool, devEUI lorawan.EUI64, frame gw.UplinkFrameSet, reason string) error {
+	b, err := proto.Marshal(&ns.RejectedUplinkFrameSet{
+		UplinkFrameSet: &frame,
+		Reason:         reason,
+	})
 	if err != nil {
+		return errors.Wrap(err, "marshal rejected uplink frame error")
+	}
+
+	key := fmt.Sprintf(deviceFrameLogRejectedPubSubKeyTempl, devEUI)
+	if err := publish(message{pool: p, key: key, data: b}); err != nil {
 		return errors.Wrap(err, "publish frame to device channel error")
 	}
 	return nil
 }
 
+// LogDownlinkTXAckForGateway logs the given downlink TX acknowledgement to
+// the gateway pub-sub key.
+func LogDownlinkTXAckForGateway(p *redis.Pool, ack gw.DownlinkTXAck) error {
+	var id lorawan.EUI64
+	copy(id[:], ack.GatewayId)
+
+	b, err := proto.Marshal(&ack)
+	if err != nil {
+		return errors.Wrap(err, "marshal downlink tx ack error")
+	}
+
+	key := fmt.Sprintf(gatewayFrameLogTXAckPubSubKeyTempl, id)
+	if err := publish(message{pool: p, key: key, data: b}); err != nil {
+		return errors.Wrap(err, "publish downlink tx ack to gateway channel error")
+	}
+	return nil
+}
+
 // GetFrameLogForGateway subscribes to the uplink and downlink frame logs
-// for the given gateway and sends this to the given channel.
+// and the downlink TX acknowledgements for the given gateway and sends this
+// to the given channel.
 func GetFrameLogForGateway(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64, frameLogChan chan FrameLog) error {
 	uplinkKey := fmt.Sprintf(gatewayFrameLogUplinkPubSubKeyTempl, gatewayID)
 	downlinkKey := fmt.Sprintf(gatewayFrameLogDownlinkPubSubKeyTempl, gatewayID)
-	return getFrameLogs(ctx, p, uplinkKey, downlinkKey, frameLogChan)
+	txAckKey := fmt.Sprintf(gatewayFrameLogTXAckPubSubKeyTempl, gatewayID)
+	return getFrameLogs(ctx, p, uplinkKey, downlinkKey, "", txAckKey, false, frameLogChan)
 }
 
-// GetFrameLogForDevice subscribes to the uplink and downlink frame logs
-// for the given device and sends this to the given channel.
+// GetFrameLogForDevice subscribes to the uplink, downlink and rejected
+// uplink frame logs for the given device and sends this to the given channel.
 func GetFrameLogForDevice(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, frameLogChan chan FrameLog) error {
 	uplinkKey := fmt.Sprintf(deviceFrameLogUplinkPubSubKeyTempl, devEUI)
 	downlinkKey := fmt.Sprintf(deviceFrameLogDownlinkPubSubKeyTempl, devEUI)
-	return getFrameLogs(ctx, p, uplinkKey, downlinkKey, frameLogChan)
+	rejectedKey := fmt.Sprintf(deviceFrameLogRejectedPubSubKeyTempl, devEUI)
+	return getFrameLogs(ctx, p, uplinkKey, downlinkKey, rejectedKey, "", true, frameLogChan)
 }
 
-func getFrameLogs(ctx context.Context, p *redis.Pool, uplinkKey, downlinkKey string, frameLogChan chan FrameLog) error {
+// getFrameLogs subscribes to the given keys. The rejectedKey and txAckKey
+// are optional and can be left blank. The deviceDownlink argument must be set
+// when the downlinkKey contains device downlink frames.
+func getFrameLogs(ctx context.Context, p *redis.Pool, uplinkKey, downlinkKey, rejectedKey, txAckKey string, deviceDownlink bool, frameLogChan chan FrameLog) error {
 	c := p.Get()
 	defer c.Close()
 
+	keys := []interface{}{uplinkKey, downlinkKey}
+	if rejectedKey != "" {
+		keys = append(keys, rejectedKey)
+	}
+	if txAckKey != "" {
+		keys = append(keys, txAckKey)
+	}
+
 	psc := redis.PubSubConn{Conn: c}
-	if err := psc.Subscribe(uplinkKey, downlinkKey); err != nil {
+	if err := psc.Subscribe(keys...); err != nil {
 		return errors.Wrap(err, "subscribe error")
 	}
 
@@ -149,11 +204,16 @@ func getFrameLogs(ctx context.Context, p *redis.Pool, uplinkKey, downlinkKey str
 		for {
 			switch v := psc.Receive().(type) {
 			case redis.Message:
-				fl, err := redisMessageToFrameLog(v, uplinkKey, downlinkKey)
+				fl, err := redisMessageToFrameLog(v, uplinkKey, downlinkKey, rejectedKey, txAckKey, deviceDownlink)
 				if err != nil {
 					log.WithError(err).Error("decode message error")
 				} else {
-					frameLogChan <- fl
+					// never block on a slow subscriber, drop the frame instead
+					select {
+					case frameLogChan <- fl:
+					default:
+						log.WithField("channel", v.Channel).Warning("frame-log subscriber is too slow, dropping frame")
+					}
 				}
 			case redis.Subscription:
 				if v.Count == 0 {
@@ -193,7 +253,7 @@ loop:
 	return <-done
 }
 
-func redisMessageToFrameLog(msg redis.Message, uplinkKey, downlinkKey string) (FrameLog, error) {
+func redisMessageToFrameLog(msg redis.Message, uplinkKey, downlinkKey, rejectedKey, txAckKey string, deviceDownlink bool) (FrameLog, error) {
 	var fl FrameLog
 
 	if msg.Channel == uplinkKey {
@@ -203,12 +263,64 @@ func redisMessageToFrameLog(msg redis.Message, uplinkKey, downlinkKey string) (F
 		}
 	}
 
-	if msg.Channel == downlinkKey {
+	if msg.Channel == downlinkKey && deviceDownlink {
+		var dfl ns.DeviceDownlinkFrameLog
+		if err := proto.Unmarshal(msg.Data, &dfl); err != nil {
+			return fl, errors.Wrap(err, "unmarshal device downlink frame error")
+		}
+		fl.DownlinkFrame = dfl.DownlinkFrame
+		fl.DownlinkRXWindow = dfl.RxWindow
+		fl.DownlinkMACCommandTransport = dfl.MacCommandTransport
+	}
+
+	if msg.Channel == downlinkKey && !deviceDownlink {
 		fl.DownlinkFrame = &gw.DownlinkFrame{}
 		if err := proto.Unmarshal(msg.Data, fl.DownlinkFrame); err != nil {
 			return fl, errors.Wrap(err, "unmarshal downlink frame error")
 		}
 	}
 
+	if rejectedKey != "" && msg.Channel == rejectedKey {
+		fl.RejectedUplinkFrame = &ns.RejectedUplinkFrameSet{}
+		if err := proto.Unmarshal(msg.Data, fl.RejectedUplinkFrame); err != nil {
+			return fl, errors.Wrap(err, "unmarshal rejected uplink frame-set error")
+		}
+	}
+
+	if txAckKey != "" && msg.Channel == txAckKey {
+		fl.DownlinkTXAck = &gw.DownlinkTXAck{}
+		if err := proto.Unmarshal(msg.Data, fl.DownlinkTXAck); err != nil {
+			return fl, errors.Wrap(err, "unmarshal downlink tx ack error")
+		}
+	}
+
+	setAirtime(&fl)
+
 	return fl, nil
 }
+
+// setAirtime sets the airtime of the uplink or downlink frame. Frames for
+// which the airtime can not be calculated (e.g. unknown modulation) are
+// logged without airtime.
+func setAirtime(fl *FrameLog) {
+	var d time.Duration
+	var err error
+
+	switch {
+	case fl.UplinkFrame != nil:
+		d, err = airtime.GetUplinkAirtime(len(fl.UplinkFrame.PhyPayload), fl.UplinkFrame.TxInfo)
+	case fl.RejectedUplinkFrame != nil && fl.RejectedUplinkFrame.UplinkFrameSet != nil:
+		d, err = airtime.GetUplinkAirtime(len(fl.RejectedUplinkFrame.UplinkFrameSet.PhyPayload), fl.RejectedUplinkFrame.UplinkFrameSet.TxInfo)
+	case fl.DownlinkFrame != nil:
+		d, err = airtime.GetDownlinkAirtime(*fl.DownlinkFrame)
+	default:
+		return
+	}
+
+	if err != nil {
+		log.WithError(err).Warning("get frame airtime error")
+		return
+	}
+
+	fl.Airtime = d
+}
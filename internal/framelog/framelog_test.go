@@ -11,6 +11,7 @@ import (
 
 	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
@@ -62,6 +63,8 @@ func (ts *FrameLogTestSuite) TestGetFrameLogForGateway() {
 				ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
 					LoraModulationInfo: &gw.LoRaModulationInfo{
 						SpreadingFactor: 7,
+						Bandwidth:       125,
+						CodeRate:        "4/5",
 					},
 				},
 			},
@@ -75,6 +78,7 @@ func (ts *FrameLogTestSuite) TestGetFrameLogForGateway() {
 		assert.NoError(LogUplinkFrameForGateways(storage.RedisPool(), uplinkFrameSet))
 		frameLog := <-logChannel
 		assert.True(proto.Equal(&uplinkFrameSet, frameLog.UplinkFrame))
+		assert.Equal(30976*time.Microsecond, frameLog.Airtime)
 	})
 
 	ts.T().Run("LogDownlinkFrameForGateway", func(t *testing.T) {
@@ -93,6 +97,20 @@ func (ts *FrameLogTestSuite) TestGetFrameLogForGateway() {
 			DownlinkFrame: &downlinkFrame,
 		}, <-logChannel)
 	})
+
+	ts.T().Run("LogDownlinkTXAckForGateway", func(t *testing.T) {
+		assert := require.New(t)
+		ack := gw.DownlinkTXAck{
+			GatewayId: ts.GatewayID[:],
+			Token:     12345,
+			Error:     "TOO_LATE",
+		}
+
+		assert.NoError(LogDownlinkTXAckForGateway(storage.RedisPool(), ack))
+		frameLog := <-logChannel
+		assert.Nil(frameLog.DownlinkFrame)
+		assert.True(proto.Equal(&ack, frameLog.DownlinkTXAck))
+	})
 }
 
 func (ts *FrameLogTestSuite) TestGetFrameLogForDevice() {
@@ -147,13 +165,36 @@ func (ts *FrameLogTestSuite) TestGetFrameLogForDevice() {
 			},
 		}
 
-		assert.NoError(LogDownlinkFrameForDevEUI(storage.RedisPool(), ts.DevEUI, downlinkFrame))
+		assert.NoError(LogDownlinkFrameForDevEUI(storage.RedisPool(), ts.DevEUI, downlinkFrame, ns.RXWindow_RX2, ns.MACCommandTransport_FRM_PAYLOAD))
 		downlinkFrame.TxInfo.XXX_sizecache = 0
 
 		assert.Equal(FrameLog{
-			DownlinkFrame: &downlinkFrame,
+			DownlinkFrame:               &downlinkFrame,
+			DownlinkRXWindow:            ns.RXWindow_RX2,
+			DownlinkMACCommandTransport: ns.MACCommandTransport_FRM_PAYLOAD,
 		}, <-logChannel)
 	})
+
+	ts.T().Run("LogRejectedUplinkFrameForDevEUI", func(t *testing.T) {
+		assert := require.New(t)
+
+		uplinkFrameSet := gw.UplinkFrameSet{
+			PhyPayload: []byte{1, 2, 3, 4},
+			RxInfo: []*gw.UplinkRXInfo{
+				{
+					GatewayId: ts.GatewayID[:],
+				},
+			},
+		}
+
+		assert.NoError(LogRejectedUplinkFrameForDevEUI(storage.RedisPool(), ts.DevEUI, uplinkFrameSet, "dev-nonce has already been used"))
+		frameLog := <-logChannel
+		assert.Nil(frameLog.UplinkFrame)
+		assert.True(proto.Equal(frameLog.RejectedUplinkFrame, &ns.RejectedUplinkFrameSet{
+			UplinkFrameSet: &uplinkFrameSet,
+			Reason:         "dev-nonce has already been used",
+		}))
+	})
 }
 
 func TestFrameLog(t *testing.T) {
@@ -1,6 +1,7 @@
 package framelog
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/brocaar/loraserver/api/gw"
@@ -17,6 +18,25 @@ func CreateUplinkFrameSet(rxPacket models.RXPacket) (gw.UplinkFrameSet, error) {
 	return gw.UplinkFrameSet{
 		PhyPayload: b,
 		TxInfo:     rxPacket.TXInfo,
-		RxInfo:     rxPacket.RXInfoSet,
+		RxInfo:     RawRXInfoSet(rxPacket.GetAntennaRXInfoSet()),
 	}, nil
 }
+
+// RawRXInfoSet returns a copy of the given rx-info set with the RSSI and SNR
+// calibration offsets reverted, so that the frame-logs contain the values as
+// reported by the gateways. The applied offsets are retained.
+func RawRXInfoSet(rxInfoSet []*gw.UplinkRXInfo) []*gw.UplinkRXInfo {
+	out := make([]*gw.UplinkRXInfo, 0, len(rxInfoSet))
+	for _, rxInfo := range rxInfoSet {
+		if rxInfo.RssiOffset == 0 && rxInfo.LoraSnrOffset == 0 {
+			out = append(out, rxInfo)
+			continue
+		}
+
+		raw := proto.Clone(rxInfo).(*gw.UplinkRXInfo)
+		raw.Rssi -= int32(rxInfo.RssiOffset)
+		raw.LoraSnr -= rxInfo.LoraSnrOffset
+		out = append(out, raw)
+	}
+	return out
+}
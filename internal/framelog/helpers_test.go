@@ -0,0 +1,27 @@
+package framelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/gw"
+)
+
+func TestRawRXInfoSet(t *testing.T) {
+	assert := require.New(t)
+
+	rxInfoSet := []*gw.UplinkRXInfo{
+		{Rssi: -56, RssiOffset: -6, LoraSnr: 3.5, LoraSnrOffset: 1.5},
+		{Rssi: -60, LoraSnr: 3},
+	}
+
+	assert.Equal([]*gw.UplinkRXInfo{
+		{Rssi: -50, RssiOffset: -6, LoraSnr: 2, LoraSnrOffset: 1.5},
+		{Rssi: -60, LoraSnr: 3},
+	}, RawRXInfoSet(rxInfoSet))
+
+	// the corrected values must not be modified
+	assert.EqualValues(-56, rxInfoSet[0].Rssi)
+	assert.Equal(3.5, rxInfoSet[0].LoraSnr)
+}
@@ -0,0 +1,26 @@
+package framelog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	fdc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "framelog_dropped_count",
+		Help: "The number of frame-logs dropped because the async publish buffer was full.",
+	})
+
+	fpec = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "framelog_publish_error_count",
+		Help: "The number of frame-logs that failed to be published by the async writer.",
+	})
+)
+
+func droppedCounter() prometheus.Counter {
+	return fdc
+}
+
+func publishErrorCounter() prometheus.Counter {
+	return fpec
+}
@@ -0,0 +1,205 @@
+package framelog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/config"
+)
+
+const (
+	batchSize     = 100
+	flushInterval = 100 * time.Millisecond
+)
+
+var (
+	writerMux sync.RWMutex
+	writer    *asyncWriter
+)
+
+// Setup configures the package. When async publishing is enabled, a writer
+// is started which publishes the frame-logs in batches.
+func Setup(conf config.Config) error {
+	writerMux.Lock()
+	defer writerMux.Unlock()
+
+	if writer != nil {
+		writer.stop()
+		writer = nil
+	}
+
+	if conf.NetworkServer.FrameLog.Async {
+		writer = newAsyncWriter(conf.NetworkServer.FrameLog.BufferSize)
+		log.WithField("buffer_size", conf.NetworkServer.FrameLog.BufferSize).Info("framelog: async publishing enabled")
+	}
+
+	return nil
+}
+
+// Stop stops the async writer (when enabled) after publishing the remaining
+// buffered frame-logs. Frame-logs published after Stop are dropped.
+func Stop() error {
+	writerMux.RLock()
+	defer writerMux.RUnlock()
+
+	if writer != nil {
+		writer.stop()
+	}
+	return nil
+}
+
+// message holds a frame-log to publish.
+type message struct {
+	pool *redis.Pool
+	key  string
+	data []byte
+}
+
+// publish publishes the given messages directly, or hands them to the
+// async writer when enabled.
+func publish(msgs ...message) error {
+	writerMux.RLock()
+	w := writer
+	writerMux.RUnlock()
+
+	if w != nil {
+		w.enqueue(msgs)
+		return nil
+	}
+
+	return publishMessages(msgs)
+}
+
+// publishMessages publishes the given messages using a single round-trip
+// per Redis pool.
+func publishMessages(msgs []message) error {
+	pools := make(map[*redis.Pool][]message)
+	for _, msg := range msgs {
+		pools[msg.pool] = append(pools[msg.pool], msg)
+	}
+
+	for p, msgs := range pools {
+		if err := publishPipelined(p, msgs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func publishPipelined(p *redis.Pool, msgs []message) error {
+	c := p.Get()
+	defer c.Close()
+
+	for _, msg := range msgs {
+		if err := c.Send("PUBLISH", msg.key, msg.data); err != nil {
+			return errors.Wrap(err, "publish frame-log error")
+		}
+	}
+	if err := c.Flush(); err != nil {
+		return errors.Wrap(err, "publish frame-log error")
+	}
+	for range msgs {
+		if _, err := c.Receive(); err != nil {
+			return errors.Wrap(err, "publish frame-log error")
+		}
+	}
+
+	return nil
+}
+
+// asyncWriter publishes the frame-logs from a bounded buffer. When the
+// buffer is full or when the writer has been stopped, frame-logs are
+// dropped instead of blocking the caller.
+type asyncWriter struct {
+	queue chan message
+	wg    sync.WaitGroup
+
+	mux    sync.RWMutex
+	closed bool
+}
+
+func newAsyncWriter(bufferSize int) *asyncWriter {
+	w := asyncWriter{
+		queue: make(chan message, bufferSize),
+	}
+
+	w.wg.Add(1)
+	go w.run()
+
+	return &w
+}
+
+func (w *asyncWriter) enqueue(msgs []message) {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	if w.closed {
+		droppedCounter().Add(float64(len(msgs)))
+		return
+	}
+
+	for _, msg := range msgs {
+		select {
+		case w.queue <- msg:
+		default:
+			droppedCounter().Inc()
+		}
+	}
+}
+
+// stop closes the buffer and waits until the remaining frame-logs have been
+// published.
+func (w *asyncWriter) stop() {
+	w.mux.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mux.Unlock()
+
+	w.wg.Wait()
+}
+
+func (w *asyncWriter) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []message
+
+	for {
+		select {
+		case msg, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+
+			batch = append(batch, msg)
+			if len(batch) >= batchSize {
+				w.flush(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			w.flush(batch)
+			batch = nil
+		}
+	}
+}
+
+func (w *asyncWriter) flush(batch []message) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := publishMessages(batch); err != nil {
+		publishErrorCounter().Add(float64(len(batch)))
+		log.WithError(err).WithField("count", len(batch)).Error("framelog: publish frame-logs error")
+	}
+}
@@ -0,0 +1,74 @@
+package framelog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+)
+
+func (ts *FrameLogTestSuite) TestAsyncWriter() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.FrameLog.Async = true
+	conf.NetworkServer.FrameLog.BufferSize = 10
+	assert.NoError(Setup(conf))
+	defer Stop()
+
+	logChannel := make(chan FrameLog, 10)
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		GetFrameLogForDevice(cctx, storage.RedisPool(), ts.DevEUI, logChannel)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	frame := gw.UplinkFrameSet{PhyPayload: []byte{1, 2, 3, 4}}
+	assert.NoError(LogUplinkFrameForDevEUI(storage.RedisPool(), ts.DevEUI, frame))
+
+	// remaining frame-logs are published on stop
+	assert.NoError(Stop())
+
+	select {
+	case frameLog := <-logChannel:
+		assert.True(proto.Equal(&frame, frameLog.UplinkFrame))
+	case <-time.After(time.Second):
+		assert.Fail("expected frame-log")
+	}
+}
+
+func TestAsyncWriterDropsWhenFull(t *testing.T) {
+	assert := require.New(t)
+
+	// no writer goroutine is consuming the queue
+	w := asyncWriter{queue: make(chan message, 1)}
+	before := testutil.ToFloat64(droppedCounter())
+
+	w.enqueue([]message{{key: "a"}, {key: "b"}, {key: "c"}})
+
+	assert.Len(w.queue, 1)
+	assert.Equal(before+2, testutil.ToFloat64(droppedCounter()))
+}
+
+func TestAsyncWriterDropsWhenStopped(t *testing.T) {
+	assert := require.New(t)
+
+	w := newAsyncWriter(10)
+	w.stop()
+	before := testutil.ToFloat64(droppedCounter())
+
+	// must not panic on the closed queue
+	w.enqueue([]message{{key: "a"}, {key: "b"}})
+	w.stop()
+
+	assert.Equal(before+2, testutil.ToFloat64(droppedCounter()))
+}
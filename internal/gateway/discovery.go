@@ -0,0 +1,258 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/proprietary"
+	"github.com/brocaar/loraserver/internal/helpers"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// discoveryPingMaxAge defines the max. age of a received discovery ping,
+// pings with an older timestamp are considered as replayed. Pings within
+// this age are only handled once.
+const discoveryPingMaxAge = time.Minute
+
+var (
+	discoveryInterval  time.Duration
+	discoveryFrequency int
+	discoveryDR        int
+	discoveryHMACKey   []byte
+	discoveryRetention time.Duration
+)
+
+func setupDiscovery(conf config.Config) error {
+	c := conf.NetworkServer.Gateway.Discovery
+
+	discoveryInterval = c.Interval
+	discoveryFrequency = c.Frequency
+	discoveryDR = c.DR
+	discoveryRetention = c.Retention
+
+	// The ping must be received by the other gateways, which only listen
+	// on the uplink channels. Default to the first enabled uplink channel.
+	if discoveryFrequency == 0 {
+		channels := band.Band().GetEnabledUplinkChannelIndices()
+		if len(channels) == 0 {
+			return errors.New("no enabled uplink channels for gateway discovery frequency")
+		}
+
+		c, err := band.Band().GetUplinkChannel(channels[0])
+		if err != nil {
+			return errors.Wrap(err, "get uplink channel error")
+		}
+		discoveryFrequency = c.Frequency
+	}
+
+	discoveryHMACKey = nil
+	if c.HMACKey != "" {
+		b, err := hex.DecodeString(c.HMACKey)
+		if err != nil {
+			return errors.Wrap(err, "decode discovery hmac key error")
+		}
+		discoveryHMACKey = b
+	}
+
+	if discoveryInterval > 0 && len(discoveryHMACKey) == 0 {
+		return errors.New("gateway discovery hmac key must be set")
+	}
+
+	return nil
+}
+
+// DiscoveryLoop starts an infinite loop sending the discovery pings for the
+// gateways with discovery enabled and removing the expired ping receptions.
+// It returns immediately when the discovery interval is not configured.
+func DiscoveryLoop() {
+	if discoveryInterval <= 0 {
+		return
+	}
+
+	for {
+		log.Debug("running gateway discovery")
+		if err := sendDiscoveryPings(); err != nil {
+			log.WithError(err).Error("send gateway discovery pings error")
+		}
+		if err := pruneDiscoveryPingRXs(); err != nil {
+			log.WithError(err).Error("prune gateway discovery ping receptions error")
+		}
+		time.Sleep(discoveryInterval)
+	}
+}
+
+// sendDiscoveryPings sends a discovery ping for every gateway with discovery
+// enabled. The lock makes sure that only one network-server instance sends
+// the ping of a gateway within the discovery interval.
+func sendDiscoveryPings() error {
+	gws, err := storage.GetDiscoveryEnabledGateways(storage.DB())
+	if err != nil {
+		return errors.Wrap(err, "get discovery enabled gateways error")
+	}
+
+	for _, g := range gws {
+		locked, err := storage.AcquireGatewayDiscoveryLock(storage.RedisPool(), g.GatewayID, discoveryInterval)
+		if err != nil {
+			return errors.Wrap(err, "acquire gateway discovery lock error")
+		}
+		if !locked {
+			continue
+		}
+
+		if err := sendDiscoveryPing(g.GatewayID, time.Now()); err != nil {
+			log.WithError(err).WithField("gateway_id", g.GatewayID).Error("send gateway discovery ping error")
+			continue
+		}
+		discoveryPingTXCounter().Inc()
+	}
+
+	return nil
+}
+
+func sendDiscoveryPing(gatewayID lorawan.EUI64, ts time.Time) error {
+	macPayload := newDiscoveryPingPayload(gatewayID, ts)
+	mic := discoveryPingMIC(macPayload)
+
+	// the ping must be received by other gateways, thus the polarity is not
+	// inverted
+	return proprietary.Handle(macPayload, mic, []lorawan.EUI64{gatewayID}, false, discoveryFrequency, discoveryDR)
+}
+
+func pruneDiscoveryPingRXs() error {
+	if discoveryRetention <= 0 {
+		return nil
+	}
+
+	count, err := storage.DeleteGatewayDiscoveryPingRXsBefore(storage.DB(), time.Now().Add(-discoveryRetention))
+	if err != nil {
+		return errors.Wrap(err, "delete gateway discovery ping receptions error")
+	}
+
+	if count != 0 {
+		log.WithField("count", count).Info("gateway discovery ping receptions pruned")
+	}
+
+	return nil
+}
+
+// HandleDiscoveryPing handles a (possible) discovery ping received by the
+// given gateways. It returns false when the payload is not a valid discovery
+// ping, in which case it must be handled as a regular proprietary uplink.
+func HandleDiscoveryPing(macPayload []byte, mic lorawan.MIC, rxInfoSet []*gw.UplinkRXInfo) (bool, error) {
+	if len(discoveryHMACKey) == 0 {
+		return false, nil
+	}
+
+	txGatewayID, ts, ok := parseDiscoveryPingPayload(macPayload, mic)
+	if !ok {
+		return false, nil
+	}
+
+	if age := time.Since(ts); age > discoveryPingMaxAge || age < -discoveryPingMaxAge {
+		log.WithFields(log.Fields{
+			"tx_gateway_id": txGatewayID,
+			"timestamp":     ts,
+		}).Warning("gateway discovery ping timestamp out of range")
+		return true, nil
+	}
+
+	// the timestamp acts as frame-counter of the ping, a replay of a ping
+	// within the max. age has the same timestamp and MIC
+	seen, err := storage.SetGatewayDiscoveryPingSeen(storage.RedisPool(), txGatewayID, ts.UnixNano(), mic, 2*discoveryPingMaxAge)
+	if err != nil {
+		return true, errors.Wrap(err, "set gateway discovery ping seen error")
+	}
+	if !seen {
+		log.WithFields(log.Fields{
+			"tx_gateway_id": txGatewayID,
+			"timestamp":     ts,
+		}).Warning("gateway discovery ping replayed")
+		return true, nil
+	}
+
+	if _, err := storage.GetAndCacheGateway(storage.DB(), storage.RedisPool(), txGatewayID); err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return true, nil
+		}
+		return true, errors.Wrap(err, "get gateway error")
+	}
+
+	for _, rxInfo := range rxInfoSet {
+		rxGatewayID := helpers.GetGatewayID(rxInfo)
+		if rxGatewayID == txGatewayID {
+			continue
+		}
+
+		if _, err := storage.GetAndCacheGateway(storage.DB(), storage.RedisPool(), rxGatewayID); err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				continue
+			}
+			return true, errors.Wrap(err, "get gateway error")
+		}
+
+		rx := storage.GatewayDiscoveryPingRX{
+			TXGatewayID: txGatewayID,
+			RXGatewayID: rxGatewayID,
+			RSSI:        int(rxInfo.Rssi),
+			LoRaSNR:     rxInfo.LoraSnr,
+		}
+		if err := storage.CreateGatewayDiscoveryPingRX(storage.DB(), &rx); err != nil {
+			return true, errors.Wrap(err, "create gateway discovery ping rx error")
+		}
+		discoveryPingRXCounter().Inc()
+	}
+
+	return true, nil
+}
+
+// newDiscoveryPingPayload returns the discovery ping payload containing the
+// gateway ID and the timestamp (unix nanoseconds).
+func newDiscoveryPingPayload(gatewayID lorawan.EUI64, ts time.Time) []byte {
+	b := make([]byte, 16)
+	copy(b[0:8], gatewayID[:])
+	binary.BigEndian.PutUint64(b[8:16], uint64(ts.UnixNano()))
+	return b
+}
+
+// parseDiscoveryPingPayload returns the gateway ID and timestamp of the
+// given discovery ping payload. It returns false when the payload is not a
+// discovery ping or when the MIC is invalid.
+func parseDiscoveryPingPayload(b []byte, mic lorawan.MIC) (lorawan.EUI64, time.Time, bool) {
+	var gatewayID lorawan.EUI64
+
+	if len(b) != 16 {
+		return gatewayID, time.Time{}, false
+	}
+
+	expMIC := discoveryPingMIC(b)
+	if !hmac.Equal(expMIC[:], mic[:]) {
+		return gatewayID, time.Time{}, false
+	}
+
+	copy(gatewayID[:], b[0:8])
+	ts := time.Unix(0, int64(binary.BigEndian.Uint64(b[8:16])))
+
+	return gatewayID, ts, true
+}
+
+// discoveryPingMIC returns the MIC of the discovery ping, which is the
+// truncated HMAC-SHA256 of the payload.
+func discoveryPingMIC(b []byte) lorawan.MIC {
+	var mic lorawan.MIC
+
+	h := hmac.New(sha256.New, discoveryHMACKey)
+	h.Write(b)
+	copy(mic[:], h.Sum(nil))
+
+	return mic
+}
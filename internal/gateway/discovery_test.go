@@ -0,0 +1,188 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestDiscoveryPingPayload(t *testing.T) {
+	assert := require.New(t)
+
+	discoveryHMACKey = []byte{1, 2, 3, 4}
+	defer func() { discoveryHMACKey = nil }()
+
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	ts := time.Unix(0, 1234567890)
+
+	b := newDiscoveryPingPayload(gatewayID, ts)
+	mic := discoveryPingMIC(b)
+
+	t.Run("Valid", func(t *testing.T) {
+		assert := require.New(t)
+
+		id, tsParsed, ok := parseDiscoveryPingPayload(b, mic)
+		assert.True(ok)
+		assert.Equal(gatewayID, id)
+		assert.True(ts.Equal(tsParsed))
+	})
+
+	t.Run("Invalid MIC", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, _, ok := parseDiscoveryPingPayload(b, lorawan.MIC{1, 2, 3, 4})
+		assert.False(ok)
+	})
+
+	t.Run("Invalid length", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, _, ok := parseDiscoveryPingPayload(b[:8], mic)
+		assert.False(ok)
+	})
+
+	assert.NotEqual(mic, discoveryPingMIC(newDiscoveryPingPayload(gatewayID, ts.Add(time.Second))))
+}
+
+type DiscoveryTestSuite struct {
+	suite.Suite
+
+	backend  *test.GatewayBackend
+	gateways []storage.Gateway
+}
+
+func (ts *DiscoveryTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	conf.NetworkServer.Gateway.Discovery.Interval = time.Minute
+	conf.NetworkServer.Gateway.Discovery.Frequency = 868100000
+	conf.NetworkServer.Gateway.Discovery.DR = 5
+	conf.NetworkServer.Gateway.Discovery.HMACKey = "01020304"
+	conf.NetworkServer.Gateway.Discovery.Retention = time.Hour
+
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+	test.MustFlushRedis(storage.RedisPool())
+
+	ts.backend = test.NewGatewayBackend()
+	gateway.SetBackend(ts.backend)
+
+	ts.gateways = []storage.Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, DiscoveryEnabled: true},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+	}
+	for i := range ts.gateways {
+		assert.NoError(storage.CreateGateway(storage.DB(), &ts.gateways[i]))
+	}
+}
+
+func (ts *DiscoveryTestSuite) TearDownSuite() {
+	assert := require.New(ts.T())
+	assert.NoError(Setup(test.GetConfig()))
+}
+
+func (ts *DiscoveryTestSuite) TestDiscovery() {
+	var phy lorawan.PHYPayload
+
+	ts.T().Run("Send pings", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(sendDiscoveryPings())
+		frame := <-ts.backend.TXPacketChan
+		assert.Equal(ts.gateways[0].GatewayID[:], frame.TxInfo.GatewayId)
+		assert.EqualValues(868100000, frame.TxInfo.Frequency)
+		assert.False(frame.TxInfo.GetLoraModulationInfo().PolarizationInversion)
+		assert.NoError(phy.UnmarshalBinary(frame.PhyPayload))
+		assert.Equal(lorawan.Proprietary, phy.MHDR.MType)
+
+		// the lock prevents sending the ping again within the interval
+		assert.NoError(sendDiscoveryPings())
+		assert.Len(ts.backend.TXPacketChan, 0)
+	})
+
+	ts.T().Run("Handle ping", func(t *testing.T) {
+		assert := require.New(t)
+
+		dataPL, ok := phy.MACPayload.(*lorawan.DataPayload)
+		assert.True(ok)
+
+		rxInfoSet := []*gw.UplinkRXInfo{
+			{GatewayId: ts.gateways[0].GatewayID[:], Rssi: -50, LoraSnr: 10},
+			{GatewayId: ts.gateways[1].GatewayID[:], Rssi: -110, LoraSnr: -5.5},
+			{GatewayId: []byte{3, 3, 3, 3, 3, 3, 3, 3}, Rssi: -100, LoraSnr: 1},
+		}
+
+		ok, err := HandleDiscoveryPing(dataPL.Bytes, phy.MIC, rxInfoSet)
+		assert.NoError(err)
+		assert.True(ok)
+
+		rxs, err := storage.GetGatewayDiscoveryPingRXsForGatewayID(storage.DB(), ts.gateways[0].GatewayID, time.Time{}, time.Time{})
+		assert.NoError(err)
+		assert.Len(rxs, 1)
+		assert.Equal(ts.gateways[1].GatewayID, rxs[0].RXGatewayID)
+		assert.Equal(-110, rxs[0].RSSI)
+		assert.Equal(-5.5, rxs[0].LoRaSNR)
+
+		t.Run("Replayed", func(t *testing.T) {
+			assert := require.New(t)
+
+			ok, err := HandleDiscoveryPing(dataPL.Bytes, phy.MIC, rxInfoSet)
+			assert.NoError(err)
+			assert.True(ok)
+
+			rxs, err := storage.GetGatewayDiscoveryPingRXsForGatewayID(storage.DB(), ts.gateways[0].GatewayID, time.Time{}, time.Time{})
+			assert.NoError(err)
+			assert.Len(rxs, 1)
+		})
+
+		t.Run("Invalid MIC", func(t *testing.T) {
+			assert := require.New(t)
+
+			ok, err := HandleDiscoveryPing(dataPL.Bytes, lorawan.MIC{1, 2, 3, 4}, rxInfoSet)
+			assert.NoError(err)
+			assert.False(ok)
+		})
+
+		t.Run("Expired", func(t *testing.T) {
+			assert := require.New(t)
+
+			b := newDiscoveryPingPayload(ts.gateways[0].GatewayID, time.Now().Add(-time.Hour))
+			ok, err := HandleDiscoveryPing(b, discoveryPingMIC(b), rxInfoSet)
+			assert.NoError(err)
+			assert.True(ok)
+
+			rxs, err := storage.GetGatewayDiscoveryPingRXsForGatewayID(storage.DB(), ts.gateways[0].GatewayID, time.Time{}, time.Time{})
+			assert.NoError(err)
+			assert.Len(rxs, 1)
+		})
+	})
+
+	ts.T().Run("Prune", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.CreateGatewayDiscoveryPingRX(storage.DB(), &storage.GatewayDiscoveryPingRX{
+			CreatedAt:   time.Now().Add(-2 * time.Hour),
+			TXGatewayID: ts.gateways[0].GatewayID,
+			RXGatewayID: ts.gateways[1].GatewayID,
+		}))
+
+		assert.NoError(pruneDiscoveryPingRXs())
+
+		rxs, err := storage.GetGatewayDiscoveryPingRXsForGatewayID(storage.DB(), ts.gateways[0].GatewayID, time.Time{}, time.Time{})
+		assert.NoError(err)
+		assert.Len(rxs, 1)
+	})
+}
+
+func TestDiscovery(t *testing.T) {
+	suite.Run(t, new(DiscoveryTestSuite))
+}
@@ -4,9 +4,13 @@ import (
 	"crypto/aes"
 	"encoding/binary"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
@@ -18,6 +22,7 @@ import (
 	"github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/helpers"
+	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 	loraband "github.com/brocaar/lorawan/band"
@@ -35,13 +40,13 @@ func NewStatsHandler() *StatsHandler {
 
 // Start starts the stats handler.
 func (s *StatsHandler) Start() error {
+	s.wg.Add(1)
 	go func() {
-		s.wg.Add(1)
 		defer s.wg.Done()
 
 		for stats := range gateway.Backend().StatsPacketChan() {
+			s.wg.Add(1)
 			go func(stats gw.GatewayStats) {
-				s.wg.Add(1)
 				defer s.wg.Done()
 
 				if err := updateGatewayState(storage.DB(), storage.RedisPool(), stats); err != nil {
@@ -87,14 +92,98 @@ func handleGatewayStats(p *redis.Pool, stats gw.GatewayStats) error {
 		return errors.Wrap(err, "save metrics error")
 	}
 
+	// network-wide aggregate of the stats of all gateways
+	err = storage.SaveMetrics(p, "network", metrics)
+	if err != nil {
+		return errors.Wrap(err, "save network metrics error")
+	}
+
+	return nil
+}
+
+// rxPacketsMetricPrefix defines the prefix of the metrics holding the number
+// of received packets per frequency and spreading-factor.
+const rxPacketsMetricPrefix = "rx_count_freq_sf:"
+
+// SaveRXPacketMetrics increments the number of received packets per
+// frequency and spreading-factor for each gateway within the rx-info set.
+// Only LoRa modulated packets are counted.
+func SaveRXPacketMetrics(p *redis.Pool, txInfo *gw.UplinkTXInfo, rxInfo []*gw.UplinkRXInfo) error {
+	modInfo := txInfo.GetLoraModulationInfo()
+	if modInfo == nil {
+		return nil
+	}
+
+	metrics := storage.MetricsRecord{
+		Time: time.Now(),
+		Metrics: map[string]float64{
+			fmt.Sprintf("%s%d:%d", rxPacketsMetricPrefix, txInfo.Frequency, modInfo.SpreadingFactor): 1,
+		},
+	}
+
+	for i := range rxInfo {
+		gatewayID := helpers.GetGatewayID(rxInfo[i])
+		if err := storage.SaveMetrics(p, "gw:"+gatewayID.String(), metrics); err != nil {
+			return errors.Wrap(err, "save metrics error")
+		}
+	}
+
 	return nil
 }
 
+// SaveRXAirtimeMetrics adds the given airtime (in milliseconds) to the RX
+// airtime metrics of each gateway within the rx-info set and once to the
+// RX airtime metrics of the network.
+func SaveRXAirtimeMetrics(p *redis.Pool, d time.Duration, rxInfo []*gw.UplinkRXInfo) error {
+	metrics := storage.MetricsRecord{
+		Time: time.Now(),
+		Metrics: map[string]float64{
+			"rx_airtime_ms": float64(d) / float64(time.Millisecond),
+		},
+	}
+
+	for i := range rxInfo {
+		gatewayID := helpers.GetGatewayID(rxInfo[i])
+		if err := storage.SaveMetrics(p, "gw:"+gatewayID.String(), metrics); err != nil {
+			return errors.Wrap(err, "save metrics error")
+		}
+	}
+
+	if err := storage.SaveMetrics(p, "network", metrics); err != nil {
+		return errors.Wrap(err, "save network metrics error")
+	}
+
+	return nil
+}
+
+// ParseRXPacketMetric parses the frequency and spreading-factor from the
+// given metric name. It returns false when the name is not a per frequency
+// and spreading-factor metric.
+func ParseRXPacketMetric(name string) (uint32, uint32, bool) {
+	if !strings.HasPrefix(name, rxPacketsMetricPrefix) {
+		return 0, 0, false
+	}
+
+	var freq, sf uint32
+	if _, err := fmt.Sscanf(strings.TrimPrefix(name, rxPacketsMetricPrefix), "%d:%d", &freq, &sf); err != nil {
+		return 0, 0, false
+	}
+
+	return freq, sf, true
+}
+
 func updateGatewayState(db sqlx.Ext, p *redis.Pool, stats gw.GatewayStats) error {
 	gatewayID := helpers.GetGatewayID(&stats)
 	gw, err := storage.GetAndCacheGateway(db, p, gatewayID)
 	if err != nil {
-		return errors.Wrap(err, "get gateway error")
+		if errors.Cause(err) != storage.ErrDoesNotExist || !autoCreate {
+			return errors.Wrap(err, "get gateway error")
+		}
+
+		gw, err = autoCreateGateway(db, p, stats)
+		if err != nil {
+			return errors.Wrap(err, "auto-create gateway error")
+		}
 	}
 
 	now := time.Now()
@@ -103,11 +192,12 @@ func updateGatewayState(db sqlx.Ext, p *redis.Pool, stats gw.GatewayStats) error
 		gw.FirstSeenAt = &now
 	}
 	gw.LastSeenAt = &now
+	gw.ConfigVersion = stats.ConfigVersion
 
-	if stats.Location != nil {
-		gw.Location.Latitude = stats.Location.Latitude
-		gw.Location.Longitude = stats.Location.Longitude
-		gw.Altitude = stats.Location.Altitude
+	if stats.Location != nil && gw.UpdateLocationFromStats {
+		if err := updateLocationFromStats(p, &gw, *stats.Location); err != nil {
+			return errors.Wrap(err, "update location from stats error")
+		}
 	}
 
 	if err := storage.UpdateGateway(db, &gw); err != nil {
@@ -125,6 +215,140 @@ func updateGatewayState(db sqlx.Ext, p *redis.Pool, stats gw.GatewayStats) error
 	return nil
 }
 
+// updateLocationFromStats updates the gateway location with the given GPS
+// location. Locations at (0, 0) and locations too far away from the previous
+// GPS location are ignored, as these are most likely bogus GPS fixes. An
+// ignored location is accepted once it has been confirmed by
+// locationJumpConfirmationCount consecutive location updates. The ignored
+// location is stored in Redis, so that the confirmations are shared by all
+// network-server instances.
+func updateLocationFromStats(p *redis.Pool, g *storage.Gateway, loc common.Location) error {
+	if loc.Latitude == 0 && loc.Longitude == 0 {
+		return nil
+	}
+
+	if g.LocationFromGPS && locationMaxJumpDistance > 0 {
+		if d := distance(g.Location.Latitude, g.Location.Longitude, loc.Latitude, loc.Longitude); d > locationMaxJumpDistance {
+			confirmed, err := confirmLocationJump(p, g.GatewayID, loc)
+			if err != nil {
+				return errors.Wrap(err, "confirm location jump error")
+			}
+
+			if !confirmed {
+				log.WithFields(log.Fields{
+					"gateway_id": g.GatewayID,
+					"distance":   d,
+				}).Warning("gateway location update from stats ignored, max jump distance exceeded")
+				return nil
+			}
+
+			log.WithFields(log.Fields{
+				"gateway_id": g.GatewayID,
+				"distance":   d,
+			}).Info("gateway location update from stats accepted, max jump distance exceeded but confirmed")
+		} else if err := storage.DeleteGatewayLocationJump(p, g.GatewayID); err != nil {
+			return errors.Wrap(err, "delete gateway location jump error")
+		}
+	}
+
+	if g.LocationFromGPS && g.Location.Latitude == loc.Latitude && g.Location.Longitude == loc.Longitude && g.Altitude == loc.Altitude {
+		return nil
+	}
+
+	now := time.Now()
+	g.Location.Latitude = loc.Latitude
+	g.Location.Longitude = loc.Longitude
+	g.Altitude = loc.Altitude
+	g.LocationFromGPS = true
+	g.LocationUpdatedAt = &now
+
+	return nil
+}
+
+// confirmLocationJump registers the given location, exceeding the max jump
+// distance, for the given gateway. It returns true when the location has
+// been confirmed by locationJumpConfirmationCount consecutive location
+// updates within the max jump distance of the first ignored location.
+func confirmLocationJump(p *redis.Pool, gatewayID lorawan.EUI64, loc common.Location) (bool, error) {
+	jump, err := storage.GetGatewayLocationJump(p, gatewayID)
+	if err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return false, errors.Wrap(err, "get gateway location jump error")
+	}
+
+	if err == nil && distance(jump.Latitude, jump.Longitude, loc.Latitude, loc.Longitude) <= locationMaxJumpDistance {
+		jump.Confirmations++
+	} else {
+		jump = storage.GatewayLocationJump{
+			Latitude:  loc.Latitude,
+			Longitude: loc.Longitude,
+			Altitude:  loc.Altitude,
+		}
+	}
+
+	if locationJumpConfirmationCount > 0 && jump.Confirmations >= locationJumpConfirmationCount {
+		if err := storage.DeleteGatewayLocationJump(p, gatewayID); err != nil {
+			return false, errors.Wrap(err, "delete gateway location jump error")
+		}
+		return true, nil
+	}
+
+	if err := storage.SaveGatewayLocationJump(p, gatewayID, jump); err != nil {
+		return false, errors.Wrap(err, "save gateway location jump error")
+	}
+
+	return false, nil
+}
+
+// distance returns the distance in meters between the given coordinates,
+// using the haversine formula.
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371000
+
+	rad := func(deg float64) float64 {
+		return deg * math.Pi / 180
+	}
+
+	dLat := rad(lat2 - lat1)
+	dLon := rad(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// autoCreateGateway creates the gateway sending the given stats. The
+// gateway is flagged as auto-created so that it can be reviewed by the
+// operator.
+func autoCreateGateway(db sqlx.Ext, p *redis.Pool, stats gw.GatewayStats) (storage.Gateway, error) {
+	gatewayID := helpers.GetGatewayID(&stats)
+
+	g := storage.Gateway{
+		GatewayID:               gatewayID,
+		GatewayProfileID:        autoCreateGatewayProfileID,
+		AutoCreated:             true,
+		UpdateLocationFromStats: true,
+	}
+
+	if stats.Location != nil {
+		if err := updateLocationFromStats(p, &g, *stats.Location); err != nil {
+			return g, errors.Wrap(err, "update location from stats error")
+		}
+	}
+
+	if err := storage.CreateGateway(db, &g); err != nil {
+		// the gateway might have been created by a concurrent stats packet
+		if errors.Cause(err) == storage.ErrAlreadyExists {
+			return storage.GetGateway(db, gatewayID)
+		}
+		return g, errors.Wrap(err, "create gateway error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+	}).Info("gateway auto-created")
+
+	return g, nil
+}
+
 // UpdateMetaDataInRxInfoSet updates the gateway meta-data in the
 // given rx-info set. It will:
 //   - add the gateway location
@@ -212,6 +436,72 @@ func UpdateMetaDataInRxInfoSet(db sqlx.Queryer, p *redis.Pool, rxInfo []*gw.Upli
 	return nil
 }
 
+// ApplyRSSISNROffsets applies the RSSI and SNR calibration offsets of the
+// receiving gateways to the given rx-info set. The applied offsets are
+// stored in the rx-info elements, so that the raw values can be restored
+// for the frame-logs. As the offsets might change the signal strength order,
+// the rx-info set is sorted again when any offset was applied.
+func ApplyRSSISNROffsets(db sqlx.Queryer, p *redis.Pool, rxInfo []*gw.UplinkRXInfo) error {
+	var applied bool
+
+	for i := range rxInfo {
+		id := helpers.GetGatewayID(rxInfo[i])
+		g, err := storage.GetAndCacheGateway(db, p, id)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"gateway_id": id,
+			}).WithError(err).Error("get gateway error")
+			continue
+		}
+
+		if g.RSSIOffset == 0 && g.SNROffset == 0 {
+			continue
+		}
+
+		// the rssi is an integer value, round the offset so that the
+		// raw value can be restored
+		rssiOffset := math.Round(g.RSSIOffset)
+
+		rxInfo[i].Rssi += int32(rssiOffset)
+		rxInfo[i].RssiOffset = rssiOffset
+		rxInfo[i].LoraSnr += g.SNROffset
+		rxInfo[i].LoraSnrOffset = g.SNROffset
+		applied = true
+	}
+
+	if applied {
+		sort.Sort(models.BySignalStrength(rxInfo))
+	}
+
+	return nil
+}
+
+// MoveMaintenanceModeGatewaysLast moves the rx-info elements of gateways in
+// maintenance mode to the end of the given rx-info set. The order of the
+// other elements is retained. As the downlink gateway is selected from the
+// start of the rx-info set, gateways in maintenance mode are only used for
+// downlink when no other gateway received the uplink.
+func MoveMaintenanceModeGatewaysLast(db sqlx.Queryer, p *redis.Pool, rxInfo []*gw.UplinkRXInfo) error {
+	maintenance := make(map[lorawan.EUI64]bool)
+	for i := range rxInfo {
+		id := helpers.GetGatewayID(rxInfo[i])
+		g, err := storage.GetAndCacheGateway(db, p, id)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"gateway_id": id,
+			}).WithError(err).Error("get gateway error")
+			continue
+		}
+		maintenance[id] = g.MaintenanceMode
+	}
+
+	sort.SliceStable(rxInfo, func(i, j int) bool {
+		return !maintenance[helpers.GetGatewayID(rxInfo[i])] && maintenance[helpers.GetGatewayID(rxInfo[j])]
+	})
+
+	return nil
+}
+
 func decryptFineTimestamp(key lorawan.AES128Key, rxTime time.Time, ts gw.EncryptedFineTimestamp) (gw.PlainFineTimestamp, error) {
 	var plainTS gw.PlainFineTimestamp
 
@@ -244,6 +534,32 @@ func decryptFineTimestamp(key lorawan.AES128Key, rxTime time.Time, ts gw.Encrypt
 	return plainTS, nil
 }
 
+// UpdateConfiguration sends the configuration of the gateway-profile to the
+// given gateway, in case the configuration version last reported by the
+// gateway is outdated.
+func UpdateConfiguration(db sqlx.Queryer, g storage.Gateway) error {
+	return handleConfigurationUpdate(db, g, g.ConfigVersion)
+}
+
+// UpdateConfigurationForGatewayProfile sends the configuration of the given
+// gateway-profile to all the gateways using it.
+func UpdateConfigurationForGatewayProfile(db sqlx.Queryer, id uuid.UUID) error {
+	gws, err := storage.GetGatewaysForGatewayProfileID(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get gateways error")
+	}
+
+	for _, g := range gws {
+		if err := UpdateConfiguration(db, g); err != nil {
+			log.WithFields(log.Fields{
+				"gateway_id": g.GatewayID,
+			}).WithError(err).Error("update gateway configuration error")
+		}
+	}
+
+	return nil
+}
+
 func handleConfigurationUpdate(db sqlx.Queryer, g storage.Gateway, currentVersion string) error {
 	if g.GatewayProfileID == nil {
 		log.WithField("gateway_id", g.GatewayID).Debug("gateway-profile is not set, skipping configuration update")
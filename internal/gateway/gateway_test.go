@@ -10,6 +10,7 @@ import (
 
 	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend/controller"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
@@ -137,6 +138,32 @@ func (ts *GatewayConfigurationTestSuite) TestUpdate() {
 			},
 		}, gwConfig)
 	})
+
+	ts.T().Run("Gateway-profile fan-out", func(t *testing.T) {
+		assert := require.New(t)
+
+		gp, err := storage.GetGatewayProfile(storage.DB(), *ts.gateway.GatewayProfileID)
+		assert.NoError(err)
+
+		// gateway reports the current version
+		ts.gateway.ConfigVersion = gp.GetVersion()
+		assert.NoError(storage.UpdateGateway(storage.DB(), &ts.gateway))
+
+		assert.NoError(UpdateConfigurationForGatewayProfile(storage.DB(), gp.ID))
+		assert.Equal(0, len(ts.backend.GatewayConfigPacketChan))
+
+		// gateway-profile update
+		gp.Channels = []int64{0}
+		gp.ExtraChannels = nil
+		assert.NoError(storage.UpdateGatewayProfile(storage.DB(), &gp))
+		gp, err = storage.GetGatewayProfile(storage.DB(), gp.ID)
+		assert.NoError(err)
+
+		assert.NoError(UpdateConfigurationForGatewayProfile(storage.DB(), gp.ID))
+		gwConfig := <-ts.backend.GatewayConfigPacketChan
+		assert.Equal(gp.GetVersion(), gwConfig.Version)
+		assert.Len(gwConfig.Channels, 1)
+	})
 }
 
 func TestGatewayConfigurationUpdate(t *testing.T) {
@@ -207,6 +234,431 @@ func (ts *GatewayStatsTestSuite) TestStats() {
 	}, metrics)
 }
 
+func (ts *GatewayStatsTestSuite) TestRXPacketMetrics() {
+	assert := require.New(ts.T())
+	test.MustFlushRedis(storage.RedisPool())
+
+	txInfo := gw.UplinkTXInfo{
+		Frequency:  868100000,
+		Modulation: common.Modulation_LORA,
+		ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+			LoraModulationInfo: &gw.LoRaModulationInfo{
+				SpreadingFactor: 7,
+			},
+		},
+	}
+	rxInfo := []*gw.UplinkRXInfo{
+		{GatewayId: ts.gateway.GatewayID[:]},
+	}
+
+	assert.NoError(SaveRXPacketMetrics(storage.RedisPool(), &txInfo, rxInfo))
+	assert.NoError(SaveRXPacketMetrics(storage.RedisPool(), &txInfo, rxInfo))
+
+	now := time.Now()
+	metrics, err := storage.GetMetrics(storage.RedisPool(), storage.AggregationMinute, "gw:0102030405060708", now, now)
+	assert.NoError(err)
+	assert.Len(metrics, 1)
+	assert.Equal(map[string]float64{
+		"rx_count_freq_sf:868100000:7": 2,
+	}, metrics[0].Metrics)
+
+	freq, sf, ok := ParseRXPacketMetric("rx_count_freq_sf:868100000:7")
+	assert.True(ok)
+	assert.EqualValues(868100000, freq)
+	assert.EqualValues(7, sf)
+
+	_, _, ok = ParseRXPacketMetric("rx_count")
+	assert.False(ok)
+}
+
+func (ts *GatewayStatsTestSuite) TestRXAirtimeMetrics() {
+	assert := require.New(ts.T())
+	test.MustFlushRedis(storage.RedisPool())
+
+	rxInfo := []*gw.UplinkRXInfo{
+		{GatewayId: ts.gateway.GatewayID[:]},
+	}
+
+	assert.NoError(SaveRXAirtimeMetrics(storage.RedisPool(), 118016*time.Microsecond, rxInfo))
+	assert.NoError(SaveRXAirtimeMetrics(storage.RedisPool(), 41216*time.Microsecond, rxInfo))
+
+	now := time.Now()
+	for _, name := range []string{"gw:0102030405060708", "network"} {
+		metrics, err := storage.GetMetrics(storage.RedisPool(), storage.AggregationMinute, name, now, now)
+		assert.NoError(err)
+		assert.Len(metrics, 1)
+		assert.InDelta(159.232, metrics[0].Metrics["rx_airtime_ms"], 0.0001)
+	}
+}
+
+func (ts *GatewayStatsTestSuite) TestAutoCreate() {
+	gatewayID := lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8}
+	stats := gw.GatewayStats{
+		GatewayId: gatewayID[:],
+		Location: &common.Location{
+			Latitude:  1.123,
+			Longitude: 1.124,
+			Altitude:  15.3,
+		},
+	}
+
+	ts.T().Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+		autoCreate = false
+
+		assert.Error(updateGatewayState(storage.DB(), storage.RedisPool(), stats))
+		_, err := storage.GetGateway(storage.DB(), gatewayID)
+		assert.Equal(storage.ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Enabled", func(t *testing.T) {
+		assert := require.New(t)
+		autoCreate = true
+		defer func() { autoCreate = false }()
+
+		assert.NoError(updateGatewayState(storage.DB(), storage.RedisPool(), stats))
+		g, err := storage.GetGateway(storage.DB(), gatewayID)
+		assert.NoError(err)
+		assert.True(g.AutoCreated)
+		assert.NotNil(g.FirstSeenAt)
+		assert.Equal(1.123, g.Location.Latitude)
+		assert.Equal(1.124, g.Location.Longitude)
+		assert.Equal(15.3, g.Altitude)
+		assert.True(g.UpdateLocationFromStats)
+		assert.True(g.LocationFromGPS)
+		assert.NotNil(g.LocationUpdatedAt)
+	})
+}
+
+func TestUpdateLocationFromStats(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(storage.Setup(test.GetConfig()))
+	test.MustFlushRedis(storage.RedisPool())
+
+	locationMaxJumpDistance = 1000
+	defer func() { locationMaxJumpDistance = 0 }()
+
+	tests := []struct {
+		Name             string
+		Gateway          storage.Gateway
+		Location         common.Location
+		ExpectedLocation storage.GPSPoint
+		ExpectedUpdated  bool
+	}{
+		{
+			Name:             "manual location is updated",
+			Gateway:          storage.Gateway{Location: storage.GPSPoint{Latitude: 10, Longitude: 10}},
+			Location:         common.Location{Latitude: 52.3667, Longitude: 4.8945},
+			ExpectedLocation: storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945},
+			ExpectedUpdated:  true,
+		},
+		{
+			Name:             "gps location within max jump distance",
+			Gateway:          storage.Gateway{Location: storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945}, LocationFromGPS: true},
+			Location:         common.Location{Latitude: 52.3668, Longitude: 4.8946},
+			ExpectedLocation: storage.GPSPoint{Latitude: 52.3668, Longitude: 4.8946},
+			ExpectedUpdated:  true,
+		},
+		{
+			Name:             "gps location exceeds max jump distance",
+			Gateway:          storage.Gateway{Location: storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945}, LocationFromGPS: true},
+			Location:         common.Location{Latitude: 52.3767, Longitude: 4.8945},
+			ExpectedLocation: storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945},
+		},
+		{
+			Name:             "zero location is ignored",
+			Gateway:          storage.Gateway{Location: storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945}},
+			Location:         common.Location{},
+			ExpectedLocation: storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(updateLocationFromStats(storage.RedisPool(), &tst.Gateway, tst.Location))
+			assert.Equal(tst.ExpectedLocation, tst.Gateway.Location)
+			assert.Equal(tst.ExpectedUpdated, tst.Gateway.LocationUpdatedAt != nil)
+		})
+	}
+}
+
+func TestUpdateLocationFromStatsJumpConfirmation(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(storage.Setup(test.GetConfig()))
+	test.MustFlushRedis(storage.RedisPool())
+
+	locationMaxJumpDistance = 1000
+	locationJumpConfirmationCount = 2
+	defer func() {
+		locationMaxJumpDistance = 0
+		locationJumpConfirmationCount = 0
+	}()
+
+	current := storage.GPSPoint{Latitude: 52.3667, Longitude: 4.8945}
+	moved := common.Location{Latitude: 52.3767, Longitude: 4.8945}
+	bogus := common.Location{Latitude: 53.3667, Longitude: 4.8945}
+
+	g := storage.Gateway{
+		GatewayID:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		Location:        current,
+		LocationFromGPS: true,
+	}
+
+	// the jump is ignored and a report near the current location resets it
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, common.Location{Latitude: current.Latitude, Longitude: current.Longitude}))
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.Equal(current, g.Location)
+
+	// a report inconsistent with the ignored location restarts the count
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, bogus))
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.Equal(current, g.Location)
+
+	// the ignored location is accepted after two confirmations
+	assert.NoError(updateLocationFromStats(storage.RedisPool(), &g, moved))
+	assert.Equal(storage.GPSPoint{Latitude: moved.Latitude, Longitude: moved.Longitude}, g.Location)
+	assert.NotNil(g.LocationUpdatedAt)
+}
+
 func TestGatewayStats(t *testing.T) {
 	suite.Run(t, new(GatewayStatsTestSuite))
 }
+
+type MaintenanceModeTestSuite struct {
+	suite.Suite
+}
+
+func (ts *MaintenanceModeTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+	test.MustFlushRedis(storage.RedisPool())
+
+	for _, g := range []storage.Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, MaintenanceMode: true},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+		{GatewayID: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}},
+	} {
+		assert.NoError(storage.CreateGateway(storage.DB(), &g))
+	}
+}
+
+func (ts *MaintenanceModeTestSuite) TestMoveMaintenanceModeGatewaysLast() {
+	tests := []struct {
+		Name     string
+		RXInfo   []lorawan.EUI64
+		Expected []lorawan.EUI64
+	}{
+		{
+			Name:     "maintenance-mode gateway is moved last",
+			RXInfo:   []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {3, 3, 3, 3, 3, 3, 3, 3}, {2, 2, 2, 2, 2, 2, 2, 2}},
+			Expected: []lorawan.EUI64{{3, 3, 3, 3, 3, 3, 3, 3}, {2, 2, 2, 2, 2, 2, 2, 2}, {1, 1, 1, 1, 1, 1, 1, 1}},
+		},
+		{
+			Name:     "only maintenance-mode gateway",
+			RXInfo:   []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+			Expected: []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var rxInfo []*gw.UplinkRXInfo
+			for i := range tst.RXInfo {
+				rxInfo = append(rxInfo, &gw.UplinkRXInfo{GatewayId: tst.RXInfo[i][:]})
+			}
+
+			assert.NoError(MoveMaintenanceModeGatewaysLast(storage.DB(), storage.RedisPool(), rxInfo))
+
+			var ids []lorawan.EUI64
+			for i := range rxInfo {
+				var id lorawan.EUI64
+				copy(id[:], rxInfo[i].GatewayId)
+				ids = append(ids, id)
+			}
+			assert.Equal(tst.Expected, ids)
+		})
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	suite.Run(t, new(MaintenanceModeTestSuite))
+}
+
+type RSSISNROffsetTestSuite struct {
+	suite.Suite
+}
+
+func (ts *RSSISNROffsetTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+	test.MustFlushRedis(storage.RedisPool())
+
+	for _, g := range []storage.Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, RSSIOffset: -6.4, SNROffset: 1.5},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+	} {
+		assert.NoError(storage.CreateGateway(storage.DB(), &g))
+	}
+}
+
+func (ts *RSSISNROffsetTestSuite) TestApplyRSSISNROffsets() {
+	assert := require.New(ts.T())
+
+	rxInfo := []*gw.UplinkRXInfo{
+		{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Rssi: -50, LoraSnr: 2},
+		{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Rssi: -60, LoraSnr: 3},
+	}
+
+	assert.NoError(ApplyRSSISNROffsets(storage.DB(), storage.RedisPool(), rxInfo))
+	assert.Equal([]*gw.UplinkRXInfo{
+		{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Rssi: -56, RssiOffset: -6, LoraSnr: 3.5, LoraSnrOffset: 1.5},
+		{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Rssi: -60, LoraSnr: 3},
+	}, rxInfo)
+
+	ts.T().Run("Order by corrected signal strength", func(t *testing.T) {
+		assert := require.New(t)
+
+		rxInfo := []*gw.UplinkRXInfo{
+			{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Rssi: -50, LoraSnr: 2.5},
+			{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Rssi: -60, LoraSnr: 3},
+		}
+
+		assert.NoError(ApplyRSSISNROffsets(storage.DB(), storage.RedisPool(), rxInfo))
+		assert.Equal([]byte{1, 1, 1, 1, 1, 1, 1, 1}, rxInfo[0].GatewayId)
+		assert.Equal(4.0, rxInfo[0].LoraSnr)
+	})
+}
+
+func TestRSSISNROffset(t *testing.T) {
+	suite.Run(t, new(RSSISNROffsetTestSuite))
+}
+
+type StateCheckerTestSuite struct {
+	suite.Suite
+
+	ncClient *test.NetworkControllerClient
+	gateway  storage.Gateway
+}
+
+func (ts *StateCheckerTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+
+	ts.ncClient = test.NewNetworkControllerClient()
+	controller.SetClient(ts.ncClient)
+
+	statsInterval = 30 * time.Second
+	offlineMultiplier = 3
+
+	now := time.Now()
+	ts.gateway = storage.Gateway{
+		GatewayID:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		LastSeenAt: &now,
+	}
+	assert.NoError(storage.CreateGateway(storage.DB(), &ts.gateway))
+
+	// never seen, stays offline
+	assert.NoError(storage.CreateGateway(storage.DB(), &storage.Gateway{
+		GatewayID: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+	}))
+}
+
+func (ts *StateCheckerTestSuite) TestCheckGatewayStates() {
+	ts.T().Run("Online", func(t *testing.T) {
+		assert := require.New(t)
+		stateDebounce = 0
+
+		assert.NoError(checkGatewayStates())
+		req := <-ts.ncClient.HandleGatewayStateChangeChan
+		assert.Equal(ts.gateway.GatewayID[:], req.GatewayId)
+		assert.True(req.Online)
+		assert.Len(ts.ncClient.HandleGatewayStateChangeChan, 0)
+
+		gw, err := storage.GetGateway(storage.DB(), ts.gateway.GatewayID)
+		assert.NoError(err)
+		assert.True(gw.Online)
+		assert.NotNil(gw.OnlineChangedAt)
+	})
+
+	ts.T().Run("Offline", func(t *testing.T) {
+		assert := require.New(t)
+		stateDebounce = 0
+
+		lastSeen := time.Now().Add(-5 * time.Minute)
+		ts.gateway.LastSeenAt = &lastSeen
+		assert.NoError(storage.UpdateGateway(storage.DB(), &ts.gateway))
+
+		assert.NoError(checkGatewayStates())
+		req := <-ts.ncClient.HandleGatewayStateChangeChan
+		assert.Equal(ts.gateway.GatewayID[:], req.GatewayId)
+		assert.False(req.Online)
+		assert.Len(ts.ncClient.HandleGatewayStateChangeChan, 0)
+	})
+
+	ts.T().Run("Debounced", func(t *testing.T) {
+		assert := require.New(t)
+		stateDebounce = time.Hour
+
+		now := time.Now()
+		ts.gateway.LastSeenAt = &now
+		assert.NoError(storage.UpdateGateway(storage.DB(), &ts.gateway))
+
+		assert.NoError(checkGatewayStates())
+		assert.Len(ts.ncClient.HandleGatewayStateChangeChan, 0)
+
+		gw, err := storage.GetGateway(storage.DB(), ts.gateway.GatewayID)
+		assert.NoError(err)
+		assert.False(gw.Online)
+	})
+
+	ts.T().Run("Gateway-profile stats interval", func(t *testing.T) {
+		assert := require.New(t)
+		stateDebounce = 0
+
+		gp := storage.GatewayProfile{
+			StatsInterval: 5 * time.Minute,
+		}
+		assert.NoError(storage.CreateGatewayProfile(storage.DB(), &gp))
+
+		// within 3 * 5 minutes, but not within 3 * 30 seconds
+		lastSeen := time.Now().Add(-5 * time.Minute)
+		g := storage.Gateway{
+			GatewayID:        lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8},
+			GatewayProfileID: &gp.ID,
+			LastSeenAt:       &lastSeen,
+		}
+		assert.NoError(storage.CreateGateway(storage.DB(), &g))
+
+		assert.NoError(checkGatewayStates())
+
+		online := make(map[lorawan.EUI64]bool)
+		for len(ts.ncClient.HandleGatewayStateChangeChan) > 0 {
+			req := <-ts.ncClient.HandleGatewayStateChangeChan
+			var id lorawan.EUI64
+			copy(id[:], req.GatewayId)
+			online[id] = req.Online
+		}
+		assert.Equal(map[lorawan.EUI64]bool{
+			ts.gateway.GatewayID: true,
+			g.GatewayID:          true,
+		}, online)
+	})
+}
+
+func TestStateChecker(t *testing.T) {
+	suite.Run(t, new(StateCheckerTestSuite))
+}
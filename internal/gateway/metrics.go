@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dptc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gateway_discovery_ping_tx_count",
+		Help: "The number of gateway discovery pings sent.",
+	})
+
+	dprc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gateway_discovery_ping_rx_count",
+		Help: "The number of stored gateway discovery ping receptions (a ping received by multiple gateways is counted once for each gateway).",
+	})
+)
+
+func discoveryPingTXCounter() prometheus.Counter {
+	return dptc
+}
+
+func discoveryPingRXCounter() prometheus.Counter {
+	return dprc
+}
@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/nc"
+	"github.com/brocaar/loraserver/internal/backend/controller"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+var (
+	statsInterval      time.Duration
+	offlineMultiplier  int
+	stateCheckInterval time.Duration
+	stateDebounce      time.Duration
+
+	autoCreate                 bool
+	autoCreateGatewayProfileID *uuid.UUID
+
+	locationMaxJumpDistance       float64
+	locationJumpConfirmationCount int
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	c := conf.NetworkServer.Gateway.OfflineDetection
+	statsInterval = c.StatsInterval
+	offlineMultiplier = c.OfflineMultiplier
+	stateCheckInterval = c.CheckInterval
+	stateDebounce = c.DebounceDuration
+
+	autoCreate = conf.NetworkServer.Gateway.AutoCreate.Enabled
+	autoCreateGatewayProfileID = nil
+	if id := conf.NetworkServer.Gateway.AutoCreate.GatewayProfileID; id != "" {
+		gpID, err := uuid.FromString(id)
+		if err != nil {
+			return errors.Wrap(err, "parse auto-create gateway-profile id error")
+		}
+		autoCreateGatewayProfileID = &gpID
+	}
+
+	locationMaxJumpDistance = conf.NetworkServer.Gateway.LocationUpdate.MaxJumpDistance
+	locationJumpConfirmationCount = conf.NetworkServer.Gateway.LocationUpdate.JumpConfirmationCount
+
+	if err := setupDiscovery(conf); err != nil {
+		return errors.Wrap(err, "setup discovery error")
+	}
+
+	return nil
+}
+
+// StateCheckerLoop starts an infinite loop checking for gateways that went
+// offline or came back online. It returns immediately when the check
+// interval is not configured.
+func StateCheckerLoop() {
+	if stateCheckInterval <= 0 {
+		return
+	}
+
+	for {
+		log.Debug("running gateway state checker")
+		if err := checkGatewayStates(); err != nil {
+			log.WithError(err).Error("gateway state checker error")
+		}
+		time.Sleep(stateCheckInterval)
+	}
+}
+
+// checkGatewayStates updates the online state of the gateways and sends an
+// event to the network-controller for every state transition.
+func checkGatewayStates() error {
+	gws, err := storage.UpdateGatewayOnlineStates(storage.DB(), offlineMultiplier, statsInterval, time.Now().Add(-stateDebounce))
+	if err != nil {
+		return errors.Wrap(err, "update gateway online states error")
+	}
+
+	for _, gw := range gws {
+		req := nc.HandleGatewayStateChangeRequest{
+			GatewayId: gw.GatewayID[:],
+			Online:    gw.Online,
+		}
+
+		if gw.LastSeenAt != nil {
+			req.LastSeenAt, _ = ptypes.TimestampProto(*gw.LastSeenAt)
+		}
+
+		if _, err := controller.Client().HandleGatewayStateChange(context.Background(), &req); err != nil {
+			log.WithError(err).WithField("gateway_id", gw.GatewayID).Error("send gateway state change to network-controller error")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,306 @@
+// Package geolocation resolves the location of devices using the TDOA
+// meta-data of the receiving gateways.
+package geolocation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/geo"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const resolveLockKeyTempl = "lora:ns:device:%s:geoloc:lock"
+
+var (
+	resolverMux     sync.RWMutex
+	resolver        *asyncResolver
+	resolveInterval time.Duration
+	requestTimeout  time.Duration
+)
+
+// Request holds the geolocation request of a device.
+type Request struct {
+	DevEUI                  lorawan.EUI64
+	ReferenceAltitude       float64
+	Frames                  []*geo.FrameRXInfo
+	GeolocationClient       geo.GeolocationServerServiceClient
+	ApplicationServerClient as.ApplicationServerServiceClient
+}
+
+// Setup configures the package. When workers are configured, the
+// geolocation requests are queued and handled by these workers.
+func Setup(conf config.Config) error {
+	resolverMux.Lock()
+	defer resolverMux.Unlock()
+
+	if resolver != nil {
+		resolver.stop(0)
+		resolver = nil
+	}
+
+	resolveInterval = conf.GeolocationServer.ResolveInterval
+	requestTimeout = conf.GeolocationServer.RequestTimeout
+
+	if conf.GeolocationServer.Workers > 0 {
+		resolver = newAsyncResolver(conf.GeolocationServer.Workers, conf.GeolocationServer.QueueSize)
+		log.WithFields(log.Fields{
+			"workers":    conf.GeolocationServer.Workers,
+			"queue_size": conf.GeolocationServer.QueueSize,
+		}).Info("geolocation: resolve workers started")
+	}
+
+	return nil
+}
+
+// Stop stops the resolve workers (when configured) after handling the
+// remaining queued geolocation requests, bounded by the given timeout.
+// Geolocation requests received after Stop are dropped.
+func Stop(timeout time.Duration) error {
+	resolverMux.RLock()
+	defer resolverMux.RUnlock()
+
+	if resolver != nil {
+		resolver.stop(timeout)
+	}
+	return nil
+}
+
+// Resolve resolves the location of the device using the given frames and
+// forwards it to the application-server. It does not block, the request is
+// handled asynchronously. Requests within the resolve interval of the
+// previous request of the device are skipped before being queued. When the
+// request is dropped by the queue, the device is not rate-limited.
+func Resolve(req Request) {
+	if len(req.Frames) == 0 {
+		return
+	}
+
+	locked, err := acquireResolveLock(storage.RedisPool(), req.DevEUI)
+	if err != nil {
+		log.WithField("dev_eui", req.DevEUI).WithError(err).Error("geolocation: acquire resolve lock error")
+		return
+	}
+	if !locked {
+		rateLimitedCounter().Inc()
+		log.WithFields(log.Fields{
+			"dev_eui": req.DevEUI,
+		}).Debug("geolocation: skipping geolocation, rate-limited")
+		return
+	}
+
+	resolverMux.RLock()
+	r := resolver
+	resolverMux.RUnlock()
+
+	if r != nil {
+		if !r.enqueue(req) {
+			// the request was dropped, do not rate-limit the next request
+			if err := releaseResolveLock(storage.RedisPool(), req.DevEUI); err != nil {
+				log.WithField("dev_eui", req.DevEUI).WithError(err).Error("geolocation: release resolve lock error")
+			}
+		}
+		return
+	}
+
+	go handleRequest(req)
+}
+
+func handleRequest(req Request) {
+	if err := resolve(storage.RedisPool(), req); err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": req.DevEUI,
+		}).WithError(err).Error("geolocation: resolve device location error")
+	}
+}
+
+func resolve(p *redis.Pool, req Request) error {
+	if len(req.Frames) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var result *geo.ResolveResult
+
+	if len(req.Frames) == 1 {
+		// Single-frame geolocation.
+		resp, err := req.GeolocationClient.ResolveTDOA(ctx, &geo.ResolveTDOARequest{
+			DevEui:                  req.DevEUI[:],
+			FrameRxInfo:             req.Frames[0],
+			DeviceReferenceAltitude: req.ReferenceAltitude,
+		})
+		if err != nil {
+			return errors.Wrap(err, "resolve tdoa error")
+		}
+		result = resp.Result
+	} else {
+		// Multi-frame geolocation.
+		resp, err := req.GeolocationClient.ResolveMultiFrameTDOA(ctx, &geo.ResolveMultiFrameTDOARequest{
+			DevEui:                  req.DevEUI[:],
+			FrameRxInfoSet:          req.Frames,
+			DeviceReferenceAltitude: req.ReferenceAltitude,
+		})
+		if err != nil {
+			return errors.Wrap(err, "resolve multi-frame tdoa error")
+		}
+		result = resp.Result
+	}
+
+	if result == nil || result.Location == nil {
+		return errors.New("geolocation-server result or result.location must not be nil")
+	}
+
+	if err := storage.SaveDeviceLocation(p, storage.DeviceLocation{
+		DevEUI:     req.DevEUI,
+		Latitude:   result.Location.Latitude,
+		Longitude:  result.Location.Longitude,
+		Altitude:   result.Location.Altitude,
+		Accuracy:   result.Location.Accuracy,
+		ResolvedAt: time.Now(),
+	}); err != nil {
+		return errors.Wrap(err, "save device location error")
+	}
+
+	_, err := req.ApplicationServerClient.SetDeviceLocation(ctx, &as.SetDeviceLocationRequest{
+		DevEui:   req.DevEUI[:],
+		Location: result.Location,
+	})
+	if err != nil {
+		return errors.Wrap(err, "set device-location error")
+	}
+
+	return nil
+}
+
+// acquireResolveLock returns true when the lock for resolving the location
+// of the given device was acquired, false when the location was already
+// resolved within the resolve interval.
+func acquireResolveLock(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
+	if resolveInterval == 0 {
+		return true, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(resolveLockKeyTempl, devEUI)
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(resolveInterval/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "acquire resolve lock error")
+	}
+
+	return true, nil
+}
+
+// releaseResolveLock releases the resolve lock of the given device.
+func releaseResolveLock(p *redis.Pool, devEUI lorawan.EUI64) error {
+	if resolveInterval == 0 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(resolveLockKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "release resolve lock error")
+	}
+
+	return nil
+}
+
+// asyncResolver handles the geolocation requests from a bounded queue. When
+// the queue is full or when the resolver has been stopped, geolocation
+// requests are dropped instead of blocking the caller.
+type asyncResolver struct {
+	queue chan Request
+	wg    sync.WaitGroup
+
+	mux    sync.RWMutex
+	closed bool
+}
+
+func newAsyncResolver(workers, queueSize int) *asyncResolver {
+	r := asyncResolver{
+		queue: make(chan Request, queueSize),
+	}
+
+	for i := 0; i < workers; i++ {
+		r.wg.Add(1)
+		go r.run()
+	}
+
+	return &r
+}
+
+// enqueue queues the given request. It returns false when the request was
+// dropped.
+func (r *asyncResolver) enqueue(req Request) bool {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	if r.closed {
+		droppedCounter().Inc()
+		return false
+	}
+
+	select {
+	case r.queue <- req:
+		return true
+	default:
+		droppedCounter().Inc()
+		log.WithFields(log.Fields{
+			"dev_eui": req.DevEUI,
+		}).Warning("geolocation: queue is full, geolocation request dropped")
+		return false
+	}
+}
+
+// stop closes the queue and waits until the remaining geolocation requests
+// have been handled or the given timeout (when > 0) expires.
+func (r *asyncResolver) stop(timeout time.Duration) {
+	r.mux.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mux.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	if timeout == 0 {
+		<-done
+		return
+	}
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.WithField("remaining", len(r.queue)).Warning("geolocation: stop resolve workers timed out")
+	}
+}
+
+func (r *asyncResolver) run() {
+	defer r.wg.Done()
+
+	for req := range r.queue {
+		handleRequest(req)
+	}
+}
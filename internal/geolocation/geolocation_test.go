@@ -0,0 +1,69 @@
+package geolocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/geo"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestAsyncResolverDropsWhenFull(t *testing.T) {
+	assert := require.New(t)
+
+	// no worker goroutine is consuming the queue
+	r := asyncResolver{queue: make(chan Request, 1)}
+	before := testutil.ToFloat64(droppedCounter())
+
+	assert.True(r.enqueue(Request{DevEUI: lorawan.EUI64{1}}))
+	assert.False(r.enqueue(Request{DevEUI: lorawan.EUI64{2}}))
+	assert.False(r.enqueue(Request{DevEUI: lorawan.EUI64{3}}))
+
+	assert.Len(r.queue, 1)
+	assert.Equal(before+2, testutil.ToFloat64(droppedCounter()))
+}
+
+func TestAsyncResolverDropsWhenStopped(t *testing.T) {
+	assert := require.New(t)
+
+	r := newAsyncResolver(1, 1)
+	r.stop(time.Second)
+	before := testutil.ToFloat64(droppedCounter())
+
+	// must not panic on the closed queue
+	assert.False(r.enqueue(Request{DevEUI: lorawan.EUI64{1}}))
+
+	assert.Equal(before+1, testutil.ToFloat64(droppedCounter()))
+}
+
+func TestResolveReleasesLockOnDrop(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(storage.Setup(test.GetConfig()))
+	test.MustFlushRedis(storage.RedisPool())
+
+	resolveInterval = time.Minute
+	defer func() { resolveInterval = 0 }()
+
+	resolverMux.Lock()
+	resolver = newAsyncResolver(1, 1)
+	resolver.stop(time.Second)
+	resolverMux.Unlock()
+	defer func() {
+		resolverMux.Lock()
+		resolver = nil
+		resolverMux.Unlock()
+	}()
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	Resolve(Request{DevEUI: devEUI, Frames: []*geo.FrameRXInfo{{}}})
+
+	// the dropped request must not rate-limit the device
+	locked, err := acquireResolveLock(storage.RedisPool(), devEUI)
+	assert.NoError(err)
+	assert.True(locked)
+}
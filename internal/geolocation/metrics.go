@@ -0,0 +1,26 @@
+package geolocation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	gdc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "geolocation_dropped_count",
+		Help: "The number of geolocation requests dropped because the resolve queue was full.",
+	})
+
+	grlc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "geolocation_rate_limited_count",
+		Help: "The number of geolocation requests skipped because of the per-device resolve interval.",
+	})
+)
+
+func droppedCounter() prometheus.Counter {
+	return gdc
+}
+
+func rateLimitedCounter() prometheus.Counter {
+	return grlc
+}
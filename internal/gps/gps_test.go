@@ -19,6 +19,9 @@ func TestTime(t *testing.T) {
 			{Time: time.Date(2025, time.July, 14, 0, 0, 0, 0, time.UTC), TimeSinceGPSEpoch: 1436486418 * time.Second},
 			{Time: time.Date(2012, time.June, 30, 23, 59, 59, 0, time.UTC), TimeSinceGPSEpoch: 1025136014 * time.Second},
 			{Time: time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC), TimeSinceGPSEpoch: 1025136016 * time.Second},
+			{Time: time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC), TimeSinceGPSEpoch: 1167264016 * time.Second},
+			{Time: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), TimeSinceGPSEpoch: 1167264018 * time.Second},
+			{Time: time.Date(2017, time.January, 1, 0, 0, 0, 500000000, time.UTC), TimeSinceGPSEpoch: 1167264018*time.Second + 500*time.Millisecond},
 		}
 
 		for i, test := range tests {
@@ -45,6 +45,12 @@ func handleDevStatusAns(ds *storage.DeviceSession, sp storage.ServiceProfile, as
 		"margin":  pl.Margin,
 	}).Info("dev_status_ans answer received")
 
+	ds.LastDevStatus = &storage.DevStatus{
+		Battery:    pl.Battery,
+		Margin:     pl.Margin,
+		ReceivedAt: time.Now(),
+	}
+
 	if !sp.ReportDevStatusBattery && !sp.ReportDevStatusMargin {
 		log.WithField("dev_eui", ds.DevEUI).Warning("reporting device-status has been disabled in service-profile")
 		return nil, nil
@@ -134,6 +134,10 @@ func (ts *DevStatusTestSuite) TestDevStatusAns() {
 			assert.Len(resp, 0)
 
 			assert.Equal(tst.ExpectedSetDeviceStatusRequest, <-asClient.SetDeviceStatusChan)
+
+			assert.NotNil(tst.DeviceSession.LastDevStatus)
+			assert.EqualValues(10, tst.DeviceSession.LastDevStatus.Margin)
+			assert.EqualValues(150, tst.DeviceSession.LastDevStatus.Battery)
 		})
 	}
 }
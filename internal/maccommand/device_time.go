@@ -19,6 +19,10 @@ import (
 //   * Time field
 //   * Current server time
 //
+// As the rx-info set is sorted by signal strength, the first gateway
+// providing the field is used. Gateways without GPS do not set the
+// TimeSinceGpsEpoch field.
+//
 // Note that the last case is a fallback to at least return something. With a
 // high latency between the gateway and the network-server, this timestamp
 // might not be accurate.
@@ -38,7 +42,8 @@ func handleDeviceTimeReq(ds *storage.DeviceSession, rxPacket models.RXPacket) ([
 				log.WithError(err).Error("time since gps epoch to duration error")
 				continue
 			}
-		} else if rxInfo.Time != nil {
+			break
+		} else if rxInfo.Time != nil && timeField.IsZero() {
 			timeField, err = ptypes.Timestamp(rxInfo.Time)
 			if err != nil {
 				log.WithError(err).Error("time to timestamp error")
@@ -42,6 +42,28 @@ func TestDeviceTime(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "time since gps epoch of first gps gateway",
+			RXPacket: models.RXPacket{
+				RXInfoSet: []*gw.UplinkRXInfo{
+					{
+						Time: nowPB,
+					},
+					{
+						TimeSinceGpsEpoch: timeSinceGPSEpochPB,
+					},
+					{
+						TimeSinceGpsEpoch: ptypes.DurationProto(2 * timeSinceGPSEpoch),
+					},
+				},
+			},
+			ExpectedMACCommand: lorawan.MACCommand{
+				CID: lorawan.DeviceTimeAns,
+				Payload: &lorawan.DeviceTimeAnsPayload{
+					TimeSinceGPSEpoch: timeSinceGPSEpoch,
+				},
+			},
+		},
 		{
 			Name: "time field",
 			RXPacket: models.RXPacket{
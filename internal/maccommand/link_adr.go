@@ -3,6 +3,7 @@ package maccommand
 import (
 	"fmt"
 
+	"github.com/brocaar/loraserver/internal/adr"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
@@ -50,6 +51,13 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 	// take the last one
 	adrReq := linkADRPayloads[len(linkADRPayloads)-1]
 
+	ds.LastLinkADRReq = &storage.LinkADRReq{
+		DR:           int(adrReq.DataRate),
+		TXPowerIndex: int(adrReq.TXPower),
+		NbTrans:      adrReq.Redundancy.NbRep,
+		ACK:          channelMaskACK && dataRateACK && powerACK,
+	}
+
 	if channelMaskACK && dataRateACK && powerACK {
 		chans, err := band.Band().GetEnabledUplinkChannelIndicesForLinkADRReqPayloads(ds.EnabledUplinkChannels, linkADRPayloads)
 		if err != nil {
@@ -60,6 +68,7 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 		ds.DR = int(adrReq.DataRate)
 		ds.NbTrans = adrReq.Redundancy.NbRep
 		ds.EnabledUplinkChannels = chans
+		ds.LinkADRReqRejectionCount = 0
 
 		log.WithFields(log.Fields{
 			"dev_eui":          ds.DevEUI,
@@ -70,6 +79,8 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 		}).Info("link_adr request acknowledged")
 
 	} else {
+		ds.LinkADRReqRejectionCount++
+
 		// TODO: remove workaround once all RN2483 nodes have the issue below
 		// fixed.
 		//
@@ -79,7 +90,6 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 		// when TXPower 0 is not supported. See also section 5.2 in the
 		// LoRaWAN specs.
 		if !powerACK && adrReq.TXPower == 0 {
-			ds.TXPowerIndex = 1
 			ds.MinSupportedTXPowerIndex = 1
 		}
 
@@ -91,12 +101,36 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 			ds.MaxSupportedTXPowerIndex = int(adrReq.TXPower) - 1
 		}
 
+		// The same applies to the data-rate, the next request will be
+		// limited to the rejected data-rate - 1.
+		if !dataRateACK && adrReq.DataRate > 0 {
+			ds.MaxSupportedDR = int(adrReq.DataRate) - 1
+			ds.MaxSupportedDRSet = true
+		}
+
+		// The device rejected the channel-mask. The enabled channels of the
+		// device-session are still the known-good channels, make sure no
+		// further channel-mask reconfiguration is requested.
+		if !channelMaskACK {
+			ds.ChannelMaskRejected = true
+		}
+
 		log.WithFields(log.Fields{
 			"dev_eui":          ds.DevEUI,
 			"channel_mask_ack": channelMaskACK,
 			"data_rate_ack":    dataRateACK,
 			"power_ack":        powerACK,
+			"rejection_count":  ds.LinkADRReqRejectionCount,
 		}).Warning("link_adr request not acknowledged")
+
+		if max := adr.GetMaxLinkADRReqRejections(); max > 0 && ds.LinkADRReqRejectionCount >= max && !ds.ADRStopped {
+			ds.ADRStopped = true
+
+			log.WithFields(log.Fields{
+				"dev_eui":         ds.DevEUI,
+				"rejection_count": ds.LinkADRReqRejectionCount,
+			}).Warning("too many link_adr requests rejected, adr stopped")
+		}
 	}
 
 	return nil, nil
@@ -0,0 +1,77 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/internal/adr"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestLinkADRAnsMaxRejections(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.MaxLinkADRReqRejections = 2
+	assert.NoError(adr.Setup(conf))
+	defer adr.Setup(test.GetConfig())
+
+	asClient := test.NewApplicationClient()
+
+	ds := storage.DeviceSession{
+		DevEUI:                lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		EnabledUplinkChannels: []int{0, 1, 2},
+	}
+
+	pending := storage.MACCommandBlock{
+		CID: lorawan.LinkADRReq,
+		MACCommands: storage.MACCommands{
+			{
+				CID: lorawan.LinkADRReq,
+				Payload: &lorawan.LinkADRReqPayload{
+					ChMask:   lorawan.ChMask{true, true, true},
+					DataRate: 5,
+					TXPower:  2,
+					Redundancy: lorawan.Redundancy{
+						NbRep: 1,
+					},
+				},
+			},
+		},
+	}
+
+	answer := storage.MACCommandBlock{
+		CID: lorawan.LinkADRAns,
+		MACCommands: storage.MACCommands{
+			{
+				CID: lorawan.LinkADRAns,
+				Payload: &lorawan.LinkADRAnsPayload{
+					ChannelMaskACK: true,
+					DataRateACK:    false,
+					PowerACK:       true,
+				},
+			},
+		},
+	}
+
+	_, err := Handle(&ds, storage.DeviceProfile{}, storage.ServiceProfile{}, asClient, answer, &pending, models.RXPacket{})
+	assert.NoError(err)
+	assert.Equal(1, ds.LinkADRReqRejectionCount)
+	assert.False(ds.ADRStopped)
+
+	_, err = Handle(&ds, storage.DeviceProfile{}, storage.ServiceProfile{}, asClient, answer, &pending, models.RXPacket{})
+	assert.NoError(err)
+	assert.Equal(2, ds.LinkADRReqRejectionCount)
+	assert.True(ds.ADRStopped)
+
+	// the session parameters are only updated on a positive ack
+	assert.Equal(0, ds.DR)
+	assert.Equal(0, ds.TXPowerIndex)
+	assert.Equal([]int{0, 1, 2}, ds.EnabledUplinkChannels)
+	assert.Equal(4, ds.MaxSupportedDR)
+	assert.True(ds.MaxSupportedDRSet)
+}
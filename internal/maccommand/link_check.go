@@ -30,10 +30,34 @@ func handleLinkCheckReq(ds *storage.DeviceSession, rxPacket models.RXPacket) ([]
 		return nil, fmt.Errorf("sf %d not in sf to required snr table", modInfo.SpreadingFactor)
 	}
 
-	margin := rxPacket.RXInfoSet[0].LoraSnr - requiredSNR
+	// the rx info-set is sorted by signal strength, which is not always
+	// the best SNR (e.g. when sorting on RSSI), and it could contain
+	// multiple receptions by the same gateway
+	maxSNR := rxPacket.RXInfoSet[0].LoraSnr
+	gateways := make(map[lorawan.EUI64]struct{})
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		if rxInfo.LoraSnr > maxSNR {
+			maxSNR = rxInfo.LoraSnr
+		}
+
+		var id lorawan.EUI64
+		copy(id[:], rxInfo.GatewayId)
+		gateways[id] = struct{}{}
+	}
+
+	// the margin value 255 is reserved
+	margin := maxSNR - requiredSNR
 	if margin < 0 {
 		margin = 0
 	}
+	if margin > 254 {
+		margin = 254
+	}
+
+	gwCnt := len(gateways)
+	if gwCnt > 255 {
+		gwCnt = 255
+	}
 
 	block := storage.MACCommandBlock{
 		CID: lorawan.LinkCheckAns,
@@ -42,7 +66,7 @@ func handleLinkCheckReq(ds *storage.DeviceSession, rxPacket models.RXPacket) ([]
 				CID: lorawan.LinkCheckAns,
 				Payload: &lorawan.LinkCheckAnsPayload{
 					Margin: uint8(margin),
-					GwCnt:  uint8(len(rxPacket.RXInfoSet)),
+					GwCnt:  uint8(gwCnt),
 				},
 			},
 		},
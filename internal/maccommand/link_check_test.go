@@ -65,6 +65,57 @@ func (ts *LinkCheckTestSuite) TestLinkCheckReq() {
 	}, resp[0])
 }
 
+func (ts *LinkCheckTestSuite) TestLinkCheckReqMultipleGateways() {
+	assert := require.New(ts.T())
+
+	ds := storage.DeviceSession{
+		DevEUI:                [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		EnabledUplinkChannels: []int{0, 1},
+	}
+
+	block := storage.MACCommandBlock{
+		CID: lorawan.LinkCheckReq,
+		MACCommands: storage.MACCommands{
+			lorawan.MACCommand{
+				CID: lorawan.LinkCheckReq,
+			},
+		},
+	}
+
+	// sorted by signal strength, the best SNR is not the first item
+	rxPacket := models.RXPacket{
+		TXInfo: &gw.UplinkTXInfo{},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{
+				GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+				LoraSnr:   6,
+				Rssi:      -50,
+			},
+			{
+				GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2},
+				LoraSnr:   9,
+				Rssi:      -80,
+			},
+			{
+				GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+				LoraSnr:   3,
+				Rssi:      -90,
+			},
+		},
+	}
+
+	assert.NoError(helpers.SetUplinkTXInfoDataRate(rxPacket.TXInfo, 2, band.Band()))
+
+	resp, err := Handle(&ds, storage.DeviceProfile{}, storage.ServiceProfile{}, nil, block, nil, rxPacket)
+	assert.NoError(err)
+
+	assert.Len(resp, 1)
+	assert.Equal(&lorawan.LinkCheckAnsPayload{
+		GwCnt:  2,
+		Margin: 24, // 9 - -15 (see SpreadFactorToRequiredSNRTable)
+	}, resp[0].MACCommands[0].Payload)
+}
+
 func TestLinkCheck(t *testing.T) {
 	suite.Run(t, new(LinkCheckTestSuite))
 }
@@ -61,6 +61,12 @@ func TestHandleDownlink(t *testing.T) {
 							TXPowerIndex:          3,
 							NbTrans:               2,
 							DR:                    5,
+							LastLinkADRReq: &storage.LinkADRReq{
+								DR:           5,
+								TXPowerIndex: 3,
+								NbTrans:      2,
+								ACK:          true,
+							},
 						},
 					},
 					{
@@ -84,10 +90,16 @@ func TestHandleDownlink(t *testing.T) {
 						ExpectedDeviceSession: storage.DeviceSession{
 							EnabledUplinkChannels:    []int{0, 1},
 							MaxSupportedTXPowerIndex: 2,
+							LinkADRReqRejectionCount: 1,
+							LastLinkADRReq: &storage.LinkADRReq{
+								DR:           5,
+								TXPowerIndex: 3,
+								NbTrans:      2,
+							},
 						},
 					},
 					{
-						Name: "pending request and negative tx-power ack on tx-power 0 sets (min) tx-power to 1",
+						Name: "pending request and negative tx-power ack on tx-power 0 sets min tx-power to 1",
 						DeviceSession: storage.DeviceSession{
 							EnabledUplinkChannels: []int{0, 1},
 						},
@@ -106,8 +118,106 @@ func TestHandleDownlink(t *testing.T) {
 						},
 						ExpectedDeviceSession: storage.DeviceSession{
 							EnabledUplinkChannels:    []int{0, 1},
-							TXPowerIndex:             1,
 							MinSupportedTXPowerIndex: 1,
+							LinkADRReqRejectionCount: 1,
+							LastLinkADRReq: &storage.LinkADRReq{
+								DR:      5,
+								NbTrans: 2,
+							},
+						},
+					},
+					{
+						Name: "pending request and negative data-rate ack sets the max supported data-rate",
+						DeviceSession: storage.DeviceSession{
+							EnabledUplinkChannels: []int{0, 1},
+							DR:                    2,
+						},
+						LinkADRReqPayload: &lorawan.LinkADRReqPayload{
+							ChMask:   lorawan.ChMask{true, true},
+							DataRate: 5,
+							TXPower:  3,
+							Redundancy: lorawan.Redundancy{
+								NbRep: 1,
+							},
+						},
+						LinkADRAnsPayload: lorawan.LinkADRAnsPayload{
+							ChannelMaskACK: true,
+							DataRateACK:    false,
+							PowerACK:       true,
+						},
+						ExpectedDeviceSession: storage.DeviceSession{
+							EnabledUplinkChannels:    []int{0, 1},
+							DR:                       2,
+							MaxSupportedDR:           4,
+							MaxSupportedDRSet:        true,
+							LinkADRReqRejectionCount: 1,
+							LastLinkADRReq: &storage.LinkADRReq{
+								DR:           5,
+								TXPowerIndex: 3,
+								NbTrans:      1,
+							},
+						},
+					},
+					{
+						Name: "pending request and negative channel-mask ack keeps the enabled channels and flags the device",
+						DeviceSession: storage.DeviceSession{
+							EnabledUplinkChannels:    []int{0, 1},
+							LinkADRReqRejectionCount: 1,
+						},
+						LinkADRReqPayload: &lorawan.LinkADRReqPayload{
+							ChMask:   lorawan.ChMask{true, true, true},
+							DataRate: 5,
+							TXPower:  3,
+							Redundancy: lorawan.Redundancy{
+								NbRep: 1,
+							},
+						},
+						LinkADRAnsPayload: lorawan.LinkADRAnsPayload{
+							ChannelMaskACK: false,
+							DataRateACK:    true,
+							PowerACK:       true,
+						},
+						ExpectedDeviceSession: storage.DeviceSession{
+							EnabledUplinkChannels:    []int{0, 1},
+							ChannelMaskRejected:      true,
+							LinkADRReqRejectionCount: 2,
+							LastLinkADRReq: &storage.LinkADRReq{
+								DR:           5,
+								TXPowerIndex: 3,
+								NbTrans:      1,
+							},
+						},
+					},
+					{
+						Name: "pending request and positive ACK resets the rejection count",
+						DeviceSession: storage.DeviceSession{
+							EnabledUplinkChannels:    []int{0, 1},
+							LinkADRReqRejectionCount: 2,
+						},
+						LinkADRReqPayload: &lorawan.LinkADRReqPayload{
+							ChMask:   lorawan.ChMask{true, true},
+							DataRate: 3,
+							TXPower:  1,
+							Redundancy: lorawan.Redundancy{
+								NbRep: 1,
+							},
+						},
+						LinkADRAnsPayload: lorawan.LinkADRAnsPayload{
+							ChannelMaskACK: true,
+							DataRateACK:    true,
+							PowerACK:       true,
+						},
+						ExpectedDeviceSession: storage.DeviceSession{
+							EnabledUplinkChannels: []int{0, 1},
+							TXPowerIndex:          1,
+							NbTrans:               1,
+							DR:                    3,
+							LastLinkADRReq: &storage.LinkADRReq{
+								DR:           3,
+								TXPowerIndex: 1,
+								NbTrans:      1,
+								ACK:          true,
+							},
 						},
 					},
 					{
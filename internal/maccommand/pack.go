@@ -0,0 +1,63 @@
+package maccommand
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// Pack selects the mac-command blocks that fit within maxSize bytes. It
+// returns the selected blocks and the blocks that must be sent on a next
+// downlink opportunity.
+//
+// Internal blocks get priority over blocks enqueued by an external service,
+// unless externalFirst is set to true. Internal blocks that do not fit are
+// skipped so that smaller blocks can still be sent. For external blocks,
+// the queue order is preserved: once an external block does not fit, all
+// the external blocks following it are held back.
+func Pack(blocks []storage.MACCommandBlock, maxSize int, externalFirst bool) ([]storage.MACCommandBlock, []storage.MACCommandBlock, error) {
+	var internal, external []storage.MACCommandBlock
+	for _, block := range blocks {
+		if block.External {
+			external = append(external, block)
+		} else {
+			internal = append(internal, block)
+		}
+	}
+
+	var ordered []storage.MACCommandBlock
+	if externalFirst {
+		ordered = append(external, internal...)
+	} else {
+		ordered = append(internal, external...)
+	}
+
+	var packed, remaining []storage.MACCommandBlock
+	var externalHeldBack bool
+	remainingSize := maxSize
+
+	for _, block := range ordered {
+		if block.External && externalHeldBack {
+			remaining = append(remaining, block)
+			continue
+		}
+
+		size, err := block.Size()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "get mac-command block size error")
+		}
+
+		if size > remainingSize {
+			if block.External {
+				externalHeldBack = true
+			}
+			remaining = append(remaining, block)
+			continue
+		}
+
+		remainingSize -= size
+		packed = append(packed, block)
+	}
+
+	return packed, remaining, nil
+}
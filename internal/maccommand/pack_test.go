@@ -0,0 +1,104 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+func TestPack(t *testing.T) {
+	// 5 bytes
+	linkADRReq := storage.MACCommandBlock{
+		CID: lorawan.LinkADRReq,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.LinkADRReq, Payload: &lorawan.LinkADRReqPayload{}},
+		},
+	}
+
+	// 12 bytes
+	newChannelReq := storage.MACCommandBlock{
+		CID: lorawan.NewChannelReq,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000}},
+			{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000}},
+		},
+	}
+
+	// 5 bytes
+	rxParamSetupReq := storage.MACCommandBlock{
+		CID:      lorawan.RXParamSetupReq,
+		External: true,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.RXParamSetupReq, Payload: &lorawan.RXParamSetupReqPayload{Frequency: 869525000}},
+		},
+	}
+
+	// 2 bytes
+	dutyCycleReq := storage.MACCommandBlock{
+		CID:      lorawan.DutyCycleReq,
+		External: true,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.DutyCycleReq, Payload: &lorawan.DutyCycleReqPayload{MaxDCycle: 1}},
+		},
+	}
+
+	// 6 bytes
+	externalNewChannelReq := storage.MACCommandBlock{
+		CID:      lorawan.NewChannelReq,
+		External: true,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 5, Freq: 867500000}},
+		},
+	}
+
+	// 1 byte
+	devStatusReq := storage.MACCommandBlock{
+		CID:      lorawan.DevStatusReq,
+		External: true,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.DevStatusReq},
+		},
+	}
+
+	t.Run("Drain in three downlinks", func(t *testing.T) {
+		assert := require.New(t)
+
+		remaining := []storage.MACCommandBlock{rxParamSetupReq, linkADRReq, dutyCycleReq, newChannelReq, externalNewChannelReq, devStatusReq}
+		expected := [][]storage.MACCommandBlock{
+			{linkADRReq, rxParamSetupReq, dutyCycleReq},
+			{newChannelReq},
+			{externalNewChannelReq, devStatusReq},
+		}
+
+		for _, exp := range expected {
+			var packed []storage.MACCommandBlock
+			var err error
+
+			packed, remaining, err = Pack(remaining, 15, false)
+			assert.NoError(err)
+			assert.Equal(exp, packed)
+		}
+		assert.Len(remaining, 0)
+	})
+
+	t.Run("External first", func(t *testing.T) {
+		assert := require.New(t)
+
+		packed, remaining, err := Pack([]storage.MACCommandBlock{linkADRReq, newChannelReq, rxParamSetupReq, dutyCycleReq}, 15, true)
+		assert.NoError(err)
+		assert.Equal([]storage.MACCommandBlock{rxParamSetupReq, dutyCycleReq, linkADRReq}, packed)
+		assert.Equal([]storage.MACCommandBlock{newChannelReq}, remaining)
+	})
+
+	t.Run("External queue order is preserved", func(t *testing.T) {
+		assert := require.New(t)
+
+		packed, remaining, err := Pack([]storage.MACCommandBlock{linkADRReq, externalNewChannelReq, devStatusReq}, 10, false)
+		assert.NoError(err)
+		assert.Equal([]storage.MACCommandBlock{linkADRReq}, packed)
+		assert.Equal([]storage.MACCommandBlock{externalNewChannelReq, devStatusReq}, remaining)
+	})
+}
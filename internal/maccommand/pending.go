@@ -0,0 +1,82 @@
+package maccommand
+
+import (
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// HandlePendingTimeouts handles the pending mac-command blocks for which no
+// answer was received within ackUplinks uplinks. fCnt is the frame-counter
+// of the current uplink.
+// Timed-out blocks that were enqueued by an external service are re-queued
+// until maxRetries has been reached. All other timed-out blocks are dropped.
+// Internal mac-commands are not re-queued as these are re-generated on the
+// next downlink when still needed.
+func HandlePendingTimeouts(p *redis.Pool, devEUI lorawan.EUI64, fCnt uint32, ackUplinks, maxRetries int) error {
+	if ackUplinks <= 0 {
+		return nil
+	}
+
+	blocks, err := storage.GetPendingMACCommands(p, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get pending mac-commands error")
+	}
+
+	return HandlePendingBlocks(p, devEUI, blocks, nil, fCnt, ackUplinks, maxRetries)
+}
+
+// HandlePendingBlocks handles the timeouts of the given (already fetched)
+// pending mac-command blocks like HandlePendingTimeouts and deletes the
+// pending mac-commands for the given answered CIDs. All changes are written
+// in a single round-trip.
+func HandlePendingBlocks(p *redis.Pool, devEUI lorawan.EUI64, blocks []storage.MACCommandBlock, answered []lorawan.CID, fCnt uint32, ackUplinks, maxRetries int) error {
+	deleteCIDs := append([]lorawan.CID{}, answered...)
+	var queueBlocks []storage.MACCommandBlock
+
+	for _, block := range blocks {
+		if ackUplinks <= 0 || !pendingTimedOut(block, fCnt, ackUplinks) {
+			continue
+		}
+
+		deleteCIDs = append(deleteCIDs, block.CID)
+
+		if block.External && block.RetryCount < maxRetries {
+			block.FCntUp = 0
+			block.RetryCount++
+			queueBlocks = append(queueBlocks, block)
+
+			log.WithFields(log.Fields{
+				"dev_eui":     devEUI,
+				"cid":         block.CID,
+				"retry_count": block.RetryCount,
+			}).Warning("pending mac-command timed out, re-queued")
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":     devEUI,
+			"cid":         block.CID,
+			"external":    block.External,
+			"retry_count": block.RetryCount,
+		}).Warning("pending mac-command timed out, dropped")
+	}
+
+	if err := storage.UpdatePendingMACCommands(p, devEUI, deleteCIDs, queueBlocks); err != nil {
+		return errors.Wrap(err, "update pending mac-commands error")
+	}
+
+	return nil
+}
+
+// pendingTimedOut returns true when the given number of uplinks (including
+// the current one) has been received since the block was sent.
+func pendingTimedOut(block storage.MACCommandBlock, fCnt uint32, ackUplinks int) bool {
+	if fCnt < block.FCntUp {
+		return false
+	}
+	return fCnt-block.FCntUp+1 >= uint32(ackUplinks)
+}
@@ -0,0 +1,233 @@
+package maccommand
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type PendingTestSuite struct {
+	TestBase
+}
+
+func (ts *PendingTestSuite) TestHandlePendingTimeouts() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	block := storage.MACCommandBlock{
+		CID:      lorawan.DevStatusReq,
+		External: true,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.DevStatusReq},
+		},
+		FCntUp: 10,
+	}
+
+	tests := []struct {
+		Name          string
+		Block         storage.MACCommandBlock
+		FCnt          uint32
+		ExpectPending bool
+		ExpectedQueue []storage.MACCommandBlock
+	}{
+		{
+			Name:          "not yet timed out",
+			Block:         block,
+			FCnt:          11,
+			ExpectPending: true,
+		},
+		{
+			Name:  "timed out external block is re-queued",
+			Block: block,
+			FCnt:  12,
+			ExpectedQueue: []storage.MACCommandBlock{
+				{
+					CID:         block.CID,
+					External:    true,
+					MACCommands: block.MACCommands,
+					RetryCount:  1,
+				},
+			},
+		},
+		{
+			Name: "timed out external block exceeding max retries is dropped",
+			Block: storage.MACCommandBlock{
+				CID:         block.CID,
+				External:    true,
+				MACCommands: block.MACCommands,
+				FCntUp:      10,
+				RetryCount:  2,
+			},
+			FCnt: 12,
+		},
+		{
+			Name: "timed out internal block is dropped",
+			Block: storage.MACCommandBlock{
+				CID:         block.CID,
+				MACCommands: block.MACCommands,
+				FCntUp:      10,
+			},
+			FCnt: 12,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			ts.SetupTest()
+
+			assert.NoError(storage.SetPendingMACCommand(storage.RedisPool(), devEUI, tst.Block))
+			assert.NoError(HandlePendingTimeouts(storage.RedisPool(), devEUI, tst.FCnt, 3, 2))
+
+			pending, err := storage.GetPendingMACCommand(storage.RedisPool(), devEUI, tst.Block.CID)
+			assert.NoError(err)
+			if tst.ExpectPending {
+				assert.Equal(&tst.Block, pending)
+			} else {
+				assert.Nil(pending)
+			}
+
+			queue, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedQueue, queue)
+		})
+	}
+}
+
+func (ts *PendingTestSuite) TestHandlePendingBlocks() {
+	assert := require.New(ts.T())
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	answered := storage.MACCommandBlock{
+		CID:         lorawan.LinkADRReq,
+		MACCommands: storage.MACCommands{{CID: lorawan.LinkADRReq}},
+		FCntUp:      11,
+	}
+	timedOut := storage.MACCommandBlock{
+		CID:         lorawan.DevStatusReq,
+		External:    true,
+		MACCommands: storage.MACCommands{{CID: lorawan.DevStatusReq}},
+		FCntUp:      10,
+	}
+
+	assert.NoError(storage.SetPendingMACCommand(storage.RedisPool(), devEUI, answered))
+	assert.NoError(storage.SetPendingMACCommand(storage.RedisPool(), devEUI, timedOut))
+
+	assert.NoError(HandlePendingBlocks(storage.RedisPool(), devEUI, []storage.MACCommandBlock{timedOut}, []lorawan.CID{answered.CID}, 12, 3, 2))
+
+	pending, err := storage.GetPendingMACCommands(storage.RedisPool(), devEUI)
+	assert.NoError(err)
+	assert.Len(pending, 0)
+
+	queue, err := storage.GetMACCommandQueueItems(storage.RedisPool(), devEUI)
+	assert.NoError(err)
+	assert.Equal([]storage.MACCommandBlock{
+		{
+			CID:         timedOut.CID,
+			External:    true,
+			MACCommands: timedOut.MACCommands,
+			RetryCount:  1,
+		},
+	}, queue)
+}
+
+func TestPending(t *testing.T) {
+	suite.Run(t, new(PendingTestSuite))
+}
+
+// countingConn counts the number of round-trips to Redis.
+type countingConn struct {
+	redis.Conn
+	count *int64
+}
+
+func (c countingConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	if cmd != "" {
+		atomic.AddInt64(c.count, 1)
+	}
+	return c.Conn.Do(cmd, args...)
+}
+
+// BenchmarkPendingMACCommandsPerUplink compares the Redis round-trips
+// needed per uplink answering one pending mac-command, using the per-CID
+// accessors versus the pipelined HandlePendingBlocks.
+func BenchmarkPendingMACCommandsPerUplink(b *testing.B) {
+	conf := test.GetConfig()
+	if err := storage.Setup(conf); err != nil {
+		b.Fatal(err)
+	}
+
+	var count int64
+	p := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			c, err := redis.DialURL(conf.Redis.URL)
+			if err != nil {
+				return nil, err
+			}
+			return countingConn{Conn: c, count: &count}, nil
+		},
+	}
+	defer p.Close()
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	block := storage.MACCommandBlock{
+		CID:         lorawan.LinkADRReq,
+		MACCommands: storage.MACCommands{{CID: lorawan.LinkADRReq}},
+		FCntUp:      10,
+	}
+
+	benchmarks := []struct {
+		name   string
+		uplink func() error
+	}{
+		{
+			name: "per-cid",
+			uplink: func() error {
+				if _, err := storage.GetPendingMACCommand(p, devEUI, block.CID); err != nil {
+					return err
+				}
+				if err := storage.DeletePendingMACCommand(p, devEUI, block.CID); err != nil {
+					return err
+				}
+				return HandlePendingTimeouts(p, devEUI, 11, 3, 2)
+			},
+		},
+		{
+			name: "pipelined",
+			uplink: func() error {
+				blocks, err := storage.GetPendingMACCommands(p, devEUI)
+				if err != nil {
+					return err
+				}
+				return HandlePendingBlocks(p, devEUI, nil, []lorawan.CID{blocks[0].CID}, 11, 3, 2)
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			test.MustFlushRedis(storage.RedisPool())
+			atomic.StoreInt64(&count, 0)
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				if err := storage.SetPendingMACCommand(storage.RedisPool(), devEUI, block); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+
+				if err := bm.uplink(); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.Logf("round-trips per uplink: %.1f", float64(atomic.LoadInt64(&count))/float64(b.N))
+		})
+	}
+}
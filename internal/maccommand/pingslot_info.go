@@ -3,8 +3,10 @@ package maccommand
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/internal/downlink/data/classb"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
@@ -19,7 +21,11 @@ func handlePingSlotInfoReq(ds *storage.DeviceSession, block storage.MACCommandBl
 		return nil, fmt.Errorf("expected *lorawan.PingSlotInfoReqPayload, got: %T", block.MACCommands[0].Payload)
 	}
 
-	ds.PingSlotNb = 1 << (7 - pl.Periodicity)
+	pingNb, err := classb.GetPingNbForPeriodicity(int(pl.Periodicity))
+	if err != nil {
+		return nil, errors.Wrap(err, "get ping-slot nb error")
+	}
+	ds.PingSlotNb = pingNb
 
 	log.WithFields(log.Fields{
 		"dev_eui":      ds.DevEUI,
@@ -0,0 +1,49 @@
+package code
+
+import (
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// RebuildDevAddrIndex rebuilds the DevAddr to DevEUI index used for the
+// uplink device-session lookup. As device-sessions stored by older versions
+// might not have been added to the index (or stale entries have been left
+// behind), each device-session is re-saved which will add it to the index
+// of its (pending) DevAddr.
+func RebuildDevAddrIndex(p *redis.Pool, db sqlx.Queryer) error {
+	var devEUIs []lorawan.EUI64
+	err := sqlx.Select(db, &devEUIs, `
+		select
+			dev_eui
+		from
+			device
+	`)
+	if err != nil {
+		return errors.Wrap(err, "select device eui error")
+	}
+
+	var count int
+	for _, devEUI := range devEUIs {
+		ds, err := storage.GetDeviceSession(p, devEUI)
+		if err != nil {
+			if err == storage.ErrDoesNotExist {
+				continue
+			}
+			return errors.Wrap(err, "get device-session error")
+		}
+
+		if err := storage.SaveDeviceSession(p, ds); err != nil {
+			return errors.Wrap(err, "save device-session error")
+		}
+		count++
+	}
+
+	log.WithField("device_session_count", count).Info("devaddr index rebuilt")
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package code
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type RebuildDevAddrIndexTestSuite struct {
+	suite.Suite
+}
+
+func (ts *RebuildDevAddrIndexTestSuite) SetupSuite() {
+	conf := test.GetConfig()
+	if err := storage.Setup(conf); err != nil {
+		panic(err)
+	}
+
+	test.MustResetDB(storage.DB().DB)
+}
+
+func (ts *RebuildDevAddrIndexTestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *RebuildDevAddrIndexTestSuite) TestRebuildDevAddrIndex() {
+	assert := require.New(ts.T())
+
+	// test a clean database
+	assert.NoError(RebuildDevAddrIndex(storage.RedisPool(), storage.DB()))
+
+	var sp storage.ServiceProfile
+	var dp storage.DeviceProfile
+	var rp storage.RoutingProfile
+	assert.NoError(storage.CreateServiceProfile(storage.DB(), &sp))
+	assert.NoError(storage.CreateDeviceProfile(storage.DB(), &dp))
+	assert.NoError(storage.CreateRoutingProfile(storage.DB(), &rp))
+
+	d := storage.Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ServiceProfileID: sp.ID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateDevice(storage.DB(), &d))
+
+	ds := storage.DeviceSession{
+		DevEUI:  d.DevEUI,
+		DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+	}
+	assert.NoError(storage.SaveDeviceSession(storage.RedisPool(), ds))
+
+	// remove the devaddr index
+	c := storage.RedisPool().Get()
+	defer c.Close()
+	_, err := c.Do("DEL", fmt.Sprintf("lora:ns:devaddr:%s", ds.DevAddr))
+	assert.NoError(err)
+
+	sessions, err := storage.GetDeviceSessionsForDevAddr(storage.RedisPool(), ds.DevAddr)
+	assert.NoError(err)
+	assert.Len(sessions, 0)
+
+	assert.NoError(RebuildDevAddrIndex(storage.RedisPool(), storage.DB()))
+
+	sessions, err = storage.GetDeviceSessionsForDevAddr(storage.RedisPool(), ds.DevAddr)
+	assert.NoError(err)
+	assert.Len(sessions, 1)
+	assert.Equal(d.DevEUI, sessions[0].DevEUI)
+}
+
+func TestRebuildDevAddrIndex(t *testing.T) {
+	suite.Run(t, new(RebuildDevAddrIndexTestSuite))
+}
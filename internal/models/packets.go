@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/lorawan"
 )
@@ -15,6 +17,25 @@ type RXPacket struct {
 	PHYPayload lorawan.PHYPayload
 	TXInfo     *gw.UplinkTXInfo
 	RXInfoSet  []*gw.UplinkRXInfo
+
+	// AntennaRXInfoSet contains the rx-info of all the receiving gateway
+	// antennas. RXInfoSet only contains the best antenna of each gateway.
+	// This is not set when each gateway received the uplink on a single
+	// antenna.
+	AntennaRXInfoSet []*gw.UplinkRXInfo
+
+	// ReceivedAt contains the time when the network-server received the
+	// (first) uplink frame. This is not set when unknown.
+	ReceivedAt time.Time
+}
+
+// GetAntennaRXInfoSet returns the rx-info of all the receiving gateway
+// antennas.
+func (p RXPacket) GetAntennaRXInfoSet() []*gw.UplinkRXInfo {
+	if len(p.AntennaRXInfoSet) != 0 {
+		return p.AntennaRXInfoSet
+	}
+	return p.RXInfoSet
 }
 
 // BySignalStrength implements sort.Interface for []gw.UplinkRXInfo
@@ -42,3 +63,25 @@ func (s BySignalStrength) Less(i, j int) bool {
 
 	return s[i].LoraSnr > s[j].LoraSnr
 }
+
+// GetBestAntennaRXInfoSet returns the given rx-info set, containing only the
+// first rx-info item of each gateway. As the rx-info set is sorted by signal
+// strength, this is the antenna which received the uplink best. The order
+// of the rx-info set is retained.
+func GetBestAntennaRXInfoSet(rxInfoSet []*gw.UplinkRXInfo) []*gw.UplinkRXInfo {
+	seen := make(map[lorawan.EUI64]struct{})
+	out := make([]*gw.UplinkRXInfo, 0, len(rxInfoSet))
+
+	for _, rxInfo := range rxInfoSet {
+		var gatewayID lorawan.EUI64
+		copy(gatewayID[:], rxInfo.GatewayId)
+
+		if _, ok := seen[gatewayID]; ok {
+			continue
+		}
+		seen[gatewayID] = struct{}{}
+		out = append(out, rxInfo)
+	}
+
+	return out
+}
@@ -42,3 +42,29 @@ func TestRXInfoSet(t *testing.T) {
 
 	assert.Equal(rxInfoSetExpected, rxInfoSet)
 }
+
+func TestGetBestAntennaRXInfoSet(t *testing.T) {
+	assert := require.New(t)
+
+	rxInfoSet := []*gw.UplinkRXInfo{
+		{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Antenna: 1, LoraSnr: 7},
+		{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Antenna: 0, LoraSnr: 6},
+		{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Antenna: 0, LoraSnr: 5},
+		{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Antenna: 1, LoraSnr: 4},
+		{GatewayId: []byte{3, 3, 3, 3, 3, 3, 3, 3}, Antenna: 0, LoraSnr: 3},
+	}
+
+	assert.Equal([]*gw.UplinkRXInfo{
+		rxInfoSet[0],
+		rxInfoSet[1],
+		rxInfoSet[4],
+	}, GetBestAntennaRXInfoSet(rxInfoSet))
+
+	rxPacket := RXPacket{
+		RXInfoSet: GetBestAntennaRXInfoSet(rxInfoSet),
+	}
+	assert.Len(rxPacket.GetAntennaRXInfoSet(), 3)
+
+	rxPacket.AntennaRXInfoSet = rxInfoSet
+	assert.Len(rxPacket.GetAntennaRXInfoSet(), 5)
+}
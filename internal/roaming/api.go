@@ -0,0 +1,331 @@
+package roaming
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/dutycycle"
+	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/helpers"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// resultError is an error with a corresponding Backend Interfaces result
+// code.
+type resultError struct {
+	code backend.ResultCode
+	err  error
+}
+
+func (e resultError) Error() string {
+	return e.err.Error()
+}
+
+var apiServer *http.Server
+
+// startAPIServer starts the roaming API server. As the API allows roaming
+// partners to send downlinks through the gateways of this network, the
+// roaming partners must authenticate using a client-certificate.
+func startAPIServer(c config.Config) error {
+	conf := c.Roaming.API
+
+	log.WithFields(log.Fields{
+		"bind":     conf.Bind,
+		"ca_cert":  conf.CACert,
+		"tls_cert": conf.TLSCert,
+		"tls_key":  conf.TLSKey,
+	}).Info("roaming: starting roaming api server")
+
+	if conf.CACert == "" || conf.TLSCert == "" || conf.TLSKey == "" {
+		return errors.New("roaming: ca_cert, tls_cert and tls_key must be set for the roaming api")
+	}
+
+	rawCACert, err := ioutil.ReadFile(conf.CACert)
+	if err != nil {
+		return errors.Wrap(err, "roaming: load ca cert error")
+	}
+
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(rawCACert) {
+		return errors.New("roaming: append ca cert to pool error")
+	}
+
+	server := &http.Server{
+		Handler: &API{},
+		Addr:    conf.Bind,
+		TLSConfig: &tls.Config{
+			ClientCAs:  caCertPool,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		},
+	}
+
+	mux.Lock()
+	apiServer = server
+	mux.Unlock()
+
+	go func() {
+		err := server.ListenAndServeTLS(conf.TLSCert, conf.TLSKey)
+		if err != http.ErrServerClosed {
+			log.WithError(err).Error("roaming: roaming api server error")
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the roaming API server after all requests in progress have
+// completed or the given timeout has expired.
+func Stop(timeout time.Duration) error {
+	mux.RLock()
+	server := apiServer
+	mux.RUnlock()
+
+	if server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.WithError(err).Warning("roaming: graceful stop of roaming api server timed out")
+		return server.Close()
+	}
+
+	return nil
+}
+
+// API implements the Backend Interfaces API for receiving the downlinks
+// (XmitDataReq) from the roaming partners.
+type API struct{}
+
+// ServeHTTP implements the http.Handler interface.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var basePL backend.BasePayload
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.WithError(err).Error("roaming: read request body error")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := json.Unmarshal(b, &basePL); err != nil {
+		a.writeXmitDataAns(w, basePL, resultError{backend.MalformedRequest, errors.Wrap(err, "unmarshal request error")})
+		return
+	}
+
+	if basePL.MessageType != backend.XmitDataReq {
+		a.writeXmitDataAns(w, basePL, resultError{backend.MalformedRequest, errors.Errorf("unexpected message-type: %s", basePL.MessageType)})
+		return
+	}
+
+	if err := validateSenderCertificate(r, basePL.SenderID); err != nil {
+		a.writeXmitDataAns(w, basePL, resultError{backend.UnknownSender, err})
+		return
+	}
+
+	var pl backend.XmitDataReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		a.writeXmitDataAns(w, basePL, resultError{backend.MalformedRequest, errors.Wrap(err, "unmarshal request error")})
+		return
+	}
+
+	a.writeXmitDataAns(w, basePL, handleXmitDataReq(pl))
+}
+
+func (a *API) writeXmitDataAns(w http.ResponseWriter, req backend.BasePayload, err error) {
+	ans := backend.XmitDataAnsPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        getNetID().String(),
+			ReceiverID:      req.SenderID,
+			TransactionID:   req.TransactionID,
+			MessageType:     backend.XmitDataAns,
+		},
+		Result: backend.Result{
+			ResultCode: backend.Success,
+		},
+	}
+
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"sender_id":      req.SenderID,
+			"transaction_id": req.TransactionID,
+		}).Error("roaming: handle XmitDataReq error")
+
+		ans.Result.ResultCode = backend.Other
+		if e, ok := err.(resultError); ok {
+			ans.Result.ResultCode = e.code
+		}
+		ans.Result.Description = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(ans); err != nil {
+		log.WithError(err).Error("roaming: write response error")
+	}
+}
+
+// validateSenderCertificate validates that the CommonName of the (verified)
+// client-certificate matches the SenderID of the request.
+func validateSenderCertificate(r *http.Request, senderID string) error {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return errors.New("client-certificate is required")
+	}
+
+	if cn := r.TLS.PeerCertificates[0].Subject.CommonName; !strings.EqualFold(cn, senderID) {
+		return errors.Errorf("client-certificate CommonName %s does not match SenderID %s", cn, senderID)
+	}
+
+	return nil
+}
+
+// handleXmitDataReq sends the downlink of the given XmitDataReq to the
+// gateway, using the ULToken of the uplink meta-data.
+func handleXmitDataReq(pl backend.XmitDataReqPayload) error {
+	var senderID lorawan.NetID
+	if err := senderID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		return resultError{backend.UnknownSender, errors.Wrap(err, "unmarshal SenderID error")}
+	}
+	if _, err := GetAgreementForNetID(senderID); err != nil {
+		return resultError{backend.UnknownSender, errors.Wrap(err, "get roaming agreement error")}
+	}
+
+	if pl.DLMetaData == nil || len(pl.PHYPayload) == 0 {
+		return resultError{backend.MalformedRequest, errors.New("DLMetaData and PHYPayload are required")}
+	}
+
+	frame, err := getDownlinkFrame(senderID, pl.PHYPayload, *pl.DLMetaData)
+	if err != nil {
+		return resultError{backend.MalformedRequest, err}
+	}
+
+	if err := storage.SetDownlinkTXInfoPower(storage.DB(), storage.RedisPool(), frame.TxInfo, downlinkTXPower); err != nil {
+		return resultError{backend.XmitFailed, err}
+	}
+
+	if err := gateway.Backend().SendTXPacket(frame); err != nil {
+		return resultError{backend.XmitFailed, errors.Wrap(err, "send downlink-frame to gateway error")}
+	}
+	downlinkTXCounter(senderID).Inc()
+
+	if err := dutycycle.LogDownlinkFrame(storage.RedisPool(), frame); err != nil {
+		log.WithError(err).Error("roaming: log gateway duty-cycle airtime error")
+	}
+
+	if err := framelog.LogDownlinkFrameForGateway(storage.RedisPool(), frame); err != nil {
+		log.WithError(err).Error("roaming: log downlink frame for gateway error")
+	}
+
+	return nil
+}
+
+// getDownlinkFrame returns the downlink-frame for the given PHYPayload and
+// downlink meta-data. The RX1 parameters are used when present, else the
+// RX2 parameters. Only ULTokens issued to the given sender are accepted.
+func getDownlinkFrame(senderID lorawan.NetID, phyPayload []byte, dl backend.DLMetaData) (gw.DownlinkFrame, error) {
+	var rxInfo *gw.UplinkRXInfo
+	for _, gwInfo := range dl.GWInfo {
+		if len(gwInfo.ULToken) == 0 {
+			continue
+		}
+
+		ri, err := getRXInfoFromULToken(senderID, gwInfo.ULToken)
+		if err != nil {
+			return gw.DownlinkFrame{}, errors.Wrap(err, "get rx-info from ULToken error")
+		}
+		rxInfo = &ri
+		break
+	}
+	if rxInfo == nil {
+		return gw.DownlinkFrame{}, errors.New("no gateway with ULToken in DLMetaData")
+	}
+
+	txInfo := gw.DownlinkTXInfo{
+		GatewayId: rxInfo.GatewayId,
+		Board:     rxInfo.Board,
+		Antenna:   rxInfo.Antenna,
+		Context:   rxInfo.Context,
+	}
+
+	delay := band.Band().GetDefaults().ReceiveDelay1
+	if dl.RXDelay1 != nil && *dl.RXDelay1 > 0 {
+		delay = time.Duration(*dl.RXDelay1) * time.Second
+	}
+
+	var freq float64
+	var dr int
+	switch {
+	case dl.DLFreq1 != nil && dl.DataRate1 != nil:
+		freq = *dl.DLFreq1
+		dr = *dl.DataRate1
+	case dl.DLFreq2 != nil && dl.DataRate2 != nil:
+		freq = *dl.DLFreq2
+		dr = *dl.DataRate2
+		delay += time.Second
+	default:
+		return gw.DownlinkFrame{}, errors.New("DLFreq1 and DataRate1 or DLFreq2 and DataRate2 are required")
+	}
+
+	txInfo.Frequency = uint32(math.Round(freq * 1000000))
+
+	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, dr, band.Band()); err != nil {
+		return gw.DownlinkFrame{}, errors.Wrap(err, "set downlink tx-info data-rate error")
+	}
+
+	if dl.ClassMode != nil && *dl.ClassMode == "C" {
+		txInfo.Timing = gw.DownlinkTiming_IMMEDIATELY
+		txInfo.TimingInfo = &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
+			ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
+		}
+	} else {
+		txInfo.Timing = gw.DownlinkTiming_DELAY
+		txInfo.TimingInfo = &gw.DownlinkTXInfo_DelayTimingInfo{
+			DelayTimingInfo: &gw.DelayTimingInfo{
+				Delay: ptypes.DurationProto(delay),
+			},
+		}
+	}
+
+	// the gateway specific TX power is set by the caller
+	txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+
+	token, err := getToken()
+	if err != nil {
+		return gw.DownlinkFrame{}, err
+	}
+
+	return gw.DownlinkFrame{
+		Token:      token,
+		TxInfo:     &txInfo,
+		PhyPayload: phyPayload,
+	}, nil
+}
+
+func getToken() (uint32, error) {
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return 0, errors.Wrap(err, "read random error")
+	}
+	return uint32(binary.BigEndian.Uint16(b)), nil
+}
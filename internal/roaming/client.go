@@ -0,0 +1,136 @@
+package roaming
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan/backend"
+)
+
+// Client defines the roaming partner (network-server) client interface.
+type Client interface {
+	PRStartReq(pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error)
+	XmitDataReq(pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error)
+}
+
+type client struct {
+	server     string
+	httpClient *http.Client
+}
+
+// PRStartReq issues a passive-roaming start request.
+func (c *client) PRStartReq(pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error) {
+	var ans backend.PRStartAnsPayload
+
+	if err := c.request(pl, &ans); err != nil {
+		return ans, err
+	}
+
+	if ans.Result.ResultCode != backend.Success {
+		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	}
+
+	return ans, nil
+}
+
+// XmitDataReq issues a transmit data request.
+func (c *client) XmitDataReq(pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error) {
+	var ans backend.XmitDataAnsPayload
+
+	if err := c.request(pl, &ans); err != nil {
+		return ans, err
+	}
+
+	if ans.Result.ResultCode != backend.Success {
+		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	}
+
+	return ans, nil
+}
+
+func (c *client) request(pl, ans interface{}) error {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal request error")
+	}
+
+	resp, err := c.httpClient.Post(c.server, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "http post error")
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(ans); err != nil {
+		return errors.Wrap(err, "unmarshal response error")
+	}
+
+	return nil
+}
+
+// NewClient creates a new roaming partner client.
+// If the caCert is set, it will configure the CA certificate to validate the
+// server certificate of the roaming partner. When the tlsCert and tlsKey are
+// set, then these will be configured as client-certificates for
+// authentication. Requests taking longer than the given timeout are
+// canceled, as these are made while handling the uplink.
+func NewClient(server, caCert, tlsCert, tlsKey string, timeout time.Duration) (Client, error) {
+	log.WithFields(log.Fields{
+		"server":   server,
+		"ca_cert":  caCert,
+		"tls_cert": tlsCert,
+		"tls_key":  tlsKey,
+		"timeout":  timeout,
+	}).Info("roaming: configuring roaming partner client")
+
+	if caCert == "" && tlsCert == "" && tlsKey == "" {
+		return &client{
+			server: server,
+			httpClient: &http.Client{
+				Timeout: timeout,
+			},
+		}, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caCert != "" {
+		rawCACert, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "load ca cert error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCACert) {
+			return nil, errors.New("append ca cert to pool error")
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if tlsCert != "" || tlsKey != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load x509 keypair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &client{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+			Timeout: timeout,
+		},
+		server: server,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package roaming
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/brocaar/lorawan"
+)
+
+var (
+	ufc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "roaming_uplink_forward_count",
+		Help: "The number of uplink frames forwarded to a roaming partner (per NetID and message-type).",
+	}, []string{"net_id", "message_type"})
+
+	ufec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "roaming_uplink_forward_error_count",
+		Help: "The number of uplink frames that failed to be forwarded to a roaming partner (per NetID).",
+	}, []string{"net_id"})
+
+	udc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "roaming_uplink_dropped_count",
+		Help: "The number of uplink frames dropped because there is no roaming agreement for the DevAddr.",
+	})
+
+	dtc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "roaming_downlink_tx_count",
+		Help: "The number of downlink frames received from a roaming partner and sent to the gateway (per NetID).",
+	}, []string{"net_id"})
+)
+
+func uplinkForwardCounter(netID lorawan.NetID, mt string) prometheus.Counter {
+	return ufc.With(prometheus.Labels{"net_id": netID.String(), "message_type": mt})
+}
+
+func uplinkForwardErrorCounter(netID lorawan.NetID) prometheus.Counter {
+	return ufec.With(prometheus.Labels{"net_id": netID.String()})
+}
+
+func uplinkDroppedCounter() prometheus.Counter {
+	return udc
+}
+
+func downlinkTXCounter(netID lorawan.NetID) prometheus.Counter {
+	return dtc.With(prometheus.Labels{"net_id": netID.String()})
+}
@@ -0,0 +1,181 @@
+// Package roaming implements the (forwarding network-server) passive-roaming
+// using the LoRaWAN Backend Interfaces.
+package roaming
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// ErrNoAgreement is returned when there is no roaming agreement for the
+// given DevAddr or NetID.
+var ErrNoAgreement = errors.New("no roaming agreement")
+
+// Agreement defines a passive-roaming agreement with a roaming partner.
+type Agreement struct {
+	NetID                  lorawan.NetID
+	Client                 Client
+	PassiveRoamingLifetime time.Duration
+}
+
+var (
+	mux             sync.RWMutex
+	enabled         bool
+	netID           lorawan.NetID
+	rfRegion        backend.RFRegion
+	downlinkTXPower int
+	ulTokenKey      []byte
+	agreements      []Agreement
+)
+
+// Setup configures the roaming package and starts the roaming API server
+// (when roaming is enabled).
+func Setup(c config.Config) error {
+	conf := c.Roaming
+
+	var as []Agreement
+	for _, s := range conf.Servers {
+		var a Agreement
+		if err := a.NetID.UnmarshalText([]byte(s.NetID)); err != nil {
+			return errors.Wrap(err, "roaming: unmarshal NetID error")
+		}
+
+		client, err := NewClient(s.Server, s.CACert, s.TLSCert, s.TLSKey, conf.RequestTimeout)
+		if err != nil {
+			return errors.Wrap(err, "roaming: create client error")
+		}
+
+		a.Client = client
+		a.PassiveRoamingLifetime = s.PassiveRoamingLifetime
+		as = append(as, a)
+	}
+
+	key := []byte(conf.API.ULTokenKey)
+	if len(key) == 0 {
+		var err error
+		key, err = getRandomULTokenKey()
+		if err != nil {
+			return errors.Wrap(err, "roaming: get random ULToken key error")
+		}
+
+		if conf.Enabled {
+			log.Warning("roaming: ul_token_key is not set, using a random key (downlinks for uplinks received by other instances will be rejected)")
+		}
+	}
+
+	mux.Lock()
+	enabled = conf.Enabled
+	netID = c.NetworkServer.NetID
+	rfRegion = bandNameToRFRegion(c.NetworkServer.Band.Name)
+	downlinkTXPower = c.NetworkServer.NetworkSettings.DownlinkTXPower
+	ulTokenKey = key
+	agreements = as
+	mux.Unlock()
+
+	if !conf.Enabled {
+		return nil
+	}
+
+	return startAPIServer(c)
+}
+
+// SetAgreements enables roaming and sets the given roaming agreements.
+// This is intended for testing.
+func SetAgreements(nID lorawan.NetID, as []Agreement) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	enabled = true
+	netID = nID
+	agreements = as
+}
+
+// Enabled returns true when passive-roaming is enabled.
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return enabled
+}
+
+// GetAgreementForDevAddr returns the roaming agreement of which the NetID
+// matches the given DevAddr. It returns ErrNoAgreement when there is no such
+// agreement.
+func GetAgreementForDevAddr(devAddr lorawan.DevAddr) (Agreement, error) {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	for _, a := range agreements {
+		if devAddr.IsNetID(a.NetID) {
+			return a, nil
+		}
+	}
+
+	return Agreement{}, ErrNoAgreement
+}
+
+// GetAgreementForNetID returns the roaming agreement for the given NetID.
+// It returns ErrNoAgreement when there is no such agreement.
+func GetAgreementForNetID(nID lorawan.NetID) (Agreement, error) {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	for _, a := range agreements {
+		if a.NetID == nID {
+			return a, nil
+		}
+	}
+
+	return Agreement{}, ErrNoAgreement
+}
+
+func getNetID() lorawan.NetID {
+	mux.RLock()
+	defer mux.RUnlock()
+	return netID
+}
+
+func getRFRegion() backend.RFRegion {
+	mux.RLock()
+	defer mux.RUnlock()
+	return rfRegion
+}
+
+func getTransactionID() (uint32, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return 0, errors.Wrap(err, "read random bytes error")
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func bandNameToRFRegion(name loraband.Name) backend.RFRegion {
+	switch name {
+	case loraband.EU_863_870, loraband.EU868:
+		return backend.EU868
+	case loraband.US_902_928, loraband.US915:
+		return backend.US902
+	case loraband.CN_779_787, loraband.CN779:
+		return backend.China779
+	case loraband.EU_433, loraband.EU433:
+		return backend.EU433
+	case loraband.AU_915_928, loraband.AU915:
+		return backend.Australia915
+	case loraband.CN_470_510, loraband.CN470:
+		return backend.China470
+	case loraband.AS_923, loraband.AS923:
+		return backend.AS923
+	default:
+		return backend.RFRegion(name)
+	}
+}
@@ -0,0 +1,292 @@
+package roaming
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/helpers"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestAgreements(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(Setup(test.GetConfig()))
+
+	devAddr := lorawan.DevAddr{1, 2, 3, 4}
+	assert.False(Enabled())
+
+	// NetID 000000 (type 0, NwkID 0x00) matches DevAddr 01020304
+	SetAgreements(lorawan.NetID{3, 2, 1}, []Agreement{
+		{NetID: lorawan.NetID{0, 0, 0}},
+	})
+
+	assert.True(Enabled())
+
+	a, err := GetAgreementForDevAddr(devAddr)
+	assert.NoError(err)
+	assert.Equal(lorawan.NetID{0, 0, 0}, a.NetID)
+
+	_, err = GetAgreementForDevAddr(lorawan.DevAddr{0x40, 2, 3, 4})
+	assert.Equal(ErrNoAgreement, err)
+
+	_, err = GetAgreementForNetID(lorawan.NetID{0, 0, 0})
+	assert.NoError(err)
+
+	_, err = GetAgreementForNetID(lorawan.NetID{1, 2, 3})
+	assert.Equal(ErrNoAgreement, err)
+}
+
+func TestClient(t *testing.T) {
+	tests := []struct {
+		Name          string
+		ResultCode    backend.ResultCode
+		ExpectedError bool
+	}{
+		{
+			Name:       "success",
+			ResultCode: backend.Success,
+		},
+		{
+			Name:          "no roaming agreement",
+			ResultCode:    backend.NoRoamingAgreement,
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var req backend.BasePayload
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(json.NewDecoder(r.Body).Decode(&req))
+				lifetime := 60
+				assert.NoError(json.NewEncoder(w).Encode(backend.PRStartAnsPayload{
+					BasePayload: backend.BasePayload{
+						MessageType:   backend.PRStartAns,
+						TransactionID: req.TransactionID,
+					},
+					Result:   backend.Result{ResultCode: tst.ResultCode},
+					Lifetime: &lifetime,
+				}))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(server.URL, "", "", "", time.Second)
+			assert.NoError(err)
+
+			ans, err := c.PRStartReq(backend.PRStartReqPayload{
+				BasePayload: backend.BasePayload{
+					MessageType:   backend.PRStartReq,
+					TransactionID: 1234,
+				},
+			})
+			assert.Equal(backend.PRStartReq, req.MessageType)
+			assert.EqualValues(1234, ans.TransactionID)
+
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(60, *ans.Lifetime)
+		})
+	}
+}
+
+func TestULMetaDataToDownlinkFrame(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(Setup(test.GetConfig()))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	nowPB, err := ptypes.TimestampProto(now)
+	assert.NoError(err)
+
+	rxInfo := gw.UplinkRXInfo{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Time:      nowPB,
+		Rssi:      -60,
+		LoraSnr:   5.5,
+		Board:     1,
+		Antenna:   2,
+		Context:   []byte{1, 2, 3, 4},
+		Location: &common.Location{
+			Latitude:  1.123,
+			Longitude: 2.123,
+		},
+	}
+
+	txInfo := gw.UplinkTXInfo{
+		Frequency: 868100000,
+	}
+	assert.NoError(helpers.SetUplinkTXInfoDataRate(&txInfo, 3, band.Band()))
+
+	devAddr := lorawan.DevAddr{1, 2, 3, 4}
+	netID := lorawan.NetID{1, 2, 3}
+	ulMetaData, err := getULMetaData(netID, devAddr, models.RXPacket{
+		TXInfo:    &txInfo,
+		RXInfoSet: []*gw.UplinkRXInfo{&rxInfo},
+	})
+	assert.NoError(err)
+
+	assert.Equal(devAddr, *ulMetaData.DevAddr)
+	assert.Equal(3, *ulMetaData.DataRate)
+	assert.Equal(868.1, *ulMetaData.ULFreq)
+	assert.Equal(backend.EU868, ulMetaData.RFRegion)
+	assert.Equal(1, *ulMetaData.GWCnt)
+	assert.True(now.Equal(time.Time(ulMetaData.RecvTime)))
+	assert.Len(ulMetaData.GWInfo, 1)
+	assert.Equal(backend.HEXBytes{1, 2, 3, 4, 5, 6, 7, 8}, ulMetaData.GWInfo[0].ID)
+	assert.Equal(-60, *ulMetaData.GWInfo[0].RSSI)
+	assert.Equal(5.5, *ulMetaData.GWInfo[0].SNR)
+	assert.Equal(1.123, *ulMetaData.GWInfo[0].Lat)
+	assert.Equal(2.123, *ulMetaData.GWInfo[0].Lon)
+
+	dlFreq1 := 868.1
+	dlFreq2 := 869.525
+	dr1 := 3
+	dr2 := 0
+	rxDelay1 := 1
+	classC := "C"
+
+	tamperedGWInfo := []backend.GWInfoElement{ulMetaData.GWInfo[0]}
+	tamperedGWInfo[0].ULToken = append(backend.HEXBytes{}, ulMetaData.GWInfo[0].ULToken...)
+	tamperedGWInfo[0].ULToken[len(tamperedGWInfo[0].ULToken)-1]++
+
+	tests := []struct {
+		Name          string
+		SenderID      lorawan.NetID
+		DLMetaData    backend.DLMetaData
+		Frequency     uint32
+		DR            int
+		Timing        gw.DownlinkTiming
+		Delay         time.Duration
+		ExpectedError bool
+	}{
+		{
+			Name:     "rx1",
+			SenderID: netID,
+			DLMetaData: backend.DLMetaData{
+				DLFreq1:   &dlFreq1,
+				DataRate1: &dr1,
+				DLFreq2:   &dlFreq2,
+				DataRate2: &dr2,
+				RXDelay1:  &rxDelay1,
+				GWInfo:    ulMetaData.GWInfo,
+			},
+			Frequency: 868100000,
+			DR:        3,
+			Timing:    gw.DownlinkTiming_DELAY,
+			Delay:     time.Second,
+		},
+		{
+			Name:     "rx2",
+			SenderID: netID,
+			DLMetaData: backend.DLMetaData{
+				DLFreq2:   &dlFreq2,
+				DataRate2: &dr2,
+				RXDelay1:  &rxDelay1,
+				GWInfo:    ulMetaData.GWInfo,
+			},
+			Frequency: 869525000,
+			DR:        0,
+			Timing:    gw.DownlinkTiming_DELAY,
+			Delay:     2 * time.Second,
+		},
+		{
+			Name:     "class-c",
+			SenderID: netID,
+			DLMetaData: backend.DLMetaData{
+				DLFreq2:   &dlFreq2,
+				DataRate2: &dr2,
+				ClassMode: &classC,
+				GWInfo:    ulMetaData.GWInfo,
+			},
+			Frequency: 869525000,
+			DR:        0,
+			Timing:    gw.DownlinkTiming_IMMEDIATELY,
+		},
+		{
+			Name:     "no ULToken",
+			SenderID: netID,
+			DLMetaData: backend.DLMetaData{
+				DLFreq1:   &dlFreq1,
+				DataRate1: &dr1,
+			},
+			ExpectedError: true,
+		},
+		{
+			Name:     "no frequency",
+			SenderID: netID,
+			DLMetaData: backend.DLMetaData{
+				GWInfo: ulMetaData.GWInfo,
+			},
+			ExpectedError: true,
+		},
+		{
+			Name:     "ULToken issued to other NetID",
+			SenderID: lorawan.NetID{3, 2, 1},
+			DLMetaData: backend.DLMetaData{
+				DLFreq1:   &dlFreq1,
+				DataRate1: &dr1,
+				GWInfo:    ulMetaData.GWInfo,
+			},
+			ExpectedError: true,
+		},
+		{
+			Name:     "tampered ULToken",
+			SenderID: netID,
+			DLMetaData: backend.DLMetaData{
+				DLFreq1:   &dlFreq1,
+				DataRate1: &dr1,
+				GWInfo:    tamperedGWInfo,
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			frame, err := getDownlinkFrame(tst.SenderID, []byte{1, 2, 3}, tst.DLMetaData)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			assert.Equal([]byte{1, 2, 3}, frame.PhyPayload)
+			assert.Equal(rxInfo.GatewayId, frame.TxInfo.GatewayId)
+			assert.Equal(rxInfo.Board, frame.TxInfo.Board)
+			assert.Equal(rxInfo.Antenna, frame.TxInfo.Antenna)
+			assert.Equal(rxInfo.Context, frame.TxInfo.Context)
+			assert.Equal(tst.Frequency, frame.TxInfo.Frequency)
+			assert.Equal(tst.Timing, frame.TxInfo.Timing)
+			assert.EqualValues(band.Band().GetDownlinkTXPower(int(tst.Frequency)), frame.TxInfo.Power)
+
+			dr, err := helpers.GetDataRateIndex(false, frame.TxInfo, band.Band())
+			assert.NoError(err)
+			assert.Equal(tst.DR, dr)
+
+			if tst.Timing == gw.DownlinkTiming_DELAY {
+				delay, err := ptypes.Duration(frame.TxInfo.GetDelayTimingInfo().Delay)
+				assert.NoError(err)
+				assert.Equal(tst.Delay, delay)
+			}
+		})
+	}
+}
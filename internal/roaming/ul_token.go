@@ -0,0 +1,68 @@
+package roaming
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+// ErrInvalidULToken is returned when the ULToken signature is invalid.
+var ErrInvalidULToken = errors.New("invalid ULToken")
+
+// getULToken returns the signed ULToken for the given rx-info. The token
+// contains the HMAC-SHA256 (bound to the NetID of the roaming partner to
+// which the uplink is forwarded) followed by the protobuf encoded rx-info.
+func getULToken(nID lorawan.NetID, rxInfo *gw.UplinkRXInfo) ([]byte, error) {
+	b, err := proto.Marshal(rxInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal rx-info error")
+	}
+
+	return append(getULTokenMAC(nID, b), b...), nil
+}
+
+// getRXInfoFromULToken validates the signature of the given ULToken and
+// returns the rx-info. It returns ErrInvalidULToken when the token was not
+// issued by this network-server to the given NetID.
+func getRXInfoFromULToken(nID lorawan.NetID, token []byte) (gw.UplinkRXInfo, error) {
+	var rxInfo gw.UplinkRXInfo
+
+	if len(token) < sha256.Size {
+		return rxInfo, ErrInvalidULToken
+	}
+
+	mac, b := token[:sha256.Size], token[sha256.Size:]
+	if !hmac.Equal(mac, getULTokenMAC(nID, b)) {
+		return rxInfo, ErrInvalidULToken
+	}
+
+	if err := proto.Unmarshal(b, &rxInfo); err != nil {
+		return rxInfo, errors.Wrap(err, "unmarshal rx-info error")
+	}
+
+	return rxInfo, nil
+}
+
+func getULTokenMAC(nID lorawan.NetID, b []byte) []byte {
+	mux.RLock()
+	h := hmac.New(sha256.New, ulTokenKey)
+	mux.RUnlock()
+
+	h.Write(nID[:])
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+func getRandomULTokenKey() ([]byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Wrap(err, "read random bytes error")
+	}
+	return b, nil
+}
@@ -0,0 +1,206 @@
+package roaming
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/helpers"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// HandleUplink forwards the given uplink (of which the DevAddr does not
+// match the NetID of this network-server) to the roaming partner of which
+// the NetID matches the DevAddr. When there is no passive-roaming session
+// for the DevAddr, a PRStartReq is sent, else the uplink is forwarded using
+// a XmitDataReq. Uplinks for which there is no roaming agreement are dropped.
+func HandleUplink(devAddr lorawan.DevAddr, rxPacket models.RXPacket) error {
+	a, err := GetAgreementForDevAddr(devAddr)
+	if err != nil {
+		if err == ErrNoAgreement {
+			uplinkDroppedCounter().Inc()
+			log.WithField("dev_addr", devAddr).Info("roaming: no roaming agreement for DevAddr, dropping uplink")
+			return nil
+		}
+		return err
+	}
+
+	phyB, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	ulMetaData, err := getULMetaData(a.NetID, devAddr, rxPacket)
+	if err != nil {
+		return errors.Wrap(err, "get uplink meta-data error")
+	}
+
+	_, err = storage.GetPassiveRoamingSession(storage.RedisPool(), devAddr)
+	switch err {
+	case nil:
+		err = xmitDataReq(a, devAddr, phyB, ulMetaData)
+	case storage.ErrDoesNotExist:
+		err = prStartReq(a, devAddr, phyB, ulMetaData)
+	default:
+		return errors.Wrap(err, "get passive-roaming session error")
+	}
+	if err != nil {
+		uplinkForwardErrorCounter(a.NetID).Inc()
+		return err
+	}
+
+	return nil
+}
+
+func prStartReq(a Agreement, devAddr lorawan.DevAddr, phyB []byte, ulMetaData backend.ULMetaData) error {
+	transactionID, err := getTransactionID()
+	if err != nil {
+		return err
+	}
+
+	req := backend.PRStartReqPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        getNetID().String(),
+			ReceiverID:      a.NetID.String(),
+			TransactionID:   transactionID,
+			MessageType:     backend.PRStartReq,
+		},
+		PHYPayload: backend.HEXBytes(phyB),
+		ULMetaData: ulMetaData,
+	}
+
+	ans, err := a.Client.PRStartReq(req)
+	if err != nil {
+		return errors.Wrap(err, "PRStartReq error")
+	}
+	uplinkForwardCounter(a.NetID, string(backend.PRStartReq)).Inc()
+
+	log.WithFields(log.Fields{
+		"dev_addr":       devAddr,
+		"net_id":         a.NetID,
+		"transaction_id": transactionID,
+	}).Info("roaming: uplink forwarded to roaming partner using PRStartReq")
+
+	// a lifetime of 0 means stateless passive-roaming, in which case every
+	// uplink is forwarded using a PRStartReq
+	lifetime := a.PassiveRoamingLifetime
+	if ans.Lifetime == nil || *ans.Lifetime <= 0 {
+		lifetime = 0
+	} else if l := time.Duration(*ans.Lifetime) * time.Second; l < lifetime {
+		lifetime = l
+	}
+
+	if lifetime == 0 {
+		return nil
+	}
+
+	if err := storage.SavePassiveRoamingSession(storage.RedisPool(), devAddr, a.NetID, lifetime); err != nil {
+		return errors.Wrap(err, "save passive-roaming session error")
+	}
+
+	return nil
+}
+
+func xmitDataReq(a Agreement, devAddr lorawan.DevAddr, phyB []byte, ulMetaData backend.ULMetaData) error {
+	transactionID, err := getTransactionID()
+	if err != nil {
+		return err
+	}
+
+	req := backend.XmitDataReqPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        getNetID().String(),
+			ReceiverID:      a.NetID.String(),
+			TransactionID:   transactionID,
+			MessageType:     backend.XmitDataReq,
+		},
+		PHYPayload: backend.HEXBytes(phyB),
+		ULMetaData: &ulMetaData,
+	}
+
+	if _, err := a.Client.XmitDataReq(req); err != nil {
+		// the roaming partner might have ended the passive-roaming session,
+		// the next uplink will start a new session
+		if err := storage.DeletePassiveRoamingSession(storage.RedisPool(), devAddr); err != nil {
+			log.WithError(err).WithField("dev_addr", devAddr).Error("roaming: delete passive-roaming session error")
+		}
+		return errors.Wrap(err, "XmitDataReq error")
+	}
+	uplinkForwardCounter(a.NetID, string(backend.XmitDataReq)).Inc()
+
+	log.WithFields(log.Fields{
+		"dev_addr":       devAddr,
+		"net_id":         a.NetID,
+		"transaction_id": transactionID,
+	}).Info("roaming: uplink forwarded to roaming partner using XmitDataReq")
+
+	return nil
+}
+
+// getULMetaData returns the uplink meta-data for the given uplink. The
+// ULToken of each gateway contains the (signed) rx-info, which is used to
+// schedule the downlink returned by the roaming partner with the given NetID.
+func getULMetaData(nID lorawan.NetID, devAddr lorawan.DevAddr, rxPacket models.RXPacket) (backend.ULMetaData, error) {
+	dr, err := helpers.GetDataRateIndex(true, rxPacket.TXInfo, band.Band())
+	if err != nil {
+		return backend.ULMetaData{}, errors.Wrap(err, "get data-rate index error")
+	}
+
+	freq := float64(rxPacket.TXInfo.Frequency) / 1000000
+	gwCnt := len(rxPacket.RXInfoSet)
+	recvTime := time.Now()
+
+	out := backend.ULMetaData{
+		DevAddr:  &devAddr,
+		DataRate: &dr,
+		ULFreq:   &freq,
+		RFRegion: getRFRegion(),
+		GWCnt:    &gwCnt,
+	}
+
+	for i, rxInfo := range rxPacket.RXInfoSet {
+		if i == 0 && rxInfo.Time != nil {
+			if t, err := ptypes.Timestamp(rxInfo.Time); err == nil {
+				recvTime = t
+			}
+		}
+
+		ulToken, err := getULToken(nID, rxInfo)
+		if err != nil {
+			return out, errors.Wrap(err, "get ULToken error")
+		}
+
+		rssi := int(rxInfo.Rssi)
+		snr := rxInfo.LoraSnr
+
+		gwInfo := backend.GWInfoElement{
+			ID:        backend.HEXBytes(rxInfo.GatewayId),
+			RFRegion:  out.RFRegion,
+			RSSI:      &rssi,
+			SNR:       &snr,
+			ULToken:   backend.HEXBytes(ulToken),
+			DLAllowed: true,
+		}
+
+		if loc := rxInfo.Location; loc != nil {
+			lat := loc.Latitude
+			lon := loc.Longitude
+			gwInfo.Lat = &lat
+			gwInfo.Lon = &lon
+		}
+
+		out.GWInfo = append(out.GWInfo, gwInfo)
+	}
+
+	out.RecvTime = backend.ISO8601Time(recvTime)
+
+	return out, nil
+}
@@ -106,6 +106,8 @@ func (q *TxLogger) Exec(query string, args ...interface{}) (sql.Result, error) {
 }
 
 func logQuery(query string, duration time.Duration, args ...interface{}) {
+	postgreSQLQueryDuration().Observe(duration.Seconds())
+
 	log.WithFields(log.Fields{
 		"query":    query,
 		"args":     args,
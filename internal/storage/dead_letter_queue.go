@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/as"
+)
+
+const (
+	deadLetterQueueKeyTempl           = "lora:ns:rp:%s:dlq"            // contains the failed uplink data forwards of a routing-profile
+	deadLetterQueueProcessingKeyTempl = "lora:ns:rp:%s:dlq:processing" // contains the item of the dead-letter queue being forwarded
+	deadLetterRoutingProfiles         = "lora:ns:dlq:rp"               // contains the set of routing-profile IDs with a dead-letter queue
+	deadLetterQueueLockTempl          = "lora:ns:rp:%s:dlq:lock"
+)
+
+// PushDeadLetterUplinkData adds the given uplink data to the dead-letter
+// queue of the routing-profile. When the queue exceeds the given max.
+// length, the oldest items are removed.
+func PushDeadLetterUplinkData(p *redis.Pool, rpID uuid.UUID, req as.HandleUplinkDataRequest, maxLength int) error {
+	b, err := proto.Marshal(&req)
+	if err != nil {
+		return errors.Wrap(err, "protobuf marshal error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deadLetterQueueKeyTempl, rpID)
+
+	c.Send("MULTI")
+	c.Send("RPUSH", key, b)
+	c.Send("LTRIM", key, -maxLength, -1)
+	c.Send("SADD", deadLetterRoutingProfiles, rpID.String())
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "redis exec error")
+	}
+
+	return nil
+}
+
+// popDeadLetterUplinkDataScript atomically moves the oldest item of the
+// dead-letter queue to the processing list and returns it. When the
+// processing list still contains an item (e.g. the previous forward
+// failed), this item is returned instead.
+var popDeadLetterUplinkDataScript = redis.NewScript(2, `
+	local item = redis.call('LINDEX', KEYS[2], 0)
+	if item then
+		return item
+	end
+
+	item = redis.call('LPOP', KEYS[1])
+	if item then
+		redis.call('RPUSH', KEYS[2], item)
+	end
+	return item
+`)
+
+// PopDeadLetterUplinkData moves the oldest item of the dead-letter queue of
+// the routing-profile to the processing list and returns it. The item must
+// be removed using AckDeadLetterUplinkData once it has been handled, until
+// then it is returned on every call. It returns ErrDoesNotExist when the
+// queue is empty.
+func PopDeadLetterUplinkData(p *redis.Pool, rpID uuid.UUID) (as.HandleUplinkDataRequest, error) {
+	var req as.HandleUplinkDataRequest
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(popDeadLetterUplinkDataScript.Do(c,
+		fmt.Sprintf(deadLetterQueueKeyTempl, rpID),
+		fmt.Sprintf(deadLetterQueueProcessingKeyTempl, rpID),
+	))
+	if err != nil {
+		if err == redis.ErrNil {
+			return req, ErrDoesNotExist
+		}
+		return req, errors.Wrap(err, "pop dead-letter item error")
+	}
+
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return req, errors.Wrap(err, "protobuf unmarshal error")
+	}
+
+	return req, nil
+}
+
+// AckDeadLetterUplinkData removes the item returned by
+// PopDeadLetterUplinkData from the processing list of the routing-profile.
+func AckDeadLetterUplinkData(p *redis.Pool, rpID uuid.UUID) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(deadLetterQueueProcessingKeyTempl, rpID)); err != nil {
+		return errors.Wrap(err, "ack dead-letter item error")
+	}
+
+	return nil
+}
+
+// GetDeadLetterQueueLength returns the number of items in the dead-letter
+// queue of the routing-profile, including the item being processed.
+func GetDeadLetterQueueLength(p *redis.Pool, rpID uuid.UUID) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LLEN", fmt.Sprintf(deadLetterQueueKeyTempl, rpID))
+	c.Send("LLEN", fmt.Sprintf(deadLetterQueueProcessingKeyTempl, rpID))
+	vals, err := redis.Ints(c.Do("EXEC"))
+	if err != nil {
+		return 0, errors.Wrap(err, "get dead-letter queue length error")
+	}
+
+	return vals[0] + vals[1], nil
+}
+
+// GetDeadLetterRoutingProfileIDs returns the IDs of the routing-profiles
+// which have (had) items in their dead-letter queue.
+func GetDeadLetterRoutingProfileIDs(p *redis.Pool) ([]uuid.UUID, error) {
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.Strings(c.Do("SMEMBERS", deadLetterRoutingProfiles))
+	if err != nil {
+		return nil, errors.Wrap(err, "get dead-letter routing-profiles error")
+	}
+
+	var out []uuid.UUID
+	for _, v := range vals {
+		id, err := uuid.FromString(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse uuid error")
+		}
+		out = append(out, id)
+	}
+
+	return out, nil
+}
+
+// FlushDeadLetterQueue removes all items from the dead-letter queue of the
+// routing-profile.
+func FlushDeadLetterQueue(p *redis.Pool, rpID uuid.UUID) error {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("DEL", fmt.Sprintf(deadLetterQueueKeyTempl, rpID), fmt.Sprintf(deadLetterQueueProcessingKeyTempl, rpID))
+	c.Send("SREM", deadLetterRoutingProfiles, rpID.String())
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "redis exec error")
+	}
+
+	return nil
+}
+
+// refreshDeadLetterQueueLockScript extends the TTL of the lock, only when it
+// is still held by the given lock ID. It returns 1 on success and 0 when the
+// lock is not held by the given lock ID.
+var refreshDeadLetterQueueLockScript = redis.NewScript(1, `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+// releaseDeadLetterQueueLockScript removes the lock, only when it is still
+// held by the given lock ID.
+var releaseDeadLetterQueueLockScript = redis.NewScript(1, `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`)
+
+// AcquireDeadLetterQueueLock acquires the lock for draining the dead-letter
+// queue of the routing-profile, using the given lock ID. It returns false
+// when the lock is already held, e.g. by an other instance.
+func AcquireDeadLetterQueueLock(p *redis.Pool, rpID, lockID uuid.UUID, ttl time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deadLetterQueueLockTempl, rpID)
+	_, err := redis.String(c.Do("SET", key, lockID.String(), "PX", int64(ttl/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "acquire dead-letter queue lock error")
+	}
+
+	return true, nil
+}
+
+// RefreshDeadLetterQueueLock resets the TTL of the dead-letter queue lock
+// held by the given lock ID. It returns false when the lock is no longer
+// held by the given lock ID, e.g. because it expired.
+func RefreshDeadLetterQueueLock(p *redis.Pool, rpID, lockID uuid.UUID, ttl time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ok, err := redis.Bool(refreshDeadLetterQueueLockScript.Do(c,
+		fmt.Sprintf(deadLetterQueueLockTempl, rpID),
+		lockID.String(),
+		int64(ttl/time.Millisecond),
+	))
+	if err != nil {
+		return false, errors.Wrap(err, "refresh dead-letter queue lock error")
+	}
+
+	return ok, nil
+}
+
+// ReleaseDeadLetterQueueLock releases the dead-letter queue lock when it is
+// held by the given lock ID.
+func ReleaseDeadLetterQueueLock(p *redis.Pool, rpID, lockID uuid.UUID) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := releaseDeadLetterQueueLockScript.Do(c,
+		fmt.Sprintf(deadLetterQueueLockTempl, rpID),
+		lockID.String(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "release dead-letter queue lock error")
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/as"
+)
+
+func (ts *StorageTestSuite) TestDeadLetterQueue() {
+	assert := require.New(ts.T())
+	rpID, err := uuid.NewV4()
+	assert.NoError(err)
+
+	ts.T().Run("Empty", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := PopDeadLetterUplinkData(ts.RedisPool(), rpID)
+		assert.Equal(ErrDoesNotExist, err)
+
+		l, err := GetDeadLetterQueueLength(ts.RedisPool(), rpID)
+		assert.NoError(err)
+		assert.Equal(0, l)
+	})
+
+	ts.T().Run("Push exceeding max length", func(t *testing.T) {
+		assert := require.New(t)
+
+		for i := 1; i <= 3; i++ {
+			assert.NoError(PushDeadLetterUplinkData(ts.RedisPool(), rpID, as.HandleUplinkDataRequest{FCnt: uint32(i)}, 2))
+		}
+
+		l, err := GetDeadLetterQueueLength(ts.RedisPool(), rpID)
+		assert.NoError(err)
+		assert.Equal(2, l)
+
+		ids, err := GetDeadLetterRoutingProfileIDs(ts.RedisPool())
+		assert.NoError(err)
+		assert.Equal([]uuid.UUID{rpID}, ids)
+
+		t.Run("Pop and ack returns the oldest item", func(t *testing.T) {
+			assert := require.New(t)
+
+			req, err := PopDeadLetterUplinkData(ts.RedisPool(), rpID)
+			assert.NoError(err)
+			assert.EqualValues(2, req.FCnt)
+
+			// the item is counted until acked
+			l, err := GetDeadLetterQueueLength(ts.RedisPool(), rpID)
+			assert.NoError(err)
+			assert.Equal(2, l)
+
+			// items pushed while processing do not affect the popped item
+			assert.NoError(PushDeadLetterUplinkData(ts.RedisPool(), rpID, as.HandleUplinkDataRequest{FCnt: 4}, 1))
+
+			// the item is returned again until acked
+			req, err = PopDeadLetterUplinkData(ts.RedisPool(), rpID)
+			assert.NoError(err)
+			assert.EqualValues(2, req.FCnt)
+
+			assert.NoError(AckDeadLetterUplinkData(ts.RedisPool(), rpID))
+
+			req, err = PopDeadLetterUplinkData(ts.RedisPool(), rpID)
+			assert.NoError(err)
+			assert.EqualValues(4, req.FCnt)
+		})
+
+		t.Run("Flush", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(FlushDeadLetterQueue(ts.RedisPool(), rpID))
+
+			l, err := GetDeadLetterQueueLength(ts.RedisPool(), rpID)
+			assert.NoError(err)
+			assert.Equal(0, l)
+
+			ids, err := GetDeadLetterRoutingProfileIDs(ts.RedisPool())
+			assert.NoError(err)
+			assert.Len(ids, 0)
+		})
+	})
+
+	ts.T().Run("Lock", func(t *testing.T) {
+		assert := require.New(t)
+
+		lockID := uuid.Must(uuid.NewV4())
+		otherLockID := uuid.Must(uuid.NewV4())
+
+		locked, err := AcquireDeadLetterQueueLock(ts.RedisPool(), rpID, lockID, time.Minute)
+		assert.NoError(err)
+		assert.True(locked)
+
+		locked, err = AcquireDeadLetterQueueLock(ts.RedisPool(), rpID, otherLockID, time.Minute)
+		assert.NoError(err)
+		assert.False(locked)
+
+		t.Run("Refresh", func(t *testing.T) {
+			assert := require.New(t)
+
+			ok, err := RefreshDeadLetterQueueLock(ts.RedisPool(), rpID, lockID, time.Minute)
+			assert.NoError(err)
+			assert.True(ok)
+
+			ok, err = RefreshDeadLetterQueueLock(ts.RedisPool(), rpID, otherLockID, time.Minute)
+			assert.NoError(err)
+			assert.False(ok)
+		})
+
+		t.Run("Release", func(t *testing.T) {
+			assert := require.New(t)
+
+			// the lock is not released by an other lock ID
+			assert.NoError(ReleaseDeadLetterQueueLock(ts.RedisPool(), rpID, otherLockID))
+			locked, err := AcquireDeadLetterQueueLock(ts.RedisPool(), rpID, otherLockID, time.Minute)
+			assert.NoError(err)
+			assert.False(locked)
+
+			assert.NoError(ReleaseDeadLetterQueueLock(ts.RedisPool(), rpID, lockID))
+			locked, err = AcquireDeadLetterQueueLock(ts.RedisPool(), rpID, otherLockID, time.Minute)
+			assert.NoError(err)
+			assert.True(locked)
+
+			ok, err := RefreshDeadLetterQueueLock(ts.RedisPool(), rpID, lockID, time.Minute)
+			assert.NoError(err)
+			assert.False(ok)
+		})
+	})
+}
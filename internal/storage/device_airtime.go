@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const deviceAirtimeKeyTempl = "lora:ns:device:%s:airtime:%s"
+
+const (
+	// deviceAirtimeWindow defines the rolling window over which the airtime
+	// of a device is aggregated.
+	deviceAirtimeWindow = 24 * time.Hour
+
+	// deviceAirtimeBucketSize defines the size of a single airtime bucket.
+	// The rolling window moves with this granularity.
+	deviceAirtimeBucketSize = 15 * time.Minute
+)
+
+// AirtimeDirection defines the direction of the airtime.
+type AirtimeDirection string
+
+// Available airtime directions.
+const (
+	UplinkAirtime   AirtimeDirection = "ul"
+	DownlinkAirtime AirtimeDirection = "dl"
+)
+
+// AddDeviceAirtime adds the given airtime to the airtime bucket of the device
+// for the given timestamp. Buckets are stored in a hash with the bucket start
+// (unix timestamp) as field and the airtime in microseconds as value.
+func AddDeviceAirtime(p *redis.Pool, devEUI lorawan.EUI64, dir AirtimeDirection, ts time.Time, d time.Duration) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deviceAirtimeKeyTempl, devEUI, dir)
+	bucket := ts.Truncate(deviceAirtimeBucketSize).Unix()
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", key, bucket, int64(d/time.Microsecond))
+	c.Send("PEXPIRE", key, int64((deviceAirtimeWindow+deviceAirtimeBucketSize)/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "add device airtime error")
+	}
+
+	return nil
+}
+
+// GetDeviceAirtime returns the aggregated airtime of the device within the
+// rolling window ending at the given timestamp. Expired buckets are removed.
+func GetDeviceAirtime(p *redis.Pool, devEUI lorawan.EUI64, dir AirtimeDirection, ts time.Time) (time.Duration, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deviceAirtimeKeyTempl, devEUI, dir)
+
+	buckets, err := redis.Int64Map(c.Do("HGETALL", key))
+	if err != nil {
+		return 0, errors.Wrap(err, "get device airtime error")
+	}
+
+	start := ts.Add(-deviceAirtimeWindow).Truncate(deviceAirtimeBucketSize).Unix()
+
+	var out time.Duration
+	var expired []interface{}
+	for k, v := range buckets {
+		bucket, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "parse bucket error")
+		}
+
+		if bucket <= start {
+			expired = append(expired, k)
+			continue
+		}
+
+		out += time.Duration(v) * time.Microsecond
+	}
+
+	if len(expired) != 0 {
+		if _, err := c.Do("HDEL", append([]interface{}{key}, expired...)...); err != nil {
+			return 0, errors.Wrap(err, "delete expired device airtime buckets error")
+		}
+	}
+
+	return out, nil
+}
+
+// DeviceAirtimeBudgetExceeded returns true when the aggregated airtime of
+// the device within the rolling window plus the airtime of the frame being
+// checked exceeds the given budget. A budget of 0 means unlimited.
+func DeviceAirtimeBudgetExceeded(p *redis.Pool, devEUI lorawan.EUI64, dir AirtimeDirection, frame, budget time.Duration) (bool, error) {
+	if budget == 0 {
+		return false, nil
+	}
+
+	d, err := GetDeviceAirtime(p, devEUI, dir, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return d+frame > budget, nil
+}
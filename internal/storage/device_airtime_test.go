@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceAirtime() {
+	assert := require.New(ts.T())
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	now := time.Now()
+
+	assert.NoError(AddDeviceAirtime(ts.RedisPool(), devEUI, UplinkAirtime, now, 100*time.Millisecond))
+	assert.NoError(AddDeviceAirtime(ts.RedisPool(), devEUI, UplinkAirtime, now.Add(-time.Hour), 50*time.Millisecond))
+	assert.NoError(AddDeviceAirtime(ts.RedisPool(), devEUI, UplinkAirtime, now.Add(-25*time.Hour), 1000*time.Millisecond))
+	assert.NoError(AddDeviceAirtime(ts.RedisPool(), devEUI, DownlinkAirtime, now, 20*time.Millisecond))
+
+	d, err := GetDeviceAirtime(ts.RedisPool(), devEUI, UplinkAirtime, now)
+	assert.NoError(err)
+	assert.Equal(150*time.Millisecond, d)
+
+	d, err = GetDeviceAirtime(ts.RedisPool(), devEUI, DownlinkAirtime, now)
+	assert.NoError(err)
+	assert.Equal(20*time.Millisecond, d)
+
+	exceeded, err := DeviceAirtimeBudgetExceeded(ts.RedisPool(), devEUI, UplinkAirtime, time.Second, 0)
+	assert.NoError(err)
+	assert.False(exceeded)
+
+	exceeded, err = DeviceAirtimeBudgetExceeded(ts.RedisPool(), devEUI, UplinkAirtime, 50*time.Millisecond, 200*time.Millisecond)
+	assert.NoError(err)
+	assert.False(exceeded)
+
+	exceeded, err = DeviceAirtimeBudgetExceeded(ts.RedisPool(), devEUI, UplinkAirtime, 0, 150*time.Millisecond)
+	assert.NoError(err)
+	assert.False(exceeded)
+
+	// the airtime of the frame itself does not fit within the budget
+	exceeded, err = DeviceAirtimeBudgetExceeded(ts.RedisPool(), devEUI, UplinkAirtime, 51*time.Millisecond, 200*time.Millisecond)
+	assert.NoError(err)
+	assert.True(exceeded)
+}
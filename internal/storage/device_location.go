@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+const deviceLocationKeyTempl = "lora:ns:device:%s:location" // contains the last resolved location of a DevEUI
+
+// DeviceLocation holds the last resolved location of a device.
+type DeviceLocation struct {
+	DevEUI     lorawan.EUI64
+	Latitude   float64
+	Longitude  float64
+	Altitude   float64
+	Accuracy   uint32
+	ResolvedAt time.Time
+}
+
+// SaveDeviceLocation saves the given device location. It is stored
+// separately from the device-session as it is updated asynchronously from
+// the uplink handling. Like the device-session, it expires when the device
+// is not seen for the device-session TTL.
+func SaveDeviceLocation(p *redis.Pool, loc DeviceLocation) error {
+	b, err := proto.Marshal(&DeviceLocationPB{
+		DevEui:           loc.DevEUI[:],
+		Latitude:         loc.Latitude,
+		Longitude:        loc.Longitude,
+		Altitude:         loc.Altitude,
+		Accuracy:         loc.Accuracy,
+		ResolvedAtUnixNs: loc.ResolvedAt.UnixNano(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "protobuf encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+	exp := int64(deviceSessionTTL / time.Millisecond)
+	_, err = c.Do("PSETEX", fmt.Sprintf(deviceLocationKeyTempl, loc.DevEUI), exp, b)
+	if err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": loc.DevEUI,
+	}).Info("device location saved")
+
+	return nil
+}
+
+// GetDeviceLocation returns the last resolved location of the given device.
+func GetDeviceLocation(p *redis.Pool, devEUI lorawan.EUI64) (DeviceLocation, error) {
+	var loc DeviceLocation
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(deviceLocationKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return loc, ErrDoesNotExist
+		}
+		return loc, errors.Wrap(err, "get error")
+	}
+
+	var locPB DeviceLocationPB
+	if err := proto.Unmarshal(val, &locPB); err != nil {
+		return loc, errors.Wrap(err, "protobuf unmarshal error")
+	}
+
+	copy(loc.DevEUI[:], locPB.DevEui)
+	loc.Latitude = locPB.Latitude
+	loc.Longitude = locPB.Longitude
+	loc.Altitude = locPB.Altitude
+	loc.Accuracy = locPB.Accuracy
+	loc.ResolvedAt = time.Unix(0, locPB.ResolvedAtUnixNs).UTC()
+
+	return loc, nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceLocation() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetDeviceLocation(ts.RedisPool(), devEUI)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		loc := DeviceLocation{
+			DevEUI:     devEUI,
+			Latitude:   1.123,
+			Longitude:  2.123,
+			Altitude:   3.123,
+			Accuracy:   10,
+			ResolvedAt: time.Now().Round(time.Second).UTC(),
+		}
+		assert.NoError(SaveDeviceLocation(ts.RedisPool(), loc))
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+
+			locGet, err := GetDeviceLocation(ts.RedisPool(), devEUI)
+			assert.NoError(err)
+			assert.Equal(loc, locGet)
+		})
+	})
+}
@@ -19,32 +19,47 @@ const (
 	DeviceProfileKeyTempl = "lora:ns:dp:%s"
 )
 
+// RXWindowPreference defines the RX window preference for downlink
+// transmissions.
+type RXWindowPreference int
+
+// Available RX window preferences.
+const (
+	RXWindowPreferenceAuto RXWindowPreference = iota // use the network-server rx_window setting
+	RXWindowPreferenceRX1
+	RXWindowPreferenceRX2
+)
+
 // DeviceProfile defines the backend.DeviceProfile with some extra meta-data
 type DeviceProfile struct {
-	CreatedAt           time.Time `db:"created_at"`
-	UpdatedAt           time.Time `db:"updated_at"`
-	ID                  uuid.UUID `db:"device_profile_id"`
-	SupportsClassB      bool      `db:"supports_class_b"`
-	ClassBTimeout       int       `db:"class_b_timeout"` // Unit: seconds
-	PingSlotPeriod      int       `db:"ping_slot_period"`
-	PingSlotDR          int       `db:"ping_slot_dr"`
-	PingSlotFreq        int       `db:"ping_slot_freq"` // in Hz
-	SupportsClassC      bool      `db:"supports_class_c"`
-	ClassCTimeout       int       `db:"class_c_timeout"`     // Unit: seconds
-	MACVersion          string    `db:"mac_version"`         // Example: "1.0.2" [LW102]
-	RegParamsRevision   string    `db:"reg_params_revision"` // Example: "B" [RP102B]
-	RXDelay1            int       `db:"rx_delay_1"`
-	RXDROffset1         int       `db:"rx_dr_offset_1"`
-	RXDataRate2         int       `db:"rx_data_rate_2"`       // Unit: bits-per-second
-	RXFreq2             int       `db:"rx_freq_2"`            // In Hz
-	FactoryPresetFreqs  []int     `db:"factory_preset_freqs"` // In Hz
-	MaxEIRP             int       `db:"max_eirp"`             // In dBm
-	MaxDutyCycle        int       `db:"max_duty_cycle"`       // Example: 10 indicates 10%
-	SupportsJoin        bool      `db:"supports_join"`
-	RFRegion            string    `db:"rf_region"`
-	Supports32bitFCnt   bool      `db:"supports_32bit_fcnt"`
-	GeolocBufferTTL     int       `db:"geoloc_buffer_ttl"`
-	GeolocMinBufferSize int       `db:"geoloc_min_buffer_size"`
+	CreatedAt           time.Time          `db:"created_at"`
+	UpdatedAt           time.Time          `db:"updated_at"`
+	ID                  uuid.UUID          `db:"device_profile_id"`
+	SupportsClassB      bool               `db:"supports_class_b"`
+	ClassBTimeout       int                `db:"class_b_timeout"` // Unit: seconds
+	PingSlotPeriod      int                `db:"ping_slot_period"`
+	PingSlotDR          int                `db:"ping_slot_dr"`
+	PingSlotFreq        int                `db:"ping_slot_freq"` // in Hz
+	SupportsClassC      bool               `db:"supports_class_c"`
+	ClassCTimeout       int                `db:"class_c_timeout"`     // Unit: seconds
+	MACVersion          string             `db:"mac_version"`         // Example: "1.0.2" [LW102]
+	RegParamsRevision   string             `db:"reg_params_revision"` // Example: "B" [RP102B]
+	RXDelay1            int                `db:"rx_delay_1"`
+	RXDROffset1         int                `db:"rx_dr_offset_1"`
+	RXDataRate2         int                `db:"rx_data_rate_2"`       // Unit: bits-per-second
+	RXFreq2             int                `db:"rx_freq_2"`            // In Hz
+	FactoryPresetFreqs  []int              `db:"factory_preset_freqs"` // In Hz
+	MaxEIRP             int                `db:"max_eirp"`             // In dBm
+	MaxDutyCycle        int                `db:"max_duty_cycle"`       // Example: 10 indicates 10%
+	SupportsJoin        bool               `db:"supports_join"`
+	RFRegion            string             `db:"rf_region"`
+	Supports32bitFCnt   bool               `db:"supports_32bit_fcnt"`
+	GeolocBufferTTL     int                `db:"geoloc_buffer_ttl"`
+	GeolocMinBufferSize int                `db:"geoloc_min_buffer_size"`
+	ADRAlgorithmID      string             `db:"adr_algorithm_id"`
+	RXWindowPreference  RXWindowPreference `db:"rx_window_preference"`
+	ABPFCntReset        bool               `db:"abp_fcnt_reset"`
+	MaxFCntGap          int                `db:"max_fcnt_gap"` // 0 = band default
 }
 
 // CreateDeviceProfile creates the given device-profile.
@@ -88,8 +103,12 @@ func CreateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
             rf_region,
             supports_32bit_fcnt,
 			geoloc_buffer_ttl,
-			geoloc_min_buffer_size
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)`,
+			geoloc_min_buffer_size,
+			adr_algorithm_id,
+			rx_window_preference,
+			abp_fcnt_reset,
+			max_fcnt_gap
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)`,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.ID,
@@ -114,6 +133,10 @@ func CreateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
 		dp.Supports32bitFCnt,
 		dp.GeolocBufferTTL,
 		dp.GeolocMinBufferSize,
+		dp.ADRAlgorithmID,
+		dp.RXWindowPreference,
+		dp.ABPFCntReset,
+		dp.MaxFCntGap,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -174,6 +197,8 @@ func GetDeviceProfileCache(p *redis.Pool, id uuid.UUID) (DeviceProfile, error) {
 
 // FlushDeviceProfileCache deletes a cached device-profile.
 func FlushDeviceProfileCache(p *redis.Pool, id uuid.UUID) error {
+	profiles.flush(fmt.Sprintf(deviceProfileCacheKeyTempl, id))
+
 	key := fmt.Sprintf(DeviceProfileKeyTempl, id)
 	c := p.Get()
 	defer c.Close()
@@ -189,8 +214,14 @@ func FlushDeviceProfileCache(p *redis.Pool, id uuid.UUID) error {
 // in case available, else it will be retrieved from the database and then
 // stored in cache.
 func GetAndCacheDeviceProfile(db sqlx.Queryer, p *redis.Pool, id uuid.UUID) (DeviceProfile, error) {
+	memKey := fmt.Sprintf(deviceProfileCacheKeyTempl, id)
+	if v, ok := profiles.get(memKey); ok {
+		return v.(DeviceProfile), nil
+	}
+
 	dp, err := GetDeviceProfileCache(p, id)
 	if err == nil {
+		profiles.set(memKey, dp)
 		return dp, nil
 	}
 
@@ -206,6 +237,8 @@ func GetAndCacheDeviceProfile(db sqlx.Queryer, p *redis.Pool, id uuid.UUID) (Dev
 		return DeviceProfile{}, errors.Wrap(err, "get device-profile error")
 	}
 
+	profiles.set(memKey, dp)
+
 	err = CreateDeviceProfileCache(p, dp)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -246,7 +279,11 @@ func GetDeviceProfile(db sqlx.Queryer, id uuid.UUID) (DeviceProfile, error) {
             rf_region,
             supports_32bit_fcnt,
 			geoloc_buffer_ttl,
-			geoloc_min_buffer_size
+			geoloc_min_buffer_size,
+			adr_algorithm_id,
+			rx_window_preference,
+			abp_fcnt_reset,
+			max_fcnt_gap
         from device_profile
         where
             device_profile_id = $1
@@ -279,6 +316,10 @@ func GetDeviceProfile(db sqlx.Queryer, id uuid.UUID) (DeviceProfile, error) {
 		&dp.Supports32bitFCnt,
 		&dp.GeolocBufferTTL,
 		&dp.GeolocMinBufferSize,
+		&dp.ADRAlgorithmID,
+		&dp.RXWindowPreference,
+		&dp.ABPFCntReset,
+		&dp.MaxFCntGap,
 	)
 	if err != nil {
 		return dp, handlePSQLError(err, "select error")
@@ -319,7 +360,11 @@ func UpdateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
             rf_region = $20,
             supports_32bit_fcnt = $21,
 			geoloc_buffer_ttl = $22,
-			geoloc_min_buffer_size = $23
+			geoloc_min_buffer_size = $23,
+			adr_algorithm_id = $24,
+			rx_window_preference = $25,
+			abp_fcnt_reset = $26,
+			max_fcnt_gap = $27
         where
             device_profile_id = $1`,
 		dp.ID,
@@ -345,6 +390,10 @@ func UpdateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
 		dp.Supports32bitFCnt,
 		dp.GeolocBufferTTL,
 		dp.GeolocMinBufferSize,
+		dp.ADRAlgorithmID,
+		dp.RXWindowPreference,
+		dp.ABPFCntReset,
+		dp.MaxFCntGap,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -43,6 +43,10 @@ func TestDeviceProfile(t *testing.T) {
 				Supports32bitFCnt:   true,
 				GeolocBufferTTL:     10,
 				GeolocMinBufferSize: 3,
+				ADRAlgorithmID:      "default",
+				RXWindowPreference:  RXWindowPreferenceRX2,
+				ABPFCntReset:        true,
+				MaxFCntGap:          1000,
 			}
 
 			So(CreateDeviceProfile(DB(), &dp), ShouldBeNil)
@@ -106,6 +110,10 @@ func TestDeviceProfile(t *testing.T) {
 				dp.Supports32bitFCnt = false
 				dp.GeolocBufferTTL = 20
 				dp.GeolocMinBufferSize = 4
+				dp.ADRAlgorithmID = "static"
+				dp.RXWindowPreference = RXWindowPreferenceAuto
+				dp.ABPFCntReset = false
+				dp.MaxFCntGap = 0
 
 				So(UpdateDeviceProfile(DB(), &dp), ShouldBeNil)
 				dp.UpdatedAt = dp.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -3,11 +3,14 @@ package storage
 import (
 	"context"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/internal/backend/applicationserver"
@@ -29,13 +32,28 @@ type DeviceQueueItem struct {
 	IsPending               bool            `db:"is_pending"`
 	EmitAtTimeSinceGPSEpoch *time.Duration  `db:"emit_at_time_since_gps_epoch"`
 	TimeoutAfter            *time.Time      `db:"timeout_after"`
+	RetryCount              int             `db:"retry_count"`
+	Reference               string          `db:"reference"`
+	ExpiresAt               *time.Time      `db:"expires_at"`
+	GatewayID               *lorawan.EUI64  `db:"gateway_id"`
 }
 
+// downlinkStatusTimeout defines the timeout for notifying the
+// application-server about the downlink status.
+const downlinkStatusTimeout = time.Second
+
+// maxDeviceQueueReferenceLength defines the max. length (in characters)
+// of the device-queue item reference.
+const maxDeviceQueueReferenceLength = 100
+
 // Validate validates the DeviceQueueItem.
 func (d DeviceQueueItem) Validate() error {
 	if d.FPort == 0 {
 		return ErrInvalidFPort
 	}
+	if utf8.RuneCountInString(d.Reference) > maxDeviceQueueReferenceLength {
+		return ErrDeviceQueueReferenceTooLong
+	}
 	return nil
 }
 
@@ -49,6 +67,11 @@ func CreateDeviceQueueItem(db sqlx.Queryer, qi *DeviceQueueItem) error {
 	qi.CreatedAt = now
 	qi.UpdatedAt = now
 
+	if qi.ExpiresAt == nil && deviceQueueItemTTL != 0 {
+		expiresAt := now.Add(deviceQueueItemTTL)
+		qi.ExpiresAt = &expiresAt
+	}
+
 	err := sqlx.Get(db, &qi.ID, `
         insert into device_queue (
             created_at,
@@ -61,8 +84,12 @@ func CreateDeviceQueueItem(db sqlx.Queryer, qi *DeviceQueueItem) error {
             confirmed,
             emit_at_time_since_gps_epoch,
             is_pending,
-            timeout_after
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+            timeout_after,
+            retry_count,
+            reference,
+            expires_at,
+            gateway_id
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
         returning id`,
 		qi.CreatedAt,
 		qi.UpdatedAt,
@@ -75,6 +102,10 @@ func CreateDeviceQueueItem(db sqlx.Queryer, qi *DeviceQueueItem) error {
 		qi.EmitAtTimeSinceGPSEpoch,
 		qi.IsPending,
 		qi.TimeoutAfter,
+		qi.RetryCount,
+		qi.Reference,
+		qi.ExpiresAt,
+		qi.GatewayID,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -114,7 +145,11 @@ func UpdateDeviceQueueItem(db sqlx.Execer, qi *DeviceQueueItem) error {
             emit_at_time_since_gps_epoch = $8,
             is_pending = $9,
             timeout_after = $10,
-			dev_addr = $11
+			dev_addr = $11,
+            retry_count = $12,
+            reference = $13,
+            expires_at = $14,
+            gateway_id = $15
         where
             id = $1`,
 		qi.ID,
@@ -128,6 +163,10 @@ func UpdateDeviceQueueItem(db sqlx.Execer, qi *DeviceQueueItem) error {
 		qi.IsPending,
 		qi.TimeoutAfter,
 		qi.DevAddr[:],
+		qi.RetryCount,
+		qi.Reference,
+		qi.ExpiresAt,
+		qi.GatewayID,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -146,6 +185,7 @@ func UpdateDeviceQueueItem(db sqlx.Execer, qi *DeviceQueueItem) error {
 		"is_pending":                   qi.IsPending,
 		"emit_at_time_since_gps_epoch": qi.EmitAtTimeSinceGPSEpoch,
 		"timeout_after":                qi.TimeoutAfter,
+		"retry_count":                  qi.RetryCount,
 	}).Info("device-queue item updated")
 
 	return nil
@@ -268,23 +308,46 @@ func GetDeviceQueueItemsForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) ([]Devi
 // device-queue for the given DevEUI item respecting:
 // * maxPayloadSize: the maximum payload size
 // * fCnt: the current expected frame-counter
-// In case the payload exceeds the max payload size or when the payload
-// frame-counter is behind the actual frame-counter, the payload will be removed
-// from the queue and the next one will be retrieved. In such a case, the
-// application-server will be notified.
-func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lorawan.EUI64, maxPayloadSize int, fCnt uint32, routingProfileID uuid.UUID) (DeviceQueueItem, error) {
+// * maxRetryCount: the max number of retransmissions of a pending item
+// In case a pending item timed out and the max retry count has not been
+// reached, the item will be returned (again) for retransmission.
+// In case the payload exceeds the max payload size, when the payload
+// frame-counter is behind the actual frame-counter or when the item expired,
+// the payload will be removed from the queue and the next one will be
+// retrieved. In such a case, the application-server will be notified.
+func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lorawan.EUI64, maxPayloadSize int, fCnt uint32, routingProfileID uuid.UUID, maxRetryCount int) (DeviceQueueItem, error) {
 	for {
 		qi, err := GetNextDeviceQueueItemForDevEUI(db, devEUI)
 		if err != nil {
 			return DeviceQueueItem{}, errors.Wrap(err, "get next device-queue item error")
 		}
 
-		if qi.FCnt < fCnt || len(qi.FRMPayload) > maxPayloadSize || (qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now())) {
+		if qi.IsPending && qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now()) && qi.RetryCount < maxRetryCount {
+			qi.IsPending = false
+			qi.TimeoutAfter = nil
+			qi.RetryCount++
+
+			if err := UpdateDeviceQueueItem(db, &qi); err != nil {
+				return DeviceQueueItem{}, errors.Wrap(err, "update device-queue item error")
+			}
+
+			log.WithFields(log.Fields{
+				"dev_eui":                devEUI,
+				"device_queue_item_fcnt": qi.FCnt,
+				"retry_count":            qi.RetryCount,
+			}).Info("device-queue item timed out, retrying transmission")
+
+			return qi, nil
+		}
+
+		expired := !qi.IsPending && qi.ExpiresAt != nil && qi.ExpiresAt.Before(time.Now())
+
+		if qi.FCnt < fCnt || len(qi.FRMPayload) > maxPayloadSize || (qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now())) || expired {
 			rp, err := GetRoutingProfile(db, routingProfileID)
 			if err != nil {
 				return DeviceQueueItem{}, errors.Wrap(err, "get routing-profile error")
 			}
-			asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+			asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
 			if err != nil {
 				return DeviceQueueItem{}, errors.Wrap(err, "get application-server client error")
 			}
@@ -308,6 +371,16 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lo
 				if err != nil {
 					return DeviceQueueItem{}, errors.Wrap(err, "application-server client error")
 				}
+			} else if expired {
+				log.WithFields(log.Fields{
+					"dev_eui":                devEUI,
+					"device_queue_item_fcnt": qi.FCnt,
+					"expires_at":             qi.ExpiresAt,
+				}).Warning("device-queue item discarded as it expired")
+
+				if err := SendDownlinkStatus(asClient, qi, as.DownlinkStatus_EXPIRED); err != nil {
+					return DeviceQueueItem{}, err
+				}
 			} else if qi.FCnt < fCnt {
 				// handle frame-counter error
 				log.WithFields(log.Fields{
@@ -343,6 +416,10 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lo
 				if err != nil {
 					return DeviceQueueItem{}, errors.Wrap(err, "application-server client error")
 				}
+
+				if err := SendDownlinkStatus(asClient, qi, as.DownlinkStatus_DISCARDED_SIZE); err != nil {
+					return DeviceQueueItem{}, err
+				}
 			}
 
 			// try next frame
@@ -353,6 +430,56 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lo
 	}
 }
 
+// GetExpiredDeviceQueueItems returns max. count device-queue items which
+// expired and are not pending. The items will be locked for update so that
+// multiple instances can run this query in parallel.
+func GetExpiredDeviceQueueItems(db sqlx.Queryer, count int) ([]DeviceQueueItem, error) {
+	var items []DeviceQueueItem
+	err := sqlx.Select(db, &items, `
+        select
+            *
+        from
+            device_queue
+        where
+            expires_at < $1
+            and is_pending = false
+        order by
+            expires_at
+        limit $2
+        for update skip locked`,
+		time.Now(),
+		count,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return items, nil
+}
+
+// SendDownlinkStatus notifies the application-server about the status of the
+// given device-queue item. Application-servers not implementing the
+// HandleDownlinkStatus method are ignored.
+func SendDownlinkStatus(asClient as.ApplicationServerServiceClient, qi DeviceQueueItem, s as.DownlinkStatus) error {
+	ctx, cancel := context.WithTimeout(context.Background(), downlinkStatusTimeout)
+	defer cancel()
+
+	_, err := asClient.HandleDownlinkStatus(ctx, &as.HandleDownlinkStatusRequest{
+		DevEui:    qi.DevEUI[:],
+		FCnt:      qi.FCnt,
+		Reference: qi.Reference,
+		Status:    s,
+	})
+	if err != nil {
+		if grpc.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
+
 // GetDevicesWithClassBOrClassCDeviceQueueItems returns a slice of devices that qualify
 // for downlink Class-C transmission.
 // The device records will be locked for update so that multiple instances can
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -228,12 +229,21 @@ func TestDeviceQueue(t *testing.T) {
 					Name          string
 					FCnt          uint32
 					MaxFRMPayload int
+					MaxRetryCount int
 
 					ExpectedDeviceQueueItemID *int64
 					ExpectedHandleError       []as.HandleErrorRequest
 					ExpectedHandleDownlinkACK []as.HandleDownlinkACKRequest
+					ExpectedDownlinkStatus    []as.HandleDownlinkStatusRequest
 					ExpectedError             error
 				}{
+					{
+						Name:                      "retry first item from the queue (timeout)",
+						FCnt:                      100,
+						MaxFRMPayload:             7,
+						MaxRetryCount:             1,
+						ExpectedDeviceQueueItemID: &items[0].ID,
+					},
 					{
 						Name:                      "nACK + first item from the queue (timeout)",
 						FCnt:                      100,
@@ -254,6 +264,9 @@ func TestDeviceQueue(t *testing.T) {
 						ExpectedHandleDownlinkACK: []as.HandleDownlinkACKRequest{
 							{DevEui: d.DevEUI[:], FCnt: items[0].FCnt, Acknowledged: false},
 						},
+						ExpectedDownlinkStatus: []as.HandleDownlinkStatusRequest{
+							{DevEui: d.DevEUI[:], FCnt: 101, Status: as.DownlinkStatus_DISCARDED_SIZE},
+						},
 					},
 					{
 						Name:                      "nACK + first two items discarded (payload size)",
@@ -267,6 +280,10 @@ func TestDeviceQueue(t *testing.T) {
 						ExpectedHandleDownlinkACK: []as.HandleDownlinkACKRequest{
 							{DevEui: d.DevEUI[:], FCnt: items[0].FCnt, Acknowledged: false},
 						},
+						ExpectedDownlinkStatus: []as.HandleDownlinkStatusRequest{
+							{DevEui: d.DevEUI[:], FCnt: 101, Status: as.DownlinkStatus_DISCARDED_SIZE},
+							{DevEui: d.DevEUI[:], FCnt: 102, Status: as.DownlinkStatus_DISCARDED_SIZE},
+						},
 					},
 					{
 						Name:          "nACK + all items discarded (payload size)",
@@ -281,6 +298,12 @@ func TestDeviceQueue(t *testing.T) {
 						ExpectedHandleDownlinkACK: []as.HandleDownlinkACKRequest{
 							{DevEui: d.DevEUI[:], FCnt: items[0].FCnt, Acknowledged: false},
 						},
+						ExpectedDownlinkStatus: []as.HandleDownlinkStatusRequest{
+							{DevEui: d.DevEUI[:], FCnt: 101, Status: as.DownlinkStatus_DISCARDED_SIZE},
+							{DevEui: d.DevEUI[:], FCnt: 102, Status: as.DownlinkStatus_DISCARDED_SIZE},
+							{DevEui: d.DevEUI[:], FCnt: 103, Status: as.DownlinkStatus_DISCARDED_SIZE},
+							{DevEui: d.DevEUI[:], FCnt: 104, Status: as.DownlinkStatus_DISCARDED_SIZE},
+						},
 						ExpectedError: ErrDoesNotExist,
 					},
 					{
@@ -299,7 +322,7 @@ func TestDeviceQueue(t *testing.T) {
 
 				for i, test := range tests {
 					Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
-						qi, err := GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(DB(), d.DevEUI, test.MaxFRMPayload, test.FCnt, rp.ID)
+						qi, err := GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(DB(), d.DevEUI, test.MaxFRMPayload, test.FCnt, rp.ID, test.MaxRetryCount)
 						if test.ExpectedHandleError == nil {
 							So(*test.ExpectedDeviceQueueItemID, ShouldEqual, qi.ID)
 							So(err, ShouldBeNil)
@@ -318,9 +341,73 @@ func TestDeviceQueue(t *testing.T) {
 							req := <-asClient.HandleDownlinkACKChan
 							So(req, ShouldResemble, ack)
 						}
+
+						So(asClient.HandleDownlinkStatusChan, ShouldHaveLength, len(test.ExpectedDownlinkStatus))
+						for _, status := range test.ExpectedDownlinkStatus {
+							req := <-asClient.HandleDownlinkStatusChan
+							So(req, ShouldResemble, status)
+						}
 					})
 				}
 			})
+
+			Convey("Given an expired and a non-expired queue item", func() {
+				oneMinuteAgo := time.Now().Add(-time.Minute)
+
+				items := []DeviceQueueItem{
+					{
+						DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+						DevEUI:     d.DevEUI,
+						FCnt:       100,
+						FPort:      1,
+						FRMPayload: []byte{1, 2, 3},
+						Reference:  "expired",
+						ExpiresAt:  &oneMinuteAgo,
+					},
+					{
+						DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+						DevEUI:     d.DevEUI,
+						FCnt:       101,
+						FPort:      1,
+						FRMPayload: []byte{1, 2, 3},
+						Reference:  "valid",
+					},
+				}
+				for i := range items {
+					So(CreateDeviceQueueItem(DB(), &items[i]), ShouldBeNil)
+				}
+
+				Convey("Then GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt discards the expired item", func() {
+					qi, err := GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(DB(), d.DevEUI, 7, 100, rp.ID, 0)
+					So(err, ShouldBeNil)
+					So(qi.ID, ShouldEqual, items[1].ID)
+					So(qi.Reference, ShouldEqual, "valid")
+
+					So(asClient.HandleDownlinkStatusChan, ShouldHaveLength, 1)
+					So(<-asClient.HandleDownlinkStatusChan, ShouldResemble, as.HandleDownlinkStatusRequest{
+						DevEui:    d.DevEUI[:],
+						FCnt:      100,
+						Reference: "expired",
+						Status:    as.DownlinkStatus_EXPIRED,
+					})
+				})
+
+				Convey("Then GetExpiredDeviceQueueItems returns the expired item", func() {
+					expired, err := GetExpiredDeviceQueueItems(DB(), 10)
+					So(err, ShouldBeNil)
+					So(expired, ShouldHaveLength, 1)
+					So(expired[0].ID, ShouldEqual, items[0].ID)
+				})
+			})
+
+			Convey("Then CreateDeviceQueueItem rejects a reference exceeding 100 characters", func() {
+				qi := DeviceQueueItem{
+					DevEUI:    d.DevEUI,
+					FPort:     1,
+					Reference: strings.Repeat("a", 101),
+				}
+				So(CreateDeviceQueueItem(DB(), &qi), ShouldEqual, ErrDeviceQueueReferenceTooLong)
+			})
 		})
 	})
 }
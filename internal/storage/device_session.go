@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/gob"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/lorawan"
@@ -31,6 +33,24 @@ const (
 // UplinkHistorySize contains the number of frames to store
 const UplinkHistorySize = 20
 
+// randomDevAddrMaxAttempts defines the max. number of random DevAddr
+// candidates to try before giving up.
+const randomDevAddrMaxAttempts = 10
+
+// lastRXInfoSetMaxSize defines the max. number of gateways stored in the
+// LastRXInfoSet of the device-session.
+const lastRXInfoSetMaxSize = 3
+
+// saveDeviceSessionMaxAttempts defines the max. number of attempts to save
+// the device-session when it is concurrently modified by an other process.
+const saveDeviceSessionMaxAttempts = 10
+
+// confirmedUplinkMaxTransmissions defines the max. number of transmissions
+// of a confirmed uplink by a device that did not receive the ACK (LoRaWAN
+// 1.0.2). Retransmissions beyond this number are not acknowledged, as these
+// are most likely replayed frames.
+const confirmedUplinkMaxTransmissions = 8
+
 // RXWindow defines the RX window option.
 type RXWindow int8
 
@@ -64,10 +84,54 @@ type UplinkHistory struct {
 	GatewayCount int
 }
 
+// LinkADRReq contains the parameters of a LinkADRReq mac-command sent to the
+// device and whether the device acknowledged them.
+type LinkADRReq struct {
+	DR           int
+	TXPowerIndex int
+	NbTrans      uint8
+	ACK          bool
+}
+
+// DevStatus contains the device-status as reported by the device through the
+// DevStatusAns mac-command.
+type DevStatus struct {
+	Battery    uint8
+	Margin     int8
+	ReceivedAt time.Time
+}
+
 // UplinkGatewayHistory contains the uplink gateway history meta-data.
 // This is used for Class-B and Class-C downlinks.
 type UplinkGatewayHistory struct{}
 
+// DeviceSessionRXInfo contains the meta-data of a gateway which received the
+// last uplink of the device.
+type DeviceSessionRXInfo struct {
+	GatewayID lorawan.EUI64
+	Board     uint32
+	Antenna   uint32
+	RSSI      int
+	LoRaSNR   float64
+	Time      time.Time
+
+	// TimeSinceGPSEpoch contains the time since GPS epoch as reported by
+	// the gateway (0 when the gateway has no GPS time).
+	TimeSinceGPSEpoch time.Duration
+}
+
+// DeviceSessionTXInfo contains the meta-data of the last downlink
+// transmission to the device.
+type DeviceSessionTXInfo struct {
+	GatewayID lorawan.EUI64
+	Frequency int
+	DR        int
+	TXPower   int
+	FCnt      uint32
+	Airtime   time.Duration
+	Time      time.Time
+}
+
 // KeyEnvelope defined a key-envelope.
 type KeyEnvelope struct {
 	KEKLabel string
@@ -143,9 +207,19 @@ type DeviceSession struct {
 	// request was made.
 	LastDevStatusRequested time.Time
 
+	// LastDevStatus contains the last device-status reported by the device.
+	LastDevStatus *DevStatus
+
 	// LastDownlinkTX contains the timestamp of the last downlink.
 	LastDownlinkTX time.Time
 
+	// LastRXInfoSet contains the best gateways (sorted by SNR and RSSI) which
+	// received the last uplink of the device (see SetLastRXInfoSet).
+	LastRXInfoSet []DeviceSessionRXInfo
+
+	// LastTXInfo contains the meta-data of the last downlink transmission.
+	LastTXInfo *DeviceSessionTXInfo
+
 	// Class-B related configuration.
 	BeaconLocked      bool
 	PingSlotNb        int
@@ -177,6 +251,70 @@ type DeviceSession struct {
 
 	// Max uplink EIRP limitation.
 	UplinkMaxEIRPIndex uint8
+
+	// InstallationMargin overrides the global ADR installation margin (dB)
+	// for this device. When 0, the global installation margin is used.
+	InstallationMargin float64
+
+	// RXWindowPreference overrides the network-server rx_window setting
+	// for this device. It is copied from the device-profile on activation.
+	RXWindowPreference RXWindowPreference
+
+	// LastUplinkMIC holds the MIC of the last processed uplink. Together
+	// with FCntUp it is used to detect uplink retransmissions.
+	LastUplinkMIC lorawan.MIC
+
+	// UplinkRetransmissionCount holds the number of received uplink
+	// retransmissions.
+	UplinkRetransmissionCount uint32
+
+	// SkipFCntValidationCount holds the number of uplinks that were only
+	// accepted because frame-counter validation is skipped.
+	SkipFCntValidationCount uint32
+
+	// LastUplinkRetransmissions holds the number of retransmissions received
+	// for the last uplink. Up to NbTrans - 1 retransmissions are expected.
+	LastUplinkRetransmissions int
+
+	// ABPFCntReset enables the frame-counter reset detection for ABP
+	// devices. It is copied from the device-profile on ABP activation and
+	// is never set for OTAA devices.
+	ABPFCntReset bool
+
+	// MaxFCntGap overrides the max frame-counter gap of the band. It is
+	// copied from the device-profile on activation. When 0, the band
+	// default is used.
+	MaxFCntGap uint32
+
+	// MaxSupportedDR defines the maximum data-rate supported by the device.
+	// It is lowered when the device rejects the data-rate of a LinkADRReq.
+	// It is only applied when MaxSupportedDRSet is true.
+	MaxSupportedDR int
+
+	// MaxSupportedDRSet is set when MaxSupportedDR holds the maximum
+	// data-rate supported by the device.
+	MaxSupportedDRSet bool
+
+	// LinkADRReqRejectionCount holds the number of consecutive LinkADRReq
+	// mac-commands that were rejected by the device.
+	LinkADRReqRejectionCount int
+
+	// ChannelMaskRejected is set when the device rejected the channel-mask
+	// of a LinkADRReq. While set, no channel-mask reconfiguration is
+	// requested and the known-good channels are kept.
+	ChannelMaskRejected bool
+
+	// ADRStopped is set when the device rejected too many consecutive
+	// LinkADRReq mac-commands. While set, the ADR engine is not used.
+	ADRStopped bool
+
+	// LastLinkADRReq contains the last LinkADRReq answered by the device.
+	LastLinkADRReq *LinkADRReq
+
+	// Version is incremented on every save. It is used to detect that the
+	// device-session was changed by an other process since it was read
+	// (see SaveDeviceSessionIfUnchanged).
+	Version uint64
 }
 
 // AppendUplinkHistory appends an UplinkHistory item and makes sure the list
@@ -232,6 +370,25 @@ func (s DeviceSession) GetMACVersion() lorawan.MACVersion {
 	return lorawan.LoRaWAN1_0
 }
 
+// GetMaxFCntGap returns the max frame-counter gap of the device-session,
+// which is either the device-profile override or the band default.
+func (s DeviceSession) GetMaxFCntGap() uint32 {
+	if s.MaxFCntGap != 0 {
+		return s.MaxFCntGap
+	}
+	return band.Band().GetDefaults().MaxFCntGap
+}
+
+// ResetLinkADRState resets the state resulting from LinkADRReq mac-commands
+// rejected by the device, e.g. after the device has been reconfigured.
+func (s *DeviceSession) ResetLinkADRState() {
+	s.MaxSupportedDR = 0
+	s.MaxSupportedDRSet = false
+	s.LinkADRReqRejectionCount = 0
+	s.ChannelMaskRejected = false
+	s.ADRStopped = false
+}
+
 // ResetToBootParameters resets the device-session to the device boo
 // parameters as defined by the given device-profile.
 func (s *DeviceSession) ResetToBootParameters(dp DeviceProfile) {
@@ -247,26 +404,56 @@ func (s *DeviceSession) ResetToBootParameters(dp DeviceProfile) {
 	s.TXPowerIndex = 0
 	s.MinSupportedTXPowerIndex = 0
 	s.MaxSupportedTXPowerIndex = 0
+	s.ResetLinkADRState()
 	s.ExtraUplinkChannels = make(map[int]loraband.Channel)
 	s.RXDelay = uint8(dp.RXDelay1)
 	s.RX1DROffset = uint8(dp.RXDROffset1)
 	s.RX2DR = uint8(dp.RXDataRate2)
 	s.RX2Frequency = int(dp.RXFreq2)
-	s.EnabledUplinkChannels = band.Band().GetStandardUplinkChannelIndices() // TODO: replace by ServiceProfile.ChannelMask?
+	// This reflects the channels the device is using after boot. The
+	// service-profile channel-mask (if set) is applied by the channel
+	// reconfiguration on the first uplink.
+	s.EnabledUplinkChannels = band.Band().GetStandardUplinkChannelIndices()
 	s.ChannelFrequencies = channelFrequencies
 	s.PingSlotDR = dp.PingSlotDR
 	s.PingSlotFrequency = int(dp.PingSlotFreq)
 	s.NbTrans = 1
+	s.UplinkDwellTime400ms = band.DwellTime400msByDefault()
+	s.DownlinkDwellTime400ms = band.DwellTime400msByDefault()
 
 	if dp.PingSlotPeriod != 0 {
 		s.PingSlotNb = (1 << 12) / dp.PingSlotPeriod
 	}
 }
 
+// SetLastRXInfoSet sorts the given rx-info set by SNR and RSSI (best first)
+// and stores the first lastRXInfoSetMaxSize items as LastRXInfoSet.
+func (s *DeviceSession) SetLastRXInfoSet(rxInfoSet []DeviceSessionRXInfo) {
+	set := make([]DeviceSessionRXInfo, len(rxInfoSet))
+	copy(set, rxInfoSet)
+
+	sort.SliceStable(set, func(i, j int) bool {
+		if set[i].LoRaSNR == set[j].LoRaSNR {
+			return set[i].RSSI > set[j].RSSI
+		}
+		return set[i].LoRaSNR > set[j].LoRaSNR
+	})
+
+	if len(set) > lastRXInfoSetMaxSize {
+		set = set[:lastRXInfoSetMaxSize]
+	}
+
+	s.LastRXInfoSet = set
+}
+
 // GetDownlinkGatewayMAC returns the gateway MAC of the gateway close to the
-// device.
-// TODO: refactor so that it uses GetDeviceGatewayRXInfoSet.
+// device. This is the best gateway of the last uplink, or in case this is
+// not available, a gateway from the uplink gateway history.
 func (s DeviceSession) GetDownlinkGatewayMAC() (lorawan.EUI64, error) {
+	if len(s.LastRXInfoSet) != 0 {
+		return s.LastRXInfoSet[0].GatewayID, nil
+	}
+
 	for mac := range s.UplinkGatewayHistory {
 		return mac, nil
 	}
@@ -275,17 +462,33 @@ func (s DeviceSession) GetDownlinkGatewayMAC() (lorawan.EUI64, error) {
 }
 
 // GetRandomDevAddr returns a random DevAddr, prefixed with NwkID based on the
-// given NetID.
+// given NetID. DevAddr candidates already in use by other device-sessions are
+// skipped. When no free DevAddr is found after a number of attempts,
+// ErrDevAddrSpaceExhausted is returned.
 func GetRandomDevAddr(p *redis.Pool, netID lorawan.NetID) (lorawan.DevAddr, error) {
-	var d lorawan.DevAddr
-	b := make([]byte, len(d))
-	if _, err := rand.Read(b); err != nil {
-		return d, errors.Wrap(err, "read random bytes error")
+	c := p.Get()
+	defer c.Close()
+
+	for i := 0; i < randomDevAddrMaxAttempts; i++ {
+		var d lorawan.DevAddr
+		b := make([]byte, len(d))
+		if _, err := rand.Read(b); err != nil {
+			return d, errors.Wrap(err, "read random bytes error")
+		}
+		copy(d[:], b)
+		d.SetAddrPrefix(netID)
+
+		count, err := redis.Int(c.Do("SCARD", fmt.Sprintf(devAddrKeyTempl, d)))
+		if err != nil {
+			return d, errors.Wrap(err, "get devaddr set cardinality error")
+		}
+
+		if count == 0 {
+			return d, nil
+		}
 	}
-	copy(d[:], b)
-	d.SetAddrPrefix(netID)
 
-	return d, nil
+	return lorawan.DevAddr{}, ErrDevAddrSpaceExhausted
 }
 
 // ValidateAndGetFullFCntUp validates if the given fCntUp is valid
@@ -297,23 +500,175 @@ func GetRandomDevAddr(p *redis.Pool, netID lorawan.NetID) (lorawan.DevAddr, erro
 func ValidateAndGetFullFCntUp(s DeviceSession, fCntUp uint32) (uint32, bool) {
 	// we need to compare the difference of the 16 LSB
 	gap := uint32(uint16(fCntUp) - uint16(s.FCntUp%65536))
-	if gap < band.Band().GetDefaults().MaxFCntGap {
+	if gap < s.GetMaxFCntGap() {
 		return s.FCntUp + gap, true
 	}
 	return 0, false
 }
 
+// IsUplinkRetransmission returns true when the given uplink has the same
+// FCnt and MIC as the last processed uplink of the device-session.
+// For LoRaWAN 1.1 only the FNwkSIntKey part of the MIC is compared, as the
+// SNwkSIntKey part depends on the data-rate and channel of the transmission.
+func IsUplinkRetransmission(s DeviceSession, phy lorawan.PHYPayload) bool {
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok || s.FCntUp == 0 {
+		return false
+	}
+
+	if uint16(macPL.FHDR.FCnt) != uint16(s.FCntUp-1) {
+		return false
+	}
+
+	if s.GetMACVersion() == lorawan.LoRaWAN1_0 {
+		return phy.MIC == s.LastUplinkMIC
+	}
+	return bytes.Equal(phy.MIC[2:], s.LastUplinkMIC[2:])
+}
+
+// IsExpectedUplinkRetransmission returns true when the number of received
+// retransmissions of the last uplink does not exceed the number of
+// retransmissions requested by the NbTrans of the device-session.
+func (s DeviceSession) IsExpectedUplinkRetransmission() bool {
+	return s.LastUplinkRetransmissions < int(s.NbTrans)
+}
+
+// IsExpectedConfirmedUplinkRetransmission returns true when the number of
+// received retransmissions of the last (confirmed) uplink does not exceed
+// the number of retransmissions expected by NbTrans or by the retries of an
+// unacknowledged confirmed uplink, whichever is greater.
+func (s DeviceSession) IsExpectedConfirmedUplinkRetransmission() bool {
+	return s.IsExpectedUplinkRetransmission() || s.LastUplinkRetransmissions < confirmedUplinkMaxTransmissions
+}
+
 // SaveDeviceSession saves the device-session. In case it doesn't exist yet
-// it will be created.
+// it will be created. The device-session is saved unconditionally, its
+// stored Version is incremented so that other processes holding a copy of
+// the device-session will fail to save it using SaveDeviceSessionIfUnchanged.
 func SaveDeviceSession(p *redis.Pool, s DeviceSession) error {
-	dsPB := deviceSessionToPB(s)
-	b, err := proto.Marshal(&dsPB)
+	return saveDeviceSession(p, s, false)
+}
+
+// ActivateDeviceSession saves the device-session and flushes the
+// mac-command queue and the pending mac-commands of the device within the
+// same transaction. This avoids that mac-commands of a previous session are
+// sent using the new security context.
+func ActivateDeviceSession(p *redis.Pool, s DeviceSession) error {
+	return saveDeviceSession(p, s, true)
+}
+
+// SaveDeviceSessionIfUnchanged saves the device-session only when the
+// stored device-session has not been saved by an other process since it was
+// read (optimistic locking). Else ErrDeviceSessionChanged is returned.
+// On success, the Version of the given device-session is incremented.
+func SaveDeviceSessionIfUnchanged(p *redis.Pool, s *DeviceSession) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deviceSessionKeyTempl, s.DevEUI)
+	if _, err := c.Do("WATCH", key); err != nil {
+		return errors.Wrap(err, "watch error")
+	}
+
+	val, err := redis.Bytes(c.Do("GET", key))
+	if err != nil && err != redis.ErrNil {
+		c.Do("UNWATCH")
+		return errors.Wrap(err, "get error")
+	}
+
+	// in case the device-session expired in the meantime, it is re-created
+	if err == nil {
+		var dsPB DeviceSessionPB
+		if err := proto.Unmarshal(val, &dsPB); err == nil && dsPB.Version != s.Version {
+			c.Do("UNWATCH")
+			return ErrDeviceSessionChanged
+		}
+	}
+
+	ds := *s
+	ds.Version++
+
+	reply, err := execSaveDeviceSession(c, ds, false)
 	if err != nil {
-		return errors.Wrap(err, "protobuf encode error")
+		return err
+	}
+
+	// the transaction is aborted when the watched key was modified
+	if reply == nil {
+		return ErrDeviceSessionChanged
 	}
 
+	s.Version = ds.Version
+
+	log.WithFields(log.Fields{
+		"dev_eui":  s.DevEUI,
+		"dev_addr": s.DevAddr,
+		"version":  s.Version,
+	}).Info("device-session saved")
+
+	return nil
+}
+
+func saveDeviceSession(p *redis.Pool, s DeviceSession, flushMACCommands bool) error {
 	c := p.Get()
 	defer c.Close()
+
+	key := fmt.Sprintf(deviceSessionKeyTempl, s.DevEUI)
+
+	// the Version must be incremented relative to the stored device-session,
+	// in case it was changed in the meantime the save is retried
+	for i := 0; i < saveDeviceSessionMaxAttempts; i++ {
+		if _, err := c.Do("WATCH", key); err != nil {
+			return errors.Wrap(err, "watch error")
+		}
+
+		val, err := redis.Bytes(c.Do("GET", key))
+		if err != nil && err != redis.ErrNil {
+			c.Do("UNWATCH")
+			return errors.Wrap(err, "get error")
+		}
+
+		ds := s
+		if err == nil {
+			var dsPB DeviceSessionPB
+			if err := proto.Unmarshal(val, &dsPB); err == nil && dsPB.Version > ds.Version {
+				ds.Version = dsPB.Version
+			}
+		}
+		ds.Version++
+
+		reply, err := execSaveDeviceSession(c, ds, flushMACCommands)
+		if err != nil {
+			return err
+		}
+
+		// the transaction is aborted when the watched key was modified
+		if reply == nil {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":  ds.DevEUI,
+			"dev_addr": ds.DevAddr,
+			"version":  ds.Version,
+		}).Info("device-session saved")
+
+		return nil
+	}
+
+	return ErrDeviceSessionChanged
+}
+
+// execSaveDeviceSession saves the device-session within a MULTI / EXEC
+// transaction and returns the EXEC reply.
+func execSaveDeviceSession(c redis.Conn, s DeviceSession, flushMACCommands bool) (interface{}, error) {
+	dsPB := deviceSessionToPB(s)
+	b, err := proto.Marshal(&dsPB)
+	if err != nil {
+		c.Do("UNWATCH")
+		return nil, errors.Wrap(err, "protobuf encode error")
+	}
+
 	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
 
 	c.Send("MULTI")
@@ -324,16 +679,15 @@ func SaveDeviceSession(p *redis.Pool, s DeviceSession) error {
 		c.Send("SADD", fmt.Sprintf(devAddrKeyTempl, s.PendingRejoinDeviceSession.DevAddr), s.DevEUI[:])
 		c.Send("PEXPIRE", fmt.Sprintf(devAddrKeyTempl, s.PendingRejoinDeviceSession.DevAddr), exp)
 	}
-	if _, err := c.Do("EXEC"); err != nil {
-		return errors.Wrap(err, "exec error")
+	if flushMACCommands {
+		c.Send("DEL", getMACCommandKeys(s.DevEUI)...)
+	}
+	reply, err := c.Do("EXEC")
+	if err != nil {
+		return nil, errors.Wrap(err, "exec error")
 	}
 
-	log.WithFields(log.Fields{
-		"dev_eui":  s.DevEUI,
-		"dev_addr": s.DevAddr,
-	}).Info("device-session saved")
-
-	return nil
+	return reply, nil
 }
 
 // GetDeviceSession returns the device-session for the given DevEUI.
@@ -366,22 +720,83 @@ func GetDeviceSession(p *redis.Pool, devEUI lorawan.EUI64) (DeviceSession, error
 	return deviceSessionFromPB(dsPB), nil
 }
 
-// DeleteDeviceSession deletes the device-session matching the given DevEUI.
+// GetDeviceSessionTTL returns the remaining TTL of the device-session
+// matching the given DevEUI. It returns 0 when the device-session does not
+// expire.
+func GetDeviceSessionTTL(p *redis.Pool, devEUI lorawan.EUI64) (time.Duration, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ttl, err := redis.Int64(c.Do("PTTL", fmt.Sprintf(deviceSessionKeyTempl, devEUI)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get ttl error")
+	}
+
+	switch ttl {
+	case -2:
+		return 0, ErrDoesNotExist
+	case -1:
+		return 0, nil
+	default:
+		return time.Duration(ttl) * time.Millisecond, nil
+	}
+}
+
+// DeleteDeviceSession deletes the device-session matching the given DevEUI,
+// together with its mac-command queue and pending mac-commands.
 func DeleteDeviceSession(p *redis.Pool, devEUI lorawan.EUI64) error {
 	c := p.Get()
 	defer c.Close()
 
-	val, err := redis.Int(c.Do("DEL", fmt.Sprintf(deviceSessionKeyTempl, devEUI)))
+	ds, err := GetDeviceSession(p, devEUI)
 	if err != nil {
-		return errors.Wrap(err, "delete error")
+		return err
 	}
-	if val == 0 {
-		return ErrDoesNotExist
+
+	c.Send("MULTI")
+	c.Send("DEL", fmt.Sprintf(deviceSessionKeyTempl, devEUI))
+	c.Send("SREM", fmt.Sprintf(devAddrKeyTempl, ds.DevAddr), devEUI[:])
+	if ds.PendingRejoinDeviceSession != nil {
+		c.Send("SREM", fmt.Sprintf(devAddrKeyTempl, ds.PendingRejoinDeviceSession.DevAddr), devEUI[:])
+	}
+	c.Send("DEL", getMACCommandKeys(devEUI)...)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "delete error")
 	}
 	log.WithField("dev_eui", devEUI).Info("device-session deleted")
 	return nil
 }
 
+// GetDeviceSessionCount returns the number of device-sessions. It iterates
+// over the device-session keys using SCAN, so that Redis is not blocked.
+func GetDeviceSessionCount(p *redis.Pool) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	// match on the 16 hex characters of the DevEUI to exclude the other
+	// device keys
+	match := fmt.Sprintf(deviceSessionKeyTempl, strings.Repeat("?", 16))
+
+	var count int
+	cursor := 0
+	for {
+		vals, err := redis.Values(c.Do("SCAN", cursor, "MATCH", match, "COUNT", 1000))
+		if err != nil {
+			return 0, errors.Wrap(err, "scan error")
+		}
+
+		var keys []string
+		if _, err := redis.Scan(vals, &cursor, &keys); err != nil {
+			return 0, errors.Wrap(err, "scan values error")
+		}
+		count += len(keys)
+
+		if cursor == 0 {
+			return count, nil
+		}
+	}
+}
+
 // GetDeviceSessionsForDevAddr returns a slice of device-sessions using the
 // given DevAddr. When no device-session is using the given DevAddr, this returns
 // an empty slice.
@@ -405,7 +820,15 @@ func GetDeviceSessionsForDevAddr(p *redis.Pool, devAddr lorawan.DevAddr) ([]Devi
 
 		s, err := GetDeviceSession(p, devEUI)
 		if err != nil {
-			// TODO: in case not found, remove the DevEUI from the list
+			// the device-session has expired or has been removed, remove
+			// the stale DevEUI from the DevAddr index
+			if err == ErrDoesNotExist {
+				if _, err := c.Do("SREM", fmt.Sprintf(devAddrKeyTempl, devAddr), devEUI[:]); err != nil {
+					return nil, errors.Wrap(err, "remove dev_eui from devaddr set error")
+				}
+				continue
+			}
+
 			log.WithFields(log.Fields{
 				"dev_addr": devAddr,
 				"dev_eui":  devEUI,
@@ -447,6 +870,22 @@ func GetDeviceSessionForPHYPayload(p *redis.Pool, phy lorawan.PHYPayload, txDR,
 	for _, s := range sessions {
 		// reset to the original FCnt
 		macPL.FHDR.FCnt = originalFCnt
+
+		// a retransmission of the last processed uplink must be returned
+		// so that it is not rejected as a frame-counter error, the MIC is
+		// validated to make sure it was sent by this device
+		if IsUplinkRetransmission(s, phy) {
+			macPL.FHDR.FCnt = s.FCntUp - 1
+			micOK, err := phy.ValidateUplinkDataMIC(s.GetMACVersion(), s.ConfFCnt, uint8(txDR), uint8(txCh), s.FNwkSIntKey, s.SNwkSIntKey)
+			if err != nil {
+				return DeviceSession{}, errors.Wrap(err, "validate mic error")
+			}
+			if micOK {
+				return s, nil
+			}
+			macPL.FHDR.FCnt = originalFCnt
+		}
+
 		// get full FCnt
 		fullFCnt, ok := ValidateAndGetFullFCntUp(s, macPL.FHDR.FCnt)
 		if !ok {
@@ -470,14 +909,17 @@ func GetDeviceSessionForPHYPayload(p *redis.Pool, phy lorawan.PHYPayload, txDR,
 				}
 
 				if micOK {
+					s.SkipFCntValidationCount++
+
 					// we need to update the NodeSession
-					if err := SaveDeviceSession(p, s); err != nil {
+					if err := SaveDeviceSessionIfUnchanged(p, &s); err != nil {
 						return DeviceSession{}, err
 					}
+					skipFCntValidationCounter().Inc()
 					log.WithFields(log.Fields{
 						"dev_addr": macPL.FHDR.DevAddr,
 						"dev_eui":  s.DevEUI,
-					}).Warning("frame counters reset")
+					}).Warning("frame counters reset, frame-counter validation is skipped for this device")
 					return s, nil
 				}
 			}
@@ -499,6 +941,83 @@ func GetDeviceSessionForPHYPayload(p *redis.Pool, phy lorawan.PHYPayload, txDR,
 	return DeviceSession{}, ErrDoesNotExistOrFCntOrMICInvalid
 }
 
+// UplinkValidationError describes why an uplink could not be matched to the
+// device-session of the device that sent it.
+type UplinkValidationError struct {
+	DeviceSession DeviceSession
+	Type          as.ErrorType
+	FCnt          uint32
+	ExpectedFCnt  uint32
+}
+
+// GetUplinkValidationError returns why the given PHYPayload could not be
+// matched to a device-session by GetDeviceSessionForPHYPayload. It returns
+// nil when the reason can't be determined, e.g. when the DevAddr is unknown
+// or when the MIC is invalid and multiple device-sessions use the DevAddr.
+func GetUplinkValidationError(p *redis.Pool, phy lorawan.PHYPayload, txDR, txCh int) (*UplinkValidationError, error) {
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+	}
+	originalFCnt := macPL.FHDR.FCnt
+	defer func() {
+		macPL.FHDR.FCnt = originalFCnt
+	}()
+
+	sessions, err := GetDeviceSessionsForDevAddr(p, macPL.FHDR.DevAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sessions {
+		if fullFCnt, ok := ValidateAndGetFullFCntUp(s, originalFCnt); ok {
+			// the FCnt is valid, thus the MIC must be invalid
+			if len(sessions) == 1 {
+				return &UplinkValidationError{
+					DeviceSession: s,
+					Type:          as.ErrorType_DATA_UP_MIC,
+					FCnt:          fullFCnt,
+					ExpectedFCnt:  s.FCntUp,
+				}, nil
+			}
+			continue
+		}
+
+		// the FCnt is invalid, validate the MIC using the FCnt of the
+		// previous uplink (re-transmission) or the 16 bit FCnt as-is
+		errType := as.ErrorType_DATA_UP_FCNT_GAP_TOO_LARGE
+		fCnt := originalFCnt
+		if s.FCntUp > 0 && uint16(originalFCnt) == uint16(s.FCntUp-1) {
+			errType = as.ErrorType_DATA_UP_FCNT_RETRANSMISSION
+			fCnt = s.FCntUp - 1
+		} else if originalFCnt < s.FCntUp {
+			// only a frame-counter (close to) zero indicates that the device
+			// restarted its frame-counters, any other lower frame-counter
+			// could be a replay of a previous uplink
+			errType = as.ErrorType_DATA_UP_FCNT
+			if originalFCnt <= abpFCntResetMaxFCnt {
+				errType = as.ErrorType_DATA_UP_FCNT_RESET
+			}
+		}
+
+		macPL.FHDR.FCnt = fCnt
+		micOK, err := phy.ValidateUplinkDataMIC(s.GetMACVersion(), s.ConfFCnt, uint8(txDR), uint8(txCh), s.FNwkSIntKey, s.SNwkSIntKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "validate mic error")
+		}
+		if micOK {
+			return &UplinkValidationError{
+				DeviceSession: s,
+				Type:          errType,
+				FCnt:          fCnt,
+				ExpectedFCnt:  s.FCntUp,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // DeviceSessionExists returns a bool indicating if a device session exist.
 func DeviceSessionExists(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
 	c := p.Get()
@@ -669,9 +1188,23 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		RejoinCount_0:     uint32(d.RejoinCount0),
 		ReferenceAltitude: d.ReferenceAltitude,
 
-		UplinkDwellTime_400Ms:   d.UplinkDwellTime400ms,
-		DownlinkDwellTime_400Ms: d.DownlinkDwellTime400ms,
-		UplinkMaxEirpIndex:      uint32(d.UplinkMaxEIRPIndex),
+		UplinkDwellTime_400Ms:     d.UplinkDwellTime400ms,
+		DownlinkDwellTime_400Ms:   d.DownlinkDwellTime400ms,
+		UplinkMaxEirpIndex:        uint32(d.UplinkMaxEIRPIndex),
+		InstallationMargin:        d.InstallationMargin,
+		RxWindowPreference:        uint32(d.RXWindowPreference),
+		LastUplinkMic:             d.LastUplinkMIC[:],
+		UplinkRetransmissionCount: d.UplinkRetransmissionCount,
+		SkipFCntValidationCount:   d.SkipFCntValidationCount,
+		AbpFcntReset:              d.ABPFCntReset,
+		MaxFcntGap:                d.MaxFCntGap,
+		LastUplinkRetransmissions: uint32(d.LastUplinkRetransmissions),
+		MaxSupportedDr:            uint32(d.MaxSupportedDR),
+		MaxSupportedDrSet:         d.MaxSupportedDRSet,
+		LinkAdrReqRejectionCount:  uint32(d.LinkADRReqRejectionCount),
+		ChannelMaskRejected:       d.ChannelMaskRejected,
+		AdrStopped:                d.ADRStopped,
+		Version:                   d.Version,
 	}
 
 	if d.AppSKeyEvelope != nil {
@@ -710,6 +1243,48 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		out.UplinkGatewayHistory[mac.String()] = nil
 	}
 
+	if d.LastLinkADRReq != nil {
+		out.LastLinkAdrReq = &DeviceSessionPBLinkADRReq{
+			Dr:           uint32(d.LastLinkADRReq.DR),
+			TxPowerIndex: uint32(d.LastLinkADRReq.TXPowerIndex),
+			NbTrans:      uint32(d.LastLinkADRReq.NbTrans),
+			Ack:          d.LastLinkADRReq.ACK,
+		}
+	}
+
+	if d.LastDevStatus != nil {
+		out.LastDevStatus = &DeviceSessionPBDevStatus{
+			Battery:          uint32(d.LastDevStatus.Battery),
+			Margin:           int32(d.LastDevStatus.Margin),
+			ReceivedAtUnixNs: d.LastDevStatus.ReceivedAt.UnixNano(),
+		}
+	}
+
+	for _, rxInfo := range d.LastRXInfoSet {
+		out.LastRxInfoSet = append(out.LastRxInfoSet, &DeviceSessionPBRXInfo{
+			GatewayId:  rxInfo.GatewayID[:],
+			Board:      rxInfo.Board,
+			Antenna:    rxInfo.Antenna,
+			Rssi:       int32(rxInfo.RSSI),
+			LoraSnr:    rxInfo.LoRaSNR,
+			TimeUnixNs: rxInfo.Time.UnixNano(),
+
+			TimeSinceGpsEpochNs: int64(rxInfo.TimeSinceGPSEpoch),
+		})
+	}
+
+	if d.LastTXInfo != nil {
+		out.LastTxInfo = &DeviceSessionPBTXInfo{
+			GatewayId:  d.LastTXInfo.GatewayID[:],
+			Frequency:  uint32(d.LastTXInfo.Frequency),
+			Dr:         uint32(d.LastTXInfo.DR),
+			TxPower:    int32(d.LastTXInfo.TXPower),
+			FCnt:       d.LastTXInfo.FCnt,
+			AirtimeNs:  int64(d.LastTXInfo.Airtime),
+			TimeUnixNs: d.LastTXInfo.Time.UnixNano(),
+		}
+	}
+
 	if d.PendingRejoinDeviceSession != nil {
 		dsPB := deviceSessionToPB(*d.PendingRejoinDeviceSession)
 		b, err := proto.Marshal(&dsPB)
@@ -768,9 +1343,22 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		RejoinCount0:      uint16(d.RejoinCount_0),
 		ReferenceAltitude: d.ReferenceAltitude,
 
-		UplinkDwellTime400ms:   d.UplinkDwellTime_400Ms,
-		DownlinkDwellTime400ms: d.DownlinkDwellTime_400Ms,
-		UplinkMaxEIRPIndex:     uint8(d.UplinkMaxEirpIndex),
+		UplinkDwellTime400ms:      d.UplinkDwellTime_400Ms,
+		DownlinkDwellTime400ms:    d.DownlinkDwellTime_400Ms,
+		UplinkMaxEIRPIndex:        uint8(d.UplinkMaxEirpIndex),
+		InstallationMargin:        d.InstallationMargin,
+		RXWindowPreference:        RXWindowPreference(d.RxWindowPreference),
+		UplinkRetransmissionCount: d.UplinkRetransmissionCount,
+		SkipFCntValidationCount:   d.SkipFCntValidationCount,
+		ABPFCntReset:              d.AbpFcntReset,
+		MaxFCntGap:                d.MaxFcntGap,
+		LastUplinkRetransmissions: int(d.LastUplinkRetransmissions),
+		MaxSupportedDR:            int(d.MaxSupportedDr),
+		MaxSupportedDRSet:         d.MaxSupportedDrSet,
+		LinkADRReqRejectionCount:  int(d.LinkAdrReqRejectionCount),
+		ChannelMaskRejected:       d.ChannelMaskRejected,
+		ADRStopped:                d.AdrStopped,
+		Version:                   d.Version,
 	}
 
 	if d.LastDeviceStatusRequestTimeUnixNs > 0 {
@@ -787,6 +1375,7 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 	copy(out.FNwkSIntKey[:], d.FNwkSIntKey)
 	copy(out.SNwkSIntKey[:], d.SNwkSIntKey)
 	copy(out.NwkSEncKey[:], d.NwkSEncKey)
+	copy(out.LastUplinkMIC[:], d.LastUplinkMic)
 
 	if d.AppSKeyEnvelope != nil {
 		out.AppSKeyEvelope = &KeyEnvelope{
@@ -820,6 +1409,23 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		})
 	}
 
+	if d.LastLinkAdrReq != nil {
+		out.LastLinkADRReq = &LinkADRReq{
+			DR:           int(d.LastLinkAdrReq.Dr),
+			TXPowerIndex: int(d.LastLinkAdrReq.TxPowerIndex),
+			NbTrans:      uint8(d.LastLinkAdrReq.NbTrans),
+			ACK:          d.LastLinkAdrReq.Ack,
+		}
+	}
+
+	if d.LastDevStatus != nil {
+		out.LastDevStatus = &DevStatus{
+			Battery:    uint8(d.LastDevStatus.Battery),
+			Margin:     int8(d.LastDevStatus.Margin),
+			ReceivedAt: time.Unix(0, d.LastDevStatus.ReceivedAtUnixNs),
+		}
+	}
+
 	for idStr := range d.UplinkGatewayHistory {
 		var id lorawan.EUI64
 		if err := id.UnmarshalText([]byte(idStr)); err != nil {
@@ -828,6 +1434,32 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		out.UplinkGatewayHistory[id] = UplinkGatewayHistory{}
 	}
 
+	for _, rxInfo := range d.LastRxInfoSet {
+		item := DeviceSessionRXInfo{
+			Board:   rxInfo.Board,
+			Antenna: rxInfo.Antenna,
+			RSSI:    int(rxInfo.Rssi),
+			LoRaSNR: rxInfo.LoraSnr,
+			Time:    time.Unix(0, rxInfo.TimeUnixNs),
+
+			TimeSinceGPSEpoch: time.Duration(rxInfo.TimeSinceGpsEpochNs),
+		}
+		copy(item.GatewayID[:], rxInfo.GatewayId)
+		out.LastRXInfoSet = append(out.LastRXInfoSet, item)
+	}
+
+	if d.LastTxInfo != nil {
+		out.LastTXInfo = &DeviceSessionTXInfo{
+			Frequency: int(d.LastTxInfo.Frequency),
+			DR:        int(d.LastTxInfo.Dr),
+			TXPower:   int(d.LastTxInfo.TxPower),
+			FCnt:      d.LastTxInfo.FCnt,
+			Airtime:   time.Duration(d.LastTxInfo.AirtimeNs),
+			Time:      time.Unix(0, d.LastTxInfo.TimeUnixNs),
+		}
+		copy(out.LastTXInfo.GatewayID[:], d.LastTxInfo.GatewayId)
+	}
+
 	if len(d.PendingRejoinDeviceSession) != 0 {
 		var dsPB DeviceSessionPB
 		if err := proto.Unmarshal(d.PendingRejoinDeviceSession, &dsPB); err != nil {
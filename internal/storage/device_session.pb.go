@@ -146,6 +146,131 @@ func (m *DeviceSessionPBUplinkADRHistory) GetGatewayCount() uint32 {
 	return 0
 }
 
+type DeviceSessionPBLinkADRReq struct {
+	// Requested data-rate.
+	Dr uint32 `protobuf:"varint,1,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Requested TX Power index.
+	TxPowerIndex uint32 `protobuf:"varint,2,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Requested number of transmissions.
+	NbTrans uint32 `protobuf:"varint,3,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// LinkADRAns was positive (all bits acknowledged).
+	Ack                  bool     `protobuf:"varint,4,opt,name=ack,proto3" json:"ack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceSessionPBLinkADRReq) Reset()         { *m = DeviceSessionPBLinkADRReq{} }
+func (m *DeviceSessionPBLinkADRReq) String() string { return proto.CompactTextString(m) }
+func (*DeviceSessionPBLinkADRReq) ProtoMessage()    {}
+func (*DeviceSessionPBLinkADRReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_958563bbc6ebadf7, []int{2}
+}
+
+func (m *DeviceSessionPBLinkADRReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSessionPBLinkADRReq.Unmarshal(m, b)
+}
+func (m *DeviceSessionPBLinkADRReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSessionPBLinkADRReq.Marshal(b, m, deterministic)
+}
+func (m *DeviceSessionPBLinkADRReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSessionPBLinkADRReq.Merge(m, src)
+}
+func (m *DeviceSessionPBLinkADRReq) XXX_Size() int {
+	return xxx_messageInfo_DeviceSessionPBLinkADRReq.Size(m)
+}
+func (m *DeviceSessionPBLinkADRReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSessionPBLinkADRReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceSessionPBLinkADRReq proto.InternalMessageInfo
+
+func (m *DeviceSessionPBLinkADRReq) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBLinkADRReq) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBLinkADRReq) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBLinkADRReq) GetAck() bool {
+	if m != nil {
+		return m.Ack
+	}
+	return false
+}
+
+type DeviceSessionPBDevStatus struct {
+	// Battery level (as reported by the device).
+	Battery uint32 `protobuf:"varint,1,opt,name=battery,proto3" json:"battery,omitempty"`
+	// Demodulation signal-to-noise ratio margin (dB).
+	Margin int32 `protobuf:"varint,2,opt,name=margin,proto3" json:"margin,omitempty"`
+	// Timestamp when the answer was received (unix nsec).
+	ReceivedAtUnixNs     int64    `protobuf:"varint,3,opt,name=received_at_unix_ns,json=receivedAtUnixNs,proto3" json:"received_at_unix_ns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceSessionPBDevStatus) Reset()         { *m = DeviceSessionPBDevStatus{} }
+func (m *DeviceSessionPBDevStatus) String() string { return proto.CompactTextString(m) }
+func (*DeviceSessionPBDevStatus) ProtoMessage()    {}
+func (*DeviceSessionPBDevStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_958563bbc6ebadf7, []int{3}
+}
+
+func (m *DeviceSessionPBDevStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSessionPBDevStatus.Unmarshal(m, b)
+}
+func (m *DeviceSessionPBDevStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSessionPBDevStatus.Marshal(b, m, deterministic)
+}
+func (m *DeviceSessionPBDevStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSessionPBDevStatus.Merge(m, src)
+}
+func (m *DeviceSessionPBDevStatus) XXX_Size() int {
+	return xxx_messageInfo_DeviceSessionPBDevStatus.Size(m)
+}
+func (m *DeviceSessionPBDevStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSessionPBDevStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceSessionPBDevStatus proto.InternalMessageInfo
+
+func (m *DeviceSessionPBDevStatus) GetBattery() uint32 {
+	if m != nil {
+		return m.Battery
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBDevStatus) GetMargin() int32 {
+	if m != nil {
+		return m.Margin
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBDevStatus) GetReceivedAtUnixNs() int64 {
+	if m != nil {
+		return m.ReceivedAtUnixNs
+	}
+	return 0
+}
+
 type DeviceSessionPBUplinkGatewayHistory struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -156,7 +281,7 @@ func (m *DeviceSessionPBUplinkGatewayHistory) Reset()         { *m = DeviceSessi
 func (m *DeviceSessionPBUplinkGatewayHistory) String() string { return proto.CompactTextString(m) }
 func (*DeviceSessionPBUplinkGatewayHistory) ProtoMessage()    {}
 func (*DeviceSessionPBUplinkGatewayHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_958563bbc6ebadf7, []int{2}
+	return fileDescriptor_958563bbc6ebadf7, []int{4}
 }
 
 func (m *DeviceSessionPBUplinkGatewayHistory) XXX_Unmarshal(b []byte) error {
@@ -177,6 +302,194 @@ func (m *DeviceSessionPBUplinkGatewayHistory) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeviceSessionPBUplinkGatewayHistory proto.InternalMessageInfo
 
+type DeviceSessionPBRXInfo struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// RSSI.
+	Rssi int32 `protobuf:"varint,2,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	// LoRa SNR.
+	LoraSnr float64 `protobuf:"fixed64,3,opt,name=lora_snr,json=loraSnr,proto3" json:"lora_snr,omitempty"`
+	// Timestamp when the uplink was received (unix nsec).
+	TimeUnixNs int64 `protobuf:"varint,4,opt,name=time_unix_ns,json=timeUnixNs,proto3" json:"time_unix_ns,omitempty"`
+	// Board of the gateway.
+	Board uint32 `protobuf:"varint,5,opt,name=board,proto3" json:"board,omitempty"`
+	// Antenna of the gateway.
+	Antenna uint32 `protobuf:"varint,6,opt,name=antenna,proto3" json:"antenna,omitempty"`
+	// Time since GPS epoch as reported by the gateway (nsec).
+	TimeSinceGpsEpochNs  int64    `protobuf:"varint,7,opt,name=time_since_gps_epoch_ns,json=timeSinceGpsEpochNs,proto3" json:"time_since_gps_epoch_ns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceSessionPBRXInfo) Reset()         { *m = DeviceSessionPBRXInfo{} }
+func (m *DeviceSessionPBRXInfo) String() string { return proto.CompactTextString(m) }
+func (*DeviceSessionPBRXInfo) ProtoMessage()    {}
+func (*DeviceSessionPBRXInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_958563bbc6ebadf7, []int{5}
+}
+
+func (m *DeviceSessionPBRXInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSessionPBRXInfo.Unmarshal(m, b)
+}
+func (m *DeviceSessionPBRXInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSessionPBRXInfo.Marshal(b, m, deterministic)
+}
+func (m *DeviceSessionPBRXInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSessionPBRXInfo.Merge(m, src)
+}
+func (m *DeviceSessionPBRXInfo) XXX_Size() int {
+	return xxx_messageInfo_DeviceSessionPBRXInfo.Size(m)
+}
+func (m *DeviceSessionPBRXInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSessionPBRXInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceSessionPBRXInfo proto.InternalMessageInfo
+
+func (m *DeviceSessionPBRXInfo) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *DeviceSessionPBRXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBRXInfo) GetLoraSnr() float64 {
+	if m != nil {
+		return m.LoraSnr
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBRXInfo) GetTimeUnixNs() int64 {
+	if m != nil {
+		return m.TimeUnixNs
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBRXInfo) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBRXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBRXInfo) GetTimeSinceGpsEpochNs() int64 {
+	if m != nil {
+		return m.TimeSinceGpsEpochNs
+	}
+	return 0
+}
+
+type DeviceSessionPBTXInfo struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,3,opt,name=dr,proto3" json:"dr,omitempty"`
+	// TX power (dBm).
+	TxPower int32 `protobuf:"varint,4,opt,name=tx_power,json=txPower,proto3" json:"tx_power,omitempty"`
+	// Timestamp when the downlink was sent (unix nsec).
+	TimeUnixNs int64 `protobuf:"varint,5,opt,name=time_unix_ns,json=timeUnixNs,proto3" json:"time_unix_ns,omitempty"`
+	// Frame-counter.
+	FCnt uint32 `protobuf:"varint,6,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Airtime (nsec).
+	AirtimeNs            int64    `protobuf:"varint,7,opt,name=airtime_ns,json=airtimeNs,proto3" json:"airtime_ns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceSessionPBTXInfo) Reset()         { *m = DeviceSessionPBTXInfo{} }
+func (m *DeviceSessionPBTXInfo) String() string { return proto.CompactTextString(m) }
+func (*DeviceSessionPBTXInfo) ProtoMessage()    {}
+func (*DeviceSessionPBTXInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_958563bbc6ebadf7, []int{6}
+}
+
+func (m *DeviceSessionPBTXInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceSessionPBTXInfo.Unmarshal(m, b)
+}
+func (m *DeviceSessionPBTXInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceSessionPBTXInfo.Marshal(b, m, deterministic)
+}
+func (m *DeviceSessionPBTXInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceSessionPBTXInfo.Merge(m, src)
+}
+func (m *DeviceSessionPBTXInfo) XXX_Size() int {
+	return xxx_messageInfo_DeviceSessionPBTXInfo.Size(m)
+}
+func (m *DeviceSessionPBTXInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceSessionPBTXInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceSessionPBTXInfo proto.InternalMessageInfo
+
+func (m *DeviceSessionPBTXInfo) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *DeviceSessionPBTXInfo) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBTXInfo) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBTXInfo) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBTXInfo) GetTimeUnixNs() int64 {
+	if m != nil {
+		return m.TimeUnixNs
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBTXInfo) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBTXInfo) GetAirtimeNs() int64 {
+	if m != nil {
+		return m.AirtimeNs
+	}
+	return 0
+}
+
 type DeviceSessionPB struct {
 	// ID of the device-profile.
 	DeviceProfileId string `protobuf:"bytes,1,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
@@ -284,17 +597,55 @@ type DeviceSessionPB struct {
 	// DownlinkDwellTime.
 	DownlinkDwellTime_400Ms bool `protobuf:"varint,48,opt,name=downlink_dwell_time_400ms,json=downlinkDwellTime400ms,proto3" json:"downlink_dwell_time_400ms,omitempty"`
 	// Uplink max. EIRP index.
-	UplinkMaxEirpIndex   uint32   `protobuf:"varint,49,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UplinkMaxEirpIndex uint32 `protobuf:"varint,49,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
+	// ADR installation margin (dB).
+	// When 0, the global installation margin is used.
+	InstallationMargin float64 `protobuf:"fixed64,50,opt,name=installation_margin,json=installationMargin,proto3" json:"installation_margin,omitempty"`
+	// Last LinkADRReq mac-command and the answer of the device.
+	LastLinkAdrReq *DeviceSessionPBLinkADRReq `protobuf:"bytes,51,opt,name=last_link_adr_req,json=lastLinkAdrReq,proto3" json:"last_link_adr_req,omitempty"`
+	// Last device-status as reported by the device (DevStatusAns).
+	LastDevStatus *DeviceSessionPBDevStatus `protobuf:"bytes,52,opt,name=last_dev_status,json=lastDevStatus,proto3" json:"last_dev_status,omitempty"`
+	// Version of the device-session, incremented on every save.
+	// This is used for optimistic locking.
+	Version uint64 `protobuf:"varint,53,opt,name=version,proto3" json:"version,omitempty"`
+	// Best gateways (sorted by SNR and RSSI) which received the last uplink.
+	LastRxInfoSet []*DeviceSessionPBRXInfo `protobuf:"bytes,54,rep,name=last_rx_info_set,json=lastRxInfoSet,proto3" json:"last_rx_info_set,omitempty"`
+	// Meta-data of the last downlink transmission.
+	LastTxInfo *DeviceSessionPBTXInfo `protobuf:"bytes,55,opt,name=last_tx_info,json=lastTxInfo,proto3" json:"last_tx_info,omitempty"`
+	// RX window preference (0 = auto, 1 = RX1, 2 = RX2).
+	RxWindowPreference uint32 `protobuf:"varint,56,opt,name=rx_window_preference,json=rxWindowPreference,proto3" json:"rx_window_preference,omitempty"`
+	// MIC of the last processed uplink.
+	LastUplinkMic []byte `protobuf:"bytes,57,opt,name=last_uplink_mic,json=lastUplinkMic,proto3" json:"last_uplink_mic,omitempty"`
+	// Number of received uplink retransmissions.
+	UplinkRetransmissionCount uint32 `protobuf:"varint,58,opt,name=uplink_retransmission_count,json=uplinkRetransmissionCount,proto3" json:"uplink_retransmission_count,omitempty"`
+	// ABP frame-counter reset detection.
+	AbpFcntReset bool `protobuf:"varint,59,opt,name=abp_fcnt_reset,json=abpFcntReset,proto3" json:"abp_fcnt_reset,omitempty"`
+	// Max frame-counter gap (0 = band default).
+	MaxFcntGap uint32 `protobuf:"varint,60,opt,name=max_fcnt_gap,json=maxFcntGap,proto3" json:"max_fcnt_gap,omitempty"`
+	// Max data-rate supported by the device (only when max_supported_dr_set).
+	MaxSupportedDr uint32 `protobuf:"varint,61,opt,name=max_supported_dr,json=maxSupportedDr,proto3" json:"max_supported_dr,omitempty"`
+	// Number of consecutive rejected LinkADRReq mac-commands.
+	LinkAdrReqRejectionCount uint32 `protobuf:"varint,62,opt,name=link_adr_req_rejection_count,json=linkAdrReqRejectionCount,proto3" json:"link_adr_req_rejection_count,omitempty"`
+	// The device rejected the channel-mask of a LinkADRReq.
+	ChannelMaskRejected bool `protobuf:"varint,63,opt,name=channel_mask_rejected,json=channelMaskRejected,proto3" json:"channel_mask_rejected,omitempty"`
+	// ADR has been stopped because of too many rejected LinkADRReqs.
+	AdrStopped bool `protobuf:"varint,64,opt,name=adr_stopped,json=adrStopped,proto3" json:"adr_stopped,omitempty"`
+	// Number of received retransmissions of the last uplink.
+	LastUplinkRetransmissions uint32 `protobuf:"varint,65,opt,name=last_uplink_retransmissions,json=lastUplinkRetransmissions,proto3" json:"last_uplink_retransmissions,omitempty"`
+	// The max data-rate supported by the device is set.
+	MaxSupportedDrSet bool `protobuf:"varint,66,opt,name=max_supported_dr_set,json=maxSupportedDrSet,proto3" json:"max_supported_dr_set,omitempty"`
+	// Number of uplinks accepted because frame-counter validation is skipped.
+	SkipFCntValidationCount uint32   `protobuf:"varint,67,opt,name=skip_f_cnt_validation_count,json=skipFCntValidationCount,proto3" json:"skip_f_cnt_validation_count,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *DeviceSessionPB) Reset()         { *m = DeviceSessionPB{} }
 func (m *DeviceSessionPB) String() string { return proto.CompactTextString(m) }
 func (*DeviceSessionPB) ProtoMessage()    {}
 func (*DeviceSessionPB) Descriptor() ([]byte, []int) {
-	return fileDescriptor_958563bbc6ebadf7, []int{3}
+	return fileDescriptor_958563bbc6ebadf7, []int{7}
 }
 
 func (m *DeviceSessionPB) XXX_Unmarshal(b []byte) error {
@@ -637,6 +988,132 @@ func (m *DeviceSessionPB) GetUplinkMaxEirpIndex() uint32 {
 	return 0
 }
 
+func (m *DeviceSessionPB) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetLastLinkAdrReq() *DeviceSessionPBLinkADRReq {
+	if m != nil {
+		return m.LastLinkAdrReq
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetLastDevStatus() *DeviceSessionPBDevStatus {
+	if m != nil {
+		return m.LastDevStatus
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetLastRxInfoSet() []*DeviceSessionPBRXInfo {
+	if m != nil {
+		return m.LastRxInfoSet
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetLastTxInfo() *DeviceSessionPBTXInfo {
+	if m != nil {
+		return m.LastTxInfo
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetRxWindowPreference() uint32 {
+	if m != nil {
+		return m.RxWindowPreference
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetLastUplinkMic() []byte {
+	if m != nil {
+		return m.LastUplinkMic
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetUplinkRetransmissionCount() uint32 {
+	if m != nil {
+		return m.UplinkRetransmissionCount
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetAbpFcntReset() bool {
+	if m != nil {
+		return m.AbpFcntReset
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetMaxFcntGap() uint32 {
+	if m != nil {
+		return m.MaxFcntGap
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetMaxSupportedDr() uint32 {
+	if m != nil {
+		return m.MaxSupportedDr
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetLinkAdrReqRejectionCount() uint32 {
+	if m != nil {
+		return m.LinkAdrReqRejectionCount
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetChannelMaskRejected() bool {
+	if m != nil {
+		return m.ChannelMaskRejected
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetAdrStopped() bool {
+	if m != nil {
+		return m.AdrStopped
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetLastUplinkRetransmissions() uint32 {
+	if m != nil {
+		return m.LastUplinkRetransmissions
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetMaxSupportedDrSet() bool {
+	if m != nil {
+		return m.MaxSupportedDrSet
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetSkipFCntValidationCount() uint32 {
+	if m != nil {
+		return m.SkipFCntValidationCount
+	}
+	return 0
+}
+
 type DeviceGatewayRXInfoSetPB struct {
 	// Device EUI.
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -653,7 +1130,7 @@ func (m *DeviceGatewayRXInfoSetPB) Reset()         { *m = DeviceGatewayRXInfoSet
 func (m *DeviceGatewayRXInfoSetPB) String() string { return proto.CompactTextString(m) }
 func (*DeviceGatewayRXInfoSetPB) ProtoMessage()    {}
 func (*DeviceGatewayRXInfoSetPB) Descriptor() ([]byte, []int) {
-	return fileDescriptor_958563bbc6ebadf7, []int{4}
+	return fileDescriptor_958563bbc6ebadf7, []int{8}
 }
 
 func (m *DeviceGatewayRXInfoSetPB) XXX_Unmarshal(b []byte) error {
@@ -711,7 +1188,7 @@ func (m *DeviceGatewayRXInfoPB) Reset()         { *m = DeviceGatewayRXInfoPB{} }
 func (m *DeviceGatewayRXInfoPB) String() string { return proto.CompactTextString(m) }
 func (*DeviceGatewayRXInfoPB) ProtoMessage()    {}
 func (*DeviceGatewayRXInfoPB) Descriptor() ([]byte, []int) {
-	return fileDescriptor_958563bbc6ebadf7, []int{5}
+	return fileDescriptor_958563bbc6ebadf7, []int{9}
 }
 
 func (m *DeviceGatewayRXInfoPB) XXX_Unmarshal(b []byte) error {
@@ -753,104 +1230,235 @@ func (m *DeviceGatewayRXInfoPB) GetLoraSnr() float64 {
 	return 0
 }
 
+type DeviceLocationPB struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Latitude.
+	Latitude float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	// Longitude.
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Altitude.
+	Altitude float64 `protobuf:"fixed64,4,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	// Accuracy (meters).
+	Accuracy uint32 `protobuf:"varint,5,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	// Timestamp when the location was resolved (unix nsec).
+	ResolvedAtUnixNs     int64    `protobuf:"varint,6,opt,name=resolved_at_unix_ns,json=resolvedAtUnixNs,proto3" json:"resolved_at_unix_ns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceLocationPB) Reset()         { *m = DeviceLocationPB{} }
+func (m *DeviceLocationPB) String() string { return proto.CompactTextString(m) }
+func (*DeviceLocationPB) ProtoMessage()    {}
+func (*DeviceLocationPB) Descriptor() ([]byte, []int) {
+	return fileDescriptor_958563bbc6ebadf7, []int{10}
+}
+
+func (m *DeviceLocationPB) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceLocationPB.Unmarshal(m, b)
+}
+func (m *DeviceLocationPB) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceLocationPB.Marshal(b, m, deterministic)
+}
+func (m *DeviceLocationPB) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceLocationPB.Merge(m, src)
+}
+func (m *DeviceLocationPB) XXX_Size() int {
+	return xxx_messageInfo_DeviceLocationPB.Size(m)
+}
+func (m *DeviceLocationPB) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceLocationPB.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceLocationPB proto.InternalMessageInfo
+
+func (m *DeviceLocationPB) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *DeviceLocationPB) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *DeviceLocationPB) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *DeviceLocationPB) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *DeviceLocationPB) GetAccuracy() uint32 {
+	if m != nil {
+		return m.Accuracy
+	}
+	return 0
+}
+
+func (m *DeviceLocationPB) GetResolvedAtUnixNs() int64 {
+	if m != nil {
+		return m.ResolvedAtUnixNs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*DeviceSessionPBChannel)(nil), "storage.DeviceSessionPBChannel")
 	proto.RegisterType((*DeviceSessionPBUplinkADRHistory)(nil), "storage.DeviceSessionPBUplinkADRHistory")
+	proto.RegisterType((*DeviceSessionPBLinkADRReq)(nil), "storage.DeviceSessionPBLinkADRReq")
+	proto.RegisterType((*DeviceSessionPBDevStatus)(nil), "storage.DeviceSessionPBDevStatus")
 	proto.RegisterType((*DeviceSessionPBUplinkGatewayHistory)(nil), "storage.DeviceSessionPBUplinkGatewayHistory")
+	proto.RegisterType((*DeviceSessionPBRXInfo)(nil), "storage.DeviceSessionPBRXInfo")
+	proto.RegisterType((*DeviceSessionPBTXInfo)(nil), "storage.DeviceSessionPBTXInfo")
 	proto.RegisterType((*DeviceSessionPB)(nil), "storage.DeviceSessionPB")
 	proto.RegisterMapType((map[uint32]*DeviceSessionPBChannel)(nil), "storage.DeviceSessionPB.ExtraUplinkChannelsEntry")
 	proto.RegisterMapType((map[string]*DeviceSessionPBUplinkGatewayHistory)(nil), "storage.DeviceSessionPB.UplinkGatewayHistoryEntry")
 	proto.RegisterType((*DeviceGatewayRXInfoSetPB)(nil), "storage.DeviceGatewayRXInfoSetPB")
 	proto.RegisterType((*DeviceGatewayRXInfoPB)(nil), "storage.DeviceGatewayRXInfoPB")
+	proto.RegisterType((*DeviceLocationPB)(nil), "storage.DeviceLocationPB")
 }
 
 func init() { proto.RegisterFile("device_session.proto", fileDescriptor_958563bbc6ebadf7) }
 
 var fileDescriptor_958563bbc6ebadf7 = []byte{
-	// 1346 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x6d, 0x57, 0x1b, 0xb7,
-	0x12, 0x3e, 0xc6, 0xe1, 0x6d, 0xc0, 0x01, 0xc4, 0x9b, 0xe0, 0xc2, 0xc5, 0x31, 0xc9, 0x8d, 0x6f,
-	0x6e, 0x42, 0x80, 0x9b, 0xf4, 0xa4, 0xf9, 0xd0, 0x53, 0xc0, 0xa4, 0xe5, 0xa4, 0xa1, 0x9c, 0x35,
-	0xc9, 0xe9, 0x37, 0x1d, 0xb1, 0x92, 0x61, 0xeb, 0xb5, 0x76, 0xab, 0xd5, 0xe2, 0xf5, 0x5f, 0xe9,
-	0x8f, 0xea, 0x6f, 0xea, 0xd1, 0x48, 0x06, 0xdb, 0x98, 0x7e, 0xb2, 0x77, 0x9e, 0x67, 0x66, 0xa4,
-	0xd1, 0xcc, 0x23, 0xc1, 0x8a, 0x90, 0xb7, 0x51, 0x28, 0x59, 0x26, 0xb3, 0x2c, 0x4a, 0xd4, 0x5e,
-	0xaa, 0x13, 0x93, 0x90, 0xe9, 0xcc, 0x24, 0x9a, 0x5f, 0xcb, 0xcd, 0x75, 0x9e, 0x46, 0x6f, 0xc3,
-	0xa4, 0xd3, 0x49, 0x94, 0xff, 0x71, 0x8c, 0x9a, 0x80, 0xb5, 0x06, 0x7a, 0x36, 0x9d, 0xe3, 0xc5,
-	0xf1, 0xc9, 0x0d, 0x57, 0x4a, 0xc6, 0x64, 0x0b, 0x66, 0x5b, 0x5a, 0xfe, 0x91, 0x4b, 0x15, 0xf6,
-	0x68, 0xa9, 0x5a, 0xaa, 0x57, 0x82, 0x7b, 0x03, 0x59, 0x85, 0xa9, 0x4e, 0xa4, 0x98, 0xd0, 0x74,
-	0x02, 0xa1, 0xc9, 0x4e, 0xa4, 0x1a, 0x1a, 0xcd, 0xbc, 0xb0, 0xe6, 0xb2, 0x37, 0xf3, 0xa2, 0xa1,
-	0x6b, 0x7f, 0x96, 0x60, 0x67, 0x24, 0xcd, 0xd7, 0x34, 0x8e, 0x54, 0xfb, 0xa8, 0x11, 0xfc, 0x1c,
-	0xd9, 0x45, 0xf6, 0xc8, 0x32, 0x4c, 0xb6, 0x58, 0xa8, 0x8c, 0xcf, 0xf5, 0xa4, 0x75, 0xa2, 0x0c,
-	0x59, 0x87, 0x69, 0x1b, 0x2f, 0x53, 0x2e, 0xcf, 0x44, 0x60, 0xc3, 0x37, 0x95, 0x26, 0xcf, 0xe1,
-	0xa9, 0x29, 0x58, 0x9a, 0x74, 0xa5, 0x66, 0x91, 0x12, 0xb2, 0xf0, 0x09, 0xe7, 0x4d, 0x71, 0x61,
-	0x8d, 0x67, 0xd6, 0x46, 0x76, 0xa1, 0x72, 0xcd, 0x8d, 0xec, 0xf2, 0x1e, 0x0b, 0x93, 0x5c, 0x19,
-	0xfa, 0xc4, 0x91, 0xbc, 0xf1, 0xc4, 0xda, 0x6a, 0x2f, 0x60, 0x77, 0xec, 0xda, 0x7e, 0x72, 0x24,
-	0xbf, 0xbe, 0xda, 0x5f, 0x04, 0x16, 0x46, 0x78, 0xe4, 0x15, 0x2c, 0xf9, 0xba, 0xa7, 0x3a, 0x69,
-	0x45, 0xb1, 0x64, 0x91, 0xc0, 0xf5, 0xcf, 0x06, 0x0b, 0x0e, 0xb8, 0x70, 0xf6, 0x33, 0x41, 0x5e,
-	0x03, 0xc9, 0xa4, 0x1e, 0x25, 0x4f, 0x20, 0x79, 0xd1, 0x23, 0x43, 0x6c, 0x9d, 0xe4, 0x26, 0x52,
-	0xd7, 0x83, 0xec, 0xb2, 0x63, 0x7b, 0xe4, 0x9e, 0xbd, 0x01, 0x33, 0x42, 0xde, 0x32, 0x2e, 0x84,
-	0xc6, 0x2d, 0xce, 0x07, 0xd3, 0x42, 0xde, 0x1e, 0x09, 0xa1, 0x6d, 0x05, 0x2d, 0x24, 0xf3, 0x88,
-	0x4e, 0x22, 0x32, 0x25, 0xe4, 0xed, 0x69, 0x1e, 0x59, 0x9f, 0xdf, 0x93, 0x48, 0x21, 0x32, 0xe5,
-	0x7c, 0xec, 0xb7, 0x85, 0x9e, 0xc3, 0x42, 0x8b, 0xa9, 0x6e, 0x9b, 0x65, 0x2c, 0x52, 0x86, 0xb5,
-	0x65, 0x8f, 0x4e, 0x23, 0x63, 0xae, 0x75, 0xde, 0x6d, 0x37, 0xcf, 0x94, 0xf9, 0x2c, 0x7b, 0x96,
-	0x95, 0x8d, 0xb0, 0x66, 0x1c, 0x2b, 0x1b, 0x60, 0x3d, 0x83, 0x8a, 0xe3, 0x48, 0x15, 0x22, 0x67,
-	0x16, 0x39, 0xa0, 0xba, 0xed, 0xe6, 0xa9, 0x0a, 0x2d, 0xe5, 0x47, 0x20, 0x3c, 0x4d, 0x59, 0x66,
-	0x61, 0x26, 0xd5, 0xad, 0x8c, 0x93, 0x54, 0xd2, 0x37, 0xd5, 0x52, 0x7d, 0xee, 0x70, 0x79, 0xcf,
-	0xb7, 0xeb, 0x67, 0xd9, 0x3b, 0xf5, 0x50, 0xb0, 0xc0, 0xd3, 0xb4, 0x39, 0x60, 0x20, 0x14, 0x66,
-	0xb0, 0x77, 0x58, 0x9e, 0x52, 0xc0, 0x23, 0x9e, 0xb2, 0xed, 0xf3, 0x35, 0x25, 0x3b, 0x30, 0xaf,
-	0x98, 0xc3, 0x44, 0xd2, 0x55, 0x74, 0xce, 0x35, 0xb2, 0xfa, 0x74, 0xa2, 0x4c, 0x23, 0xe9, 0x2a,
-	0x4b, 0xe0, 0x83, 0x84, 0x79, 0x47, 0xe0, 0x77, 0x84, 0x2d, 0x80, 0x30, 0x51, 0x2d, 0xc7, 0xa1,
-	0x2f, 0x11, 0x9e, 0xb1, 0x16, 0xcb, 0x20, 0x2f, 0x61, 0x31, 0x6b, 0x47, 0xa9, 0x8f, 0x10, 0xde,
-	0xc8, 0xb0, 0x4d, 0x2b, 0xd5, 0x52, 0x7d, 0x26, 0xa8, 0x58, 0xbb, 0xe5, 0x9c, 0x58, 0xa3, 0x2d,
-	0xb7, 0x2e, 0x98, 0x90, 0x31, 0xef, 0xd1, 0xa7, 0x18, 0x64, 0x5a, 0x17, 0x0d, 0xfb, 0x49, 0x6a,
-	0x50, 0xd1, 0xc5, 0x01, 0x13, 0x9a, 0x25, 0xad, 0x56, 0x26, 0x0d, 0x5d, 0x40, 0x7c, 0x4e, 0x17,
-	0x07, 0x0d, 0xfd, 0x2b, 0x9a, 0xec, 0x60, 0xe9, 0xe2, 0xd0, 0x0e, 0xd6, 0xa2, 0x1b, 0x2c, 0x5d,
-	0x1c, 0x36, 0xb4, 0x6d, 0x70, 0x6b, 0xbe, 0x1f, 0xd4, 0x25, 0xd7, 0xe0, 0xba, 0x38, 0xfc, 0x74,
-	0x37, 0xab, 0x0f, 0x67, 0x85, 0x8c, 0x99, 0x95, 0xa7, 0x30, 0x21, 0x34, 0x5d, 0x46, 0x64, 0x42,
-	0x68, 0xb2, 0x08, 0x65, 0x2e, 0x34, 0x5d, 0xc1, 0xcd, 0xd8, 0xbf, 0xe4, 0x07, 0xd8, 0xc2, 0x61,
-	0xcc, 0xd3, 0x34, 0xd1, 0x46, 0x0a, 0x36, 0x12, 0x75, 0x15, 0x7d, 0xa9, 0x9d, 0xd0, 0x3e, 0xe5,
-	0x72, 0x30, 0xc3, 0x06, 0xcc, 0xa8, 0x2b, 0x66, 0x34, 0x57, 0x19, 0x5d, 0x77, 0x25, 0x50, 0x57,
-	0x97, 0xf6, 0x93, 0x7c, 0x07, 0xeb, 0x52, 0xf1, 0xab, 0x58, 0x0a, 0x96, 0xe3, 0xf0, 0xb1, 0xd0,
-	0xc9, 0x50, 0x46, 0x69, 0xb5, 0x5c, 0xaf, 0x04, 0xab, 0x1e, 0x76, 0xa3, 0xe9, 0x35, 0x2a, 0x23,
-	0x12, 0x56, 0x65, 0x61, 0x34, 0x7f, 0xe0, 0xb5, 0x51, 0x2d, 0xd7, 0xe7, 0x0e, 0x0f, 0xf6, 0xbc,
-	0x00, 0xee, 0x8d, 0x4c, 0xee, 0xde, 0xa9, 0xf5, 0x1a, 0x0e, 0x76, 0xaa, 0x8c, 0xee, 0x05, 0xcb,
-	0xf2, 0x21, 0x42, 0xde, 0xc2, 0xb2, 0x8f, 0x7c, 0x57, 0xea, 0x48, 0x66, 0x74, 0x13, 0x97, 0x46,
-	0x3c, 0xf4, 0xe9, 0x1e, 0x21, 0xdf, 0x80, 0xf8, 0x15, 0x71, 0xa1, 0xd9, 0x8d, 0x93, 0x10, 0xfa,
-	0x2f, 0x5c, 0x54, 0xfd, 0xb1, 0x45, 0x8d, 0x4a, 0x62, 0xb0, 0xe8, 0x62, 0x1c, 0x09, 0xdd, 0x17,
-	0xc9, 0x1b, 0x58, 0xf3, 0x71, 0xfb, 0xba, 0xd6, 0x8f, 0xbd, 0x85, 0xb1, 0x0f, 0x1f, 0xdd, 0xf0,
-	0x38, 0x4d, 0x73, 0x3b, 0x5e, 0xc9, 0xc7, 0x40, 0x24, 0x80, 0x97, 0x31, 0xcf, 0x0c, 0xeb, 0xdf,
-	0x2b, 0x86, 0x9b, 0x3c, 0x63, 0xb8, 0xc5, 0xcc, 0x30, 0x13, 0x75, 0x24, 0xcb, 0x55, 0x54, 0x30,
-	0x95, 0xd1, 0xed, 0x6a, 0xa9, 0x5e, 0x0e, 0x9e, 0x59, 0xba, 0xcf, 0x8a, 0xe4, 0xc0, 0x71, 0x2f,
-	0xa3, 0x8e, 0xfc, 0xaa, 0xa2, 0xe2, 0x3c, 0x23, 0x67, 0x50, 0x73, 0x31, 0x93, 0xae, 0xc2, 0x4d,
-	0x98, 0x02, 0x23, 0x65, 0x86, 0x77, 0xd2, 0xbb, 0x70, 0x55, 0x0c, 0xb7, 0x8d, 0xe1, 0x3c, 0xf1,
-	0xb2, 0xb8, 0xec, 0xd3, 0x7c, 0xa8, 0x5d, 0xa8, 0x5c, 0x49, 0x1e, 0x26, 0x8a, 0xc5, 0x49, 0xd8,
-	0x96, 0x82, 0x3e, 0xc3, 0x3e, 0x9d, 0x77, 0xc6, 0x5f, 0xd0, 0x46, 0xaa, 0x30, 0x9f, 0x5a, 0x05,
-	0xcd, 0xe2, 0xc4, 0x30, 0x75, 0x45, 0x6b, 0xd8, 0x74, 0x60, 0x6d, 0xcd, 0x38, 0x31, 0xe7, 0x57,
-	0xc3, 0x0c, 0xa1, 0xe9, 0xee, 0x30, 0xa3, 0xa1, 0xc9, 0x1e, 0x2c, 0xdf, 0x33, 0xee, 0xe7, 0xec,
-	0x39, 0x12, 0x97, 0xfa, 0xc4, 0xfb, 0x61, 0xdb, 0x81, 0xb9, 0x0e, 0x0f, 0xd9, 0xad, 0xd4, 0xb6,
-	0xf0, 0xf4, 0x05, 0x2a, 0x36, 0x74, 0x78, 0xf8, 0xcd, 0x59, 0x70, 0x8a, 0x22, 0xf5, 0xf8, 0x14,
-	0xfd, 0xc7, 0x4f, 0x51, 0xa4, 0xc6, 0x4f, 0xd1, 0x3b, 0x58, 0xd3, 0x12, 0x95, 0xbb, 0x7f, 0x18,
-	0x7e, 0x34, 0xe8, 0x6b, 0x2c, 0xc1, 0x8a, 0x43, 0x7d, 0xf5, 0x4f, 0x1d, 0x46, 0x3e, 0xc2, 0xe6,
-	0x88, 0x97, 0x1d, 0x65, 0xbc, 0x14, 0x99, 0xa2, 0x75, 0xcc, 0xb9, 0x36, 0xe4, 0xf9, 0x85, 0x17,
-	0x78, 0x3f, 0x9e, 0x93, 0x0f, 0xb0, 0x31, 0xc6, 0x17, 0x5b, 0x40, 0xd1, 0xff, 0xa2, 0xeb, 0xea,
-	0xa8, 0xab, 0x3d, 0xaf, 0x73, 0xab, 0x3c, 0xde, 0xd3, 0x65, 0xda, 0xa7, 0xaf, 0xbc, 0x3e, 0xa1,
-	0x15, 0xe3, 0xef, 0x93, 0x23, 0xd8, 0x4e, 0xa5, 0x12, 0xb6, 0xca, 0x9e, 0x3d, 0xfc, 0x98, 0xa1,
-	0xff, 0xc3, 0x2b, 0x63, 0xd3, 0x93, 0x02, 0xe4, 0x0c, 0xf5, 0x37, 0x79, 0x03, 0x44, 0xcb, 0x96,
-	0xd4, 0x52, 0x85, 0x92, 0xf1, 0xd8, 0x44, 0x26, 0x17, 0x92, 0xee, 0x55, 0x4b, 0xf5, 0x52, 0xb0,
-	0x74, 0x87, 0x1c, 0x79, 0x80, 0xbc, 0x87, 0x75, 0x3f, 0x46, 0xa2, 0x2b, 0xe3, 0xd8, 0xed, 0xe5,
-	0xdd, 0xfe, 0x7e, 0x27, 0xa3, 0x6f, 0x5d, 0x11, 0x1d, 0xdc, 0xb0, 0xa8, 0xdd, 0x0a, 0x62, 0xe4,
-	0x7b, 0xd8, 0xb8, 0x6b, 0xdd, 0x07, 0x8e, 0xfb, 0xe8, 0xb8, 0xd6, 0x27, 0x8c, 0xb8, 0x1e, 0xc0,
-	0xaa, 0xcf, 0x68, 0x6b, 0x27, 0x23, 0x9d, 0xfa, 0xe3, 0x3e, 0xc0, 0x82, 0x78, 0xb5, 0xf8, 0xc2,
-	0x8b, 0xd3, 0x48, 0xa7, 0x78, 0xd0, 0x9b, 0xd7, 0x40, 0x1f, 0x53, 0x29, 0x2b, 0xce, 0xf6, 0x2e,
-	0x75, 0x4f, 0x25, 0xfb, 0x97, 0xbc, 0x87, 0xc9, 0x5b, 0x1e, 0xe7, 0x12, 0x5f, 0x14, 0x73, 0x87,
-	0x3b, 0x8f, 0x09, 0x81, 0x8f, 0x13, 0x38, 0xf6, 0xc7, 0x89, 0x0f, 0xa5, 0xcd, 0x1c, 0x36, 0x1e,
-	0x55, 0x87, 0xc1, 0x4c, 0xb3, 0x2e, 0xd3, 0xf1, 0x70, 0xa6, 0xd7, 0xff, 0x2c, 0x67, 0xc3, 0x31,
-	0x07, 0xd2, 0xd6, 0x7a, 0x40, 0x9d, 0x87, 0xa7, 0x04, 0xbf, 0x9d, 0xa9, 0x56, 0xd2, 0x94, 0xe6,
-	0xe2, 0x78, 0xf0, 0xd5, 0x52, 0x1a, 0x7a, 0xb5, 0xb8, 0x5b, 0x6a, 0xe2, 0xee, 0x96, 0x7a, 0x07,
-	0x93, 0x91, 0x91, 0x9d, 0x8c, 0x96, 0x51, 0xff, 0xfe, 0x3d, 0xb2, 0x98, 0xa1, 0xd0, 0x17, 0xc7,
-	0x81, 0x23, 0xd7, 0x24, 0xac, 0x8e, 0xc5, 0xc9, 0x36, 0x40, 0x5f, 0x58, 0xfd, 0x4b, 0x6e, 0x3e,
-	0x98, 0xf5, 0x96, 0x33, 0x41, 0x08, 0x3c, 0xd1, 0x59, 0x16, 0x61, 0xfe, 0xc9, 0x00, 0xff, 0xdb,
-	0x5b, 0x2d, 0x4e, 0x34, 0xc7, 0x37, 0x6a, 0x19, 0x1b, 0x6e, 0xda, 0x7e, 0x37, 0x95, 0xbe, 0x9a,
-	0xc2, 0x37, 0xf6, 0xff, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x7c, 0x9f, 0x3a, 0x41, 0x9d, 0x0b,
-	0x00, 0x00,
+	// 2016 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xeb, 0x72, 0xdb, 0xb8,
+	0x15, 0x1e, 0xf9, 0xee, 0x63, 0x39, 0xb6, 0xe1, 0x1b, 0xec, 0x4d, 0x1a, 0x47, 0xc9, 0x6e, 0xdc,
+	0x6d, 0xe2, 0x38, 0xde, 0x64, 0x9b, 0x4d, 0xb7, 0x69, 0x1c, 0xcb, 0x49, 0x3d, 0x1b, 0xbb, 0x1e,
+	0xca, 0x49, 0xfb, 0x0f, 0x03, 0x91, 0x90, 0xcd, 0x8a, 0x02, 0x19, 0x10, 0x92, 0xa8, 0xe9, 0x4c,
+	0x1f, 0xa1, 0x0f, 0xd0, 0x67, 0xea, 0xdf, 0x3e, 0x46, 0xdf, 0xa1, 0x83, 0x03, 0x90, 0xba, 0x58,
+	0xca, 0xf4, 0x47, 0x7f, 0x49, 0x38, 0xe7, 0x3b, 0x17, 0x1c, 0xe0, 0x5c, 0x40, 0xd8, 0x08, 0x44,
+	0x27, 0xf4, 0x05, 0x4b, 0x45, 0x9a, 0x86, 0xb1, 0x3c, 0x48, 0x54, 0xac, 0x63, 0x32, 0x9f, 0xea,
+	0x58, 0xf1, 0x6b, 0xb1, 0xbb, 0xcd, 0x93, 0xf0, 0x99, 0x1f, 0xb7, 0x5a, 0xb1, 0x74, 0x3f, 0x16,
+	0x51, 0x09, 0x60, 0xab, 0x8a, 0x92, 0x35, 0x2b, 0x78, 0xf9, 0xee, 0xe4, 0x86, 0x4b, 0x29, 0x22,
+	0x72, 0x17, 0x16, 0x1b, 0x4a, 0x7c, 0x69, 0x0b, 0xe9, 0xf7, 0x68, 0x69, 0xaf, 0xb4, 0xbf, 0xec,
+	0xf5, 0x09, 0x64, 0x13, 0xe6, 0x5a, 0xa1, 0x64, 0x81, 0xa2, 0x53, 0xc8, 0x9a, 0x6d, 0x85, 0xb2,
+	0xaa, 0x90, 0xcc, 0x33, 0x43, 0x9e, 0x76, 0x64, 0x9e, 0x55, 0x55, 0xe5, 0x9f, 0x25, 0xb8, 0x3f,
+	0x62, 0xe6, 0x53, 0x12, 0x85, 0xb2, 0x79, 0x5c, 0xf5, 0xfe, 0x18, 0x1a, 0x27, 0x7b, 0x64, 0x1d,
+	0x66, 0x1b, 0xcc, 0x97, 0xda, 0xd9, 0x9a, 0x69, 0x9c, 0x48, 0x4d, 0xb6, 0x61, 0xde, 0xe8, 0x4b,
+	0xa5, 0xb5, 0x33, 0xe5, 0x19, 0xf5, 0x35, 0xa9, 0xc8, 0x23, 0xb8, 0xa3, 0x33, 0x96, 0xc4, 0x5d,
+	0xa1, 0x58, 0x28, 0x03, 0x91, 0x39, 0x83, 0x65, 0x9d, 0x5d, 0x1a, 0xe2, 0x99, 0xa1, 0x91, 0x87,
+	0xb0, 0x7c, 0xcd, 0xb5, 0xe8, 0xf2, 0x1e, 0xf3, 0xe3, 0xb6, 0xd4, 0x74, 0xc6, 0x82, 0x1c, 0xf1,
+	0xc4, 0xd0, 0x2a, 0x7f, 0x87, 0x9d, 0x11, 0xdf, 0x3e, 0x5a, 0xcf, 0x3c, 0xf1, 0x85, 0xdc, 0x81,
+	0xa9, 0x40, 0x39, 0x97, 0xa6, 0x82, 0x71, 0x76, 0xa7, 0xc6, 0xd8, 0xdd, 0x81, 0x05, 0x59, 0x67,
+	0x5a, 0x71, 0x99, 0x3a, 0xbf, 0xe6, 0x65, 0xfd, 0xca, 0x2c, 0xc9, 0x2a, 0x4c, 0x73, 0xbf, 0x89,
+	0x8e, 0x2c, 0x78, 0xe6, 0x6f, 0xe5, 0x6f, 0x40, 0x47, 0xec, 0x57, 0x45, 0xa7, 0xa6, 0xb9, 0x6e,
+	0xa7, 0x84, 0xc2, 0x7c, 0x9d, 0x6b, 0x2d, 0x54, 0x7e, 0x04, 0xf9, 0x92, 0x6c, 0x99, 0x48, 0xab,
+	0xeb, 0x50, 0xa2, 0x03, 0xb3, 0x9e, 0x5b, 0x91, 0xa7, 0xb0, 0xae, 0x84, 0x2f, 0xc2, 0x8e, 0x08,
+	0x18, 0xd7, 0xac, 0x2d, 0xc3, 0x8c, 0x39, 0x2f, 0xa6, 0xbd, 0xd5, 0x9c, 0x75, 0xac, 0x3f, 0xc9,
+	0x30, 0xbb, 0x48, 0x2b, 0xdf, 0xc2, 0xc3, 0xb1, 0x07, 0xf3, 0xc1, 0x46, 0xc8, 0x1d, 0x4e, 0xe5,
+	0x3f, 0x25, 0xd8, 0x1c, 0xc1, 0x79, 0x7f, 0x39, 0x93, 0x8d, 0x98, 0xdc, 0x03, 0xc8, 0x43, 0x1c,
+	0x06, 0xe8, 0x64, 0xd9, 0x5b, 0x74, 0x94, 0xb3, 0x80, 0x10, 0x98, 0x51, 0x69, 0x1a, 0x3a, 0x27,
+	0xf1, 0xbf, 0x89, 0x4e, 0x14, 0x2b, 0x8e, 0xa7, 0x6a, 0xfc, 0x2a, 0x79, 0xf3, 0x66, 0x6d, 0x8e,
+	0x75, 0x0f, 0xca, 0x3a, 0x6c, 0x89, 0xc2, 0xed, 0x19, 0x74, 0x1b, 0x0c, 0xcd, 0x3a, 0x4c, 0x36,
+	0x60, 0xb6, 0x1e, 0x73, 0x15, 0xd0, 0x59, 0x7b, 0xc1, 0x70, 0x61, 0xe2, 0xc4, 0xa5, 0x16, 0x52,
+	0x72, 0x3a, 0x67, 0xe3, 0xe4, 0x96, 0xe4, 0x05, 0x6c, 0xa3, 0xc6, 0x34, 0x94, 0xbe, 0x60, 0xd7,
+	0x49, 0xca, 0x44, 0x12, 0xfb, 0x37, 0x46, 0xf9, 0x3c, 0x2a, 0x5f, 0x37, 0xec, 0x9a, 0xe1, 0x7e,
+	0x48, 0xd2, 0x53, 0xc3, 0xbb, 0x48, 0x2b, 0xff, 0xbe, 0xbd, 0xdf, 0xab, 0xff, 0x69, 0xbf, 0x43,
+	0x59, 0x33, 0x35, 0x9a, 0x35, 0xf6, 0x36, 0x4d, 0x17, 0xb7, 0x69, 0x07, 0x16, 0xf2, 0xdb, 0x84,
+	0x5b, 0x9d, 0xf5, 0xe6, 0xdd, 0x3d, 0xba, 0x15, 0x89, 0xd9, 0x5b, 0x91, 0x28, 0x12, 0x66, 0x6e,
+	0x20, 0x61, 0xee, 0x01, 0xf0, 0x50, 0xa1, 0x64, 0xb1, 0xc3, 0x45, 0x47, 0xb9, 0x48, 0x2b, 0xff,
+	0xd8, 0x85, 0x95, 0x91, 0x7d, 0x91, 0xef, 0x61, 0xcd, 0x15, 0x8f, 0x44, 0xc5, 0x8d, 0x30, 0x12,
+	0xf9, 0xc6, 0x16, 0xbd, 0x15, 0xcb, 0xb8, 0xb4, 0xf4, 0xb3, 0x80, 0x3c, 0x01, 0x92, 0x0a, 0x35,
+	0x0a, 0x9e, 0x42, 0xf0, 0xaa, 0xe3, 0x0c, 0xa1, 0x55, 0xdc, 0xd6, 0xa1, 0xbc, 0x1e, 0x44, 0x4f,
+	0x5b, 0xb4, 0xe3, 0xf4, 0xd1, 0x3b, 0xb0, 0x10, 0x88, 0x0e, 0xe3, 0x41, 0x60, 0x83, 0x51, 0xf6,
+	0xe6, 0x03, 0xd1, 0x39, 0x0e, 0x02, 0x65, 0xca, 0x80, 0x61, 0x89, 0x76, 0x88, 0x71, 0x28, 0x7b,
+	0x73, 0x81, 0xe8, 0x9c, 0xb6, 0xf1, 0x2a, 0xfd, 0x35, 0x0e, 0x25, 0x72, 0xe6, 0xac, 0x8c, 0x59,
+	0x1b, 0xd6, 0x23, 0x58, 0x69, 0x30, 0xd9, 0x6d, 0xb2, 0x94, 0x85, 0x52, 0xb3, 0xa6, 0xe8, 0x61,
+	0x38, 0xca, 0xde, 0x52, 0xe3, 0xa2, 0xdb, 0xac, 0x9d, 0x49, 0xfd, 0x8b, 0xe8, 0x19, 0x54, 0x3a,
+	0x82, 0x5a, 0xb0, 0xa8, 0x74, 0x00, 0xf5, 0x00, 0x96, 0x2d, 0x46, 0x48, 0x1f, 0x31, 0x8b, 0x88,
+	0x01, 0xd9, 0x6d, 0xd6, 0x4e, 0xa5, 0x6f, 0x20, 0x6f, 0x81, 0xf0, 0x24, 0x61, 0xa9, 0x61, 0x33,
+	0x21, 0x3b, 0x22, 0x8a, 0x13, 0x41, 0x9f, 0xee, 0x95, 0xf6, 0x97, 0x8e, 0xd6, 0x0f, 0x5c, 0xcd,
+	0xfd, 0x45, 0xf4, 0x4e, 0x1d, 0xcb, 0x5b, 0xe1, 0x49, 0x52, 0x1b, 0x20, 0x10, 0x0a, 0x0b, 0x78,
+	0x9e, 0xac, 0x9d, 0x50, 0xc0, 0x23, 0x9d, 0x33, 0x47, 0xfa, 0x29, 0x21, 0xf7, 0xa1, 0x2c, 0x99,
+	0xe5, 0x05, 0x71, 0x57, 0xd2, 0x25, 0x7b, 0xaf, 0xe4, 0xfb, 0x13, 0xa9, 0xab, 0x71, 0x57, 0x1a,
+	0x00, 0x1f, 0x04, 0x94, 0x2d, 0x80, 0x17, 0x80, 0xbb, 0x00, 0x7e, 0x2c, 0x1b, 0x16, 0x43, 0x1f,
+	0x23, 0x7b, 0xc1, 0x50, 0x0c, 0x82, 0x3c, 0x86, 0xd5, 0xb4, 0x19, 0x26, 0x4e, 0x83, 0x7f, 0x23,
+	0xfc, 0x26, 0x5d, 0xc6, 0x02, 0xb5, 0x6c, 0xe8, 0x06, 0x73, 0x62, 0x88, 0x26, 0xdc, 0x2a, 0x63,
+	0x81, 0x88, 0x78, 0x8f, 0xde, 0xb1, 0x79, 0xa6, 0xb2, 0xaa, 0x59, 0x92, 0x0a, 0x2c, 0xab, 0xec,
+	0x39, 0x0b, 0x14, 0x8b, 0x1b, 0x8d, 0x54, 0x68, 0xba, 0x82, 0xfc, 0x25, 0x95, 0x3d, 0xaf, 0xaa,
+	0x3f, 0x21, 0xc9, 0x74, 0x07, 0x95, 0x1d, 0x99, 0xee, 0xb0, 0x6a, 0x93, 0x57, 0x65, 0x47, 0x55,
+	0x65, 0xaa, 0xb4, 0x21, 0xf7, 0xf3, 0x66, 0xcd, 0x96, 0x54, 0x95, 0x1d, 0xbd, 0xcf, 0x69, 0x63,
+	0x0a, 0x2f, 0x19, 0x53, 0x78, 0x6d, 0x82, 0xad, 0x17, 0x09, 0x66, 0xaa, 0x6d, 0xa0, 0xe8, 0x86,
+	0xab, 0xb6, 0x81, 0x22, 0x6f, 0xe0, 0x2e, 0x76, 0x94, 0x76, 0x92, 0xc4, 0x4a, 0x8b, 0x80, 0x8d,
+	0x68, 0xdd, 0x44, 0x59, 0x6a, 0xda, 0x4c, 0x0e, 0xb9, 0x9a, 0x54, 0xda, 0xb7, 0x87, 0x4b, 0xfb,
+	0x8f, 0xb0, 0x2d, 0x24, 0xaf, 0x47, 0x22, 0x60, 0x6d, 0x2c, 0xa2, 0xcc, 0xb7, 0xbd, 0x34, 0xa5,
+	0x74, 0x6f, 0x7a, 0x7f, 0xd9, 0xdb, 0x74, 0x6c, 0x5b, 0x62, 0x5d, 0xa3, 0x4d, 0x89, 0x80, 0x4d,
+	0x91, 0x69, 0xc5, 0x6f, 0x49, 0xed, 0xec, 0x4d, 0xef, 0x2f, 0x1d, 0x3d, 0x3f, 0x70, 0x5d, 0xfc,
+	0x60, 0x24, 0x73, 0x0f, 0x4e, 0x8d, 0xd4, 0xb0, 0xb2, 0x53, 0xa9, 0x55, 0xcf, 0x5b, 0x17, 0xb7,
+	0x39, 0xe4, 0x19, 0xac, 0x3b, 0xcd, 0x45, 0xa8, 0x43, 0x91, 0xd2, 0x5d, 0x74, 0x8d, 0x38, 0xd6,
+	0xfb, 0x3e, 0x87, 0x7c, 0x06, 0xe2, 0x3c, 0xe2, 0x81, 0x62, 0x37, 0xb6, 0x15, 0xd0, 0x6f, 0xd0,
+	0xa9, 0xfd, 0x49, 0x4e, 0x8d, 0xf6, 0x75, 0x6f, 0xd5, 0xea, 0x38, 0x0e, 0x94, 0xa3, 0x90, 0x1b,
+	0xd8, 0x72, 0x7a, 0xf3, 0x4a, 0x9a, 0xeb, 0xbe, 0x8b, 0xba, 0x8f, 0x26, 0x6e, 0x78, 0x5c, 0x6f,
+	0xb2, 0x3b, 0xde, 0x68, 0x8f, 0x61, 0x11, 0x0f, 0x1e, 0x47, 0x3c, 0xd5, 0x2c, 0x1f, 0x8e, 0xb0,
+	0xa9, 0x32, 0xdc, 0x62, 0xaa, 0xd9, 0x50, 0x7d, 0xbd, 0x87, 0xa5, 0xf2, 0x81, 0x81, 0x3b, 0xab,
+	0x08, 0xf6, 0x2c, 0xf6, 0xaa, 0x5f, 0x76, 0xcf, 0xa0, 0x62, 0x75, 0xc6, 0x5d, 0x89, 0x9b, 0xd0,
+	0x19, 0x6a, 0x4a, 0x35, 0x6f, 0x25, 0x85, 0xba, 0x3d, 0x54, 0x77, 0x0f, 0xd5, 0x39, 0xe0, 0x55,
+	0x76, 0x95, 0xc3, 0x9c, 0xaa, 0x87, 0xb0, 0x5c, 0x17, 0xdc, 0x8f, 0x25, 0x8b, 0x62, 0xbf, 0x29,
+	0x02, 0xfa, 0x00, 0xef, 0x69, 0xd9, 0x12, 0x3f, 0x22, 0xcd, 0x34, 0x82, 0xc4, 0x54, 0xd0, 0x34,
+	0x8a, 0x35, 0x93, 0x75, 0x5a, 0xc1, 0x4b, 0x07, 0x86, 0x56, 0x8b, 0x62, 0x7d, 0x51, 0x1f, 0x46,
+	0x04, 0x8a, 0x3e, 0x1c, 0x46, 0x54, 0x15, 0x39, 0x80, 0xf5, 0x3e, 0xa2, 0x9f, 0x67, 0x8f, 0x10,
+	0xb8, 0x96, 0x03, 0xfb, 0xc9, 0x76, 0x1f, 0x96, 0x5a, 0xdc, 0x67, 0x1d, 0xa1, 0x4c, 0xe0, 0xe9,
+	0xb7, 0x58, 0xb1, 0xa1, 0xc5, 0xfd, 0xcf, 0x96, 0x82, 0x59, 0x14, 0xca, 0xc9, 0x59, 0xf4, 0x9d,
+	0xcb, 0xa2, 0x50, 0x8e, 0xcf, 0xa2, 0x17, 0xb0, 0xa5, 0x04, 0x56, 0xee, 0xfc, 0x30, 0x5c, 0x6a,
+	0xd0, 0x27, 0x18, 0x82, 0x0d, 0xcb, 0x75, 0xd1, 0x3f, 0xb5, 0x3c, 0xf2, 0x1a, 0x76, 0x47, 0xa4,
+	0x4c, 0x2a, 0xe3, 0x64, 0xc7, 0x24, 0xdd, 0x47, 0x9b, 0x5b, 0x43, 0x92, 0xe7, 0x3c, 0xc3, 0x21,
+	0xef, 0x82, 0xbc, 0x82, 0x9d, 0x31, 0xb2, 0xb6, 0x51, 0xd2, 0x5f, 0xa3, 0xe8, 0xe6, 0xa8, 0xa8,
+	0x39, 0xaf, 0x0b, 0x53, 0x79, 0x9c, 0xa4, 0xb5, 0x74, 0x48, 0xbf, 0x77, 0xf5, 0x09, 0xa9, 0xa8,
+	0xff, 0x90, 0x1c, 0xc3, 0xbd, 0x44, 0xc8, 0xc0, 0x44, 0xd9, 0xa1, 0x87, 0x27, 0x72, 0xfa, 0x1b,
+	0x6c, 0x19, 0xbb, 0x0e, 0xe4, 0x21, 0x66, 0xe8, 0x7e, 0x93, 0xa7, 0x40, 0x94, 0x68, 0x08, 0x25,
+	0xcc, 0xa4, 0xc2, 0x23, 0x1d, 0xea, 0x76, 0x20, 0xe8, 0x01, 0x4e, 0x48, 0x6b, 0x05, 0xe7, 0xd8,
+	0x31, 0xc8, 0x4b, 0xd8, 0x76, 0x69, 0x14, 0x74, 0x45, 0x14, 0xd9, 0xbd, 0xbc, 0x38, 0x3c, 0x6c,
+	0xa5, 0xf4, 0x99, 0x0d, 0xa2, 0x65, 0x57, 0x0d, 0xd7, 0x6c, 0x05, 0x79, 0xe4, 0x27, 0xd8, 0x29,
+	0xae, 0xee, 0x2d, 0xc1, 0x43, 0x14, 0xdc, 0xca, 0x01, 0x23, 0xa2, 0xcf, 0x61, 0xd3, 0x59, 0x34,
+	0xb1, 0x13, 0xa1, 0x4a, 0xdc, 0x71, 0x3f, 0xc7, 0x80, 0xb8, 0x6a, 0x71, 0xce, 0xb3, 0xd3, 0x50,
+	0x25, 0xf6, 0xa0, 0x9f, 0xc1, 0x7a, 0x28, 0x53, 0xcd, 0xa3, 0x88, 0xeb, 0x30, 0x96, 0xcc, 0xcd,
+	0xac, 0x47, 0xb8, 0x29, 0x32, 0xc8, 0x3a, 0x47, 0x0e, 0x39, 0x87, 0x35, 0x4c, 0xaf, 0xa2, 0xee,
+	0x28, 0xf1, 0x85, 0xfe, 0x80, 0x6d, 0xb4, 0x32, 0xa9, 0x2e, 0xf4, 0xe7, 0x75, 0xef, 0x8e, 0x11,
+	0xfe, 0x68, 0xeb, 0x8d, 0x99, 0xdf, 0xcf, 0x60, 0x25, 0xaf, 0x00, 0x2e, 0xfd, 0xe9, 0x0b, 0x54,
+	0xf6, 0x60, 0x92, 0xb2, 0x62, 0xf8, 0xf6, 0x96, 0x5d, 0x31, 0xe8, 0xcf, 0xe2, 0x79, 0x42, 0xbc,
+	0xdc, 0x2b, 0xed, 0xcf, 0x78, 0xf9, 0x92, 0x7c, 0x80, 0x55, 0x34, 0xa2, 0x32, 0x16, 0xca, 0x46,
+	0xcc, 0x4c, 0xfb, 0xfb, 0x11, 0x4b, 0xd9, 0xaf, 0x26, 0x59, 0xb1, 0xd3, 0xb3, 0x35, 0xe1, 0x65,
+	0xe6, 0x7f, 0x4d, 0x68, 0xf2, 0x16, 0xca, 0xa8, 0x48, 0x5b, 0x45, 0xf4, 0xb7, 0x7b, 0xa5, 0xaf,
+	0x29, 0xb1, 0x23, 0xa9, 0x07, 0x46, 0xe6, 0x0a, 0x95, 0x90, 0x43, 0xd8, 0x50, 0x19, 0xeb, 0x86,
+	0x32, 0x88, 0xbb, 0x2c, 0x29, 0x2e, 0x0d, 0x7d, 0x65, 0x4f, 0x48, 0x65, 0x7f, 0x46, 0xd6, 0x65,
+	0xc1, 0x21, 0xdf, 0xb9, 0x08, 0xe5, 0x27, 0x1b, 0xfa, 0xf4, 0x27, 0xbc, 0xaa, 0xe8, 0x9b, 0xad,
+	0xb8, 0xe7, 0xa1, 0x4f, 0xde, 0xc0, 0x37, 0x0e, 0xa2, 0x04, 0xb6, 0xbf, 0x56, 0x88, 0x6e, 0xb8,
+	0x97, 0xd5, 0x6b, 0x34, 0xb0, 0x63, 0x21, 0xde, 0x10, 0x02, 0x33, 0xc4, 0xa4, 0x11, 0xaf, 0x27,
+	0xac, 0x61, 0x46, 0x0c, 0x25, 0x4c, 0x88, 0x7e, 0x67, 0xab, 0x1d, 0xaf, 0x27, 0xef, 0x7d, 0xa9,
+	0x3d, 0x43, 0x33, 0xb5, 0xcc, 0xdc, 0x2d, 0x44, 0x5d, 0xf3, 0x84, 0xfe, 0x6c, 0x6b, 0x59, 0x8b,
+	0x67, 0x06, 0xf3, 0x81, 0x27, 0x64, 0x1f, 0x56, 0x87, 0x1b, 0x78, 0xa0, 0xe8, 0xef, 0x11, 0x75,
+	0x67, 0xb0, 0x69, 0x57, 0xb1, 0xd5, 0x0f, 0xde, 0x22, 0x93, 0x97, 0xc2, 0xd7, 0x7d, 0x97, 0xdf,
+	0xa0, 0x14, 0x8d, 0x8a, 0xdb, 0xe2, 0xe5, 0x00, 0xeb, 0xf1, 0x11, 0x6c, 0xe6, 0x0d, 0xb3, 0xc5,
+	0xd3, 0xa6, 0x93, 0x17, 0x01, 0xfd, 0x03, 0x3a, 0x9e, 0x77, 0xd3, 0x73, 0x9e, 0x36, 0x3d, 0xc7,
+	0x32, 0x95, 0xd3, 0x98, 0x4b, 0x75, 0x9c, 0x24, 0x22, 0xa0, 0x6f, 0x11, 0x09, 0x3c, 0x50, 0x35,
+	0x4b, 0x31, 0x61, 0x1c, 0x0c, 0xf7, 0x70, 0x2c, 0x53, 0x7a, 0x6c, 0xc3, 0xd8, 0x0f, 0xfd, 0x70,
+	0x28, 0x4d, 0x17, 0xdf, 0x18, 0xdd, 0x3e, 0xde, 0xb7, 0x77, 0x68, 0x69, 0x6d, 0x38, 0x04, 0xe6,
+	0x4e, 0xfd, 0x0c, 0xdf, 0x0c, 0x0c, 0x77, 0x1d, 0x1e, 0x85, 0x01, 0x1f, 0x08, 0xc2, 0x09, 0x1a,
+	0xdc, 0xce, 0xe7, 0xbc, 0xcf, 0x05, 0x1f, 0x63, 0xb0, 0x7b, 0x0d, 0x74, 0xd2, 0x94, 0x61, 0x86,
+	0x2b, 0x33, 0x0b, 0xdb, 0x87, 0xa9, 0xf9, 0x4b, 0x5e, 0xc2, 0x6c, 0x87, 0x47, 0x6d, 0x81, 0x2f,
+	0x82, 0xa5, 0xa3, 0xfb, 0x93, 0x2e, 0xae, 0xd3, 0xe3, 0x59, 0xf4, 0xeb, 0xa9, 0x57, 0xa5, 0xdd,
+	0x36, 0xec, 0x4c, 0xec, 0xee, 0x83, 0x96, 0x16, 0xad, 0xa5, 0x77, 0xc3, 0x96, 0x9e, 0x7c, 0x7d,
+	0x1c, 0x19, 0xd6, 0x39, 0x60, 0xb6, 0xd2, 0xcb, 0x1f, 0xdf, 0x0e, 0x62, 0xf3, 0xb2, 0x26, 0xf4,
+	0xe5, 0xbb, 0xc1, 0x57, 0x47, 0x69, 0xe8, 0xd5, 0x61, 0xa7, 0xcc, 0xa9, 0x62, 0xca, 0x7c, 0x01,
+	0xb3, 0xa1, 0x16, 0x2d, 0xf3, 0xca, 0x1e, 0x97, 0xf4, 0x43, 0xaa, 0x2f, 0xdf, 0x79, 0x16, 0x5c,
+	0x11, 0xb0, 0x39, 0x96, 0xff, 0xff, 0x7d, 0x52, 0x57, 0xfe, 0x55, 0x82, 0x55, 0x6b, 0xe7, 0x63,
+	0xec, 0xe3, 0xc9, 0x7e, 0x6d, 0x6b, 0xbb, 0xb0, 0x10, 0x71, 0xdb, 0x60, 0xd0, 0x40, 0xc9, 0x2b,
+	0xd6, 0xe6, 0x6d, 0x1b, 0xc5, 0xf2, 0xda, 0x32, 0xad, 0x95, 0x3e, 0xc1, 0x48, 0x16, 0x3d, 0x6b,
+	0xc6, 0x4a, 0xe6, 0x6b, 0xe4, 0xf9, 0x7e, 0x5b, 0x71, 0xbf, 0xe7, 0xde, 0xed, 0xc5, 0xda, 0x7e,
+	0xb0, 0x48, 0xe3, 0x68, 0xe4, 0x83, 0xc5, 0x5c, 0xfe, 0xc1, 0xc2, 0xb2, 0xf2, 0x0f, 0x16, 0xf5,
+	0x39, 0xfc, 0x6e, 0xf5, 0xc3, 0x7f, 0x07, 0x00, 0x1d, 0xf9, 0xa1, 0xd8, 0xf1, 0x12, 0x00, 0x00,
 }
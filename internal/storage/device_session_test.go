@@ -3,10 +3,13 @@ package storage
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/gomodule/redigo/redis"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/require"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
@@ -40,6 +43,27 @@ func TestGetRandomDevAddr(t *testing.T) {
 			}
 		})
 	})
+
+	Convey("Given a Redis database and NetID E00000 (type 7) with all DevAddrs in use", t, func() {
+		test.MustFlushRedis(RedisPool())
+		netID := lorawan.NetID{0xe0, 0x00, 0x00}
+
+		c := RedisPool().Get()
+		defer c.Close()
+
+		// NetID type 7 leaves 7 bits for the NwkAddr
+		for i := 0; i < 128; i++ {
+			devAddr := lorawan.DevAddr{0, 0, 0, byte(i)}
+			devAddr.SetAddrPrefix(netID)
+			_, err := c.Do("SADD", fmt.Sprintf(devAddrKeyTempl, devAddr), []byte{1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+		}
+
+		Convey("Then GetRandomDevAddr returns ErrDevAddrSpaceExhausted", func() {
+			_, err := GetRandomDevAddr(RedisPool(), netID)
+			So(err, ShouldEqual, ErrDevAddrSpaceExhausted)
+		})
+	})
 }
 
 func TestUplinkHistory(t *testing.T) {
@@ -90,6 +114,213 @@ func TestUplinkHistory(t *testing.T) {
 	})
 }
 
+func TestValidateAndGetFullFCntUpMaxFCntGap(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(band.Setup(test.GetConfig()))
+	defaults := band.Band().GetDefaults()
+
+	s := DeviceSession{FCntUp: 10}
+	assert.Equal(defaults.MaxFCntGap, s.GetMaxFCntGap())
+
+	_, ok := ValidateAndGetFullFCntUp(s, 10+defaults.MaxFCntGap)
+	assert.False(ok)
+
+	s.MaxFCntGap = defaults.MaxFCntGap * 2
+	assert.Equal(defaults.MaxFCntGap*2, s.GetMaxFCntGap())
+
+	fCnt, ok := ValidateAndGetFullFCntUp(s, 10+defaults.MaxFCntGap)
+	assert.True(ok)
+	assert.Equal(10+defaults.MaxFCntGap, fCnt)
+
+	s.MaxFCntGap = 10
+	_, ok = ValidateAndGetFullFCntUp(s, 20)
+	assert.False(ok)
+}
+
+func TestIsUplinkRetransmission(t *testing.T) {
+	mic := lorawan.MIC{1, 2, 3, 4}
+
+	tests := []struct {
+		Name          string
+		DeviceSession DeviceSession
+		FCnt          uint32
+		MIC           lorawan.MIC
+		Expected      bool
+	}{
+		{
+			Name:          "same FCnt and MIC",
+			DeviceSession: DeviceSession{FCntUp: 11, LastUplinkMIC: mic},
+			FCnt:          10,
+			MIC:           mic,
+			Expected:      true,
+		},
+		{
+			Name:          "same 16 bit FCnt and MIC",
+			DeviceSession: DeviceSession{FCntUp: 65537, LastUplinkMIC: mic},
+			FCnt:          0,
+			MIC:           mic,
+			Expected:      true,
+		},
+		{
+			Name:          "next FCnt",
+			DeviceSession: DeviceSession{FCntUp: 11, LastUplinkMIC: mic},
+			FCnt:          11,
+			MIC:           mic,
+		},
+		{
+			Name:          "same FCnt, different MIC",
+			DeviceSession: DeviceSession{FCntUp: 11, LastUplinkMIC: mic},
+			FCnt:          10,
+			MIC:           lorawan.MIC{4, 3, 2, 1},
+		},
+		{
+			Name:          "no previous uplink",
+			DeviceSession: DeviceSession{},
+			FCnt:          65535,
+		},
+		{
+			Name:          "LoRaWAN 1.1 same FCnt and FNwkSIntKey part of MIC",
+			DeviceSession: DeviceSession{FCntUp: 11, LastUplinkMIC: mic, MACVersion: "1.1.0"},
+			FCnt:          10,
+			MIC:           lorawan.MIC{5, 6, 3, 4},
+			Expected:      true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			phy := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						FCnt: tst.FCnt,
+					},
+				},
+				MIC: tst.MIC,
+			}
+
+			assert.Equal(tst.Expected, IsUplinkRetransmission(tst.DeviceSession, phy))
+		})
+	}
+}
+
+func TestIsExpectedUplinkRetransmission(t *testing.T) {
+	tests := []struct {
+		Name            string
+		NbTrans         uint8
+		Retransmissions int
+		Expected        bool
+	}{
+		{"nb_trans 1", 1, 1, false},
+		{"nb_trans 2, first retransmission", 2, 1, true},
+		{"nb_trans 2, second retransmission", 2, 2, false},
+		{"nb_trans 3, second retransmission", 3, 2, true},
+		{"nb_trans not set", 0, 1, false},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			ds := DeviceSession{
+				NbTrans:                   tst.NbTrans,
+				LastUplinkRetransmissions: tst.Retransmissions,
+			}
+			assert.Equal(tst.Expected, ds.IsExpectedUplinkRetransmission())
+		})
+	}
+}
+
+func TestIsExpectedConfirmedUplinkRetransmission(t *testing.T) {
+	tests := []struct {
+		Name            string
+		NbTrans         uint8
+		Retransmissions int
+		Expected        bool
+	}{
+		{"nb_trans 1, first retransmission", 1, 1, true},
+		{"nb_trans 1, seventh retransmission", 1, 7, true},
+		{"nb_trans 1, eighth retransmission", 1, 8, false},
+		{"nb_trans not set, first retransmission", 0, 1, true},
+		{"nb_trans 15, tenth retransmission", 15, 10, true},
+		{"nb_trans 15, fifteenth retransmission", 15, 15, false},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			ds := DeviceSession{
+				NbTrans:                   tst.NbTrans,
+				LastUplinkRetransmissions: tst.Retransmissions,
+			}
+			assert.Equal(tst.Expected, ds.IsExpectedConfirmedUplinkRetransmission())
+		})
+	}
+}
+
+func TestLastRXInfoSet(t *testing.T) {
+	Convey("Given an empty device-session", t, func() {
+		s := DeviceSession{
+			UplinkGatewayHistory: map[lorawan.EUI64]UplinkGatewayHistory{
+				{1, 1, 1, 1, 1, 1, 1, 1}: {},
+			},
+		}
+
+		Convey("Then GetDownlinkGatewayMAC falls back to the uplink gateway-history", func() {
+			id, err := s.GetDownlinkGatewayMAC()
+			So(err, ShouldBeNil)
+			So(id, ShouldEqual, lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1})
+		})
+
+		Convey("When calling SetLastRXInfoSet with four gateways", func() {
+			s.SetLastRXInfoSet([]DeviceSessionRXInfo{
+				{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, RSSI: -100, LoRaSNR: 1},
+				{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RSSI: -60, LoRaSNR: 5},
+				{GatewayID: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, RSSI: -50, LoRaSNR: 5},
+				{GatewayID: lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}, RSSI: -120, LoRaSNR: -10, Time: time.Unix(1000, 0)},
+			})
+
+			Convey("Then the best three gateways are stored, sorted by SNR and RSSI", func() {
+				So(s.LastRXInfoSet, ShouldHaveLength, 3)
+				So(s.LastRXInfoSet[0].GatewayID, ShouldEqual, lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3})
+				So(s.LastRXInfoSet[1].GatewayID, ShouldEqual, lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2})
+				So(s.LastRXInfoSet[2].GatewayID, ShouldEqual, lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1})
+			})
+
+			Convey("Then GetDownlinkGatewayMAC returns the best gateway", func() {
+				id, err := s.GetDownlinkGatewayMAC()
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3})
+			})
+		})
+
+		Convey("When converting the device-session with rx and tx info to and from protobuf", func() {
+			s.LastRXInfoSet = []DeviceSessionRXInfo{
+				{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Board: 1, Antenna: 2, RSSI: -100, LoRaSNR: 1.5, Time: time.Unix(1000, 0), TimeSinceGPSEpoch: 10 * time.Second},
+			}
+			s.LastTXInfo = &DeviceSessionTXInfo{
+				GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+				Frequency: 869525000,
+				DR:        3,
+				TXPower:   14,
+				FCnt:      10,
+				Airtime:   41 * time.Millisecond,
+				Time:      time.Unix(1001, 0),
+			}
+
+			out := deviceSessionFromPB(deviceSessionToPB(s))
+
+			Convey("Then the rx and tx info are preserved", func() {
+				So(out.LastRXInfoSet, ShouldResemble, s.LastRXInfoSet)
+				So(out.LastTXInfo, ShouldResemble, s.LastTXInfo)
+			})
+		})
+	})
+}
+
 func TestDeviceSession(t *testing.T) {
 	conf := test.GetConfig()
 	if err := Setup(conf); err != nil {
@@ -136,6 +367,12 @@ func TestDeviceSession(t *testing.T) {
 					So(DeleteDeviceSession(RedisPool(), s.DevEUI), ShouldBeNil)
 					So(DeleteDeviceSession(RedisPool(), s.DevEUI), ShouldEqual, ErrDoesNotExist)
 
+					c := RedisPool().Get()
+					defer c.Close()
+					count, err := redis.Int(c.Do("SCARD", fmt.Sprintf(devAddrKeyTempl, s.DevAddr)))
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+
 				})
 			})
 
@@ -157,6 +394,8 @@ func TestDeviceSession(t *testing.T) {
 					{65535, defaults.MaxFCntGap, 0, false},                                  // roll-over happened, but too many lost frames
 					{65535, 0, 65536, true},                                                 // roll-over happened
 					{65536, 0, 65536, true},                                                 // re-transmission
+					{65535, 65535, 65535, true},                                             // re-transmission before roll-over
+					{131071, 1, 131073, true},                                               // second roll-over happened
 					{4294967295, 0, 0, true},                                                // 32 bit roll-over happened, counter started at 0 again
 				}
 
@@ -227,14 +466,15 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 
 		Convey("Given a set of tests", func() {
 			testTable := []struct {
-				Name           string
-				DevAddr        lorawan.DevAddr
-				SNwkSIntKey    lorawan.AES128Key
-				FNwkSIntKey    lorawan.AES128Key
-				FCnt           uint32
-				ExpectedDevEUI lorawan.EUI64
-				ExpectedFCntUp uint32
-				ExpectedError  error
+				Name                            string
+				DevAddr                         lorawan.DevAddr
+				SNwkSIntKey                     lorawan.AES128Key
+				FNwkSIntKey                     lorawan.AES128Key
+				FCnt                            uint32
+				ExpectedDevEUI                  lorawan.EUI64
+				ExpectedFCntUp                  uint32
+				ExpectedSkipFCntValidationCount uint32
+				ExpectedError                   error
 			}{
 				{
 					Name:           "matching DevEUI 0101010101010101",
@@ -255,13 +495,14 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 					ExpectedDevEUI: deviceSessions[1].DevEUI,
 				},
 				{
-					Name:           "matching DevEUI 0101010101010101 with frame counter reset",
-					DevAddr:        devAddr,
-					FNwkSIntKey:    deviceSessions[0].FNwkSIntKey,
-					SNwkSIntKey:    deviceSessions[0].SNwkSIntKey,
-					FCnt:           0,
-					ExpectedFCntUp: 0, // has been reset
-					ExpectedDevEUI: deviceSessions[0].DevEUI,
+					Name:                            "matching DevEUI 0101010101010101 with frame counter reset",
+					DevAddr:                         devAddr,
+					FNwkSIntKey:                     deviceSessions[0].FNwkSIntKey,
+					SNwkSIntKey:                     deviceSessions[0].SNwkSIntKey,
+					FCnt:                            0,
+					ExpectedFCntUp:                  0, // has been reset
+					ExpectedDevEUI:                  deviceSessions[0].DevEUI,
+					ExpectedSkipFCntValidationCount: 1,
 				},
 				{
 					Name:          "matching DevEUI 0202020202020202 with invalid frame counter",
@@ -324,6 +565,7 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 					So(err, ShouldBeNil)
 					So(s.DevEUI, ShouldResemble, test.ExpectedDevEUI)
 					So(s.FCntUp, ShouldEqual, test.ExpectedFCntUp)
+					So(s.SkipFCntValidationCount, ShouldEqual, test.ExpectedSkipFCntValidationCount)
 				})
 			}
 		})
@@ -383,3 +625,204 @@ func (ts *StorageTestSuite) TestDeviceGatewayRXInfoSet() {
 		})
 	})
 }
+
+func (ts *StorageTestSuite) TestGetDeviceSessionCount() {
+	assert := require.New(ts.T())
+
+	for _, devEUI := range []lorawan.EUI64{{1, 2, 3, 4, 5, 6, 7, 8}, {8, 7, 6, 5, 4, 3, 2, 1}} {
+		assert.NoError(SaveDeviceSession(ts.RedisPool(), DeviceSession{DevEUI: devEUI}))
+	}
+
+	// other device keys must not be counted
+	assert.NoError(SaveDeviceGatewayRXInfoSet(ts.RedisPool(), DeviceGatewayRXInfoSet{
+		DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+	}))
+
+	count, err := GetDeviceSessionCount(ts.RedisPool())
+	assert.NoError(err)
+	assert.Equal(2, count)
+}
+
+func (ts *StorageTestSuite) TestSaveDeviceSessionIfUnchanged() {
+	assert := require.New(ts.T())
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	assert.NoError(SaveDeviceSession(ts.RedisPool(), DeviceSession{DevEUI: devEUI}))
+
+	// both processes read the same device-session
+	ds1, err := GetDeviceSession(ts.RedisPool(), devEUI)
+	assert.NoError(err)
+	ds2, err := GetDeviceSession(ts.RedisPool(), devEUI)
+	assert.NoError(err)
+
+	ts.T().Run("first save succeeds", func(t *testing.T) {
+		assert := require.New(t)
+		ds1.FCntUp = 10
+		assert.NoError(SaveDeviceSessionIfUnchanged(ts.RedisPool(), &ds1))
+		assert.EqualValues(2, ds1.Version)
+	})
+
+	ts.T().Run("second save fails", func(t *testing.T) {
+		assert := require.New(t)
+		ds2.FCntUp = 11
+		assert.Equal(ErrDeviceSessionChanged, SaveDeviceSessionIfUnchanged(ts.RedisPool(), &ds2))
+
+		ds, err := GetDeviceSession(ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.EqualValues(10, ds.FCntUp)
+	})
+
+	ts.T().Run("save after re-read succeeds", func(t *testing.T) {
+		assert := require.New(t)
+		ds1.FCntUp = 12
+		assert.NoError(SaveDeviceSessionIfUnchanged(ts.RedisPool(), &ds1))
+		assert.EqualValues(3, ds1.Version)
+	})
+
+	ts.T().Run("save fails after unconditional save", func(t *testing.T) {
+		assert := require.New(t)
+
+		ds, err := GetDeviceSession(ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.EqualValues(3, ds.Version)
+
+		ds.FCntUp = 13
+		assert.NoError(SaveDeviceSession(ts.RedisPool(), ds))
+
+		ds, err = GetDeviceSession(ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.EqualValues(4, ds.Version)
+
+		ds1.FCntUp = 14
+		assert.Equal(ErrDeviceSessionChanged, SaveDeviceSessionIfUnchanged(ts.RedisPool(), &ds1))
+		ds1 = ds
+	})
+
+	ts.T().Run("expired device-session is re-created", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(DeleteDeviceSession(ts.RedisPool(), devEUI))
+		assert.NoError(SaveDeviceSessionIfUnchanged(ts.RedisPool(), &ds1))
+		assert.EqualValues(5, ds1.Version)
+	})
+}
+
+func (ts *StorageTestSuite) TestGetUplinkValidationError() {
+	assert := require.New(ts.T())
+
+	ds := DeviceSession{
+		DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+		DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		SNwkSIntKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		FNwkSIntKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		FCntUp:      10,
+	}
+	assert.NoError(SaveDeviceSession(ts.RedisPool(), ds))
+
+	tests := []struct {
+		Name          string
+		DevAddr       lorawan.DevAddr
+		Key           lorawan.AES128Key
+		FCnt          uint32
+		ExpectedError *UplinkValidationError
+	}{
+		{
+			Name:    "invalid MIC",
+			DevAddr: ds.DevAddr,
+			Key:     lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+			FCnt:    10,
+			ExpectedError: &UplinkValidationError{
+				Type:         as.ErrorType_DATA_UP_MIC,
+				FCnt:         10,
+				ExpectedFCnt: 10,
+			},
+		},
+		{
+			Name:    "re-transmission",
+			DevAddr: ds.DevAddr,
+			Key:     ds.FNwkSIntKey,
+			FCnt:    9,
+			ExpectedError: &UplinkValidationError{
+				Type:         as.ErrorType_DATA_UP_FCNT_RETRANSMISSION,
+				FCnt:         9,
+				ExpectedFCnt: 10,
+			},
+		},
+		{
+			Name:    "frame-counter gap too large",
+			DevAddr: ds.DevAddr,
+			Key:     ds.FNwkSIntKey,
+			FCnt:    10 + band.Band().GetDefaults().MaxFCntGap,
+			ExpectedError: &UplinkValidationError{
+				Type:         as.ErrorType_DATA_UP_FCNT_GAP_TOO_LARGE,
+				FCnt:         10 + band.Band().GetDefaults().MaxFCntGap,
+				ExpectedFCnt: 10,
+			},
+		},
+		{
+			Name:    "frame-counter reset",
+			DevAddr: ds.DevAddr,
+			Key:     ds.FNwkSIntKey,
+			FCnt:    0,
+			ExpectedError: &UplinkValidationError{
+				Type:         as.ErrorType_DATA_UP_FCNT_RESET,
+				FCnt:         0,
+				ExpectedFCnt: 10,
+			},
+		},
+		{
+			Name:    "replayed uplink",
+			DevAddr: ds.DevAddr,
+			Key:     ds.FNwkSIntKey,
+			FCnt:    8,
+			ExpectedError: &UplinkValidationError{
+				Type:         as.ErrorType_DATA_UP_FCNT,
+				FCnt:         8,
+				ExpectedFCnt: 10,
+			},
+		},
+		{
+			Name:    "invalid frame-counter and MIC",
+			DevAddr: ds.DevAddr,
+			Key:     lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+			FCnt:    0,
+		},
+		{
+			Name:    "unknown DevAddr",
+			DevAddr: lorawan.DevAddr{4, 3, 2, 1},
+			Key:     ds.FNwkSIntKey,
+			FCnt:    10,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			phy := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: tst.DevAddr,
+						FCnt:    tst.FCnt,
+					},
+				},
+			}
+			assert.NoError(phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, tst.Key, tst.Key))
+
+			uErr, err := GetUplinkValidationError(ts.RedisPool(), phy, 0, 0)
+			assert.NoError(err)
+			if tst.ExpectedError == nil {
+				assert.Nil(uErr)
+				return
+			}
+			assert.NotNil(uErr)
+			assert.Equal(ds.DevEUI, uErr.DeviceSession.DevEUI)
+			assert.Equal(tst.ExpectedError.Type, uErr.Type)
+			assert.Equal(tst.ExpectedError.FCnt, uErr.FCnt)
+			assert.Equal(tst.ExpectedError.ExpectedFCnt, uErr.ExpectedFCnt)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package storage
 
 import (
+	"bytes"
+	"encoding/gob"
 	"fmt"
 	"time"
 
+	"github.com/gofrs/uuid"
 	proto "github.com/golang/protobuf/proto"
 	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
@@ -16,6 +19,15 @@ import (
 const downlinkFramesTTL = time.Second * 10
 const downlinkFramesKeyTempl = "lora:ns:frames:%d"
 const downlinkFramesDevEUIKeyTempl = "lora:ns:frames:deveui:%d"
+const downlinkFramesStatusKeyTempl = "lora:ns:frames:status:%d"
+
+// DownlinkFrameStatus holds the device-queue item sent by the downlink-frame
+// of which the application-server must be notified once the gateway
+// acknowledged the transmission.
+type DownlinkFrameStatus struct {
+	RoutingProfileID uuid.UUID
+	DeviceQueueItem  DeviceQueueItem
+}
 
 // SaveDownlinkFrames saves the given downlink-frames. The downlink-frames
 // must share the same token!
@@ -97,3 +109,54 @@ func PopDownlinkFrame(p *redis.Pool, token uint32) (lorawan.EUI64, gw.DownlinkFr
 
 	return devEUI, out, nil
 }
+
+// SaveDownlinkFrameStatus saves the given downlink-frame status for the
+// given token.
+func SaveDownlinkFrameStatus(p *redis.Pool, token uint32, status DownlinkFrameStatus) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(status); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	exp := int64(downlinkFramesTTL) / int64(time.Millisecond)
+	if _, err := c.Do("PSETEX", fmt.Sprintf(downlinkFramesStatusKeyTempl, token), exp, buf.Bytes()); err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return nil
+}
+
+// PopDownlinkFrameStatus returns and deletes the downlink-frame status for
+// the given token. It returns ErrDoesNotExist when there is no status.
+func PopDownlinkFrameStatus(p *redis.Pool, token uint32) (DownlinkFrameStatus, error) {
+	var status DownlinkFrameStatus
+	key := fmt.Sprintf(downlinkFramesStatusKeyTempl, token)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("GET", key)
+	c.Send("DEL", key)
+	vals, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return status, errors.Wrap(err, "exec error")
+	}
+
+	b, err := redis.Bytes(vals[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return status, ErrDoesNotExist
+		}
+		return status, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&status); err != nil {
+		return status, errors.Wrap(err, "gob decode error")
+	}
+
+	return status, nil
+}
@@ -13,8 +13,14 @@ var (
 	ErrDoesNotExist                   = errors.New("object does not exist")
 	ErrDoesNotExistOrFCntOrMICInvalid = errors.New("device-session does not exist or invalid fcnt or mic")
 	ErrInvalidAggregationInterval     = errors.New("invalid aggregation interval")
+	ErrMetricsRangeExceedsTTL         = errors.New("metrics range exceeds the hour aggregation ttl")
 	ErrInvalidName                    = errors.New("invalid gateway name")
 	ErrInvalidFPort                   = errors.New("invalid fPort (must be > 0)")
+	ErrDeviceQueueReferenceTooLong    = errors.New("device-queue item reference must not exceed 100 characters")
+	ErrDevAddrSpaceExhausted          = errors.New("no free DevAddr available")
+	ErrGatewayTagKeyTooLong           = errors.New("gateway tag key must not exceed 64 bytes")
+	ErrGatewayTooManyTags             = errors.New("gateway must not have more than 32 tags")
+	ErrDeviceSessionChanged           = errors.New("device-session has been changed by an other process")
 )
 
 func handlePSQLError(err error, description string) error {
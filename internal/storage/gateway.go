@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -15,6 +17,8 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/lorawan"
 )
 
@@ -23,6 +27,12 @@ const (
 	gatewayKeyTempl = "lora:ns:gw:%s"
 )
 
+// gateway tag limits
+const (
+	gatewayTagKeyMaxLength = 64
+	gatewayTagsMaxCount    = 32
+)
+
 // GPSPoint contains a GPS point.
 type GPSPoint struct {
 	Latitude  float64
@@ -45,17 +55,172 @@ func (l *GPSPoint) Scan(src interface{}) error {
 	return err
 }
 
+// GatewayTags contains the key / value metadata of a gateway.
+type GatewayTags map[string]string
+
+// Value implements the driver.Valuer interface.
+func (t GatewayTags) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// An empty set of tags is returned as nil (as is done by the gob encoding
+// of the gateway cache).
+func (t *GatewayTags) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	if len(tags) == 0 {
+		*t = nil
+	} else {
+		*t = tags
+	}
+
+	return nil
+}
+
+// Validate validates the gateway tags.
+func (t GatewayTags) Validate() error {
+	if len(t) > gatewayTagsMaxCount {
+		return ErrGatewayTooManyTags
+	}
+
+	for k := range t {
+		if len(k) > gatewayTagKeyMaxLength {
+			return ErrGatewayTagKeyTooLong
+		}
+	}
+
+	return nil
+}
+
+// GatewayOrderBy defines the ordering of gateways.
+type GatewayOrderBy int
+
+// Available gateway orderings.
+const (
+	GatewayOrderByID GatewayOrderBy = iota
+	GatewayOrderByCreatedAt
+	GatewayOrderByLastSeenAt
+)
+
+// GatewayFilters provides filters for filtering gateways.
+type GatewayFilters struct {
+	// Tags contains the tags the gateway must have (key and value).
+	Tags GatewayTags
+
+	// Search matches a substring of the Gateway ID (HEX encoded). Colons
+	// are ignored, so both 01:02:03 and 010203 are accepted.
+	Search string
+
+	// OnlineWithin (when > 0) only matches gateways seen within the given
+	// duration.
+	OnlineWithin time.Duration
+
+	// MaintenanceMode (when set) only matches gateways with the given
+	// maintenance mode.
+	MaintenanceMode *bool
+
+	// OrderBy defines the ordering of the result-set.
+	OrderBy GatewayOrderBy
+
+	// OrderDesc sets the descending ordering.
+	OrderDesc bool
+}
+
+// sql returns the SQL where clause and the arguments.
+func (f GatewayFilters) sql() (string, []interface{}) {
+	where := []string{"tags @> $1"}
+	args := []interface{}{f.Tags}
+
+	if search := strings.ToLower(strings.Replace(f.Search, ":", "", -1)); search != "" {
+		args = append(args, "%"+escapeLike(search)+"%")
+		where = append(where, fmt.Sprintf("encode(gateway_id, 'hex') like $%d escape '\\'", len(args)))
+	}
+
+	if f.OnlineWithin > 0 {
+		args = append(args, time.Now().Add(-f.OnlineWithin))
+		where = append(where, fmt.Sprintf("last_seen_at >= $%d", len(args)))
+	}
+
+	if f.MaintenanceMode != nil {
+		args = append(args, *f.MaintenanceMode)
+		where = append(where, fmt.Sprintf("maintenance_mode = $%d", len(args)))
+	}
+
+	return "where " + strings.Join(where, " and "), args
+}
+
+// escapeLike escapes the LIKE wildcards (and the escape character) in the
+// given string, so that it is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// orderBySQL returns the SQL order by clause.
+func (f GatewayFilters) orderBySQL() string {
+	direction := "asc"
+	if f.OrderDesc {
+		direction = "desc"
+	}
+
+	switch f.OrderBy {
+	case GatewayOrderByCreatedAt:
+		return fmt.Sprintf("order by created_at %s, gateway_id", direction)
+	case GatewayOrderByLastSeenAt:
+		return fmt.Sprintf("order by last_seen_at %s nulls last, gateway_id", direction)
+	default:
+		return fmt.Sprintf("order by gateway_id %s", direction)
+	}
+}
+
 // Gateway represents a gateway.
 type Gateway struct {
-	GatewayID        lorawan.EUI64  `db:"gateway_id"`
-	CreatedAt        time.Time      `db:"created_at"`
-	UpdatedAt        time.Time      `db:"updated_at"`
-	FirstSeenAt      *time.Time     `db:"first_seen_at"`
-	LastSeenAt       *time.Time     `db:"last_seen_at"`
-	Location         GPSPoint       `db:"location"`
-	Altitude         float64        `db:"altitude"`
-	GatewayProfileID *uuid.UUID     `db:"gateway_profile_id"`
-	Boards           []GatewayBoard `db:"-"`
+	GatewayID               lorawan.EUI64  `db:"gateway_id"`
+	CreatedAt               time.Time      `db:"created_at"`
+	UpdatedAt               time.Time      `db:"updated_at"`
+	FirstSeenAt             *time.Time     `db:"first_seen_at"`
+	LastSeenAt              *time.Time     `db:"last_seen_at"`
+	Location                GPSPoint       `db:"location"`
+	Altitude                float64        `db:"altitude"`
+	GatewayProfileID        *uuid.UUID     `db:"gateway_profile_id"`
+	MaintenanceMode         bool           `db:"maintenance_mode"`
+	Tags                    GatewayTags    `db:"tags"`
+	RSSIOffset              float64        `db:"rssi_offset"`
+	SNROffset               float64        `db:"snr_offset"`
+	AntennaGain             float64        `db:"antenna_gain"`
+	MaxTXPower              int            `db:"max_tx_power"`
+	AutoCreated             bool           `db:"auto_created"`
+	UpdateLocationFromStats bool           `db:"update_location_from_stats"`
+	DiscoveryEnabled        bool           `db:"discovery_enabled"`
+	LocationFromGPS         bool           `db:"location_from_gps"`
+	LocationUpdatedAt       *time.Time     `db:"location_updated_at"`
+	ConfigVersion           string         `db:"config_version"`
+	Online                  bool           `db:"online"`
+	OnlineChangedAt         *time.Time     `db:"online_changed_at"`
+	Boards                  []GatewayBoard `db:"-"`
+}
+
+// GetDownlinkTXPower returns the downlink TX power (dBm) of the gateway for
+// the given frequency. This is the max EIRP of the band, capped by the
+// MaxTXPower of the gateway (when set), minus the antenna gain.
+func (g Gateway) GetDownlinkTXPower(frequency int) int {
+	return band.GetDownlinkTXPower(frequency, g.MaxTXPower, g.AntennaGain)
 }
 
 // GatewayBoard holds the gateway board configuration.
@@ -66,6 +231,10 @@ type GatewayBoard struct {
 
 // CreateGateway creates the given gateway.
 func CreateGateway(db sqlx.Execer, gw *Gateway) error {
+	if err := gw.Tags.Validate(); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	gw.CreatedAt = now
 	gw.UpdatedAt = now
@@ -79,8 +248,20 @@ func CreateGateway(db sqlx.Execer, gw *Gateway) error {
 			last_seen_at,
 			location,
 			altitude,
-			gateway_profile_id
-		) values ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			gateway_profile_id,
+			maintenance_mode,
+			tags,
+			rssi_offset,
+			snr_offset,
+			auto_created,
+			update_location_from_stats,
+			location_from_gps,
+			location_updated_at,
+			config_version,
+			discovery_enabled,
+			antenna_gain,
+			max_tx_power
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`,
 		gw.GatewayID[:],
 		gw.CreatedAt,
 		gw.UpdatedAt,
@@ -89,6 +270,18 @@ func CreateGateway(db sqlx.Execer, gw *Gateway) error {
 		gw.Location,
 		gw.Altitude,
 		gw.GatewayProfileID,
+		gw.MaintenanceMode,
+		gw.Tags,
+		gw.RSSIOffset,
+		gw.SNROffset,
+		gw.AutoCreated,
+		gw.UpdateLocationFromStats,
+		gw.LocationFromGPS,
+		gw.LocationUpdatedAt,
+		gw.ConfigVersion,
+		gw.DiscoveryEnabled,
+		gw.AntennaGain,
+		gw.MaxTXPower,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -207,6 +400,35 @@ func GetAndCacheGateway(db sqlx.Queryer, p *redis.Pool, gatewayID lorawan.EUI64)
 	return gw, nil
 }
 
+// GetGatewayDownlinkTXPower returns the downlink TX power (dBm) of the given
+// gateway for the given frequency, using the gateway cache.
+func GetGatewayDownlinkTXPower(db sqlx.Queryer, p *redis.Pool, gatewayID lorawan.EUI64, frequency int) (int, error) {
+	gw, err := GetAndCacheGateway(db, p, gatewayID)
+	if err != nil {
+		return 0, err
+	}
+	return gw.GetDownlinkTXPower(frequency), nil
+}
+
+// SetDownlinkTXInfoPower sets the TX power of the given downlink tx-info.
+// When txPower is not -1 (configured override), this value is used, else
+// the downlink TX power of the gateway for the tx-info frequency.
+func SetDownlinkTXInfoPower(db sqlx.Queryer, p *redis.Pool, txInfo *gw.DownlinkTXInfo, txPower int) error {
+	if txPower == -1 {
+		var gatewayID lorawan.EUI64
+		copy(gatewayID[:], txInfo.GatewayId)
+
+		var err error
+		txPower, err = GetGatewayDownlinkTXPower(db, p, gatewayID, int(txInfo.Frequency))
+		if err != nil {
+			return errors.Wrap(err, "get gateway downlink tx power error")
+		}
+	}
+
+	txInfo.Power = int32(txPower)
+	return nil
+}
+
 // GetGateway returns the gateway for the given Gateway ID.
 func GetGateway(db sqlx.Queryer, id lorawan.EUI64) (Gateway, error) {
 	var gw Gateway
@@ -237,6 +459,10 @@ func GetGateway(db sqlx.Queryer, id lorawan.EUI64) (Gateway, error) {
 
 // UpdateGateway updates the given gateway.
 func UpdateGateway(db sqlx.Execer, gw *Gateway) error {
+	if err := gw.Tags.Validate(); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	gw.UpdatedAt = now
 
@@ -247,7 +473,19 @@ func UpdateGateway(db sqlx.Execer, gw *Gateway) error {
 			last_seen_at = $4,
 			location = $5,
 			altitude = $6,
-			gateway_profile_id = $7
+			gateway_profile_id = $7,
+			maintenance_mode = $8,
+			tags = $9,
+			rssi_offset = $10,
+			snr_offset = $11,
+			auto_created = $12,
+			update_location_from_stats = $13,
+			location_from_gps = $14,
+			location_updated_at = $15,
+			config_version = $16,
+			discovery_enabled = $17,
+			antenna_gain = $18,
+			max_tx_power = $19
 		where gateway_id = $1`,
 		gw.GatewayID[:],
 		gw.UpdatedAt,
@@ -256,6 +494,18 @@ func UpdateGateway(db sqlx.Execer, gw *Gateway) error {
 		gw.Location,
 		gw.Altitude,
 		gw.GatewayProfileID,
+		gw.MaintenanceMode,
+		gw.Tags,
+		gw.RSSIOffset,
+		gw.SNROffset,
+		gw.AutoCreated,
+		gw.UpdateLocationFromStats,
+		gw.LocationFromGPS,
+		gw.LocationUpdatedAt,
+		gw.ConfigVersion,
+		gw.DiscoveryEnabled,
+		gw.AntennaGain,
+		gw.MaxTXPower,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -315,6 +565,130 @@ func DeleteGateway(db sqlx.Execer, id lorawan.EUI64) error {
 	return nil
 }
 
+// GetGatewayCount returns the number of gateways matching the given filters.
+func GetGatewayCount(db sqlx.Queryer, filters GatewayFilters) (int, error) {
+	where, args := filters.sql()
+
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from gateway "+where, args...)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// GetGatewaysForGatewayProfileID returns the gateways using the given
+// gateway-profile. Note that the gateway boards are not loaded.
+func GetGatewaysForGatewayProfileID(db sqlx.Queryer, id uuid.UUID) ([]Gateway, error) {
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, `
+		select
+			*
+		from
+			gateway
+		where
+			gateway_profile_id = $1
+		order by
+			gateway_id`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return gws, nil
+}
+
+// GetOnlineGatewayCount returns the number of gateways which are online.
+func GetOnlineGatewayCount(db sqlx.Queryer) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from gateway where online = true")
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// GetGateways returns a slice of gateways matching the given filters,
+// ordered as defined by the filters. Note that the gateway boards are not
+// returned.
+func GetGateways(db sqlx.Queryer, filters GatewayFilters, limit, offset int) ([]Gateway, error) {
+	where, args := filters.sql()
+	args = append(args, limit, offset)
+
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, fmt.Sprintf(`
+		select
+			*
+		from
+			gateway
+		%s
+		%s
+		limit $%d
+		offset $%d`,
+		where,
+		filters.orderBySQL(),
+		len(args)-1,
+		len(args),
+	), args...)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return gws, nil
+}
+
+// UpdateGatewayOnlineStates toggles the online state of the gateways for
+// which the state changed. A gateway is offline when it was not seen within
+// offlineMultiplier times its stats interval. The stats interval of the
+// gateway-profile is used when set, else the given default stats interval.
+// The state of gateways which changed state after changedBefore is left
+// untouched (debounce). It returns the updated gateways.
+//
+// As the check and update are done in a single statement, this can be
+// safely executed by multiple network-server instances.
+func UpdateGatewayOnlineStates(db sqlx.Queryer, offlineMultiplier int, defaultStatsInterval time.Duration, changedBefore time.Time) ([]Gateway, error) {
+	now := time.Now()
+
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, `
+		update gateway g
+		set
+			online = not g.online,
+			online_changed_at = $1
+		from (
+			select
+				g2.gateway_id,
+				$1::timestamptz - (coalesce(nullif(gp.stats_interval, 0), $3::bigint) / 1000 * $2::bigint) * interval '1 microsecond' as last_seen_before
+			from gateway g2
+			left join gateway_profile gp
+				on gp.gateway_profile_id = g2.gateway_profile_id
+		) t
+		where
+			t.gateway_id = g.gateway_id
+			and (
+				(g.online = true and (g.last_seen_at is null or g.last_seen_at < t.last_seen_before))
+				or (g.online = false and g.last_seen_at >= t.last_seen_before)
+			)
+			and (g.online_changed_at is null or g.online_changed_at < $4)
+		returning g.*`,
+		now,
+		offlineMultiplier,
+		int64(defaultStatsInterval),
+		changedBefore,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "update error")
+	}
+
+	for _, gw := range gws {
+		log.WithFields(log.Fields{
+			"gateway_id": gw.GatewayID,
+			"online":     gw.Online,
+		}).Info("gateway online state changed")
+	}
+
+	return gws, nil
+}
+
 // GetGatewaysForIDs returns a map of gateways given a slice of IDs.
 func GetGatewaysForIDs(db sqlx.Queryer, ids []lorawan.EUI64) (map[lorawan.EUI64]Gateway, error) {
 	out := make(map[lorawan.EUI64]Gateway)
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	gatewayDiscoveryLockTempl = "lora:ns:gw:%s:discovery:lock"
+	gatewayDiscoveryPingTempl = "lora:ns:gw:%s:discovery:ping:%d:%s"
+)
+
+// GatewayDiscoveryPingRX contains the reception of a discovery ping,
+// transmitted by one gateway and received by an other gateway.
+type GatewayDiscoveryPingRX struct {
+	ID          int64         `db:"id"`
+	CreatedAt   time.Time     `db:"created_at"`
+	TXGatewayID lorawan.EUI64 `db:"tx_gateway_id"`
+	RXGatewayID lorawan.EUI64 `db:"rx_gateway_id"`
+	RSSI        int           `db:"rssi"`
+	LoRaSNR     float64       `db:"lora_snr"`
+}
+
+// CreateGatewayDiscoveryPingRX creates the given discovery ping reception.
+func CreateGatewayDiscoveryPingRX(db sqlx.Queryer, rx *GatewayDiscoveryPingRX) error {
+	if rx.CreatedAt.IsZero() {
+		rx.CreatedAt = time.Now()
+	}
+
+	err := sqlx.Get(db, &rx.ID, `
+		insert into gateway_discovery_ping_rx (
+			created_at,
+			tx_gateway_id,
+			rx_gateway_id,
+			rssi,
+			lora_snr
+		) values ($1, $2, $3, $4, $5)
+		returning id`,
+		rx.CreatedAt,
+		rx.TXGatewayID[:],
+		rx.RXGatewayID[:],
+		rx.RSSI,
+		rx.LoRaSNR,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	return nil
+}
+
+// GetGatewayDiscoveryPingRXsForGatewayID returns the receptions of the
+// discovery pings transmitted by the given gateway, between the given start
+// and end timestamps (when not zero), ordered by reception time.
+func GetGatewayDiscoveryPingRXsForGatewayID(db sqlx.Queryer, txGatewayID lorawan.EUI64, start, end time.Time) ([]GatewayDiscoveryPingRX, error) {
+	var startPtr, endPtr *time.Time
+	if !start.IsZero() {
+		startPtr = &start
+	}
+	if !end.IsZero() {
+		endPtr = &end
+	}
+
+	var rxs []GatewayDiscoveryPingRX
+	err := sqlx.Select(db, &rxs, `
+		select
+			*
+		from
+			gateway_discovery_ping_rx
+		where
+			tx_gateway_id = $1
+			and ($2::timestamptz is null or created_at >= $2)
+			and ($3::timestamptz is null or created_at < $3)
+		order by
+			created_at,
+			id`,
+		txGatewayID[:],
+		startPtr,
+		endPtr,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return rxs, nil
+}
+
+// DeleteGatewayDiscoveryPingRXsBefore deletes the discovery ping receptions
+// created before the given timestamp. It returns the number of deleted
+// records.
+func DeleteGatewayDiscoveryPingRXsBefore(db sqlx.Execer, before time.Time) (int64, error) {
+	res, err := db.Exec(`
+		delete from gateway_discovery_ping_rx
+		where
+			created_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "get rows affected error")
+	}
+
+	return ra, nil
+}
+
+// GetDiscoveryEnabledGateways returns the gateways for which the gateway
+// discovery is enabled. Note that the gateway boards are not loaded.
+func GetDiscoveryEnabledGateways(db sqlx.Queryer) ([]Gateway, error) {
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, `
+		select
+			*
+		from
+			gateway
+		where
+			discovery_enabled = true
+		order by
+			gateway_id`,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return gws, nil
+}
+
+// AcquireGatewayDiscoveryLock acquires the lock for sending the discovery
+// ping of the given gateway. It returns false when the lock is already held,
+// e.g. by an other instance.
+func AcquireGatewayDiscoveryLock(p *redis.Pool, gatewayID lorawan.EUI64, ttl time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayDiscoveryLockTempl, gatewayID)
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(ttl/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "acquire gateway discovery lock error")
+	}
+
+	return true, nil
+}
+
+// SetGatewayDiscoveryPingSeen marks the discovery ping of the given gateway,
+// timestamp (unix nanoseconds) and MIC as seen. It returns false when the
+// ping was already seen within the given ttl, e.g. when it was replayed.
+func SetGatewayDiscoveryPingSeen(p *redis.Pool, gatewayID lorawan.EUI64, ts int64, mic lorawan.MIC, ttl time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayDiscoveryPingTempl, gatewayID, ts, mic)
+	_, err := redis.String(c.Do("SET", key, "seen", "PX", int64(ttl/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "set gateway discovery ping seen error")
+	}
+
+	return true, nil
+}
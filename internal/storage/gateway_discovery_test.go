@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayDiscovery() {
+	assert := require.New(ts.T())
+
+	gws := []Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, DiscoveryEnabled: true},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+	}
+	for i := range gws {
+		assert.NoError(CreateGateway(ts.Tx(), &gws[i]))
+	}
+
+	ts.T().Run("GetDiscoveryEnabledGateways", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := GetDiscoveryEnabledGateways(ts.Tx())
+		assert.NoError(err)
+		assert.Len(out, 1)
+		assert.Equal(gws[0].GatewayID, out[0].GatewayID)
+	})
+
+	ts.T().Run("Create ping rx", func(t *testing.T) {
+		assert := require.New(t)
+		now := time.Now().Round(time.Second).UTC()
+
+		rxs := []GatewayDiscoveryPingRX{
+			{
+				CreatedAt:   now.Add(-2 * time.Hour),
+				TXGatewayID: gws[0].GatewayID,
+				RXGatewayID: gws[1].GatewayID,
+				RSSI:        -110,
+				LoRaSNR:     -5.5,
+			},
+			{
+				CreatedAt:   now,
+				TXGatewayID: gws[0].GatewayID,
+				RXGatewayID: gws[1].GatewayID,
+				RSSI:        -100,
+				LoRaSNR:     2.5,
+			},
+		}
+		for i := range rxs {
+			assert.NoError(CreateGatewayDiscoveryPingRX(ts.Tx(), &rxs[i]))
+			assert.NotEqual(0, rxs[i].ID)
+		}
+
+		t.Run("Get for tx gateway", func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := GetGatewayDiscoveryPingRXsForGatewayID(ts.Tx(), gws[0].GatewayID, time.Time{}, time.Time{})
+			assert.NoError(err)
+			assert.Len(out, 2)
+			assert.Equal(rxs[0].ID, out[0].ID)
+			assert.True(out[1].CreatedAt.Equal(now))
+			assert.Equal(-100, out[1].RSSI)
+			assert.Equal(2.5, out[1].LoRaSNR)
+
+			out, err = GetGatewayDiscoveryPingRXsForGatewayID(ts.Tx(), gws[0].GatewayID, now.Add(-time.Hour), time.Time{})
+			assert.NoError(err)
+			assert.Len(out, 1)
+			assert.Equal(rxs[1].ID, out[0].ID)
+
+			out, err = GetGatewayDiscoveryPingRXsForGatewayID(ts.Tx(), gws[1].GatewayID, time.Time{}, time.Time{})
+			assert.NoError(err)
+			assert.Len(out, 0)
+		})
+
+		t.Run("Delete before", func(t *testing.T) {
+			assert := require.New(t)
+
+			count, err := DeleteGatewayDiscoveryPingRXsBefore(ts.Tx(), now.Add(-time.Hour))
+			assert.NoError(err)
+			assert.EqualValues(1, count)
+
+			out, err := GetGatewayDiscoveryPingRXsForGatewayID(ts.Tx(), gws[0].GatewayID, time.Time{}, time.Time{})
+			assert.NoError(err)
+			assert.Len(out, 1)
+			assert.Equal(rxs[1].ID, out[0].ID)
+		})
+	})
+
+	ts.T().Run("Lock", func(t *testing.T) {
+		assert := require.New(t)
+
+		locked, err := AcquireGatewayDiscoveryLock(ts.RedisPool(), gws[0].GatewayID, time.Minute)
+		assert.NoError(err)
+		assert.True(locked)
+
+		locked, err = AcquireGatewayDiscoveryLock(ts.RedisPool(), gws[0].GatewayID, time.Minute)
+		assert.NoError(err)
+		assert.False(locked)
+	})
+}
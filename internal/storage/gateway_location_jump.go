@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayLocationJumpKeyTempl = "lora:ns:gw:%s:location:jump" // contains the ignored GPS location of a gateway
+
+// gatewayLocationJumpTTL defines the time after which an unconfirmed
+// ignored GPS location expires.
+const gatewayLocationJumpTTL = 24 * time.Hour
+
+// GatewayLocationJump holds a GPS location of a gateway which was ignored
+// because of the max jump distance, and the number of consecutive location
+// updates confirming it.
+type GatewayLocationJump struct {
+	Latitude      float64 `redis:"latitude"`
+	Longitude     float64 `redis:"longitude"`
+	Altitude      float64 `redis:"altitude"`
+	Confirmations int     `redis:"confirmations"`
+}
+
+// SaveGatewayLocationJump saves the given ignored location of the gateway.
+func SaveGatewayLocationJump(p *redis.Pool, gatewayID lorawan.EUI64, jump GatewayLocationJump) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayLocationJumpKeyTempl, gatewayID)
+
+	c.Send("MULTI")
+	c.Send("HMSET", redis.Args{}.Add(key).AddFlat(&jump)...)
+	c.Send("PEXPIRE", key, int64(gatewayLocationJumpTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "save gateway location jump error")
+	}
+
+	return nil
+}
+
+// GetGatewayLocationJump returns the ignored location of the gateway.
+func GetGatewayLocationJump(p *redis.Pool, gatewayID lorawan.EUI64) (GatewayLocationJump, error) {
+	var jump GatewayLocationJump
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("HGETALL", fmt.Sprintf(gatewayLocationJumpKeyTempl, gatewayID)))
+	if err != nil {
+		return jump, errors.Wrap(err, "get gateway location jump error")
+	}
+	if len(values) == 0 {
+		return jump, ErrDoesNotExist
+	}
+
+	if err := redis.ScanStruct(values, &jump); err != nil {
+		return jump, errors.Wrap(err, "scan gateway location jump error")
+	}
+
+	return jump, nil
+}
+
+// DeleteGatewayLocationJump deletes the ignored location of the gateway.
+func DeleteGatewayLocationJump(p *redis.Pool, gatewayID lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(gatewayLocationJumpKeyTempl, gatewayID)); err != nil {
+		return errors.Wrap(err, "delete gateway location jump error")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayLocationJump() {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetGatewayLocationJump(ts.RedisPool(), gatewayID)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		jump := GatewayLocationJump{
+			Latitude:      52.3767,
+			Longitude:     4.8945,
+			Altitude:      12.5,
+			Confirmations: 2,
+		}
+		assert.NoError(SaveGatewayLocationJump(ts.RedisPool(), gatewayID, jump))
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+
+			jumpGet, err := GetGatewayLocationJump(ts.RedisPool(), gatewayID)
+			assert.NoError(err)
+			assert.Equal(jump, jumpGet)
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(DeleteGatewayLocationJump(ts.RedisPool(), gatewayID))
+			_, err := GetGatewayLocationJump(ts.RedisPool(), gatewayID)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+	})
+}
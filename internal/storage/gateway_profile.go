@@ -30,6 +30,8 @@ type GatewayProfile struct {
 	ID            uuid.UUID      `db:"gateway_profile_id"`
 	CreatedAt     time.Time      `db:"created_at"`
 	UpdatedAt     time.Time      `db:"updated_at"`
+	Name          string         `db:"name"`
+	StatsInterval time.Duration  `db:"stats_interval"`
 	Channels      []int64        `db:"channels"`
 	ExtraChannels []ExtraChannel `db:"-"`
 }
@@ -60,12 +62,16 @@ func CreateGatewayProfile(db sqlx.Execer, c *GatewayProfile) error {
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
-		) values ($1, $2, $3, $4)`,
+			channels,
+			name,
+			stats_interval
+		) values ($1, $2, $3, $4, $5, $6)`,
 		c.ID,
 		c.CreatedAt,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.Name,
+		c.StatsInterval,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -109,7 +115,9 @@ func GetGatewayProfile(db sqlx.Queryer, id uuid.UUID) (GatewayProfile, error) {
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
+			channels,
+			name,
+			stats_interval
 		from gateway_profile
 		where
 			gateway_profile_id = $1`,
@@ -119,6 +127,8 @@ func GetGatewayProfile(db sqlx.Queryer, id uuid.UUID) (GatewayProfile, error) {
 		&c.CreatedAt,
 		&c.UpdatedAt,
 		pq.Array(&c.Channels),
+		&c.Name,
+		&c.StatsInterval,
 	)
 	if err != nil {
 		return c, handlePSQLError(err, "select error")
@@ -169,12 +179,16 @@ func UpdateGatewayProfile(db sqlx.Execer, c *GatewayProfile) error {
 		update gateway_profile
 		set
 			updated_at = $2,
-			channels = $3
+			channels = $3,
+			name = $4,
+			stats_interval = $5
 		where
 			gateway_profile_id = $1`,
 		c.ID,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.Name,
+		c.StatsInterval,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
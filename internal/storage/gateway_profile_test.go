@@ -20,7 +20,9 @@ func TestGatewayProfile(t *testing.T) {
 
 		Convey("When creating gateway profile", func() {
 			gc := GatewayProfile{
-				Channels: []int64{0, 1, 2},
+				Name:          "test-profile",
+				StatsInterval: 30 * time.Second,
+				Channels:      []int64{0, 1, 2},
 				ExtraChannels: []ExtraChannel{
 					{
 						Modulation:       ModulationLoRa,
@@ -1,11 +1,15 @@
 package storage
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/lorawan"
 )
 
@@ -79,6 +83,16 @@ func (ts *StorageTestSuite) TestGateway() {
 				Longitude: 3.123,
 			}
 			gw.Altitude = 100.5
+			gw.MaintenanceMode = true
+			gw.RSSIOffset = -6
+			gw.SNROffset = 1.5
+			gw.AntennaGain = 2.5
+			gw.MaxTXPower = 14
+			gw.DiscoveryEnabled = true
+			gw.Tags = GatewayTags{
+				"site":  "amsterdam-01",
+				"owner": "customer-a",
+			}
 			gw.Boards = []GatewayBoard{
 				{
 					FineTimestampKey: &aesKey,
@@ -105,6 +119,170 @@ func (ts *StorageTestSuite) TestGateway() {
 			assert.Equal(gw, gwGet)
 		})
 
+		t.Run("List", func(t *testing.T) {
+			assert := require.New(t)
+
+			gw2 := Gateway{
+				GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+				Tags: GatewayTags{
+					"site":  "amsterdam-02",
+					"owner": "customer-a",
+				},
+			}
+			assert.NoError(CreateGateway(ts.Tx(), &gw2))
+
+			maintenanceMode := true
+			noMaintenanceMode := false
+
+			tests := []struct {
+				Name          string
+				Filters       GatewayFilters
+				Limit         int
+				Offset        int
+				ExpectedCount int
+				ExpectedIDs   []lorawan.EUI64
+			}{
+				{
+					Name:          "no filters",
+					Limit:         10,
+					ExpectedCount: 2,
+					ExpectedIDs:   []lorawan.EUI64{gw.GatewayID, gw2.GatewayID},
+				},
+				{
+					Name:          "no filters with limit and offset",
+					Limit:         1,
+					Offset:        1,
+					ExpectedCount: 2,
+					ExpectedIDs:   []lorawan.EUI64{gw2.GatewayID},
+				},
+				{
+					Name:          "filter on owner",
+					Filters:       GatewayFilters{Tags: GatewayTags{"owner": "customer-a"}},
+					Limit:         10,
+					ExpectedCount: 2,
+					ExpectedIDs:   []lorawan.EUI64{gw.GatewayID, gw2.GatewayID},
+				},
+				{
+					Name:          "filter on site",
+					Filters:       GatewayFilters{Tags: GatewayTags{"site": "amsterdam-02"}},
+					Limit:         10,
+					ExpectedCount: 1,
+					ExpectedIDs:   []lorawan.EUI64{gw2.GatewayID},
+				},
+				{
+					Name:          "filter without match",
+					Filters:       GatewayFilters{Tags: GatewayTags{"owner": "customer-b"}},
+					Limit:         10,
+					ExpectedCount: 0,
+				},
+				{
+					Name:          "search plain hex",
+					Filters:       GatewayFilters{Search: "0202"},
+					Limit:         10,
+					ExpectedCount: 1,
+					ExpectedIDs:   []lorawan.EUI64{gw2.GatewayID},
+				},
+				{
+					Name:          "search colon separated hex",
+					Filters:       GatewayFilters{Search: "03:04:05"},
+					Limit:         10,
+					ExpectedCount: 1,
+					ExpectedIDs:   []lorawan.EUI64{gw.GatewayID},
+				},
+				{
+					Name:          "search wildcards are matched literally",
+					Filters:       GatewayFilters{Search: "%"},
+					Limit:         10,
+					ExpectedCount: 0,
+				},
+				{
+					Name:          "search single character wildcard is matched literally",
+					Filters:       GatewayFilters{Search: "0_02"},
+					Limit:         10,
+					ExpectedCount: 0,
+				},
+				{
+					Name:          "online only",
+					Filters:       GatewayFilters{OnlineWithin: time.Hour},
+					Limit:         10,
+					ExpectedCount: 1,
+					ExpectedIDs:   []lorawan.EUI64{gw.GatewayID},
+				},
+				{
+					Name:          "in maintenance mode",
+					Filters:       GatewayFilters{MaintenanceMode: &maintenanceMode},
+					Limit:         10,
+					ExpectedCount: 1,
+					ExpectedIDs:   []lorawan.EUI64{gw.GatewayID},
+				},
+				{
+					Name:          "not in maintenance mode",
+					Filters:       GatewayFilters{MaintenanceMode: &noMaintenanceMode},
+					Limit:         10,
+					ExpectedCount: 1,
+					ExpectedIDs:   []lorawan.EUI64{gw2.GatewayID},
+				},
+				{
+					Name:          "order by gateway id desc",
+					Filters:       GatewayFilters{OrderDesc: true},
+					Limit:         10,
+					ExpectedCount: 2,
+					ExpectedIDs:   []lorawan.EUI64{gw2.GatewayID, gw.GatewayID},
+				},
+				{
+					Name:          "order by created at desc",
+					Filters:       GatewayFilters{OrderBy: GatewayOrderByCreatedAt, OrderDesc: true},
+					Limit:         10,
+					ExpectedCount: 2,
+					ExpectedIDs:   []lorawan.EUI64{gw2.GatewayID, gw.GatewayID},
+				},
+				{
+					Name:          "order by last seen at (never seen last)",
+					Filters:       GatewayFilters{OrderBy: GatewayOrderByLastSeenAt, OrderDesc: true},
+					Limit:         10,
+					ExpectedCount: 2,
+					ExpectedIDs:   []lorawan.EUI64{gw.GatewayID, gw2.GatewayID},
+				},
+			}
+
+			for _, tst := range tests {
+				t.Run(tst.Name, func(t *testing.T) {
+					assert := require.New(t)
+
+					count, err := GetGatewayCount(ts.Tx(), tst.Filters)
+					assert.NoError(err)
+					assert.Equal(tst.ExpectedCount, count)
+
+					gws, err := GetGateways(ts.Tx(), tst.Filters, tst.Limit, tst.Offset)
+					assert.NoError(err)
+
+					var ids []lorawan.EUI64
+					for _, g := range gws {
+						ids = append(ids, g.GatewayID)
+					}
+					assert.Equal(tst.ExpectedIDs, ids)
+				})
+			}
+
+			assert.NoError(DeleteGateway(ts.Tx(), gw2.GatewayID))
+		})
+
+		t.Run("Invalid tags", func(t *testing.T) {
+			assert := require.New(t)
+
+			tooMany := make(GatewayTags)
+			for i := 0; i < 33; i++ {
+				tooMany[fmt.Sprintf("key-%d", i)] = "value"
+			}
+
+			gwInvalid := gw
+			gwInvalid.Tags = tooMany
+			assert.Equal(ErrGatewayTooManyTags, UpdateGateway(ts.Tx(), &gwInvalid))
+
+			gwInvalid.Tags = GatewayTags{strings.Repeat("a", 65): "value"}
+			assert.Equal(ErrGatewayTagKeyTooLong, UpdateGateway(ts.Tx(), &gwInvalid))
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			assert := require.New(t)
 			assert.NoError(DeleteGateway(ts.Tx(), gw.GatewayID))
@@ -113,3 +291,75 @@ func (ts *StorageTestSuite) TestGateway() {
 		})
 	})
 }
+
+func (ts *StorageTestSuite) TestSetDownlinkTXInfoPower() {
+	assert := require.New(ts.T())
+
+	g := Gateway{
+		GatewayID:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		AntennaGain: 2.5,
+		MaxTXPower:  20,
+	}
+	assert.NoError(CreateGateway(ts.Tx(), &g))
+
+	ts.T().Run("Configured override", func(t *testing.T) {
+		assert := require.New(t)
+
+		txInfo := gw.DownlinkTXInfo{GatewayId: g.GatewayID[:], Frequency: 868100000}
+		assert.NoError(SetDownlinkTXInfoPower(ts.Tx(), ts.RedisPool(), &txInfo, 14))
+		assert.EqualValues(14, txInfo.Power)
+	})
+
+	ts.T().Run("Gateway settings", func(t *testing.T) {
+		assert := require.New(t)
+
+		txInfo := gw.DownlinkTXInfo{GatewayId: g.GatewayID[:], Frequency: 868100000}
+		assert.NoError(SetDownlinkTXInfoPower(ts.Tx(), ts.RedisPool(), &txInfo, -1))
+		assert.EqualValues(band.GetDownlinkTXPower(868100000, 20, 2.5), txInfo.Power)
+	})
+
+	ts.T().Run("Unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		txInfo := gw.DownlinkTXInfo{GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1}, Frequency: 868100000}
+		assert.Error(SetDownlinkTXInfoPower(ts.Tx(), ts.RedisPool(), &txInfo, -1))
+	})
+}
+
+func (ts *StorageTestSuite) TestGetOnlineGatewayCount() {
+	assert := require.New(ts.T())
+
+	for _, gw := range []Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+	} {
+		assert.NoError(CreateGateway(ts.Tx(), &gw))
+	}
+
+	_, err := ts.Tx().Exec("update gateway set online = true where gateway_id = $1", []byte{1, 1, 1, 1, 1, 1, 1, 1})
+	assert.NoError(err)
+
+	count, err := GetOnlineGatewayCount(ts.Tx())
+	assert.NoError(err)
+	assert.Equal(1, count)
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		In       string
+		Expected string
+	}{
+		{"0102", "0102"},
+		{"%", `\%`},
+		{"01_2", `01\_2`},
+		{`01\`, `01\\`},
+		{`\%_`, `\\\%\_`},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.In, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, escapeLike(tst.In))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayTXSlotKeyTempl = "lora:ns:gw:%s:txslot"
+
+// gatewayTXSlotTTL defines the TTL of the reservations of a gateway.
+// This must be greater than the max RX delay (15 seconds).
+const gatewayTXSlotTTL = 30 * time.Second
+
+// reserveGatewayTXSlotScript atomically checks that the requested slot does
+// not overlap with an existing reservation and stores the reservation.
+// Reservations are stored in a sorted-set with "start:airtime" as member.
+// As the 32bit concentrator counter rolls over, all timestamps are compared
+// modulo 2^32. When an existing reservation starts more than the TTL after
+// the requested slot, the counter has moved backwards (e.g. the gateway was
+// restarted) and all reservations are dropped. Reservations starting more
+// than the TTL before the requested slot are removed. It returns 1 on
+// success and 0 on conflict.
+var reserveGatewayTXSlotScript = redis.NewScript(1, `
+	local m = 4294967296
+	local s = tonumber(ARGV[1])
+	local d = tonumber(ARGV[2])
+	local ttl = tonumber(ARGV[3])
+
+	local items = redis.call('ZRANGE', KEYS[1], 0, -1)
+	for _, item in ipairs(items) do
+		local is = tonumber(string.match(item, '^(%d+):'))
+		if is then
+			local ahead = (is - s) % m
+			if ahead < m / 2 and ahead > ttl then
+				redis.call('DEL', KEYS[1])
+				items = {}
+				break
+			end
+		end
+	end
+
+	for _, item in ipairs(items) do
+		local is, id = string.match(item, '^(%d+):(%d+)$')
+		if is then
+			is = tonumber(is)
+			id = tonumber(id)
+			if (s - is) % m < id or (is - s) % m < d then
+				return 0
+			end
+		end
+	end
+
+	for _, item in ipairs(items) do
+		local is = tonumber(string.match(item, '^(%d+):'))
+		if is then
+			local behind = (s - is) % m
+			if behind < m / 2 and behind > ttl then
+				redis.call('ZREM', KEYS[1], item)
+			end
+		end
+	end
+
+	redis.call('ZADD', KEYS[1], s, s .. ':' .. d)
+	redis.call('PEXPIRE', KEYS[1], ARGV[4])
+	return 1
+`)
+
+// ReserveGatewayTXSlot reserves the TX slot of the given gateway, starting at
+// the given concentrator timestamp (in microseconds) for the given airtime.
+// It returns false when the slot overlaps with an existing reservation.
+func ReserveGatewayTXSlot(p *redis.Pool, gatewayID lorawan.EUI64, start uint32, airtime time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ok, err := redis.Bool(reserveGatewayTXSlotScript.Do(c,
+		fmt.Sprintf(gatewayTXSlotKeyTempl, gatewayID),
+		start,
+		int64(airtime/time.Microsecond),
+		int64(gatewayTXSlotTTL/time.Microsecond),
+		int64(gatewayTXSlotTTL/time.Millisecond),
+	))
+	if err != nil {
+		return false, errors.Wrap(err, "reserve gateway tx slot error")
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestReserveGatewayTXSlot() {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	otherGatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+	rebootGatewayID := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+
+	tests := []struct {
+		Name       string
+		GatewayID  lorawan.EUI64
+		Start      uint32
+		Airtime    time.Duration
+		ExpectedOK bool
+	}{
+		{
+			Name:       "first reservation",
+			GatewayID:  gatewayID,
+			Start:      1000000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "overlaps start",
+			GatewayID:  gatewayID,
+			Start:      950000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: false,
+		},
+		{
+			Name:       "overlaps end",
+			GatewayID:  gatewayID,
+			Start:      1050000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: false,
+		},
+		{
+			Name:       "within",
+			GatewayID:  gatewayID,
+			Start:      1010000,
+			Airtime:    10 * time.Millisecond,
+			ExpectedOK: false,
+		},
+		{
+			Name:       "adjacent",
+			GatewayID:  gatewayID,
+			Start:      1100000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "before the counter roll-over",
+			GatewayID:  gatewayID,
+			Start:      4294967000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "overlaps across the counter roll-over",
+			GatewayID:  gatewayID,
+			Start:      10000,
+			Airtime:    10 * time.Millisecond,
+			ExpectedOK: false,
+		},
+		{
+			Name:       "after the counter roll-over",
+			GatewayID:  gatewayID,
+			Start:      100000,
+			Airtime:    10 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "before gateway restart",
+			GatewayID:  rebootGatewayID,
+			Start:      100000000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "counter moved backwards",
+			GatewayID:  rebootGatewayID,
+			Start:      1000000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "reservations before gateway restart are dropped",
+			GatewayID:  rebootGatewayID,
+			Start:      100000000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "other gateway",
+			GatewayID:  otherGatewayID,
+			Start:      1000000,
+			Airtime:    100 * time.Millisecond,
+			ExpectedOK: true,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ok, err := ReserveGatewayTXSlot(ts.RedisPool(), tst.GatewayID, tst.Start, tst.Airtime)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedOK, ok)
+		})
+	}
+}
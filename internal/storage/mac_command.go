@@ -24,6 +24,15 @@ type MACCommandBlock struct {
 	CID         lorawan.CID
 	External    bool // command was enqueued by an external service
 	MACCommands MACCommands
+
+	// FCntUp holds the uplink frame-counter that was expected at the time
+	// the block was sent. It is used to detect pending blocks that did not
+	// get an answer within the configured number of uplinks.
+	FCntUp uint32
+
+	// RetryCount holds the number of times the block was re-queued because
+	// no answer was received.
+	RetryCount int
 }
 
 // Size returns the size (in bytes) of the mac-commands within this block.
@@ -93,6 +102,16 @@ func FlushMACCommandQueue(p *redis.Pool, devEUI lorawan.EUI64) error {
 	return nil
 }
 
+// getMACCommandKeys returns the keys of the mac-command queue and of all the
+// possible pending mac-commands for the given DevEUI.
+func getMACCommandKeys(devEUI lorawan.EUI64) []interface{} {
+	keys := []interface{}{fmt.Sprintf(macCommandQueueTempl, devEUI)}
+	for cid := 0; cid < 256; cid++ {
+		keys = append(keys, fmt.Sprintf(macCommandPendingTempl, devEUI, cid))
+	}
+	return keys
+}
+
 // CreateMACCommandQueueItem creates a new mac-command queue item.
 func CreateMACCommandQueueItem(p *redis.Pool, devEUI lorawan.EUI64, block MACCommandBlock) error {
 	var buf bytes.Buffer
@@ -239,6 +258,46 @@ func GetPendingMACCommand(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID)
 	return &block, nil
 }
 
+// GetPendingMACCommands returns all the pending mac-command blocks for the
+// given DevEUI.
+func GetPendingMACCommands(p *redis.Pool, devEUI lorawan.EUI64) ([]MACCommandBlock, error) {
+	var out []MACCommandBlock
+
+	c := p.Get()
+	defer c.Close()
+
+	var keys []interface{}
+	for cid := 0; cid < 256; cid++ {
+		keys = append(keys, fmt.Sprintf(macCommandPendingTempl, devEUI, cid))
+	}
+
+	values, err := redis.Values(c.Do("MGET", keys...))
+	if err != nil {
+		return nil, errors.Wrap(err, "get pending mac-commands error")
+	}
+
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		b, ok := value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("expected []byte type, got %T", value)
+		}
+
+		var block MACCommandBlock
+		err = gob.NewDecoder(bytes.NewReader(b)).Decode(&block)
+		if err != nil {
+			return nil, errors.Wrap(err, "gob decode error")
+		}
+
+		out = append(out, block)
+	}
+
+	return out, nil
+}
+
 // DeletePendingMACCommand removes the pending mac-command for the given CID.
 func DeletePendingMACCommand(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID) error {
 	c := p.Get()
@@ -260,3 +319,49 @@ func DeletePendingMACCommand(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CI
 
 	return nil
 }
+
+// UpdatePendingMACCommands deletes the pending mac-commands for the given
+// CIDs and adds the given blocks to the mac-command queue, using a single
+// round-trip.
+func UpdatePendingMACCommands(p *redis.Pool, devEUI lorawan.EUI64, deleteCIDs []lorawan.CID, queueBlocks []MACCommandBlock) error {
+	if len(deleteCIDs) == 0 && len(queueBlocks) == 0 {
+		return nil
+	}
+
+	var queueItems []interface{}
+	for _, block := range queueBlocks {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(block); err != nil {
+			return errors.Wrap(err, "gob encode error")
+		}
+		queueItems = append(queueItems, buf.Bytes())
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	if len(deleteCIDs) != 0 {
+		var keys []interface{}
+		for _, cid := range deleteCIDs {
+			keys = append(keys, fmt.Sprintf(macCommandPendingTempl, devEUI, cid))
+		}
+		c.Send("DEL", keys...)
+	}
+	if len(queueItems) != 0 {
+		key := fmt.Sprintf(macCommandQueueTempl, devEUI)
+		c.Send("RPUSH", append([]interface{}{key}, queueItems...)...)
+		c.Send("PEXPIRE", key, int64(deviceSessionTTL)/int64(time.Millisecond))
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "update pending mac-commands error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":        devEUI,
+		"deleted_count":  len(deleteCIDs),
+		"requeued_count": len(queueBlocks),
+	}).Info("pending mac-commands updated")
+
+	return nil
+}
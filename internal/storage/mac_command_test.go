@@ -81,6 +81,40 @@ func TestMACCommand(t *testing.T) {
 				So(*block, ShouldResemble, macCommands[0])
 			})
 
+			Convey("Then all pending mac-commands can be retrieved", func() {
+				So(SetPendingMACCommand(RedisPool(), devEUI, macCommands[1]), ShouldBeNil)
+
+				blocks, err := GetPendingMACCommands(RedisPool(), devEUI)
+				So(err, ShouldBeNil)
+				So(blocks, ShouldResemble, macCommands)
+			})
+
+			Convey("When activating a device-session", func() {
+				So(CreateMACCommandQueueItem(RedisPool(), devEUI, macCommands[1]), ShouldBeNil)
+				So(ActivateDeviceSession(RedisPool(), DeviceSession{DevEUI: devEUI}), ShouldBeNil)
+
+				Convey("Then the queue and pending mac-commands have been flushed", func() {
+					block, err := GetPendingMACCommand(RedisPool(), devEUI, macCommands[0].CID)
+					So(err, ShouldBeNil)
+					So(block, ShouldBeNil)
+
+					blocks, err := GetMACCommandQueueItems(RedisPool(), devEUI)
+					So(err, ShouldBeNil)
+					So(blocks, ShouldHaveLength, 0)
+				})
+
+				Convey("When deleting the device-session", func() {
+					So(SetPendingMACCommand(RedisPool(), devEUI, macCommands[0]), ShouldBeNil)
+					So(DeleteDeviceSession(RedisPool(), devEUI), ShouldBeNil)
+
+					Convey("Then the pending mac-command has been removed", func() {
+						block, err := GetPendingMACCommand(RedisPool(), devEUI, macCommands[0].CID)
+						So(err, ShouldBeNil)
+						So(block, ShouldBeNil)
+					})
+				})
+			})
+
 			Convey("When deleting a pending mac-command", func() {
 				So(DeletePendingMACCommand(RedisPool(), devEUI, macCommands[0].CID), ShouldBeNil)
 
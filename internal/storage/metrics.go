@@ -227,3 +227,77 @@ func GetMetrics(p *redis.Pool, agg AggregationInterval, name string, start, end
 
 	return out, nil
 }
+
+// GetMetricsForLocation returns the metrics for the requested aggregation
+// interval, with the timestamps in the given time location.
+// As the stored aggregations are truncated using the configured time
+// location, the DAY and MONTH intervals are aggregated from the HOUR
+// aggregation when the given location differs. In this case
+// ErrMetricsRangeExceedsTTL is returned when the requested range starts
+// before the HOUR aggregation TTL, the first DAY or MONTH only contains the
+// hours that are still stored. For locations with a non-hour offset, the day
+// boundary is rounded to the hour of the configured location.
+func GetMetricsForLocation(p *redis.Pool, agg AggregationInterval, name string, start, end time.Time, loc *time.Location) ([]MetricsRecord, error) {
+	if loc.String() == timeLocation.String() || (agg != AggregationDay && agg != AggregationMonth) {
+		metrics, err := GetMetrics(p, agg, name, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range metrics {
+			metrics[i].Time = metrics[i].Time.In(loc)
+		}
+
+		return metrics, nil
+	}
+
+	truncate := func(ts time.Time) time.Time {
+		ts = ts.In(loc)
+		if agg == AggregationMonth {
+			return time.Date(ts.Year(), ts.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, loc)
+	}
+
+	next := func(ts time.Time) time.Time {
+		if agg == AggregationMonth {
+			return ts.AddDate(0, 1, 0)
+		}
+		return ts.AddDate(0, 0, 1)
+	}
+
+	if start.Before(time.Now().Add(-metricsHourTTL)) {
+		return nil, ErrMetricsRangeExceedsTTL
+	}
+
+	start = truncate(start)
+	end = truncate(end)
+
+	var out []MetricsRecord
+	index := make(map[int64]int)
+	for ts := start; !ts.After(end); ts = next(ts) {
+		index[ts.Unix()] = len(out)
+		out = append(out, MetricsRecord{
+			Time:    ts,
+			Metrics: make(map[string]float64),
+		})
+	}
+
+	hours, err := GetMetrics(p, AggregationHour, name, start, next(end).Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range hours {
+		i, ok := index[truncate(h.Time).Unix()]
+		if !ok {
+			continue
+		}
+
+		for k, v := range h.Metrics {
+			out[i].Metrics[k] += v
+		}
+	}
+
+	return out, nil
+}
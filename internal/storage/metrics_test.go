@@ -375,3 +375,73 @@ func (ts *StorageTestSuite) TestMetrics() {
 		})
 	}
 }
+
+func (ts *StorageTestSuite) TestMetricsForLocation() {
+	assert := require.New(ts.T())
+
+	utc := time.UTC
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	assert.NoError(err)
+
+	SetMetricsTTL(time.Minute, 48*time.Hour, time.Minute, time.Minute)
+	assert.NoError(SetTimeLocation("UTC"))
+	test.MustFlushRedis(ts.RedisPool())
+
+	// Sydney is UTC+10 or UTC+11, the two records are on different UTC days
+	// but on the same day in Sydney
+	now := time.Now().In(sydney)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, sydney)
+	utcDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, utc)
+
+	for _, m := range []MetricsRecord{
+		{Time: day.Add(8*time.Hour + 30*time.Minute), Metrics: map[string]float64{"foo": 1}},
+		{Time: day.Add(14*time.Hour + 30*time.Minute), Metrics: map[string]float64{"foo": 2}},
+	} {
+		for _, agg := range []AggregationInterval{AggregationHour, AggregationDay} {
+			assert.NoError(SaveMetricsForInterval(ts.RedisPool(), agg, "metrics_test", m))
+		}
+	}
+
+	ts.T().Run("configured location", func(t *testing.T) {
+		assert := require.New(t)
+
+		metrics, err := GetMetricsForLocation(ts.RedisPool(), AggregationDay, "metrics_test", utcDay.AddDate(0, 0, -1), utcDay, utc)
+		assert.NoError(err)
+		assert.EqualValues([]MetricsRecord{
+			{Time: utcDay.AddDate(0, 0, -1), Metrics: map[string]float64{"foo": 1}},
+			{Time: utcDay, Metrics: map[string]float64{"foo": 2}},
+		}, metrics)
+	})
+
+	ts.T().Run("other location", func(t *testing.T) {
+		assert := require.New(t)
+
+		metrics, err := GetMetricsForLocation(ts.RedisPool(), AggregationDay, "metrics_test", day, day.AddDate(0, 0, 1), sydney)
+		assert.NoError(err)
+		assert.EqualValues([]MetricsRecord{
+			{Time: day, Metrics: map[string]float64{"foo": 3}},
+			{Time: day.AddDate(0, 0, 1), Metrics: map[string]float64{}},
+		}, metrics)
+	})
+
+	ts.T().Run("other location month", func(t *testing.T) {
+		assert := require.New(t)
+
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, sydney)
+
+		// the month starts before the hour aggregation ttl, the requested
+		// range does not
+		metrics, err := GetMetricsForLocation(ts.RedisPool(), AggregationMonth, "metrics_test", now, now, sydney)
+		assert.NoError(err)
+		assert.EqualValues([]MetricsRecord{
+			{Time: month, Metrics: map[string]float64{"foo": 3}},
+		}, metrics)
+	})
+
+	ts.T().Run("other location exceeding the hour aggregation ttl", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetMetricsForLocation(ts.RedisPool(), AggregationDay, "metrics_test", day.AddDate(0, 0, -3), day, sydney)
+		assert.Equal(ErrMetricsRangeExceedsTTL, err)
+	})
+}
@@ -1,13 +1,17 @@
 package storage
 
 import (
+	"context"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/lorawan"
 )
 
@@ -391,3 +395,26 @@ func GetMaxScheduleAtForMulticastGroup(db sqlx.Queryer, multicastGroupID uuid.UU
 	}
 	return time.Time{}, nil
 }
+
+// SendMulticastDownlinkStatus notifies the application-server about the
+// status of the given multicast queue-item. Application-servers not
+// implementing the HandleMulticastDownlinkStatus method are ignored.
+func SendMulticastDownlinkStatus(asClient as.ApplicationServerServiceClient, qi MulticastQueueItem, s as.DownlinkStatus) error {
+	ctx, cancel := context.WithTimeout(context.Background(), downlinkStatusTimeout)
+	defer cancel()
+
+	_, err := asClient.HandleMulticastDownlinkStatus(ctx, &as.HandleMulticastDownlinkStatusRequest{
+		MulticastGroupId: qi.MulticastGroupID.Bytes(),
+		FCnt:             qi.FCnt,
+		GatewayId:        qi.GatewayID[:],
+		Status:           s,
+	})
+	if err != nil {
+		if grpc.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const passiveRoamingSessionKeyTempl = "lora:ns:roaming:devaddr:%s"
+
+// SavePassiveRoamingSession stores the passive-roaming session for the given
+// DevAddr, with the NetID of the serving network-server. The session expires
+// after the given lifetime.
+func SavePassiveRoamingSession(p *redis.Pool, devAddr lorawan.DevAddr, netID lorawan.NetID, lifetime time.Duration) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(passiveRoamingSessionKeyTempl, devAddr), int64(lifetime/time.Millisecond), netID[:])
+	if err != nil {
+		return errors.Wrap(err, "save passive-roaming session error")
+	}
+
+	return nil
+}
+
+// GetPassiveRoamingSession returns the NetID of the serving network-server
+// for the given DevAddr. It returns ErrDoesNotExist when there is no
+// (active) passive-roaming session.
+func GetPassiveRoamingSession(p *redis.Pool, devAddr lorawan.DevAddr) (lorawan.NetID, error) {
+	var netID lorawan.NetID
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(passiveRoamingSessionKeyTempl, devAddr)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return netID, ErrDoesNotExist
+		}
+		return netID, errors.Wrap(err, "get passive-roaming session error")
+	}
+
+	if err := netID.UnmarshalBinary(b); err != nil {
+		return netID, errors.Wrap(err, "unmarshal netid error")
+	}
+
+	return netID, nil
+}
+
+// DeletePassiveRoamingSession removes the passive-roaming session for the
+// given DevAddr.
+func DeletePassiveRoamingSession(p *redis.Pool, devAddr lorawan.DevAddr) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(passiveRoamingSessionKeyTempl, devAddr)); err != nil {
+		return errors.Wrap(err, "delete passive-roaming session error")
+	}
+
+	return nil
+}
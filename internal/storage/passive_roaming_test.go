@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestPassiveRoamingSession() {
+	devAddr := lorawan.DevAddr{1, 2, 3, 4}
+	netID := lorawan.NetID{1, 2, 3}
+
+	ts.T().Run("Does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetPassiveRoamingSession(ts.RedisPool(), devAddr)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(SavePassiveRoamingSession(ts.RedisPool(), devAddr, netID, time.Minute))
+
+		n, err := GetPassiveRoamingSession(ts.RedisPool(), devAddr)
+		assert.NoError(err)
+		assert.Equal(netID, n)
+
+		t.Run("Delete", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(DeletePassiveRoamingSession(ts.RedisPool(), devAddr))
+
+			_, err := GetPassiveRoamingSession(ts.RedisPool(), devAddr)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+	})
+}
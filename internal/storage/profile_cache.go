@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const (
+	deviceProfileCacheKeyTempl  = "dp:%s"
+	serviceProfileCacheKeyTempl = "sp:%s"
+	routingProfileCacheKeyTempl = "rp:%s"
+)
+
+// profileCache implements an in-memory cache for the device-, service- and
+// routing-profiles. Items expire after the configured TTL so that changes
+// made on other instances are eventually picked up. Changes made through
+// the API of this instance are invalidated directly. Expired items are
+// removed every TTL, so that deleted profiles do not pile up.
+type profileCache struct {
+	sync.RWMutex
+	ttl      time.Duration
+	disabled bool
+	items    map[string]profileCacheItem
+	done     chan struct{}
+}
+
+type profileCacheItem struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+var profiles = newProfileCache(time.Minute, false)
+
+func newProfileCache(ttl time.Duration, disabled bool) *profileCache {
+	c := profileCache{
+		ttl:      ttl,
+		disabled: disabled,
+		items:    make(map[string]profileCacheItem),
+		done:     make(chan struct{}),
+	}
+
+	if !disabled && ttl > 0 {
+		go c.sweepLoop()
+	}
+
+	return &c
+}
+
+// close stops the removal of expired items.
+func (c *profileCache) close() {
+	close(c.done)
+}
+
+func (c *profileCache) sweepLoop() {
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// sweep removes the expired items.
+func (c *profileCache) sweep() {
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	for k, item := range c.items {
+		if now.After(item.expiresAt) {
+			delete(c.items, k)
+		}
+	}
+}
+
+// get returns the cached value for the given key.
+func (c *profileCache) get(key string) (interface{}, bool) {
+	if c.disabled {
+		return nil, false
+	}
+
+	c.RLock()
+	item, ok := c.items[key]
+	c.RUnlock()
+
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+// set caches the given value.
+func (c *profileCache) set(key string, value interface{}) {
+	if c.disabled {
+		return
+	}
+
+	c.Lock()
+	c.items[key] = profileCacheItem{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.Unlock()
+}
+
+// flush removes the given key from the cache.
+func (c *profileCache) flush(key string) {
+	c.Lock()
+	delete(c.items, key)
+	c.Unlock()
+}
+
+// GetAndCacheRoutingProfile returns the routing-profile from the in-memory
+// cache in case available, else it will be retrieved from the database and
+// then stored in cache.
+func GetAndCacheRoutingProfile(db sqlx.Queryer, id uuid.UUID) (RoutingProfile, error) {
+	key := fmt.Sprintf(routingProfileCacheKeyTempl, id)
+	if v, ok := profiles.get(key); ok {
+		return v.(RoutingProfile), nil
+	}
+
+	rp, err := GetRoutingProfile(db, id)
+	if err != nil {
+		return RoutingProfile{}, errors.Wrap(err, "get routing-profile error")
+	}
+
+	profiles.set(key, rp)
+
+	return rp, nil
+}
+
+// FlushRoutingProfileCache deletes a cached routing-profile.
+func FlushRoutingProfileCache(id uuid.UUID) {
+	profiles.flush(fmt.Sprintf(routingProfileCacheKeyTempl, id))
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileCache(t *testing.T) {
+	t.Run("get and flush", func(t *testing.T) {
+		assert := require.New(t)
+		c := newProfileCache(time.Minute, false)
+		defer c.close()
+
+		_, ok := c.get("dp:foo")
+		assert.False(ok)
+
+		c.set("dp:foo", DeviceProfile{MACVersion: "1.0.2"})
+		v, ok := c.get("dp:foo")
+		assert.True(ok)
+		assert.Equal(DeviceProfile{MACVersion: "1.0.2"}, v)
+
+		c.flush("dp:foo")
+		_, ok = c.get("dp:foo")
+		assert.False(ok)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		assert := require.New(t)
+		c := newProfileCache(time.Millisecond, false)
+		defer c.close()
+
+		c.set("dp:foo", DeviceProfile{})
+		time.Sleep(2 * time.Millisecond)
+
+		_, ok := c.get("dp:foo")
+		assert.False(ok)
+
+		// expired items are removed by the sweep
+		time.Sleep(10 * time.Millisecond)
+		c.RLock()
+		assert.Len(c.items, 0)
+		c.RUnlock()
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert := require.New(t)
+		c := newProfileCache(time.Minute, true)
+		defer c.close()
+
+		c.set("dp:foo", DeviceProfile{})
+		_, ok := c.get("dp:foo")
+		assert.False(ok)
+	})
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pqd = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "storage_postgresql_query_duration_seconds",
+		Help: "The duration of the executed PostgreSQL queries.",
+	})
+
+	rcd = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "storage_redis_command_duration_seconds",
+		Help: "The duration of the executed Redis commands (per command).",
+	}, []string{"command"})
+
+	sfvc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "storage_skip_fcnt_validation_count",
+		Help: "The number of uplinks accepted with an invalid frame-counter because frame-counter validation is skipped.",
+	})
+)
+
+func postgreSQLQueryDuration() prometheus.Observer {
+	return pqd
+}
+
+func skipFCntValidationCounter() prometheus.Counter {
+	return sfvc
+}
+
+func redisCommandDuration(cmd string) prometheus.Observer {
+	// an empty command flushes and receives the pipelined commands
+	if cmd == "" {
+		cmd = "PIPELINE"
+	}
+	return rcd.With(prometheus.Labels{"command": strings.ToUpper(cmd)})
+}
+
+// redisConnMetrics wraps a Redis connection to record the command durations.
+type redisConnMetrics struct {
+	redis.Conn
+}
+
+// Do records the duration of the executed command.
+func (c redisConnMetrics) Do(cmd string, args ...interface{}) (interface{}, error) {
+	start := time.Now()
+	reply, err := c.Conn.Do(cmd, args...)
+	redisCommandDuration(cmd).Observe(time.Since(start).Seconds())
+	return reply, err
+}
+
+// DoWithTimeout records the duration of the executed command.
+func (c redisConnMetrics) DoWithTimeout(timeout time.Duration, cmd string, args ...interface{}) (interface{}, error) {
+	start := time.Now()
+	reply, err := redis.DoWithTimeout(c.Conn, timeout, cmd, args...)
+	redisCommandDuration(cmd).Observe(time.Since(start).Seconds())
+	return reply, err
+}
+
+// ReceiveWithTimeout implements redis.ConnWithTimeout.
+func (c redisConnMetrics) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	return redis.ReceiveWithTimeout(c.Conn, timeout)
+}
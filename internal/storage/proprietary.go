@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayProprietaryUplinkCountKeyTempl = "lora:ns:gw:%s:proprietary:count"
+
+// IncrGatewayProprietaryUplinkCount increments and returns the number of
+// proprietary uplink frames received by the given gateway within the
+// current interval. The counter is reset after each interval.
+func IncrGatewayProprietaryUplinkCount(p *redis.Pool, gatewayID lorawan.EUI64, interval time.Duration) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayProprietaryUplinkCountKeyTempl, gatewayID)
+
+	c.Send("MULTI")
+	c.Send("SET", key, 0, "PX", int64(interval/time.Millisecond), "NX")
+	c.Send("INCR", key)
+	vals, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return 0, errors.Wrap(err, "redis exec error")
+	}
+
+	count, err := redis.Int(vals[1], nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "read proprietary uplink count error")
+	}
+
+	return count, nil
+}
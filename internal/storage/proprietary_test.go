@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestIncrGatewayProprietaryUplinkCount() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	for i := 1; i <= 3; i++ {
+		count, err := IncrGatewayProprietaryUplinkCount(ts.RedisPool(), gatewayID, 100*time.Millisecond)
+		assert.NoError(err)
+		assert.Equal(i, count)
+	}
+
+	// the counter is reset after the interval
+	time.Sleep(150 * time.Millisecond)
+
+	count, err := IncrGatewayProprietaryUplinkCount(ts.RedisPool(), gatewayID, 100*time.Millisecond)
+	assert.NoError(err)
+	assert.Equal(1, count)
+}
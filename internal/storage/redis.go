@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/config"
+)
+
+// errRedisReadOnly is returned by the connection Err method after the
+// connected node rejected a write, e.g. after a Sentinel failover.
+var errRedisReadOnly = errors.New("redis node is read-only")
+
+// newRedisPool returns a new Redis connection pool. When Sentinel is
+// configured, connections are made to the master as reported by the
+// Sentinels, else to the configured URL.
+func newRedisPool(c config.Config) *redis.Pool {
+	dial := func() (redis.Conn, error) {
+		return dialRedis(c.Redis.URL)
+	}
+
+	sentinel := c.Redis.SentinelMasterName != ""
+	if sentinel {
+		dial = func() (redis.Conn, error) {
+			return dialRedisSentinelMaster(c.Redis.URL, c.Redis.SentinelMasterName, c.Redis.SentinelAddrs)
+		}
+	}
+
+	return &redis.Pool{
+		MaxIdle:     c.Redis.MaxIdle,
+		MaxActive:   c.Redis.MaxActive,
+		IdleTimeout: c.Redis.IdleTimeout,
+		Wait:        true,
+		Dial: func() (redis.Conn, error) {
+			c, err := dial()
+			if err != nil {
+				return nil, fmt.Errorf("redis connection error: %s", err)
+			}
+			return redisConnMetrics{c}, nil
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Now().Sub(t) < onBorrowPingInterval {
+				return nil
+			}
+
+			if sentinel {
+				// after a failover, the old master becomes a replica
+				role, err := redis.Values(c.Do("ROLE"))
+				if err != nil {
+					return fmt.Errorf("get redis role error: %s", err)
+				}
+				if len(role) == 0 || fmt.Sprintf("%s", role[0]) != "master" {
+					return errRedisReadOnly
+				}
+				return nil
+			}
+
+			_, err := c.Do("PING")
+			if err != nil {
+				return fmt.Errorf("ping redis error: %s", err)
+			}
+			return nil
+		},
+	}
+}
+
+func dialRedis(redisURL string) (redis.Conn, error) {
+	return redis.DialURL(redisURL,
+		redis.DialReadTimeout(redisDialReadTimeout),
+		redis.DialWriteTimeout(redisDialWriteTimeout),
+	)
+}
+
+// dialRedisSentinelMaster connects to the master as reported by the first
+// reachable Sentinel. The host of the given URL is replaced by the master
+// address, the other URL parts (e.g. password and database) are used as-is.
+func dialRedisSentinelMaster(redisURL, masterName string, sentinelAddrs []string) (redis.Conn, error) {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse url error")
+	}
+
+	var errs []string
+	for _, addr := range sentinelAddrs {
+		masterAddr, err := getRedisSentinelMasterAddr(addr, masterName)
+		if err != nil {
+			log.WithError(err).WithField("sentinel", addr).Warning("storage: get master address from redis sentinel error")
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		u.Host = masterAddr
+		c, err := dialRedis(u.String())
+		if err != nil {
+			return nil, errors.Wrap(err, "dial redis master error")
+		}
+
+		return &sentinelConn{Conn: c}, nil
+	}
+
+	return nil, fmt.Errorf("no redis sentinel available: %s", strings.Join(errs, ", "))
+}
+
+// getRedisSentinelMasterAddr returns the address of the master with the
+// given name from the Sentinel.
+func getRedisSentinelMasterAddr(sentinelAddr, masterName string) (string, error) {
+	c, err := redis.Dial("tcp", sentinelAddr,
+		redis.DialConnectTimeout(redisDialWriteTimeout),
+		redis.DialReadTimeout(redisDialWriteTimeout),
+		redis.DialWriteTimeout(redisDialWriteTimeout),
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "dial sentinel error")
+	}
+	defer c.Close()
+
+	res, err := redis.Strings(c.Do("SENTINEL", "get-master-addr-by-name", masterName))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", fmt.Errorf("unknown master: %s", masterName)
+		}
+		return "", errors.Wrap(err, "get master address error")
+	}
+
+	if len(res) != 2 {
+		return "", fmt.Errorf("expected host and port, got: %v", res)
+	}
+
+	return net.JoinHostPort(res[0], res[1]), nil
+}
+
+// sentinelConn wraps the connection to the Sentinel master. When the node
+// rejects a write because it is no longer the master, the connection
+// reports an error so that it is not returned to the pool.
+type sentinelConn struct {
+	redis.Conn
+	readOnly bool
+}
+
+// Err returns errRedisReadOnly after the node rejected a write.
+func (c *sentinelConn) Err() error {
+	if c.readOnly {
+		return errRedisReadOnly
+	}
+	return c.Conn.Err()
+}
+
+// Do executes the given command.
+func (c *sentinelConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	reply, err := c.Conn.Do(cmd, args...)
+	c.checkReadOnly(reply, err)
+	return reply, err
+}
+
+// DoWithTimeout implements redis.ConnWithTimeout.
+func (c *sentinelConn) DoWithTimeout(timeout time.Duration, cmd string, args ...interface{}) (interface{}, error) {
+	reply, err := redis.DoWithTimeout(c.Conn, timeout, cmd, args...)
+	c.checkReadOnly(reply, err)
+	return reply, err
+}
+
+// ReceiveWithTimeout implements redis.ConnWithTimeout.
+func (c *sentinelConn) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	return redis.ReceiveWithTimeout(c.Conn, timeout)
+}
+
+func (c *sentinelConn) checkReadOnly(reply interface{}, err error) {
+	if isRedisReadOnlyError(err) {
+		c.readOnly = true
+		return
+	}
+
+	// errors of a pipelined command are returned as part of the reply
+	if values, ok := reply.([]interface{}); ok {
+		for _, v := range values {
+			if e, ok := v.(redis.Error); ok && isRedisReadOnlyError(e) {
+				c.readOnly = true
+				return
+			}
+		}
+	}
+}
+
+func isRedisReadOnlyError(err error) bool {
+	e, ok := err.(redis.Error)
+	return ok && strings.HasPrefix(string(e), "READONLY")
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeSentinel starts a Sentinel which replies to all commands with the
+// given RESP encoded reply. It returns the address and a function to stop it.
+func startFakeSentinel(t *testing.T, reply string) (string, func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+
+				// *3\r\n followed by three bulk strings ($n\r\nvalue\r\n)
+				for i := 0; i < 7; i++ {
+					if _, err := r.ReadString('\n'); err != nil {
+						return
+					}
+				}
+				fmt.Fprint(conn, reply)
+			}(conn)
+		}
+	}()
+
+	return ln.Addr().String(), func() { ln.Close() }
+}
+
+func TestGetRedisSentinelMasterAddr(t *testing.T) {
+	t.Run("master known", func(t *testing.T) {
+		assert := require.New(t)
+		addr, stop := startFakeSentinel(t, "*2\r\n$8\r\n10.0.0.1\r\n$4\r\n6380\r\n")
+		defer stop()
+
+		masterAddr, err := getRedisSentinelMasterAddr(addr, "mymaster")
+		assert.NoError(err)
+		assert.Equal("10.0.0.1:6380", masterAddr)
+	})
+
+	t.Run("master unknown", func(t *testing.T) {
+		assert := require.New(t)
+		addr, stop := startFakeSentinel(t, "*-1\r\n")
+		defer stop()
+
+		_, err := getRedisSentinelMasterAddr(addr, "mymaster")
+		assert.EqualError(err, "unknown master: mymaster")
+	})
+
+	t.Run("no sentinel available", func(t *testing.T) {
+		assert := require.New(t)
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(err)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		_, err = dialRedisSentinelMaster("redis://localhost:6379", "mymaster", []string{addr})
+		assert.Error(err)
+	})
+}
+
+type fakeRedisConn struct {
+	redis.Conn
+	reply interface{}
+	err   error
+}
+
+func (c fakeRedisConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	return c.reply, c.err
+}
+
+func (c fakeRedisConn) Err() error {
+	return nil
+}
+
+func TestSentinelConnReadOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    interface{}
+		err      error
+		readOnly bool
+	}{
+		{
+			name:  "ok",
+			reply: "OK",
+		},
+		{
+			name: "other error",
+			err:  redis.Error("ERR wrong number of arguments"),
+		},
+		{
+			name:     "read-only error",
+			err:      redis.Error("READONLY You can't write against a read only replica."),
+			readOnly: true,
+		},
+		{
+			name:     "read-only error in pipeline reply",
+			reply:    []interface{}{"OK", redis.Error("READONLY You can't write against a read only replica.")},
+			readOnly: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			c := sentinelConn{Conn: fakeRedisConn{reply: tst.reply, err: tst.err}}
+
+			c.Do("SET", "foo", "bar")
+			if tst.readOnly {
+				assert.Equal(errRedisReadOnly, c.Err())
+			} else {
+				assert.NoError(c.Err())
+			}
+		})
+	}
+}
@@ -51,6 +51,8 @@ type ServiceProfile struct {
 	NwkGeoLoc              bool       `db:"nwk_geo_loc"`
 	TargetPER              int        `db:"target_per"` // Example: 10 indicates 10%
 	MinGWDiversity         int        `db:"min_gw_diversity"`
+	ULAirtimeBudget        int        `db:"ul_airtime_budget"` // Unit: milliseconds per 24 hours, 0 = unlimited
+	DLAirtimeBudget        int        `db:"dl_airtime_budget"` // Unit: milliseconds per 24 hours, 0 = unlimited
 }
 
 // CreateServiceProfile creates the given service-profile.
@@ -92,8 +94,10 @@ func CreateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 			ra_allowed,
 			nwk_geo_loc,
 			target_per,
-			min_gw_diversity
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			min_gw_diversity,
+			ul_airtime_budget,
+			dl_airtime_budget
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)`,
 		sp.CreatedAt,
 		sp.UpdatedAt,
 		sp.ID,
@@ -116,6 +120,8 @@ func CreateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.ULAirtimeBudget,
+		sp.DLAirtimeBudget,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -179,6 +185,8 @@ func GetServiceProfileCache(p *redis.Pool, id uuid.UUID) (ServiceProfile, error)
 
 // FlushServiceProfileCache deletes a cached service-profile.
 func FlushServiceProfileCache(p *redis.Pool, id uuid.UUID) error {
+	profiles.flush(fmt.Sprintf(serviceProfileCacheKeyTempl, id))
+
 	key := fmt.Sprintf(ServiceProfileKeyTempl, id)
 	c := p.Get()
 	defer c.Close()
@@ -194,8 +202,14 @@ func FlushServiceProfileCache(p *redis.Pool, id uuid.UUID) error {
 // available, else it will be retrieved from the database and then stored
 // in cache.
 func GetAndCacheServiceProfile(db sqlx.Queryer, p *redis.Pool, id uuid.UUID) (ServiceProfile, error) {
+	memKey := fmt.Sprintf(serviceProfileCacheKeyTempl, id)
+	if v, ok := profiles.get(memKey); ok {
+		return v.(ServiceProfile), nil
+	}
+
 	sp, err := GetServiceProfileCache(p, id)
 	if err == nil {
+		profiles.set(memKey, sp)
 		return sp, nil
 	}
 
@@ -211,6 +225,8 @@ func GetAndCacheServiceProfile(db sqlx.Queryer, p *redis.Pool, id uuid.UUID) (Se
 		return ServiceProfile{}, errors.Wrap(err, "get service-profile-error")
 	}
 
+	profiles.set(memKey, sp)
+
 	err = CreateServiceProfileCache(p, sp)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -258,7 +274,9 @@ func UpdateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 			ra_allowed = $18,
 			nwk_geo_loc = $19,
 			target_per = $20,
-			min_gw_diversity = $21
+			min_gw_diversity = $21,
+			ul_airtime_budget = $22,
+			dl_airtime_budget = $23
 		where
 			service_profile_id = $1`,
 		sp.ID,
@@ -282,6 +300,8 @@ func UpdateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.ULAirtimeBudget,
+		sp.DLAirtimeBudget,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -41,6 +41,8 @@ func TestServiceProfile(t *testing.T) {
 				NwkGeoLoc:              true,
 				TargetPER:              1,
 				MinGWDiversity:         8,
+				ULAirtimeBudget:        60000,
+				DLAirtimeBudget:        30000,
 			}
 
 			So(CreateServiceProfile(DB(), &sp), ShouldBeNil)
@@ -76,6 +78,8 @@ func TestServiceProfile(t *testing.T) {
 				sp.NwkGeoLoc = false
 				sp.TargetPER = 2
 				sp.MinGWDiversity = 9
+				sp.ULAirtimeBudget = 0
+				sp.DLAirtimeBudget = 36000
 
 				So(UpdateServiceProfile(DB(), &sp), ShouldBeNil)
 				sp.UpdatedAt = sp.UpdatedAt.UTC().Truncate(time.Millisecond)
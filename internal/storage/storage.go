@@ -1,10 +1,8 @@
 package storage
 
 import (
-	"fmt"
 	"time"
 
-	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	migrate "github.com/rubenv/sql-migrate"
@@ -17,6 +15,13 @@ import (
 // deviceSessionTTL holds the device-session TTL.
 var deviceSessionTTL time.Duration
 
+// deviceQueueItemTTL holds the device-queue item TTL.
+var deviceQueueItemTTL time.Duration
+
+// abpFCntResetMaxFCnt holds the max. frame-counter of an uplink which
+// is classified as frame-counter reset.
+var abpFCntResetMaxFCnt uint32
+
 // schedulerInterval holds the interval in which the Class-B and -C
 // scheduler runs.
 var schedulerInterval time.Duration
@@ -26,36 +31,14 @@ func Setup(c config.Config) error {
 	log.Info("storage: setting up storage module")
 
 	deviceSessionTTL = c.NetworkServer.DeviceSessionTTL
+	deviceQueueItemTTL = c.NetworkServer.DeviceQueueItemTTL
 	schedulerInterval = c.NetworkServer.Scheduler.SchedulerInterval
+	abpFCntResetMaxFCnt = c.NetworkServer.NetworkSettings.ABPFCntResetMaxFCnt
+	profiles.close()
+	profiles = newProfileCache(c.PostgreSQL.ProfileCacheTTL, c.PostgreSQL.DisableProfileCache)
 
 	log.Info("storage: setting up Redis connection pool")
-	redisPool = &redis.Pool{
-		MaxIdle:     c.Redis.MaxIdle,
-		MaxActive:   c.Redis.MaxActive,
-		IdleTimeout: c.Redis.IdleTimeout,
-		Wait:        true,
-		Dial: func() (redis.Conn, error) {
-			c, err := redis.DialURL(c.Redis.URL,
-				redis.DialReadTimeout(redisDialReadTimeout),
-				redis.DialWriteTimeout(redisDialWriteTimeout),
-			)
-			if err != nil {
-				return nil, fmt.Errorf("redis connection error: %s", err)
-			}
-			return c, err
-		},
-		TestOnBorrow: func(c redis.Conn, t time.Time) error {
-			if time.Now().Sub(t) < onBorrowPingInterval {
-				return nil
-			}
-
-			_, err := c.Do("PING")
-			if err != nil {
-				return fmt.Errorf("ping redis error: %s", err)
-			}
-			return nil
-		},
-	}
+	redisPool = newRedisPool(c)
 
 	log.Info("storage: connecting to PostgreSQL")
 	d, err := sqlx.Open("postgres", c.PostgreSQL.DSN)
@@ -111,3 +94,16 @@ func Transaction(f func(tx sqlx.Ext) error) error {
 	}
 	return nil
 }
+
+// Close closes the Redis connection pool and the PostgreSQL connection.
+func Close() error {
+	if err := redisPool.Close(); err != nil {
+		return errors.Wrap(err, "storage: close redis pool error")
+	}
+
+	if err := db.Close(); err != nil {
+		return errors.Wrap(err, "storage: close postgresql error")
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/gomodule/redigo/redis"
 	migrate "github.com/rubenv/sql-migrate"
@@ -52,6 +53,8 @@ func GetConfig() config.Config {
 
 	c.Redis.URL = "redis://localhost:6379/1"
 	c.PostgreSQL.DSN = "postgres://localhost/loraserver_ns_test?sslmode=disable"
+	// profiles are updated directly in the database by the tests
+	c.PostgreSQL.DisableProfileCache = true
 
 	c.NetworkServer.NetID = lorawan.NetID{3, 2, 1}
 	c.NetworkServer.DeviceSessionTTL = time.Hour
@@ -187,7 +190,7 @@ type ApplicationServerPool struct {
 }
 
 // Get returns the Client.
-func (p *ApplicationServerPool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
+func (p *ApplicationServerPool) Get(routingProfileID uuid.UUID, hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
 	p.GetHostname = hostname
 	return p.Client, nil
 }
@@ -208,30 +211,39 @@ type ApplicationClient struct {
 	SetDeviceStatusError    error
 	SetDeviceLocationErrror error
 
-	HandleDataUpChan        chan as.HandleUplinkDataRequest
-	HandleProprietaryUpChan chan as.HandleProprietaryUplinkRequest
-	HandleErrorChan         chan as.HandleErrorRequest
-	HandleDownlinkACKChan   chan as.HandleDownlinkACKRequest
-	SetDeviceStatusChan     chan as.SetDeviceStatusRequest
-	SetDeviceLocationChan   chan as.SetDeviceLocationRequest
+	HandleDataUpChan         chan as.HandleUplinkDataRequest
+	HandleProprietaryUpChan  chan as.HandleProprietaryUplinkRequest
+	HandleErrorChan          chan as.HandleErrorRequest
+	HandleDownlinkACKChan    chan as.HandleDownlinkACKRequest
+	HandleDownlinkStatusChan chan as.HandleDownlinkStatusRequest
+	SetDeviceStatusChan      chan as.SetDeviceStatusRequest
+	SetDeviceLocationChan    chan as.SetDeviceLocationRequest
 
-	HandleDataUpResponse        empty.Empty
-	HandleProprietaryUpResponse empty.Empty
-	HandleErrorResponse         empty.Empty
-	HandleDownlinkACKResponse   empty.Empty
-	SetDeviceStatusResponse     empty.Empty
-	SetDeviceLocationResponse   empty.Empty
+	HandleMulticastDownlinkStatusChan chan as.HandleMulticastDownlinkStatusRequest
+
+	HandleDataUpResponse         empty.Empty
+	HandleProprietaryUpResponse  empty.Empty
+	HandleErrorResponse          empty.Empty
+	HandleDownlinkACKResponse    empty.Empty
+	HandleDownlinkStatusResponse empty.Empty
+	SetDeviceStatusResponse      empty.Empty
+	SetDeviceLocationResponse    empty.Empty
+
+	HandleMulticastDownlinkStatusResponse empty.Empty
 }
 
 // NewApplicationClient returns a new ApplicationClient.
 func NewApplicationClient() *ApplicationClient {
 	return &ApplicationClient{
-		HandleDataUpChan:        make(chan as.HandleUplinkDataRequest, 100),
-		HandleProprietaryUpChan: make(chan as.HandleProprietaryUplinkRequest, 100),
-		HandleErrorChan:         make(chan as.HandleErrorRequest, 100),
-		HandleDownlinkACKChan:   make(chan as.HandleDownlinkACKRequest, 100),
-		SetDeviceStatusChan:     make(chan as.SetDeviceStatusRequest, 100),
-		SetDeviceLocationChan:   make(chan as.SetDeviceLocationRequest, 100),
+		HandleDataUpChan:         make(chan as.HandleUplinkDataRequest, 100),
+		HandleProprietaryUpChan:  make(chan as.HandleProprietaryUplinkRequest, 100),
+		HandleErrorChan:          make(chan as.HandleErrorRequest, 100),
+		HandleDownlinkACKChan:    make(chan as.HandleDownlinkACKRequest, 100),
+		HandleDownlinkStatusChan: make(chan as.HandleDownlinkStatusRequest, 100),
+		SetDeviceStatusChan:      make(chan as.SetDeviceStatusRequest, 100),
+		SetDeviceLocationChan:    make(chan as.SetDeviceLocationRequest, 100),
+
+		HandleMulticastDownlinkStatusChan: make(chan as.HandleMulticastDownlinkStatusRequest, 100),
 	}
 }
 
@@ -265,6 +277,18 @@ func (t *ApplicationClient) HandleDownlinkACK(ctx context.Context, in *as.Handle
 	return &t.HandleDownlinkACKResponse, nil
 }
 
+// HandleDownlinkStatus method.
+func (t *ApplicationClient) HandleDownlinkStatus(ctx context.Context, in *as.HandleDownlinkStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	t.HandleDownlinkStatusChan <- *in
+	return &t.HandleDownlinkStatusResponse, nil
+}
+
+// HandleMulticastDownlinkStatus method.
+func (t *ApplicationClient) HandleMulticastDownlinkStatus(ctx context.Context, in *as.HandleMulticastDownlinkStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	t.HandleMulticastDownlinkStatusChan <- *in
+	return &t.HandleMulticastDownlinkStatusResponse, nil
+}
+
 // SetDeviceStatus method.
 func (t *ApplicationClient) SetDeviceStatus(ctx context.Context, in *as.SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	t.SetDeviceStatusChan <- *in
@@ -279,8 +303,9 @@ func (t *ApplicationClient) SetDeviceLocation(ctx context.Context, in *as.SetDev
 
 // NetworkControllerClient is a network-controller client for testing.
 type NetworkControllerClient struct {
-	HandleRXInfoChan           chan nc.HandleUplinkMetaDataRequest
-	HandleDataUpMACCommandChan chan nc.HandleUplinkMACCommandRequest
+	HandleRXInfoChan             chan nc.HandleUplinkMetaDataRequest
+	HandleDataUpMACCommandChan   chan nc.HandleUplinkMACCommandRequest
+	HandleGatewayStateChangeChan chan nc.HandleGatewayStateChangeRequest
 
 	HandleRXInfoResponse           empty.Empty
 	HandleDataUpMACCommandResponse empty.Empty
@@ -289,8 +314,9 @@ type NetworkControllerClient struct {
 // NewNetworkControllerClient returns a new NetworkControllerClient.
 func NewNetworkControllerClient() *NetworkControllerClient {
 	return &NetworkControllerClient{
-		HandleRXInfoChan:           make(chan nc.HandleUplinkMetaDataRequest, 100),
-		HandleDataUpMACCommandChan: make(chan nc.HandleUplinkMACCommandRequest, 100),
+		HandleRXInfoChan:             make(chan nc.HandleUplinkMetaDataRequest, 100),
+		HandleDataUpMACCommandChan:   make(chan nc.HandleUplinkMACCommandRequest, 100),
+		HandleGatewayStateChangeChan: make(chan nc.HandleGatewayStateChangeRequest, 100),
 	}
 }
 
@@ -306,6 +332,12 @@ func (t *NetworkControllerClient) HandleUplinkMACCommand(ctx context.Context, in
 	return &empty.Empty{}, nil
 }
 
+// HandleGatewayStateChange method.
+func (t *NetworkControllerClient) HandleGatewayStateChange(ctx context.Context, in *nc.HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	t.HandleGatewayStateChangeChan <- *in
+	return &empty.Empty{}, nil
+}
+
 // GeolocationClient is a geolocation client for testing.
 type GeolocationClient struct {
 	ResolveTDOAChan               chan geo.ResolveTDOARequest
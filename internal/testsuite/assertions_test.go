@@ -184,6 +184,10 @@ func AssertDeviceSession(ds storage.DeviceSession) Assertion {
 		assert.NotEqual(lorawan.DevAddr{}, sess.DevAddr)
 		sess.DevAddr = lorawan.DevAddr{}
 
+		// the version depends on the number of saves
+		assert.NotZero(sess.Version)
+		sess.Version = ds.Version
+
 		if sess.PendingRejoinDeviceSession != nil {
 			assert.NotEqual(lorawan.DevAddr{}, sess.PendingRejoinDeviceSession.DevAddr)
 			sess.PendingRejoinDeviceSession.DevAddr = lorawan.DevAddr{}
@@ -216,6 +220,19 @@ func AssertASHandleProprietaryUplinkRequest(req as.HandleProprietaryUplinkReques
 	}
 }
 
+// AssertNoASHandleProprietaryUplinkRequest asserts that there is no
+// proprietary uplink request.
+func AssertNoASHandleProprietaryUplinkRequest() Assertion {
+	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ts.ASClient.HandleProprietaryUpChan:
+			assert.Fail("unexpected proprietary uplink request")
+		default:
+		}
+	}
+}
+
 // AssertASHandleUplinkDataRequest asserts the given uplink request.
 func AssertASHandleUplinkDataRequest(req as.HandleUplinkDataRequest) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
@@ -226,6 +243,19 @@ func AssertASHandleUplinkDataRequest(req as.HandleUplinkDataRequest) Assertion {
 	}
 }
 
+// AssertNoASHandleUplinkDataRequest asserts that there is no uplink data
+// request.
+func AssertNoASHandleUplinkDataRequest() Assertion {
+	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ts.ASClient.HandleDataUpChan:
+			assert.Fail("unexpected uplink data request")
+		default:
+		}
+	}
+}
+
 // AssertASHandleDownlinkACKRequest asserts the given ack request.
 func AssertASHandleDownlinkACKRequest(req as.HandleDownlinkACKRequest) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
@@ -246,6 +276,17 @@ func AssertASHandleErrorRequest(req as.HandleErrorRequest) Assertion {
 	}
 }
 
+// AssertASHandleMulticastDownlinkStatusRequest asserts the given multicast
+// downlink status request.
+func AssertASHandleMulticastDownlinkStatusRequest(req as.HandleMulticastDownlinkStatusRequest) Assertion {
+	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+		r := <-ts.ASClient.HandleMulticastDownlinkStatusChan
+		if !proto.Equal(&r, &req) {
+			assert.Equal(req, r)
+		}
+	}
+}
+
 // AssertNCHandleUplinkMACCommandRequest asserts the given mac-command request.
 func AssertNCHandleUplinkMACCommandRequest(req nc.HandleUplinkMACCommandRequest) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
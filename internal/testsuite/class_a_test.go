@@ -301,6 +301,232 @@ func (ts *ClassATestSuite) TestLW10RelaxFrameCounter() {
 	}
 }
 
+func (ts *ClassATestSuite) TestLW10UplinkRetransmission() {
+	ts.CreateDeviceSession(storage.DeviceSession{
+		MACVersion:            "1.0.2",
+		JoinEUI:               lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+		FNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		NwkSEncKey:            [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		FCntUp:                8,
+		NFCntDown:             5,
+		EnabledUplinkChannels: []int{0, 1, 2},
+		RX2Frequency:          869525000,
+		LastUplinkMIC:         lorawan.MIC{48, 94, 26, 239},
+	})
+
+	var fPortOne uint8 = 1
+
+	// the device did not receive the ACK of its confirmed uplink and retries
+	confirmedPHY := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.ConfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ts.DeviceSession.DevAddr,
+				FCnt:    7,
+			},
+			FPort: &fPortOne,
+		},
+	}
+	ts.Require().NoError(confirmedPHY.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, ts.DeviceSession.FNwkSIntKey, ts.DeviceSession.SNwkSIntKey))
+
+	confirmedDS := *ts.DeviceSession
+	confirmedDS.NbTrans = 1
+	confirmedDS.LastUplinkMIC = confirmedPHY.MIC
+
+	tests := []ClassATest{
+		{
+			Name:          "confirmed retransmission with nb_trans 1 is acknowledged",
+			DeviceSession: confirmedDS,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload:    confirmedPHY,
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(6),
+				AssertNoASHandleUplinkDataRequest(),
+				func(assert *require.Assertions, ts *IntegrationTestSuite) {
+					downlinkFrame := <-ts.GWBackend.TXPacketChan
+
+					var phy lorawan.PHYPayload
+					assert.NoError(phy.UnmarshalBinary(downlinkFrame.PhyPayload))
+					macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+					assert.True(ok)
+					assert.True(macPL.FHDR.FCtrl.ACK)
+				},
+			},
+		},
+		{
+			Name:          "retransmission of the last uplink",
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ts.DeviceSession.DevAddr,
+						FCnt:    7,
+					},
+					FPort: &fPortOne,
+				},
+				MIC: lorawan.MIC{48, 94, 26, 239},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(5),
+				AssertNoASHandleUplinkDataRequest(),
+				func(assert *require.Assertions, ts *IntegrationTestSuite) {
+					ds, err := storage.GetDeviceSession(storage.RedisPool(), ts.Device.DevEUI)
+					assert.NoError(err)
+					assert.EqualValues(1, ds.UplinkRetransmissionCount)
+				},
+			},
+		},
+		{
+			Name:          "unconfirmed retransmission does not trigger a downlink",
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.Device.DevEUI, FPort: 1, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ts.DeviceSession.DevAddr,
+						FCnt:    7,
+					},
+					FPort: &fPortOne,
+				},
+				MIC: lorawan.MIC{48, 94, 26, 239},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(5),
+				AssertNoASHandleUplinkDataRequest(),
+				AssertNoDownlinkFrame,
+				AssertDeviceQueueItems([]storage.DeviceQueueItem{
+					{DevEUI: ts.Device.DevEUI, FPort: 1, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+				}),
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			ts.AssertClassATest(t, tst)
+		})
+	}
+}
+
+func (ts *ClassATestSuite) TestLW10ABPFCntReset() {
+	ts.CreateDeviceSession(storage.DeviceSession{
+		MACVersion:            "1.0.2",
+		JoinEUI:               lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+		FNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		NwkSEncKey:            [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		FCntUp:                8,
+		NFCntDown:             5,
+		EnabledUplinkChannels: []int{0, 1, 2},
+		RX2Frequency:          869525000,
+		ABPFCntReset:          true,
+	})
+
+	var fPortOne uint8 = 1
+
+	// a replay of a previous uplink must not reset the device-session
+	replayPHY := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ts.DeviceSession.DevAddr,
+				FCnt:    6,
+			},
+			FPort: &fPortOne,
+		},
+	}
+	ts.Require().NoError(replayPHY.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, ts.DeviceSession.FNwkSIntKey, ts.DeviceSession.SNwkSIntKey))
+
+	tests := []ClassATest{
+		{
+			Name:          "replayed uplink",
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload:    replayPHY,
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(5),
+				AssertNoASHandleUplinkDataRequest(),
+			},
+		},
+		{
+			Name:          "the device restarted its frame-counters",
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ts.DeviceSession.DevAddr,
+						FCnt:    0,
+					},
+					FPort: &fPortOne,
+				},
+				MIC: lorawan.MIC{131, 36, 83, 163},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(1),
+				AssertNFCntDown(0),
+				AssertASHandleErrorRequest(as.HandleErrorRequest{
+					DevEui:       ts.Device.DevEUI[:],
+					Type:         as.ErrorType_DATA_UP_FCNT_RESET,
+					Error:        "frame-counter reset, device-session frame-counters have been reset",
+					FCnt:         0,
+					ExpectedFCnt: 8,
+					RxInfo:       []*gw.UplinkRXInfo{&ts.RXInfo},
+				}),
+				AssertASHandleUplinkDataRequest(as.HandleUplinkDataRequest{
+					DevEui:  ts.Device.DevEUI[:],
+					JoinEui: ts.DeviceSession.JoinEUI[:],
+					FCnt:    0,
+					FPort:   1,
+					Dr:      0,
+					TxInfo:  &ts.TXInfo,
+					RxInfo:  []*gw.UplinkRXInfo{&ts.RXInfo},
+				}),
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			ts.AssertClassATest(t, tst)
+		})
+	}
+}
+
 func (ts *ClassATestSuite) TestLW10Uplink() {
 	ts.CreateDeviceSession(storage.DeviceSession{
 		MACVersion:            "1.0.2",
@@ -1536,6 +1762,161 @@ func (ts *ClassATestSuite) TestLW10AddGWMetadata() {
 	assert.NoError(storage.UpdateServiceProfile(storage.DB(), ts.ServiceProfile))
 }
 
+func (ts *ClassATestSuite) TestLW10UplinkErrorAddGWMetadata() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.UplinkErrors.ForwardTypes = []string{"DATA_UP_MIC"}
+	assert.NoError(uplink.Setup(conf))
+
+	tests := []struct {
+		Name           string
+		AddGWMetadata  bool
+		ExpectedRXInfo []*gw.UplinkRXInfo
+	}{
+		{
+			Name:           "service-profile: gw meta-data",
+			AddGWMetadata:  true,
+			ExpectedRXInfo: []*gw.UplinkRXInfo{&ts.RXInfo},
+		},
+		{
+			Name:          "service-profile: no gw meta-data",
+			AddGWMetadata: false,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			test.MustFlushRedis(storage.RedisPool())
+
+			ts.ServiceProfile.AddGWMetadata = tst.AddGWMetadata
+			assert.NoError(storage.UpdateServiceProfile(storage.DB(), ts.ServiceProfile))
+
+			ts.CreateDeviceSession(storage.DeviceSession{
+				MACVersion:            "1.0.2",
+				DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+				FNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				SNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				NwkSEncKey:            [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				FCntUp:                8,
+				EnabledUplinkChannels: []int{0, 1, 2},
+			})
+
+			phy := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ts.DeviceSession.DevAddr,
+						FCnt:    10,
+					},
+				},
+				MIC: lorawan.MIC{1, 2, 3, 4},
+			}
+			phyB, err := phy.MarshalBinary()
+			assert.NoError(err)
+
+			err = uplink.HandleRXPacket(gw.UplinkFrame{
+				RxInfo:     &ts.RXInfo,
+				TxInfo:     &ts.TXInfo,
+				PhyPayload: phyB,
+			})
+			assert.Error(err)
+
+			req := <-ts.ASClient.HandleErrorChan
+			assert.Equal(as.ErrorType_DATA_UP_MIC, req.Type)
+			assert.EqualValues(10, req.FCnt)
+			assert.EqualValues(8, req.ExpectedFCnt)
+			assert.Equal(tst.ExpectedRXInfo, req.RxInfo)
+		})
+	}
+
+	ts.ServiceProfile.AddGWMetadata = true
+	assert.NoError(storage.UpdateServiceProfile(storage.DB(), ts.ServiceProfile))
+	assert.NoError(uplink.Setup(test.GetConfig()))
+}
+
+func (ts *ClassATestSuite) TestLW10UplinkNetIDFilter() {
+	assert := require.New(ts.T())
+
+	tests := []struct {
+		Name           string
+		ExtraNetIDs    []string
+		ExpectedFCnt   uint32
+		ExpectedDataUp bool
+	}{
+		{
+			Name:         "DevAddr does not match NetID",
+			ExpectedFCnt: 8,
+		},
+		{
+			Name:           "DevAddr matches extra NetID",
+			ExtraNetIDs:    []string{"000000"},
+			ExpectedFCnt:   11,
+			ExpectedDataUp: true,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			test.MustFlushRedis(storage.RedisPool())
+
+			conf := test.GetConfig()
+			conf.NetworkServer.ExtraNetIDs = tst.ExtraNetIDs
+			conf.NetworkServer.NetIDFilter.Enabled = true
+			assert.NoError(uplink.Setup(conf))
+
+			// DevAddr 01020304 matches NetID 000000, not the test NetID 030201
+			ts.CreateDeviceSession(storage.DeviceSession{
+				MACVersion:            "1.0.2",
+				DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+				FNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				SNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				NwkSEncKey:            [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				FCntUp:                8,
+				EnabledUplinkChannels: []int{0, 1, 2},
+			})
+
+			phy := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ts.DeviceSession.DevAddr,
+						FCnt:    10,
+					},
+				},
+			}
+			assert.NoError(phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, ts.DeviceSession.FNwkSIntKey, ts.DeviceSession.SNwkSIntKey))
+			phyB, err := phy.MarshalBinary()
+			assert.NoError(err)
+
+			assert.NoError(uplink.HandleRXPacket(gw.UplinkFrame{
+				RxInfo:     &ts.RXInfo,
+				TxInfo:     &ts.TXInfo,
+				PhyPayload: phyB,
+			}))
+
+			if tst.ExpectedDataUp {
+				req := <-ts.ASClient.HandleDataUpChan
+				assert.EqualValues(10, req.FCnt)
+			}
+
+			ds, err := storage.GetDeviceSession(storage.RedisPool(), ts.DeviceSession.DevEUI)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedFCnt, ds.FCntUp)
+		})
+	}
+
+	assert.NoError(uplink.Setup(test.GetConfig()))
+}
+
 func (ts *ClassATestSuite) TestLW11DeviceQueue() {
 	ts.CreateDeviceSession(storage.DeviceSession{
 		MACVersion:            "1.1.0",
@@ -220,6 +220,14 @@ func (ts *ClassBTestSuite) TestUplink() {
 				}
 			} else {
 				assert.Equal(storage.DeviceModeA, d.Mode)
+
+				queueItems, err := storage.GetDeviceQueueItemsForDevEUI(storage.DB(), test.DeviceSession.DevEUI)
+				assert.NoError(err)
+
+				for _, qi := range queueItems {
+					assert.Nil(qi.EmitAtTimeSinceGPSEpoch)
+					assert.Nil(qi.TimeoutAfter)
+				}
 			}
 		})
 	}
@@ -69,6 +69,27 @@ func (ts *ClassCTestSuite) TestClassC() {
 	}
 	assert.NoError(helpers.SetDownlinkTXInfoDataRate(&txInfo, 5, band.Band()))
 
+	pinnedGatewayID := lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}
+	txInfoPinned := txInfo
+	txInfoPinned.GatewayId = pinnedGatewayID[:]
+
+	// the tx power must be capped by the settings of the pinned gateway
+	cappedGatewayID := lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}
+	txInfoCapped := txInfo
+	txInfoCapped.GatewayId = cappedGatewayID[:]
+	txInfoCapped.Power = 12
+
+	// the pinned gateway must be used when the device has no uplink history
+	noHistoryGatewayID := lorawan.EUI64{5, 5, 5, 5, 5, 5, 5, 5}
+	txInfoNoHistory := txInfo
+	txInfoNoHistory.GatewayId = noHistoryGatewayID[:]
+
+	dsNoHistory := *ts.DeviceSession
+	dsNoHistory.UplinkGatewayHistory = nil
+	dsNoHistory.LastRXInfoSet = nil
+
+	maintenanceGatewayID := lorawan.EUI64{6, 6, 6, 6, 6, 6, 6, 6}
+
 	fPortTen := uint8(10)
 
 	tests := []DownlinkTest{
@@ -103,6 +124,133 @@ func (ts *ClassCTestSuite) TestClassC() {
 				}),
 			},
 		},
+		{
+			Name: "unconfirmed data pinned to gateway",
+			BeforeFunc: func(*DownlinkTest) error {
+				return storage.CreateGateway(storage.DB(), &storage.Gateway{GatewayID: pinnedGatewayID})
+			},
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: make([]byte, 242), GatewayID: &pinnedGatewayID},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(6),
+				AssertDownlinkFrame(txInfoPinned, lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataDown,
+						Major: lorawan.LoRaWANR1,
+					},
+					MIC: lorawan.MIC{155, 150, 40, 188},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: ts.DeviceSession.DevAddr,
+							FCnt:    5,
+							FCtrl: lorawan.FCtrl{
+								ADR: true,
+							},
+						},
+						FPort: &fPortTen,
+						FRMPayload: []lorawan.Payload{
+							&lorawan.DataPayload{Bytes: make([]byte, 242)},
+						},
+					},
+				}),
+			},
+		},
+		{
+			Name: "unconfirmed data pinned to gateway with lower max tx power",
+			BeforeFunc: func(*DownlinkTest) error {
+				return storage.CreateGateway(storage.DB(), &storage.Gateway{
+					GatewayID:   cappedGatewayID,
+					MaxTXPower:  14,
+					AntennaGain: 2,
+				})
+			},
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: make([]byte, 242), GatewayID: &cappedGatewayID},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(6),
+				AssertDownlinkFrame(txInfoCapped, lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataDown,
+						Major: lorawan.LoRaWANR1,
+					},
+					MIC: lorawan.MIC{155, 150, 40, 188},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: ts.DeviceSession.DevAddr,
+							FCnt:    5,
+							FCtrl: lorawan.FCtrl{
+								ADR: true,
+							},
+						},
+						FPort: &fPortTen,
+						FRMPayload: []lorawan.Payload{
+							&lorawan.DataPayload{Bytes: make([]byte, 242)},
+						},
+					},
+				}),
+			},
+		},
+		{
+			Name: "unconfirmed data pinned to gateway without uplink history",
+			BeforeFunc: func(*DownlinkTest) error {
+				return storage.CreateGateway(storage.DB(), &storage.Gateway{GatewayID: noHistoryGatewayID})
+			},
+			DeviceSession: dsNoHistory,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: make([]byte, 242), GatewayID: &noHistoryGatewayID},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(6),
+				AssertDownlinkFrame(txInfoNoHistory, lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataDown,
+						Major: lorawan.LoRaWANR1,
+					},
+					MIC: lorawan.MIC{155, 150, 40, 188},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: ts.DeviceSession.DevAddr,
+							FCnt:    5,
+							FCtrl: lorawan.FCtrl{
+								ADR: true,
+							},
+						},
+						FPort: &fPortTen,
+						FRMPayload: []lorawan.Payload{
+							&lorawan.DataPayload{Bytes: make([]byte, 242)},
+						},
+					},
+				}),
+			},
+		},
+		{
+			Name: "unconfirmed data pinned to gateway in maintenance mode",
+			BeforeFunc: func(*DownlinkTest) error {
+				return storage.CreateGateway(storage.DB(), &storage.Gateway{
+					GatewayID:       maintenanceGatewayID,
+					MaintenanceMode: true,
+				})
+			},
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: make([]byte, 242), GatewayID: &maintenanceGatewayID},
+			},
+			Assert: []Assertion{
+				AssertFCntUp(8),
+				AssertNFCntDown(5),
+				AssertNoDownlinkFrame,
+				AssertDeviceQueueItems([]storage.DeviceQueueItem{
+					{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: make([]byte, 242), GatewayID: &maintenanceGatewayID},
+				}),
+			},
+		},
 		{
 			Name:          "unconfirmed data (only first item is emitted because of class-c downlink lock)",
 			DeviceSession: *ts.DeviceSession,
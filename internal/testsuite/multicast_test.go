@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/gps"
@@ -119,6 +120,12 @@ func (ts *MulticastTestSuite) TestMulticast() {
 					},
 					MIC: lorawan.MIC{0x8, 0xb5, 0x29, 0xe8},
 				}),
+				AssertASHandleMulticastDownlinkStatusRequest(as.HandleMulticastDownlinkStatusRequest{
+					MulticastGroupId: ts.MulticastGroup.ID.Bytes(),
+					FCnt:             10,
+					GatewayId:        ts.Gateway.GatewayID[:],
+					Status:           as.DownlinkStatus_TRANSMITTED,
+				}),
 			},
 		},
 		{
@@ -190,6 +197,12 @@ func (ts *MulticastTestSuite) TestMulticast() {
 					},
 					MIC: lorawan.MIC{0x8, 0xb5, 0x29, 0xe8},
 				}),
+				AssertASHandleMulticastDownlinkStatusRequest(as.HandleMulticastDownlinkStatusRequest{
+					MulticastGroupId: ts.MulticastGroup.ID.Bytes(),
+					FCnt:             10,
+					GatewayId:        ts.Gateway.GatewayID[:],
+					Status:           as.DownlinkStatus_TRANSMITTED,
+				}),
 			},
 		},
 		{
@@ -209,6 +222,12 @@ func (ts *MulticastTestSuite) TestMulticast() {
 			Assert: []Assertion{
 				AssertNoDownlinkFrame,
 				AssertMulticastQueueItems([]storage.MulticastQueueItem{}),
+				AssertASHandleMulticastDownlinkStatusRequest(as.HandleMulticastDownlinkStatusRequest{
+					MulticastGroupId: ts.MulticastGroup.ID.Bytes(),
+					FCnt:             10,
+					GatewayId:        ts.Gateway.GatewayID[:],
+					Status:           as.DownlinkStatus_DISCARDED_SIZE,
+				}),
 			},
 		},
 	}
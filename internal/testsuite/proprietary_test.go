@@ -2,6 +2,7 @@ package testsuite
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -10,9 +11,12 @@ import (
 	"github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/band"
 	"github.com/brocaar/loraserver/internal/downlink"
+	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/loraserver/internal/uplink"
 	"github.com/brocaar/lorawan"
 )
 
@@ -197,6 +201,60 @@ func (ts *ProprietaryTestCase) TestUplink() {
 	}
 }
 
+func (ts *ProprietaryTestCase) TestUplinkRateLimit() {
+	assert := require.New(ts.T())
+
+	ts.CreateRoutingProfile(storage.RoutingProfile{})
+
+	conf := test.GetConfig()
+	conf.NetworkServer.ProprietaryUplink.RateLimit = 1
+	conf.NetworkServer.ProprietaryUplink.RateLimitInterval = time.Minute
+	assert.NoError(uplink.Setup(conf))
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			Major: lorawan.LoRaWANR1,
+			MType: lorawan.Proprietary,
+		},
+		MACPayload: &lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+		MIC:        lorawan.MIC{5, 6, 7, 8},
+	}
+	phyB, err := phy.MarshalBinary()
+	assert.NoError(err)
+
+	txInfo := gw.UplinkTXInfo{
+		Frequency: 868100000,
+	}
+	assert.NoError(helpers.SetUplinkTXInfoDataRate(&txInfo, 0, band.Band()))
+
+	rxInfo := gw.UplinkRXInfo{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+
+	// the first frame is within the rate-limit
+	assert.NoError(uplink.HandleRXPacket(gw.UplinkFrame{
+		PhyPayload: phyB,
+		TxInfo:     &txInfo,
+		RxInfo:     &rxInfo,
+	}))
+	req := <-ts.ASClient.HandleProprietaryUpChan
+	assert.Equal([]byte{1, 2, 3, 4}, req.MacPayload)
+
+	// the second frame exceeds the rate-limit
+	phy.MACPayload = &lorawan.DataPayload{Bytes: []byte{4, 3, 2, 1}}
+	phyB, err = phy.MarshalBinary()
+	assert.NoError(err)
+
+	assert.NoError(uplink.HandleRXPacket(gw.UplinkFrame{
+		PhyPayload: phyB,
+		TxInfo:     &txInfo,
+		RxInfo:     &rxInfo,
+	}))
+	AssertNoASHandleProprietaryUplinkRequest()(assert, &ts.IntegrationTestSuite)
+
+	assert.NoError(uplink.Setup(test.GetConfig()))
+}
+
 func TestProprietary(t *testing.T) {
 	suite.Run(t, new(ProprietaryTestCase))
 }
@@ -12,34 +12,61 @@ import (
 
 // GetTransportCredentials returns the gRPC transport credentials.
 func GetTransportCredentials(caCert, tlsCert, tlsKey string, verifyClientCert bool) (credentials.TransportCredentials, error) {
+	if verifyClientCert {
+		return GetServerTransportCredentials(caCert, tlsCert, tlsKey, true)
+	}
+
+	cert, caCertPool, err := loadCertificates(caCert, tlsCert, tlsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caCertPool,
+	}), nil
+}
+
+// GetServerTransportCredentials returns the gRPC server transport credentials.
+// Client-certificates are verified against the given CA certificate. When
+// requireClientCert is false, clients without certificate are accepted too,
+// in which case the caller must perform its own authorization.
+func GetServerTransportCredentials(caCert, tlsCert, tlsKey string, requireClientCert bool) (credentials.TransportCredentials, error) {
+	cert, caCertPool, err := loadCertificates(caCert, tlsCert, tlsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAuth := tls.RequireAndVerifyClientCert
+	if !requireClientCert {
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    caCertPool,
+		ClientAuth:   clientAuth,
+	}), nil
+}
+
+func loadCertificates(caCert, tlsCert, tlsKey string) (tls.Certificate, *x509.CertPool, error) {
 	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "load tls key-pair error")
+		return tls.Certificate{}, nil, errors.Wrap(err, "load tls key-pair error")
 	}
 
 	var caCertPool *x509.CertPool
 	if caCert != "" {
 		rawCaCert, err := ioutil.ReadFile(caCert)
 		if err != nil {
-			return nil, errors.Wrap(err, "load ca certificate error")
+			return tls.Certificate{}, nil, errors.Wrap(err, "load ca certificate error")
 		}
 
 		caCertPool = x509.NewCertPool()
 		if !caCertPool.AppendCertsFromPEM(rawCaCert) {
-			return nil, fmt.Errorf("append ca certificate error: %s", caCert)
+			return tls.Certificate{}, nil, fmt.Errorf("append ca certificate error: %s", caCert)
 		}
 	}
 
-	if verifyClientCert {
-		return credentials.NewTLS(&tls.Config{
-			Certificates: []tls.Certificate{cert},
-			ClientCAs:    caCertPool,
-			ClientAuth:   tls.RequireAndVerifyClientCert,
-		}), nil
-	}
-
-	return credentials.NewTLS(&tls.Config{
-		Certificates: []tls.Certificate{cert},
-		RootCAs:      caCertPool,
-	}), nil
+	return cert, caCertPool, nil
 }
@@ -29,10 +29,14 @@ const (
 // strength (strongest at index 0). This method exists since multiple gateways
 // are able to receive the same packet, but the packet needs to processed
 // only once.
+// The set and lock are stored in Redis, so that this is also safe when
+// multiple network-server instances receive the same packet.
 // It is safe to collect the same packet received by the same gateway twice.
 // Since the underlying storage type is a set, the result will always be a
 // unique set per gateway MAC and packet MIC.
 func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(packet models.RXPacket) error) error {
+	receivedAt := time.Now()
+
 	b, err := proto.Marshal(&rxPacket)
 	if err != nil {
 		return errors.Wrap(err, "marshal uplink frame error")
@@ -88,7 +92,9 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(pa
 		return errors.New("zero items in collect set")
 	}
 
-	var out models.RXPacket
+	out := models.RXPacket{
+		ReceivedAt: receivedAt,
+	}
 	for i, b := range payloads {
 		var uplinkFrame gw.UplinkFrame
 		if err := proto.Unmarshal(b, &uplinkFrame); err != nil {
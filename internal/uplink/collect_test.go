@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -19,12 +20,15 @@ import (
 
 type CollectTestSuite struct {
 	suite.Suite
+
+	redisURL string
 }
 
 func (ts *CollectTestSuite) SetupSuite() {
 	assert := require.New(ts.T())
 	conf := test.GetConfig()
 	conf.NetworkServer.DeduplicationDelay = time.Millisecond * 500
+	ts.redisURL = conf.Redis.URL
 
 	assert.NoError(storage.Setup(conf))
 	assert.NoError(Setup(conf))
@@ -128,6 +132,66 @@ func (ts *CollectTestSuite) TestDeduplication() {
 	}
 }
 
+// TestDeduplicationMultipleInstances tests that when two network-server
+// instances (each using its own Redis pool) receive the same frame from
+// different gateways, the frame is handled exactly once, by the instance
+// acquiring the lock, with the rx-info of both gateways.
+func (ts *CollectTestSuite) TestDeduplicationMultipleInstances() {
+	assert := require.New(ts.T())
+	test.MustFlushRedis(storage.RedisPool())
+
+	otherInstancePool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.DialURL(ts.redisURL)
+		},
+	}
+	defer otherInstancePool.Close()
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MIC:        [4]byte{4, 2, 3, 4},
+		MACPayload: &lorawan.MACPayload{},
+	}
+	phyB, err := phy.MarshalBinary()
+	assert.NoError(err)
+
+	var mu sync.Mutex
+	var called, received int
+	cb := func(packet models.RXPacket) error {
+		mu.Lock()
+		defer mu.Unlock()
+		called++
+		received = len(packet.RXInfoSet)
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i, p := range []*redis.Pool{storage.RedisPool(), otherInstancePool} {
+		gatewayID := lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, byte(i)}
+		packet := gw.UplinkFrame{
+			RxInfo: &gw.UplinkRXInfo{
+				GatewayId: gatewayID[:],
+			},
+			TxInfo:     &gw.UplinkTXInfo{},
+			PhyPayload: phyB,
+		}
+		assert.NoError(helpers.SetUplinkTXInfoDataRate(packet.TxInfo, 0, band.Band()))
+
+		wg.Add(1)
+		go func(p *redis.Pool, packet gw.UplinkFrame) {
+			defer wg.Done()
+			assert.NoError(collectAndCallOnce(p, packet, cb))
+		}(p, packet)
+	}
+	wg.Wait()
+
+	assert.Equal(1, called)
+	assert.Equal(2, received)
+}
+
 func TestCollect(t *testing.T) {
 	suite.Run(t, new(CollectTestSuite))
 }
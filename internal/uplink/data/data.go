@@ -2,9 +2,12 @@ package data
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/brocaar/loraserver/api/geo"
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/api/nc"
+	"github.com/brocaar/loraserver/internal/airtime"
 	"github.com/brocaar/loraserver/internal/backend/applicationserver"
 	"github.com/brocaar/loraserver/internal/backend/controller"
 	"github.com/brocaar/loraserver/internal/backend/geolocationserver"
@@ -21,50 +25,100 @@ import (
 	datadown "github.com/brocaar/loraserver/internal/downlink/data"
 	"github.com/brocaar/loraserver/internal/downlink/data/classb"
 	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/geolocation"
 	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/roaming"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
 
 const applicationClientTimeout = time.Second
 
+var errAbort = errors.New("abort")
+
 var tasks = []func(*dataContext) error{
 	setContextFromDataPHYPayload,
+	filterNetID,
 	getDeviceSessionForPHYPayload,
+	getDeviceProfile,
+	rejectFOptsWithFPortZero,
+	rejectMaxPayloadSizeExceeded,
 	decryptFOptsMACCommands,
 	decryptFRMPayloadMACCommands,
 	logUplinkFrame,
-	getDeviceProfile,
 	getServiceProfile,
+	accountUplinkAirtime,
 	getApplicationServerClientForDataUp,
-	resolveDeviceLocation,
+	unlessRetransmission(
+		resolveDeviceLocation,
+	),
 	setADR,
 	setUplinkDataRate,
 	setBeaconLocked,
 	sendRXInfoToNetworkController,
-	handleFOptsMACCommands,
-	handleFRMPayloadMACCommands,
+	unlessRetransmission(
+		getPendingMACCommands,
+		handleFOptsMACCommands,
+		handleFRMPayloadMACCommands,
+		handlePendingMACCommandTimeouts,
+	),
 	storeDeviceGatewayRXInfoSet,
 	appendMetaDataToUplinkHistory,
-	sendFRMPayloadToApplicationServer,
+	unlessRetransmission(
+		sendFRMPayloadToApplicationServer,
+	),
 	setLastRXInfoSet,
-	syncUplinkFCnt,
+	unlessRetransmission(
+		syncUplinkFCnt,
+	),
 	saveDeviceSession,
-	handleUplinkACK,
-	handleDownlink,
+	unlessRetransmission(
+		handleUplinkACK,
+		handleDownlink,
+	),
+	handleRetransmissionDownlink,
 }
 
 var (
 	getDownlinkDataDelay time.Duration
 	disableMACCommands   bool
+	macCommandAckUplinks int
+	macCommandMaxRetries int
+
+	disableADRACKReqDownlink bool
+
+	netIDs                 []lorawan.NetID
+	netIDFilterEnabled     bool
+	netIDFilterLogRejected bool
 )
 
 // Setup configures the package.
 func Setup(conf config.Config) error {
 	getDownlinkDataDelay = conf.NetworkServer.GetDownlinkDataDelay
 	disableMACCommands = conf.NetworkServer.NetworkSettings.DisableMACCommands
+	macCommandAckUplinks = conf.NetworkServer.NetworkSettings.MACCommandAckUplinks
+	macCommandMaxRetries = conf.NetworkServer.NetworkSettings.MACCommandMaxRetries
+	disableADRACKReqDownlink = conf.NetworkServer.NetworkSettings.DisableADRACKReqDownlink
+	forwardErrorRateInterval = conf.NetworkServer.UplinkErrors.RateLimitInterval
+	netIDFilterEnabled = conf.NetworkServer.NetIDFilter.Enabled
+	netIDFilterLogRejected = conf.NetworkServer.NetIDFilter.LogRejected
+
+	netIDs = []lorawan.NetID{conf.NetworkServer.NetID}
+	for _, s := range conf.NetworkServer.ExtraNetIDs {
+		var netID lorawan.NetID
+		if err := netID.UnmarshalText([]byte(s)); err != nil {
+			return errors.Wrap(err, "decode extra net_id error")
+		}
+		netIDs = append(netIDs, netID)
+	}
+
+	if err := setForwardErrorTypes(conf.NetworkServer.UplinkErrors.ForwardTypes); err != nil {
+		return errors.Wrap(err, "set uplink error forward types error")
+	}
+
+	setupForwarding(conf)
 
 	return nil
 }
@@ -78,6 +132,21 @@ type dataContext struct {
 	ApplicationServerClient as.ApplicationServerServiceClient
 	MACCommandResponses     []storage.MACCommandBlock
 	MustSendDownlink        bool
+
+	// PendingMACCommands holds the pending mac-command blocks which are
+	// fetched once per uplink. Answered blocks are removed from the map and
+	// their CID is added to AnsweredMACCommands, so that all pending
+	// mac-command changes can be written back in a single round-trip.
+	PendingMACCommands  map[lorawan.CID]storage.MACCommandBlock
+	AnsweredMACCommands []lorawan.CID
+
+	// AirtimeBudgetExceeded indicates that the device exceeded the uplink
+	// airtime budget of the service-profile.
+	AirtimeBudgetExceeded bool
+
+	// Retransmission indicates that the uplink is a retransmission of the
+	// last processed uplink (same FCnt and MIC).
+	Retransmission bool
 }
 
 // Handle handles an uplink data frame
@@ -88,6 +157,9 @@ func Handle(rxPacket models.RXPacket) error {
 
 	for _, t := range tasks {
 		if err := t(&ctx); err != nil {
+			if err == errAbort {
+				return nil
+			}
 			return err
 		}
 	}
@@ -95,6 +167,24 @@ func Handle(rxPacket models.RXPacket) error {
 	return nil
 }
 
+// unlessRetransmission only executes the given tasks when the uplink is not
+// a retransmission of the last processed uplink.
+func unlessRetransmission(tasks ...func(*dataContext) error) func(*dataContext) error {
+	return func(ctx *dataContext) error {
+		if ctx.Retransmission {
+			return nil
+		}
+
+		for _, f := range tasks {
+			if err := f(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func setContextFromDataPHYPayload(ctx *dataContext) error {
 	macPL, ok := ctx.RXPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
 	if !ok {
@@ -104,6 +194,50 @@ func setContextFromDataPHYPayload(ctx *dataContext) error {
 	return nil
 }
 
+// filterNetID handles data frames of which the DevAddr does not match the
+// NetID (or one of the extra NetIDs) of this network-server. These frames are
+// forwarded to the roaming partner when passive-roaming is enabled, else they
+// are rejected when the NetID filter is enabled.
+func filterNetID(ctx *dataContext) error {
+	devAddr := ctx.MACPayload.FHDR.DevAddr
+	for _, netID := range netIDs {
+		if devAddr.IsNetID(netID) {
+			return nil
+		}
+	}
+
+	if roaming.Enabled() {
+		if err := roaming.HandleUplink(devAddr, ctx.RXPacket); err != nil {
+			return errors.Wrap(err, "handle passive-roaming uplink error")
+		}
+		return errAbort
+	}
+
+	if !netIDFilterEnabled {
+		return nil
+	}
+
+	netIDType := devAddr.NetIDType()
+	nwkID := hex.EncodeToString(devAddr.NwkID())
+	netIDRejectedCounter(netIDType, nwkID).Inc()
+
+	if netIDFilterLogRejected {
+		var gatewayIDs []string
+		for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+			gatewayIDs = append(gatewayIDs, helpers.GetGatewayID(rxInfo).String())
+		}
+
+		log.WithFields(log.Fields{
+			"dev_addr":    devAddr,
+			"net_id_type": netIDType,
+			"nwk_id":      nwkID,
+			"gateway_ids": gatewayIDs,
+		}).Debug("DevAddr does not match NetID, uplink data frame rejected")
+	}
+
+	return errAbort
+}
+
 func getDeviceSessionForPHYPayload(ctx *dataContext) error {
 	txDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
 	if err != nil {
@@ -132,14 +266,200 @@ func getDeviceSessionForPHYPayload(ctx *dataContext) error {
 	}
 
 	ds, err := storage.GetDeviceSessionForPHYPayload(storage.RedisPool(), ctx.RXPacket.PHYPayload, txDR, txCh)
+	if err == storage.ErrDoesNotExistOrFCntOrMICInvalid && handleUplinkValidationError(ctx, txDR, txCh) {
+		// the frame-counters of the ABP device have been reset
+		ds, err = storage.GetDeviceSessionForPHYPayload(storage.RedisPool(), ctx.RXPacket.PHYPayload, txDR, txCh)
+	}
 	if err != nil {
 		return errors.Wrap(err, "get device-session error")
 	}
 	ctx.DeviceSession = ds
 
+	if storage.IsUplinkRetransmission(ds, ctx.RXPacket.PHYPayload) {
+		ctx.Retransmission = true
+		ctx.DeviceSession.UplinkRetransmissionCount++
+		ctx.DeviceSession.LastUplinkRetransmissions++
+
+		// with NbTrans > 1, the device transmits each uplink NbTrans times
+		expected := ctx.DeviceSession.IsExpectedUplinkRetransmission()
+		logger := log.WithFields(log.Fields{
+			"dev_eui":        ds.DevEUI,
+			"f_cnt":          ctx.MACPayload.FHDR.FCnt,
+			"nb_trans":       ds.NbTrans,
+			"retransmission": ctx.DeviceSession.LastUplinkRetransmissions,
+		})
+		if expected {
+			logger.Debug("uplink retransmission received (nb_trans)")
+		} else {
+			logger.Info("uplink retransmission received")
+		}
+		retransmissionCounter(expected).Inc()
+	}
+
+	return nil
+}
+
+// rejectFOptsWithFPortZero rejects frames containing mac-commands in both
+// the FOpts and FRMPayload (FPort = 0) fields. The LoRaWAN specification
+// states that such frames must be discarded.
+func rejectFOptsWithFPortZero(ctx *dataContext) error {
+	if ctx.MACPayload.FPort == nil || *ctx.MACPayload.FPort != 0 || len(ctx.MACPayload.FHDR.FOpts) == 0 {
+		return nil
+	}
+
+	reason := "mac-commands are present in both FOpts and FRMPayload (FPort=0)"
+
+	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
+	if err != nil {
+		return errors.Wrap(err, "create uplink frame-log error")
+	}
+
+	if err := framelog.LogRejectedUplinkFrameForDevEUI(storage.RedisPool(), ctx.DeviceSession.DevEUI, uplinkFrameSet, reason); err != nil {
+		log.WithError(err).Error("log rejected uplink frame for device error")
+	}
+
+	return errors.New(reason)
+}
+
+// handleUplinkValidationError determines why the uplink could not be matched
+// to a device-session and handles the error for the matching device.
+// It returns true when the frame-counters of the (ABP) device-session have
+// been reset, in which case the uplink must be matched again.
+func handleUplinkValidationError(ctx *dataContext, txDR, txCh int) bool {
+	uErr, err := storage.GetUplinkValidationError(storage.RedisPool(), ctx.RXPacket.PHYPayload, txDR, txCh)
+	if err != nil {
+		log.WithError(err).Error("get uplink validation error error")
+		return false
+	}
+	if uErr == nil {
+		return false
+	}
+
+	if uErr.Type == as.ErrorType_DATA_UP_FCNT_RESET && uErr.DeviceSession.ABPFCntReset {
+		if err := resetABPFrameCounters(ctx, *uErr); err != nil {
+			log.WithError(err).WithField("dev_eui", uErr.DeviceSession.DevEUI).Error("reset abp frame-counters error")
+		} else {
+			return true
+		}
+	}
+
+	errStr := storage.ErrDoesNotExistOrFCntOrMICInvalid.Error()
+	switch uErr.Type {
+	case as.ErrorType_DATA_UP_MIC:
+		errStr = "invalid MIC"
+	case as.ErrorType_DATA_UP_FCNT_RESET:
+		errStr = "frame-counter reset"
+	case as.ErrorType_DATA_UP_FCNT_RETRANSMISSION:
+		errStr = "frame-counter of previous uplink (re-transmission)"
+	case as.ErrorType_DATA_UP_FCNT:
+		errStr = "invalid frame-counter"
+	case as.ErrorType_DATA_UP_FCNT_GAP_TOO_LARGE:
+		errStr = fmt.Sprintf("frame-counter gap exceeds max frame-counter gap of %d", uErr.DeviceSession.GetMaxFCntGap())
+	}
+
+	if err := handleUplinkError(uErr.DeviceSession, ctx.RXPacket, uErr.Type, errStr, uErr.FCnt, uErr.ExpectedFCnt); err != nil {
+		log.WithError(err).WithField("dev_eui", uErr.DeviceSession.DevEUI).Error("handle uplink error error")
+	}
+
+	return false
+}
+
+// resetABPFrameCounters resets the frame-counters of the ABP device-session
+// after the device restarted its frame-counters (e.g. after a power-cycle).
+// Like on ABP activation, the device-session is reset to the boot parameters
+// and the device-queue, mac-command queue and pending mac-commands are
+// flushed. The application-server is notified of the reset.
+func resetABPFrameCounters(ctx *dataContext, uErr storage.UplinkValidationError) error {
+	ds := uErr.DeviceSession
+
+	dp, err := storage.GetAndCacheDeviceProfile(storage.DB(), storage.RedisPool(), ds.DeviceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get device-profile error")
+	}
+
+	// this must never be applied to OTAA devices
+	if dp.SupportsJoin {
+		return errors.New("abp frame-counter reset is not allowed for otaa devices")
+	}
+
+	ds.FCntUp = uErr.FCnt
+	ds.NFCntDown = 0
+	ds.AFCntDown = 0
+	ds.ConfFCnt = 0
+	ds.UplinkHistory = []storage.UplinkHistory{}
+	ds.LastUplinkMIC = lorawan.MIC{}
+	ds.LastUplinkRetransmissions = 0
+	ds.ResetToBootParameters(dp)
+
+	if err := storage.FlushDeviceQueueForDevEUI(storage.DB(), ds.DevEUI); err != nil {
+		return errors.Wrap(err, "flush device-queue error")
+	}
+
+	if err := storage.ActivateDeviceSession(storage.RedisPool(), ds); err != nil {
+		return errors.Wrap(err, "save device-session error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":       ds.DevEUI,
+		"f_cnt":         uErr.FCnt,
+		"expected_fcnt": uErr.ExpectedFCnt,
+	}).Warning("abp frame-counter reset detected, device-session frame-counters reset")
+
+	// the application-server must always be notified of the reset,
+	// regardless the uplink error forward types and rate-limit
+	if err := sendUplinkError(ds, ctx.RXPacket, as.ErrorType_DATA_UP_FCNT_RESET, "frame-counter reset, device-session frame-counters have been reset", uErr.FCnt, uErr.ExpectedFCnt); err != nil {
+		log.WithError(err).WithField("dev_eui", ds.DevEUI).Error("send frame-counter reset to application-server error")
+	}
+
 	return nil
 }
 
+// rejectMaxPayloadSizeExceeded rejects frames of which the MACPayload
+// exceeds the max. payload size for the used data-rate.
+func rejectMaxPayloadSizeExceeded(ctx *dataContext) error {
+	size, maxSize, err := getMACPayloadSize(ctx.RXPacket, ctx.DeviceProfile)
+	if err != nil {
+		return err
+	}
+	if size <= maxSize {
+		return nil
+	}
+
+	reason := fmt.Sprintf("MACPayload size %d exceeds max payload size %d", size, maxSize)
+
+	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
+	if err != nil {
+		return errors.Wrap(err, "create uplink frame-log error")
+	}
+
+	if err := framelog.LogRejectedUplinkFrameForDevEUI(storage.RedisPool(), ctx.DeviceSession.DevEUI, uplinkFrameSet, reason); err != nil {
+		log.WithError(err).Error("log rejected uplink frame for device error")
+	}
+
+	if err := handleUplinkError(ctx.DeviceSession, ctx.RXPacket, as.ErrorType_DATA_UP_SIZE, reason, ctx.MACPayload.FHDR.FCnt, ctx.DeviceSession.FCntUp); err != nil {
+		log.WithError(err).WithField("dev_eui", ctx.DeviceSession.DevEUI).Error("handle uplink error error")
+	}
+
+	return errors.New(reason)
+}
+
+// getMACPayloadSize returns the MACPayload size of the received frame and
+// the max. MACPayload size for the data-rate of the frame.
+func getMACPayloadSize(rxPacket models.RXPacket, dp storage.DeviceProfile) (int, int, error) {
+	b, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	maxSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(dp.MACVersion, dp.RegParamsRevision, rxPacket.DR)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "get max payload size error")
+	}
+
+	// the PHYPayload contains the MHDR (1 byte) and the MIC (4 bytes)
+	return len(b) - 5, maxSize.M, nil
+}
+
 func logUplinkFrame(ctx *dataContext) error {
 	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
 	if err != nil {
@@ -173,6 +493,45 @@ func getServiceProfile(ctx *dataContext) error {
 	return nil
 }
 
+// accountUplinkAirtime adds the airtime of the uplink to the uplink airtime
+// of the device. When the uplink does not fit within the uplink airtime
+// budget of the service-profile, the uplink is still handled, but flagged
+// to the application-server.
+func accountUplinkAirtime(ctx *dataContext) error {
+	b, err := ctx.RXPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	// the uplink must not be rejected when the airtime can not be calculated
+	d, err := airtime.GetUplinkAirtime(len(b), ctx.RXPacket.TXInfo)
+	if err != nil {
+		log.WithError(err).WithField("dev_eui", ctx.DeviceSession.DevEUI).Error("get uplink airtime error")
+		return nil
+	}
+
+	exceeded, err := storage.DeviceAirtimeBudgetExceeded(storage.RedisPool(), ctx.DeviceSession.DevEUI, storage.UplinkAirtime, d, time.Duration(ctx.ServiceProfile.ULAirtimeBudget)*time.Millisecond)
+	if err != nil {
+		return errors.Wrap(err, "get device airtime budget error")
+	}
+
+	if err := storage.AddDeviceAirtime(storage.RedisPool(), ctx.DeviceSession.DevEUI, storage.UplinkAirtime, time.Now(), d); err != nil {
+		return errors.Wrap(err, "add device airtime error")
+	}
+
+	if exceeded {
+		log.WithFields(log.Fields{
+			"dev_eui":         ctx.DeviceSession.DevEUI,
+			"ul_budget_ms":    ctx.ServiceProfile.ULAirtimeBudget,
+			"service_profile": ctx.DeviceSession.ServiceProfileID,
+		}).Warning("uplink airtime budget exceeded")
+		airtimeBudgetExceededCounter().Inc()
+		ctx.AirtimeBudgetExceeded = true
+	}
+
+	return nil
+}
+
 func setADR(ctx *dataContext) error {
 	ctx.DeviceSession.ADR = ctx.MACPayload.FHDR.FCtrl.ADR
 	return nil
@@ -211,6 +570,25 @@ func appendMetaDataToUplinkHistory(ctx *dataContext) error {
 		}
 	}
 
+	// the retransmission might have been received by more gateways or with
+	// a better SNR, update the uplink history record of the original uplink
+	if ctx.Retransmission {
+		count := len(ctx.DeviceSession.UplinkHistory)
+		if count == 0 || ctx.DeviceSession.UplinkHistory[count-1].FCnt != ctx.MACPayload.FHDR.FCnt {
+			return nil
+		}
+
+		h := &ctx.DeviceSession.UplinkHistory[count-1]
+		if len(ctx.RXPacket.RXInfoSet) > h.GatewayCount {
+			h.GatewayCount = len(ctx.RXPacket.RXInfoSet)
+		}
+		if maxSNR > h.MaxSNR {
+			h.MaxSNR = maxSNR
+		}
+
+		return nil
+	}
+
 	ctx.DeviceSession.AppendUplinkHistory(storage.UplinkHistory{
 		FCnt:         ctx.MACPayload.FHDR.FCnt,
 		GatewayCount: len(ctx.RXPacket.RXInfoSet),
@@ -249,12 +627,12 @@ func storeDeviceGatewayRXInfoSet(ctx *dataContext) error {
 }
 
 func getApplicationServerClientForDataUp(ctx *dataContext) error {
-	rp, err := storage.GetRoutingProfile(storage.DB(), ctx.DeviceSession.RoutingProfileID)
+	rp, err := storage.GetAndCacheRoutingProfile(storage.DB(), ctx.DeviceSession.RoutingProfileID)
 	if err != nil {
 		return errors.Wrap(err, "get routing-profile error")
 	}
 
-	asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
 	if err != nil {
 		return errors.Wrap(err, "get application-server client error")
 	}
@@ -317,62 +695,14 @@ func resolveDeviceLocation(ctx *dataContext) error {
 		return nil
 	}
 
-	// perform the actual geolocation in a separate goroutine
-	go func(devEUI lorawan.EUI64, referenceAlt float64, geoClient geo.GeolocationServerServiceClient, asClient as.ApplicationServerServiceClient, frames []*geo.FrameRXInfo) {
-		var result *geo.ResolveResult
-
-		// Single-frame geolocation.
-		if len(frames) == 1 {
-			resp, err := geoClient.ResolveTDOA(context.Background(), &geo.ResolveTDOARequest{
-				DevEui:                  devEUI[:],
-				FrameRxInfo:             frames[0],
-				DeviceReferenceAltitude: referenceAlt,
-			})
-			if err != nil {
-				log.WithFields(log.Fields{
-					"dev_eui": devEUI,
-				}).WithError(err).Error("resolve tdoa error")
-				return
-			}
-
-			result = resp.Result
-		}
-
-		// Multi-frame geolocation.
-		if len(frames) > 1 {
-			resp, err := geoClient.ResolveMultiFrameTDOA(context.Background(), &geo.ResolveMultiFrameTDOARequest{
-				DevEui:                  devEUI[:],
-				FrameRxInfoSet:          frames,
-				DeviceReferenceAltitude: referenceAlt,
-			})
-			if err != nil {
-				log.WithFields(log.Fields{
-					"dev_eui": devEUI,
-				}).WithError(err).Error("resolve multi-frame tdoa error")
-				return
-			}
-
-			result = resp.Result
-		}
-
-		if result == nil || result.Location == nil {
-			log.WithFields(log.Fields{
-				"dev_eui": devEUI,
-			}).Error("geolocation-server result or result.location must not be nil")
-			return
-		}
-
-		_, err = asClient.SetDeviceLocation(context.Background(), &as.SetDeviceLocationRequest{
-			DevEui:   devEUI[:],
-			Location: result.Location,
-		})
-		if err != nil {
-			log.WithFields(log.Fields{
-				"dev_eui": devEUI,
-			}).WithError(err).Error("set device-location error")
-		}
-
-	}(ctx.DeviceSession.DevEUI, ctx.DeviceSession.ReferenceAltitude, geolocationserver.Client(), ctx.ApplicationServerClient, buffer)
+	// perform the actual geolocation asynchronously
+	geolocation.Resolve(geolocation.Request{
+		DevEUI:                  ctx.DeviceSession.DevEUI,
+		ReferenceAltitude:       ctx.DeviceSession.ReferenceAltitude,
+		Frames:                  buffer,
+		GeolocationClient:       geolocationserver.Client(),
+		ApplicationServerClient: ctx.ApplicationServerClient,
+	})
 
 	return nil
 }
@@ -438,6 +768,12 @@ func setBeaconLocked(ctx *dataContext) error {
 			return errors.Wrap(err, "update device error")
 		}
 
+		// the device is no longer listening in its ping-slots, the
+		// queue-items will be sent as Class-A downlink
+		if err := classb.UnscheduleDeviceQueueFromPingSlotsForDevEUI(storage.DB(), ctx.DeviceSession); err != nil {
+			return errors.Wrap(err, "unschedule device-queue from ping-slots error")
+		}
+
 		log.WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
 			"mode":    storage.DeviceModeA,
@@ -456,16 +792,39 @@ func sendRXInfoToNetworkController(ctx *dataContext) error {
 	return nil
 }
 
+func getPendingMACCommands(ctx *dataContext) error {
+	ctx.PendingMACCommands = make(map[lorawan.CID]storage.MACCommandBlock)
+
+	if disableMACCommands {
+		return nil
+	}
+
+	blocks, err := storage.GetPendingMACCommands(storage.RedisPool(), ctx.DeviceSession.DevEUI)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+		}).Errorf("get pending mac-commands error: %s", err)
+		return nil
+	}
+
+	for _, block := range blocks {
+		ctx.PendingMACCommands[block.CID] = block
+	}
+
+	return nil
+}
+
 func handleFOptsMACCommands(ctx *dataContext) error {
 	if len(ctx.MACPayload.FHDR.FOpts) == 0 {
 		return nil
 	}
 
-	blocks, mustRespondWithDownlink, err := handleUplinkMACCommands(
+	blocks, answered, mustRespondWithDownlink, err := handleUplinkMACCommands(
 		&ctx.DeviceSession,
 		ctx.DeviceProfile,
 		ctx.ServiceProfile,
 		ctx.ApplicationServerClient,
+		ctx.PendingMACCommands,
 		ctx.MACPayload.FHDR.FOpts,
 		ctx.RXPacket,
 	)
@@ -478,6 +837,7 @@ func handleFOptsMACCommands(ctx *dataContext) error {
 	}
 
 	ctx.MACCommandResponses = append(ctx.MACCommandResponses, blocks...)
+	ctx.AnsweredMACCommands = append(ctx.AnsweredMACCommands, answered...)
 	if !ctx.MustSendDownlink {
 		ctx.MustSendDownlink = mustRespondWithDownlink
 	}
@@ -494,7 +854,7 @@ func handleFRMPayloadMACCommands(ctx *dataContext) error {
 		return errors.New("expected mac commands, but FRMPayload is empty (FPort=0)")
 	}
 
-	blocks, mustRespondWithDownlink, err := handleUplinkMACCommands(&ctx.DeviceSession, ctx.DeviceProfile, ctx.ServiceProfile, ctx.ApplicationServerClient, ctx.MACPayload.FRMPayload, ctx.RXPacket)
+	blocks, answered, mustRespondWithDownlink, err := handleUplinkMACCommands(&ctx.DeviceSession, ctx.DeviceProfile, ctx.ServiceProfile, ctx.ApplicationServerClient, ctx.PendingMACCommands, ctx.MACPayload.FRMPayload, ctx.RXPacket)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"dev_eui":  ctx.DeviceSession.DevEUI,
@@ -504,6 +864,7 @@ func handleFRMPayloadMACCommands(ctx *dataContext) error {
 	}
 
 	ctx.MACCommandResponses = append(ctx.MACCommandResponses, blocks...)
+	ctx.AnsweredMACCommands = append(ctx.AnsweredMACCommands, answered...)
 	if !ctx.MustSendDownlink {
 		ctx.MustSendDownlink = mustRespondWithDownlink
 	}
@@ -511,13 +872,33 @@ func handleFRMPayloadMACCommands(ctx *dataContext) error {
 	return nil
 }
 
+func handlePendingMACCommandTimeouts(ctx *dataContext) error {
+	if disableMACCommands {
+		return nil
+	}
+
+	var pending []storage.MACCommandBlock
+	for _, block := range ctx.PendingMACCommands {
+		pending = append(pending, block)
+	}
+
+	if err := maccommand.HandlePendingBlocks(storage.RedisPool(), ctx.DeviceSession.DevEUI, pending, ctx.AnsweredMACCommands, ctx.MACPayload.FHDR.FCnt, macCommandAckUplinks, macCommandMaxRetries); err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+		}).Errorf("handle pending mac-command timeouts error: %s", err)
+	}
+
+	return nil
+}
+
 func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 	publishDataUpReq := as.HandleUplinkDataRequest{
-		DevEui:  ctx.DeviceSession.DevEUI[:],
-		JoinEui: ctx.DeviceSession.JoinEUI[:],
-		FCnt:    ctx.MACPayload.FHDR.FCnt,
-		Adr:     ctx.MACPayload.FHDR.FCtrl.ADR,
-		TxInfo:  ctx.RXPacket.TXInfo,
+		DevEui:                ctx.DeviceSession.DevEUI[:],
+		JoinEui:               ctx.DeviceSession.JoinEUI[:],
+		FCnt:                  ctx.MACPayload.FHDR.FCnt,
+		Adr:                   ctx.MACPayload.FHDR.FCtrl.ADR,
+		TxInfo:                ctx.RXPacket.TXInfo,
+		AirtimeBudgetExceeded: ctx.AirtimeBudgetExceeded,
 	}
 
 	dr, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
@@ -539,7 +920,7 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 	}
 
 	if ctx.ServiceProfile.AddGWMetadata {
-		publishDataUpReq.RxInfo = ctx.RXPacket.RXInfoSet
+		publishDataUpReq.RxInfo = applicationServerRXInfoSet(ctx.ServiceProfile, ctx.RXPacket.RXInfoSet)
 	}
 
 	if ctx.MACPayload.FPort != nil {
@@ -555,17 +936,32 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 		publishDataUpReq.Data = dataPL.Bytes
 	}
 
-	go func(asClient as.ApplicationServerServiceClient, publishDataUpReq as.HandleUplinkDataRequest) {
-		ctx := context.Background()
-		ctxTimeout, cancel := context.WithTimeout(ctx, applicationClientTimeout)
-		defer cancel()
+	goForwardUplinkData(ctx.DeviceSession.RoutingProfileID, publishDataUpReq)
 
-		if _, err := asClient.HandleUplinkData(ctxTimeout, &publishDataUpReq); err != nil {
-			log.WithError(err).Error("publish uplink data to application-server error")
+	return nil
+}
+
+// applicationServerRXInfoSet returns the rx-info set to forward to the
+// application-server. The fine-timestamps are only forwarded when network
+// geolocation is enabled by the service-profile.
+func applicationServerRXInfoSet(sp storage.ServiceProfile, rxInfoSet []*gw.UplinkRXInfo) []*gw.UplinkRXInfo {
+	if sp.NwkGeoLoc {
+		return rxInfoSet
+	}
+
+	out := make([]*gw.UplinkRXInfo, 0, len(rxInfoSet))
+	for _, rxInfo := range rxInfoSet {
+		if rxInfo.FineTimestampType == gw.FineTimestampType_NONE {
+			out = append(out, rxInfo)
+			continue
 		}
-	}(ctx.ApplicationServerClient, publishDataUpReq)
 
-	return nil
+		rxInfo = proto.Clone(rxInfo).(*gw.UplinkRXInfo)
+		rxInfo.FineTimestampType = gw.FineTimestampType_NONE
+		rxInfo.FineTimestamp = nil
+		out = append(out, rxInfo)
+	}
+	return out
 }
 
 func setLastRXInfoSet(ctx *dataContext) error {
@@ -575,18 +971,48 @@ func setLastRXInfoSet(ctx *dataContext) error {
 			gatewayID: storage.UplinkGatewayHistory{},
 		}
 	}
+
+	var rxInfoSet []storage.DeviceSessionRXInfo
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		item := storage.DeviceSessionRXInfo{
+			GatewayID: helpers.GetGatewayID(rxInfo),
+			Board:     rxInfo.Board,
+			Antenna:   rxInfo.Antenna,
+			RSSI:      int(rxInfo.Rssi),
+			LoRaSNR:   rxInfo.LoraSnr,
+			Time:      time.Now(),
+		}
+		if rxInfo.TimeSinceGpsEpoch != nil {
+			if d, err := ptypes.Duration(rxInfo.TimeSinceGpsEpoch); err == nil {
+				item.TimeSinceGPSEpoch = d
+			}
+		}
+		if rxInfo.Time != nil {
+			if ts, err := ptypes.Timestamp(rxInfo.Time); err == nil {
+				item.Time = ts
+			}
+		}
+		rxInfoSet = append(rxInfoSet, item)
+	}
+	ctx.DeviceSession.SetLastRXInfoSet(rxInfoSet)
+
 	return nil
 }
 
 func syncUplinkFCnt(ctx *dataContext) error {
 	// sync counter with that of the device + 1
 	ctx.DeviceSession.FCntUp = ctx.MACPayload.FHDR.FCnt + 1
+
+	// store the MIC to detect retransmissions of this uplink
+	ctx.DeviceSession.LastUplinkMIC = ctx.RXPacket.PHYPayload.MIC
+	ctx.DeviceSession.LastUplinkRetransmissions = 0
 	return nil
 }
 
 func saveDeviceSession(ctx *dataContext) error {
-	// save node-session
-	return storage.SaveDeviceSession(storage.RedisPool(), ctx.DeviceSession)
+	// save node-session, this fails when the device-session has been
+	// updated by an other process since it was read
+	return storage.SaveDeviceSessionIfUnchanged(storage.RedisPool(), &ctx.DeviceSession)
 }
 
 func handleUplinkACK(ctx *dataContext) error {
@@ -623,10 +1049,25 @@ func handleUplinkACK(ctx *dataContext) error {
 		return errors.Wrap(err, "application-server client error")
 	}
 
+	if err := storage.SendDownlinkStatus(ctx.ApplicationServerClient, qi, as.DownlinkStatus_ACKNOWLEDGED); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func handleDownlink(ctx *dataContext) error {
+	// a downlink must be sent on ADRACKReq, to reset the ADR_ACK_CNT of the
+	// device, unless this has been disabled
+	mustSend := ctx.MustSendDownlink
+	if ctx.MACPayload.FHDR.FCtrl.ADRACKReq {
+		adrAckReqCounter().Inc()
+
+		if !disableADRACKReqDownlink {
+			mustSend = true
+		}
+	}
+
 	// handle downlink (ACK)
 	time.Sleep(getDownlinkDataDelay)
 	if err := datadown.HandleResponse(
@@ -634,7 +1075,7 @@ func handleDownlink(ctx *dataContext) error {
 		ctx.ServiceProfile,
 		ctx.DeviceSession,
 		ctx.MACPayload.FHDR.FCtrl.ADR,
-		ctx.MACPayload.FHDR.FCtrl.ADRACKReq || ctx.MustSendDownlink,
+		mustSend,
 		ctx.RXPacket.PHYPayload.MHDR.MType == lorawan.ConfirmedDataUp,
 		ctx.MACCommandResponses,
 	); err != nil {
@@ -644,6 +1085,28 @@ func handleDownlink(ctx *dataContext) error {
 	return nil
 }
 
+// handleRetransmissionDownlink acknowledges the retransmission of a
+// confirmed uplink, as the ACK of the original uplink might have been lost.
+// This is limited to the retransmissions expected by NbTrans or by the
+// retries of the device, other retransmissions (e.g. replayed frames) never
+// result in a downlink.
+func handleRetransmissionDownlink(ctx *dataContext) error {
+	if !ctx.Retransmission || ctx.RXPacket.PHYPayload.MHDR.MType != lorawan.ConfirmedDataUp || !ctx.DeviceSession.IsExpectedConfirmedUplinkRetransmission() {
+		return nil
+	}
+
+	time.Sleep(getDownlinkDataDelay)
+	if err := datadown.HandleRetransmissionResponse(
+		ctx.RXPacket,
+		ctx.ServiceProfile,
+		ctx.DeviceSession,
+	); err != nil {
+		return errors.Wrap(err, "run uplink retransmission response flow error")
+	}
+
+	return nil
+}
+
 // sendRXInfoPayload sends the rx and tx meta-data to the network controller.
 func sendRXInfoPayload(ds storage.DeviceSession, rxPacket models.RXPacket) error {
 	rxInfoReq := nc.HandleUplinkMetaDataRequest{
@@ -663,22 +1126,26 @@ func sendRXInfoPayload(ds storage.DeviceSession, rxPacket models.RXPacket) error
 }
 
 // handleUplinkMACCommands handles the given uplink mac-commands.
-// It returns the mac-commands to respond with + a bool indicating the a downlink MUST be send,
+// It returns the mac-commands to respond with, the CIDs of the answered
+// pending mac-commands (these are removed from the given pending map and must
+// be deleted from the storage) + a bool indicating the a downlink MUST be send,
 // this to make sure that a response has been received by the NS.
-func handleUplinkMACCommands(ds *storage.DeviceSession, dp storage.DeviceProfile, sp storage.ServiceProfile, asClient as.ApplicationServerServiceClient, commands []lorawan.Payload, rxPacket models.RXPacket) ([]storage.MACCommandBlock, bool, error) {
+func handleUplinkMACCommands(ds *storage.DeviceSession, dp storage.DeviceProfile, sp storage.ServiceProfile, asClient as.ApplicationServerServiceClient, pendingBlocks map[lorawan.CID]storage.MACCommandBlock, commands []lorawan.Payload, rxPacket models.RXPacket) ([]storage.MACCommandBlock, []lorawan.CID, bool, error) {
 	var cids []lorawan.CID
+	var answered []lorawan.CID
 	var out []storage.MACCommandBlock
 	var mustRespondWithDownlink bool
 	blocks := make(map[lorawan.CID]storage.MACCommandBlock)
+	adrStopped := ds.ADRStopped
 
 	// group mac-commands by CID
 	for _, pl := range commands {
 		cmd, ok := pl.(*lorawan.MACCommand)
 		if !ok {
-			return nil, false, fmt.Errorf("expected *lorawan.MACCommand, got %T", pl)
+			return nil, nil, false, fmt.Errorf("expected *lorawan.MACCommand, got %T", pl)
 		}
 		if cmd == nil {
-			return nil, false, errors.New("*lorawan.MACCommand must not be nil")
+			return nil, nil, false, errors.New("*lorawan.MACCommand must not be nil")
 		}
 
 		block, ok := blocks[cmd.CID]
@@ -717,20 +1184,14 @@ func handleUplinkMACCommands(ds *storage.DeviceSession, dp storage.DeviceProfile
 			// pending mac-command block contains the request.
 			// we need this pending mac-command block to find out if the command
 			// was scheduled through the API (external).
-			pending, err := storage.GetPendingMACCommand(storage.RedisPool(), ds.DevEUI, block.CID)
-			if err != nil {
-				log.WithFields(logFields).Errorf("read pending mac-command error: %s", err)
-				continue
-			}
-			if pending != nil {
+			// in case the node is requesting a mac-command, there is nothing pending
+			var pending *storage.MACCommandBlock
+			if pendingBlock, ok := pendingBlocks[block.CID]; ok {
+				pending = &pendingBlock
 				external = pending.External
-			}
 
-			// in case the node is requesting a mac-command, there is nothing pending
-			if pending != nil {
-				if err = storage.DeletePendingMACCommand(storage.RedisPool(), ds.DevEUI, block.CID); err != nil {
-					log.WithFields(logFields).Errorf("delete pending mac-command error: %s", err)
-				}
+				delete(pendingBlocks, block.CID)
+				answered = append(answered, block.CID)
 			}
 
 			// CID >= 0x80 are proprietary mac-commands and are not handled by LoRa Server
@@ -771,5 +1232,13 @@ func handleUplinkMACCommands(ds *storage.DeviceSession, dp storage.DeviceProfile
 		}
 	}
 
-	return out, mustRespondWithDownlink, nil
+	// ADR has been stopped because of too many rejected LinkADRReqs
+	if !adrStopped && ds.ADRStopped {
+		errStr := fmt.Sprintf("adr stopped after %d rejected link_adr requests", ds.LinkADRReqRejectionCount)
+		if err := handleUplinkError(*ds, rxPacket, as.ErrorType_LINK_ADR_REQ_REJECTED, errStr, 0, 0); err != nil {
+			log.WithError(err).WithField("dev_eui", ds.DevEUI).Error("handle link_adr rejected error error")
+		}
+	}
+
+	return out, answered, mustRespondWithDownlink, nil
 }
@@ -0,0 +1,45 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+func TestApplicationServerRXInfoSet(t *testing.T) {
+	assert := require.New(t)
+
+	rxInfoSet := []*gw.UplinkRXInfo{
+		{
+			GatewayId:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			FineTimestampType: gw.FineTimestampType_PLAIN,
+			FineTimestamp: &gw.UplinkRXInfo_PlainFineTimestamp{
+				PlainFineTimestamp: &gw.PlainFineTimestamp{},
+			},
+		},
+		{
+			GatewayId: []byte{2, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+
+	t.Run("NwkGeoLoc enabled", func(t *testing.T) {
+		assert := require.New(t)
+		assert.Equal(rxInfoSet, applicationServerRXInfoSet(storage.ServiceProfile{NwkGeoLoc: true}, rxInfoSet))
+	})
+
+	t.Run("NwkGeoLoc disabled", func(t *testing.T) {
+		assert := require.New(t)
+		out := applicationServerRXInfoSet(storage.ServiceProfile{}, rxInfoSet)
+		assert.Equal([]*gw.UplinkRXInfo{
+			{GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+			{GatewayId: []byte{2, 2, 3, 4, 5, 6, 7, 8}},
+		}, out)
+	})
+
+	// the rx-info set used by the network-server must not be modified
+	assert.Equal(gw.FineTimestampType_PLAIN, rxInfoSet[0].FineTimestampType)
+	assert.NotNil(rxInfoSet[0].GetPlainFineTimestamp())
+}
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const uplinkErrorLockKeyTempl = "lora:ns:device:%s:error:%s:lock"
+
+var (
+	forwardErrorTypes        map[as.ErrorType]bool
+	forwardErrorRateInterval time.Duration
+)
+
+// setForwardErrorTypes sets the error types which are forwarded to the
+// application-server.
+func setForwardErrorTypes(types []string) error {
+	forwardErrorTypes = make(map[as.ErrorType]bool)
+	for _, t := range types {
+		v, ok := as.ErrorType_value[t]
+		if !ok {
+			return fmt.Errorf("unknown uplink error type: %s", t)
+		}
+		forwardErrorTypes[as.ErrorType(v)] = true
+
+		// a frame-counter gap which is too large used to be reported as
+		// DATA_UP_FCNT
+		if as.ErrorType(v) == as.ErrorType_DATA_UP_FCNT {
+			forwardErrorTypes[as.ErrorType_DATA_UP_FCNT_GAP_TOO_LARGE] = true
+		}
+	}
+	return nil
+}
+
+// handleUplinkError counts the given error and forwards it to the
+// application-server of the device when the error type is enabled and the
+// error has not been forwarded within the rate-limit interval.
+func handleUplinkError(ds storage.DeviceSession, rxPacket models.RXPacket, errType as.ErrorType, errStr string, fCnt, expectedFCnt uint32) error {
+	errorCounter(errType.String()).Inc()
+
+	log.WithFields(log.Fields{
+		"dev_eui":        ds.DevEUI,
+		"type":           errType,
+		"f_cnt":          fCnt,
+		"expected_f_cnt": expectedFCnt,
+	}).Warning("uplink data validation error")
+
+	if !forwardErrorTypes[errType] {
+		return nil
+	}
+
+	locked, err := acquireUplinkErrorLock(storage.RedisPool(), ds.DevEUI, errType)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	return sendUplinkError(ds, rxPacket, errType, errStr, fCnt, expectedFCnt)
+}
+
+// sendUplinkError sends the given error to the application-server of the
+// device.
+func sendUplinkError(ds storage.DeviceSession, rxPacket models.RXPacket, errType as.ErrorType, errStr string, fCnt, expectedFCnt uint32) error {
+	sp, err := storage.GetAndCacheServiceProfile(storage.DB(), storage.RedisPool(), ds.ServiceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get service-profile error")
+	}
+
+	rp, err := storage.GetAndCacheRoutingProfile(storage.DB(), ds.RoutingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), applicationClientTimeout)
+	defer cancel()
+
+	req := as.HandleErrorRequest{
+		DevEui:       ds.DevEUI[:],
+		Type:         errType,
+		Error:        errStr,
+		FCnt:         fCnt,
+		ExpectedFCnt: expectedFCnt,
+	}
+
+	// the gateway meta-data must only be exposed when allowed by the
+	// service-profile
+	if sp.AddGWMetadata {
+		req.RxInfo = applicationServerRXInfoSet(sp, rxPacket.RXInfoSet)
+	}
+
+	_, err = asClient.HandleError(ctx, &req)
+	if err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
+
+// acquireUplinkErrorLock returns true when the lock for forwarding the
+// given error type was acquired, false when the error was already forwarded
+// within the rate-limit interval.
+func acquireUplinkErrorLock(p *redis.Pool, devEUI lorawan.EUI64, errType as.ErrorType) (bool, error) {
+	if forwardErrorRateInterval == 0 {
+		return true, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(uplinkErrorLockKeyTempl, devEUI, errType)
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(forwardErrorRateInterval/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "acquire uplink error lock error")
+	}
+
+	return true, nil
+}
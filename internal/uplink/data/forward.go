@@ -0,0 +1,281 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+var (
+	forwardRetryCount   int
+	forwardRetryBackoff time.Duration
+
+	deadLetterQueueEnabled   bool
+	deadLetterQueueMaxLength int
+	deadLetterDrainInterval  time.Duration
+
+	deadLetterWorker *drainWorker
+
+	// forwardWG tracks the pending uplink data forwards.
+	forwardWG sync.WaitGroup
+)
+
+// setupForwarding configures the uplink data forwarding and (re)starts the
+// dead-letter queue worker when enabled.
+func setupForwarding(conf config.Config) {
+	forwardRetryCount = conf.ApplicationServer.RetryCount
+	forwardRetryBackoff = conf.ApplicationServer.RetryBackoff
+	deadLetterQueueEnabled = conf.ApplicationServer.DeadLetterQueue.Enabled
+	deadLetterQueueMaxLength = conf.ApplicationServer.DeadLetterQueue.MaxLength
+	deadLetterDrainInterval = conf.ApplicationServer.DeadLetterQueue.DrainInterval
+
+	StopDeadLetterWorker()
+
+	if deadLetterQueueEnabled && deadLetterDrainInterval != 0 {
+		deadLetterWorker = newDrainWorker(deadLetterDrainInterval)
+	}
+}
+
+// StopDeadLetterWorker stops the dead-letter queue worker (when running).
+func StopDeadLetterWorker() {
+	if deadLetterWorker != nil {
+		deadLetterWorker.stop()
+		deadLetterWorker = nil
+	}
+}
+
+// WaitForPendingForwards waits until the pending uplink data forwards
+// (including their retries) have completed.
+func WaitForPendingForwards() {
+	forwardWG.Wait()
+}
+
+// goForwardUplinkData forwards the given uplink data in a separate
+// go-routine, tracked by WaitForPendingForwards.
+func goForwardUplinkData(rpID uuid.UUID, req as.HandleUplinkDataRequest) {
+	forwardWG.Add(1)
+	go func() {
+		defer forwardWG.Done()
+		forwardUplinkData(rpID, req)
+	}()
+}
+
+// forwardUplinkData forwards the given uplink data to the application-server
+// of the routing-profile. Failed attempts are retried with an exponential
+// backoff when the application-server is unavailable. When all attempts
+// failed, the uplink data is stored in the dead-letter queue (when enabled).
+func forwardUplinkData(rpID uuid.UUID, req as.HandleUplinkDataRequest) {
+	backoff := forwardRetryBackoff
+
+	var err error
+	for i := 0; i <= forwardRetryCount; i++ {
+		if i != 0 {
+			forwardRetryCounter().Inc()
+			time.Sleep(backoff)
+			backoff = backoff * 2
+		}
+
+		err = sendUplinkData(rpID, req)
+		if err == nil || !isRetryableError(err) {
+			break
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	log.WithError(err).WithField("routing_profile_id", rpID).Error("publish uplink data to application-server error")
+
+	if !deadLetterQueueEnabled || !isRetryableError(err) {
+		return
+	}
+
+	if err := storage.PushDeadLetterUplinkData(storage.RedisPool(), rpID, req, deadLetterQueueMaxLength); err != nil {
+		log.WithError(err).WithField("routing_profile_id", rpID).Error("push uplink data to dead-letter queue error")
+		return
+	}
+	deadLetterCounter().Inc()
+}
+
+// sendUplinkData sends the uplink data to the application-server of the
+// routing-profile.
+func sendUplinkData(rpID uuid.UUID, req as.HandleUplinkDataRequest) error {
+	rp, err := storage.GetAndCacheRoutingProfile(storage.DB(), rpID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), applicationClientTimeout)
+	defer cancel()
+
+	if _, err := asClient.HandleUplinkData(ctx, &req); err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
+
+// isRetryableError returns true when the error indicates that the
+// application-server is (temporarily) unavailable.
+func isRetryableError(err error) bool {
+	err = errors.Cause(err)
+	if err == storage.ErrDoesNotExist {
+		return false
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		// e.g. a dial error
+		return true
+	}
+
+	switch s.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// drainWorker periodically forwards the dead-letter queues to the
+// application-servers.
+type drainWorker struct {
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newDrainWorker(interval time.Duration) *drainWorker {
+	w := drainWorker{
+		done: make(chan struct{}),
+	}
+
+	w.wg.Add(1)
+	go w.run(interval)
+
+	return &w
+}
+
+func (w *drainWorker) stop() {
+	close(w.done)
+	w.wg.Wait()
+}
+
+func (w *drainWorker) run(interval time.Duration) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if err := drainDeadLetterQueues(w.done, interval); err != nil {
+				log.WithError(err).Error("drain dead-letter queues error")
+			}
+		}
+	}
+}
+
+// drainDeadLetterQueues forwards the dead-letter queues of all
+// routing-profiles until stopped.
+func drainDeadLetterQueues(done chan struct{}, lockTTL time.Duration) error {
+	ids, err := storage.GetDeadLetterRoutingProfileIDs(storage.RedisPool())
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := drainDeadLetterQueue(done, id, lockTTL); err != nil {
+			log.WithError(err).WithField("routing_profile_id", id).Warning("drain dead-letter queue error")
+		}
+
+		l, err := storage.GetDeadLetterQueueLength(storage.RedisPool(), id)
+		if err != nil {
+			return err
+		}
+		deadLetterQueueDepthGauge(id).Set(float64(l))
+	}
+
+	return nil
+}
+
+// drainDeadLetterQueue forwards the dead-letter queue of the given
+// routing-profile until it is empty, the application-server is unavailable,
+// the lock was lost or until stopped.
+func drainDeadLetterQueue(done chan struct{}, rpID uuid.UUID, lockTTL time.Duration) error {
+	lockID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+
+	locked, err := storage.AcquireDeadLetterQueueLock(storage.RedisPool(), rpID, lockID, lockTTL)
+	if err != nil || !locked {
+		return err
+	}
+	defer func() {
+		if err := storage.ReleaseDeadLetterQueueLock(storage.RedisPool(), rpID, lockID); err != nil {
+			log.WithError(err).WithField("routing_profile_id", rpID).Error("release dead-letter queue lock error")
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		// the lock is refreshed for every item, in case it expired (e.g. the
+		// forwarding took longer than the TTL), an other instance might
+		// be draining the queue
+		locked, err := storage.RefreshDeadLetterQueueLock(storage.RedisPool(), rpID, lockID, lockTTL)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return errors.New("dead-letter queue lock expired")
+		}
+
+		req, err := storage.PopDeadLetterUplinkData(storage.RedisPool(), rpID)
+		if err != nil {
+			if err == storage.ErrDoesNotExist {
+				return nil
+			}
+			return err
+		}
+
+		if err := sendUplinkData(rpID, req); err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				// the routing-profile has been removed
+				deadLetterQueueDepthDelete(rpID)
+				return storage.FlushDeadLetterQueue(storage.RedisPool(), rpID)
+			}
+			if isRetryableError(err) {
+				return err
+			}
+			log.WithError(err).WithField("routing_profile_id", rpID).Error("dead-letter uplink data rejected by application-server")
+		}
+
+		if err := storage.AckDeadLetterUplinkData(storage.RedisPool(), rpID); err != nil {
+			return err
+		}
+	}
+}
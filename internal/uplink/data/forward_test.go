@@ -0,0 +1,189 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+)
+
+type ForwardTestSuite struct {
+	suite.Suite
+
+	asClient *test.ApplicationClient
+	rp       storage.RoutingProfile
+}
+
+func (ts *ForwardTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+
+	ts.asClient = test.NewApplicationClient()
+	applicationserver.SetPool(test.NewApplicationServerPool(ts.asClient))
+
+	assert.NoError(storage.CreateRoutingProfile(storage.DB(), &ts.rp))
+
+	forwardRetryCount = 2
+	forwardRetryBackoff = time.Millisecond
+	deadLetterQueueEnabled = true
+	deadLetterQueueMaxLength = 10
+}
+
+func (ts *ForwardTestSuite) TearDownSuite() {
+	forwardRetryCount = 0
+	forwardRetryBackoff = 0
+	deadLetterQueueEnabled = false
+	deadLetterQueueMaxLength = 0
+}
+
+func (ts *ForwardTestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+	ts.asClient.HandleDataUpErr = nil
+}
+
+func (ts *ForwardTestSuite) TestForwardUplinkData() {
+	ts.T().Run("forwarded", func(t *testing.T) {
+		assert := require.New(t)
+
+		forwardUplinkData(ts.rp.ID, as.HandleUplinkDataRequest{FCnt: 1})
+		assert.Equal(as.HandleUplinkDataRequest{FCnt: 1}, <-ts.asClient.HandleDataUpChan)
+
+		l, err := storage.GetDeadLetterQueueLength(storage.RedisPool(), ts.rp.ID)
+		assert.NoError(err)
+		assert.Equal(0, l)
+	})
+
+	ts.T().Run("retries exhausted", func(t *testing.T) {
+		assert := require.New(t)
+		ts.asClient.HandleDataUpErr = status.Error(codes.Unavailable, "unavailable")
+		defer func() { ts.asClient.HandleDataUpErr = nil }()
+
+		forwardUplinkData(ts.rp.ID, as.HandleUplinkDataRequest{FCnt: 2})
+
+		req, err := storage.PopDeadLetterUplinkData(storage.RedisPool(), ts.rp.ID)
+		assert.NoError(err)
+		assert.EqualValues(2, req.FCnt)
+		assert.NoError(storage.FlushDeadLetterQueue(storage.RedisPool(), ts.rp.ID))
+	})
+
+	ts.T().Run("non-retryable error", func(t *testing.T) {
+		assert := require.New(t)
+		ts.asClient.HandleDataUpErr = status.Error(codes.InvalidArgument, "invalid")
+		defer func() { ts.asClient.HandleDataUpErr = nil }()
+
+		forwardUplinkData(ts.rp.ID, as.HandleUplinkDataRequest{FCnt: 3})
+
+		l, err := storage.GetDeadLetterQueueLength(storage.RedisPool(), ts.rp.ID)
+		assert.NoError(err)
+		assert.Equal(0, l)
+	})
+}
+
+func (ts *ForwardTestSuite) TestDrainDeadLetterQueue() {
+	done := make(chan struct{})
+
+	push := func(assert *require.Assertions, fCnts ...uint32) {
+		for _, fCnt := range fCnts {
+			assert.NoError(storage.PushDeadLetterUplinkData(storage.RedisPool(), ts.rp.ID, as.HandleUplinkDataRequest{FCnt: fCnt}, deadLetterQueueMaxLength))
+		}
+	}
+
+	queueLength := func(assert *require.Assertions) int {
+		l, err := storage.GetDeadLetterQueueLength(storage.RedisPool(), ts.rp.ID)
+		assert.NoError(err)
+		return l
+	}
+
+	ts.T().Run("stops on unavailable", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+		push(assert, 1, 2)
+
+		ts.asClient.HandleDataUpErr = status.Error(codes.Unavailable, "unavailable")
+		assert.Error(drainDeadLetterQueue(done, ts.rp.ID, time.Minute))
+		assert.Equal(2, queueLength(assert))
+
+		// the item which failed is forwarded first
+		ts.asClient.HandleDataUpErr = nil
+		assert.NoError(drainDeadLetterQueue(done, ts.rp.ID, time.Minute))
+		assert.EqualValues(1, (<-ts.asClient.HandleDataUpChan).FCnt)
+		assert.EqualValues(2, (<-ts.asClient.HandleDataUpChan).FCnt)
+		assert.Equal(0, queueLength(assert))
+	})
+
+	ts.T().Run("drops rejected items", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+		push(assert, 1, 2)
+
+		ts.asClient.HandleDataUpErr = status.Error(codes.InvalidArgument, "invalid")
+		defer func() { ts.asClient.HandleDataUpErr = nil }()
+
+		assert.NoError(drainDeadLetterQueue(done, ts.rp.ID, time.Minute))
+		assert.Equal(0, queueLength(assert))
+	})
+
+	ts.T().Run("locked by an other instance", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+		push(assert, 1)
+
+		locked, err := storage.AcquireDeadLetterQueueLock(storage.RedisPool(), ts.rp.ID, uuid.Must(uuid.NewV4()), time.Minute)
+		assert.NoError(err)
+		assert.True(locked)
+
+		assert.NoError(drainDeadLetterQueue(done, ts.rp.ID, time.Minute))
+		assert.Equal(1, queueLength(assert))
+	})
+
+	ts.T().Run("routing-profile removed", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+
+		rpID := uuid.Must(uuid.NewV4())
+		assert.NoError(storage.PushDeadLetterUplinkData(storage.RedisPool(), rpID, as.HandleUplinkDataRequest{FCnt: 1}, deadLetterQueueMaxLength))
+
+		assert.NoError(drainDeadLetterQueue(done, rpID, time.Minute))
+		l, err := storage.GetDeadLetterQueueLength(storage.RedisPool(), rpID)
+		assert.NoError(err)
+		assert.Equal(0, l)
+	})
+}
+
+func TestForward(t *testing.T) {
+	suite.Run(t, new(ForwardTestSuite))
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Error    error
+		Expected bool
+	}{
+		{"unavailable", status.Error(codes.Unavailable, ""), true},
+		{"deadline exceeded", status.Error(codes.DeadlineExceeded, ""), true},
+		{"resource exhausted", status.Error(codes.ResourceExhausted, ""), true},
+		{"invalid argument", status.Error(codes.InvalidArgument, ""), false},
+		{"routing-profile does not exist", storage.ErrDoesNotExist, false},
+		{"dial error", errors.New("dial error"), true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, isRetryableError(tst.Error))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	adrAckReqc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_data_adr_ack_req_count",
+		Help: "The number of received uplink frames with the ADRACKReq bit set.",
+	})
+
+	errc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "uplink_data_error_count",
+		Help: "The number of uplink data frames that failed validation (per error type).",
+	}, []string{"type"})
+
+	frc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_data_forward_retry_count",
+		Help: "The number of retried uplink data forwards to the application-server.",
+	})
+
+	dlc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_data_dead_letter_count",
+		Help: "The number of uplink data forwards stored in the dead-letter queue.",
+	})
+
+	nidrc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "uplink_data_net_id_rejected_count",
+		Help: "The number of uplink data frames rejected because the DevAddr does not match the NetID (per NetID type and NwkID).",
+	}, []string{"net_id_type", "nwk_id"})
+
+	abec = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_data_airtime_budget_exceeded_count",
+		Help: "The number of uplink data frames received from devices that exceeded the uplink airtime budget of the service-profile.",
+	})
+
+	rtc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "uplink_data_retransmission_count",
+		Help: "The number of received uplink data retransmissions (same FCnt and MIC as the previous uplink), expected is true when the retransmission is caused by the NbTrans of the device.",
+	}, []string{"expected"})
+
+	dlqd = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "uplink_data_dead_letter_queue_depth",
+		Help: "The number of items in the dead-letter queue (per routing-profile).",
+	}, []string{"routing_profile_id"})
+)
+
+func adrAckReqCounter() prometheus.Counter {
+	return adrAckReqc
+}
+
+func errorCounter(t string) prometheus.Counter {
+	return errc.With(prometheus.Labels{"type": t})
+}
+
+func forwardRetryCounter() prometheus.Counter {
+	return frc
+}
+
+func deadLetterCounter() prometheus.Counter {
+	return dlc
+}
+
+func netIDRejectedCounter(netIDType int, nwkID string) prometheus.Counter {
+	return nidrc.With(prometheus.Labels{"net_id_type": strconv.Itoa(netIDType), "nwk_id": nwkID})
+}
+
+func airtimeBudgetExceededCounter() prometheus.Counter {
+	return abec
+}
+
+func retransmissionCounter(expected bool) prometheus.Counter {
+	return rtc.With(prometheus.Labels{"expected": strconv.FormatBool(expected)})
+}
+
+func deadLetterQueueDepthGauge(rpID uuid.UUID) prometheus.Gauge {
+	return dlqd.With(prometheus.Labels{"routing_profile_id": rpID.String()})
+}
+
+func deadLetterQueueDepthDelete(rpID uuid.UUID) {
+	dlqd.Delete(prometheus.Labels{"routing_profile_id": rpID.String()})
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/brocaar/loraserver/internal/backend/joinserver"
 	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/channels"
 	"github.com/brocaar/loraserver/internal/config"
 	joindown "github.com/brocaar/loraserver/internal/downlink/join"
 	"github.com/brocaar/loraserver/internal/framelog"
@@ -157,12 +158,36 @@ func validateNonce(ctx *context) error {
 	// validate that the nonce has not been used yet
 	err := storage.ValidateDevNonce(storage.DB(), ctx.JoinRequestPayload.JoinEUI, ctx.JoinRequestPayload.DevEUI, ctx.JoinRequestPayload.DevNonce, lorawan.JoinRequestType)
 	if err != nil {
+		if err == storage.ErrAlreadyExists {
+			logDevNonceReplay(ctx)
+		}
 		return errors.Wrap(err, "validate dev-nonce error")
 	}
 
 	return nil
 }
 
+// logDevNonceReplay logs the rejected join-request to the frame-log of the
+// device, so that a replay can be distinguished from other join failures.
+func logDevNonceReplay(ctx *context) {
+	log.WithFields(log.Fields{
+		"dev_eui":   ctx.JoinRequestPayload.DevEUI,
+		"join_eui":  ctx.JoinRequestPayload.JoinEUI,
+		"dev_nonce": ctx.JoinRequestPayload.DevNonce,
+	}).Warning("join-request rejected, dev-nonce has already been used")
+
+	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
+	if err != nil {
+		log.WithError(err).Error("create uplink frame-set error")
+		return
+	}
+
+	reason := fmt.Sprintf("dev-nonce %d has already been used (possible replay)", ctx.JoinRequestPayload.DevNonce)
+	if err := framelog.LogRejectedUplinkFrameForDevEUI(storage.RedisPool(), ctx.JoinRequestPayload.DevEUI, uplinkFrameSet, reason); err != nil {
+		log.WithError(err).Error("log rejected uplink frame for device error")
+	}
+}
+
 func getRandomDevAddr(ctx *context) error {
 	devAddr, err := storage.GetRandomDevAddr(storage.RedisPool(), netID)
 	if err != nil {
@@ -187,7 +212,7 @@ func getJoinAcceptFromAS(ctx *context) error {
 	transactionID := binary.LittleEndian.Uint32(randomBytes)
 
 	var cFListB []byte
-	cFList := band.Band().GetCFList(ctx.DeviceProfile.MACVersion)
+	cFList := channels.GetCFList(ctx.ServiceProfile, ctx.DeviceProfile.MACVersion)
 	if cFList != nil {
 		cFListB, err = cFList.MarshalBinary()
 		if err != nil {
@@ -263,7 +288,14 @@ func createDeviceSession(ctx *context) error {
 		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
 		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
 		NbTrans:               1,
+		RXWindowPreference:    ctx.DeviceProfile.RXWindowPreference,
+		MaxFCntGap:            uint32(ctx.DeviceProfile.MaxFCntGap),
 		ReferenceAltitude:     ctx.Device.ReferenceAltitude,
+
+		// until the device acknowledged the TxParamSetupReq mac-command,
+		// it operates using the default dwell-time of the band
+		UplinkDwellTime400ms:   band.DwellTime400msByDefault(),
+		DownlinkDwellTime400ms: band.DwellTime400msByDefault(),
 	}
 
 	if ctx.JoinAnsPayload.AppSKey != nil {
@@ -311,6 +343,16 @@ func createDeviceSession(ctx *context) error {
 		ds.NwkSEncKey = key
 	}
 
+	// the device applies the channel-mask of the CFList (LoRaWAN 1.0.3+)
+	if cfList := channels.GetCFList(ctx.ServiceProfile, ctx.DeviceProfile.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannelMask {
+		chMaskPL, ok := cfList.Payload.(*lorawan.CFListChannelMaskPayload)
+		if !ok {
+			return fmt.Errorf("expected *lorawan.CFListChannelMaskPayload, got %T", cfList.Payload)
+		}
+
+		ds.EnabledUplinkChannels = channels.GetCFListEnabledUplinkChannelIndices(*chMaskPL)
+	}
+
 	if cfList := band.Band().GetCFList(ctx.DeviceProfile.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
 		channelPL, ok := cfList.Payload.(*lorawan.CFListChannelPayload)
 		if !ok {
@@ -0,0 +1,45 @@
+package uplink
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ufc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_frame_count",
+		Help: "The number of received uplink frames (before deduplication).",
+	})
+
+	udgc = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "uplink_deduplication_gateway_count",
+		Help:    "The number of gateways that received the same uplink frame (after deduplication).",
+		Buckets: []float64{1, 2, 3, 5, 8, 13, 21},
+	})
+
+	uhc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "uplink_handle_count",
+		Help: "The number of handled uplink frames after deduplication (per message-type).",
+	}, []string{"mtype"})
+
+	uhec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "uplink_handle_error_count",
+		Help: "The number of uplink frames that failed to be handled (per message-type).",
+	}, []string{"mtype"})
+)
+
+func uplinkFrameCounter() prometheus.Counter {
+	return ufc
+}
+
+func uplinkDeduplicationGatewayCount() prometheus.Observer {
+	return udgc
+}
+
+func uplinkHandleCounter(mType string) prometheus.Counter {
+	return uhc.With(prometheus.Labels{"mtype": mType})
+}
+
+func uplinkHandleErrorCounter(mType string) prometheus.Counter {
+	return uhec.With(prometheus.Labels{"mtype": mType})
+}
@@ -0,0 +1,174 @@
+package proprietary
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/backend/applicationserver"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// Handler defines the interface of a proprietary uplink handler.
+type Handler interface {
+	HandleProprietaryUplink(req as.HandleProprietaryUplinkRequest) error
+}
+
+var (
+	handlerMux sync.RWMutex
+	handler    Handler = &applicationServerHandler{}
+)
+
+// setHandler replaces the proprietary uplink handler. When the replaced
+// handler is a mqttHandler, its MQTT connection is closed.
+func setHandler(h Handler) {
+	handlerMux.Lock()
+	old := handler
+	handler = h
+	handlerMux.Unlock()
+
+	if mh, ok := old.(*mqttHandler); ok {
+		mh.close()
+	}
+}
+
+// getHandler returns the proprietary uplink handler.
+func getHandler() Handler {
+	handlerMux.RLock()
+	defer handlerMux.RUnlock()
+	return handler
+}
+
+// applicationServerHandler forwards the proprietary uplink to the
+// application-server of each routing-profile.
+type applicationServerHandler struct{}
+
+func (h *applicationServerHandler) HandleProprietaryUplink(req as.HandleProprietaryUplinkRequest) error {
+	// send proprietary to all application servers, as the network-server
+	// has know knowledge / state about which application-server is responsible
+	// for this frame
+	rps, err := storage.GetAllRoutingProfiles(storage.DB())
+	if err != nil {
+		return errors.Wrap(err, "get all routing-profiles error")
+	}
+
+	for _, rp := range rps {
+		go func(rp storage.RoutingProfile, req as.HandleProprietaryUplinkRequest) {
+			asClient, err := applicationserver.Pool().Get(rp.ID, rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+			if err != nil {
+				log.WithError(err).Error("get application-server client error")
+				return
+			}
+
+			if _, err = asClient.HandleProprietaryUplink(context.Background(), &req); err != nil {
+				log.WithError(err).Error("handle proprietary up error")
+				return
+			}
+		}(rp, req)
+	}
+
+	return nil
+}
+
+// mqttHandler publishes the (JSON encoded) proprietary uplink to a MQTT
+// topic.
+type mqttHandler struct {
+	conn  paho.Client
+	topic string
+	qos   uint8
+}
+
+func newMQTTHandler(c config.Config) (*mqttHandler, error) {
+	conf := c.NetworkServer.ProprietaryUplink.MQTT
+
+	h := mqttHandler{
+		topic: conf.Topic,
+		qos:   conf.QOS,
+	}
+
+	opts := paho.NewClientOptions()
+	opts.AddBroker(conf.Server)
+	opts.SetUsername(conf.Username)
+	opts.SetPassword(conf.Password)
+	opts.SetClientID(conf.ClientID)
+
+	tlsconfig, err := newTLSConfig(conf.CACert, conf.TLSCert, conf.TLSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "load mqtt certificate files error")
+	}
+	if tlsconfig != nil {
+		opts.SetTLSConfig(tlsconfig)
+	}
+
+	log.WithField("server", conf.Server).Info("uplink/proprietary: connecting to mqtt broker")
+	h.conn = paho.NewClient(opts)
+	for {
+		if token := h.conn.Connect(); token.Wait() && token.Error() != nil {
+			log.Errorf("uplink/proprietary: connecting to mqtt broker failed, will retry in 2s: %s", token.Error())
+			time.Sleep(2 * time.Second)
+		} else {
+			break
+		}
+	}
+
+	return &h, nil
+}
+
+func (h *mqttHandler) HandleProprietaryUplink(req as.HandleProprietaryUplinkRequest) error {
+	var m jsonpb.Marshaler
+	str, err := m.MarshalToString(&req)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	if token := h.conn.Publish(h.topic, h.qos, false, []byte(str)); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "publish proprietary uplink error")
+	}
+
+	return nil
+}
+
+func (h *mqttHandler) close() {
+	h.conn.Disconnect(250)
+}
+
+func newTLSConfig(cafile, certFile, certKeyFile string) (*tls.Config, error) {
+	if cafile == "" && certFile == "" && certKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if cafile != "" {
+		cacert, err := ioutil.ReadFile(cafile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load ca certificate error")
+		}
+		certpool := x509.NewCertPool()
+		if !certpool.AppendCertsFromPEM(cacert) {
+			return nil, errors.New("append ca certificate error")
+		}
+
+		tlsConfig.RootCAs = certpool
+	}
+
+	if certFile != "" || certKeyFile != "" {
+		kp, err := tls.LoadX509KeyPair(certFile, certKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load tls key-pair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{kp}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,17 @@
+package proprietary
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	rlc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_proprietary_rate_limited_count",
+		Help: "The number of proprietary uplink frame receptions dropped because the receiving gateway exceeded the rate-limit.",
+	})
+)
+
+func rateLimitedCounter() prometheus.Counter {
+	return rlc
+}
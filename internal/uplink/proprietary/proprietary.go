@@ -1,30 +1,66 @@
 package proprietary
 
 import (
-	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/api/common"
-	"github.com/brocaar/loraserver/internal/backend/applicationserver"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/helpers"
 	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
 
+var errAbort = errors.New("abort")
+
 var tasks = []func(*proprietaryContext) error{
 	setContextFromProprietaryPHYPayload,
-	sendProprietaryPayloadToApplicationServer,
+	handleGatewayDiscoveryPing,
+	applyGatewayRateLimit,
+	setGatewayLocations,
+	sendProprietaryPayloadToHandler,
 }
 
+var (
+	rateLimit         int
+	rateLimitInterval time.Duration
+)
+
 type proprietaryContext struct {
 	RXPacket    models.RXPacket
 	DataPayload *lorawan.DataPayload
 }
 
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	c := conf.NetworkServer.ProprietaryUplink
+
+	rateLimit = c.RateLimit
+	rateLimitInterval = c.RateLimitInterval
+
+	switch c.Handler {
+	case "", "application_server":
+		setHandler(&applicationServerHandler{})
+	case "mqtt":
+		h, err := newMQTTHandler(conf)
+		if err != nil {
+			return errors.Wrap(err, "new mqtt handler error")
+		}
+		setHandler(h)
+	default:
+		return fmt.Errorf("unknown proprietary uplink handler: %s", c.Handler)
+	}
+
+	return nil
+}
+
 // Handle handles a proprietary uplink frame.
 func Handle(rxPacket models.RXPacket) error {
 	ctx := proprietaryContext{
@@ -33,6 +69,9 @@ func Handle(rxPacket models.RXPacket) error {
 
 	for _, t := range tasks {
 		if err := t(&ctx); err != nil {
+			if err == errAbort {
+				return nil
+			}
 			return err
 		}
 	}
@@ -49,34 +88,72 @@ func setContextFromProprietaryPHYPayload(ctx *proprietaryContext) error {
 	return nil
 }
 
-func sendProprietaryPayloadToApplicationServer(ctx *proprietaryContext) error {
-	var ids []lorawan.EUI64
+// handleGatewayDiscoveryPing handles the gateway discovery pings. These are
+// not forwarded to the proprietary uplink handler.
+func handleGatewayDiscoveryPing(ctx *proprietaryContext) error {
+	ok, err := gateway.HandleDiscoveryPing(ctx.DataPayload.Bytes, ctx.RXPacket.PHYPayload.MIC, ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "handle gateway discovery ping error")
+	}
+	if ok {
+		return errAbort
+	}
+	return nil
+}
 
-	handleReq := as.HandleProprietaryUplinkRequest{
-		MacPayload: ctx.DataPayload.Bytes,
-		Mic:        ctx.RXPacket.PHYPayload.MIC[:],
-		TxInfo:     ctx.RXPacket.TXInfo,
-		RxInfo:     ctx.RXPacket.RXInfoSet,
+// applyGatewayRateLimit removes the rx-info of the gateways that exceeded
+// the proprietary uplink rate-limit. The frame is not forwarded when none
+// of the receiving gateways are within the rate-limit.
+func applyGatewayRateLimit(ctx *proprietaryContext) error {
+	if rateLimit == 0 {
+		return nil
 	}
 
-	// get gateway info
-	for i := range handleReq.RxInfo {
-		var id lorawan.EUI64
-		copy(id[:], handleReq.RxInfo[i].GatewayId)
-		ids = append(ids, id)
+	var rxInfoSet []*gw.UplinkRXInfo
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		gatewayID := helpers.GetGatewayID(rxInfo)
+
+		count, err := storage.IncrGatewayProprietaryUplinkCount(storage.RedisPool(), gatewayID, rateLimitInterval)
+		if err != nil {
+			return errors.Wrap(err, "increment gateway proprietary uplink count error")
+		}
+
+		if count > rateLimit {
+			rateLimitedCounter().Inc()
+			log.WithFields(log.Fields{
+				"gateway_id": gatewayID,
+				"rate_limit": rateLimit,
+			}).Warning("gateway exceeded proprietary uplink rate-limit")
+			continue
+		}
+
+		rxInfoSet = append(rxInfoSet, rxInfo)
+	}
+
+	if len(rxInfoSet) == 0 {
+		return errAbort
+	}
+
+	ctx.RXPacket.RXInfoSet = rxInfoSet
+
+	return nil
+}
+
+func setGatewayLocations(ctx *proprietaryContext) error {
+	var ids []lorawan.EUI64
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		ids = append(ids, helpers.GetGatewayID(rxInfo))
 	}
+
 	gws, err := storage.GetGatewaysForIDs(storage.DB(), ids)
 	if err != nil {
 		log.WithField("gateway_ids", ids).Warningf("get gateways for gateway ids error: %s", err)
-		gws = make(map[lorawan.EUI64]storage.Gateway)
+		return nil
 	}
 
-	for i := range handleReq.RxInfo {
-		var id lorawan.EUI64
-		copy(id[:], handleReq.RxInfo[i].GatewayId)
-
-		if gw, ok := gws[id]; ok {
-			handleReq.RxInfo[i].Location = &common.Location{
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		if gw, ok := gws[helpers.GetGatewayID(rxInfo)]; ok {
+			rxInfo.Location = &common.Location{
 				Latitude:  gw.Location.Latitude,
 				Longitude: gw.Location.Longitude,
 				Altitude:  gw.Altitude,
@@ -84,27 +161,19 @@ func sendProprietaryPayloadToApplicationServer(ctx *proprietaryContext) error {
 		}
 	}
 
-	// send proprietary to all application servers, as the network-server
-	// has know knowledge / state about which application-server is responsible
-	// for this frame
-	rps, err := storage.GetAllRoutingProfiles(storage.DB())
-	if err != nil {
-		return errors.Wrap(err, "get all routing-profiles error")
-	}
+	return nil
+}
 
-	for _, rp := range rps {
-		go func(rp storage.RoutingProfile, handleReq as.HandleProprietaryUplinkRequest) {
-			asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
-			if err != nil {
-				log.WithError(err).Error("get application-server client error")
-				return
-			}
+func sendProprietaryPayloadToHandler(ctx *proprietaryContext) error {
+	req := as.HandleProprietaryUplinkRequest{
+		MacPayload: ctx.DataPayload.Bytes,
+		Mic:        ctx.RXPacket.PHYPayload.MIC[:],
+		TxInfo:     ctx.RXPacket.TXInfo,
+		RxInfo:     ctx.RXPacket.RXInfoSet,
+	}
 
-			if _, err = asClient.HandleProprietaryUplink(context.Background(), &handleReq); err != nil {
-				log.WithError(err).Error("handle proprietary up error")
-				return
-			}
-		}(rp, handleReq)
+	if err := getHandler().HandleProprietaryUplink(req); err != nil {
+		return errors.Wrap(err, "handle proprietary uplink error")
 	}
 
 	return nil
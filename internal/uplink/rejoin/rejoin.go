@@ -12,6 +12,7 @@ import (
 
 	"github.com/brocaar/loraserver/internal/backend/joinserver"
 	"github.com/brocaar/loraserver/internal/band"
+	"github.com/brocaar/loraserver/internal/channels"
 	"github.com/brocaar/loraserver/internal/config"
 	joindown "github.com/brocaar/loraserver/internal/downlink/join"
 	"github.com/brocaar/loraserver/internal/framelog"
@@ -283,7 +284,7 @@ func getRejoinAcceptFromJS(ctx *context) error {
 	// 2: Used to rekey a device or change its DevAddr (DevAddr, session keys,
 	//    frame counters). Radio parameters are kept unchanged.
 	if ctx.RejoinType == lorawan.RejoinRequestType0 || ctx.RejoinType == lorawan.RejoinRequestType1 {
-		cFList := band.Band().GetCFList(ctx.DeviceSession.MACVersion)
+		cFList := channels.GetCFList(ctx.ServiceProfile, ctx.DeviceSession.MACVersion)
 		if cFList != nil {
 			cFListB, err := cFList.MarshalBinary()
 			if err != nil {
@@ -335,6 +336,13 @@ func setRejoin0PendingDeviceSession(ctx *context) error {
 		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
 		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
 		NbTrans:               1,
+		RXWindowPreference:    ctx.DeviceProfile.RXWindowPreference,
+		MaxFCntGap:            uint32(ctx.DeviceProfile.MaxFCntGap),
+
+		// until the device acknowledged the TxParamSetupReq mac-command,
+		// it operates using the default dwell-time of the band
+		UplinkDwellTime400ms:   band.DwellTime400msByDefault(),
+		DownlinkDwellTime400ms: band.DwellTime400msByDefault(),
 	}
 
 	if ctx.RejoinAnsPayload.AppSKey != nil {
@@ -382,6 +390,16 @@ func setRejoin0PendingDeviceSession(ctx *context) error {
 		pendingDS.NwkSEncKey = key
 	}
 
+	// the device applies the channel-mask of the CFList (LoRaWAN 1.0.3+)
+	if cfList := channels.GetCFList(ctx.ServiceProfile, ctx.DeviceSession.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannelMask {
+		chMaskPL, ok := cfList.Payload.(*lorawan.CFListChannelMaskPayload)
+		if !ok {
+			return fmt.Errorf("expected *lorawan.CFListChannelMaskPayload, got %T", cfList.Payload)
+		}
+
+		pendingDS.EnabledUplinkChannels = channels.GetCFListEnabledUplinkChannelIndices(*chMaskPL)
+	}
+
 	if cfList := band.Band().GetCFList(ctx.DeviceSession.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
 		channelPL, ok := cfList.Payload.(*lorawan.CFListChannelPayload)
 		if !ok {
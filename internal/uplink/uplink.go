@@ -11,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/airtime"
 	gwbackend "github.com/brocaar/loraserver/internal/backend/gateway"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/downlink/ack"
@@ -43,6 +44,10 @@ func Setup(conf config.Config) error {
 		return errors.Wrap(err, "configure uplink/rejoin error")
 	}
 
+	if err := proprietary.Setup(conf); err != nil {
+		return errors.Wrap(err, "configure uplink/proprietary error")
+	}
+
 	deduplicationDelay = conf.NetworkServer.DeduplicationDelay
 
 	return nil
@@ -60,37 +65,53 @@ func NewServer() *Server {
 
 // Start starts the server.
 func (s *Server) Start() error {
+	s.wg.Add(2)
+
 	go func() {
-		s.wg.Add(1)
 		defer s.wg.Done()
 		HandleRXPackets(&s.wg)
 	}()
 
 	go func() {
-		s.wg.Add(1)
 		defer s.wg.Done()
 		HandleDownlinkTXAcks(&s.wg)
 	}()
 	return nil
 }
 
-// Stop closes the gateway backend and waits for the server to complete the
-// pending packets.
-func (s *Server) Stop() error {
+// Stop closes the gateway backend (stops consuming new gateway messages),
+// stops the dead-letter queue worker and waits for the server to complete
+// the pending packets and uplink data forwards. It returns an error when
+// these did not complete within the given timeout.
+func (s *Server) Stop(timeout time.Duration) error {
 	if err := gwbackend.Backend().Close(); err != nil {
 		return fmt.Errorf("close gateway backend error: %s", err)
 	}
 	log.Info("waiting for pending actions to complete")
-	s.wg.Wait()
-	return nil
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		data.WaitForPendingForwards()
+		close(done)
+	}()
+
+	data.StopDeadLetterWorker()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("pending actions did not complete within %s", timeout)
+	}
 }
 
 // HandleRXPackets consumes received packets by the gateway and handles them
 // in a separate go-routine. Errors are logged.
 func HandleRXPackets(wg *sync.WaitGroup) {
 	for uplinkFrame := range gwbackend.Backend().RXPacketChan() {
+		wg.Add(1)
 		go func(uplinkFrame gw.UplinkFrame) {
-			wg.Add(1)
 			defer wg.Done()
 			if err := HandleRXPacket(uplinkFrame); err != nil {
 				data := base64.StdEncoding.EncodeToString(uplinkFrame.PhyPayload)
@@ -102,6 +123,7 @@ func HandleRXPackets(wg *sync.WaitGroup) {
 
 // HandleRXPacket handles a single rxpacket.
 func HandleRXPacket(uplinkFrame gw.UplinkFrame) error {
+	uplinkFrameCounter().Inc()
 	return collectPackets(uplinkFrame)
 }
 
@@ -109,8 +131,8 @@ func HandleRXPacket(uplinkFrame gw.UplinkFrame) error {
 // the gateway.
 func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
 	for downlinkTXAck := range gwbackend.Backend().DownlinkTXAckChan() {
+		wg.Add(1)
 		go func(downlinkTXAck gw.DownlinkTXAck) {
-			wg.Add(1)
 			defer wg.Done()
 			if err := ack.HandleDownlinkTXAck(downlinkTXAck); err != nil {
 				log.WithFields(log.Fields{
@@ -130,27 +152,70 @@ func collectPackets(uplinkFrame gw.UplinkFrame) error {
 			log.WithError(err).Error("update gateway meta-data in rx-info set error")
 		}
 
+		// apply the gateway rssi / snr calibration offsets
+		if err := gateway.ApplyRSSISNROffsets(storage.DB(), storage.RedisPool(), rxPacket.RXInfoSet); err != nil {
+			log.WithError(err).Error("apply gateway rssi / snr offsets in rx-info set error")
+		}
+
+		// in case of multi-antenna gateways, only the best antenna of each
+		// gateway is used for further processing (e.g. ADR and downlink)
+		if bestAntennaRXInfoSet := models.GetBestAntennaRXInfoSet(rxPacket.RXInfoSet); len(bestAntennaRXInfoSet) != len(rxPacket.RXInfoSet) {
+			rxPacket.AntennaRXInfoSet = rxPacket.RXInfoSet
+			rxPacket.RXInfoSet = bestAntennaRXInfoSet
+		}
+		uplinkDeduplicationGatewayCount().Observe(float64(len(rxPacket.RXInfoSet)))
+
+		// make sure gateways in maintenance mode are only used for downlink
+		// when no other gateway received the uplink
+		if err := gateway.MoveMaintenanceModeGatewaysLast(storage.DB(), storage.RedisPool(), rxPacket.RXInfoSet); err != nil {
+			log.WithError(err).Error("move maintenance-mode gateways last in rx-info set error")
+		}
+
 		// log the frame for each receiving gatewa
 		if err := framelog.LogUplinkFrameForGateways(storage.RedisPool(), gw.UplinkFrameSet{
 			PhyPayload: uplinkFrame.PhyPayload,
 			TxInfo:     rxPacket.TXInfo,
-			RxInfo:     rxPacket.RXInfoSet,
+			RxInfo:     framelog.RawRXInfoSet(rxPacket.GetAntennaRXInfoSet()),
 		}); err != nil {
 			log.WithError(err).Error("log uplink frames for gateways error")
 		}
 
-		// handle the frame based on message-type
-		switch rxPacket.PHYPayload.MHDR.MType {
-		case lorawan.JoinRequest:
-			return join.Handle(rxPacket)
-		case lorawan.RejoinRequest:
-			return rejoin.Handle(rxPacket)
-		case lorawan.UnconfirmedDataUp, lorawan.ConfirmedDataUp:
-			return data.Handle(rxPacket)
-		case lorawan.Proprietary:
-			return proprietary.Handle(rxPacket)
-		default:
-			return nil
+		// count the received packets per frequency and spreading-factor
+		if err := gateway.SaveRXPacketMetrics(storage.RedisPool(), rxPacket.TXInfo, rxPacket.RXInfoSet); err != nil {
+			log.WithError(err).Error("save gateway rx packet metrics error")
+		}
+
+		// account the airtime of the uplink
+		if d, err := airtime.GetUplinkAirtime(len(uplinkFrame.PhyPayload), rxPacket.TXInfo); err != nil {
+			log.WithError(err).Error("get uplink airtime error")
+		} else if err := gateway.SaveRXAirtimeMetrics(storage.RedisPool(), d, rxPacket.RXInfoSet); err != nil {
+			log.WithError(err).Error("save gateway rx airtime metrics error")
+		}
+
+		mType := rxPacket.PHYPayload.MHDR.MType.String()
+		uplinkHandleCounter(mType).Inc()
+
+		if err := handleUplinkFrame(rxPacket); err != nil {
+			uplinkHandleErrorCounter(mType).Inc()
+			return err
 		}
+
+		return nil
 	})
 }
+
+// handleUplinkFrame handles the frame based on message-type.
+func handleUplinkFrame(rxPacket models.RXPacket) error {
+	switch rxPacket.PHYPayload.MHDR.MType {
+	case lorawan.JoinRequest:
+		return join.Handle(rxPacket)
+	case lorawan.RejoinRequest:
+		return rejoin.Handle(rxPacket)
+	case lorawan.UnconfirmedDataUp, lorawan.ConfirmedDataUp:
+		return data.Handle(rxPacket)
+	case lorawan.Proprietary:
+		return proprietary.Handle(rxPacket)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,10 @@
+-- +migrate Up
+alter table device_queue
+    add column retry_count integer not null default 0;
+
+alter table device_queue
+    alter column retry_count drop default;
+
+-- +migrate Down
+alter table device_queue
+    drop column retry_count;
@@ -0,0 +1,16 @@
+-- +migrate Up
+alter table gateway
+    add column online boolean not null default false,
+    add column online_changed_at timestamp with time zone;
+
+update gateway
+set
+    online = true,
+    online_changed_at = now()
+where
+    last_seen_at > now() - interval '90 seconds';
+
+-- +migrate Down
+alter table gateway
+    drop column online_changed_at,
+    drop column online;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table gateway
+    add column rssi_offset double precision not null default 0,
+    add column snr_offset double precision not null default 0;
+
+-- +migrate Down
+alter table gateway
+    drop column snr_offset,
+    drop column rssi_offset;
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- existing gateways keep updating their location from the stats
+alter table gateway
+    add column update_location_from_stats boolean not null default true,
+    add column location_from_gps boolean not null default false,
+    add column location_updated_at timestamp with time zone;
+
+-- +migrate Down
+alter table gateway
+    drop column location_updated_at,
+    drop column location_from_gps,
+    drop column update_location_from_stats;
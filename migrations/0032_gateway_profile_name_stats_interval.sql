@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table gateway_profile
+    add column name varchar(100) not null default '',
+    add column stats_interval bigint not null default 0;
+
+-- +migrate Down
+alter table gateway_profile
+    drop column stats_interval,
+    drop column name;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway
+    add column config_version varchar(100) not null default '';
+
+-- +migrate Down
+alter table gateway
+    drop column config_version;
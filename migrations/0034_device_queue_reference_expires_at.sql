@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_queue
+    add column reference varchar(100) not null default '',
+    add column expires_at timestamp with time zone null;
+
+-- +migrate Down
+alter table device_queue
+    drop column expires_at,
+    drop column reference;
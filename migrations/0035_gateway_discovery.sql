@@ -0,0 +1,23 @@
+-- +migrate Up
+alter table gateway
+    add column discovery_enabled boolean not null default false;
+
+create table gateway_discovery_ping_rx (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    tx_gateway_id bytea not null references gateway on delete cascade,
+    rx_gateway_id bytea not null references gateway on delete cascade,
+    rssi integer not null,
+    lora_snr double precision not null
+);
+
+create index idx_gateway_discovery_ping_rx_tx_gateway_id_created_at on gateway_discovery_ping_rx(tx_gateway_id, created_at);
+create index idx_gateway_discovery_ping_rx_created_at on gateway_discovery_ping_rx(created_at);
+
+-- +migrate Down
+drop index idx_gateway_discovery_ping_rx_created_at;
+drop index idx_gateway_discovery_ping_rx_tx_gateway_id_created_at;
+drop table gateway_discovery_ping_rx;
+
+alter table gateway
+    drop column discovery_enabled;
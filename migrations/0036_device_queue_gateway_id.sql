@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_queue
+    add column gateway_id bytea references gateway on delete set null;
+
+-- +migrate Down
+alter table device_queue
+    drop column gateway_id;
@@ -0,0 +1,10 @@
+-- +migrate Up
+alter table device_profile
+    add column rx_window_preference smallint not null default 0;
+
+alter table device_profile
+    alter column rx_window_preference drop default;
+
+-- +migrate Down
+alter table device_profile
+    drop column rx_window_preference;
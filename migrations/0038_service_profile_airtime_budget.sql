@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table service_profile
+    add column ul_airtime_budget integer not null default 0,
+    add column dl_airtime_budget integer not null default 0;
+
+alter table service_profile
+    alter column ul_airtime_budget drop default,
+    alter column dl_airtime_budget drop default;
+
+-- +migrate Down
+alter table service_profile
+    drop column dl_airtime_budget,
+    drop column ul_airtime_budget;
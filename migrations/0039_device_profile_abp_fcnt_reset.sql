@@ -0,0 +1,10 @@
+-- +migrate Up
+alter table device_profile
+    add column abp_fcnt_reset boolean not null default false;
+
+alter table device_profile
+    alter column abp_fcnt_reset drop default;
+
+-- +migrate Down
+alter table device_profile
+    drop column abp_fcnt_reset;
@@ -0,0 +1,10 @@
+-- +migrate Up
+alter table device_profile
+    add column max_fcnt_gap integer not null default 0;
+
+alter table device_profile
+    alter column max_fcnt_gap drop default;
+
+-- +migrate Down
+alter table device_profile
+    drop column max_fcnt_gap;
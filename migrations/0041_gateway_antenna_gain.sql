@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway
+    add column antenna_gain double precision not null default 0;
+
+-- +migrate Down
+alter table gateway
+    drop column antenna_gain;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway
+    add column max_tx_power integer not null default 0;
+
+-- +migrate Down
+alter table gateway
+    drop column max_tx_power;
@@ -0,0 +1,5 @@
+-- +migrate Up
+create index idx_device_queue_expires_at on device_queue(expires_at) where expires_at is not null;
+
+-- +migrate Down
+drop index idx_device_queue_expires_at;